@@ -40,12 +40,15 @@ func Post(ctx context.Context, db graph.Database, adcsEnabled bool, citrixEnable
 		return &aggregateStats, err
 	} else if adcsStats, err := adAnalysis.PostADCS(ctx, db, groupExpansions, adcsEnabled); err != nil {
 		return &aggregateStats, err
+	} else if entraConnectStats, err := adAnalysis.PostEntraConnect(ctx, db); err != nil {
+		return &aggregateStats, err
 	} else {
 		aggregateStats.Merge(stats)
 		aggregateStats.Merge(syncLAPSStats)
 		aggregateStats.Merge(dcSyncStats)
 		aggregateStats.Merge(localGroupStats)
 		aggregateStats.Merge(adcsStats)
+		aggregateStats.Merge(entraConnectStats)
 		return &aggregateStats, nil
 	}
 }
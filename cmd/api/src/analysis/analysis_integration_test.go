@@ -194,3 +194,29 @@ func TestFetchRDPEntityBitmapForComputer(t *testing.T) {
 		}))
 	})
 }
+
+func TestFetchAdminToEntityBitmapForComputer(t *testing.T) {
+	testContext := integration.NewGraphTestContext(t, schema.DefaultGraphSchema())
+	testContext.DatabaseTestWithSetup(func(harness *integration.HarnessDetails) error {
+		harness.URAPrivilegeHarness.Setup(testContext)
+		return nil
+	}, func(harness integration.HarnessDetails, db graph.Database) {
+		require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+			adminEntityIDBitmap, err := analysis.FetchAdminToEntityBitmapForComputer(tx, harness.URAPrivilegeHarness.Computer.ID, analysis.AdminGroupSuffix)
+			require.Nil(t, err)
+
+			// Members of the administrators group and first degree holders of admin equivalent privileges
+			require.Equal(t, 3, int(adminEntityIDBitmap.Cardinality()))
+
+			require.True(t, adminEntityIDBitmap.Contains(harness.URAPrivilegeHarness.AdminUser.ID.Uint64()))
+			require.True(t, adminEntityIDBitmap.Contains(harness.URAPrivilegeHarness.BackupOperatorsGroup.ID.Uint64()))
+			require.True(t, adminEntityIDBitmap.Contains(harness.URAPrivilegeHarness.DebugUser.ID.Uint64()))
+
+			require.False(t, adminEntityIDBitmap.Contains(harness.URAPrivilegeHarness.BackupOperatorsLocalGroup.ID.Uint64()))
+			require.False(t, adminEntityIDBitmap.Contains(harness.URAPrivilegeHarness.BackupUser.ID.Uint64()))
+			require.False(t, adminEntityIDBitmap.Contains(harness.URAPrivilegeHarness.ImpersonateUser.ID.Uint64()))
+
+			return nil
+		}))
+	})
+}
@@ -541,13 +541,13 @@ func TestGroupEntityDetails(t *testing.T) {
 		require.Nil(t, err)
 		assert.NotEqual(t, "", groupObjectID)
 
-		group, err := azureanalysis.GroupEntityDetails(testContext.Graph.Database, groupObjectID, false)
+		group, err := azureanalysis.GroupEntityDetails(context.Background(), testContext.Graph.Database, groupObjectID, false)
 
 		require.Nil(t, err)
 		assert.Equal(t, harness.AZEntityPanelHarness.Group.Properties.Get(common.ObjectID.String()).Any(), group.Properties[common.ObjectID.String()])
 		assert.Equal(t, 0, group.InboundObjectControl)
 
-		group, err = azureanalysis.GroupEntityDetails(testContext.Graph.Database, groupObjectID, true)
+		group, err = azureanalysis.GroupEntityDetails(context.Background(), testContext.Graph.Database, groupObjectID, true)
 
 		require.Nil(t, err)
 		assert.NotEqual(t, 0, group.InboundObjectControl)
@@ -677,6 +677,86 @@ func TestRoleAddSecret(t *testing.T) {
 	})
 }
 
+func TestAdministrativeUnitRoleAssignments(t *testing.T) {
+	testContext := integration.NewGraphTestContext(t, schema.DefaultGraphSchema())
+	testContext.ReadTransactionTestWithSetup(func(harness *integration.HarnessDetails) error {
+		harness.AZAdministrativeUnitHarness.Setup(testContext)
+		return nil
+	}, func(harness integration.HarnessDetails, tx graph.Transaction) {
+		_, err := azureanalysis.UserRoleAssignments(context.Background(), testContext.Graph.Database)
+		require.Nil(t, err)
+
+		edgeExists := func(start, end *graph.Node, kind graph.Kind) bool {
+			count, err := tx.Relationships().Filterf(func() graph.Criteria {
+				return query.And(
+					query.Equals(query.StartID(), start.ID),
+					query.Equals(query.EndID(), end.ID),
+					query.Kind(query.Relationship(), kind),
+				)
+			}).Count()
+			require.Nil(t, err)
+			return count > 0
+		}
+
+		unitHarness := harness.AZAdministrativeUnitHarness
+
+		// Scoped assignments only grant control over members of the administrative unit
+		assert.True(t, edgeExists(unitHarness.ScopedHelpdeskAdmin, unitHarness.UnitUser, azure.ResetPassword))
+		assert.False(t, edgeExists(unitHarness.ScopedHelpdeskAdmin, unitHarness.UserOutsideUnits, azure.ResetPassword))
+		assert.False(t, edgeExists(unitHarness.ScopedHelpdeskAdmin, unitHarness.RestrictedUnitUser, azure.ResetPassword))
+		assert.True(t, edgeExists(unitHarness.ScopedGroupsAdmin, unitHarness.UnitGroup, azure.AddMembers))
+
+		// Tenant wide assignments do not grant control over members of restricted management administrative units
+		assert.True(t, edgeExists(unitHarness.HelpdeskAdminRole, unitHarness.UnitUser, azure.ResetPassword))
+		assert.True(t, edgeExists(unitHarness.HelpdeskAdminRole, unitHarness.UserOutsideUnits, azure.ResetPassword))
+		assert.False(t, edgeExists(unitHarness.HelpdeskAdminRole, unitHarness.RestrictedUnitUser, azure.ResetPassword))
+	})
+}
+
+func TestListStorageKeys(t *testing.T) {
+	testContext := integration.NewGraphTestContext(t, schema.DefaultGraphSchema())
+	testContext.ReadTransactionTestWithSetup(func(harness *integration.HarnessDetails) error {
+		harness.AZStorageAccountHarness.Setup(testContext)
+		return nil
+	}, func(harness integration.HarnessDetails, tx graph.Transaction) {
+		_, err := azureanalysis.ListStorageKeys(context.Background(), testContext.Graph.Database)
+		require.Nil(t, err)
+
+		keyReaders, err := ops.FetchStartNodes(tx.Relationships().Filterf(func() graph.Criteria {
+			return query.Kind(query.Relationship(), azure.ListStorageKeys)
+		}))
+		require.Nil(t, err)
+
+		storageHarness := harness.AZStorageAccountHarness
+
+		// Only key listing roles on accounts that accept shared key authorization create ListStorageKeys edges
+		assert.Equal(t, 2, keyReaders.Len())
+		assert.True(t, keyReaders.Contains(storageHarness.StorageAccountContributor))
+		assert.True(t, keyReaders.Contains(storageHarness.StorageAccountKeyOperator))
+	})
+}
+
+func TestGuestAccounts(t *testing.T) {
+	testContext := integration.NewGraphTestContext(t, schema.DefaultGraphSchema())
+	testContext.ReadTransactionTestWithSetup(func(harness *integration.HarnessDetails) error {
+		harness.AZGuestHarness.Setup(testContext)
+		return nil
+	}, func(harness integration.HarnessDetails, tx graph.Transaction) {
+		_, err := azureanalysis.GuestAccounts(context.Background(), testContext.Graph.Database)
+		require.Nil(t, err)
+
+		guestAccounts, err := ops.FetchRelationships(tx.Relationships().Filterf(func() graph.Criteria {
+			return query.Kind(query.Relationship(), azure.HasGuestAccount)
+		}))
+		require.Nil(t, err)
+
+		// Only guests whose home tenant identity was collected are linked
+		require.Len(t, guestAccounts, 1)
+		assert.Equal(t, harness.AZGuestHarness.HomeUser.ID, guestAccounts[0].StartID)
+		assert.Equal(t, harness.AZGuestHarness.GuestUser.ID, guestAccounts[0].EndID)
+	})
+}
+
 func TestServicePrincipalEntityDetails(t *testing.T) {
 	testContext := integration.NewGraphTestContext(t, schema.DefaultGraphSchema())
 	testContext.ReadTransactionTestWithSetup(func(harness *integration.HarnessDetails) error {
@@ -736,13 +816,13 @@ func TestTenantEntityDetails(t *testing.T) {
 		require.Nil(t, err)
 		assert.NotEqual(t, "", tenantObjectID)
 
-		tenant, err := azureanalysis.TenantEntityDetails(testContext.Graph.Database, tenantObjectID, false)
+		tenant, err := azureanalysis.TenantEntityDetails(context.Background(), testContext.Graph.Database, tenantObjectID, false)
 
 		require.Nil(t, err)
 		assert.Equal(t, harness.AZEntityPanelHarness.Tenant.Properties.Get(common.ObjectID.String()).Any(), tenant.Properties[common.ObjectID.String()])
 		assert.Equal(t, 0, tenant.InboundObjectControl)
 
-		tenant, err = azureanalysis.TenantEntityDetails(testContext.Graph.Database, tenantObjectID, true)
+		tenant, err = azureanalysis.TenantEntityDetails(context.Background(), testContext.Graph.Database, tenantObjectID, true)
 
 		require.Nil(t, err)
 		assert.NotEqual(t, 0, tenant.InboundObjectControl)
@@ -760,13 +840,13 @@ func TestUserEntityDetails(t *testing.T) {
 		require.Nil(t, err)
 		assert.NotEqual(t, "", userObjectID)
 
-		user, err := azureanalysis.UserEntityDetails(testContext.Graph.Database, userObjectID, false)
+		user, err := azureanalysis.UserEntityDetails(context.Background(), testContext.Graph.Database, userObjectID, false)
 
 		require.Nil(t, err)
 		assert.Equal(t, harness.AZEntityPanelHarness.User.Properties.Get(common.ObjectID.String()).Any(), user.Properties[common.ObjectID.String()])
 		assert.Equal(t, 0, user.OutboundObjectControl)
 
-		user, err = azureanalysis.UserEntityDetails(testContext.Graph.Database, userObjectID, true)
+		user, err = azureanalysis.UserEntityDetails(context.Background(), testContext.Graph.Database, userObjectID, true)
 
 		require.Nil(t, err)
 		assert.NotEqual(t, 0, user.OutboundObjectControl)
@@ -29,10 +29,10 @@ func NewBaseEntityDetails(node *graph.Node) azure.BaseDetails {
 	}
 }
 
-func BaseEntityDetails(db graph.Database, objectID string, hydrateCounts bool) (azure.BaseDetails, error) {
+func BaseEntityDetails(ctx context.Context, db graph.Database, objectID string, hydrateCounts bool) (azure.BaseDetails, error) {
 	var details azure.BaseDetails
 
-	return details, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+	return details, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
 		if node, err := azure.FetchEntityByObjectID(tx, objectID); err != nil {
 			return err
 		} else {
@@ -35,16 +35,28 @@ func Post(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessin
 		return &aggregateStats, err
 	} else if executeCommandStats, err := azureAnalysis.ExecuteCommand(ctx, db); err != nil {
 		return &aggregateStats, err
+	} else if rbacRoleAssignmentStats, err := azureAnalysis.CustomRBACRoleAssignments(ctx, db); err != nil {
+		return &aggregateStats, err
+	} else if listStorageKeysStats, err := azureAnalysis.ListStorageKeys(ctx, db); err != nil {
+		return &aggregateStats, err
+	} else if guestAccountStats, err := azureAnalysis.GuestAccounts(ctx, db); err != nil {
+		return &aggregateStats, err
 	} else if appRoleAssignmentStats, err := azureAnalysis.AppRoleAssignments(ctx, db); err != nil {
 		return &aggregateStats, err
 	} else if hybridStats, err := hybrid.PostHybrid(ctx, db); err != nil {
 		return &aggregateStats, err
+	} else if entraConnectStats, err := hybrid.PostEntraConnect(ctx, db); err != nil {
+		return &aggregateStats, err
 	} else {
 		aggregateStats.Merge(stats)
 		aggregateStats.Merge(userRoleStats)
 		aggregateStats.Merge(executeCommandStats)
+		aggregateStats.Merge(rbacRoleAssignmentStats)
+		aggregateStats.Merge(listStorageKeysStats)
+		aggregateStats.Merge(guestAccountStats)
 		aggregateStats.Merge(appRoleAssignmentStats)
 		aggregateStats.Merge(hybridStats)
+		aggregateStats.Merge(entraConnectStats)
 		return &aggregateStats, nil
 	}
 }
@@ -26,6 +26,7 @@ import (
 	schema "github.com/specterops/bloodhound/graphschema"
 
 	"github.com/specterops/bloodhound/analysis"
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
 	"github.com/specterops/bloodhound/analysis/hybrid"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/ops"
@@ -35,8 +36,67 @@ import (
 	"github.com/specterops/bloodhound/graphschema/common"
 	"github.com/specterops/bloodhound/src/test/integration"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestEntraConnect(t *testing.T) {
+	testContext := integration.NewGraphTestContext(t, schema.DefaultGraphSchema())
+
+	testContext.DatabaseTestWithSetup(
+		func(harness *integration.HarnessDetails) error {
+			harness.EntraConnectHarness.Setup(testContext)
+			return nil
+		},
+		func(harness integration.HarnessDetails, db graph.Database) {
+			_, err := adAnalysis.PostEntraConnect(context.Background(), db)
+			require.Nil(t, err)
+
+			_, err = hybrid.PostEntraConnect(context.Background(), db)
+			require.Nil(t, err)
+
+			entraConnectHarness := harness.EntraConnectHarness
+
+			db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+				credentialTargets, err := ops.FetchEndNodes(tx.Relationships().Filterf(func() graph.Criteria {
+					return query.And(
+						query.Equals(query.StartID(), entraConnectHarness.EntraConnect.ID),
+						query.Kind(query.Relationship(), ad.DumpEntraConnectCredentials),
+					)
+				}))
+				require.Nil(t, err)
+
+				// The Entra Connect server holds the credentials of both the AD DS connector account and the Entra sync account
+				assert.Equal(t, 2, credentialTargets.Len())
+				assert.True(t, credentialTargets.Contains(entraConnectHarness.ConnectorAccount))
+				assert.True(t, credentialTargets.Contains(entraConnectHarness.EntraSyncAccount))
+
+				passwordResetTargets, err := ops.FetchEndNodes(tx.Relationships().Filterf(func() graph.Criteria {
+					return query.And(
+						query.Equals(query.StartID(), entraConnectHarness.EntraSyncAccount.ID),
+						query.Kind(query.Relationship(), azure.ResetPassword),
+					)
+				}))
+				require.Nil(t, err)
+
+				// The Entra sync account can only set the passwords of synced users
+				assert.Equal(t, 1, passwordResetTargets.Len())
+				assert.True(t, passwordResetTargets.Contains(entraConnectHarness.SyncedEntraUser))
+
+				otherComputerEdges, err := ops.FetchRelationships(tx.Relationships().Filterf(func() graph.Criteria {
+					return query.And(
+						query.Equals(query.StartID(), entraConnectHarness.OtherComputer.ID),
+						query.Kind(query.Relationship(), ad.DumpEntraConnectCredentials),
+					)
+				}))
+				require.Nil(t, err)
+				assert.Empty(t, otherComputerEdges)
+
+				return nil
+			})
+		},
+	)
+}
+
 func TestHybridAttackPaths(t *testing.T) {
 	testContext := integration.NewGraphTestContext(t, schema.DefaultGraphSchema())
 
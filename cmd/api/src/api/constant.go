@@ -34,13 +34,18 @@ const (
 	AuthorizationSchemeBearer       = "bearer"
 
 	// Query parameters
-	QueryParameterSortBy         = "sort_by"
-	QueryParameterHydrateCounts  = "counts"
-	QueryParameterHydrateDomains = "hydrate_domains"
-	QueryParameterHydrateOUs     = "hydrate_ous"
-	QueryParameterScope          = "scope"
-	QueryParameterState          = "state"
-	QueryParameterCode           = "code"
+	QueryParameterSortBy           = "sort_by"
+	QueryParameterHydrateCounts    = "counts"
+	QueryParameterHydrateDomains   = "hydrate_domains"
+	QueryParameterHydrateOUs       = "hydrate_ous"
+	QueryParameterScope            = "scope"
+	QueryParameterState            = "state"
+	QueryParameterCode             = "code"
+	QueryParameterDryRun           = "dry_run"
+	QueryParameterStaleAfterDays   = "stale_after_days"
+	QueryParameterFromCollectionID = "from_collection_id"
+	QueryParameterToCollectionID   = "to_collection_id"
+	QueryParameterDaysAgo          = "days_ago"
 
 	// URI path parameters
 	URIPathVariableApplicationConfigurationParameter = "parameter"
@@ -65,4 +70,5 @@ const (
 	URIPathVariableSavedQueryID                      = "saved_query_id"
 	URIPathVariableSSOProviderID                     = "sso_provider_id"
 	URIPathVariableSSOProviderSlug                   = "sso_provider_slug"
+	URIPathVariableWorkspaceID                       = "workspace_id"
 )
@@ -70,6 +70,8 @@ const (
 	ErrorResponseUserDuplicatePrincipal             = "principal name must be unique"
 	ErrorResponseDetailsUniqueViolation             = "unique constraint was violated"
 	ErrorResponseDetailsNotImplemented              = "All good things to those who wait. Not implemented."
+	ErrorResponseDetailsWorkspacesUnsupported       = "workspaces require the PostgreSQL graph driver"
+	ErrorResponseDetailsDefaultGraphOnly            = "this resource is only available for the default graph and does not support the Workspace header"
 
 	FmtErrorResponseDetailsBadQueryParameters = "there are errors in the query parameters: %v"
 )
@@ -217,8 +217,9 @@ func parsePreferHeaderWait(value string) (time.Duration, error) {
 func CORSMiddleware() mux.MiddlewareFunc {
 	return handlers.CORS(
 		handlers.AllowCredentials(),
-		handlers.AllowedMethods([]string{"HEAD", "GET", "POST", "DELETE", "PUT"}),
-		handlers.AllowedHeaders([]string{headers.ContentType.String(), headers.Authorization.String()}),
+		handlers.AllowedMethods([]string{"HEAD", "GET", "POST", "DELETE", "PUT", "PATCH"}),
+		handlers.AllowedHeaders([]string{headers.ContentType.String(), headers.Authorization.String(), headers.Workspace.String(), headers.UploadOffset.String(), headers.UploadLength.String()}),
+		handlers.ExposedHeaders([]string{headers.UploadOffset.String(), headers.UploadLength.String()}),
 		handlers.AllowedOrigins([]string{""}),
 	)
 }
@@ -0,0 +1,94 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+type WorkspaceGetter interface {
+	GetWorkspace(ctx context.Context, id int64) (model.Workspace, error)
+}
+
+// CanAccessWorkspace returns true if the actor of the given auth context is a member of the given workspace or is
+// allowed to manage users
+func CanAccessWorkspace(authorizer auth.Authorizer, authCtx auth.Context, workspace model.Workspace) bool {
+	if authorizer.AllowsPermission(authCtx, auth.Permissions().AuthManageUsers) {
+		return true
+	} else if user, isUser := auth.GetUserFromAuthCtx(authCtx); isUser {
+		return workspace.HasMember(user)
+	}
+
+	return false
+}
+
+// WorkspaceMiddleware is a middleware func generator that returns a http.Handler which selects the workspace named by
+// the Workspace header of a request. It must run after AuthMiddleware.
+//
+// Requests without a Workspace header operate on the default graph. Requests with a Workspace header must be made by a
+// member of the selected workspace or by an actor that may manage users. Every graph transaction opened with the
+// context of an accepted request is scoped to the graph of the selected workspace. Workspace graphs only exist for the
+// PostgreSQL graph driver; requests with a Workspace header are rejected while any other driver is active.
+func WorkspaceMiddleware(db WorkspaceGetter, graphDB graph.Database, authorizer auth.Authorizer) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if workspaceHeader := request.Header.Get(headers.Workspace.String()); workspaceHeader == "" {
+				next.ServeHTTP(response, request)
+			} else if !pg.IsPostgreSQLGraph(graphDB) {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotImplemented, api.ErrorResponseDetailsWorkspacesUnsupported, request), response)
+			} else if bhCtx := ctx.FromRequest(request); !bhCtx.AuthCtx.Authenticated() {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, "not authenticated", request), response)
+			} else if workspaceID, err := strconv.ParseInt(workspaceHeader, 10, 64); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Workspace header must be a workspace ID", request), response)
+			} else if workspace, err := db.GetWorkspace(request.Context(), workspaceID); err != nil {
+				api.HandleDatabaseError(request, response, err)
+			} else if !CanAccessWorkspace(authorizer, bhCtx.AuthCtx, workspace) {
+				authorizer.AuditLogUnauthorizedAccess(request)
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "not authorized for workspace", request), response)
+			} else {
+				bhCtx.Workspace = &workspace
+				next.ServeHTTP(response, request.WithContext(graph.WithGraphTarget(request.Context(), workspace.Graph())))
+			}
+		})
+	}
+}
+
+// DefaultGraphOnlyMiddleware is a middleware func generator that returns a http.Handler which rejects requests that
+// selected a workspace. It guards resources that are only kept for the default graph. It must run after
+// WorkspaceMiddleware.
+func DefaultGraphOnlyMiddleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if bhCtx := ctx.FromRequest(request); bhCtx.Workspace != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsDefaultGraphOnly, request), response)
+			} else {
+				next.ServeHTTP(response, request)
+			}
+		})
+	}
+}
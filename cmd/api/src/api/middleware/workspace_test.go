@@ -0,0 +1,200 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/database"
+	dbmocks "github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/utils/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWorkspaceMiddleware(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = dbmocks.NewMockDatabase(mockCtrl)
+		member   = model.User{
+			PrincipalName: "member",
+			Unique:        model.Unique{ID: uuid.FromStringOrNil("22222222-2222-2222-2222-222222222222")},
+		}
+		admin = model.User{
+			PrincipalName: "admin",
+			Roles:         model.Roles{{Permissions: auth.Permissions().All()}},
+			Unique:        model.Unique{ID: uuid.FromStringOrNil("33333333-3333-3333-3333-333333333333")},
+		}
+		outsider = model.User{
+			PrincipalName: "outsider",
+			Unique:        model.Unique{ID: uuid.FromStringOrNil("44444444-4444-4444-4444-444444444444")},
+		}
+		workspace = model.Workspace{
+			Name:      "client",
+			Members:   model.Users{member},
+			BigSerial: model.BigSerial{ID: 2},
+		}
+		pgGraph          = graph.NewDatabaseSwitch(context.Background(), &pg.Driver{})
+		workspaceHandler = WorkspaceMiddleware(mockDB, pgGraph, auth.NewAuthorizer(mockDB))(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			target, hasTarget := graph.GraphTargetFromContext(request.Context())
+
+			if bhCtx := ctx.FromRequest(request); bhCtx.Workspace == nil {
+				require.False(t, hasTarget)
+			} else {
+				require.True(t, hasTarget)
+				require.Equal(t, "workspace_2", target.Name)
+				require.Equal(t, workspace.ID, bhCtx.Workspace.ID)
+			}
+
+			response.WriteHeader(http.StatusOK)
+		}))
+		userCtx = func(user model.User) *ctx.Context {
+			return &ctx.Context{AuthCtx: auth.Context{Owner: user, PermissionOverrides: auth.PermissionOverrides{}}}
+		}
+	)
+
+	mockDB.EXPECT().GetWorkspace(gomock.Any(), int64(2)).Return(workspace, nil).AnyTimes()
+	mockDB.EXPECT().GetWorkspace(gomock.Any(), int64(3)).Return(model.Workspace{}, database.ErrNotFound).AnyTimes()
+	mockDB.EXPECT().AppendAuditLog(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	t.Run("Default Graph", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithContext(userCtx(outsider)).
+			OnHandler(workspaceHandler).
+			Require().
+			ResponseStatusCode(http.StatusOK)
+	})
+
+	t.Run("Not Authenticated", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithHeader(headers.Workspace.String(), "2").
+			WithContext(&ctx.Context{}).
+			OnHandler(workspaceHandler).
+			Require().
+			ResponseStatusCode(http.StatusUnauthorized)
+	})
+
+	t.Run("Malformed Workspace", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithHeader(headers.Workspace.String(), "client").
+			WithContext(userCtx(member)).
+			OnHandler(workspaceHandler).
+			Require().
+			ResponseStatusCode(http.StatusBadRequest)
+	})
+
+	t.Run("Workspace Not Found", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithHeader(headers.Workspace.String(), "3").
+			WithContext(userCtx(admin)).
+			OnHandler(workspaceHandler).
+			Require().
+			ResponseStatusCode(http.StatusNotFound)
+	})
+
+	t.Run("Member", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithHeader(headers.Workspace.String(), "2").
+			WithContext(userCtx(member)).
+			OnHandler(workspaceHandler).
+			Require().
+			ResponseStatusCode(http.StatusOK)
+	})
+
+	t.Run("User Administrator", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithHeader(headers.Workspace.String(), "2").
+			WithContext(userCtx(admin)).
+			OnHandler(workspaceHandler).
+			Require().
+			ResponseStatusCode(http.StatusOK)
+	})
+
+	t.Run("Outsider", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithHeader(headers.Workspace.String(), "2").
+			WithContext(userCtx(outsider)).
+			OnHandler(workspaceHandler).
+			Require().
+			ResponseStatusCode(http.StatusForbidden)
+	})
+
+	t.Run("Graph Driver Without Workspaces", func(t *testing.T) {
+		var (
+			memoryGraph = graph.NewDatabaseSwitch(context.Background(), memory.NewDatabase(0))
+			handler     = WorkspaceMiddleware(mockDB, memoryGraph, auth.NewAuthorizer(mockDB))(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				response.WriteHeader(http.StatusOK)
+			}))
+		)
+
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithHeader(headers.Workspace.String(), "2").
+			WithContext(userCtx(member)).
+			OnHandler(handler).
+			Require().
+			ResponseStatusCode(http.StatusNotImplemented)
+
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithContext(userCtx(member)).
+			OnHandler(handler).
+			Require().
+			ResponseStatusCode(http.StatusOK)
+	})
+}
+
+func TestDefaultGraphOnlyMiddleware(t *testing.T) {
+	handler := DefaultGraphOnlyMiddleware()(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		response.WriteHeader(http.StatusOK)
+	}))
+
+	t.Run("Default Graph", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithContext(&ctx.Context{}).
+			OnHandler(handler).
+			Require().
+			ResponseStatusCode(http.StatusOK)
+	})
+
+	t.Run("Workspace", func(t *testing.T) {
+		test.Request(t).
+			WithURL("http://example.com/test").
+			WithContext(&ctx.Context{Workspace: &model.Workspace{BigSerial: model.BigSerial{ID: 2}}}).
+			OnHandler(handler).
+			Require().
+			ResponseStatusCode(http.StatusBadRequest)
+	})
+}
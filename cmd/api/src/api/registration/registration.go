@@ -75,6 +75,9 @@ func RegisterFossRoutes(
 		routerInst.PathPrefix("/ui", static.AssetHandler),
 	)
 
+	// Workspace selection must follow authentication so that workspace access can be checked
+	routerInst.UsePostrouting(middleware.WorkspaceMiddleware(rdms, graphDB, authorizer))
+
 	var resources = v2.NewResources(rdms, graphDB, cfg, apiCache, graphQuery, collectorManifests, authorizer, authenticator)
 	NewV2API(resources, routerInst)
 }
@@ -95,6 +95,12 @@ func (s *Route) CheckFeatureFlag(db database.Database, flagKey string) *Route {
 	return s
 }
 
+// Ensure that the request operates on the default graph. This guards resources that are not kept for workspaces.
+func (s *Route) RequireDefaultGraph() *Route {
+	s.handler.Use(middleware.DefaultGraphOnlyMiddleware())
+	return s
+}
+
 func NewRouter(cfg config.Configuration, authorizer auth.Authorizer, contentSecurityPolicy string) Router {
 	muxRouter := mux.NewRouter()
 	muxRouter.Use(middleware.SecureHandlerMiddleware(cfg, contentSecurityPolicy))
@@ -165,3 +171,7 @@ func (s Router) DELETE(template string, handlerFunc func(http.ResponseWriter, *h
 func (s Router) PATCH(template string, handlerFunc func(http.ResponseWriter, *http.Request)) *Route {
 	return s.HandleFunc(template, handlerFunc).Methods(http.MethodPatch)
 }
+
+func (s Router) HEAD(template string, handlerFunc func(http.ResponseWriter, *http.Request)) *Route {
+	return s.HandleFunc(template, handlerFunc).Methods(http.MethodHead)
+}
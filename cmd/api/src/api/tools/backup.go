@@ -0,0 +1,866 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/config"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/version"
+)
+
+const (
+	// BackupFormatVersion is incremented whenever the layout of a backup archive changes in a way that older
+	// versions of BloodHound can not restore
+	BackupFormatVersion = 2
+
+	backupManifestName        = "manifest.json"
+	backupNodesName           = "graph/nodes.jsonl"
+	backupRelationshipsName   = "graph/relationships.jsonl"
+	backupWorkspacesDirectory = "graph/workspaces"
+	backupTablesDirectory     = "rdbms"
+)
+
+var (
+	ErrBackupIncompatible = errors.New("backup is not compatible with this instance")
+	ErrBackupMalformed    = errors.New("backup archive is malformed")
+	ErrInstanceNotEmpty   = errors.New("backups may only be restored into an instance with an empty graph")
+)
+
+// BackupManifest is the first entry of every backup archive and describes its content. The node and relationship
+// counts are those of the default graph. The graph of every workspace is listed separately.
+type BackupManifest struct {
+	FormatVersion      int                    `json:"format_version"`
+	CreatedAt          time.Time              `json:"created_at"`
+	ApplicationVersion version.Version        `json:"application_version"`
+	SchemaVersion      version.Version        `json:"schema_version"`
+	Tables             []string               `json:"tables"`
+	Nodes              int64                  `json:"nodes"`
+	Relationships      int64                  `json:"relationships"`
+	Workspaces         []BackupWorkspaceGraph `json:"workspaces,omitempty"`
+}
+
+// BackupWorkspaceGraph describes the graph of a workspace that is part of a backup archive
+type BackupWorkspaceGraph struct {
+	ID            int64 `json:"id"`
+	Nodes         int64 `json:"nodes"`
+	Relationships int64 `json:"relationships"`
+}
+
+// workspaceBackupEntry returns the name of the given archive entry of the graph of a workspace
+func workspaceBackupEntry(workspaceID int64, name string) string {
+	return path.Join(backupWorkspacesDirectory, strconv.FormatInt(workspaceID, 10), name)
+}
+
+// backupProperties stores graph properties in a driver-agnostic form. Time values are encoded as RFC3339 strings and
+// their keys are recorded so that they can be restored as times rather than strings.
+type backupProperties struct {
+	Properties     map[string]any `json:"properties"`
+	TimeProperties []string       `json:"time_properties,omitempty"`
+}
+
+type backupNode struct {
+	ID    graph.ID `json:"id"`
+	Kinds []string `json:"kinds"`
+	backupProperties
+}
+
+type backupRelationship struct {
+	ID      graph.ID `json:"id"`
+	StartID graph.ID `json:"start_id"`
+	EndID   graph.ID `json:"end_id"`
+	Kind    string   `json:"kind"`
+	backupProperties
+}
+
+func newBackupProperties(properties *graph.Properties) (backupProperties, error) {
+	if err := convertNeo4jProperties(properties); err != nil {
+		return backupProperties{}, err
+	}
+
+	encoded := backupProperties{
+		Properties: properties.MapOrEmpty(),
+	}
+
+	for key, value := range encoded.Properties {
+		if _, isTime := value.(time.Time); isTime {
+			encoded.TimeProperties = append(encoded.TimeProperties, key)
+		}
+	}
+
+	return encoded, nil
+}
+
+// decodeBackupValue converts JSON numbers into integers where possible so that integer properties are not restored as
+// floating point values
+func decodeBackupValue(value any) any {
+	switch typedValue := value.(type) {
+	case json.Number:
+		if intValue, err := typedValue.Int64(); err == nil {
+			return intValue
+		} else if floatValue, err := typedValue.Float64(); err == nil {
+			return floatValue
+		}
+
+		return typedValue.String()
+
+	case []any:
+		for idx, element := range typedValue {
+			typedValue[idx] = decodeBackupValue(element)
+		}
+
+		return typedValue
+
+	default:
+		return value
+	}
+}
+
+func (s backupProperties) decode() (*graph.Properties, error) {
+	properties := graph.NewProperties()
+
+	for key, value := range s.Properties {
+		properties.Set(key, decodeBackupValue(value))
+	}
+
+	for _, key := range s.TimeProperties {
+		if rawValue, err := properties.Get(key).String(); err != nil {
+			return nil, fmt.Errorf("time property %s is not a string: %w", key, err)
+		} else if timeValue, err := time.Parse(time.RFC3339Nano, rawValue); err != nil {
+			return nil, fmt.Errorf("time property %s is malformed: %w", key, err)
+		} else {
+			properties.Set(key, timeValue)
+		}
+	}
+
+	return properties, nil
+}
+
+// exportGraph writes every node and relationship of the graph as JSON lines to the given files, ordered by ID
+func exportGraph(ctx context.Context, graphDB graph.Database, nodesFile, relationshipsFile io.Writer) (int64, int64, error) {
+	var (
+		numNodes         int64
+		numRelationships int64
+	)
+
+	err := graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		encoder := json.NewEncoder(nodesFile)
+
+		if err := tx.Nodes().Filter(
+			query.Not(query.KindIn(query.Node(), excludedMigrationKinds()...)),
+		).OrderBy(
+			query.Order(query.NodeID(), query.Ascending()),
+		).Fetch(func(cursor graph.Cursor[*graph.Node]) error {
+			for node := range cursor.Chan() {
+				if properties, err := newBackupProperties(node.Properties); err != nil {
+					return fmt.Errorf("failed encoding properties of node %d: %w", node.ID, err)
+				} else if err := encoder.Encode(backupNode{
+					ID:               node.ID,
+					Kinds:            node.Kinds.Strings(),
+					backupProperties: properties,
+				}); err != nil {
+					return err
+				}
+
+				numNodes++
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return err
+		}
+
+		encoder = json.NewEncoder(relationshipsFile)
+
+		return tx.Relationships().OrderBy(
+			query.Order(query.RelationshipID(), query.Ascending()),
+		).Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+			for relationship := range cursor.Chan() {
+				if properties, err := newBackupProperties(relationship.Properties); err != nil {
+					return fmt.Errorf("failed encoding properties of relationship %d: %w", relationship.ID, err)
+				} else if err := encoder.Encode(backupRelationship{
+					ID:               relationship.ID,
+					StartID:          relationship.StartID,
+					EndID:            relationship.EndID,
+					Kind:             relationship.Kind.String(),
+					backupProperties: properties,
+				}); err != nil {
+					return err
+				}
+
+				numRelationships++
+			}
+
+			return cursor.Error()
+		})
+	})
+
+	return numNodes, numRelationships, err
+}
+
+func writeTarEntry(tarWriter *tar.Writer, name string, size int64, content io.Reader) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	_, err := io.Copy(tarWriter, content)
+	return err
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, file *os.File) error {
+	if info, err := file.Stat(); err != nil {
+		return err
+	} else if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	} else {
+		return writeTarEntry(tarWriter, name, info.Size(), file)
+	}
+}
+
+func writeTarJSON(tarWriter *tar.Writer, name string, value any) error {
+	if content, err := json.Marshal(value); err != nil {
+		return err
+	} else {
+		return writeTarEntry(tarWriter, name, int64(len(content)), strings.NewReader(string(content)))
+	}
+}
+
+// stagedGraph holds the nodes and relationships of a graph that were exported to the staging directory of a backup
+type stagedGraph struct {
+	nodesFile         *os.File
+	relationshipsFile *os.File
+	nodes             int64
+	relationships     int64
+}
+
+// stageGraph exports the graph targeted by the given context to files in the staging directory
+func stageGraph(ctx context.Context, graphDB graph.Database, stagingDir, name string) (*stagedGraph, error) {
+	var (
+		staged = &stagedGraph{}
+		err    error
+	)
+
+	if staged.nodesFile, err = os.Create(filepath.Join(stagingDir, name+"-nodes.jsonl")); err != nil {
+		return nil, err
+	} else if staged.relationshipsFile, err = os.Create(filepath.Join(stagingDir, name+"-relationships.jsonl")); err != nil {
+		staged.Close()
+		return nil, err
+	}
+
+	var (
+		nodesWriter         = bufio.NewWriter(staged.nodesFile)
+		relationshipsWriter = bufio.NewWriter(staged.relationshipsFile)
+	)
+
+	if staged.nodes, staged.relationships, err = exportGraph(ctx, graphDB, nodesWriter, relationshipsWriter); err != nil {
+		staged.Close()
+		return nil, err
+	} else if err := nodesWriter.Flush(); err != nil {
+		staged.Close()
+		return nil, err
+	} else if err := relationshipsWriter.Flush(); err != nil {
+		staged.Close()
+		return nil, err
+	}
+
+	return staged, nil
+}
+
+// write adds the staged nodes and relationships to the archive under the given entry names
+func (s *stagedGraph) write(tarWriter *tar.Writer, nodesName, relationshipsName string) error {
+	if err := writeTarFile(tarWriter, nodesName, s.nodesFile); err != nil {
+		return err
+	}
+
+	return writeTarFile(tarWriter, relationshipsName, s.relationshipsFile)
+}
+
+func (s *stagedGraph) Close() {
+	if s.nodesFile != nil {
+		s.nodesFile.Close()
+	}
+
+	if s.relationshipsFile != nil {
+		s.relationshipsFile.Close()
+	}
+}
+
+// backupWorkspaceIDs returns the IDs of the workspaces found in the exported workspaces table
+func backupWorkspaceIDs(tables []database.BackupTable) ([]int64, error) {
+	var workspaceIDs []int64
+
+	for _, table := range tables {
+		if table.Name != "workspaces" {
+			continue
+		}
+
+		var rows []struct {
+			ID int64 `json:"id"`
+		}
+
+		if err := json.Unmarshal(table.Rows, &rows); err != nil {
+			return nil, fmt.Errorf("failed reading exported workspaces: %w", err)
+		}
+
+		for _, row := range rows {
+			workspaceIDs = append(workspaceIDs, row.ID)
+		}
+	}
+
+	return workspaceIDs, nil
+}
+
+// CreateBackup writes a gzip compressed tar archive of the graphs and the application state of the instance. Graphs
+// are staged in the given work directory since tar entries must be sized before they are written. The graph of every
+// workspace is included when the graph driver keeps workspace graphs.
+//
+// The application state holds the password digests, TOTP secrets and API token keys of every user. The archive is
+// therefore encrypted with a key derived from the given passphrase and can not be restored without it.
+func CreateBackup(ctx context.Context, db database.BackupData, graphDB graph.Database, workDir, passphrase string, writer io.Writer) (BackupManifest, error) {
+	return createBackup(ctx, db, graphDB, pg.IsPostgreSQLGraph(graphDB), workDir, passphrase, writer)
+}
+
+func createBackup(ctx context.Context, db database.BackupData, graphDB graph.Database, workspaceGraphs bool, workDir, passphrase string, writer io.Writer) (BackupManifest, error) {
+	defer log.LogAndMeasure(log.LevelInfo, "Creating instance backup")()
+
+	manifest := BackupManifest{
+		FormatVersion:      BackupFormatVersion,
+		CreatedAt:          time.Now().UTC(),
+		ApplicationVersion: version.GetVersion(),
+		Tables:             database.BackupTables,
+	}
+
+	stagingDir, err := os.MkdirTemp(workDir, "backup")
+	if err != nil {
+		return manifest, fmt.Errorf("failed creating backup staging directory: %w", err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(stagingDir); err != nil {
+			log.Errorf("Failed removing backup staging directory %s: %v", stagingDir, err)
+		}
+	}()
+
+	if manifest.SchemaVersion, err = db.GetSchemaVersion(ctx); err != nil {
+		return manifest, fmt.Errorf("failed fetching schema version: %w", err)
+	}
+
+	tables := make([]database.BackupTable, 0, len(database.BackupTables))
+
+	for _, tableName := range database.BackupTables {
+		if table, err := db.ExportTable(ctx, tableName); err != nil {
+			return manifest, fmt.Errorf("failed exporting table %s: %w", tableName, err)
+		} else {
+			tables = append(tables, table)
+		}
+	}
+
+	defaultGraph, err := stageGraph(ctx, graphDB, stagingDir, "default")
+	if err != nil {
+		return manifest, fmt.Errorf("failed exporting graph: %w", err)
+	}
+
+	defer defaultGraph.Close()
+
+	manifest.Nodes, manifest.Relationships = defaultGraph.nodes, defaultGraph.relationships
+
+	var stagedWorkspaces []*stagedGraph
+
+	if workspaceGraphs {
+		if workspaceIDs, err := backupWorkspaceIDs(tables); err != nil {
+			return manifest, err
+		} else {
+			for _, workspaceID := range workspaceIDs {
+				staged, err := stageGraph(graph.WithGraphTarget(ctx, model.WorkspaceGraph(workspaceID)), graphDB, stagingDir, "workspace-"+strconv.FormatInt(workspaceID, 10))
+				if err != nil {
+					return manifest, fmt.Errorf("failed exporting graph of workspace %d: %w", workspaceID, err)
+				}
+
+				defer staged.Close()
+
+				stagedWorkspaces = append(stagedWorkspaces, staged)
+				manifest.Workspaces = append(manifest.Workspaces, BackupWorkspaceGraph{
+					ID:            workspaceID,
+					Nodes:         staged.nodes,
+					Relationships: staged.relationships,
+				})
+			}
+		}
+	}
+
+	encryptWriter, err := newBackupEncryptWriter(writer, passphrase)
+	if err != nil {
+		return manifest, fmt.Errorf("failed initializing backup encryption: %w", err)
+	}
+
+	var (
+		gzipWriter = gzip.NewWriter(encryptWriter)
+		tarWriter  = tar.NewWriter(gzipWriter)
+	)
+
+	if err := writeTarJSON(tarWriter, backupManifestName, manifest); err != nil {
+		return manifest, err
+	} else if err := defaultGraph.write(tarWriter, backupNodesName, backupRelationshipsName); err != nil {
+		return manifest, err
+	}
+
+	for idx, workspace := range manifest.Workspaces {
+		if err := stagedWorkspaces[idx].write(tarWriter, workspaceBackupEntry(workspace.ID, "nodes.jsonl"), workspaceBackupEntry(workspace.ID, "relationships.jsonl")); err != nil {
+			return manifest, err
+		}
+	}
+
+	for _, table := range tables {
+		if err := writeTarEntry(tarWriter, path.Join(backupTablesDirectory, table.Name+".json"), int64(len(table.Rows)), strings.NewReader(string(table.Rows))); err != nil {
+			return manifest, err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return manifest, err
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return manifest, err
+	}
+
+	return manifest, encryptWriter.Close()
+}
+
+func isGraphEmpty(ctx context.Context, graphDB graph.Database) (bool, error) {
+	var numNodes int64
+
+	return numNodes == 0, graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		var err error
+		numNodes, err = tx.Nodes().Filter(query.Not(query.KindIn(query.Node(), excludedMigrationKinds()...))).Count()
+		return err
+	})
+}
+
+// readBackupPages decodes JSON lines from the reader and hands them to the write delegate in pages
+func readBackupPages[T any](reader io.Reader, pageSize int, write func(page []T) error) error {
+	var (
+		decoder = json.NewDecoder(reader)
+		page    = make([]T, 0, pageSize)
+	)
+
+	decoder.UseNumber()
+
+	for {
+		var next T
+
+		if err := decoder.Decode(&next); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return malformedBackupError(err)
+		}
+
+		if page = append(page, next); len(page) == pageSize {
+			if err := write(page); err != nil {
+				return err
+			}
+
+			page = page[:0]
+		}
+	}
+
+	if len(page) > 0 {
+		return write(page)
+	}
+
+	return nil
+}
+
+func restoreNodes(ctx context.Context, graphDB graph.Database, reader io.Reader, mappings *nodeIDMappings) (int64, error) {
+	var numNodes int64
+
+	return numNodes, readBackupPages(reader, defaultMigrationPageSize, func(page []backupNode) error {
+		pairs := make([][2]graph.ID, 0, len(page))
+
+		if err := graphDB.WriteTransaction(ctx, func(tx graph.Transaction) error {
+			for _, node := range page {
+				if properties, err := node.decode(); err != nil {
+					return fmt.Errorf("%w: node %d: %v", ErrBackupMalformed, node.ID, err)
+				} else if created, err := tx.CreateNode(properties, graph.StringsToKinds(node.Kinds)...); err != nil {
+					return err
+				} else {
+					pairs = append(pairs, [2]graph.ID{node.ID, created.ID})
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		numNodes += int64(len(page))
+		return mappings.Append(pairs)
+	})
+}
+
+func restoreRelationships(ctx context.Context, graphDB graph.Database, reader io.Reader, mappings *nodeIDMappings) (int64, error) {
+	var numRelationships int64
+
+	return numRelationships, readBackupPages(reader, defaultMigrationPageSize, func(page []backupRelationship) error {
+		if err := graphDB.WriteTransaction(ctx, func(tx graph.Transaction) error {
+			for _, relationship := range page {
+				if startID, err := mappings.Lookup(relationship.StartID); err != nil {
+					return fmt.Errorf("%w: start node %d of relationship %d: %v", ErrBackupMalformed, relationship.StartID, relationship.ID, err)
+				} else if endID, err := mappings.Lookup(relationship.EndID); err != nil {
+					return fmt.Errorf("%w: end node %d of relationship %d: %v", ErrBackupMalformed, relationship.EndID, relationship.ID, err)
+				} else if properties, err := relationship.decode(); err != nil {
+					return fmt.Errorf("%w: relationship %d: %v", ErrBackupMalformed, relationship.ID, err)
+				} else if _, err := tx.CreateRelationshipByIDs(startID, endID, graph.StringKind(relationship.Kind), properties); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		numRelationships += int64(len(page))
+		return nil
+	})
+}
+
+// restoredGraph tracks the restore of one of the graphs listed by a backup manifest
+type restoredGraph struct {
+	ctx                   context.Context
+	name                  string
+	expectedNodes         int64
+	expectedRelationships int64
+	nodes                 int64
+	relationships         int64
+	nodesRestored         bool
+}
+
+// malformedBackupError reports a failure to read a backup archive. Failures to decrypt the archive are kept as is
+// since they are more likely caused by a wrong passphrase than by a damaged archive.
+func malformedBackupError(err error) error {
+	if errors.Is(err, ErrBackupPassphrase) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %v", ErrBackupMalformed, err)
+}
+
+// clearGraph removes every node and relationship of the graph targeted by the given context
+func clearGraph(ctx context.Context, graphDB graph.Database) error {
+	return graphDB.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		if err := tx.Relationships().Delete(); err != nil {
+			return err
+		}
+
+		return tx.Nodes().Delete()
+	})
+}
+
+// RestoreBackup restores a backup archive created by CreateBackup with the passphrase it was encrypted with. The
+// graphs must be empty and the schema version of the backup must match the schema version of the instance. The graphs
+// are restored first followed by the application state, which replaces the default state of the instance in a single
+// transaction. The restored graphs are cleared again if any part of the restore fails so that the instance is left as
+// it was found.
+func RestoreBackup(ctx context.Context, db database.BackupData, graphDB graph.Database, workDir, passphrase string, reader io.Reader) (BackupManifest, error) {
+	return restoreBackup(ctx, db, graphDB, pg.IsPostgreSQLGraph(graphDB), workDir, passphrase, reader)
+}
+
+func restoreBackup(ctx context.Context, db database.BackupData, graphDB graph.Database, workspaceGraphs bool, workDir, passphrase string, reader io.Reader) (BackupManifest, error) {
+	defer log.LogAndMeasure(log.LevelInfo, "Restoring instance backup")()
+
+	var manifest BackupManifest
+
+	decryptReader, err := newBackupDecryptReader(reader, passphrase)
+	if err != nil {
+		return manifest, err
+	}
+
+	gzipReader, err := gzip.NewReader(decryptReader)
+	if err != nil {
+		return manifest, malformedBackupError(err)
+	}
+
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+
+	if header, err := tarReader.Next(); err != nil {
+		return manifest, malformedBackupError(err)
+	} else if header.Name != backupManifestName {
+		return manifest, fmt.Errorf("%w: expected %s as the first entry but found %s", ErrBackupMalformed, backupManifestName, header.Name)
+	} else if err := json.NewDecoder(tarReader).Decode(&manifest); err != nil {
+		return manifest, malformedBackupError(err)
+	} else if manifest.FormatVersion != BackupFormatVersion {
+		return manifest, fmt.Errorf("%w: backup format version %d is not supported", ErrBackupIncompatible, manifest.FormatVersion)
+	} else if len(manifest.Workspaces) > 0 && !workspaceGraphs {
+		return manifest, fmt.Errorf("%w: backup contains workspace graphs but the graph driver does not support workspaces", ErrBackupIncompatible)
+	} else if schemaVersion, err := db.GetSchemaVersion(ctx); err != nil {
+		return manifest, fmt.Errorf("failed fetching schema version: %w", err)
+	} else if !schemaVersion.Equals(manifest.SchemaVersion) {
+		return manifest, fmt.Errorf("%w: backup schema version %s does not match instance schema version %s", ErrBackupIncompatible, manifest.SchemaVersion, schemaVersion)
+	}
+
+	var (
+		graphs = []*restoredGraph{{
+			ctx:                   ctx,
+			name:                  "default graph",
+			expectedNodes:         manifest.Nodes,
+			expectedRelationships: manifest.Relationships,
+		}}
+		nodesEntries         = map[string]*restoredGraph{backupNodesName: graphs[0]}
+		relationshipsEntries = map[string]*restoredGraph{backupRelationshipsName: graphs[0]}
+	)
+
+	for _, workspace := range manifest.Workspaces {
+		target := &restoredGraph{
+			ctx:                   graph.WithGraphTarget(ctx, model.WorkspaceGraph(workspace.ID)),
+			name:                  fmt.Sprintf("graph of workspace %d", workspace.ID),
+			expectedNodes:         workspace.Nodes,
+			expectedRelationships: workspace.Relationships,
+		}
+
+		graphs = append(graphs, target)
+		nodesEntries[workspaceBackupEntry(workspace.ID, "nodes.jsonl")] = target
+		relationshipsEntries[workspaceBackupEntry(workspace.ID, "relationships.jsonl")] = target
+	}
+
+	for _, target := range graphs {
+		if isEmpty, err := isGraphEmpty(target.ctx, graphDB); err != nil {
+			return manifest, fmt.Errorf("failed checking the %s for existing data: %w", target.name, err)
+		} else if !isEmpty {
+			return manifest, ErrInstanceNotEmpty
+		}
+	}
+
+	// The graphs were verified to be empty above, clear anything restored into them if the restore does not complete
+	restored := false
+
+	defer func() {
+		if !restored {
+			for _, target := range graphs {
+				if err := clearGraph(context.WithoutCancel(target.ctx), graphDB); err != nil {
+					log.Errorf("Failed clearing the %s after a failed restore: %v", target.name, err)
+				}
+			}
+		}
+	}()
+
+	mappingsFile, err := os.CreateTemp(workDir, "restore")
+	if err != nil {
+		return manifest, fmt.Errorf("failed creating node ID mappings: %w", err)
+	} else if err := mappingsFile.Close(); err != nil {
+		return manifest, err
+	}
+
+	defer func() {
+		if err := os.Remove(mappingsFile.Name()); err != nil {
+			log.Errorf("Failed removing node ID mappings file %s: %v", mappingsFile.Name(), err)
+		}
+	}()
+
+	mappings, err := openNodeIDMappings(mappingsFile.Name())
+	if err != nil {
+		return manifest, fmt.Errorf("failed opening node ID mappings: %w", err)
+	}
+
+	defer mappings.Close()
+
+	var (
+		tables []database.BackupTable
+
+		// mappedGraph is the graph whose node IDs are currently held by the mappings
+		mappedGraph *restoredGraph
+	)
+
+	for {
+		header, err := tarReader.Next()
+
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return manifest, malformedBackupError(err)
+		}
+
+		if target, isNodes := nodesEntries[header.Name]; isNodes {
+			if target.nodesRestored {
+				return manifest, fmt.Errorf("%w: nodes of the %s are listed more than once", ErrBackupMalformed, target.name)
+			} else if err := mappings.Truncate(0); err != nil {
+				return manifest, fmt.Errorf("failed resetting node ID mappings: %w", err)
+			} else if target.nodes, err = restoreNodes(target.ctx, graphDB, tarReader, mappings); err != nil {
+				return manifest, fmt.Errorf("failed restoring nodes of the %s: %w", target.name, err)
+			}
+
+			target.nodesRestored = true
+			mappedGraph = target
+		} else if target, isRelationships := relationshipsEntries[header.Name]; isRelationships {
+			if target != mappedGraph {
+				return manifest, fmt.Errorf("%w: relationships of the %s must directly follow its nodes", ErrBackupMalformed, target.name)
+			} else if target.relationships, err = restoreRelationships(target.ctx, graphDB, tarReader, mappings); err != nil {
+				return manifest, fmt.Errorf("failed restoring relationships of the %s: %w", target.name, err)
+			}
+
+			mappedGraph = nil
+		} else if path.Dir(header.Name) == backupTablesDirectory {
+			if rows, err := io.ReadAll(tarReader); err != nil {
+				return manifest, malformedBackupError(err)
+			} else {
+				tables = append(tables, database.BackupTable{
+					Name: strings.TrimSuffix(path.Base(header.Name), ".json"),
+					Rows: rows,
+				})
+			}
+		} else {
+			log.Warnf("Ignoring unexpected backup archive entry %s", header.Name)
+		}
+	}
+
+	for _, target := range graphs {
+		if target.nodes != target.expectedNodes || target.relationships != target.expectedRelationships {
+			return manifest, fmt.Errorf("%w: restored %d nodes and %d relationships of the %s but the manifest lists %d nodes and %d relationships", ErrBackupMalformed, target.nodes, target.relationships, target.name, target.expectedNodes, target.expectedRelationships)
+		}
+	}
+
+	// Drain the archive so that the last encrypted chunk is authenticated before the application state is replaced
+	if _, err := io.Copy(io.Discard, gzipReader); err != nil {
+		return manifest, malformedBackupError(err)
+	} else if err := db.RestoreTables(ctx, tables); err != nil {
+		return manifest, fmt.Errorf("failed restoring application state: %w", err)
+	}
+
+	restored = true
+	return manifest, nil
+}
+
+// HeaderBackupPassphrase holds the passphrase that backup archives are encrypted with
+const HeaderBackupPassphrase = "Backup-Passphrase"
+
+// BackupTool serves backup and restore of the instance over the tools API. The tools API is not authenticated and
+// backups hold the credentials of every user, so archives are always encrypted with a passphrase chosen by the
+// operator. The tools API must still only be reachable by operators since it can restore any backup.
+type BackupTool struct {
+	db      database.BackupData
+	graphDB graph.Database
+	cfg     config.Configuration
+
+	// lock serializes backup and restore operations
+	lock *sync.Mutex
+}
+
+func NewBackupTool(cfg config.Configuration, db database.BackupData, graphDB graph.Database) BackupTool {
+	return BackupTool{
+		db:      db,
+		graphDB: graphDB,
+		cfg:     cfg,
+		lock:    &sync.Mutex{},
+	}
+}
+
+// Backup streams an encrypted backup archive of the instance. Backup errors after the archive has started streaming can
+// not be reported with a status code and result in a truncated archive that fails to restore.
+func (s BackupTool) Backup(response http.ResponseWriter, request *http.Request) {
+	passphrase := request.Header.Get(HeaderBackupPassphrase)
+
+	if len(passphrase) < MinBackupPassphraseLength {
+		api.WriteJSONResponse(request.Context(), map[string]any{
+			"error": fmt.Sprintf("a passphrase of at least %d characters must be set in the %s header to encrypt the backup", MinBackupPassphraseLength, HeaderBackupPassphrase),
+		}, http.StatusBadRequest, response)
+		return
+	}
+
+	if !s.lock.TryLock() {
+		api.WriteJSONResponse(request.Context(), map[string]any{
+			"error": "a backup or restore is already in progress",
+		}, http.StatusConflict, response)
+		return
+	}
+
+	defer s.lock.Unlock()
+
+	response.Header().Set("Content-Type", "application/octet-stream")
+	response.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="bloodhound-backup-%s.tar.gz.enc"`, time.Now().UTC().Format("20060102T150405Z")))
+
+	if _, err := CreateBackup(request.Context(), s.db, s.graphDB, s.cfg.WorkDir, passphrase, response); err != nil {
+		log.Errorf("Failed creating instance backup: %v", err)
+	}
+}
+
+func (s BackupTool) Restore(response http.ResponseWriter, request *http.Request) {
+	passphrase := request.Header.Get(HeaderBackupPassphrase)
+
+	if passphrase == "" {
+		api.WriteJSONResponse(request.Context(), map[string]any{
+			"error": fmt.Sprintf("the passphrase the backup was encrypted with must be set in the %s header", HeaderBackupPassphrase),
+		}, http.StatusBadRequest, response)
+		return
+	}
+
+	if !s.lock.TryLock() {
+		api.WriteJSONResponse(request.Context(), map[string]any{
+			"error": "a backup or restore is already in progress",
+		}, http.StatusConflict, response)
+		return
+	}
+
+	defer s.lock.Unlock()
+
+	if manifest, err := RestoreBackup(request.Context(), s.db, s.graphDB, s.cfg.WorkDir, passphrase, request.Body); err != nil {
+		status := http.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, ErrBackupIncompatible), errors.Is(err, ErrBackupMalformed), errors.Is(err, ErrBackupPassphrase):
+			status = http.StatusBadRequest
+		case errors.Is(err, ErrInstanceNotEmpty):
+			status = http.StatusConflict
+		}
+
+		log.Errorf("Failed restoring instance backup: %v", err)
+		api.WriteJSONResponse(request.Context(), map[string]any{
+			"error": err.Error(),
+		}, status, response)
+	} else {
+		log.Infof("Restored backup created at %s with %d nodes and %d relationships", manifest.CreatedAt, manifest.Nodes, manifest.Relationships)
+		api.WriteJSONResponse(request.Context(), manifest, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Backup archives are encrypted as a sequence of AES-256-GCM sealed chunks. The archive starts with a header that
+// holds the argon2id salt used to derive the key from the passphrase and a random nonce prefix. The nonce of every
+// chunk is made of the nonce prefix, the index of the chunk and a flag that marks the last chunk so that reordered or
+// truncated archives fail to decrypt. The header is authenticated as additional data of every chunk.
+const (
+	MinBackupPassphraseLength = 12
+
+	backupEncryptionMagic         = "BHBKENC1"
+	backupSaltLength              = 16
+	backupNoncePrefixLength       = 7
+	backupHeaderLength            = len(backupEncryptionMagic) + backupSaltLength + backupNoncePrefixLength
+	backupChunkSize               = 64 * 1024
+	backupKeyLength               = 32
+	backupKeyIterations           = 3
+	backupKeyMemoryKibibytes      = 64 * 1024
+	backupKeyThreads              = 4
+	backupLastChunkFlag      byte = 1
+)
+
+var (
+	ErrBackupPassphrase = errors.New("backup could not be decrypted with the given passphrase or was modified")
+)
+
+func newBackupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), salt, backupKeyIterations, backupKeyMemoryKibibytes, backupKeyThreads, backupKeyLength)
+
+	if block, err := aes.NewCipher(key); err != nil {
+		return nil, err
+	} else {
+		return cipher.NewGCM(block)
+	}
+}
+
+func backupChunkNonce(noncePrefix []byte, index uint32, last bool) []byte {
+	nonce := make([]byte, 0, backupNoncePrefixLength+5)
+	nonce = append(nonce, noncePrefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, index)
+
+	if last {
+		return append(nonce, backupLastChunkFlag)
+	}
+
+	return append(nonce, 0)
+}
+
+// backupEncryptWriter encrypts everything written to it. Close must be called to seal the last chunk.
+type backupEncryptWriter struct {
+	writer      io.Writer
+	aead        cipher.AEAD
+	header      []byte
+	noncePrefix []byte
+	chunk       []byte
+	index       uint32
+}
+
+func newBackupEncryptWriter(writer io.Writer, passphrase string) (*backupEncryptWriter, error) {
+	header := make([]byte, backupHeaderLength)
+	copy(header, backupEncryptionMagic)
+
+	salt := header[len(backupEncryptionMagic) : len(backupEncryptionMagic)+backupSaltLength]
+
+	if _, err := rand.Read(header[len(backupEncryptionMagic):]); err != nil {
+		return nil, err
+	} else if aead, err := newBackupCipher(passphrase, salt); err != nil {
+		return nil, err
+	} else if _, err := writer.Write(header); err != nil {
+		return nil, err
+	} else {
+		return &backupEncryptWriter{
+			writer:      writer,
+			aead:        aead,
+			header:      header,
+			noncePrefix: header[len(backupEncryptionMagic)+backupSaltLength:],
+			chunk:       make([]byte, 0, backupChunkSize),
+		}, nil
+	}
+}
+
+func (s *backupEncryptWriter) seal(last bool) error {
+	sealed := s.aead.Seal(nil, backupChunkNonce(s.noncePrefix, s.index, last), s.chunk, s.header)
+
+	s.chunk = s.chunk[:0]
+	s.index++
+
+	_, err := s.writer.Write(sealed)
+	return err
+}
+
+func (s *backupEncryptWriter) Write(content []byte) (int, error) {
+	written := 0
+
+	for len(content) > 0 {
+		// A full chunk is only sealed once more content follows since the last chunk must carry the last chunk flag
+		if len(s.chunk) == backupChunkSize {
+			if err := s.seal(false); err != nil {
+				return written, err
+			}
+		}
+
+		numCopied := copy(s.chunk[len(s.chunk):backupChunkSize], content)
+		s.chunk = s.chunk[:len(s.chunk)+numCopied]
+
+		content = content[numCopied:]
+		written += numCopied
+	}
+
+	return written, nil
+}
+
+func (s *backupEncryptWriter) Close() error {
+	return s.seal(true)
+}
+
+// backupDecryptReader decrypts an archive written by a backupEncryptWriter. Reads fail with ErrBackupPassphrase when a
+// chunk does not authenticate and with ErrBackupMalformed when the archive ends before its last chunk.
+type backupDecryptReader struct {
+	reader      *bufio.Reader
+	aead        cipher.AEAD
+	header      []byte
+	noncePrefix []byte
+	sealed      []byte
+	chunk       []byte
+	index       uint32
+	done        bool
+}
+
+func newBackupDecryptReader(reader io.Reader, passphrase string) (*backupDecryptReader, error) {
+	header := make([]byte, backupHeaderLength)
+
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBackupMalformed, err)
+	} else if string(header[:len(backupEncryptionMagic)]) != backupEncryptionMagic {
+		return nil, fmt.Errorf("%w: archive is not an encrypted backup", ErrBackupMalformed)
+	} else if aead, err := newBackupCipher(passphrase, header[len(backupEncryptionMagic):len(backupEncryptionMagic)+backupSaltLength]); err != nil {
+		return nil, err
+	} else {
+		return &backupDecryptReader{
+			reader:      bufio.NewReader(reader),
+			aead:        aead,
+			header:      header,
+			noncePrefix: header[len(backupEncryptionMagic)+backupSaltLength:],
+			sealed:      make([]byte, backupChunkSize+aead.Overhead()),
+		}, nil
+	}
+}
+
+func (s *backupDecryptReader) open() error {
+	numRead, err := io.ReadFull(s.reader, s.sealed)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("%w: archive ends before its last chunk", ErrBackupMalformed)
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// A short chunk must be the last chunk
+		s.done = true
+
+	case err != nil:
+		return err
+
+	default:
+		// A full chunk is the last chunk only when nothing follows it
+		if _, err := s.reader.Peek(1); errors.Is(err, io.EOF) {
+			s.done = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if chunk, err := s.aead.Open(s.sealed[:0], backupChunkNonce(s.noncePrefix, s.index, s.done), s.sealed[:numRead], s.header); err != nil {
+		return ErrBackupPassphrase
+	} else {
+		s.chunk = chunk
+		s.index++
+	}
+
+	return nil
+}
+
+func (s *backupDecryptReader) Read(content []byte) (int, error) {
+	for len(s.chunk) == 0 {
+		if s.done {
+			return 0, io.EOF
+		} else if err := s.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	numCopied := copy(content, s.chunk)
+	s.chunk = s.chunk[numCopied:]
+
+	return numCopied, nil
+}
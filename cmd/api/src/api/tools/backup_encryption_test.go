@@ -0,0 +1,107 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encryptBackupContent(t *testing.T, content []byte) []byte {
+	var (
+		encrypted   = &bytes.Buffer{}
+		writer, err = newBackupEncryptWriter(encrypted, backupPassphrase)
+	)
+
+	require.Nil(t, err)
+
+	// Write in uneven pieces to cover writes that span chunk boundaries
+	for len(content) > 0 {
+		pieceLength := min(len(content), 10_000)
+
+		_, err := writer.Write(content[:pieceLength])
+		require.Nil(t, err)
+
+		content = content[pieceLength:]
+	}
+
+	require.Nil(t, writer.Close())
+	return encrypted.Bytes()
+}
+
+func TestBackupEncryption_RoundTrip(t *testing.T) {
+	for _, contentLength := range []int{0, 1, backupChunkSize, backupChunkSize + 1, 3 * backupChunkSize} {
+		content := make([]byte, contentLength)
+
+		_, err := rand.Read(content)
+		require.Nil(t, err)
+
+		encrypted := encryptBackupContent(t, content)
+
+		if contentLength >= 64 {
+			assert.NotContains(t, string(encrypted), string(content[:64]))
+		}
+
+		reader, err := newBackupDecryptReader(bytes.NewReader(encrypted), backupPassphrase)
+		require.Nil(t, err)
+
+		decrypted, err := io.ReadAll(reader)
+		require.Nil(t, err)
+		assert.Equal(t, content, decrypted)
+	}
+}
+
+func TestBackupEncryption_Tampering(t *testing.T) {
+	content := make([]byte, 2*backupChunkSize)
+	encrypted := encryptBackupContent(t, content)
+
+	readAll := func(encrypted []byte, passphrase string) error {
+		if reader, err := newBackupDecryptReader(bytes.NewReader(encrypted), passphrase); err != nil {
+			return err
+		} else {
+			_, err := io.ReadAll(reader)
+			return err
+		}
+	}
+
+	t.Run("Wrong Passphrase", func(t *testing.T) {
+		assert.ErrorIs(t, readAll(encrypted, "not the passphrase"), ErrBackupPassphrase)
+	})
+
+	t.Run("Modified Content", func(t *testing.T) {
+		modified := bytes.Clone(encrypted)
+		modified[backupHeaderLength+100] ^= 1
+
+		assert.ErrorIs(t, readAll(modified, backupPassphrase), ErrBackupPassphrase)
+	})
+
+	t.Run("Missing Last Chunk", func(t *testing.T) {
+		// The content fills exactly two chunks followed by an empty last chunk
+		truncated := encrypted[:len(encrypted)-(len(encrypted)-backupHeaderLength)/3]
+
+		assert.NotNil(t, readAll(truncated, backupPassphrase))
+	})
+
+	t.Run("Not Encrypted", func(t *testing.T) {
+		assert.ErrorIs(t, readAll([]byte("this is not an encrypted backup archive"), backupPassphrase), ErrBackupMalformed)
+	})
+}
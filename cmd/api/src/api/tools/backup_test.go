@@ -0,0 +1,268 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var backupSchemaVersion = version.Version{Major: 6, Minor: 4, Patch: 0}
+
+const backupPassphrase = "correct horse battery staple"
+
+func expectTableExports(db *mocks.MockDatabase) {
+	db.EXPECT().ExportTable(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, table string) (database.BackupTable, error) {
+		return database.BackupTable{
+			Name: table,
+			Rows: json.RawMessage(`[{"id": 1, "name": "` + table + `"}]`),
+		}, nil
+	}).Times(len(database.BackupTables))
+}
+
+func TestBackup_RoundTrip(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		sourceDB = mocks.NewMockDatabase(mockCtrl)
+		targetDB = mocks.NewMockDatabase(mockCtrl)
+		source   = newMigrationSourceGraph(t)
+		target   = memory.NewDatabase(0)
+		archive  = &bytes.Buffer{}
+		workDir  = t.TempDir()
+	)
+
+	sourceDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(backupSchemaVersion, nil)
+	expectTableExports(sourceDB)
+
+	manifest, err := CreateBackup(context.Background(), sourceDB, source, workDir, backupPassphrase, archive)
+	require.Nil(t, err)
+	assert.Equal(t, BackupFormatVersion, manifest.FormatVersion)
+	assert.Equal(t, int64(5), manifest.Nodes)
+	assert.Equal(t, int64(5), manifest.Relationships)
+
+	targetDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(backupSchemaVersion, nil)
+	targetDB.EXPECT().RestoreTables(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tables []database.BackupTable) error {
+		require.Len(t, tables, len(database.BackupTables))
+
+		for idx, table := range tables {
+			assert.Equal(t, database.BackupTables[idx], table.Name)
+			assert.JSONEq(t, `[{"id": 1, "name": "`+table.Name+`"}]`, string(table.Rows))
+		}
+
+		return nil
+	})
+
+	restored, err := RestoreBackup(context.Background(), targetDB, target, workDir, backupPassphrase, archive)
+	require.Nil(t, err)
+	assert.Equal(t, manifest.Nodes, restored.Nodes)
+
+	nodes, relationships := countGraph(t, target)
+	assert.Equal(t, int64(5), nodes)
+	assert.Equal(t, int64(5), relationships)
+
+	require.Nil(t, target.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if node, err := tx.Nodes().Filter(query.Equals(query.NodeProperty(common.ObjectID.String()), "S-1-5-21-C")).First(); err != nil {
+			return err
+		} else {
+			assert.True(t, node.Kinds.ContainsOneOf(ad.Group))
+			assert.Equal(t, int64(2), node.Properties.Get("count").Any())
+			assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), node.Properties.Get(common.LastSeen.String()).Any())
+
+			count, err := tx.Relationships().Filter(query.And(
+				query.Equals(query.EndID(), node.ID),
+				query.Kind(query.Relationship(), ad.MemberOf),
+			)).Count()
+
+			assert.Equal(t, int64(2), count)
+			return err
+		}
+	}))
+
+	// Node ID mappings and staged graph files are removed once done
+	entries, err := os.ReadDir(workDir)
+	require.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestBackup_RoundTripWorkspaces(t *testing.T) {
+	var (
+		mockCtrl       = gomock.NewController(t)
+		sourceDB       = mocks.NewMockDatabase(mockCtrl)
+		targetDB       = mocks.NewMockDatabase(mockCtrl)
+		source         = graph.NewDatabaseSwitch(context.Background(), newMigrationSourceGraph(t))
+		target         = graph.NewDatabaseSwitch(context.Background(), memory.NewDatabase(0))
+		workspaceCtx   = graph.WithGraphTarget(context.Background(), model.WorkspaceGraph(1))
+		archive        = &bytes.Buffer{}
+		workDir        = t.TempDir()
+		workspaceNodes = []string{"S-1-5-21-W1", "S-1-5-21-W2"}
+	)
+
+	// The exported workspaces table lists the workspace with ID 1
+	require.Nil(t, source.WriteTransaction(workspaceCtx, func(tx graph.Transaction) error {
+		var nodes []*graph.Node
+
+		for _, objectID := range workspaceNodes {
+			if node, err := tx.CreateNode(graph.AsProperties(map[string]any{
+				common.ObjectID.String(): objectID,
+			}), ad.Entity, ad.User); err != nil {
+				return err
+			} else {
+				nodes = append(nodes, node)
+			}
+		}
+
+		_, err := tx.CreateRelationshipByIDs(nodes[0].ID, nodes[1].ID, ad.HasSession, graph.NewProperties())
+		return err
+	}))
+
+	sourceDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(backupSchemaVersion, nil)
+	expectTableExports(sourceDB)
+
+	manifest, err := createBackup(context.Background(), sourceDB, source, true, workDir, backupPassphrase, archive)
+	require.Nil(t, err)
+	assert.Equal(t, int64(5), manifest.Nodes)
+	assert.Equal(t, int64(5), manifest.Relationships)
+	assert.Equal(t, []BackupWorkspaceGraph{{ID: 1, Nodes: 2, Relationships: 1}}, manifest.Workspaces)
+
+	t.Run("Workspaces Unsupported", func(t *testing.T) {
+		_, err := restoreBackup(context.Background(), mocks.NewMockDatabase(mockCtrl), target, false, t.TempDir(), backupPassphrase, bytes.NewReader(archive.Bytes()))
+		assert.ErrorIs(t, err, ErrBackupIncompatible)
+	})
+
+	targetDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(backupSchemaVersion, nil)
+	targetDB.EXPECT().RestoreTables(gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err = restoreBackup(context.Background(), targetDB, target, true, workDir, backupPassphrase, archive)
+	require.Nil(t, err)
+
+	nodes, relationships := countGraph(t, target)
+	assert.Equal(t, int64(5), nodes)
+	assert.Equal(t, int64(5), relationships)
+
+	require.Nil(t, target.ReadTransaction(workspaceCtx, func(tx graph.Transaction) error {
+		var objectIDs []string
+
+		if err := tx.Nodes().Fetch(func(cursor graph.Cursor[*graph.Node]) error {
+			for node := range cursor.Chan() {
+				objectID, _ := node.Properties.Get(common.ObjectID.String()).String()
+				objectIDs = append(objectIDs, objectID)
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return err
+		} else if count, err := tx.Relationships().Filter(query.Kind(query.Relationship(), ad.HasSession)).Count(); err != nil {
+			return err
+		} else {
+			assert.ElementsMatch(t, workspaceNodes, objectIDs)
+			assert.Equal(t, int64(1), count)
+			return nil
+		}
+	}))
+}
+
+func TestRestoreBackup_Errors(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		sourceDB = mocks.NewMockDatabase(mockCtrl)
+		source   = newMigrationSourceGraph(t)
+		archive  = &bytes.Buffer{}
+	)
+
+	sourceDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(backupSchemaVersion, nil)
+	expectTableExports(sourceDB)
+
+	_, err := CreateBackup(context.Background(), sourceDB, source, t.TempDir(), backupPassphrase, archive)
+	require.Nil(t, err)
+
+	t.Run("Schema Version Mismatch", func(t *testing.T) {
+		targetDB := mocks.NewMockDatabase(mockCtrl)
+		targetDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(version.Version{Major: 6, Minor: 5}, nil)
+
+		_, err := RestoreBackup(context.Background(), targetDB, memory.NewDatabase(0), t.TempDir(), backupPassphrase, bytes.NewReader(archive.Bytes()))
+		assert.ErrorIs(t, err, ErrBackupIncompatible)
+	})
+
+	t.Run("Graph Not Empty", func(t *testing.T) {
+		targetDB := mocks.NewMockDatabase(mockCtrl)
+		targetDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(backupSchemaVersion, nil)
+
+		_, err := RestoreBackup(context.Background(), targetDB, newMigrationSourceGraph(t), t.TempDir(), backupPassphrase, bytes.NewReader(archive.Bytes()))
+		assert.ErrorIs(t, err, ErrInstanceNotEmpty)
+	})
+
+	t.Run("Malformed Archive", func(t *testing.T) {
+		_, err := RestoreBackup(context.Background(), mocks.NewMockDatabase(mockCtrl), memory.NewDatabase(0), t.TempDir(), backupPassphrase, bytes.NewReader([]byte("not a backup")))
+		assert.ErrorIs(t, err, ErrBackupMalformed)
+	})
+
+	t.Run("Wrong Passphrase", func(t *testing.T) {
+		_, err := RestoreBackup(context.Background(), mocks.NewMockDatabase(mockCtrl), memory.NewDatabase(0), t.TempDir(), "not the passphrase", bytes.NewReader(archive.Bytes()))
+		assert.ErrorIs(t, err, ErrBackupPassphrase)
+	})
+
+	t.Run("Truncated Archive", func(t *testing.T) {
+		var (
+			targetDB = mocks.NewMockDatabase(mockCtrl)
+			target   = memory.NewDatabase(0)
+		)
+
+		targetDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(backupSchemaVersion, nil).AnyTimes()
+
+		_, err := RestoreBackup(context.Background(), targetDB, target, t.TempDir(), backupPassphrase, bytes.NewReader(archive.Bytes()[:archive.Len()-1]))
+		assert.NotNil(t, err)
+
+		nodes, relationships := countGraph(t, target)
+		assert.Zero(t, nodes)
+		assert.Zero(t, relationships)
+	})
+
+	t.Run("Graph Cleared On Failure", func(t *testing.T) {
+		var (
+			targetDB = mocks.NewMockDatabase(mockCtrl)
+			target   = memory.NewDatabase(0)
+		)
+
+		targetDB.EXPECT().GetSchemaVersion(gomock.Any()).Return(backupSchemaVersion, nil)
+		targetDB.EXPECT().RestoreTables(gomock.Any(), gomock.Any()).Return(errors.New("restore failed"))
+
+		_, err := RestoreBackup(context.Background(), targetDB, target, t.TempDir(), backupPassphrase, bytes.NewReader(archive.Bytes()))
+		assert.ErrorContains(t, err, "restore failed")
+
+		nodes, relationships := countGraph(t, target)
+		assert.Zero(t, nodes)
+		assert.Zero(t, relationships)
+	})
+}
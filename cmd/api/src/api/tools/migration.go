@@ -0,0 +1,726 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+)
+
+type MigrationDirection string
+
+const (
+	MigrationDirectionNeo4jToPG MigrationDirection = "neo4j-to-pg"
+	MigrationDirectionPGToNeo4j MigrationDirection = "pg-to-neo4j"
+)
+
+func (s MigrationDirection) IsValid() bool {
+	return s == MigrationDirectionNeo4jToPG || s == MigrationDirectionPGToNeo4j
+}
+
+const (
+	defaultMigrationPageSize   = 10_000
+	defaultMigrationSampleSize = 1_000
+
+	migrationCheckpointStateProperty = "state"
+	nodeIDMappingRecordSize          = 16
+)
+
+var (
+	// migrationCheckpointKind is the kind of the node that tracks migration progress in the target graph. The
+	// checkpoint is updated in the same transaction as each page of migrated data so that progress can always be
+	// resumed exactly.
+	migrationCheckpointKind = graph.StringKind("GraphMigrationCheckpoint")
+
+	ErrMigrationDirectionMismatch = errors.New("target graph contains a checkpoint for a migration in the other direction")
+	ErrNodeIDMappingNotFound      = errors.New("no node ID mapping found for source node")
+)
+
+// excludedMigrationKinds are node kinds that each graph database manages for itself and that are never migrated
+func excludedMigrationKinds() graph.Kinds {
+	return graph.Kinds{common.MigrationData, migrationCheckpointKind}
+}
+
+// migrationCheckpoint records the progress of a migration. Nodes and relationships are migrated in ascending order of
+// their source IDs so the last migrated ID of each is enough to resume from.
+type migrationCheckpoint struct {
+	Direction             MigrationDirection `json:"direction"`
+	LastNodeID            graph.ID           `json:"last_node_id"`
+	NodesMigrated         int64              `json:"nodes_migrated"`
+	NodesComplete         bool               `json:"nodes_complete"`
+	LastRelationshipID    graph.ID           `json:"last_relationship_id"`
+	RelationshipsMigrated int64              `json:"relationships_migrated"`
+	RelationshipsComplete bool               `json:"relationships_complete"`
+	NodeKinds             []string           `json:"node_kinds"`
+	RelationshipKinds     []string           `json:"relationship_kinds"`
+}
+
+func (s *migrationCheckpoint) addNodeKinds(kinds graph.Kinds) {
+	for _, kind := range kinds {
+		if !slices.Contains(s.NodeKinds, kind.String()) {
+			s.NodeKinds = append(s.NodeKinds, kind.String())
+		}
+	}
+}
+
+func (s *migrationCheckpoint) addRelationshipKind(kind graph.Kind) {
+	if !slices.Contains(s.RelationshipKinds, kind.String()) {
+		s.RelationshipKinds = append(s.RelationshipKinds, kind.String())
+	}
+}
+
+// nodeIDMappings is an append-only file of source to target node ID pairs. Pairs are written in ascending order of
+// source ID so that lookups can binary search the file rather than holding every mapping in memory.
+type nodeIDMappings struct {
+	file  *os.File
+	count int64
+}
+
+func openNodeIDMappings(path string) (*nodeIDMappings, error) {
+	if file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600); err != nil {
+		return nil, err
+	} else if info, err := file.Stat(); err != nil {
+		file.Close()
+		return nil, err
+	} else {
+		return &nodeIDMappings{
+			file:  file,
+			count: info.Size() / nodeIDMappingRecordSize,
+		}, nil
+	}
+}
+
+// Truncate discards all mappings after the first count mappings. Mappings may be written ahead of a page of nodes
+// that failed to commit and must be discarded before the page is migrated again.
+func (s *nodeIDMappings) Truncate(count int64) error {
+	if count > s.count {
+		return fmt.Errorf("node ID mappings file contains %d mappings but %d were checkpointed", s.count, count)
+	} else if err := s.file.Truncate(count * nodeIDMappingRecordSize); err != nil {
+		return err
+	}
+
+	s.count = count
+	return nil
+}
+
+// Append writes the given source and target ID pairs to the end of the file and syncs them to disk
+func (s *nodeIDMappings) Append(pairs [][2]graph.ID) error {
+	buffer := make([]byte, len(pairs)*nodeIDMappingRecordSize)
+
+	for idx, pair := range pairs {
+		binary.BigEndian.PutUint64(buffer[idx*nodeIDMappingRecordSize:], pair[0].Uint64())
+		binary.BigEndian.PutUint64(buffer[idx*nodeIDMappingRecordSize+8:], pair[1].Uint64())
+	}
+
+	if _, err := s.file.WriteAt(buffer, s.count*nodeIDMappingRecordSize); err != nil {
+		return err
+	} else if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	s.count += int64(len(pairs))
+	return nil
+}
+
+// At returns the mapping at the given index
+func (s *nodeIDMappings) At(index int64) (graph.ID, graph.ID, error) {
+	record := make([]byte, nodeIDMappingRecordSize)
+
+	if _, err := s.file.ReadAt(record, index*nodeIDMappingRecordSize); err != nil {
+		return 0, 0, err
+	}
+
+	return graph.ID(binary.BigEndian.Uint64(record)), graph.ID(binary.BigEndian.Uint64(record[8:])), nil
+}
+
+// Lookup returns the target ID of the given source node ID
+func (s *nodeIDMappings) Lookup(sourceID graph.ID) (graph.ID, error) {
+	var (
+		lookupErr error
+		index     = sort.Search(int(s.count), func(idx int) bool {
+			if lookupErr != nil {
+				return true
+			}
+
+			nextSourceID, _, err := s.At(int64(idx))
+			lookupErr = err
+
+			return nextSourceID >= sourceID
+		})
+	)
+
+	if lookupErr != nil {
+		return 0, lookupErr
+	} else if int64(index) >= s.count {
+		return 0, ErrNodeIDMappingNotFound
+	} else if nextSourceID, targetID, err := s.At(int64(index)); err != nil {
+		return 0, err
+	} else if nextSourceID != sourceID {
+		return 0, ErrNodeIDMappingNotFound
+	} else {
+		return targetID, nil
+	}
+}
+
+func (s *nodeIDMappings) Close() error {
+	return s.file.Close()
+}
+
+// MigrationProgress describes how far a migration has progressed
+type MigrationProgress struct {
+	Direction             MigrationDirection     `json:"direction"`
+	Phase                 string                 `json:"phase"`
+	NodesMigrated         int64                  `json:"nodes_migrated"`
+	RelationshipsMigrated int64                  `json:"relationships_migrated"`
+	Verification          *MigrationVerification `json:"verification,omitempty"`
+	Error                 string                 `json:"error,omitempty"`
+}
+
+// KindCountComparison compares the number of nodes or relationships of a kind in the source and target graphs
+type KindCountComparison struct {
+	Source int64 `json:"source"`
+	Target int64 `json:"target"`
+}
+
+// MigrationVerification is the outcome of comparing the source and target graphs once a migration has completed
+type MigrationVerification struct {
+	NodeKinds         map[string]KindCountComparison `json:"node_kinds"`
+	RelationshipKinds map[string]KindCountComparison `json:"relationship_kinds"`
+	SampledNodes      int                            `json:"sampled_nodes"`
+	Mismatches        []string                       `json:"mismatches"`
+}
+
+func (s MigrationVerification) Passed() bool {
+	return len(s.Mismatches) == 0
+}
+
+// graphMigration copies all nodes and relationships from a source graph database into a target graph database. The
+// migration may be canceled or interrupted at any point and resumed later from its last checkpoint.
+type graphMigration struct {
+	source       graph.Database
+	target       graph.Database
+	mappingsPath string
+	pageSize     int
+	sampleSize   int
+
+	progressLock sync.Mutex
+	progress     MigrationProgress
+}
+
+func newGraphMigration(direction MigrationDirection, source, target graph.Database, mappingsPath string) *graphMigration {
+	return &graphMigration{
+		source:       source,
+		target:       target,
+		mappingsPath: mappingsPath,
+		pageSize:     defaultMigrationPageSize,
+		sampleSize:   defaultMigrationSampleSize,
+		progress: MigrationProgress{
+			Direction: direction,
+			Phase:     "starting",
+		},
+	}
+}
+
+func (s *graphMigration) Progress() MigrationProgress {
+	s.progressLock.Lock()
+	defer s.progressLock.Unlock()
+
+	return s.progress
+}
+
+func (s *graphMigration) updateProgress(delegate func(progress *MigrationProgress)) {
+	s.progressLock.Lock()
+	defer s.progressLock.Unlock()
+
+	delegate(&s.progress)
+}
+
+func (s *graphMigration) reportCheckpoint(phase string, checkpoint migrationCheckpoint) {
+	s.updateProgress(func(progress *MigrationProgress) {
+		progress.Phase = phase
+		progress.NodesMigrated = checkpoint.NodesMigrated
+		progress.RelationshipsMigrated = checkpoint.RelationshipsMigrated
+	})
+}
+
+// Run migrates all remaining nodes and relationships and then verifies the result. The checkpoint and node ID
+// mappings are only removed once verification passes so that a failed verification may be inspected.
+func (s *graphMigration) Run(ctx context.Context) (MigrationVerification, error) {
+	checkpointNode, checkpoint, err := s.loadCheckpoint(ctx)
+	if err != nil {
+		return MigrationVerification{}, fmt.Errorf("failed loading migration checkpoint: %w", err)
+	}
+
+	mappings, err := openNodeIDMappings(s.mappingsPath)
+	if err != nil {
+		return MigrationVerification{}, fmt.Errorf("failed opening node ID mappings: %w", err)
+	}
+
+	defer mappings.Close()
+
+	if err := mappings.Truncate(checkpoint.NodesMigrated); err != nil {
+		return MigrationVerification{}, fmt.Errorf("failed restoring node ID mappings: %w", err)
+	}
+
+	if checkpoint.NodesMigrated > 0 || checkpoint.RelationshipsMigrated > 0 {
+		log.Infof("Resuming graph migration after %d nodes and %d relationships", checkpoint.NodesMigrated, checkpoint.RelationshipsMigrated)
+	}
+
+	s.reportCheckpoint("nodes", checkpoint)
+
+	if !checkpoint.NodesComplete {
+		if err := s.migrateNodes(ctx, checkpointNode, &checkpoint, mappings); err != nil {
+			return MigrationVerification{}, fmt.Errorf("failed migrating nodes: %w", err)
+		}
+	}
+
+	s.reportCheckpoint("relationships", checkpoint)
+
+	if !checkpoint.RelationshipsComplete {
+		if err := s.migrateRelationships(ctx, checkpointNode, &checkpoint, mappings); err != nil {
+			return MigrationVerification{}, fmt.Errorf("failed migrating relationships: %w", err)
+		}
+	}
+
+	s.reportCheckpoint("verifying", checkpoint)
+
+	verification, err := s.verify(ctx, checkpoint, mappings)
+	if err != nil {
+		return verification, fmt.Errorf("failed verifying migration: %w", err)
+	}
+
+	s.updateProgress(func(progress *MigrationProgress) {
+		progress.Verification = &verification
+	})
+
+	if verification.Passed() {
+		if err := s.target.WriteTransaction(ctx, func(tx graph.Transaction) error {
+			return tx.Nodes().Filter(query.Equals(query.NodeID(), checkpointNode.ID)).Delete()
+		}); err != nil {
+			return verification, fmt.Errorf("failed removing migration checkpoint: %w", err)
+		} else if err := os.Remove(s.mappingsPath); err != nil {
+			log.Errorf("Failed removing node ID mappings file %s: %v", s.mappingsPath, err)
+		}
+	}
+
+	s.reportCheckpoint("complete", checkpoint)
+	return verification, nil
+}
+
+// loadCheckpoint fetches the migration checkpoint from the target graph, creating it if the migration has not yet
+// started
+func (s *graphMigration) loadCheckpoint(ctx context.Context) (*graph.Node, migrationCheckpoint, error) {
+	var (
+		checkpointNode *graph.Node
+		checkpoint     = migrationCheckpoint{
+			Direction: s.progress.Direction,
+		}
+	)
+
+	err := s.target.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		if node, err := tx.Nodes().Filter(query.Kind(query.Node(), migrationCheckpointKind)).First(); err == nil {
+			if state, err := node.Properties.Get(migrationCheckpointStateProperty).String(); err != nil {
+				return err
+			} else if err := json.Unmarshal([]byte(state), &checkpoint); err != nil {
+				return err
+			} else if checkpoint.Direction != s.progress.Direction {
+				return ErrMigrationDirectionMismatch
+			}
+
+			checkpointNode = node
+			return nil
+		} else if !graph.IsErrNotFound(err) {
+			return err
+		} else if state, err := json.Marshal(checkpoint); err != nil {
+			return err
+		} else {
+			checkpointNode, err = tx.CreateNode(graph.NewProperties().Set(migrationCheckpointStateProperty, string(state)), migrationCheckpointKind)
+			return err
+		}
+	})
+
+	return checkpointNode, checkpoint, err
+}
+
+func saveCheckpoint(tx graph.Transaction, checkpointNode *graph.Node, checkpoint migrationCheckpoint) error {
+	if state, err := json.Marshal(checkpoint); err != nil {
+		return err
+	} else {
+		checkpointNode.Properties.Set(migrationCheckpointStateProperty, string(state))
+		return tx.UpdateNode(checkpointNode)
+	}
+}
+
+// fetchPages streams the source objects selected by the given fetch function to the write delegate in pages of up to
+// pageSize objects
+func fetchPages[T any](ctx context.Context, pageSize int, cursor graph.Cursor[T], write func(page []T) error) error {
+	page := make([]T, 0, pageSize)
+
+	for next := range cursor.Chan() {
+		if page = append(page, next); len(page) == pageSize {
+			if err := write(page); err != nil {
+				return err
+			}
+
+			page = page[:0]
+		}
+	}
+
+	if err := cursor.Error(); err != nil {
+		return err
+	} else if ctx.Err() != nil {
+		return ctx.Err()
+	} else if len(page) > 0 {
+		return write(page)
+	}
+
+	return nil
+}
+
+func (s *graphMigration) migrateNodes(ctx context.Context, checkpointNode *graph.Node, checkpoint *migrationCheckpoint, mappings *nodeIDMappings) error {
+	defer log.LogAndMeasure(log.LevelInfo, "Migrating graph nodes")()
+
+	writePage := func(page []*graph.Node) error {
+		next := *checkpoint
+		pairs := make([][2]graph.ID, 0, len(page))
+
+		if err := s.target.WriteTransaction(ctx, func(tx graph.Transaction) error {
+			for _, node := range page {
+				if err := convertNeo4jProperties(node.Properties); err != nil {
+					return fmt.Errorf("failed converting properties of node %d: %w", node.ID, err)
+				} else if created, err := tx.CreateNode(node.Properties, node.Kinds...); err != nil {
+					return err
+				} else {
+					pairs = append(pairs, [2]graph.ID{node.ID, created.ID})
+					next.addNodeKinds(node.Kinds)
+				}
+			}
+
+			next.LastNodeID = page[len(page)-1].ID
+			next.NodesMigrated += int64(len(page))
+
+			// Mappings are written ahead of the commit. Mappings of a page that fails to commit are discarded by the
+			// next run since they are beyond the checkpointed count.
+			if err := mappings.Append(pairs); err != nil {
+				return fmt.Errorf("failed writing node ID mappings: %w", err)
+			}
+
+			return saveCheckpoint(tx, checkpointNode, next)
+		}); err != nil {
+			return err
+		}
+
+		*checkpoint = next
+		s.reportCheckpoint("nodes", next)
+
+		return nil
+	}
+
+	if err := s.source.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		return tx.Nodes().Filter(query.And(
+			query.GreaterThan(query.NodeID(), checkpoint.LastNodeID),
+			query.Not(query.KindIn(query.Node(), excludedMigrationKinds()...)),
+		)).OrderBy(
+			query.Order(query.NodeID(), query.Ascending()),
+		).Fetch(func(cursor graph.Cursor[*graph.Node]) error {
+			return fetchPages(ctx, s.pageSize, cursor, writePage)
+		})
+	}); err != nil {
+		return err
+	}
+
+	checkpoint.NodesComplete = true
+
+	return s.target.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		return saveCheckpoint(tx, checkpointNode, *checkpoint)
+	})
+}
+
+func (s *graphMigration) migrateRelationships(ctx context.Context, checkpointNode *graph.Node, checkpoint *migrationCheckpoint, mappings *nodeIDMappings) error {
+	defer log.LogAndMeasure(log.LevelInfo, "Migrating graph relationships")()
+
+	writePage := func(page []*graph.Relationship) error {
+		next := *checkpoint
+
+		if err := s.target.WriteTransaction(ctx, func(tx graph.Transaction) error {
+			for _, relationship := range page {
+				if startID, err := mappings.Lookup(relationship.StartID); err != nil {
+					return fmt.Errorf("failed mapping start node %d of relationship %d: %w", relationship.StartID, relationship.ID, err)
+				} else if endID, err := mappings.Lookup(relationship.EndID); err != nil {
+					return fmt.Errorf("failed mapping end node %d of relationship %d: %w", relationship.EndID, relationship.ID, err)
+				} else if err := convertNeo4jProperties(relationship.Properties); err != nil {
+					return fmt.Errorf("failed converting properties of relationship %d: %w", relationship.ID, err)
+				} else if _, err := tx.CreateRelationshipByIDs(startID, endID, relationship.Kind, relationship.Properties); err != nil {
+					return err
+				}
+
+				next.addRelationshipKind(relationship.Kind)
+			}
+
+			next.LastRelationshipID = page[len(page)-1].ID
+			next.RelationshipsMigrated += int64(len(page))
+
+			return saveCheckpoint(tx, checkpointNode, next)
+		}); err != nil {
+			return err
+		}
+
+		*checkpoint = next
+		s.reportCheckpoint("relationships", next)
+
+		return nil
+	}
+
+	if err := s.source.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		return tx.Relationships().Filter(
+			query.GreaterThan(query.RelationshipID(), checkpoint.LastRelationshipID),
+		).OrderBy(
+			query.Order(query.RelationshipID(), query.Ascending()),
+		).Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+			return fetchPages(ctx, s.pageSize, cursor, writePage)
+		})
+	}); err != nil {
+		return err
+	}
+
+	checkpoint.RelationshipsComplete = true
+
+	return s.target.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		return saveCheckpoint(tx, checkpointNode, *checkpoint)
+	})
+}
+
+// verify compares the number of nodes and relationships of each migrated kind between the source and target graphs.
+// A sample of migrated nodes is then compared property by property along with their outbound relationships.
+func (s *graphMigration) verify(ctx context.Context, checkpoint migrationCheckpoint, mappings *nodeIDMappings) (MigrationVerification, error) {
+	defer log.LogAndMeasure(log.LevelInfo, "Verifying graph migration")()
+
+	verification := MigrationVerification{
+		NodeKinds:         map[string]KindCountComparison{},
+		RelationshipKinds: map[string]KindCountComparison{},
+		Mismatches:        []string{},
+	}
+
+	for _, kind := range checkpoint.NodeKinds {
+		if sourceCount, err := countNodes(ctx, s.source, graph.StringKind(kind)); err != nil {
+			return verification, err
+		} else if targetCount, err := countNodes(ctx, s.target, graph.StringKind(kind)); err != nil {
+			return verification, err
+		} else {
+			verification.NodeKinds[kind] = KindCountComparison{Source: sourceCount, Target: targetCount}
+
+			if sourceCount != targetCount {
+				verification.Mismatches = append(verification.Mismatches, fmt.Sprintf("node kind %s has %d nodes in the source graph but %d in the target graph", kind, sourceCount, targetCount))
+			}
+		}
+	}
+
+	for _, kind := range checkpoint.RelationshipKinds {
+		if sourceCount, err := countRelationships(ctx, s.source, graph.StringKind(kind)); err != nil {
+			return verification, err
+		} else if targetCount, err := countRelationships(ctx, s.target, graph.StringKind(kind)); err != nil {
+			return verification, err
+		} else {
+			verification.RelationshipKinds[kind] = KindCountComparison{Source: sourceCount, Target: targetCount}
+
+			if sourceCount != targetCount {
+				verification.Mismatches = append(verification.Mismatches, fmt.Sprintf("relationship kind %s has %d relationships in the source graph but %d in the target graph", kind, sourceCount, targetCount))
+			}
+		}
+	}
+
+	sampleSize := min(int64(s.sampleSize), mappings.count)
+
+	for sample := int64(0); sample < sampleSize; sample++ {
+		if sourceID, targetID, err := mappings.At(sample * mappings.count / sampleSize); err != nil {
+			return verification, err
+		} else if sourceHash, err := hashNodeWithRelationships(ctx, s.source, sourceID, mappings.Lookup); err != nil {
+			return verification, fmt.Errorf("failed hashing source node %d: %w", sourceID, err)
+		} else if targetHash, err := hashNodeWithRelationships(ctx, s.target, targetID, nil); err != nil {
+			return verification, fmt.Errorf("failed hashing target node %d: %w", targetID, err)
+		} else {
+			verification.SampledNodes++
+
+			if sourceHash != targetHash {
+				verification.Mismatches = append(verification.Mismatches, fmt.Sprintf("source node %d does not match target node %d", sourceID, targetID))
+			}
+		}
+	}
+
+	return verification, nil
+}
+
+func countNodes(ctx context.Context, db graph.Database, kind graph.Kind) (int64, error) {
+	var count int64
+
+	return count, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		var err error
+		count, err = tx.Nodes().Filter(query.Kind(query.Node(), kind)).Count()
+		return err
+	})
+}
+
+func countRelationships(ctx context.Context, db graph.Database, kind graph.Kind) (int64, error) {
+	var count int64
+
+	return count, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		var err error
+		count, err = tx.Relationships().Filter(query.Kind(query.Relationship(), kind)).Count()
+		return err
+	})
+}
+
+// hashNodeWithRelationships hashes the kinds and properties of a node along with the kind, end node and properties of
+// each of its outbound relationships. End node IDs are translated with the given mapping function when set so that
+// hashes of source nodes are comparable with hashes of their target nodes.
+func hashNodeWithRelationships(ctx context.Context, db graph.Database, nodeID graph.ID, mapID func(id graph.ID) (graph.ID, error)) (string, error) {
+	digest := sha256.New()
+
+	err := db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if node, err := tx.Nodes().Filter(query.Equals(query.NodeID(), nodeID)).First(); err != nil {
+			return err
+		} else if err := hashEntity(digest, node.Kinds.Strings(), node.Properties); err != nil {
+			return err
+		}
+
+		var relationshipHashes []string
+
+		if err := tx.Relationships().Filter(query.Equals(query.StartID(), nodeID)).Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+			for relationship := range cursor.Chan() {
+				var (
+					endID              = relationship.EndID
+					relationshipDigest = sha256.New()
+				)
+
+				if mapID != nil {
+					if mappedID, err := mapID(endID); err != nil {
+						return err
+					} else {
+						endID = mappedID
+					}
+				}
+
+				if err := hashEntity(relationshipDigest, []string{relationship.Kind.String(), endID.String()}, relationship.Properties); err != nil {
+					return err
+				}
+
+				relationshipHashes = append(relationshipHashes, hex.EncodeToString(relationshipDigest.Sum(nil)))
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return err
+		}
+
+		slices.Sort(relationshipHashes)
+		_, err := io.WriteString(digest, strings.Join(relationshipHashes, ","))
+
+		return err
+	})
+
+	return hex.EncodeToString(digest.Sum(nil)), err
+}
+
+func hashEntity(writer io.Writer, labels []string, properties *graph.Properties) error {
+	if err := convertNeo4jProperties(properties); err != nil {
+		return err
+	}
+
+	slices.Sort(labels)
+
+	normalized := make(map[string]any, len(properties.Map))
+
+	for key, value := range properties.MapOrEmpty() {
+		normalized[key] = normalizePropertyValue(value)
+	}
+
+	if content, err := json.Marshal([]any{labels, normalized}); err != nil {
+		return err
+	} else {
+		_, err := writer.Write(content)
+		return err
+	}
+}
+
+// normalizePropertyValue converts property values into a common representation since drivers return the same stored
+// value as different types. Times are compared as UTC timestamps, numbers as float64 values and lists element-wise.
+func normalizePropertyValue(value any) any {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil
+
+	case time.Time:
+		return typedValue.UTC().Format(time.RFC3339Nano)
+
+	case string:
+		if parsed, err := time.Parse(time.RFC3339Nano, typedValue); err == nil {
+			return parsed.UTC().Format(time.RFC3339Nano)
+		}
+
+		return typedValue
+
+	case bool:
+		return typedValue
+
+	case dbtype.Date, dbtype.Time, dbtype.LocalTime, dbtype.LocalDateTime:
+		return fmt.Sprint(typedValue)
+	}
+
+	reflected := reflect.ValueOf(value)
+
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflected.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflected.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float()
+
+	case reflect.Slice, reflect.Array:
+		normalized := make([]any, reflected.Len())
+
+		for idx := range normalized {
+			normalized[idx] = normalizePropertyValue(reflected.Index(idx).Interface())
+		}
+
+		return normalized
+
+	default:
+		return fmt.Sprint(value)
+	}
+}
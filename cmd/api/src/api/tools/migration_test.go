@@ -0,0 +1,261 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errWriteLimit = errors.New("write limit reached")
+
+// writeLimitedDatabase fails every write transaction after the first limit write transactions
+type writeLimitedDatabase struct {
+	graph.Database
+
+	limit  int
+	writes int
+}
+
+func (s *writeLimitedDatabase) WriteTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+	if s.writes++; s.writes > s.limit {
+		return errWriteLimit
+	}
+
+	return s.Database.WriteTransaction(ctx, txDelegate, options...)
+}
+
+func newMigrationSourceGraph(t *testing.T) graph.Database {
+	db := memory.NewDatabase(0)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		// Graph schema migration data is managed by each database and must not be migrated
+		if _, err := tx.CreateNode(graph.AsProperties(map[string]any{"version": "v6.4.0"}), common.MigrationData); err != nil {
+			return err
+		}
+
+		var nodes []*graph.Node
+
+		for idx, kind := range []graph.Kind{ad.User, ad.User, ad.Group, ad.Computer, ad.Domain} {
+			if node, err := tx.CreateNode(graph.AsProperties(map[string]any{
+				common.ObjectID.String(): "S-1-5-21-" + string(rune('A'+idx)),
+				common.Name.String():     kind.String() + string(rune('A'+idx)),
+				"count":                  idx,
+				"tags":                   []string{"a", "b"},
+				common.LastSeen.String(): time.Date(2024, 1, idx+1, 0, 0, 0, 0, time.UTC),
+			}), ad.Entity, kind); err != nil {
+				return err
+			} else {
+				nodes = append(nodes, node)
+			}
+		}
+
+		for _, relationship := range []struct {
+			start, end int
+			kind       graph.Kind
+		}{
+			{0, 2, ad.MemberOf},
+			{1, 2, ad.MemberOf},
+			{2, 3, ad.AdminTo},
+			{0, 3, ad.HasSession},
+			{3, 4, ad.DCFor},
+		} {
+			if _, err := tx.CreateRelationshipByIDs(nodes[relationship.start].ID, nodes[relationship.end].ID, relationship.kind, graph.AsProperties(map[string]any{
+				"isacl": relationship.kind == ad.AdminTo,
+			})); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	return db
+}
+
+func countGraph(t *testing.T, db graph.Database) (int64, int64) {
+	var nodes, relationships int64
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		var err error
+
+		if nodes, err = tx.Nodes().Filter(query.Kind(query.Node(), ad.Entity)).Count(); err != nil {
+			return err
+		}
+
+		relationships, err = tx.Relationships().Count()
+		return err
+	}))
+
+	return nodes, relationships
+}
+
+func countCheckpoints(t *testing.T, db graph.Database) int64 {
+	var count int64
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		var err error
+		count, err = tx.Nodes().Filter(query.Kind(query.Node(), migrationCheckpointKind)).Count()
+		return err
+	}))
+
+	return count
+}
+
+func TestGraphMigration_Run(t *testing.T) {
+	var (
+		source       = newMigrationSourceGraph(t)
+		target       = memory.NewDatabase(0)
+		mappingsPath = filepath.Join(t.TempDir(), "mappings")
+		migration    = newGraphMigration(MigrationDirectionPGToNeo4j, source, target, mappingsPath)
+	)
+
+	migration.pageSize = 2
+
+	verification, err := migration.Run(context.Background())
+	require.Nil(t, err)
+
+	assert.True(t, verification.Passed(), verification.Mismatches)
+	assert.Equal(t, 5, verification.SampledNodes)
+	assert.Equal(t, KindCountComparison{Source: 2, Target: 2}, verification.NodeKinds[ad.User.String()])
+	assert.Equal(t, KindCountComparison{Source: 2, Target: 2}, verification.RelationshipKinds[ad.MemberOf.String()])
+
+	nodes, relationships := countGraph(t, target)
+	assert.Equal(t, int64(5), nodes)
+	assert.Equal(t, int64(5), relationships)
+	assert.Equal(t, int64(0), countCheckpoints(t, target))
+
+	progress := migration.Progress()
+	assert.Equal(t, "complete", progress.Phase)
+	assert.Equal(t, int64(5), progress.NodesMigrated)
+	assert.Equal(t, int64(5), progress.RelationshipsMigrated)
+
+	_, err = os.Stat(mappingsPath)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestGraphMigration_Resume(t *testing.T) {
+	var (
+		source       = newMigrationSourceGraph(t)
+		target       = memory.NewDatabase(0)
+		mappingsPath = filepath.Join(t.TempDir(), "mappings")
+	)
+
+	// Allow the checkpoint to be created along with two pages of nodes before failing
+	interrupted := newGraphMigration(MigrationDirectionNeo4jToPG, source, &writeLimitedDatabase{Database: target, limit: 3}, mappingsPath)
+	interrupted.pageSize = 2
+
+	_, err := interrupted.Run(context.Background())
+	require.ErrorIs(t, err, errWriteLimit)
+
+	nodes, _ := countGraph(t, target)
+	assert.Equal(t, int64(4), nodes)
+	assert.Equal(t, int64(1), countCheckpoints(t, target))
+
+	// Resuming in the opposite direction must be refused
+	_, err = newGraphMigration(MigrationDirectionPGToNeo4j, source, target, mappingsPath).Run(context.Background())
+	require.ErrorIs(t, err, ErrMigrationDirectionMismatch)
+
+	resumed := newGraphMigration(MigrationDirectionNeo4jToPG, source, target, mappingsPath)
+	resumed.pageSize = 2
+
+	verification, err := resumed.Run(context.Background())
+	require.Nil(t, err)
+	assert.True(t, verification.Passed(), verification.Mismatches)
+
+	nodes, relationships := countGraph(t, target)
+	assert.Equal(t, int64(5), nodes)
+	assert.Equal(t, int64(5), relationships)
+	assert.Equal(t, int64(0), countCheckpoints(t, target))
+}
+
+func TestGraphMigration_VerificationMismatch(t *testing.T) {
+	var (
+		source       = newMigrationSourceGraph(t)
+		target       = memory.NewDatabase(0)
+		mappingsPath = filepath.Join(t.TempDir(), "mappings")
+	)
+
+	require.Nil(t, target.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		_, err := tx.CreateNode(graph.AsProperties(map[string]any{common.Name.String(): "stray"}), ad.Entity, ad.User)
+		return err
+	}))
+
+	verification, err := newGraphMigration(MigrationDirectionNeo4jToPG, source, target, mappingsPath).Run(context.Background())
+	require.Nil(t, err)
+
+	assert.False(t, verification.Passed())
+	assert.Equal(t, KindCountComparison{Source: 2, Target: 3}, verification.NodeKinds[ad.User.String()])
+	assert.Equal(t, KindCountComparison{Source: 5, Target: 6}, verification.NodeKinds[ad.Entity.String()])
+	assert.Len(t, verification.Mismatches, 2)
+
+	// The checkpoint and node ID mappings are retained for inspection when verification fails
+	assert.Equal(t, int64(1), countCheckpoints(t, target))
+
+	_, err = os.Stat(mappingsPath)
+	assert.Nil(t, err)
+}
+
+func TestNodeIDMappings(t *testing.T) {
+	mappings, err := openNodeIDMappings(filepath.Join(t.TempDir(), "mappings"))
+	require.Nil(t, err)
+
+	defer mappings.Close()
+
+	require.Nil(t, mappings.Append([][2]graph.ID{{3, 100}, {7, 101}, {12, 102}}))
+	require.Nil(t, mappings.Append([][2]graph.ID{{20, 103}}))
+
+	targetID, err := mappings.Lookup(12)
+	require.Nil(t, err)
+	assert.Equal(t, graph.ID(102), targetID)
+
+	targetID, err = mappings.Lookup(20)
+	require.Nil(t, err)
+	assert.Equal(t, graph.ID(103), targetID)
+
+	_, err = mappings.Lookup(8)
+	assert.ErrorIs(t, err, ErrNodeIDMappingNotFound)
+
+	_, err = mappings.Lookup(21)
+	assert.ErrorIs(t, err, ErrNodeIDMappingNotFound)
+
+	require.Nil(t, mappings.Truncate(2))
+
+	_, err = mappings.Lookup(12)
+	assert.ErrorIs(t, err, ErrNodeIDMappingNotFound)
+	assert.NotNil(t, mappings.Truncate(3))
+}
+
+func TestNormalizePropertyValue(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+
+	assert.Equal(t, normalizePropertyValue(timestamp), normalizePropertyValue(timestamp.UTC().Format(time.RFC3339Nano)))
+	assert.Equal(t, normalizePropertyValue(int64(5)), normalizePropertyValue(float64(5)))
+	assert.Equal(t, normalizePropertyValue([]string{"a", "b"}), normalizePropertyValue([]any{"a", "b"}))
+}
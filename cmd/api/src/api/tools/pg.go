@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"sync"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
@@ -41,51 +42,6 @@ const (
 	stateCanceling MigratorState = "canceling"
 )
 
-func migrateTypes(ctx context.Context, neoDB, pgDB graph.Database) error {
-	defer log.LogAndMeasure(log.LevelInfo, "Migrating kinds from Neo4j to PostgreSQL")()
-
-	var (
-		neoNodeKinds graph.Kinds
-		neoEdgeKinds graph.Kinds
-	)
-
-	if err := neoDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
-		var (
-			nextKindStr string
-			result      = tx.Raw("call db.labels();", nil)
-		)
-
-		for result.Next() {
-			if err := result.Scan(&nextKindStr); err != nil {
-				return err
-			}
-
-			neoNodeKinds = append(neoNodeKinds, graph.StringKind(nextKindStr))
-		}
-
-		if err := result.Error(); err != nil {
-			return err
-		}
-
-		result = tx.Raw("call db.relationshipTypes();", nil)
-
-		for result.Next() {
-			if err := result.Scan(&nextKindStr); err != nil {
-				return err
-			}
-
-			neoEdgeKinds = append(neoEdgeKinds, graph.StringKind(nextKindStr))
-		}
-
-		return nil
-	}); err != nil {
-		return err
-	}
-
-	_, err := pgDB.(*pg.Driver).KindMapper().AssertKinds(ctx, append(neoNodeKinds, neoEdgeKinds...))
-	return err
-}
-
 func convertNeo4jProperties(properties *graph.Properties) error {
 	for key, propertyValue := range properties.Map {
 		switch typedPropertyValue := propertyValue.(type) {
@@ -109,86 +65,13 @@ func convertNeo4jProperties(properties *graph.Properties) error {
 	return nil
 }
 
-func migrateNodes(ctx context.Context, neoDB, pgDB graph.Database) (map[graph.ID]graph.ID, error) {
-	defer log.LogAndMeasure(log.LevelInfo, "Migrating nodes from Neo4j to PostgreSQL")()
-
-	var (
-		// Start at 2 and assume that the first node of the graph is the graph schema migration information
-		nextNodeID     = graph.ID(2)
-		nodeIDMappings = map[graph.ID]graph.ID{}
-	)
-
-	if err := neoDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
-		return tx.Nodes().Fetch(func(cursor graph.Cursor[*graph.Node]) error {
-			if err := pgDB.BatchOperation(ctx, func(tx graph.Batch) error {
-				for next := range cursor.Chan() {
-					if err := convertNeo4jProperties(next.Properties); err != nil {
-						return err
-					}
-
-					if err := tx.CreateNode(graph.NewNode(nextNodeID, next.Properties, next.Kinds...)); err != nil {
-						return err
-					} else {
-						nodeIDMappings[next.ID] = nextNodeID
-						nextNodeID++
-					}
-				}
-
-				return nil
-			}); err != nil {
-				return err
-			}
-
-			return cursor.Error()
-		})
-	}); err != nil {
-		return nil, err
-	}
-
-	return nodeIDMappings, pgDB.Run(ctx, fmt.Sprintf(`alter sequence node_id_seq restart with %d`, nextNodeID), nil)
-}
-
-func migrateEdges(ctx context.Context, neoDB, pgDB graph.Database, nodeIDMappings map[graph.ID]graph.ID) error {
-	defer log.LogAndMeasure(log.LevelInfo, "Migrating edges from Neo4j to PostgreSQL")()
-
-	return neoDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
-		return tx.Relationships().Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
-			if err := pgDB.BatchOperation(ctx, func(tx graph.Batch) error {
-				for next := range cursor.Chan() {
-					var (
-						pgStartID = nodeIDMappings[next.StartID]
-						pgEndID   = nodeIDMappings[next.EndID]
-					)
-
-					if err := convertNeo4jProperties(next.Properties); err != nil {
-						return err
-					}
-
-					if err := tx.CreateRelationship(&graph.Relationship{
-						StartID:    pgStartID,
-						EndID:      pgEndID,
-						Kind:       next.Kind,
-						Properties: next.Properties,
-					}); err != nil {
-						return err
-					}
-				}
-
-				return nil
-			}); err != nil {
-				return err
-			}
-
-			return cursor.Error()
-		})
-	})
-}
-
 type PGMigrator struct {
 	graphSchema         graph.Schema
 	graphDBSwitch       *graph.DatabaseSwitch
 	serverCtx           context.Context
 	migrationCancelFunc func()
+	migration           *graphMigration
+	lastProgress        *MigrationProgress
 	state               MigratorState
 	lock                *sync.Mutex
 	cfg                 config.Configuration
@@ -268,42 +151,98 @@ func (s *PGMigrator) SwitchNeo4j(response http.ResponseWriter, request *http.Req
 	}
 }
 
-func (s *PGMigrator) startMigration() error {
+func (s *PGMigrator) openGraphDatabase(driverName string) (graph.Database, error) {
+	switch driverName {
+	case neo4j.DriverName:
+		if neo4jDB, err := dawgs.Open(s.serverCtx, neo4j.DriverName, dawgs.Config{
+			GraphQueryMemoryLimit: size.Gibibyte,
+			DriverCfg:             s.cfg.Neo4J.Neo4jConnectionString(),
+		}); err != nil {
+			return nil, fmt.Errorf("failed connecting to Neo4j: %w", err)
+		} else {
+			return neo4jDB, nil
+		}
+
+	default:
+		if pgDB, err := dawgs.Open(s.serverCtx, pg.DriverName, dawgs.Config{
+			GraphQueryMemoryLimit: size.Gibibyte,
+			DriverCfg:             s.cfg.Database.PostgreSQLConnectionString(),
+		}); err != nil {
+			return nil, fmt.Errorf("failed connecting to PostgreSQL: %w", err)
+		} else {
+			return pgDB, nil
+		}
+	}
+}
+
+func (s *PGMigrator) openMigrationDatabases(direction MigrationDirection) (graph.Database, graph.Database, error) {
+	sourceDriver, targetDriver := neo4j.DriverName, pg.DriverName
+
+	if direction == MigrationDirectionPGToNeo4j {
+		sourceDriver, targetDriver = pg.DriverName, neo4j.DriverName
+	}
+
+	if sourceDB, err := s.openGraphDatabase(sourceDriver); err != nil {
+		return nil, nil, err
+	} else if targetDB, err := s.openGraphDatabase(targetDriver); err != nil {
+		sourceDB.Close(s.serverCtx)
+		return nil, nil, err
+	} else {
+		return sourceDB, targetDB, nil
+	}
+}
+
+func (s *PGMigrator) startMigration(direction MigrationDirection) error {
 	if err := s.advanceState(stateMigrating, stateIdle); err != nil {
 		return fmt.Errorf("database migration state error: %w", err)
-	} else if neo4jDB, err := dawgs.Open(s.serverCtx, neo4j.DriverName, dawgs.Config{
-		GraphQueryMemoryLimit: size.Gibibyte,
-		DriverCfg:             s.cfg.Neo4J.Neo4jConnectionString(),
-	}); err != nil {
-		return fmt.Errorf("failed connecting to Neo4j: %w", err)
-	} else if pgDB, err := dawgs.Open(s.serverCtx, pg.DriverName, dawgs.Config{
-		GraphQueryMemoryLimit: size.Gibibyte,
-		DriverCfg:             s.cfg.Database.PostgreSQLConnectionString(),
-	}); err != nil {
-		return fmt.Errorf("failed connecting to PostgreSQL: %w", err)
+	} else if sourceDB, targetDB, err := s.openMigrationDatabases(direction); err != nil {
+		if err := s.advanceState(stateIdle, stateMigrating); err != nil {
+			log.Errorf("Database migration state management error: %v", err)
+		}
+
+		return err
 	} else {
-		log.Infof("Dispatching live migration from Neo4j to PostgreSQL")
+		log.Infof("Dispatching live graph migration %s", direction)
 
 		migrationCtx, migrationCancelFunc := context.WithCancel(s.serverCtx)
+		migration := newGraphMigration(direction, sourceDB, targetDB, filepath.Join(s.cfg.WorkDir, fmt.Sprintf("graph-migration-%s.ids", direction)))
+
+		s.lock.Lock()
 		s.migrationCancelFunc = migrationCancelFunc
+		s.migration = migration
+		s.lock.Unlock()
 
 		go func(ctx context.Context) {
-			defer migrationCancelFunc()
-
-			log.Infof("Starting live migration from Neo4j to PostgreSQL")
-
-			if err := pgDB.AssertSchema(ctx, s.graphSchema); err != nil {
-				log.Errorf("Unable to assert graph schema in PostgreSQL: %v", err)
-			} else if err := migrateTypes(ctx, neo4jDB, pgDB); err != nil {
-				log.Errorf("Unable to migrate Neo4j kinds to PostgreSQL: %v", err)
-			} else if nodeIDMappings, err := migrateNodes(ctx, neo4jDB, pgDB); err != nil {
-				log.Errorf("Failed importing nodes into PostgreSQL: %v", err)
-			} else if err := migrateEdges(ctx, neo4jDB, pgDB, nodeIDMappings); err != nil {
-				log.Errorf("Failed importing edges into PostgreSQL: %v", err)
+			defer func() {
+				migrationCancelFunc()
+				sourceDB.Close(s.serverCtx)
+				targetDB.Close(s.serverCtx)
+			}()
+
+			log.Infof("Starting live graph migration %s", direction)
+
+			if err := targetDB.AssertSchema(ctx, s.graphSchema); err != nil {
+				log.Errorf("Unable to assert graph schema in migration target: %v", err)
+				migration.updateProgress(func(progress *MigrationProgress) {
+					progress.Error = err.Error()
+				})
+			} else if verification, err := migration.Run(ctx); err != nil {
+				log.Errorf("Graph migration %s failed: %v", direction, err)
+				migration.updateProgress(func(progress *MigrationProgress) {
+					progress.Error = err.Error()
+				})
+			} else if !verification.Passed() {
+				log.Errorf("Graph migration %s completed but failed verification with %d mismatches", direction, len(verification.Mismatches))
 			} else {
-				log.Infof("Migration to PostgreSQL completed successfully")
+				log.Infof("Graph migration %s completed and verified successfully", direction)
 			}
 
+			s.lock.Lock()
+			progress := migration.Progress()
+			s.lastProgress = &progress
+			s.migration = nil
+			s.lock.Unlock()
+
 			if err := s.advanceState(stateIdle, stateMigrating, stateCanceling); err != nil {
 				log.Errorf("Database migration state management error: %v", err)
 			}
@@ -314,7 +253,17 @@ func (s *PGMigrator) startMigration() error {
 }
 
 func (s *PGMigrator) MigrationStart(response http.ResponseWriter, request *http.Request) {
-	if err := s.startMigration(); err != nil {
+	direction := MigrationDirectionNeo4jToPG
+
+	if directionParam := request.URL.Query().Get("direction"); directionParam != "" {
+		direction = MigrationDirection(directionParam)
+	}
+
+	if !direction.IsValid() {
+		api.WriteJSONResponse(request.Context(), map[string]any{
+			"error": fmt.Sprintf("invalid migration direction %s, expected one of: %s, %s", direction, MigrationDirectionNeo4jToPG, MigrationDirectionPGToNeo4j),
+		}, http.StatusBadRequest, response)
+	} else if err := s.startMigration(direction); err != nil {
 		api.WriteJSONResponse(request.Context(), map[string]any{
 			"error": err.Error(),
 		}, http.StatusInternalServerError, response)
@@ -344,7 +293,17 @@ func (s *PGMigrator) MigrationCancel(response http.ResponseWriter, request *http
 }
 
 func (s *PGMigrator) MigrationStatus(response http.ResponseWriter, request *http.Request) {
-	api.WriteJSONResponse(request.Context(), map[string]any{
+	s.lock.Lock()
+	status := map[string]any{
 		"state": s.state,
-	}, http.StatusOK, response)
+	}
+
+	if s.migration != nil {
+		status["progress"] = s.migration.Progress()
+	} else if s.lastProgress != nil {
+		status["progress"] = *s.lastProgress
+	}
+	s.lock.Unlock()
+
+	api.WriteJSONResponse(request.Context(), status, http.StatusOK, response)
 }
@@ -20,11 +20,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 
+	iso8601 "github.com/channelmeter/iso8601duration"
+	"github.com/specterops/bloodhound/analysis"
 	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/ops"
 	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/params"
 	"github.com/specterops/bloodhound/src/api"
 	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/model/appcfg"
@@ -56,8 +61,43 @@ func (s *Resources) handleAdRelatedEntityQuery(response http.ResponseWriter, req
 	}
 }
 
+// parseSessionFilterParams builds a session filter from the optional session_max_age and session_source query parameters
+func parseSessionFilterParams(queryParams url.Values) (adAnalysis.SessionFilter, error) {
+	var (
+		maxAge        time.Duration
+		maxAgeParam   = queryParams.Get(params.SessionMaxAge.String())
+		sessionSource = queryParams.Get(params.SessionSource.String())
+	)
+
+	if maxAgeParam != "" {
+		if duration, err := iso8601.FromString(maxAgeParam); err != nil {
+			return adAnalysis.SessionFilter{}, fmt.Errorf("invalid %s: %w", params.SessionMaxAge, err)
+		} else {
+			maxAge = duration.ToDuration()
+		}
+	}
+
+	return adAnalysis.NewSessionFilter(maxAge, sessionSource)
+}
+
+// handleAdSessionQuery serves the session endpoints, narrowing the results to the requested session age and source
+// when either is given
+func (s *Resources) handleAdSessionQuery(response http.ResponseWriter, request *http.Request, queryName string, pathDelegate any, listDelegate any, createPathDelegate func(adAnalysis.SessionFilter) analysis.PathDelegate, createListDelegate func(adAnalysis.SessionFilter) analysis.ListDelegate) {
+	queryParams := request.URL.Query()
+
+	if filter, err := parseSessionFilterParams(queryParams); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(api.FmtErrorResponseDetailsBadQueryParameters, err), request), response)
+	} else if filter.IsEmpty() {
+		s.handleAdRelatedEntityQuery(response, request, queryName, pathDelegate, listDelegate)
+	} else {
+		// The filter parameters are folded into the query name so that filtered results are cached separately
+		filteredQueryName := fmt.Sprintf("%s_%s_%s", queryName, queryParams.Get(params.SessionMaxAge.String()), queryParams.Get(params.SessionSource.String()))
+		s.handleAdRelatedEntityQuery(response, request, filteredQueryName, createPathDelegate(filter), createListDelegate(filter))
+	}
+}
+
 func (s *Resources) ListADUserSessions(response http.ResponseWriter, request *http.Request) {
-	s.handleAdRelatedEntityQuery(response, request, "ListADUserSessions", adAnalysis.FetchUserSessionPaths, adAnalysis.FetchUserSessions)
+	s.handleAdSessionQuery(response, request, "ListADUserSessions", adAnalysis.FetchUserSessionPaths, adAnalysis.FetchUserSessions, adAnalysis.CreateUserSessionPathDelegate, adAnalysis.CreateUserSessionListDelegate)
 }
 
 func (s *Resources) ListADUserSQLAdminRights(response http.ResponseWriter, request *http.Request) {
@@ -69,7 +109,7 @@ func (s *Resources) ListADGroupSessions(response http.ResponseWriter, request *h
 }
 
 func (s *Resources) ListADComputerSessions(response http.ResponseWriter, request *http.Request) {
-	s.handleAdRelatedEntityQuery(response, request, "ListADComputerSessions", adAnalysis.FetchComputerSessionPaths, adAnalysis.FetchComputerSessions)
+	s.handleAdSessionQuery(response, request, "ListADComputerSessions", adAnalysis.FetchComputerSessionPaths, adAnalysis.FetchComputerSessions, adAnalysis.CreateComputerSessionPathDelegate, adAnalysis.CreateComputerSessionListDelegate)
 }
 
 func (s *Resources) ListADComputerAdmins(response http.ResponseWriter, request *http.Request) {
@@ -25,6 +25,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/specterops/bloodhound/analysis"
@@ -262,8 +263,11 @@ func (s Resources) UpdateAssetGroupSelectors(response http.ResponseWriter, reque
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
 	} else {
 		for _, selectorSpec := range selectorSpecs {
-			if err := selectorSpec.Validate(); err != nil {
+			if selectorSpec.Action != model.SelectorSpecActionAdd {
+				continue
+			} else if _, err := s.newAssetGroupSelector(assetGroup, selectorSpec); err != nil {
 				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+				return
 			}
 		}
 
@@ -295,6 +299,71 @@ func (s Resources) UpdateAssetGroupSelectors(response http.ResponseWriter, reque
 	}
 }
 
+// newAssetGroupSelector validates the given selector spec and returns the selector that it describes. The cypher query
+// of a cypher selector is prepared to ensure that it is valid and read-only.
+func (s Resources) newAssetGroupSelector(assetGroup model.AssetGroup, selectorSpec model.AssetGroupSelectorSpec) (model.AssetGroupSelector, error) {
+	selector := selectorSpec.AssetGroupSelector(assetGroup.ID, false)
+
+	if err := selectorSpec.Validate(); err != nil {
+		return selector, err
+	} else if selector.SelectorType() == model.AssetGroupSelectorTypeCypher {
+		if _, _, err := s.GraphQuery.PrepareAssetGroupSelectorQuery(selector); err != nil {
+			return selector, err
+		}
+	}
+
+	return selector, nil
+}
+
+// PreviewAssetGroupSelector evaluates a selector against the graph without saving it so that the nodes it selects can
+// be reviewed before the selector is added to the asset group
+func (s Resources) PreviewAssetGroupSelector(response http.ResponseWriter, request *http.Request) {
+	var (
+		pathVars        = mux.Vars(request)
+		rawAssetGroupID = pathVars[api.URIPathVariableAssetGroupID]
+		queryParams     = request.URL.Query()
+		selectorSpec    model.AssetGroupSelectorSpec
+	)
+
+	if assetGroupID, err := strconv.Atoi(rawAssetGroupID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+	} else if limit, err := ParseLimitQueryParameter(queryParams, 100); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+	} else if assetGroup, err := s.DB.GetAssetGroup(request.Context(), int32(assetGroupID)); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := api.ReadJSONRequestPayloadLimited(&selectorSpec, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else {
+		// a preview always evaluates the selector as if it were being added
+		selectorSpec.Action = model.SelectorSpecActionAdd
+
+		if selector, err := s.newAssetGroupSelector(assetGroup, selectorSpec); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+		} else if nodes, err := s.GraphQuery.PreviewAssetGroupSelector(request.Context(), selector); err != nil {
+			s.writeCypherQueryError(response, request, err)
+		} else {
+			agMembers := parseAGMembersFromNodes(nodes, assetGroup.Selectors, assetGroupID)
+
+			slices.SortFunc(agMembers, func(a, b api.AssetGroupMember) int {
+				return strings.Compare(a.ObjectID, b.ObjectID)
+			})
+
+			if skip > len(agMembers) {
+				skip = len(agMembers)
+			}
+
+			endIndex := len(agMembers)
+			if skip+limit < endIndex {
+				endIndex = skip + limit
+			}
+
+			api.WriteResponseWrapperWithPagination(request.Context(), api.ListAssetGroupMembersResponse{Members: agMembers[skip:endIndex]}, limit, skip, len(agMembers), http.StatusOK, response)
+		}
+	}
+}
+
 func (s Resources) DeleteAssetGroupSelector(response http.ResponseWriter, request *http.Request) {
 	var (
 		assetGroupSelector      model.AssetGroupSelector
@@ -387,6 +456,91 @@ func (s Resources) ListAssetGroupCollections(response http.ResponseWriter, reque
 	}
 }
 
+// assetGroupCollectionDiffParameters holds the parsed query parameters of GetAssetGroupCollectionDiff. A zero value
+// means the parameter was not given.
+type assetGroupCollectionDiffParameters struct {
+	FromCollectionID int64
+	ToCollectionID   int64
+	DaysAgo          int
+}
+
+func parseAssetGroupCollectionDiffParameters(query url.Values) (assetGroupCollectionDiffParameters, error) {
+	var params assetGroupCollectionDiffParameters
+
+	if rawFromCollectionID := query.Get(api.QueryParameterFromCollectionID); rawFromCollectionID != "" {
+		if fromCollectionID, err := strconv.ParseInt(rawFromCollectionID, 10, 64); err != nil || fromCollectionID <= 0 {
+			return params, fmt.Errorf("%s must be a collection id", api.QueryParameterFromCollectionID)
+		} else {
+			params.FromCollectionID = fromCollectionID
+		}
+	}
+
+	if rawToCollectionID := query.Get(api.QueryParameterToCollectionID); rawToCollectionID != "" {
+		if toCollectionID, err := strconv.ParseInt(rawToCollectionID, 10, 64); err != nil || toCollectionID <= 0 {
+			return params, fmt.Errorf("%s must be a collection id", api.QueryParameterToCollectionID)
+		} else {
+			params.ToCollectionID = toCollectionID
+		}
+	}
+
+	if rawDaysAgo := query.Get(api.QueryParameterDaysAgo); rawDaysAgo != "" {
+		if daysAgo, err := strconv.Atoi(rawDaysAgo); err != nil || daysAgo <= 0 {
+			return params, fmt.Errorf("%s must be a positive integer", api.QueryParameterDaysAgo)
+		} else {
+			params.DaysAgo = daysAgo
+		}
+	}
+
+	if (params.FromCollectionID == 0) == (params.DaysAgo == 0) {
+		return params, fmt.Errorf("exactly one of %s or %s must be specified", api.QueryParameterFromCollectionID, api.QueryParameterDaysAgo)
+	}
+
+	return params, nil
+}
+
+// GetAssetGroupCollectionDiff compares two collections of an asset group and returns the objects that entered or left
+// the asset group between them along with the selectors that selected them. The collection compared against defaults
+// to the latest collection. The collection compared from is given either by its ID or as the latest collection that
+// was recorded at least the given number of days ago.
+func (s Resources) GetAssetGroupCollectionDiff(response http.ResponseWriter, request *http.Request) {
+	var (
+		rawAssetGroupID = mux.Vars(request)[api.URIPathVariableAssetGroupID]
+		fromCollection  model.AssetGroupCollection
+		toCollection    model.AssetGroupCollection
+	)
+
+	if assetGroupID, err := strconv.ParseInt(rawAssetGroupID, 10, 32); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if params, err := parseAssetGroupCollectionDiffParameters(request.URL.Query()); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(api.FmtErrorResponseDetailsBadQueryParameters, err), request), response)
+	} else if assetGroup, err := s.DB.GetAssetGroup(request.Context(), int32(assetGroupID)); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		if params.ToCollectionID != 0 {
+			toCollection, err = s.DB.GetAssetGroupCollection(request.Context(), assetGroup.ID, params.ToCollectionID)
+		} else {
+			toCollection, err = s.DB.GetLatestAssetGroupCollection(request.Context(), assetGroup.ID)
+		}
+
+		if err != nil {
+			api.HandleDatabaseError(request, response, err)
+			return
+		}
+
+		if params.FromCollectionID != 0 {
+			fromCollection, err = s.DB.GetAssetGroupCollection(request.Context(), assetGroup.ID, params.FromCollectionID)
+		} else {
+			fromCollection, err = s.DB.GetAssetGroupCollectionBefore(request.Context(), assetGroup.ID, time.Now().AddDate(0, 0, -params.DaysAgo))
+		}
+
+		if err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), model.DiffAssetGroupCollections(fromCollection, toCollection), http.StatusOK, response)
+		}
+	}
+}
+
 // getLatestQueryParameter parses the "latest" value
 func getLatestQueryParameter(query url.Values) (bool, error) {
 	keys, wantsLatest := query["latest"]
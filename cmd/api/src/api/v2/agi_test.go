@@ -777,6 +777,129 @@ func TestResources_UpdateAssetGroupSelectors_SuccessOwned(t *testing.T) {
 	require.Equal(t, expectedResult.Removed[0].Name, data["removed_selectors"][0].Name)
 }
 
+func TestResources_PreviewAssetGroupSelector(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockGraph  = queriesMocks.NewMockGraph(mockCtrl)
+		mockDB     = dbmocks.NewMockDatabase(mockCtrl)
+		resources  = v2.Resources{DB: mockDB, GraphQuery: mockGraph}
+		assetGroup = model.AssetGroup{
+			Name:   "test group",
+			Tag:    "test_tag",
+			Serial: model.Serial{ID: 1},
+		}
+		cypherSpec = model.AssetGroupSelectorSpec{
+			SelectorName: "servers",
+			Type:         model.AssetGroupSelectorTypeCypher,
+			Selector:     "match (n:Computer) where n.operatingsystem contains 'SERVER' return n",
+		}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.PreviewAssetGroupSelector).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidAssetGroupID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupID, "invalid")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "InvalidSelectorType",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupID, "1")
+					apitest.BodyStruct(input, model.AssetGroupSelectorSpec{SelectorName: "invalid", Type: "sql", Selector: "SELECT 1"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroup(gomock.Any(), int32(1)).Return(assetGroup, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, model.ErrAssetGroupSelectorTypeInvalid.Error())
+				},
+			},
+			{
+				Name: "HostilePropertyName",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupID, "1")
+					apitest.BodyStruct(input, model.AssetGroupSelectorSpec{SelectorName: "hostile", Type: model.AssetGroupSelectorTypeProperty, Selector: "x' or true; select 1 --"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroup(gomock.Any(), int32(1)).Return(assetGroup, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, model.ErrAssetGroupSelectorPropertyInvalid.Error())
+				},
+			},
+			{
+				Name: "MutatingCypher",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupID, "1")
+					apitest.BodyStruct(input, cypherSpec)
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroup(gomock.Any(), int32(1)).Return(assetGroup, nil)
+					mockGraph.EXPECT().PrepareAssetGroupSelectorQuery(gomock.Any()).Return("", nil, errors.New("asset group selector cypher queries must be read-only"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "read-only")
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupID, "1")
+					apitest.AddQueryParam(input, "limit", "1")
+					apitest.BodyStruct(input, cypherSpec)
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroup(gomock.Any(), int32(1)).Return(assetGroup, nil)
+					mockGraph.EXPECT().PrepareAssetGroupSelectorQuery(gomock.Any()).Return("match (n:Computer) return n", map[string]any{}, nil)
+					mockGraph.EXPECT().
+						PreviewAssetGroupSelector(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, selector model.AssetGroupSelector) (graph.NodeSet, error) {
+							require.Equal(t, model.AssetGroupSelectorTypeCypher, selector.Type)
+							require.Equal(t, cypherSpec.Selector, selector.Selector)
+
+							return graph.NodeSet{
+								1: &graph.Node{
+									ID:    1,
+									Kinds: graph.Kinds{ad.Entity, ad.Computer},
+									Properties: &graph.Properties{
+										Map: map[string]any{common.ObjectID.String(): "b", common.Name.String(): "b", ad.DomainSID.String(): "a"},
+									},
+								},
+								2: &graph.Node{
+									ID:    2,
+									Kinds: graph.Kinds{ad.Entity, ad.Computer},
+									Properties: &graph.Properties{
+										Map: map[string]any{common.ObjectID.String(): "a", common.Name.String(): "a", ad.DomainSID.String(): "a"},
+									},
+								},
+							}, nil
+						})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := api.ListAssetGroupMembersResponse{}
+					apitest.UnmarshalData(output, &result)
+					apitest.Equal(output, 1, len(result.Members))
+					apitest.Equal(output, "a", result.Members[0].ObjectID)
+					apitest.BodyContains(output, `"count":2`)
+				},
+			},
+		})
+}
+
 func TestResources_DeleteAssetGroup(t *testing.T) {
 	var (
 		mockCtrl  = gomock.NewController(t)
@@ -1134,6 +1257,159 @@ func TestResources_ListAssetGroupCollections(t *testing.T) {
 		})
 }
 
+func TestResources_GetAssetGroupCollectionDiff(t *testing.T) {
+	var (
+		mockCtrl       = gomock.NewController(t)
+		mockDB         = dbmocks.NewMockDatabase(mockCtrl)
+		resources      = v2.Resources{DB: mockDB}
+		assetGroup     = model.AssetGroup{Name: "Admin Tier Zero", Tag: model.TierZeroAssetGroupTag, Serial: model.Serial{ID: 1}}
+		fromCollection = model.AssetGroupCollection{
+			AssetGroupID: 1,
+			Entries: model.AssetGroupCollectionEntries{
+				{ObjectID: "kept", NodeLabel: "User", Selectors: []string{"admins"}},
+				{ObjectID: "removed", NodeLabel: "Computer", Selectors: []string{"servers"}},
+			},
+			BigSerial: model.BigSerial{ID: 2},
+		}
+		toCollection = model.AssetGroupCollection{
+			AssetGroupID: 1,
+			Entries: model.AssetGroupCollectionEntries{
+				{ObjectID: "kept", NodeLabel: "User", Selectors: []string{"admins"}},
+				{ObjectID: "added", NodeLabel: "Group", Selectors: []string{"cypher"}},
+			},
+			BigSerial: model.BigSerial{ID: 3},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.GetAssetGroupCollectionDiff).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetURLVar(input, api.URIPathVariableAssetGroupID, "1")
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidAssetGroupID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupID, "invalid")
+					apitest.AddQueryParam(input, api.QueryParameterDaysAgo, "7")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "MissingFromParameter",
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.QueryParameterDaysAgo)
+				},
+			},
+			{
+				Name: "FromCollectionAndDaysAgo",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterFromCollectionID, "2")
+					apitest.AddQueryParam(input, api.QueryParameterDaysAgo, "7")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "InvalidDaysAgo",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterDaysAgo, "-1")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.QueryParameterDaysAgo)
+				},
+			},
+			{
+				Name: "InvalidToCollection",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterDaysAgo, "7")
+					apitest.AddQueryParam(input, api.QueryParameterToCollectionID, "latest")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.QueryParameterToCollectionID)
+				},
+			},
+			{
+				Name: "AssetGroupNotFound",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterDaysAgo, "7")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroup(gomock.Any(), int32(1)).Return(model.AssetGroup{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "NoCollectionBefore",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterDaysAgo, "7")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroup(gomock.Any(), int32(1)).Return(assetGroup, nil)
+					mockDB.EXPECT().GetLatestAssetGroupCollection(gomock.Any(), int32(1)).Return(toCollection, nil)
+					mockDB.EXPECT().GetAssetGroupCollectionBefore(gomock.Any(), int32(1), gomock.Any()).Return(model.AssetGroupCollection{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "SuccessDaysAgo",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterDaysAgo, "7")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroup(gomock.Any(), int32(1)).Return(assetGroup, nil)
+					mockDB.EXPECT().GetLatestAssetGroupCollection(gomock.Any(), int32(1)).Return(toCollection, nil)
+					mockDB.EXPECT().GetAssetGroupCollectionBefore(gomock.Any(), int32(1), gomock.Any()).Return(fromCollection, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					result := model.AssetGroupCollectionDiff{}
+					apitest.UnmarshalData(output, &result)
+
+					require.Equal(t, int64(2), result.FromCollectionID)
+					require.Equal(t, int64(3), result.ToCollectionID)
+					require.Equal(t, []model.AssetGroupMembershipChange{{ObjectID: "added", NodeLabel: "Group", Selectors: []string{"cypher"}}}, result.Added)
+					require.Equal(t, []model.AssetGroupMembershipChange{{ObjectID: "removed", NodeLabel: "Computer", Selectors: []string{"servers"}}}, result.Removed)
+				},
+			},
+			{
+				Name: "SuccessCollectionIDs",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterFromCollectionID, "3")
+					apitest.AddQueryParam(input, api.QueryParameterToCollectionID, "2")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroup(gomock.Any(), int32(1)).Return(assetGroup, nil)
+					mockDB.EXPECT().GetAssetGroupCollection(gomock.Any(), int32(1), int64(2)).Return(fromCollection, nil)
+					mockDB.EXPECT().GetAssetGroupCollection(gomock.Any(), int32(1), int64(3)).Return(toCollection, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					result := model.AssetGroupCollectionDiff{}
+					apitest.UnmarshalData(output, &result)
+
+					require.Equal(t, int64(3), result.FromCollectionID)
+					require.Equal(t, int64(2), result.ToCollectionID)
+					require.Equal(t, "removed", result.Added[0].ObjectID)
+					require.Equal(t, "added", result.Removed[0].ObjectID)
+				},
+			},
+		})
+}
+
 func TestResources_ListAssetGroupMembers(t *testing.T) {
 	var (
 		mockCtrl   = gomock.NewController(t)
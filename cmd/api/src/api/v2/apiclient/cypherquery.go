@@ -18,10 +18,13 @@ package apiclient
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/specterops/bloodhound/src/api"
 	v2 "github.com/specterops/bloodhound/src/api/v2"
 	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/queries"
 )
 
 func (s Client) CypherQuery(request v2.CypherQueryPayload) (model.UnifiedGraph, error) {
@@ -39,3 +42,42 @@ func (s Client) CypherQuery(request v2.CypherQueryPayload) (model.UnifiedGraph,
 		return graphResponse, api.ReadAPIV2ResponsePayload(&graphResponse, response)
 	}
 }
+
+func (s Client) CypherQueryTable(request v2.CypherQueryPayload, skip, limit int) (model.CypherTable, error) {
+	var (
+		table  model.CypherTable
+		params = url.Values{
+			"result_format":                     []string{"table"},
+			model.PaginationQueryParameterSkip:  []string{strconv.Itoa(skip)},
+			model.PaginationQueryParameterLimit: []string{strconv.Itoa(limit)},
+		}
+	)
+
+	if response, err := s.Request(http.MethodPost, "api/v2/graphs/cypher", params, request); err != nil {
+		return table, err
+	} else {
+		defer response.Body.Close()
+
+		if api.IsErrorResponse(response) {
+			return table, ReadAPIError(response)
+		}
+
+		return table, api.ReadAPIV2ResponsePayload(&table, response)
+	}
+}
+
+func (s Client) CypherQueryExplain(request v2.CypherQueryPayload) (queries.CypherQueryExplanation, error) {
+	var explanation queries.CypherQueryExplanation
+
+	if response, err := s.Request(http.MethodPost, "api/v2/graphs/cypher/explain", nil, request); err != nil {
+		return explanation, err
+	} else {
+		defer response.Body.Close()
+
+		if api.IsErrorResponse(response) {
+			return explanation, ReadAPIError(response)
+		}
+
+		return explanation, api.ReadAPIV2ResponsePayload(&explanation, response)
+	}
+}
@@ -40,6 +40,11 @@ func StatusCode(output Output, code int) {
 	require.Equal(output.t, code, output.response.Code)
 }
 
+// Header requires the response header with the given key to match the given value
+func Header(output Output, key string, value string) {
+	require.Equal(output.t, value, output.response.Header().Get(key))
+}
+
 // BodyContains requires the given string to exist anywhere in the response body
 func BodyContains(output Output, message string) {
 	require.Contains(output.t, output.response.Body.String(), message)
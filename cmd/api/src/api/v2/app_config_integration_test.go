@@ -48,6 +48,9 @@ func Test_GetAppConfigs(t *testing.T) {
 
 		reconciliationFound           = false
 		reconciliationParametersValue appcfg.ReconciliationParameter
+
+		deletedObjectsFound          = false
+		deletedObjectsParameterValue appcfg.DeletedObjectsParameter
 	)
 
 	config, err := testCtx.AdminClient().GetAppConfigs()
@@ -77,6 +80,10 @@ func Test_GetAppConfigs(t *testing.T) {
 			mapParameter(t, &reconciliationParametersValue, parameter)
 			require.True(t, reconciliationParametersValue.Enabled)
 			reconciliationFound = true
+		case appcfg.DeletedObjectsKey:
+			mapParameter(t, &deletedObjectsParameterValue, parameter)
+			require.True(t, deletedObjectsParameterValue.Remove)
+			deletedObjectsFound = true
 		}
 	}
 
@@ -85,6 +92,7 @@ func Test_GetAppConfigs(t *testing.T) {
 	require.True(t, citrixConfigsFound, "Failed to find Citrix configs in response")
 	require.True(t, pruneFound, "Failed to find Prune TTL  in response")
 	require.True(t, reconciliationFound, "Failed to find Reconciliation in response")
+	require.True(t, deletedObjectsFound, "Failed to find Deleted Objects in response")
 }
 
 func Test_GetAppConfigWithParameter(t *testing.T) {
@@ -133,7 +141,8 @@ func mapParameter[T *appcfg.PasswordExpiration |
 	*appcfg.Neo4jParameters |
 	*appcfg.CitrixRDPSupport |
 	*appcfg.PruneTTLParameters |
-	*appcfg.ReconciliationParameter](t *testing.T, value T, parameter appcfg.Parameter) {
+	*appcfg.ReconciliationParameter |
+	*appcfg.DeletedObjectsParameter](t *testing.T, value T, parameter appcfg.Parameter) {
 	err := parameter.Value.Map(&value)
 	require.Nilf(t, err, "Failed to map parameter value to %T type: %v", value, err)
 }
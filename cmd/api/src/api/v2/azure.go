@@ -330,15 +330,15 @@ func (s *Resources) GetAZRelatedEntities(ctx context.Context, response http.Resp
 func GetAZEntityInformation(ctx context.Context, db graph.Database, entityType, objectID string, hydrateCounts bool) (any, error) {
 	switch entityType {
 	case entityTypeBase:
-		return azure2.BaseEntityDetails(db, objectID, hydrateCounts)
+		return azure2.BaseEntityDetails(ctx, db, objectID, hydrateCounts)
 	case entityTypeUsers:
-		return azure.UserEntityDetails(db, objectID, hydrateCounts)
+		return azure.UserEntityDetails(ctx, db, objectID, hydrateCounts)
 
 	case entityTypeGroups:
-		return azure.GroupEntityDetails(db, objectID, hydrateCounts)
+		return azure.GroupEntityDetails(ctx, db, objectID, hydrateCounts)
 
 	case entityTypeTenants:
-		return azure.TenantEntityDetails(db, objectID, hydrateCounts)
+		return azure.TenantEntityDetails(ctx, db, objectID, hydrateCounts)
 
 	case entityTypeManagementGroups:
 		return azure.ManagementGroupEntityDetails(ctx, db, objectID, hydrateCounts)
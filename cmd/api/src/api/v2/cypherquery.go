@@ -17,59 +17,120 @@
 package v2
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/specterops/bloodhound/dawgs/util"
 	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/headers"
 	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/api"
 	"github.com/specterops/bloodhound/src/auth"
 	"github.com/specterops/bloodhound/src/ctx"
 	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/queries"
+	"github.com/specterops/bloodhound/src/utils"
 )
 
 const (
 	errUnauthorizedGraphMutation = errors.Error("unauthorized graph mutation")
+
+	cypherResultFormatQueryParameter = "result_format"
+	cypherResultFormatGraph          = "graph"
+	cypherResultFormatTable          = "table"
+	cypherResultFormatCSV            = "csv"
+	cypherTableDefaultLimit          = 100
+	cypherTableCSVFilename           = "cypher-results.csv"
 )
 
 type CypherQueryPayload struct {
-	Query             string `json:"query"`
-	IncludeProperties bool   `json:"include_properties,omitempty"`
+	Query             string         `json:"query"`
+	Parameters        map[string]any `json:"parameters,omitempty"`
+	IncludeProperties bool           `json:"include_properties,omitempty"`
 }
 
 func (s Resources) CypherQuery(response http.ResponseWriter, request *http.Request) {
+	var payload CypherQueryPayload
+
+	if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "JSON malformed.", request), response)
+	} else {
+		s.runCypherQuery(response, request, payload)
+	}
+}
+
+func (s Resources) CypherQueryExplain(response http.ResponseWriter, request *http.Request) {
+	var payload CypherQueryPayload
+
+	if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "JSON malformed.", request), response)
+	} else if preparedQuery, err := s.GraphQuery.PrepareCypherQueryExplanation(payload.Query, payload.Parameters); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if explanation, err := s.GraphQuery.ExplainCypherQuery(request.Context(), preparedQuery); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
+	} else {
+		api.WriteBasicResponse(request.Context(), explanation, http.StatusOK, response)
+	}
+}
+
+func (s Resources) runCypherQuery(response http.ResponseWriter, request *http.Request, payload CypherQueryPayload) {
 	var (
-		payload       CypherQueryPayload
+		queryParams   = request.URL.Query()
+		resultFormat  = cypherResultFormatGraph
 		preparedQuery queries.PreparedQuery
-		graphResponse model.UnifiedGraph
 		err           error
 	)
 
-	if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
-		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "JSON malformed.", request), response)
+	if param := queryParams.Get(cypherResultFormatQueryParameter); param != "" {
+		resultFormat = param
+	}
+
+	switch resultFormat {
+	case cypherResultFormatGraph:
+	case cypherResultFormatTable, cypherResultFormatCSV:
+	default:
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, cypherResultFormatQueryParameter, fmt.Errorf("unsupported result format %q", resultFormat)), response)
 		return
 	}
 
-	if preparedQuery, err = s.GraphQuery.PrepareCypherQuery(payload.Query); err != nil {
+	if preparedQuery, err = s.GraphQuery.PrepareCypherQuery(payload.Query, payload.Parameters); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
-		return
+	} else if resultFormat == cypherResultFormatGraph {
+		s.runCypherGraphQuery(response, request, preparedQuery, payload.IncludeProperties)
+	} else {
+		s.runCypherTableQuery(response, request, preparedQuery, payload.IncludeProperties, resultFormat)
+	}
+}
+
+func (s Resources) writeCypherQueryError(response http.ResponseWriter, request *http.Request, err error) {
+	if errors.Is(err, errUnauthorizedGraphMutation) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "Permission denied: User may not modify the graph.", request), response)
+	} else if util.IsNeoTimeoutError(err) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "transaction timed out, reduce query complexity or try again later", request), response)
+	} else {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
+	}
+}
+
+func (s Resources) runCypherGraphQuery(response http.ResponseWriter, request *http.Request, preparedQuery queries.PreparedQuery, includeProperties bool) {
+	var (
+		graphResponse model.UnifiedGraph
+		err           error
+	)
+
+	runQuery := func() error {
+		graphResponse, err = s.GraphQuery.RawCypherQuery(request.Context(), preparedQuery, includeProperties)
+		return err
 	}
 
 	if preparedQuery.HasMutation {
-		graphResponse, err = s.cypherMutation(request, preparedQuery, payload.IncludeProperties)
+		err = s.cypherMutation(request, preparedQuery, runQuery)
 	} else {
-		graphResponse, err = s.GraphQuery.RawCypherQuery(request.Context(), preparedQuery, payload.IncludeProperties)
+		err = runQuery()
 	}
 
 	if err != nil {
-		if errors.Is(err, errUnauthorizedGraphMutation) {
-			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "Permission denied: User may not modify the graph.", request), response)
-		} else if util.IsNeoTimeoutError(err) {
-			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "transaction timed out, reduce query complexity or try again later", request), response)
-		} else {
-			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
-		}
+		s.writeCypherQueryError(response, request, err)
 	} else if !preparedQuery.HasMutation && len(graphResponse.Nodes)+len(graphResponse.Edges) == 0 {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "resource not found", request), response)
 	} else {
@@ -77,39 +138,85 @@ func (s Resources) CypherQuery(response http.ResponseWriter, request *http.Reque
 	}
 }
 
-func (s Resources) cypherMutation(request *http.Request, preparedQuery queries.PreparedQuery, includeProperties bool) (model.UnifiedGraph, error) {
+func (s Resources) runCypherTableQuery(response http.ResponseWriter, request *http.Request, preparedQuery queries.PreparedQuery, includeProperties bool, resultFormat string) {
+	var (
+		queryParams  = request.URL.Query()
+		defaultLimit = cypherTableDefaultLimit
+		table        model.CypherTable
+		count        int
+	)
+
+	// CSV downloads return every row unless a limit is requested
+	if resultFormat == cypherResultFormatCSV {
+		defaultLimit = 0
+	}
+
+	skip, err := ParseSkipQueryParameter(queryParams, 0)
+	if err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+		return
+	}
+
+	limit, err := ParseLimitQueryParameter(queryParams, defaultLimit)
+	if err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+		return
+	}
+
+	runQuery := func() error {
+		table, count, err = s.GraphQuery.RawCypherTableQuery(request.Context(), preparedQuery, includeProperties, skip, limit)
+		return err
+	}
+
+	if preparedQuery.HasMutation {
+		err = s.cypherMutation(request, preparedQuery, runQuery)
+	} else {
+		err = runQuery()
+	}
+
+	if errors.Is(err, queries.ErrCypherQueryColumnsUnavailable) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if err != nil {
+		s.writeCypherQueryError(response, request, err)
+	} else if resultFormat == cypherResultFormatCSV {
+		response.Header().Set(headers.ContentDisposition.String(), fmt.Sprintf(utils.ContentDispositionAttachmentTemplate, cypherTableCSVFilename))
+		api.WriteCSVResponse(request.Context(), table, http.StatusOK, response)
+	} else {
+		api.WriteResponseWrapperWithPagination(request.Context(), table, limit, skip, count, http.StatusOK, response)
+	}
+}
+
+func (s Resources) cypherMutation(request *http.Request, preparedQuery queries.PreparedQuery, runQuery func() error) error {
 	var (
 		auditLogEntry model.AuditEntry
-		graphResponse model.UnifiedGraph
 		err           error
 	)
 
 	if !s.Authorizer.AllowsPermission(ctx.FromRequest(request).AuthCtx, auth.Permissions().GraphDBMutate) {
 		s.Authorizer.AuditLogUnauthorizedAccess(request)
-		return model.UnifiedGraph{}, errUnauthorizedGraphMutation
+		return errUnauthorizedGraphMutation
 	}
 
 	// All mutation attempts must be audit logged even when failed
 	if auditLogEntry, err = model.NewAuditEntry(model.AuditLogActionMutateGraph, model.AuditLogStatusIntent, model.AuditData{"query": preparedQuery.StrippedQuery}); err != nil {
-		return model.UnifiedGraph{}, err
+		return err
 	}
 
 	// create an intent audit log
 	if err = s.DB.AppendAuditLog(request.Context(), auditLogEntry); err != nil {
-		return model.UnifiedGraph{}, err
+		return err
 	}
 
-	if graphResponse, err = s.GraphQuery.RawCypherQuery(request.Context(), preparedQuery, includeProperties); err != nil {
+	if err = runQuery(); err != nil {
 		auditLogEntry.Status = model.AuditLogStatusFailure
 	} else {
 		auditLogEntry.Status = model.AuditLogStatusSuccess
 	}
 
 	if err := s.DB.AppendAuditLog(request.Context(), auditLogEntry); err != nil {
-		// We want to keep err scoped because having info on the mutation graph response trumps this error
+		// We want to keep err scoped because having info on the mutation query result trumps this error
 		log.Errorf("failure to create mutation audit log %s", err.Error())
 	}
 
-	return graphResponse, err
-
+	return err
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/specterops/bloodhound/cypher/models/cypher/format"
 	"github.com/specterops/bloodhound/src/auth"
 	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/queries"
 	"github.com/specterops/bloodhound/src/utils/test"
 
 	"github.com/specterops/bloodhound/cypher/frontend"
@@ -62,7 +63,7 @@ func Test_CypherSearch(t *testing.T) {
 			})
 			assert.ErrorContains(err, "extraneous input")
 		}),
-		lab.TestCase("errors on queries that are not supported", func(assert *require.Assertions, harness *lab.Harness) {
+		lab.TestCase("errors on parameterized queries without parameter values", func(assert *require.Assertions, harness *lab.Harness) {
 			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
 			assert.True(ok)
 
@@ -70,7 +71,85 @@ func Test_CypherSearch(t *testing.T) {
 			_, err := apiClient.CypherQuery(v2.CypherQueryPayload{
 				Query: queryWithUserSpecifiedParameters,
 			})
-			assert.ErrorContains(err, frontend.ErrUserSpecifiedParametersNotSupported.Error())
+			assert.ErrorContains(err, queries.ErrCypherParameterMissing.Error())
+		}),
+		lab.TestCase("errors on unsupported parameter values", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			_, err := apiClient.CypherQuery(v2.CypherQueryPayload{
+				Query: "match (n:Computer) where n.objectid = $objectid return n",
+				Parameters: map[string]any{
+					"objectid": map[string]any{"nested": true},
+				},
+			})
+			assert.ErrorContains(err, queries.ErrCypherParameterValueUnsupported.Error())
+		}),
+		lab.TestCase("successfully runs parameterized cypher query", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			graphResponse, err := apiClient.CypherQuery(v2.CypherQueryPayload{
+				Query: "match (n:Computer) where n.objectid = $objectid return n",
+				Parameters: map[string]any{
+					"objectid": fixtures.BasicComputerSID.String(),
+				},
+			})
+			assert.NoError(err)
+			assert.Equal(1, len(graphResponse.Nodes))
+		}),
+		lab.TestCase("successfully runs cypher query with tabular results", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			table, err := apiClient.CypherQueryTable(v2.CypherQueryPayload{
+				Query: "match (n:Computer) where n.objectid = $objectid return n.objectid as objectid, n",
+				Parameters: map[string]any{
+					"objectid": fixtures.BasicComputerSID.String(),
+				},
+			}, 0, 10)
+			assert.NoError(err)
+			assert.Equal([]string{"objectid", "n"}, table.Columns)
+			assert.Equal(1, len(table.Rows))
+			assert.Equal(fixtures.BasicComputerSID.String(), table.Rows[0][0])
+		}),
+		lab.TestCase("successfully runs cypher query with map columns in tabular results", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			table, err := apiClient.CypherQueryTable(v2.CypherQueryPayload{
+				Query: "match (n:Computer) where n.objectid = $objectid return properties(n) as props, {a: 1} as literal",
+				Parameters: map[string]any{
+					"objectid": fixtures.BasicComputerSID.String(),
+				},
+			}, 0, 10)
+			assert.NoError(err)
+			assert.Equal([]string{"props", "literal"}, table.Columns)
+			assert.Equal(1, len(table.Rows))
+
+			props, isMap := table.Rows[0][0].(map[string]any)
+			assert.True(isMap)
+			assert.Equal(fixtures.BasicComputerSID.String(), props["objectid"])
+			assert.Equal(map[string]any{"a": float64(1)}, table.Rows[0][1])
+		}),
+		lab.TestCase("explains cypher query without executing it", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			explanation, err := apiClient.CypherQueryExplain(v2.CypherQueryPayload{
+				Query: "match (n:Computer) where n.objectid = $objectid return n",
+				Parameters: map[string]any{
+					"objectid": fixtures.BasicComputerSID.String(),
+				},
+			})
+			assert.NoError(err)
+			assert.NotEmpty(explanation.Query)
+			assert.Greater(explanation.TimeoutSeconds, float64(0))
+
+			if explanation.SQL != "" {
+				assert.NotEmpty(explanation.Plan)
+				assert.Contains(explanation.PlanPUML, "@startuml")
+			}
 		}),
 		lab.TestCase("successfully runs cypher query", func(assert *require.Assertions, harness *lab.Harness) {
 			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
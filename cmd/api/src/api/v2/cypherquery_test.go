@@ -0,0 +1,128 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/queries"
+	"github.com/specterops/bloodhound/src/queries/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_CypherQuery_TableResultFormat(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockGraph = mocks.NewMockGraph(mockCtrl)
+		resources = v2.Resources{GraphQuery: mockGraph}
+		payload   = v2.CypherQueryPayload{Query: "match (n) return n.name as name, count(*)"}
+		table     = model.CypherTable{
+			Columns: []string{"name", "count(*)"},
+			Rows:    [][]any{{"harley", 2}},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CypherQuery).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "UnsupportedResultFormat",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "result_format", "spreadsheet")
+					apitest.BodyStruct(input, payload)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "result_format")
+				},
+			},
+			{
+				Name: "InvalidLimit",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "result_format", "table")
+					apitest.AddQueryParam(input, "limit", "-1")
+					apitest.BodyStruct(input, payload)
+				},
+				Setup: func() {
+					mockGraph.EXPECT().PrepareCypherQuery(payload.Query, gomock.Any()).Return(queries.PreparedQuery{}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "limit")
+				},
+			},
+			{
+				Name: "ColumnsUnavailable",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "result_format", "table")
+					apitest.BodyStruct(input, payload)
+				},
+				Setup: func() {
+					mockGraph.EXPECT().PrepareCypherQuery(payload.Query, gomock.Any()).Return(queries.PreparedQuery{}, nil)
+					mockGraph.EXPECT().RawCypherTableQuery(gomock.Any(), gomock.Any(), false, 0, 100).Return(model.CypherTable{}, 0, queries.ErrCypherQueryColumnsUnavailable)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, queries.ErrCypherQueryColumnsUnavailable.Error())
+				},
+			},
+			{
+				Name: "TableSuccess",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "result_format", "table")
+					apitest.AddQueryParam(input, "skip", "1")
+					apitest.AddQueryParam(input, "limit", "1")
+					apitest.BodyStruct(input, payload)
+				},
+				Setup: func() {
+					mockGraph.EXPECT().PrepareCypherQuery(payload.Query, gomock.Any()).Return(queries.PreparedQuery{}, nil)
+					mockGraph.EXPECT().RawCypherTableQuery(gomock.Any(), gomock.Any(), false, 1, 1).Return(table, 2, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"columns":["name","count(*)"]`)
+					apitest.BodyContains(output, `"rows":[["harley",2]]`)
+					apitest.BodyContains(output, `"count":2`)
+				},
+			},
+			{
+				Name: "CSVSuccess",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "result_format", "csv")
+					apitest.BodyStruct(input, payload)
+				},
+				Setup: func() {
+					mockGraph.EXPECT().PrepareCypherQuery(payload.Query, gomock.Any()).Return(queries.PreparedQuery{}, nil)
+					mockGraph.EXPECT().RawCypherTableQuery(gomock.Any(), gomock.Any(), false, 0, 0).Return(table, 1, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.Header(output, headers.ContentType.String(), mediatypes.TextCsv.String())
+					apitest.BodyContains(output, "name,count(*)\nharley,2\n")
+				},
+			},
+		})
+}
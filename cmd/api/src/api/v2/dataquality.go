@@ -17,9 +17,11 @@
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/specterops/bloodhound/analysis/ad"
@@ -216,3 +218,26 @@ func (s *Resources) GetPlatformAggregateStats(response http.ResponseWriter, requ
 		api.WriteResponseWrapperWithTimeWindowAndPagination(request.Context(), stats, start, end, limit, skip, count, http.StatusOK, response)
 	}
 }
+
+// GetADCollectionCoverage reports which data types and collection methods have been collected for a domain, how old
+// each of them is and which computers of the domain failed to be collected
+func (s *Resources) GetADCollectionCoverage(response http.ResponseWriter, request *http.Request) {
+	queryParams := request.URL.Query()
+
+	if id, hasDomainID := mux.Vars(request)[api.URIPathVariableDomainID]; !hasDomainID {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorNoDomainId, request), response)
+	} else if staleAfterDays, err := ParseIntQueryParameter(queryParams, api.QueryParameterStaleAfterDays, model.DefaultCollectionStaleAfterDays); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, api.QueryParameterStaleAfterDays, err), response)
+	} else if staleAfterDays < 0 {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, api.QueryParameterStaleAfterDays, errors.New("must not be negative")), response)
+	} else if limit, err := ParseLimitQueryParameter(queryParams, 100); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(utils.ErrorInvalidLimit, queryParams["limit"]), request), response)
+	} else if collections, err := s.DB.GetDomainCollections(request.Context(), id); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if failures, failureCount, err := s.DB.GetComputerCollectionFailures(request.Context(), id, limit); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		staleAfter := time.Now().UTC().AddDate(0, 0, -staleAfterDays)
+		api.WriteBasicResponse(request.Context(), model.NewCollectionCoverage(id, collections, failures, failureCount, staleAfter), http.StatusOK, response)
+	}
+}
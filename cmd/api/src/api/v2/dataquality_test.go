@@ -24,6 +24,10 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/src/model/ingest"
 
 	"github.com/specterops/bloodhound/src/utils"
 
@@ -908,3 +912,86 @@ func TestGetPlatformAggregateStats_Success(t *testing.T) {
 		}
 	}
 }
+
+func TestGetADCollectionCoverage(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+		domainSID = "S-1-5-21-3130019616-2776909439-2417379446"
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.GetADCollectionCoverage).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetURLVar(input, api.URIPathVariableDomainID, domainSID)
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "MissingDomainID",
+				Input: func(input *apitest.Input) {
+					apitest.DeleteURLVar(input, api.URIPathVariableDomainID)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, v2.ErrorNoDomainId)
+				},
+			},
+			{
+				Name: "InvalidStaleAfterDays",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterStaleAfterDays, "-1")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.QueryParameterStaleAfterDays)
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetDomainCollections(gomock.Any(), domainSID).Return(nil, fmt.Errorf("db error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.QueryParameterStaleAfterDays, "30")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetDomainCollections(gomock.Any(), domainSID).Return(model.DomainCollections{{
+						DomainSID:   domainSID,
+						DataType:    string(ingest.DataTypeUser),
+						Methods:     ingest.CollectionMethodObjectProps,
+						ObjectCount: 10,
+						CollectedAt: time.Now().Add(-24 * time.Hour),
+					}, {
+						DomainSID:   domainSID,
+						DataType:    string(ingest.DataTypeSession),
+						Methods:     ingest.CollectionMethodSession,
+						ObjectCount: 4,
+						CollectedAt: time.Now().Add(-60 * 24 * time.Hour),
+					}}, nil)
+					mockDB.EXPECT().GetComputerCollectionFailures(gomock.Any(), domainSID, 100).Return(model.ComputerCollectionFailures{{
+						ComputerObjectID: "S-1-5-21-3130019616-2776909439-2417379446-1001",
+						DomainSID:        domainSID,
+						Collection:       model.ComputerCollectionSessions,
+						FailureReason:    "ErrorAccessDenied",
+					}}, 1, nil)
+				},
+				Test: func(output apitest.Output) {
+					var coverage model.CollectionCoverage
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &coverage)
+					apitest.Equal(output, []string{string(ingest.DataTypeSession)}, coverage.StaleDataTypes)
+					apitest.Equal(output, 1, coverage.ComputerFailureCount)
+					apitest.Equal(output, "ErrorAccessDenied", coverage.ComputerFailures[0].FailureReason)
+					apitest.Equal(output, true, coverage.RecollectionSuggested)
+				},
+			},
+		})
+}
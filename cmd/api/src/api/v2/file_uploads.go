@@ -35,9 +35,28 @@ import (
 	ingestModel "github.com/specterops/bloodhound/src/model/ingest"
 	"github.com/specterops/bloodhound/src/services/fileupload"
 	"github.com/specterops/bloodhound/src/services/ingest"
+	"github.com/specterops/bloodhound/src/utils"
 )
 
-const FileUploadJobIdPathParameterName = "file_upload_job_id"
+const (
+	FileUploadJobIdPathParameterName   = "file_upload_job_id"
+	ResumableUploadIdPathParameterName = "resumable_upload_id"
+
+	// HeaderUploadOffset carries the number of bytes of a resumable upload that have been received
+	HeaderUploadOffset = string(headers.UploadOffset)
+	// HeaderUploadLength carries the total length in bytes of a resumable upload
+	HeaderUploadLength = string(headers.UploadLength)
+	// ResumableUploadContentType is the content type required of requests that append data to a resumable upload
+	ResumableUploadContentType = "application/offset+octet-stream"
+)
+
+var ErrorResponseDetailsInvalidUploadContentType = fmt.Sprintf("Content type must be one of %s", strings.Join(ingestModel.AllowedFileUploadTypes, ", "))
+
+type StartResumableUploadRequest struct {
+	ContentType string `json:"content_type"`
+	Length      int64  `json:"length"`
+	SHA256      string `json:"sha256"`
+}
 
 func (s Resources) ListFileUploadJobs(response http.ResponseWriter, request *http.Request) {
 	var (
@@ -113,7 +132,9 @@ func (s Resources) StartFileUploadJob(response http.ResponseWriter, request *htt
 
 	if user, valid := auth.GetUserFromAuthCtx(reqCtx.AuthCtx); !valid {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, api.ErrorResponseDetailsAuthenticationInvalid, request), response)
-	} else if fileUploadJob, err := fileupload.StartFileUploadJob(request.Context(), s.DB, user); err != nil {
+	} else if dryRun, err := api.ParseOptionalBool(request.URL.Query().Get(api.QueryParameterDryRun), false); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(api.FmtErrorResponseDetailsBadQueryParameters, err), request), response)
+	} else if fileUploadJob, err := fileupload.StartFileUploadJob(request.Context(), s.DB, user, dryRun, reqCtx.Workspace); err != nil {
 		api.HandleDatabaseError(request, response, err)
 	} else {
 		api.WriteBasicResponse(request.Context(), fileUploadJob, http.StatusCreated, response)
@@ -131,7 +152,7 @@ func (s Resources) ProcessFileUpload(response http.ResponseWriter, request *http
 	}
 
 	if !IsValidContentTypeForUpload(request.Header) {
-		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Content type must be application/json or application/zip", request), response)
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorResponseDetailsInvalidUploadContentType, request), response)
 	} else if fileUploadJobID, err := strconv.Atoi(fileUploadJobIdString); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
 	} else if fileUploadJob, err := fileupload.GetFileUploadJobByID(request.Context(), s.DB, int64(fileUploadJobID)); err != nil {
@@ -167,6 +188,145 @@ func (s Resources) EndFileUploadJob(response http.ResponseWriter, request *http.
 	}
 }
 
+func (s Resources) GetFileUploadJobResults(response http.ResponseWriter, request *http.Request) {
+	fileUploadJobIdString := mux.Vars(request)[FileUploadJobIdPathParameterName]
+
+	if fileUploadJobID, err := strconv.ParseInt(fileUploadJobIdString, 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if _, err := fileupload.GetFileUploadJobByID(request.Context(), s.DB, fileUploadJobID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if results, err := fileupload.GetFileUploadJobResults(request.Context(), s.DB, fileUploadJobID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), results, http.StatusOK, response)
+	}
+}
+
+// GetFileUploadJobReport returns the combined ingest report of all files of a dry run file upload job
+func (s Resources) GetFileUploadJobReport(response http.ResponseWriter, request *http.Request) {
+	fileUploadJobIdString := mux.Vars(request)[FileUploadJobIdPathParameterName]
+
+	if fileUploadJobID, err := strconv.ParseInt(fileUploadJobIdString, 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if fileUploadJob, err := fileupload.GetFileUploadJobByID(request.Context(), s.DB, fileUploadJobID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if !fileUploadJob.DryRun {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "reports are only available for dry run jobs", request), response)
+	} else if results, err := fileupload.GetFileUploadJobResults(request.Context(), s.DB, fileUploadJobID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), results.Report(), http.StatusOK, response)
+	}
+}
+
+func (s Resources) StartResumableUpload(response http.ResponseWriter, request *http.Request) {
+	var (
+		fileUploadJobIdString = mux.Vars(request)[FileUploadJobIdPathParameterName]
+		uploadRequest         StartResumableUploadRequest
+	)
+
+	if fileUploadJobID, err := strconv.ParseInt(fileUploadJobIdString, 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if err := api.ReadJSONRequestPayloadLimited(&uploadRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if fileType, valid := fileupload.FileTypeForContentType(uploadRequest.ContentType); !valid {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorResponseDetailsInvalidUploadContentType, request), response)
+	} else if fileUploadJob, err := fileupload.GetFileUploadJobByID(request.Context(), s.DB, fileUploadJobID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if fileUploadJob.Status != model.JobStatusRunning {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "job must be in running status to upload files", request), response)
+	} else if upload, err := fileupload.StartResumableUpload(request.Context(), s.DB, s.Config.TempDirectory(), fileUploadJob, fileType, uploadRequest.Length, s.Config.MaxResumableUploadBytes(), uploadRequest.SHA256); errors.Is(err, fileupload.ErrInvalidUploadLength) || errors.Is(err, fileupload.ErrInvalidUploadChecksum) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if errors.Is(err, fileupload.ErrUploadTooLarge) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusRequestEntityTooLarge, err.Error(), request), response)
+	} else if err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.Header().Set(headers.Location.String(), fmt.Sprintf("/api/v2/file-upload/%d/uploads/%d", fileUploadJobID, upload.ID))
+		response.Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+		api.WriteBasicResponse(request.Context(), upload, http.StatusCreated, response)
+	}
+}
+
+func (s Resources) GetResumableUploadOffset(response http.ResponseWriter, request *http.Request) {
+	if fileUploadJobID, uploadID, err := parseResumableUploadIDs(request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if upload, err := fileupload.GetResumableUpload(request.Context(), s.DB, fileUploadJobID, uploadID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+		response.Header().Set(HeaderUploadLength, strconv.FormatInt(upload.Length, 10))
+		response.Header().Set(headers.CacheControl.String(), "no-store")
+		response.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s Resources) WriteResumableUpload(response http.ResponseWriter, request *http.Request) {
+	requestId := ctx.FromRequest(request).RequestID
+
+	if request.Body != nil {
+		defer request.Body.Close()
+	}
+
+	if !utils.HeaderMatches(request.Header, headers.ContentType.String(), ResumableUploadContentType) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("Content type must be %s", ResumableUploadContentType), request), response)
+	} else if offset, err := strconv.ParseInt(request.Header.Get(HeaderUploadOffset), 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s header is missing or malformed", HeaderUploadOffset), request), response)
+	} else if fileUploadJobID, uploadID, err := parseResumableUploadIDs(request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if upload, err := fileupload.GetResumableUpload(request.Context(), s.DB, fileUploadJobID, uploadID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if fileUploadJob, err := fileupload.GetFileUploadJobByID(request.Context(), s.DB, fileUploadJobID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if fileUploadJob.Status != model.JobStatusRunning {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "job must be in running status to upload files", request), response)
+	} else if upload, err := fileupload.WriteResumableUpload(request.Context(), s.DB, upload, offset, request.Body); errors.Is(err, fileupload.ErrUploadOffsetMismatch) || errors.Is(err, fileupload.ErrUploadFinished) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, err.Error(), request), response)
+	} else if errors.Is(err, fileupload.ErrUploadLengthExceeded) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Error writing upload: %v", err), request), response)
+	} else if err := fileupload.TouchFileUploadJobLastIngest(request.Context(), s.DB, fileUploadJob); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if !upload.Finished {
+		response.Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+		response.WriteHeader(http.StatusNoContent)
+	} else if fileName, err := fileupload.FinishResumableUpload(s.Config.TempDirectory(), upload); err != nil {
+		if abortErr := fileupload.AbortResumableUpload(request.Context(), s.DB, upload); abortErr != nil {
+			log.Errorf("Error removing resumable upload %d: %v", upload.ID, abortErr)
+		}
+
+		if errors.Is(err, fileupload.ErrUploadChecksumMismatch) || errors.Is(err, fileupload.ErrInvalidJSON) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("Error saving ingest file: %v", err), request), response)
+		} else {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Error saving ingest file: %v", err), request), response)
+		}
+	} else if _, err := ingest.CreateIngestTask(request.Context(), s.DB, fileName, upload.FileType, requestId, upload.FileUploadJobID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := fileupload.AbortResumableUpload(request.Context(), s.DB, upload); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// parseResumableUploadIDs returns the file upload job ID and resumable upload ID from the request path
+func parseResumableUploadIDs(request *http.Request) (int64, int64, error) {
+	var (
+		fileUploadJobIdString = mux.Vars(request)[FileUploadJobIdPathParameterName]
+		uploadIdString        = mux.Vars(request)[ResumableUploadIdPathParameterName]
+	)
+
+	if fileUploadJobID, err := strconv.ParseInt(fileUploadJobIdString, 10, 64); err != nil {
+		return 0, 0, err
+	} else if uploadID, err := strconv.ParseInt(uploadIdString, 10, 64); err != nil {
+		return 0, 0, err
+	} else {
+		return fileUploadJobID, uploadID, nil
+	}
+}
+
 func (s Resources) ListAcceptedFileUploadTypes(response http.ResponseWriter, request *http.Request) {
 	api.WriteBasicResponse(request.Context(), ingestModel.AllowedFileUploadTypes, http.StatusOK, response)
 }
@@ -18,20 +18,30 @@ package v2_test
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
 	v2 "github.com/specterops/bloodhound/src/api/v2"
 	"github.com/specterops/bloodhound/src/api/v2/apitest"
 	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/config"
 	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/database"
 	dbMocks "github.com/specterops/bloodhound/src/database/mocks"
 	"github.com/specterops/bloodhound/src/database/types/null"
 	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/model/ingest"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -140,6 +150,33 @@ func TestResources_StartFileUploadJob(t *testing.T) {
 					apitest.StatusCode(output, http.StatusCreated)
 				},
 			},
+			{
+				Name: "InvalidDryRun",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.AddQueryParam(input, "dry_run", "maybe")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "SuccessDryRun",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.AddQueryParam(input, "dry_run", "true")
+				},
+				Setup: func() {
+					mockDB.EXPECT().CreateFileUploadJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job model.FileUploadJob) (model.FileUploadJob, error) {
+						require.True(t, job.DryRun)
+						return job, nil
+					})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusCreated)
+					apitest.BodyContains(output, `"dry_run":true`)
+				},
+			},
 		})
 }
 
@@ -223,6 +260,417 @@ func TestResources_EndFileUploadJob(t *testing.T) {
 		})
 }
 
+func TestResources_StartResumableUpload(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		workDir   = t.TempDir()
+		resources = v2.Resources{DB: mockDB, Config: config.Configuration{WorkDir: workDir, MaxResumableUploadSize: 1}}
+		checksum  = strings.Repeat("ab", 32)
+	)
+	defer mockCtrl.Finish()
+
+	require.Nil(t, os.MkdirAll(resources.Config.TempDirectory(), 0755))
+
+	apitest.
+		NewHarness(t, resources.StartResumableUpload).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidJobID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "invalid")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "InvalidContentType",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.StartResumableUploadRequest{ContentType: "text/plain", Length: 10, SHA256: checksum})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "Content type must be")
+				},
+			},
+			{
+				Name: "InvalidJobStatus",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.StartResumableUploadRequest{ContentType: "application/zip", Length: 10, SHA256: checksum})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{Status: model.JobStatusComplete}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "job must be in running status")
+				},
+			},
+			{
+				Name: "InvalidChecksum",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.StartResumableUploadRequest{ContentType: "application/zip", Length: 10, SHA256: "abc"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{Status: model.JobStatusRunning}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "SHA-256")
+				},
+			},
+			{
+				Name: "TooLarge",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.StartResumableUploadRequest{ContentType: "application/zip", Length: 1<<30 + 1, SHA256: checksum})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{Status: model.JobStatusRunning}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusRequestEntityTooLarge)
+					apitest.BodyContains(output, "maximum upload length")
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.StartResumableUploadRequest{ContentType: "application/zip", Length: 10, SHA256: checksum})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{Status: model.JobStatusRunning, BigSerial: model.BigSerial{ID: 123}}, nil)
+					mockDB.EXPECT().CreateResumableUpload(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, upload model.ResumableUpload) (model.ResumableUpload, error) {
+						require.Equal(t, model.FileTypeZip, upload.FileType)
+						upload.ID = 7
+						return upload, nil
+					})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusCreated)
+					apitest.Header(output, headers.Location.String(), "/api/v2/file-upload/123/uploads/7")
+					apitest.Header(output, v2.HeaderUploadOffset, "0")
+					apitest.BodyContains(output, `"length":10`)
+				},
+			},
+		})
+}
+
+func TestResources_GetResumableUploadOffset(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resources.GetResumableUploadOffset).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+			apitest.SetURLVar(input, v2.ResumableUploadIdPathParameterName, "7")
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidUploadID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.ResumableUploadIdPathParameterName, "invalid")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "UploadNotFound",
+				Setup: func() {
+					mockDB.EXPECT().GetResumableUpload(gomock.Any(), int64(123), int64(7)).Return(model.ResumableUpload{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Success",
+				Setup: func() {
+					mockDB.EXPECT().GetResumableUpload(gomock.Any(), int64(123), int64(7)).Return(model.ResumableUpload{Length: 100, Offset: 40}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.Header(output, v2.HeaderUploadOffset, "40")
+					apitest.Header(output, v2.HeaderUploadLength, "100")
+				},
+			},
+		})
+}
+
+func TestResources_WriteResumableUpload(t *testing.T) {
+	const content = `{"data": [], "meta": {"type": "users", "version": 5, "count": 0, "methods": 0}}`
+
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB, Config: config.Configuration{WorkDir: t.TempDir()}}
+		digest    = sha256.Sum256([]byte(content))
+		upload    = model.ResumableUpload{
+			FileUploadJobID: 123,
+			FileType:        model.FileTypeJson,
+			Length:          int64(len(content)),
+			Offset:          10,
+			SHA256:          hex.EncodeToString(digest[:]),
+			BigSerial:       model.BigSerial{ID: 7},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	require.Nil(t, os.MkdirAll(resources.Config.TempDirectory(), 0755))
+
+	uploadFile, err := os.CreateTemp(resources.Config.TempDirectory(), "bh")
+	require.Nil(t, err)
+	upload.FileName = uploadFile.Name()
+
+	_, err = uploadFile.WriteString(content[:10])
+	require.Nil(t, err)
+	require.Nil(t, uploadFile.Close())
+
+	apitest.
+		NewHarness(t, resources.WriteResumableUpload).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+			apitest.SetURLVar(input, v2.ResumableUploadIdPathParameterName, "7")
+			apitest.SetHeader(input, headers.ContentType.String(), v2.ResumableUploadContentType)
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidContentType",
+				Input: func(input *apitest.Input) {
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.SetHeader(input, v2.HeaderUploadOffset, "10")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "MissingOffset",
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, v2.HeaderUploadOffset)
+				},
+			},
+			{
+				Name: "OffsetMismatch",
+				Input: func(input *apitest.Input) {
+					apitest.SetHeader(input, v2.HeaderUploadOffset, "0")
+					apitest.BodyString(input, content)
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetResumableUpload(gomock.Any(), int64(123), int64(7)).Return(upload, nil)
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{Status: model.JobStatusRunning}, nil)
+					mockDB.EXPECT().LockResumableUpload(gomock.Any(), int64(123), int64(7), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ int64, delegate func(upload *model.ResumableUpload) error) (model.ResumableUpload, error) {
+						locked := upload
+						return locked, delegate(&locked)
+					})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+				},
+			},
+			{
+				Name: "AlreadyFinished",
+				Input: func(input *apitest.Input) {
+					apitest.SetHeader(input, v2.HeaderUploadOffset, strconv.Itoa(len(content)))
+				},
+				Setup: func() {
+					finished := upload
+					finished.Offset = finished.Length
+					finished.Finished = true
+
+					mockDB.EXPECT().GetResumableUpload(gomock.Any(), int64(123), int64(7)).Return(finished, nil)
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{Status: model.JobStatusRunning}, nil)
+					mockDB.EXPECT().LockResumableUpload(gomock.Any(), int64(123), int64(7), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ int64, delegate func(upload *model.ResumableUpload) error) (model.ResumableUpload, error) {
+						locked := finished
+						return locked, delegate(&locked)
+					})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetHeader(input, v2.HeaderUploadOffset, "10")
+					apitest.BodyString(input, content[10:])
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetResumableUpload(gomock.Any(), int64(123), int64(7)).Return(upload, nil)
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{Status: model.JobStatusRunning}, nil)
+					mockDB.EXPECT().LockResumableUpload(gomock.Any(), int64(123), int64(7), gomock.Any()).DoAndReturn(func(_ context.Context, _, _ int64, delegate func(upload *model.ResumableUpload) error) (model.ResumableUpload, error) {
+						locked := upload
+						return locked, delegate(&locked)
+					})
+					mockDB.EXPECT().UpdateFileUploadJob(gomock.Any(), gomock.Any()).Return(nil)
+					mockDB.EXPECT().CreateIngestTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task model.IngestTask) (model.IngestTask, error) {
+						fileContent, err := os.ReadFile(task.FileName)
+						require.Nil(t, err)
+						require.Equal(t, content, string(fileContent))
+						require.Equal(t, int64(123), task.TaskID.Int64)
+
+						return task, nil
+					})
+					mockDB.EXPECT().DeleteResumableUpload(gomock.Any(), gomock.Any()).Return(nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNoContent)
+					apitest.Header(output, v2.HeaderUploadOffset, strconv.Itoa(len(content)))
+				},
+			},
+		})
+}
+
+func TestResources_GetFileUploadJobResults(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resources.GetFileUploadJobResults).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidJobID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "invalid")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "JobNotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "GetFileUploadJobResultsDatabaseError",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{}, nil)
+					mockDB.EXPECT().GetFileUploadJobResults(gomock.Any(), int64(123)).Return(nil, errors.New("db error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{}, nil)
+					mockDB.EXPECT().GetFileUploadJobResults(gomock.Any(), int64(123)).Return(model.FileUploadJobResults{{
+						FileUploadJobID: 123,
+						FileName:        "20240101_groups.json",
+						DataType:        "groups",
+						ObjectsRead:     2,
+						ObjectsWritten:  1,
+						ObjectsSkipped:  1,
+						ErrorCount:      1,
+						Errors: model.IngestErrors{{
+							Offset:  42,
+							Message: "json: cannot unmarshal number into Go value of type ein.Group",
+						}},
+					}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"file_name":"20240101_groups.json"`)
+					apitest.BodyContains(output, `"objects_skipped":1`)
+					apitest.BodyContains(output, `"offset":42`)
+				},
+			},
+		})
+}
+
+func TestResources_GetFileUploadJobReport(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resources.GetFileUploadJobReport).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidJobID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "invalid")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "NotDryRun",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).Return(model.FileUploadJob{DryRun: true}, nil)
+					mockDB.EXPECT().GetFileUploadJobResults(gomock.Any(), int64(123)).Return(model.FileUploadJobResults{{
+						FileName: "users.json",
+						Report: &model.IngestReport{
+							NodeKinds:         map[string]int{"User": 2},
+							UnknownProperties: map[string]int{"favoritecolor": 1},
+						},
+					}, {
+						FileName: "computers.json",
+						Report: &model.IngestReport{
+							NodeKinds: map[string]int{"User": 1, "Computer": 1},
+						},
+					}, {
+						FileName: "broken.json",
+					}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"node_kinds":{"Computer":1,"User":3}`)
+					apitest.BodyContains(output, `"unknown_properties":{"favoritecolor":1}`)
+				},
+			},
+		})
+}
+
 func TestResources_ListAcceptedFileUploadTypes(t *testing.T) {
 	bytes, err := json.Marshal(ingest.AllowedFileUploadTypes)
 	if err != nil {
@@ -17,9 +17,12 @@
 package v2
 
 import (
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/ein"
 	"github.com/specterops/bloodhound/graphschema/ad"
 	"github.com/specterops/bloodhound/graphschema/azure"
 	"github.com/stretchr/testify/require"
@@ -53,3 +56,31 @@ func Test_parseRelationshipKindsParam(t *testing.T) {
 	_, _, err = parseRelationshipKindsParam(validKinds, "LOLNO:Contains,GenericAll")
 	require.NotNil(t, err)
 }
+
+func Test_parseSessionFilterParams(t *testing.T) {
+	// Default case
+	filter, err := parseSessionFilterParams(url.Values{})
+
+	require.Nil(t, err)
+	require.True(t, filter.IsEmpty())
+
+	// Valid parameter definition
+	before := time.Now().UTC()
+	filter, err = parseSessionFilterParams(url.Values{
+		"session_max_age": []string{"P7D"},
+		"session_source":  []string{ein.SessionSourceRegistry},
+	})
+
+	require.Nil(t, err)
+	require.False(t, filter.IsEmpty())
+	require.Equal(t, ein.SessionSourceRegistry, filter.Source)
+	require.WithinDuration(t, before.Add(-7*24*time.Hour), filter.Since, time.Minute)
+
+	// Expect an error if the duration is malformed
+	_, err = parseSessionFilterParams(url.Values{"session_max_age": []string{"7 days"}})
+	require.NotNil(t, err)
+
+	// Expect an error if the source is unknown
+	_, err = parseSessionFilterParams(url.Values{"session_source": []string{"NOTASOURCE"}})
+	require.NotNil(t, err)
+}
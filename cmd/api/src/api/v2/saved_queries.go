@@ -20,15 +20,18 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cypher/frontend"
 	"github.com/specterops/bloodhound/src/api"
 	"github.com/specterops/bloodhound/src/auth"
 	ctx2 "github.com/specterops/bloodhound/src/ctx"
 	"github.com/specterops/bloodhound/src/database"
 	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/queries"
 	"gorm.io/gorm/utils"
 )
 
@@ -137,9 +140,40 @@ func (s Resources) ListSavedQueries(response http.ResponseWriter, request *http.
 }
 
 type CreateSavedQueryRequest struct {
-	Query       string `json:"query"`
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Query       string                     `json:"query"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description,omitempty"`
+	Parameters  model.SavedQueryParameters `json:"parameters,omitempty"`
+}
+
+// validateSavedQueryParameters checks that the declared parameters of a saved query are well-formed and that they
+// match the parameters referenced by the saved query's cypher
+func validateSavedQueryParameters(rawCypher string, parameters model.SavedQueryParameters) error {
+	if err := parameters.Validate(); err != nil {
+		return err
+	} else if len(parameters) == 0 {
+		return nil
+	} else if queryModel, err := frontend.ParseCypher(frontend.NewContext(), rawCypher); err != nil {
+		return err
+	} else if referencedNames, err := queries.CypherQueryParameterNames(queryModel); err != nil {
+		return err
+	} else {
+		declaredNames := parameters.Names()
+
+		for _, name := range referencedNames {
+			if !slices.Contains(declaredNames, name) {
+				return fmt.Errorf("query references undeclared parameter $%s", name)
+			}
+		}
+
+		for _, name := range declaredNames {
+			if !slices.Contains(referencedNames, name) {
+				return fmt.Errorf("declared parameter $%s is not referenced by the query", name)
+			}
+		}
+	}
+
+	return nil
 }
 
 func (s Resources) CreateSavedQuery(response http.ResponseWriter, request *http.Request) {
@@ -153,7 +187,9 @@ func (s Resources) CreateSavedQuery(response http.ResponseWriter, request *http.
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
 	} else if createRequest.Name == "" || createRequest.Query == "" {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "the name and/or query field is empty", request), response)
-	} else if savedQuery, err := s.DB.CreateSavedQuery(request.Context(), user.ID, createRequest.Name, createRequest.Query, createRequest.Description); err != nil {
+	} else if err := validateSavedQueryParameters(createRequest.Query, createRequest.Parameters); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if savedQuery, err := s.DB.CreateSavedQuery(request.Context(), user.ID, createRequest.Name, createRequest.Query, createRequest.Description, createRequest.Parameters); err != nil {
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "duplicate name for saved query: please choose a different name", request), response)
 		} else {
@@ -208,14 +244,57 @@ func (s Resources) UpdateSavedQuery(response http.ResponseWriter, request *http.
 	if updateRequest.Description != "" {
 		savedQuery.Description = updateRequest.Description
 	}
+	if updateRequest.Parameters != nil {
+		savedQuery.Parameters = updateRequest.Parameters
+	}
 
-	if savedQuery, err = s.DB.UpdateSavedQuery(request.Context(), savedQuery); err != nil {
+	if err = validateSavedQueryParameters(savedQuery.Query, savedQuery.Parameters); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if savedQuery, err = s.DB.UpdateSavedQuery(request.Context(), savedQuery); err != nil {
 		api.HandleDatabaseError(request, response, err)
 	} else {
 		api.WriteBasicResponse(request.Context(), savedQuery, http.StatusOK, response)
 	}
 }
 
+type RunSavedQueryRequest struct {
+	Parameters        map[string]any `json:"parameters,omitempty"`
+	IncludeProperties bool           `json:"include_properties,omitempty"`
+}
+
+// RunSavedQuery runs a saved query that the user owns or that has been shared with them, binding the given values
+// to the saved query's declared parameters
+func (s Resources) RunSavedQuery(response http.ResponseWriter, request *http.Request) {
+	var (
+		rawSavedQueryID = mux.Vars(request)[api.URIPathVariableSavedQueryID]
+		runRequest      RunSavedQueryRequest
+	)
+
+	if user, isUser := auth.GetUserFromAuthCtx(ctx2.FromRequest(request).AuthCtx); !isUser {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "No associated user found", request), response)
+	} else if err := api.ReadJSONRequestPayloadLimited(&runRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if savedQueryID, err := strconv.ParseInt(rawSavedQueryID, 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if savedQuery, err := s.DB.GetSavedQuery(request.Context(), savedQueryID); errors.Is(err, database.ErrNotFound) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "query does not exist", request), response)
+	} else if err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if scopes, err := s.DB.GetScopeForSavedQuery(request.Context(), savedQueryID, user.ID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if !scopes[model.SavedQueryScopeOwned] && !scopes[model.SavedQueryScopeShared] && !scopes[model.SavedQueryScopePublic] {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "query does not exist", request), response)
+	} else if err := savedQuery.Parameters.ValidateValues(runRequest.Parameters); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else {
+		s.runCypherQuery(response, request, CypherQueryPayload{
+			Query:             savedQuery.Query,
+			Parameters:        runRequest.Parameters,
+			IncludeProperties: runRequest.IncludeProperties,
+		})
+	}
+}
+
 func (s Resources) DeleteSavedQuery(response http.ResponseWriter, request *http.Request) {
 	var (
 		rawSavedQueryID = mux.Vars(request)[api.URIPathVariableSavedQueryID]
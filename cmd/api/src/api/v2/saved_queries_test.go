@@ -646,7 +646,7 @@ func TestResources_CreateSavedQuery_DuplicateName(t *testing.T) {
 	userId, err := uuid2.NewV4()
 	require.Nil(t, err)
 
-	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(model.SavedQuery{}, fmt.Errorf("duplicate key value violates unique constraint \"idx_saved_queries_composite_index\""))
+	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(model.SavedQuery{}, fmt.Errorf("duplicate key value violates unique constraint \"idx_saved_queries_composite_index\""))
 
 	payload := v2.CreateSavedQueryRequest{
 		Query: "Match(n) return n",
@@ -685,7 +685,7 @@ func TestResources_CreateSavedQuery_CreateFailure(t *testing.T) {
 		Description: "An example description",
 	}
 
-	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query, payload.Description).Return(model.SavedQuery{}, fmt.Errorf("foo"))
+	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query, payload.Description, payload.Parameters).Return(model.SavedQuery{}, fmt.Errorf("foo"))
 
 	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "POST", endpoint, must.MarshalJSONReader(payload))
 	require.Nil(t, err)
@@ -718,7 +718,7 @@ func TestResources_CreateSavedQuery(t *testing.T) {
 		Description: "An example description",
 	}
 
-	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query, payload.Description).Return(model.SavedQuery{
+	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query, payload.Description, payload.Parameters).Return(model.SavedQuery{
 		UserID:      userId.String(),
 		Name:        payload.Name,
 		Query:       payload.Query,
@@ -738,6 +738,83 @@ func TestResources_CreateSavedQuery(t *testing.T) {
 	require.Equal(t, http.StatusCreated, response.Code)
 }
 
+func TestResources_CreateSavedQuery_UndeclaredParameter(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	endpoint := "/api/v2/saved-queries"
+	userId, err := uuid2.NewV4()
+	require.Nil(t, err)
+
+	payload := v2.CreateSavedQueryRequest{
+		Query: "match (n:Domain) where n.name = $domain and n.objectid = $objectid return n",
+		Name:  "myCustomQuery1",
+		Parameters: model.SavedQueryParameters{{
+			Name: "domain",
+			Type: model.SavedQueryParameterTypeString,
+		}},
+	}
+
+	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "POST", endpoint, must.MarshalJSONReader(payload))
+	require.Nil(t, err)
+
+	req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+	router := mux.NewRouter()
+	router.HandleFunc(endpoint, resources.CreateSavedQuery).Methods("POST")
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, req)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "undeclared parameter $objectid")
+}
+
+func TestResources_CreateSavedQuery_WithParameters(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	endpoint := "/api/v2/saved-queries"
+	userId, err := uuid2.NewV4()
+	require.Nil(t, err)
+
+	payload := v2.CreateSavedQueryRequest{
+		Query: "match (n:Domain) where n.name = $domain return n",
+		Name:  "myCustomQuery1",
+		Parameters: model.SavedQueryParameters{{
+			Name:        "domain",
+			Type:        model.SavedQueryParameterTypeString,
+			Description: "Name of the domain",
+		}},
+	}
+
+	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query, payload.Description, payload.Parameters).Return(model.SavedQuery{
+		UserID:     userId.String(),
+		Name:       payload.Name,
+		Query:      payload.Query,
+		Parameters: payload.Parameters,
+	}, nil)
+
+	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "POST", endpoint, must.MarshalJSONReader(payload))
+	require.Nil(t, err)
+
+	req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+	router := mux.NewRouter()
+	router.HandleFunc(endpoint, resources.CreateSavedQuery).Methods("POST")
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, req)
+	require.Equal(t, http.StatusCreated, response.Code)
+}
+
 func TestResources_UpdateSavedQuery_InvalidBody(t *testing.T) {
 	var (
 		mockCtrl  = gomock.NewController(t)
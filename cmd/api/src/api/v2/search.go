@@ -58,7 +58,7 @@ func (s *Resources) GetAvailableDomains(response http.ResponseWriter, request *h
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsNotSortable, request), response)
 	} else if filterCriteria, err := domains.GetFilterCriteria(request); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
-	} else if nodes, err := s.GraphQuery.GetFilteredAndSortedNodes(orderCriteria, filterCriteria); err != nil {
+	} else if nodes, err := s.GraphQuery.GetFilteredAndSortedNodes(request.Context(), orderCriteria, filterCriteria); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("%s: %s", api.ErrorResponseDetailsInternalServerError, err), request), response)
 	} else {
 		api.WriteBasicResponse(request.Context(), setNodeProperties(nodes), http.StatusOK, response)
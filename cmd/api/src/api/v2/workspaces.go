@@ -0,0 +1,197 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/api/middleware"
+	ctx2 "github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+type WorkspaceRequest struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	MemberIDs   []uuid.UUID `json:"member_ids"`
+}
+
+func (s Resources) workspaceMembers(ctx context.Context, memberIDs []uuid.UUID) (model.Users, error) {
+	members := make(model.Users, 0, len(memberIDs))
+
+	for _, memberID := range memberIDs {
+		if member, err := s.DB.GetUser(ctx, memberID); err != nil {
+			return nil, err
+		} else {
+			members = append(members, member)
+		}
+	}
+
+	return members, nil
+}
+
+// assertWorkspaceGraph creates the graph partitions of the given workspace if they do not exist yet
+func (s Resources) assertWorkspaceGraph(ctx context.Context, workspace model.Workspace) error {
+	return s.Graph.WriteTransaction(graph.WithGraphTarget(ctx, workspace.Graph()), func(tx graph.Transaction) error {
+		_, err := tx.Nodes().Count()
+		return err
+	})
+}
+
+// deleteWorkspaceGraph removes every node and relationship of the graph of the given workspace
+func (s Resources) deleteWorkspaceGraph(ctx context.Context, workspace model.Workspace) error {
+	return s.Graph.WriteTransaction(graph.WithGraphTarget(ctx, workspace.Graph()), func(tx graph.Transaction) error {
+		if err := tx.Relationships().Delete(); err != nil {
+			return err
+		}
+
+		return tx.Nodes().Delete()
+	})
+}
+
+func (s Resources) getWorkspace(response http.ResponseWriter, request *http.Request) (model.Workspace, bool) {
+	if workspaceID, err := strconv.ParseInt(mux.Vars(request)[api.URIPathVariableWorkspaceID], 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if workspace, err := s.DB.GetWorkspace(request.Context(), workspaceID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		return workspace, true
+	}
+
+	return model.Workspace{}, false
+}
+
+func (s Resources) ListWorkspaces(response http.ResponseWriter, request *http.Request) {
+	var (
+		authCtx = ctx2.FromRequest(request).AuthCtx
+		sortBy  = request.URL.Query().Get(api.QueryParameterSortBy)
+		order   string
+	)
+
+	if sortBy != "" {
+		column := strings.TrimPrefix(sortBy, "-")
+
+		if !(model.Workspaces{}).IsSortable(column) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsNotSortable, request), response)
+			return
+		} else if order = column; strings.HasPrefix(sortBy, "-") {
+			order += " desc"
+		}
+	}
+
+	if workspaces, err := s.DB.GetAllWorkspaces(request.Context(), order); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		accessible := make(model.Workspaces, 0, len(workspaces))
+
+		for _, workspace := range workspaces {
+			if middleware.CanAccessWorkspace(s.Authorizer, authCtx, workspace) {
+				accessible = append(accessible, workspace)
+			}
+		}
+
+		api.WriteBasicResponse(request.Context(), accessible, http.StatusOK, response)
+	}
+}
+
+func (s Resources) GetWorkspace(response http.ResponseWriter, request *http.Request) {
+	if workspace, ok := s.getWorkspace(response, request); !ok {
+		return
+	} else if !middleware.CanAccessWorkspace(s.Authorizer, ctx2.FromRequest(request).AuthCtx, workspace) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsResourceNotFound, request), response)
+	} else {
+		api.WriteBasicResponse(request.Context(), workspace, http.StatusOK, response)
+	}
+}
+
+func (s Resources) CreateWorkspace(response http.ResponseWriter, request *http.Request) {
+	var createRequest WorkspaceRequest
+
+	if !pg.IsPostgreSQLGraph(s.Graph) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotImplemented, api.ErrorResponseDetailsWorkspacesUnsupported, request), response)
+	} else if err := api.ReadJSONRequestPayloadLimited(&createRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if createRequest.Name == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "workspace name is required", request), response)
+	} else if members, err := s.workspaceMembers(request.Context(), createRequest.MemberIDs); errors.Is(err, database.ErrNotFound) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "workspace member does not exist", request), response)
+	} else if err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if workspace, err := s.DB.CreateWorkspace(request.Context(), model.Workspace{
+		Name:        createRequest.Name,
+		Description: createRequest.Description,
+		Members:     members,
+	}); errors.Is(err, database.ErrDuplicateWorkspaceName) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, "duplicate workspace name", request), response)
+	} else if err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := s.assertWorkspaceGraph(request.Context(), workspace); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else {
+		api.WriteBasicResponse(request.Context(), workspace, http.StatusCreated, response)
+	}
+}
+
+func (s Resources) UpdateWorkspace(response http.ResponseWriter, request *http.Request) {
+	var updateRequest WorkspaceRequest
+
+	if workspace, ok := s.getWorkspace(response, request); !ok {
+		return
+	} else if err := api.ReadJSONRequestPayloadLimited(&updateRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if updateRequest.Name == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "workspace name is required", request), response)
+	} else if members, err := s.workspaceMembers(request.Context(), updateRequest.MemberIDs); errors.Is(err, database.ErrNotFound) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "workspace member does not exist", request), response)
+	} else if err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		workspace.Name = updateRequest.Name
+		workspace.Description = updateRequest.Description
+		workspace.Members = members
+
+		if workspace, err := s.DB.UpdateWorkspace(request.Context(), workspace); errors.Is(err, database.ErrDuplicateWorkspaceName) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, "duplicate workspace name", request), response)
+		} else if err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), workspace, http.StatusOK, response)
+		}
+	}
+}
+
+func (s Resources) DeleteWorkspace(response http.ResponseWriter, request *http.Request) {
+	if workspace, ok := s.getWorkspace(response, request); !ok {
+		return
+	} else if err := s.deleteWorkspaceGraph(request.Context(), workspace); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if err := s.DB.DeleteWorkspace(request.Context(), workspace); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 
 	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
 	"github.com/specterops/bloodhound/dawgs/drivers/neo4j"
 	"github.com/specterops/bloodhound/dawgs/drivers/pg"
 	"github.com/specterops/bloodhound/dawgs/graph"
@@ -73,34 +74,44 @@ func DefaultConfigFilePath() string {
 }
 
 func ConnectGraph(ctx context.Context, cfg config.Configuration) (*graph.DatabaseSwitch, error) {
-	var connectionString string
+	var (
+		driverName       = cfg.GraphDriver
+		connectionString string
+		err              error
+	)
+
+	// The in-memory driver keeps no state outside of this process and can not be the target of a graph migration
+	if driverName != memory.DriverName {
+		if driverName, err = tools.LookupGraphDriver(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
 
-	if driverName, err := tools.LookupGraphDriver(ctx, cfg); err != nil {
-		return nil, err
-	} else {
-		switch driverName {
-		case neo4j.DriverName:
-			log.Infof("Connecting to graph using Neo4j")
-			connectionString = cfg.Neo4J.Neo4jConnectionString()
+	switch driverName {
+	case neo4j.DriverName:
+		log.Infof("Connecting to graph using Neo4j")
+		connectionString = cfg.Neo4J.Neo4jConnectionString()
 
-		case pg.DriverName:
-			log.Infof("Connecting to graph using PostgreSQL")
-			connectionString = cfg.Database.PostgreSQLConnectionString()
+	case pg.DriverName:
+		log.Infof("Connecting to graph using PostgreSQL")
+		connectionString = cfg.Database.PostgreSQLConnectionString()
 
-		default:
-			return nil, fmt.Errorf("unknown graphdb driver name: %s", driverName)
-		}
+	case memory.DriverName:
+		log.Infof("Connecting to graph using the in-memory driver")
 
-		if connectionString == "" {
-			return nil, fmt.Errorf("graph connection requires a connection url to be set")
-		} else if graphDatabase, err := dawgs.Open(ctx, driverName, dawgs.Config{
-			GraphQueryMemoryLimit: size.Size(cfg.GraphQueryMemoryLimit) * size.Gibibyte,
-			DriverCfg:             connectionString,
-		}); err != nil {
-			return nil, err
-		} else {
-			return graph.NewDatabaseSwitch(ctx, graphDatabase), nil
-		}
+	default:
+		return nil, fmt.Errorf("unknown graphdb driver name: %s", driverName)
+	}
+
+	if connectionString == "" && driverName != memory.DriverName {
+		return nil, fmt.Errorf("graph connection requires a connection url to be set")
+	} else if graphDatabase, err := dawgs.Open(ctx, driverName, dawgs.Config{
+		GraphQueryMemoryLimit: size.Size(cfg.GraphQueryMemoryLimit) * size.Gibibyte,
+		DriverCfg:             connectionString,
+	}); err != nil {
+		return nil, err
+	} else {
+		return graph.NewDatabaseSwitch(ctx, graphDatabase), nil
 	}
 }
 
@@ -154,6 +154,9 @@ type Configuration struct {
 	DefaultAdmin                 DefaultAdminConfiguration `json:"default_admin"`
 	CollectorsBasePath           string                    `json:"collectors_base_path"`
 	DatapipeInterval             int                       `json:"datapipe_interval"`
+	IngestWorkers                int                       `json:"ingest_workers"`
+	MaxIngestDecompressedSize    uint16                    `json:"max_ingest_decompressed_size"`
+	MaxResumableUploadSize       uint16                    `json:"max_resumable_upload_size"`
 	EnableStartupWaitPeriod      bool                      `json:"enable_startup_wait_period"`
 	EnableAPILogging             bool                      `json:"enable_api_logging"`
 	EnableCypherMutations        bool                      `json:"enable_cypher_mutations"`
@@ -166,6 +169,17 @@ type Configuration struct {
 	FedRAMPEULAText              string                    `json:"fedramp_eula_text"` // Enterprise only
 }
 
+// MaxIngestDecompressedBytes returns the maximum number of bytes that may be decompressed from a single compressed
+// ingest file or archive. A value of zero disables the limit.
+func (s Configuration) MaxIngestDecompressedBytes() int64 {
+	return int64(s.MaxIngestDecompressedSize) << 30
+}
+
+// MaxResumableUploadBytes returns the maximum length of a single resumable upload. A value of zero disables the limit.
+func (s Configuration) MaxResumableUploadBytes() int64 {
+	return int64(s.MaxResumableUploadSize) << 30
+}
+
 func (s Configuration) AuthSessionTTL() time.Duration {
 	return time.Hour * time.Duration(s.AuthSessionTTLHours)
 }
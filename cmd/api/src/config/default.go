@@ -45,6 +45,9 @@ func NewDefaultConfiguration() (Configuration, error) {
 			LogPath:                      DefaultLogFilePath,
 			CollectorsBasePath:           "/etc/bloodhound/collectors",
 			DatapipeInterval:             60,
+			IngestWorkers:                1,
+			MaxIngestDecompressedSize:    32, // 32 GiB by default
+			MaxResumableUploadSize:       32, // 32 GiB by default
 			EnableStartupWaitPeriod:      true,
 			EnableAPILogging:             true,
 			DisableAnalysis:              false,
@@ -42,6 +42,10 @@ type Context struct {
 	Host         *url.URL
 	RequestedURL model.AuditableURL
 	RequestIP    string
+
+	// Workspace is the workspace selected by the request. Requests without a selected workspace operate on the default
+	// graph.
+	Workspace *model.Workspace
 }
 
 func (s *Context) ConstructGoContext() context.Context {
@@ -42,6 +42,7 @@ type Daemon struct {
 func NewDaemon[DBType database.Database](ctx context.Context, connections bootstrap.DatabaseConnections[DBType, *graph.DatabaseSwitch], cfg config.Configuration, graphSchema graph.Schema, extensions ...func(router *chi.Mux)) Daemon {
 	var (
 		pgMigrator    = tools.NewPGMigrator(ctx, cfg, graphSchema, connections.Graph)
+		backupTool    = tools.NewBackupTool(cfg, connections.RDMS, connections.Graph)
 		router        = chi.NewRouter()
 		toolContainer = tools.NewToolContainer(connections.RDMS)
 	)
@@ -67,6 +68,9 @@ func NewDaemon[DBType database.Database](ctx context.Context, connections bootst
 	router.Get("/pg-migration/status", pgMigrator.MigrationStatus)
 	router.Put("/pg-migration/cancel", pgMigrator.MigrationCancel)
 
+	router.Get("/backup", backupTool.Backup)
+	router.Put("/restore", backupTool.Restore)
+
 	// Allow query of datapipe status for infrastructure tooling
 	router.Get("/datapipe/status", func(w http.ResponseWriter, r *http.Request) {
 		if dpStatus, err := connections.RDMS.GetDatapipeStatus(ctx); err != nil {
@@ -18,6 +18,7 @@ package datapipe
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	commonanalysis "github.com/specterops/bloodhound/analysis"
@@ -36,14 +37,58 @@ import (
 	"github.com/specterops/bloodhound/src/services/agi"
 )
 
-func updateAssetGroupIsolationTags(ctx context.Context, db agi.AgiData, graphDB graph.Database) error {
+func updateAssetGroupIsolationTags(ctx context.Context, db agi.AgiData, graphDB graph.Database, selectorQueryPreparer agi.SelectorQueryPreparer, defaultGraph bool) (agi.AssetGroupSelections, error) {
 	defer log.Measure(log.LevelInfo, "Updated asset group isolation tags")()
 
 	if err := commonanalysis.ClearSystemTags(ctx, graphDB); err != nil {
+		return nil, err
+	}
+
+	return agi.UpdateAssetGroupIsolationTags(ctx, db, graphDB, selectorQueryPreparer, defaultGraph)
+}
+
+// runAssetGroupIsolationCollections records a collection for every asset group and writes an audit log entry when the
+// members of Tier Zero changed since the previous analysis run. Asset groups with selectors that failed to evaluate are
+// neither collected nor audited so that members of the failed selectors are not reported as removed.
+func runAssetGroupIsolationCollections(ctx context.Context, db database.Database, graphDB graph.Database, selections agi.AssetGroupSelections) error {
+	previousTierZeroCollections := map[int32]model.AssetGroupCollection{}
+
+	if assetGroups, err := db.GetAllAssetGroups(ctx, "", model.SQLFilter{}); err != nil {
+		return err
+	} else {
+		for _, assetGroup := range assetGroups {
+			if assetGroup.Tag != model.TierZeroAssetGroupTag || !selections.IsComplete(assetGroup.ID) {
+				continue
+			}
+
+			// Without a previous collection there is nothing to compare the first collection against
+			if previousCollection, err := db.GetLatestAssetGroupCollection(ctx, assetGroup.ID); errors.Is(err, database.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			} else {
+				previousTierZeroCollections[assetGroup.ID] = previousCollection
+			}
+		}
+	}
+
+	if err := agi.RunAssetGroupIsolationCollections(ctx, db, graphDB, selections); err != nil {
 		return err
 	}
 
-	return agi.UpdateAssetGroupIsolationTags(ctx, db, graphDB)
+	for assetGroupID, previousCollection := range previousTierZeroCollections {
+		if latestCollection, err := db.GetLatestAssetGroupCollection(ctx, assetGroupID); err != nil {
+			return err
+		} else if diff := model.DiffAssetGroupCollections(previousCollection, latestCollection); !diff.HasChanges() {
+			continue
+		} else if auditEntry, err := model.NewAuditEntry(model.AuditLogActionTierZeroMembershipChanged, model.AuditLogStatusSuccess, diff.AuditData()); err != nil {
+			return err
+		} else if err := db.AppendAuditLog(ctx, auditEntry); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func ParallelTagAzureTierZero(ctx context.Context, db graph.Database) error {
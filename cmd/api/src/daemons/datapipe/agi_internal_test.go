@@ -0,0 +1,133 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/agi"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunAssetGroupIsolationCollections(t *testing.T) {
+	var (
+		mockCtrl           = gomock.NewController(t)
+		mockDB             = mocks.NewMockDatabase(mockCtrl)
+		graphDB            = memory.NewDatabase(0)
+		tierZeroAssetGroup = model.AssetGroup{Name: "Admin Tier Zero", Tag: model.TierZeroAssetGroupTag, SystemGroup: true, Serial: model.Serial{ID: 1}}
+		tierZeroNode       *graph.Node
+	)
+
+	require.Nil(t, graphDB.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		properties := graph.NewProperties()
+		properties.Set(common.ObjectID.String(), "S-1-5-21-1-512")
+		properties.Set(common.SystemTags.String(), ad.AdminTierZero)
+
+		node, err := tx.CreateNode(properties, ad.Entity, ad.Group)
+		tierZeroNode = node
+
+		return err
+	}))
+
+	selections := agi.AssetGroupSelections{
+		tierZeroAssetGroup.ID: {
+			Matches:  map[graph.ID][]string{tierZeroNode.ID: {"Domain Admins"}},
+			Complete: true,
+		},
+	}
+
+	newCollection := func(objectIDs ...string) model.AssetGroupCollection {
+		collection := model.AssetGroupCollection{AssetGroupID: tierZeroAssetGroup.ID}
+
+		for _, objectID := range objectIDs {
+			collection.Entries = append(collection.Entries, model.AssetGroupCollectionEntry{ObjectID: objectID, Selectors: []string{"Domain Admins"}})
+		}
+
+		return collection
+	}
+
+	expectCollection := func() {
+		mockDB.EXPECT().CreateAssetGroupCollection(gomock.Any(), model.AssetGroupCollection{AssetGroupID: tierZeroAssetGroup.ID}, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ model.AssetGroupCollection, entries model.AssetGroupCollectionEntries) error {
+				require.Len(t, entries, 1)
+				require.Equal(t, "S-1-5-21-1-512", entries[0].ObjectID)
+				require.Equal(t, []string{"Domain Admins"}, entries[0].Selectors)
+
+				return nil
+			})
+	}
+
+	t.Run("first collection is not audited", func(t *testing.T) {
+		mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{tierZeroAssetGroup}, nil).Times(2)
+		mockDB.EXPECT().GetLatestAssetGroupCollection(gomock.Any(), tierZeroAssetGroup.ID).Return(model.AssetGroupCollection{}, database.ErrNotFound)
+		expectCollection()
+
+		require.Nil(t, runAssetGroupIsolationCollections(context.Background(), mockDB, graphDB, selections))
+	})
+
+	t.Run("unchanged membership is not audited", func(t *testing.T) {
+		mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{tierZeroAssetGroup}, nil).Times(2)
+		mockDB.EXPECT().GetLatestAssetGroupCollection(gomock.Any(), tierZeroAssetGroup.ID).Return(newCollection("S-1-5-21-1-512"), nil).Times(2)
+		expectCollection()
+
+		require.Nil(t, runAssetGroupIsolationCollections(context.Background(), mockDB, graphDB, selections))
+	})
+
+	t.Run("changed membership is audited", func(t *testing.T) {
+		var (
+			previousCollection = newCollection("S-1-5-21-1-519")
+			latestCollection   = newCollection("S-1-5-21-1-512")
+			expectedDiff       = model.DiffAssetGroupCollections(previousCollection, latestCollection)
+		)
+
+		mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{tierZeroAssetGroup}, nil).Times(2)
+		gomock.InOrder(
+			mockDB.EXPECT().GetLatestAssetGroupCollection(gomock.Any(), tierZeroAssetGroup.ID).Return(previousCollection, nil),
+			mockDB.EXPECT().GetLatestAssetGroupCollection(gomock.Any(), tierZeroAssetGroup.ID).Return(latestCollection, nil),
+		)
+		expectCollection()
+		mockDB.EXPECT().AppendAuditLog(gomock.Any(), model.AuditEntry{
+			Action: model.AuditLogActionTierZeroMembershipChanged,
+			Model:  expectedDiff.AuditData(),
+			Status: model.AuditLogStatusSuccess,
+		}).Return(nil)
+
+		require.Nil(t, runAssetGroupIsolationCollections(context.Background(), mockDB, graphDB, selections))
+	})
+
+	t.Run("incomplete selection is neither collected nor audited", func(t *testing.T) {
+		incompleteSelections := agi.AssetGroupSelections{
+			tierZeroAssetGroup.ID: {
+				Matches:  map[graph.ID][]string{},
+				Complete: false,
+			},
+		}
+
+		mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{tierZeroAssetGroup}, nil).Times(2)
+
+		require.Nil(t, runAssetGroupIsolationCollections(context.Background(), mockDB, graphDB, incompleteSelections))
+	})
+}
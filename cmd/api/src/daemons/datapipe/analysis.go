@@ -22,13 +22,16 @@ import (
 	"fmt"
 
 	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/cache"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/analysis/ad"
 	"github.com/specterops/bloodhound/src/analysis/azure"
 	"github.com/specterops/bloodhound/src/config"
 	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/model/appcfg"
+	"github.com/specterops/bloodhound/src/queries"
 	"github.com/specterops/bloodhound/src/services/agi"
 	"github.com/specterops/bloodhound/src/services/dataquality"
 )
@@ -38,9 +41,48 @@ var (
 	ErrAnalysisPartiallyCompleted = errors.New("analysis partially completed")
 )
 
-func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB graph.Database, _ config.Configuration) error {
+func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB graph.Database, cfg config.Configuration) error {
+	return runAnalysisOperations(ctx, db, graphDB, cfg, true)
+}
+
+// RunWorkspaceAnalysisOperations runs post-processing against the graph of the given workspace. Asset group isolation
+// collections, selector member counts and data quality stats are only kept for the default graph and are not collected
+// for workspaces.
+func RunWorkspaceAnalysisOperations(ctx context.Context, db database.Database, graphDB graph.Database, cfg config.Configuration, workspace model.Workspace) error {
+	return runAnalysisOperations(graph.WithGraphTarget(ctx, workspace.Graph()), db, graphDB, cfg, false)
+}
+
+// CombineAnalysisErrors reduces the results of several analysis runs to a single result. Analysis fails only when every
+// run failed and is partially completed when any run did not fully complete.
+func CombineAnalysisErrors(errs ...error) error {
+	var failed int
+
+	for _, err := range errs {
+		if errors.Is(err, ErrAnalysisFailed) {
+			failed++
+		}
+	}
+
+	if failed > 0 && failed == len(errs) {
+		return ErrAnalysisFailed
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return ErrAnalysisPartiallyCompleted
+		}
+	}
+
+	return nil
+}
+
+func runAnalysisOperations(ctx context.Context, db database.Database, graphDB graph.Database, cfg config.Configuration, defaultGraph bool) error {
 	var (
 		collectedErrors []error
+		selections      agi.AssetGroupSelections
+
+		// cypher asset group selectors are prepared the same way as cypher queries submitted through the API
+		selectorQueryPreparer = queries.NewGraphQuery(graphDB, cache.Cache{}, cfg)
 	)
 
 	if err := adAnalysis.FixWellKnownNodeTypes(ctx, graphDB); err != nil {
@@ -55,8 +97,10 @@ func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB gr
 		collectedErrors = append(collectedErrors, fmt.Errorf("well known group linking failed: %w", err))
 	}
 
-	if err := updateAssetGroupIsolationTags(ctx, db, graphDB); err != nil {
+	if assetGroupSelections, err := updateAssetGroupIsolationTags(ctx, db, graphDB, selectorQueryPreparer, defaultGraph); err != nil {
 		collectedErrors = append(collectedErrors, fmt.Errorf("asset group isolation tagging failed: %w", err))
+	} else {
+		selections = assetGroupSelections
 	}
 
 	if err := TagActiveDirectoryTierZero(ctx, db, graphDB); err != nil {
@@ -91,14 +135,22 @@ func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB gr
 		stats.LogStats()
 	}
 
-	if err := agi.RunAssetGroupIsolationCollections(ctx, db, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("asset group isolation collection failed: %w", err))
-		agiFailed = true
-	}
+	if defaultGraph {
+		if err := runAssetGroupIsolationCollections(ctx, db, graphDB, selections); err != nil {
+			collectedErrors = append(collectedErrors, fmt.Errorf("asset group isolation collection failed: %w", err))
+			agiFailed = true
+		}
 
-	if err := dataquality.SaveDataQuality(ctx, db, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("error saving data quality stat: %v", err))
-		dataQualityFailed = true
+		if err := dataquality.SaveDataQuality(ctx, db, graphDB); err != nil {
+			collectedErrors = append(collectedErrors, fmt.Errorf("error saving data quality stat: %v", err))
+			dataQualityFailed = true
+		}
+	} else {
+		// Asset group isolation collections and data quality stats are only kept for the default graph. They are
+		// treated as failed along with post-processing so that a workspace whose post-processing failed entirely is
+		// reported as failed.
+		agiFailed = adFailed && azureFailed
+		dataQualityFailed = agiFailed
 	}
 
 	if len(collectedErrors) > 0 {
@@ -0,0 +1,35 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/specterops/bloodhound/src/daemons/datapipe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombineAnalysisErrors(t *testing.T) {
+	assert.Nil(t, datapipe.CombineAnalysisErrors(nil))
+	assert.Nil(t, datapipe.CombineAnalysisErrors(nil, nil))
+	assert.ErrorIs(t, datapipe.CombineAnalysisErrors(datapipe.ErrAnalysisFailed), datapipe.ErrAnalysisFailed)
+	assert.ErrorIs(t, datapipe.CombineAnalysisErrors(datapipe.ErrAnalysisFailed, datapipe.ErrAnalysisFailed), datapipe.ErrAnalysisFailed)
+	assert.ErrorIs(t, datapipe.CombineAnalysisErrors(datapipe.ErrAnalysisFailed, nil), datapipe.ErrAnalysisPartiallyCompleted)
+	assert.ErrorIs(t, datapipe.CombineAnalysisErrors(nil, datapipe.ErrAnalysisPartiallyCompleted), datapipe.ErrAnalysisPartiallyCompleted)
+	assert.ErrorIs(t, datapipe.CombineAnalysisErrors(nil, errors.New("workspace error")), datapipe.ErrAnalysisPartiallyCompleted)
+}
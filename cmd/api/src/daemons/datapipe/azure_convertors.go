@@ -37,6 +37,14 @@ const (
 	PrincipalTypeUser             = "User"
 )
 
+// Azure ingest kinds for Entra ID and Azure Resource Manager objects that AzureHound does not define
+const (
+	KindAZAdministrativeUnit       enums.Kind = "AZAdministrativeUnit"
+	KindAZAdministrativeUnitMember enums.Kind = "AZAdministrativeUnitMember"
+	KindAZCrossTenantAccessPartner enums.Kind = "AZCrossTenantAccessPartner"
+	KindAZRoleDefinition           enums.Kind = "AZRoleDefinition"
+)
+
 func getKindConverter(kind enums.Kind) func(json.RawMessage, *ConvertedAzureData) {
 	switch kind {
 	case enums.KindAZApp:
@@ -141,6 +149,20 @@ func getKindConverter(kind enums.Kind) func(json.RawMessage, *ConvertedAzureData
 		return convertAzureAutomationAccount
 	case enums.KindAZAutomationAccountRoleAssignment:
 		return convertAzureAutomationAccountRoleAssignment
+	case enums.KindAZStorageAccount:
+		return convertAzureStorageAccount
+	case enums.KindAZStorageAccountRoleAssignment:
+		return convertAzureStorageAccountRoleAssignment
+	case enums.KindAZStorageContainer:
+		return convertAzureStorageContainer
+	case KindAZAdministrativeUnit:
+		return convertAzureAdministrativeUnit
+	case KindAZAdministrativeUnitMember:
+		return convertAzureAdministrativeUnitMember
+	case KindAZCrossTenantAccessPartner:
+		return convertAzureCrossTenantAccessPartner
+	case KindAZRoleDefinition:
+		return convertAzureRoleDefinition
 	default:
 		// TODO: we should probably have a hook or something to log the unknown type
 		return func(rm json.RawMessage, cd *ConvertedAzureData) {}
@@ -294,6 +316,44 @@ func convertAzureGroupMember(raw json.RawMessage, converted *ConvertedAzureData)
 	}
 }
 
+func convertAzureAdministrativeUnit(raw json.RawMessage, converted *ConvertedAzureData) {
+	var data ein.AzureAdministrativeUnit
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Errorf(SerialError, "azure administrative unit", err)
+	} else {
+		node, rel := ein.ConvertAzureAdministrativeUnit(data)
+		converted.NodeProps = append(converted.NodeProps, node)
+		converted.RelProps = append(converted.RelProps, rel)
+	}
+}
+
+func convertAzureAdministrativeUnitMember(raw json.RawMessage, converted *ConvertedAzureData) {
+	var data ein.AzureAdministrativeUnitMembers
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Errorf(SerialError, "azure administrative unit members", err)
+	} else {
+		converted.RelProps = append(converted.RelProps, ein.ConvertAzureAdministrativeUnitMembersToRels(data)...)
+	}
+}
+
+func convertAzureCrossTenantAccessPartner(raw json.RawMessage, converted *ConvertedAzureData) {
+	var data ein.AzureCrossTenantAccessPartner
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Errorf(SerialError, "azure cross-tenant access partner", err)
+	} else {
+		converted.RelProps = append(converted.RelProps, ein.ConvertAzureCrossTenantAccessPartner(data))
+	}
+}
+
+func convertAzureRoleDefinition(raw json.RawMessage, converted *ConvertedAzureData) {
+	var data ein.AzureRoleDefinition
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Errorf(SerialError, "azure role definition", err)
+	} else {
+		converted.NodeProps = append(converted.NodeProps, ein.ConvertAzureRoleDefinition(data))
+	}
+}
+
 func convertAzureGroupOwner(raw json.RawMessage, converted *ConvertedAzureData) {
 	var (
 		data models.GroupOwners
@@ -660,6 +720,38 @@ func convertAzureContainerRegistryRoleAssignment(raw json.RawMessage, converted
 	}
 }
 
+func convertAzureStorageAccount(raw json.RawMessage, converted *ConvertedAzureData) {
+	var data models.StorageAccount
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Errorf(SerialError, "azure storage account", err)
+	} else {
+		node, rel := ein.ConvertAzureStorageAccount(data)
+		converted.NodeProps = append(converted.NodeProps, node)
+		converted.RelProps = append(converted.RelProps, rel)
+	}
+}
+
+func convertAzureStorageAccountRoleAssignment(raw json.RawMessage, converted *ConvertedAzureData) {
+	var data models.AzureRoleAssignments
+
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Errorf(SerialError, "azure storage account role assignments", err)
+	} else {
+		converted.RelProps = append(converted.RelProps, ein.ConvertAzureStorageAccountRoleAssignment(data)...)
+	}
+}
+
+func convertAzureStorageContainer(raw json.RawMessage, converted *ConvertedAzureData) {
+	var data models.StorageContainer
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Errorf(SerialError, "azure storage container", err)
+	} else {
+		node, rel := ein.ConvertAzureStorageContainer(data)
+		converted.NodeProps = append(converted.NodeProps, node)
+		converted.RelProps = append(converted.RelProps, rel)
+	}
+}
+
 func convertAzureWebAppRoleAssignment(raw json.RawMessage, converted *ConvertedAzureData) {
 	var data models.AzureRoleAssignments
 
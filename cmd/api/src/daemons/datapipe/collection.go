@@ -0,0 +1,148 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"strings"
+
+	"github.com/specterops/bloodhound/ein"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+// recordCollectedObject records the domain of a decoded ingest object in the given summary along with the collection
+// failures of decoded computers. Nothing is recorded when the summary is nil.
+func recordCollectedObject(summary *model.CollectionSummary, decoded any) {
+	if summary == nil {
+		return
+	}
+
+	domainSID := collectedObjectDomainSID(decoded)
+
+	if computer, isComputer := decoded.(ein.Computer); isComputer {
+		summary.Computers = append(summary.Computers, computer.ObjectIdentifier)
+		summary.ComputerFailures = append(summary.ComputerFailures, computerCollectionFailures(computer, domainSID)...)
+	}
+
+	if domainSID != "" {
+		summary.DomainObjects[domainSID]++
+	}
+}
+
+// propertyDomainSID returns the domain SID recorded in the properties of an ingest object
+func propertyDomainSID(properties map[string]any) string {
+	domainSID, _ := properties[ad.DomainSID.String()].(string)
+	return domainSID
+}
+
+// objectDomainSID returns the domain SID of an ingest object that reports it both as a field and, for older
+// collectors, as a property
+func objectDomainSID(domainSID string, properties map[string]any) string {
+	if domainSID != "" {
+		return domainSID
+	}
+
+	return propertyDomainSID(properties)
+}
+
+// collectedObjectDomainSID returns the SID of the domain that a decoded ingest object was collected from. Sessions are
+// attributed to the domain of the computer they were collected from.
+func collectedObjectDomainSID(decoded any) string {
+	switch typed := decoded.(type) {
+	case ein.Domain:
+		return typed.ObjectIdentifier
+	case ein.Computer:
+		return objectDomainSID(typed.DomainSID, typed.Properties)
+	case ein.User:
+		return objectDomainSID(typed.DomainSID, typed.Properties)
+	case ein.RootCA:
+		return objectDomainSID(typed.DomainSID, typed.Properties)
+	case ein.EnterpriseCA:
+		return objectDomainSID(typed.DomainSID, typed.Properties)
+	case ein.NTAuthStore:
+		return objectDomainSID(typed.DomainSID, typed.Properties)
+	case ein.Group:
+		return propertyDomainSID(typed.Properties)
+	case ein.OU:
+		return propertyDomainSID(typed.Properties)
+	case ein.Container:
+		return propertyDomainSID(typed.Properties)
+	case ein.IssuancePolicy:
+		return propertyDomainSID(typed.Properties)
+	case ein.GPO:
+		return propertyDomainSID(typed.Properties)
+	case ein.AIACA:
+		return propertyDomainSID(typed.Properties)
+	case ein.CertTemplate:
+		return propertyDomainSID(typed.Properties)
+	case ein.Session:
+		if separator := strings.LastIndex(typed.ComputerSID, "-"); separator > 0 {
+			return typed.ComputerSID[:separator]
+		}
+	}
+
+	return ""
+}
+
+// computerCollectionFailures returns a failure for each collection against the computer that the collector attempted
+// but could not complete. Collections that were not attempted report no failure reason and are not returned.
+func computerCollectionFailures(computer ein.Computer, domainSID string) model.ComputerCollectionFailures {
+	var (
+		computerName, _ = computer.Properties[common.Name.String()].(string)
+		failures        model.ComputerCollectionFailures
+		addFailure      = func(collection, reason string) {
+			failures = append(failures, model.ComputerCollectionFailure{
+				ComputerObjectID: computer.ObjectIdentifier,
+				ComputerName:     computerName,
+				DomainSID:        domainSID,
+				Collection:       collection,
+				FailureReason:    reason,
+			})
+		}
+	)
+
+	if !computer.Status.Connectable && computer.Status.Error != "" {
+		addFailure(model.ComputerCollectionStatus, computer.Status.Error)
+	}
+
+	if !computer.Sessions.Collected && computer.Sessions.FailureReason != "" {
+		addFailure(model.ComputerCollectionSessions, computer.Sessions.FailureReason)
+	}
+
+	if !computer.PrivilegedSessions.Collected && computer.PrivilegedSessions.FailureReason != "" {
+		addFailure(model.ComputerCollectionPrivilegedSessions, computer.PrivilegedSessions.FailureReason)
+	}
+
+	if !computer.RegistrySessions.Collected && computer.RegistrySessions.FailureReason != "" {
+		addFailure(model.ComputerCollectionRegistrySessions, computer.RegistrySessions.FailureReason)
+	}
+
+	for _, localGroup := range computer.LocalGroups {
+		if !localGroup.Collected && localGroup.FailureReason != "" {
+			addFailure(model.ComputerCollectionLocalGroup+":"+localGroup.Name, localGroup.FailureReason)
+		}
+	}
+
+	for _, userRight := range computer.UserRights {
+		if !userRight.Collected && userRight.FailureReason != "" {
+			addFailure(model.ComputerCollectionUserRights+":"+userRight.Privilege, userRight.FailureReason)
+		}
+	}
+
+	return failures
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/ein"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordCollectedObject(t *testing.T) {
+	var (
+		summary  = model.NewCollectionSummary()
+		computer = ein.Computer{
+			IngestBase: ein.IngestBase{
+				ObjectIdentifier: "S-1-5-21-1-1000",
+				Properties:       map[string]any{"name": "WKSTN@TESTLAB.LOCAL"},
+			},
+			DomainSID: "S-1-5-21-1",
+			Status: ein.ComputerStatus{
+				Connectable: false,
+				Error:       "PortNotOpen",
+			},
+			Sessions: ein.SessionAPIResult{
+				APIResult: ein.APIResult{FailureReason: "ErrorAccessDenied"},
+			},
+			PrivilegedSessions: ein.SessionAPIResult{
+				APIResult: ein.APIResult{Collected: true},
+			},
+			LocalGroups: []ein.LocalGroupAPIResult{{
+				APIResult: ein.APIResult{FailureReason: "StatusAccessDenied"},
+				Name:      "ADMINISTRATORS@WKSTN.TESTLAB.LOCAL",
+			}, {
+				APIResult: ein.APIResult{Collected: true},
+				Name:      "REMOTE DESKTOP USERS@WKSTN.TESTLAB.LOCAL",
+			}},
+		}
+	)
+
+	recordCollectedObject(summary, computer)
+	recordCollectedObject(summary, ein.Group{IngestBase: ein.IngestBase{Properties: map[string]any{"domainsid": "S-1-5-21-1"}}})
+	recordCollectedObject(summary, ein.Session{ComputerSID: "S-1-5-21-2-1000"})
+	recordCollectedObject(summary, ein.GPO{})
+	recordCollectedObject(nil, computer)
+
+	require.Equal(t, map[string]int{"S-1-5-21-1": 2, "S-1-5-21-2": 1}, summary.DomainObjects)
+	require.Equal(t, []string{"S-1-5-21-1-1000"}, summary.Computers)
+	require.Len(t, summary.ComputerFailures, 3)
+
+	for _, failure := range summary.ComputerFailures {
+		require.Equal(t, "S-1-5-21-1-1000", failure.ComputerObjectID)
+		require.Equal(t, "WKSTN@TESTLAB.LOCAL", failure.ComputerName)
+		require.Equal(t, "S-1-5-21-1", failure.DomainSID)
+	}
+
+	require.Equal(t, model.ComputerCollectionStatus, summary.ComputerFailures[0].Collection)
+	require.Equal(t, "PortNotOpen", summary.ComputerFailures[0].FailureReason)
+	require.Equal(t, model.ComputerCollectionSessions, summary.ComputerFailures[1].Collection)
+	require.Equal(t, "LocalGroup:ADMINISTRATORS@WKSTN.TESTLAB.LOCAL", summary.ComputerFailures[2].Collection)
+}
@@ -48,9 +48,14 @@ func convertComputerData(computer ein.Computer, converted *ConvertedData) {
 			continue
 		}
 
-		if userRight.Privilege == ein.UserRightRemoteInteractiveLogon {
-			converted.RelProps = append(converted.RelProps, ein.ParseUserRightData(userRight, computer, ad.RemoteInteractiveLogonRight)...)
-			baseNodeProp.PropertyMap[ad.HasURA.String()] = true
+		if right, ok := ein.UserRightKind(userRight.Privilege); !ok {
+			continue
+		} else {
+			converted.RelProps = append(converted.RelProps, ein.ParseUserRightData(userRight, computer, right)...)
+
+			if right == ad.RemoteInteractiveLogonRight {
+				baseNodeProp.PropertyMap[ad.HasURA.String()] = true
+			}
 		}
 	}
 
@@ -101,6 +106,10 @@ func convertGPOData(gpo ein.GPO, converted *ConvertedData) {
 	converted.RelProps = append(converted.RelProps, ein.ParseACEData(gpo.Aces, gpo.ObjectIdentifier, ad.GPO)...)
 }
 
+func convertGPOSettingsData(settings ein.GPOSettings, converted *ConvertedData) {
+	converted.NodeProps = append(converted.NodeProps, ein.ParseGPOSettings(settings))
+}
+
 func convertOUData(ou ein.OU, converted *ConvertedData) {
 	converted.NodeProps = append(converted.NodeProps, ein.ConvertObjectToNode(ou.IngestBase, ad.OU))
 	converted.RelProps = append(converted.RelProps, ein.ParseACEData(ou.Aces, ou.ObjectIdentifier, ad.OU)...)
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/specterops/bloodhound/cache"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/bootstrap"
@@ -81,7 +82,7 @@ func (s *Daemon) analyze() {
 
 	defer log.LogAndMeasure(log.LevelInfo, "Graph Analysis")()
 
-	if err := RunAnalysisOperations(s.ctx, s.db, s.graphdb, s.cfg); err != nil {
+	if err := CombineAnalysisErrors(append([]error{RunAnalysisOperations(s.ctx, s.db, s.graphdb, s.cfg)}, s.analyzeWorkspaces()...)...); err != nil {
 		if errors.Is(err, ErrAnalysisFailed) {
 			FailAnalyzedFileUploadJobs(s.ctx, s.db)
 			if err := s.db.SetDatapipeStatus(s.ctx, model.DatapipeStatusIdle, false); err != nil {
@@ -112,6 +113,30 @@ func (s *Daemon) analyze() {
 	}
 }
 
+// analyzeWorkspaces runs analysis against the graph of every workspace and returns the result of each run. Workspaces
+// are only backed by separate graphs when the PostgreSQL graph driver is in use.
+func (s *Daemon) analyzeWorkspaces() []error {
+	if !pg.IsPostgreSQLGraph(s.graphdb) {
+		return nil
+	} else if workspaces, err := s.db.GetAllWorkspaces(s.ctx, ""); err != nil {
+		log.Errorf("Error fetching workspaces for analysis: %v", err)
+		return []error{ErrAnalysisFailed}
+	} else {
+		results := make([]error, 0, len(workspaces))
+
+		for _, workspace := range workspaces {
+			if s.ctx.Err() != nil {
+				break
+			}
+
+			log.Infof("Running analysis for workspace %d (%s)", workspace.ID, workspace.Name)
+			results = append(results, RunWorkspaceAnalysisOperations(s.ctx, s.db, s.graphdb, s.cfg, workspace))
+		}
+
+		return results
+	}
+}
+
 func resetCache(cacher cache.Cache, _ bool) {
 	if err := cacher.Reset(); err != nil {
 		log.Errorf("Error while resetting the cache: %v", err)
@@ -204,11 +229,18 @@ func (s *Daemon) Stop(ctx context.Context) error {
 func (s *Daemon) clearOrphanedData() {
 	if ingestTasks, err := s.db.GetAllIngestTasks(s.ctx); err != nil {
 		log.Errorf("Failed fetching available file upload ingest tasks: %v", err)
+	} else if uploads, err := s.db.GetActiveResumableUploads(s.ctx); err != nil {
+		log.Errorf("Failed fetching active resumable uploads: %v", err)
 	} else {
-		expectedFiles := make([]string, len(ingestTasks))
+		expectedFiles := make([]string, 0, len(ingestTasks)+len(uploads))
+
+		for _, ingestTask := range ingestTasks {
+			expectedFiles = append(expectedFiles, ingestTask.FileName)
+		}
 
-		for idx, ingestTask := range ingestTasks {
-			expectedFiles[idx] = ingestTask.FileName
+		// Files of resumable uploads that belong to running jobs are still being assembled
+		for _, upload := range uploads {
+			expectedFiles = append(expectedFiles, upload.FileName)
 		}
 
 		go s.orphanedFileSweeper.Clear(s.ctx, expectedFiles)
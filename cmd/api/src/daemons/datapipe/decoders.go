@@ -19,11 +19,13 @@ package datapipe
 import (
 	"errors"
 	"io"
+	"strings"
 
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/util"
 	"github.com/specterops/bloodhound/ein"
 	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
 )
 
 /*
@@ -33,7 +35,26 @@ T is any of the ingest types
 */
 type ConversionFunc[T any] func(decoded T, converted *ConvertedData)
 
-func decodeBasicData[T any](batch graph.Batch, reader io.ReadSeeker, conversionFunc ConversionFunc[T]) error {
+// recordChunk records the outcome of submitting a chunk of count converted objects to the batch. Objects in a chunk
+// that failed to submit are counted as skipped. The offset is the position of the first object in the chunk. Submitted
+// objects are only committed with the batch so callers must move them to skipped should the batch fail to commit.
+func recordChunk(result *model.FileUploadJobResult, count int, offset int64, err error) {
+	if err != nil {
+		result.ObjectsSkipped += count
+		result.AddError(offset, err)
+	} else {
+		result.ObjectsWritten += count
+	}
+}
+
+// recordDecodeError records an object that could not be decoded
+func recordDecodeError(result *model.FileUploadJobResult, offset int64, err error) {
+	result.ObjectsRead++
+	result.ObjectsSkipped++
+	result.AddError(offset, err)
+}
+
+func decodeBasicData[T any](batch graph.Batch, reader io.ReadSeeker, result *model.FileUploadJobResult, chunkSize int, conversionFunc ConversionFunc[T]) error {
 	decoder, err := CreateIngestDecoder(reader)
 	if err != nil {
 		return err
@@ -41,31 +62,42 @@ func decodeBasicData[T any](batch graph.Batch, reader io.ReadSeeker, conversionF
 
 	var (
 		count         = 0
+		chunkOffset   = decoder.InputOffset()
 		convertedData ConvertedData
 		errs          = util.NewErrorCollector()
 	)
 
 	for decoder.More() {
 		// This variable needs to be initialized here, otherwise the marshaller will cache the map in the struct
-		var decodeTarget T
+		var (
+			decodeTarget T
+			offset       = decoder.InputOffset()
+		)
+
 		if err := decoder.Decode(&decodeTarget); err != nil {
 			log.Errorf("Error decoding %T object: %v", decodeTarget, err)
 			if errors.Is(err, io.EOF) {
 				break
 			}
+
+			recordDecodeError(result, offset, err)
 			return err
 		} else {
 			count++
+			result.ObjectsRead++
 			conversionFunc(decodeTarget, &convertedData)
+			recordCollectedObject(result.Collection, decodeTarget)
 		}
 
-		if count == IngestCountThreshold {
+		if count == chunkSize {
 			if err = IngestBasicData(batch, convertedData); err != nil {
 				errs.Add(err)
 			}
+
+			recordChunk(result, count, chunkOffset, err)
 			convertedData.Clear()
 			count = 0
-
+			chunkOffset = decoder.InputOffset()
 		}
 	}
 
@@ -73,12 +105,14 @@ func decodeBasicData[T any](batch graph.Batch, reader io.ReadSeeker, conversionF
 		if err = IngestBasicData(batch, convertedData); err != nil {
 			errs.Add(err)
 		}
+
+		recordChunk(result, count, chunkOffset, err)
 	}
 
 	return errs.Combined()
 }
 
-func decodeGroupData(batch graph.Batch, reader io.ReadSeeker) error {
+func decodeGroupData(batch graph.Batch, reader io.ReadSeeker, result *model.FileUploadJobResult, chunkSize int) error {
 	decoder, err := CreateIngestDecoder(reader)
 	if err != nil {
 		return err
@@ -87,26 +121,37 @@ func decodeGroupData(batch graph.Batch, reader io.ReadSeeker) error {
 	var (
 		convertedData = ConvertedGroupData{}
 		count         = 0
+		chunkOffset   = decoder.InputOffset()
 		errs          = util.NewErrorCollector()
 	)
 
 	for decoder.More() {
-		var group ein.Group
+		var (
+			group  ein.Group
+			offset = decoder.InputOffset()
+		)
+
 		if err = decoder.Decode(&group); err != nil {
 			log.Errorf("Error decoding group object: %v", err)
 			if errors.Is(err, io.EOF) {
 				break
 			}
+
+			recordDecodeError(result, offset, err)
 		} else {
 			count++
+			result.ObjectsRead++
 			convertGroupData(group, &convertedData)
-			if count == IngestCountThreshold {
+			recordCollectedObject(result.Collection, group)
+			if count == chunkSize {
 				if err = IngestGroupData(batch, convertedData); err != nil {
 					errs.Add(err)
 				}
 
+				recordChunk(result, count, chunkOffset, err)
 				convertedData.Clear()
 				count = 0
+				chunkOffset = decoder.InputOffset()
 			}
 		}
 	}
@@ -115,12 +160,14 @@ func decodeGroupData(batch graph.Batch, reader io.ReadSeeker) error {
 		if err = IngestGroupData(batch, convertedData); err != nil {
 			errs.Add(err)
 		}
+
+		recordChunk(result, count, chunkOffset, err)
 	}
 
 	return errs.Combined()
 }
 
-func decodeSessionData(batch graph.Batch, reader io.ReadSeeker) error {
+func decodeSessionData(batch graph.Batch, reader io.ReadSeeker, result *model.FileUploadJobResult, chunkSize int) error {
 	decoder, err := CreateIngestDecoder(reader)
 	if err != nil {
 		return err
@@ -129,24 +176,36 @@ func decodeSessionData(batch graph.Batch, reader io.ReadSeeker) error {
 	var (
 		convertedData = ConvertedSessionData{}
 		count         = 0
+		chunkOffset   = decoder.InputOffset()
 		errs          = util.NewErrorCollector()
 	)
 	for decoder.More() {
-		var session ein.Session
+		var (
+			session ein.Session
+			offset  = decoder.InputOffset()
+		)
+
 		if err = decoder.Decode(&session); err != nil {
 			log.Errorf("Error decoding session object: %v", err)
 			if errors.Is(err, io.EOF) {
 				break
 			}
+
+			recordDecodeError(result, offset, err)
 		} else {
 			count++
+			result.ObjectsRead++
 			convertSessionData(session, &convertedData)
-			if count == IngestCountThreshold {
+			recordCollectedObject(result.Collection, session)
+			if count == chunkSize {
 				if err = IngestSessions(batch, convertedData.SessionProps); err != nil {
 					errs.Add(err)
 				}
+
+				recordChunk(result, count, chunkOffset, err)
 				convertedData.Clear()
 				count = 0
+				chunkOffset = decoder.InputOffset()
 			}
 		}
 	}
@@ -155,12 +214,14 @@ func decodeSessionData(batch graph.Batch, reader io.ReadSeeker) error {
 		if err = IngestSessions(batch, convertedData.SessionProps); err != nil {
 			errs.Add(err)
 		}
+
+		recordChunk(result, count, chunkOffset, err)
 	}
 
 	return errs.Combined()
 }
 
-func decodeAzureData(batch graph.Batch, reader io.ReadSeeker) error {
+func decodeAzureData(batch graph.Batch, reader io.ReadSeeker, result *model.FileUploadJobResult, chunkSize int) error {
 	decoder, err := CreateIngestDecoder(reader)
 	if err != nil {
 		return err
@@ -169,26 +230,37 @@ func decodeAzureData(batch graph.Batch, reader io.ReadSeeker) error {
 	var (
 		convertedData = ConvertedAzureData{}
 		count         = 0
+		chunkOffset   = decoder.InputOffset()
 		errs          = util.NewErrorCollector()
 	)
 
 	for decoder.More() {
-		var data AzureBase
+		var (
+			data   AzureBase
+			offset = decoder.InputOffset()
+		)
+
 		if err = decoder.Decode(&data); err != nil {
 			log.Errorf("Error decoding azure object: %v", err)
 			if errors.Is(err, io.EOF) {
 				break
 			}
+
+			recordDecodeError(result, offset, err)
 		} else {
 			convert := getKindConverter(data.Kind)
 			convert(data.Data, &convertedData)
 			count++
-			if count == IngestCountThreshold {
+			result.ObjectsRead++
+			if count == chunkSize {
 				if err = IngestAzureData(batch, convertedData); err != nil {
 					errs.Add(err)
 				}
+
+				recordChunk(result, count, chunkOffset, err)
 				convertedData.Clear()
 				count = 0
+				chunkOffset = decoder.InputOffset()
 			}
 		}
 	}
@@ -197,6 +269,78 @@ func decodeAzureData(batch graph.Batch, reader io.ReadSeeker) error {
 		if err = IngestAzureData(batch, convertedData); err != nil {
 			errs.Add(err)
 		}
+
+		recordChunk(result, count, chunkOffset, err)
+	}
+
+	return errs.Combined()
+}
+
+func decodeDeletedData(batch graph.Batch, reader io.ReadSeeker, result *model.FileUploadJobResult, chunkSize int, removeObjects bool, dryRun bool) error {
+	decoder, err := CreateIngestDecoder(reader)
+	if err != nil {
+		return err
+	}
+
+	var (
+		objectIDs   = make([]string, 0, chunkSize)
+		chunkOffset = decoder.InputOffset()
+		errs        = util.NewErrorCollector()
+	)
+
+	ingestChunk := func() {
+		// Matching deleted objects requires reading the graph, which a dry run can not do
+		if dryRun {
+			recordChunk(result, len(objectIDs), chunkOffset, nil)
+
+			objectIDs = objectIDs[:0]
+			chunkOffset = decoder.InputOffset()
+			return
+		}
+
+		nodes, relationships, err := IngestDeletedObjects(batch, objectIDs, removeObjects)
+		if err != nil {
+			errs.Add(err)
+		} else if removeObjects {
+			result.ObjectsDeleted += nodes
+		} else {
+			result.ObjectsFlagged += nodes
+		}
+
+		result.RelationshipsDeleted += relationships
+		recordChunk(result, len(objectIDs), chunkOffset, err)
+
+		objectIDs = objectIDs[:0]
+		chunkOffset = decoder.InputOffset()
+	}
+
+	for decoder.More() {
+		var (
+			deleted ein.TypedPrincipal
+			offset  = decoder.InputOffset()
+		)
+
+		if err = decoder.Decode(&deleted); err != nil {
+			log.Errorf("Error decoding deleted object: %v", err)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			recordDecodeError(result, offset, err)
+		} else if deleted.ObjectIdentifier == "" {
+			recordDecodeError(result, offset, errors.New("deleted object is missing an object identifier"))
+		} else {
+			result.ObjectsRead++
+			objectIDs = append(objectIDs, strings.ToUpper(deleted.ObjectIdentifier))
+
+			if len(objectIDs) == chunkSize {
+				ingestChunk()
+			}
+		}
+	}
+
+	if len(objectIDs) > 0 {
+		ingestChunk()
 	}
 
 	return errs.Combined()
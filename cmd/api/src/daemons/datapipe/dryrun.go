@@ -0,0 +1,175 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"fmt"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+var (
+	knownNodeKinds         = graph.Kinds(ad.NodeKinds()).Concatenate(azure.NodeKinds())
+	knownRelationshipKinds = graph.Kinds(ad.Relationships()).Concatenate(azure.Relationships())
+	knownProperties        = allKnownProperties()
+)
+
+func allKnownProperties() map[string]struct{} {
+	properties := map[string]struct{}{}
+
+	for _, property := range ad.AllProperties() {
+		properties[property.String()] = struct{}{}
+	}
+
+	for _, property := range azure.AllProperties() {
+		properties[property.String()] = struct{}{}
+	}
+
+	for _, property := range common.AllProperties() {
+		properties[property.String()] = struct{}{}
+	}
+
+	return properties
+}
+
+// dryRunBatch is a graph.Batch that discards all writes submitted to it. Each write is instead counted in an ingest
+// report along with any properties or kinds that are not part of the graph schema. Queries are not supported since
+// a dry run batch has no graph to read from.
+type dryRunBatch struct {
+	report model.IngestReport
+}
+
+func newDryRunBatch() *dryRunBatch {
+	return &dryRunBatch{
+		report: model.NewIngestReport(),
+	}
+}
+
+func (s *dryRunBatch) violation(format string, args ...any) {
+	s.report.SchemaViolations[fmt.Sprintf(format, args...)]++
+}
+
+func (s *dryRunBatch) recordProperties(properties *graph.Properties) {
+	for key := range properties.MapOrEmpty() {
+		if _, known := knownProperties[key]; !known {
+			s.report.UnknownProperties[key]++
+		}
+	}
+}
+
+func (s *dryRunBatch) recordNode(node *graph.Node, identityProperties []string) {
+	for _, kind := range node.Kinds {
+		if kind == nil || kind.String() == "" {
+			continue
+		}
+
+		s.report.NodeKinds[kind.String()]++
+
+		if !knownNodeKinds.ContainsOneOf(kind) {
+			s.violation("node kind %s is not defined in the schema", kind)
+		}
+	}
+
+	s.recordIdentity("node", node, identityProperties)
+	s.recordProperties(node.Properties)
+}
+
+func (s *dryRunBatch) recordIdentity(nodeType string, node *graph.Node, identityProperties []string) {
+	for _, identityProperty := range identityProperties {
+		if value, err := node.Properties.Get(identityProperty).String(); err != nil || value == "" {
+			s.violation("%s is missing identity property %s", nodeType, identityProperty)
+		}
+	}
+}
+
+func (s *dryRunBatch) WithGraph(graphSchema graph.Graph) graph.Batch {
+	return s
+}
+
+func (s *dryRunBatch) CreateNode(node *graph.Node) error {
+	s.recordNode(node, nil)
+	return nil
+}
+
+func (s *dryRunBatch) DeleteNode(id graph.ID) error {
+	return nil
+}
+
+func (s *dryRunBatch) Nodes() graph.NodeQuery {
+	// Dry runs do not read from the graph; callers must not issue queries against a dry run batch
+	return nil
+}
+
+func (s *dryRunBatch) Relationships() graph.RelationshipQuery {
+	// Dry runs do not read from the graph; callers must not issue queries against a dry run batch
+	return nil
+}
+
+func (s *dryRunBatch) UpdateNodeBy(update graph.NodeUpdate) error {
+	s.recordNode(update.Node, update.IdentityProperties)
+	return nil
+}
+
+func (s *dryRunBatch) CreateRelationship(relationship *graph.Relationship) error {
+	return s.recordRelationship(relationship)
+}
+
+func (s *dryRunBatch) CreateRelationshipByIDs(startNodeID, endNodeID graph.ID, kind graph.Kind, properties *graph.Properties) error {
+	return s.recordRelationship(graph.NewRelationship(0, startNodeID, endNodeID, properties, kind))
+}
+
+func (s *dryRunBatch) recordRelationship(relationship *graph.Relationship) error {
+	if relationship.Kind == nil || relationship.Kind.String() == "" {
+		s.violation("relationship has no kind")
+	} else {
+		s.report.RelationshipKinds[relationship.Kind.String()]++
+
+		if !knownRelationshipKinds.ContainsOneOf(relationship.Kind) {
+			s.violation("relationship kind %s is not defined in the schema", relationship.Kind)
+		}
+	}
+
+	s.recordProperties(relationship.Properties)
+	return nil
+}
+
+func (s *dryRunBatch) DeleteRelationship(id graph.ID) error {
+	return nil
+}
+
+func (s *dryRunBatch) UpdateRelationshipBy(update graph.RelationshipUpdate) error {
+	s.recordIdentity("relationship start node", update.Start, update.StartIdentityProperties)
+	s.recordIdentity("relationship end node", update.End, update.EndIdentityProperties)
+
+	for _, endpoint := range []*graph.Node{update.Start, update.End} {
+		for _, kind := range endpoint.Kinds {
+			if kind != nil && kind.String() != "" && !knownNodeKinds.ContainsOneOf(kind) {
+				s.violation("node kind %s is not defined in the schema", kind)
+			}
+		}
+	}
+
+	return s.recordRelationship(update.Relationship)
+}
+
+func (s *dryRunBatch) Commit() error {
+	return nil
+}
@@ -17,14 +17,21 @@
 package datapipe
 
 import (
+	"archive/tar"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 
+	"github.com/specterops/bloodhound/bomenc"
+	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/model/ingest"
+	"github.com/specterops/bloodhound/src/services/fileupload"
 )
 
+var ErrUnsupportedSeek = errors.New("decompressed and archived ingest files may only be seeked to their start")
+
 func SeekToDataTag(decoder *json.Decoder) error {
 	var (
 		depth        = 0
@@ -79,3 +86,159 @@ func CreateIngestDecoder(reader io.ReadSeeker) (*json.Decoder, error) {
 		}
 	}
 }
+
+// decompressedFile reads the decompressed content of a gzip or zstd compressed ingest file without first expanding it
+// to disk. Only seeking to the start of the content is supported, which restarts decompression.
+type decompressedFile struct {
+	file         *os.File
+	fileType     model.FileType
+	limit        int64
+	decompressor io.ReadCloser
+	reader       io.Reader
+}
+
+func openDecompressedFile(path string, fileType model.FileType, limit int64) (*decompressedFile, error) {
+	if file, err := os.Open(path); err != nil {
+		return nil, err
+	} else {
+		decompressed := &decompressedFile{
+			file:     file,
+			fileType: fileType,
+			limit:    limit,
+		}
+
+		if err := decompressed.reset(); err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		return decompressed, nil
+	}
+}
+
+func (s *decompressedFile) reset() error {
+	if s.decompressor != nil {
+		s.decompressor.Close()
+		s.decompressor = nil
+	}
+
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	} else if decompressor, err := fileupload.NewDecompressor(s.file, s.fileType); err != nil {
+		return fmt.Errorf("error decompressing file: %w", err)
+	} else if normalized, err := bomenc.NormalizeToUTF8(fileupload.NewDecompressionLimiter(s.limit).Reader(decompressor)); err != nil {
+		decompressor.Close()
+		return fmt.Errorf("error normalizing file to UTF8: %w", err)
+	} else {
+		s.decompressor = decompressor
+		s.reader = normalized
+		return nil
+	}
+}
+
+func (s *decompressedFile) Read(p []byte) (int, error) {
+	return s.reader.Read(p)
+}
+
+func (s *decompressedFile) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekStart {
+		return 0, ErrUnsupportedSeek
+	}
+
+	return 0, s.reset()
+}
+
+func (s *decompressedFile) Close() error {
+	if s.decompressor != nil {
+		s.decompressor.Close()
+	}
+
+	return s.file.Close()
+}
+
+// tarEntryFile reads the content of a single entry of a tar archive, which may be gzip or zstd compressed, without
+// first extracting it to disk. Only seeking to the start of the content is supported, which reads the archive again up
+// to the entry.
+type tarEntryFile struct {
+	file         *os.File
+	fileType     model.FileType
+	entry        int
+	limit        int64
+	decompressor io.ReadCloser
+	reader       io.Reader
+}
+
+func openTarEntryFile(path string, fileType model.FileType, entry int, limit int64) (*tarEntryFile, error) {
+	if file, err := os.Open(path); err != nil {
+		return nil, err
+	} else {
+		entryFile := &tarEntryFile{
+			file:     file,
+			fileType: fileType,
+			entry:    entry,
+			limit:    limit,
+		}
+
+		if err := entryFile.reset(); err != nil {
+			entryFile.Close()
+			return nil, err
+		}
+
+		return entryFile, nil
+	}
+}
+
+func (s *tarEntryFile) reset() error {
+	var archiveReader io.Reader = s.file
+
+	if s.decompressor != nil {
+		s.decompressor.Close()
+		s.decompressor = nil
+	}
+
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	} else if s.fileType.IsCompressed() {
+		if decompressor, err := fileupload.NewDecompressor(s.file, s.fileType); err != nil {
+			return fmt.Errorf("error decompressing file: %w", err)
+		} else {
+			s.decompressor = decompressor
+			archiveReader = decompressor
+		}
+	}
+
+	archive := tar.NewReader(archiveReader)
+
+	for idx := 0; idx <= s.entry; idx++ {
+		if _, err := archive.Next(); err != nil {
+			return fmt.Errorf("error reading archive entry %d: %w", s.entry, err)
+		}
+	}
+
+	if normalized, err := bomenc.NormalizeToUTF8(fileupload.NewDecompressionLimiter(s.limit).Reader(archive)); err != nil {
+		return fmt.Errorf("error normalizing file to UTF8: %w", err)
+	} else {
+		s.reader = normalized
+		return nil
+	}
+}
+
+func (s *tarEntryFile) Read(p []byte) (int, error) {
+	return s.reader.Read(p)
+}
+
+func (s *tarEntryFile) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekStart {
+		return 0, ErrUnsupportedSeek
+	}
+
+	return 0, s.reset()
+}
+
+func (s *tarEntryFile) Close() error {
+	if s.decompressor != nil {
+		s.decompressor.Close()
+	}
+
+	return s.file.Close()
+}
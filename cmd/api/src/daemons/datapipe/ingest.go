@@ -22,13 +22,18 @@ import (
 	"strings"
 	"time"
 
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	azureAnalysis "github.com/specterops/bloodhound/analysis/azure"
 	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
 	"github.com/specterops/bloodhound/dawgs/util"
 	"github.com/specterops/bloodhound/ein"
 	"github.com/specterops/bloodhound/graphschema/ad"
 	"github.com/specterops/bloodhound/graphschema/azure"
 	"github.com/specterops/bloodhound/graphschema/common"
 	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/model/ingest"
 	"github.com/specterops/bloodhound/src/services/fileupload"
 )
@@ -38,11 +43,90 @@ const (
 	ReconcileProperty    = "reconcile"
 )
 
-func ReadFileForIngest(batch graph.Batch, reader io.ReadSeeker, adcsEnabled bool) error {
+// ReadOptions controls how ingest files are read
+type ReadOptions struct {
+	AdcsEnabled bool
+
+	// RemoveDeletedObjects removes objects reported as deleted from the graph. When unset, deleted objects are kept and
+	// flagged with the isdeleted property instead.
+	RemoveDeletedObjects bool
+
+	// DryRun indicates that files are being read into a dry run batch that does not write to the graph. Deleted objects
+	// are counted but not matched against the graph.
+	DryRun bool
+
+	// ChunkSize is the number of decoded objects that are converted and submitted to the batch at a time. Defaults to
+	// IngestCountThreshold when unset.
+	ChunkSize int
+
+	// Writes selects which of the nodes and relationships read from a file are written to the batch. Defaults to
+	// writing both when unset.
+	Writes IngestWrites
+}
+
+// IngestWrites selects which of the nodes and relationships read from a file are written to the batch
+type IngestWrites int
+
+const (
+	WriteNodesAndRelationships IngestWrites = iota
+	WriteNodes
+	WriteRelationships
+)
+
+// filter wraps the given batch so that writes not selected are dropped
+func (s IngestWrites) filter(batch graph.Batch) graph.Batch {
+	if s == WriteNodesAndRelationships {
+		return batch
+	}
+
+	return writeFilterBatch{
+		Batch:  batch,
+		writes: s,
+	}
+}
+
+// writeFilterBatch drops the node or relationship writes made to the wrapped batch that are not selected. The endpoints
+// of relationship writes are still upserted as part of writing the relationship.
+type writeFilterBatch struct {
+	graph.Batch
+	writes IngestWrites
+}
+
+func (s writeFilterBatch) UpdateNodeBy(update graph.NodeUpdate) error {
+	if s.writes == WriteRelationships {
+		return nil
+	}
+
+	return s.Batch.UpdateNodeBy(update)
+}
+
+func (s writeFilterBatch) UpdateRelationshipBy(update graph.RelationshipUpdate) error {
+	if s.writes == WriteNodes {
+		return nil
+	}
+
+	return s.Batch.UpdateRelationshipBy(update)
+}
+
+func (s ReadOptions) chunkSize() int {
+	if s.ChunkSize <= 0 {
+		return IngestCountThreshold
+	}
+
+	return s.ChunkSize
+}
+
+// ReadFileForIngest ingests the given file into the batch and records the outcome of each object read into the
+// given result
+func ReadFileForIngest(batch graph.Batch, reader io.ReadSeeker, result *model.FileUploadJobResult, options ReadOptions) error {
 	if meta, err := fileupload.ValidateMetaTag(reader, false); err != nil {
-		return fmt.Errorf("error validating meta tag: %w", err)
+		err = fmt.Errorf("error validating meta tag: %w", err)
+		result.AddError(0, err)
+
+		return err
 	} else {
-		return IngestWrapper(batch, reader, meta, adcsEnabled)
+		result.DataType = string(meta.Type)
+		return IngestWrapper(batch, reader, meta, result, options)
 	}
 }
 
@@ -96,45 +180,99 @@ func IngestAzureData(batch graph.Batch, converted ConvertedAzureData) error {
 	return errs.Combined()
 }
 
-func IngestWrapper(batch graph.Batch, reader io.ReadSeeker, meta ingest.Metadata, adcsEnabled bool) error {
+func IngestWrapper(batch graph.Batch, reader io.ReadSeeker, meta ingest.Metadata, result *model.FileUploadJobResult, options ReadOptions) error {
+	chunkSize := options.chunkSize()
+	batch = options.Writes.filter(batch)
+
 	switch meta.Type {
 	case ingest.DataTypeComputer:
 		if meta.Version >= 5 {
-			return decodeBasicData(batch, reader, convertComputerData)
+			return decodeBasicData(batch, reader, result, chunkSize, convertComputerData)
 		}
 	case ingest.DataTypeUser:
-		return decodeBasicData(batch, reader, convertUserData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertUserData)
 	case ingest.DataTypeGroup:
-		return decodeGroupData(batch, reader)
+		return decodeGroupData(batch, reader, result, chunkSize)
 	case ingest.DataTypeDomain:
-		return decodeBasicData(batch, reader, convertDomainData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertDomainData)
 	case ingest.DataTypeGPO:
-		return decodeBasicData(batch, reader, convertGPOData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertGPOData)
+	case ingest.DataTypeGPOSettings:
+		return decodeBasicData(batch, reader, result, chunkSize, convertGPOSettingsData)
 	case ingest.DataTypeOU:
-		return decodeBasicData(batch, reader, convertOUData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertOUData)
 	case ingest.DataTypeSession:
-		return decodeSessionData(batch, reader)
+		return decodeSessionData(batch, reader, result, chunkSize)
 	case ingest.DataTypeContainer:
-		return decodeBasicData(batch, reader, convertContainerData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertContainerData)
 	case ingest.DataTypeAIACA:
-		return decodeBasicData(batch, reader, convertAIACAData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertAIACAData)
 	case ingest.DataTypeRootCA:
-		return decodeBasicData(batch, reader, convertRootCAData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertRootCAData)
 	case ingest.DataTypeEnterpriseCA:
-		return decodeBasicData(batch, reader, convertEnterpriseCAData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertEnterpriseCAData)
 	case ingest.DataTypeNTAuthStore:
-		return decodeBasicData(batch, reader, convertNTAuthStoreData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertNTAuthStoreData)
 	case ingest.DataTypeCertTemplate:
-		return decodeBasicData(batch, reader, convertCertTemplateData)
+		return decodeBasicData(batch, reader, result, chunkSize, convertCertTemplateData)
 	case ingest.DataTypeAzure:
-		return decodeAzureData(batch, reader)
+		return decodeAzureData(batch, reader, result, chunkSize)
 	case ingest.DataTypeIssuancePolicy:
-		return decodeBasicData(batch, reader, convertIssuancePolicy)
+		return decodeBasicData(batch, reader, result, chunkSize, convertIssuancePolicy)
+	case ingest.DataTypeRemoved:
+		return decodeDeletedData(batch, reader, result, chunkSize, options.RemoveDeletedObjects, options.DryRun)
 	}
 
 	return nil
 }
 
+// deletedObjectRelationshipKinds returns the relationship kinds that are produced by post-processing. These are not
+// removed when ingesting deleted objects since post-processing will recompute them.
+func deletedObjectRelationshipKinds() graph.Kinds {
+	return append(adAnalysis.PostProcessedRelationships(), azureAnalysis.PostProcessedRelationships()...)
+}
+
+// IngestDeletedObjects applies the deletion of the objects identified by the given object IDs. The collected
+// relationships of each matching node are deleted and the node is then either removed or flagged with the isdeleted
+// property. The number of matching nodes and deleted relationships are returned.
+func IngestDeletedObjects(batch graph.Batch, objectIDs []string, removeObjects bool) (int, int, error) {
+	if nodeIDs, err := ops.FetchNodeIDs(batch.Nodes().Filter(
+		query.In(query.NodeProperty(common.ObjectID.String()), objectIDs),
+	)); err != nil {
+		return 0, 0, fmt.Errorf("error fetching deleted objects: %w", err)
+	} else if len(nodeIDs) == 0 {
+		return 0, 0, nil
+	} else if relationshipIDs, err := ops.FetchRelationshipIDs(batch.Relationships().Filter(query.And(
+		query.Or(
+			query.InIDs(query.StartID(), nodeIDs...),
+			query.InIDs(query.EndID(), nodeIDs...),
+		),
+		query.Not(query.KindIn(query.Relationship(), deletedObjectRelationshipKinds()...)),
+	))); err != nil {
+		return 0, 0, fmt.Errorf("error fetching relationships of deleted objects: %w", err)
+	} else {
+		for _, relationshipID := range relationshipIDs {
+			if err := batch.DeleteRelationship(relationshipID); err != nil {
+				return 0, 0, fmt.Errorf("error deleting relationship %d of deleted object: %w", relationshipID, err)
+			}
+		}
+
+		if removeObjects {
+			for _, nodeID := range nodeIDs {
+				if err := batch.DeleteNode(nodeID); err != nil {
+					return 0, 0, fmt.Errorf("error deleting node %d: %w", nodeID, err)
+				}
+			}
+		} else if err := batch.Nodes().Filter(query.InIDs(query.NodeID(), nodeIDs...)).Update(
+			graph.NewProperties().Set(ad.IsDeleted.String(), true),
+		); err != nil {
+			return 0, 0, fmt.Errorf("error flagging deleted objects: %w", err)
+		}
+
+		return len(nodeIDs), len(relationshipIDs), nil
+	}
+}
+
 func NormalizeEinNodeProperties(properties map[string]any, objectID string, nowUTC time.Time) map[string]any {
 	delete(properties, ReconcileProperty)
 	properties[common.LastSeen.String()] = nowUTC
@@ -197,6 +335,14 @@ func IngestNodes(batch graph.Batch, identityKind graph.Kind, nodes []ein.Ingesti
 
 func IngestRelationship(batch graph.Batch, nowUTC time.Time, nodeIDKind graph.Kind, nextRel ein.IngestibleRelationship) error {
 	nextRel.RelProps[common.LastSeen.String()] = nowUTC
+
+	// Sessions also record when each source last observed them so that they may be aged and filtered by source
+	if source, ok := nextRel.RelProps[ad.SessionSource.String()].(string); ok {
+		if lastSeenProperty, ok := ein.SessionLastSeenProperty(source); ok {
+			nextRel.RelProps[lastSeenProperty.String()] = nowUTC
+		}
+	}
+
 	nextRel.Source = strings.ToUpper(nextRel.Source)
 	nextRel.Target = strings.ToUpper(nextRel.Target)
 
@@ -17,13 +17,21 @@
 package datapipe_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/ein"
 	"github.com/specterops/bloodhound/graphschema/ad"
 	"github.com/specterops/bloodhound/graphschema/common"
 	"github.com/specterops/bloodhound/src/daemons/datapipe"
+	"github.com/specterops/bloodhound/src/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNormalizeEinNodeProperties(t *testing.T) {
@@ -46,3 +54,241 @@ func TestNormalizeEinNodeProperties(t *testing.T) {
 	assert.Equal(t, "DISTINGUISHED-NAME", normalizedProperties[ad.DistinguishedName.String()])
 	assert.Equal(t, "TEMPLE", normalizedProperties[common.OperatingSystem.String()])
 }
+
+func TestReadFileForIngest_Results(t *testing.T) {
+	const groupsFile = `{"data": [{"ObjectIdentifier": "S-1-5-21-1-512", "Properties": {"name": "DOMAIN ADMINS@TESTLAB.LOCAL"}}, {"ObjectIdentifier": 5}], "meta": {"type": "groups", "version": 5, "count": 2, "methods": 0}}`
+
+	var (
+		db     = memory.NewDatabase(0)
+		result model.FileUploadJobResult
+	)
+
+	require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		return datapipe.ReadFileForIngest(batch, strings.NewReader(groupsFile), &result, datapipe.ReadOptions{})
+	}))
+
+	assert.Equal(t, "groups", result.DataType)
+	assert.Equal(t, 2, result.ObjectsRead)
+	assert.Equal(t, 1, result.ObjectsWritten)
+	assert.Equal(t, 1, result.ObjectsSkipped)
+	assert.Equal(t, 1, result.ErrorCount)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, int64(strings.Index(groupsFile, `, {"ObjectIdentifier": 5}`)), result.Errors[0].Offset)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Nodes().Filter(query.Kind(query.Node(), ad.Group)).Count(); err != nil {
+			return err
+		} else {
+			assert.Equal(t, int64(1), count)
+		}
+
+		return nil
+	}))
+}
+
+func TestReadFileForIngest_InvalidMeta(t *testing.T) {
+	var (
+		db     = memory.NewDatabase(0)
+		result model.FileUploadJobResult
+	)
+
+	require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		require.NotNil(t, datapipe.ReadFileForIngest(batch, strings.NewReader(`{"data": []}`), &result, datapipe.ReadOptions{}))
+		return nil
+	}))
+
+	assert.Equal(t, 1, result.ErrorCount)
+	assert.Equal(t, 0, result.ObjectsRead)
+}
+
+func TestReadFileForIngest_Deleted(t *testing.T) {
+	const deletedFile = `{"data": [{"ObjectIdentifier": "s-1-5-21-1-512", "ObjectType": "Group"}, {"ObjectIdentifier": "S-1-5-21-1-9999", "ObjectType": "User"}, {"ObjectType": "User"}], "meta": {"type": "deleted", "version": 6, "count": 3, "methods": 0}}`
+
+	newGraph := func(t *testing.T) graph.Database {
+		db := memory.NewDatabase(0)
+
+		require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+			if user, err := tx.CreateNode(graph.AsProperties(map[string]any{common.ObjectID.String(): "S-1-5-21-1-1105"}), ad.Entity, ad.User); err != nil {
+				return err
+			} else if group, err := tx.CreateNode(graph.AsProperties(map[string]any{common.ObjectID.String(): "S-1-5-21-1-512"}), ad.Entity, ad.Group); err != nil {
+				return err
+			} else if computer, err := tx.CreateNode(graph.AsProperties(map[string]any{common.ObjectID.String(): "S-1-5-21-1-1001"}), ad.Entity, ad.Computer); err != nil {
+				return err
+			} else if _, err := tx.CreateRelationshipByIDs(user.ID, group.ID, ad.MemberOf, graph.NewProperties()); err != nil {
+				return err
+			} else if _, err := tx.CreateRelationshipByIDs(group.ID, computer.ID, ad.GenericAll, graph.NewProperties()); err != nil {
+				return err
+			} else {
+				_, err := tx.CreateRelationshipByIDs(group.ID, computer.ID, ad.AdminTo, graph.NewProperties())
+				return err
+			}
+		}))
+
+		return db
+	}
+
+	assertRelationships := func(t *testing.T, db graph.Database, expected graph.Kinds) {
+		require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+			var kinds graph.Kinds
+
+			if err := tx.Relationships().Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+				for relationship := range cursor.Chan() {
+					kinds = append(kinds, relationship.Kind)
+				}
+
+				return cursor.Error()
+			}); err != nil {
+				return err
+			}
+
+			assert.ElementsMatch(t, expected, kinds)
+			return nil
+		}))
+	}
+
+	t.Run("Remove", func(t *testing.T) {
+		var (
+			db     = newGraph(t)
+			result model.FileUploadJobResult
+		)
+
+		require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+			return datapipe.ReadFileForIngest(batch, strings.NewReader(deletedFile), &result, datapipe.ReadOptions{RemoveDeletedObjects: true})
+		}))
+
+		assert.Equal(t, "deleted", result.DataType)
+		assert.Equal(t, 3, result.ObjectsRead)
+		assert.Equal(t, 2, result.ObjectsWritten)
+		assert.Equal(t, 1, result.ObjectsSkipped)
+		assert.Equal(t, 1, result.ObjectsDeleted)
+		assert.Equal(t, 0, result.ObjectsFlagged)
+		assert.Equal(t, 2, result.RelationshipsDeleted)
+		assert.Equal(t, 1, result.ErrorCount)
+
+		require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+			if count, err := tx.Nodes().Count(); err != nil {
+				return err
+			} else {
+				assert.Equal(t, int64(2), count)
+			}
+
+			return nil
+		}))
+
+		assertRelationships(t, db, nil)
+	})
+
+	t.Run("Flag", func(t *testing.T) {
+		var (
+			db     = newGraph(t)
+			result model.FileUploadJobResult
+		)
+
+		require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+			return datapipe.ReadFileForIngest(batch, strings.NewReader(deletedFile), &result, datapipe.ReadOptions{})
+		}))
+
+		assert.Equal(t, 0, result.ObjectsDeleted)
+		assert.Equal(t, 1, result.ObjectsFlagged)
+		assert.Equal(t, 2, result.RelationshipsDeleted)
+
+		require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+			if node, err := tx.Nodes().Filter(query.Equals(query.NodeProperty(common.ObjectID.String()), "S-1-5-21-1-512")).First(); err != nil {
+				return err
+			} else {
+				isDeleted, err := node.Properties.Get(ad.IsDeleted.String()).Bool()
+				require.Nil(t, err)
+				assert.True(t, isDeleted)
+			}
+
+			return nil
+		}))
+
+		assertRelationships(t, db, graph.Kinds{ad.AdminTo})
+	})
+}
+
+func TestReadFileForIngest_GPOSettings(t *testing.T) {
+	const gpoSettingsFile = `{"data": [{"ObjectIdentifier": "F2A1C3B4-0000-0000-0000-000000000001", "LocalGroups": [{"ObjectIdentifier": "S-1-5-32-544", "Members": [{"ObjectIdentifier": "S-1-5-21-1-512", "ObjectType": "Group"}]}], "UserRights": [{"Privilege": "SeDebugPrivilege", "Members": [{"ObjectIdentifier": "S-1-5-21-1-1105", "ObjectType": "User"}]}]}], "meta": {"type": "gposettings", "version": 6, "count": 1, "methods": 0}}`
+
+	var (
+		db     = memory.NewDatabase(0)
+		result model.FileUploadJobResult
+	)
+
+	require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		return datapipe.ReadFileForIngest(batch, strings.NewReader(gpoSettingsFile), &result, datapipe.ReadOptions{})
+	}))
+
+	assert.Equal(t, "gposettings", result.DataType)
+	assert.Equal(t, 1, result.ObjectsWritten)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if gpo, err := tx.Nodes().Filter(query.Kind(query.Node(), ad.GPO)).First(); err != nil {
+			return err
+		} else {
+			localAdmins, err := gpo.Properties.Get(ad.GPOLocalAdmins.String()).StringSlice()
+			require.Nil(t, err)
+			assert.Equal(t, []string{"S-1-5-21-1-512"}, localAdmins)
+
+			privilegeHolders, err := gpo.Properties.Get(ad.GPOAdminPrivilegeHolders.String()).StringSlice()
+			require.Nil(t, err)
+			assert.Equal(t, []string{"S-1-5-21-1-1105"}, privilegeHolders)
+		}
+
+		return nil
+	}))
+}
+
+func TestIngestRelationship_SessionSources(t *testing.T) {
+	var (
+		db              = memory.NewDatabase(0)
+		firstIngestUTC  = time.Now().UTC().Add(-time.Hour)
+		secondIngestUTC = time.Now().UTC()
+		newSession      = func(source string) ein.IngestibleRelationship {
+			return ein.NewIngestibleRelationship(
+				ein.IngestibleSource{Source: "S-1-5-21-1-1001", SourceType: ad.Computer},
+				ein.IngestibleTarget{Target: "S-1-5-21-1-1105", TargetType: ad.User},
+				ein.IngestibleRel{
+					RelProps: map[string]any{"isacl": false, ad.SessionSource.String(): source},
+					RelType:  ad.HasSession,
+				},
+			)
+		}
+	)
+
+	require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		return datapipe.IngestRelationship(batch, firstIngestUTC, ad.Entity, newSession(ein.SessionSourceRegistry))
+	}))
+
+	require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		return datapipe.IngestRelationship(batch, secondIngestUTC, ad.Entity, newSession(ein.SessionSourceNetSessionEnum))
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if session, err := tx.Relationships().Filter(query.Kind(query.Relationship(), ad.HasSession)).First(); err != nil {
+			return err
+		} else {
+			// The session keeps the last seen timestamp of each source that observed it
+			registryLastSeen, err := session.Properties.Get(ad.RegistryLastSeen.String()).Time()
+			require.Nil(t, err)
+			require.True(t, firstIngestUTC.Equal(registryLastSeen))
+
+			netSessionEnumLastSeen, err := session.Properties.Get(ad.NetSessionEnumLastSeen.String()).Time()
+			require.Nil(t, err)
+			require.True(t, secondIngestUTC.Equal(netSessionEnumLastSeen))
+
+			lastSeen, err := session.Properties.Get(common.LastSeen.String()).Time()
+			require.Nil(t, err)
+			require.True(t, secondIngestUTC.Equal(lastSeen))
+
+			source, err := session.Properties.Get(ad.SessionSource.String()).String()
+			require.Nil(t, err)
+			require.Equal(t, ein.SessionSourceNetSessionEnum, source)
+
+			require.False(t, session.Properties.Exists(ad.NetWkstaUserEnumLastSeen.String()))
+		}
+
+		return nil
+	}))
+}
@@ -17,21 +17,30 @@
 package datapipe
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/specterops/bloodhound/bomenc"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/util"
 	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/database/types/null"
 	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/model/appcfg"
+	"github.com/specterops/bloodhound/src/model/ingest"
 	"github.com/specterops/bloodhound/src/services/fileupload"
 )
 
@@ -90,26 +99,31 @@ func CompleteAnalyzedFileUploadJobs(ctx context.Context, db database.Database) {
 		log.Errorf("Failed to load file upload jobs under analysis: %v", err)
 	} else {
 		for _, job := range fileUploadJobsUnderAnalysis {
-			var (
-				status  = model.JobStatusComplete
-				message = "Complete"
-			)
+			completeFileUploadJob(ctx, db, job)
+		}
+	}
+}
 
-			if job.FailedFiles > 0 {
-				if job.FailedFiles < job.TotalFiles {
-					status = model.JobStatusPartiallyComplete
-					message = fmt.Sprintf("%d File(s) failed to ingest as JSON Content", job.FailedFiles)
-				} else {
-					status = model.JobStatusFailed
-					message = "All files failed to ingest as JSON Content"
-				}
-			}
+// completeFileUploadJob moves the job into its final status based on the number of files that failed to ingest
+func completeFileUploadJob(ctx context.Context, db database.Database, job model.FileUploadJob) {
+	var (
+		status  = model.JobStatusComplete
+		message = "Complete"
+	)
 
-			if err := fileupload.UpdateFileUploadJobStatus(ctx, db, job, status, message); err != nil {
-				log.Errorf("Error updating file upload job %d: %v", job.ID, err)
-			}
+	if job.FailedFiles > 0 {
+		if job.FailedFiles < job.TotalFiles {
+			status = model.JobStatusPartiallyComplete
+			message = fmt.Sprintf("%d File(s) failed to ingest as JSON Content", job.FailedFiles)
+		} else {
+			status = model.JobStatusFailed
+			message = "All files failed to ingest as JSON Content"
 		}
 	}
+
+	if err := fileupload.UpdateFileUploadJobStatus(ctx, db, job, status, message); err != nil {
+		log.Errorf("Error updating file upload job %d: %v", job.ID, err)
+	}
 }
 
 func ProcessIngestedFileUploadJobs(ctx context.Context, db database.Database) {
@@ -126,7 +140,10 @@ func ProcessIngestedFileUploadJobs(ctx context.Context, db database.Database) {
 			if remainingIngestTasks, err := db.GetIngestTasksForJob(ctx, ingestingFileUploadJob.ID); err != nil {
 				log.Errorf("Failed looking up remaining ingest tasks for file upload job %d: %v", ingestingFileUploadJob.ID, err)
 			} else if len(remainingIngestTasks) == 0 {
-				if err := fileupload.UpdateFileUploadJobStatus(ctx, db, ingestingFileUploadJob, model.JobStatusAnalyzing, "Analyzing"); err != nil {
+				// Dry run jobs wrote nothing to the graph so there is nothing to analyze
+				if ingestingFileUploadJob.DryRun {
+					completeFileUploadJob(ctx, db, ingestingFileUploadJob)
+				} else if err := fileupload.UpdateFileUploadJobStatus(ctx, db, ingestingFileUploadJob, model.JobStatusAnalyzing, "Analyzing"); err != nil {
 					log.Errorf("Error updating fileupload job %d: %v", ingestingFileUploadJob.ID, err)
 				}
 			}
@@ -141,98 +158,638 @@ func (s *Daemon) clearFileTask(ingestTask model.IngestTask) {
 	}
 }
 
-// preProcessIngestFile will take a path and extract zips if necessary, returning the paths for files to process
-// along with any errors and the number of failed files (in the case of a zip archive)
-func (s *Daemon) preProcessIngestFile(path string, fileType model.FileType) ([]string, error, int) {
-	if fileType == model.FileTypeJson {
-		//If this isn't a zip file, just return a slice with the path in it and let stuff process as normal
-		return []string{path}, nil, 0
-	} else if archive, err := zip.OpenReader(path); err != nil {
-		return []string{}, err, 0
+// ingestFile is a file that is ready to be read for ingest along with the name it was submitted under. Compressed files
+// are decompressed as they are read. Extracted files are temp files written from a zip archive that are removed once
+// they have been ingested. Archived files are entries of a tar archive, which may be compressed, that are read straight
+// from the archive at path. All other files are the file of the ingest task itself.
+type ingestFile struct {
+	path      string
+	name      string
+	fileType  model.FileType
+	extracted bool
+	archived  bool
+	entry     int
+}
+
+// key identifies the file among the files of its ingest task
+func (s ingestFile) key() string {
+	if s.archived {
+		return fmt.Sprintf("%s:%d", s.path, s.entry)
+	}
+
+	return s.path
+}
+
+// archiveExtractor prepares the entries of an archive for ingest. Zip entries are extracted into temp files while tar
+// entries are read straight from the archive. Failures to prepare individual entries are collected as results so that
+// the rest of the archive may still be ingested. Entry content counts against a limiter shared by every entry of the
+// archive.
+type archiveExtractor struct {
+	archivePath string
+	tempDir     string
+	limiter     *fileupload.DecompressionLimiter
+	files       []ingestFile
+	failed      model.FileUploadJobResults
+	errs        util.ErrorCollector
+}
+
+func (s *Daemon) newArchiveExtractor(archivePath string) *archiveExtractor {
+	return &archiveExtractor{
+		archivePath: archivePath,
+		tempDir:     s.cfg.TempDirectory(),
+		limiter:     fileupload.NewDecompressionLimiter(s.cfg.MaxIngestDecompressedBytes()),
+		errs:        util.NewErrorCollector(),
+	}
+}
+
+// fail records an entry of the archive that could not be extracted
+func (s *archiveExtractor) fail(name string, err error) {
+	result := model.FileUploadJobResult{
+		FileName: name,
+	}
+
+	result.AddError(0, err)
+	s.failed = append(s.failed, result)
+	s.errs.Add(err)
+}
+
+// extract writes the given archive entry to a temp file. An error is only returned if the temp file can not be
+// created, in which case extraction of the remaining entries should not be attempted.
+func (s *archiveExtractor) extract(name string, src io.Reader) error {
+	var extractErr error
+
+	tempFile, err := os.CreateTemp(s.tempDir, "bh")
+	if err != nil {
+		return err
+	} else if normFile, err := bomenc.NormalizeToUTF8(s.limiter.Reader(src)); err != nil {
+		extractErr = fmt.Errorf("error normalizing file %s to UTF8 in archive %s: %w", name, s.archivePath, err)
+	} else if _, err := io.Copy(tempFile, normFile); err != nil {
+		extractErr = fmt.Errorf("error extracting file %s in archive %s: %w", name, s.archivePath, err)
+	} else if err := tempFile.Close(); err != nil {
+		extractErr = fmt.Errorf("error closing temp file %s: %w", name, err)
 	} else {
-		var (
-			errs      = util.NewErrorCollector()
-			failed    = 0
-			filePaths = make([]string, len(archive.File))
-		)
+		s.files = append(s.files, ingestFile{path: tempFile.Name(), name: name, fileType: model.FileTypeJson, extracted: true})
+	}
 
-		for i, f := range archive.File {
-			//skip directories
-			if f.FileInfo().IsDir() {
-				continue
-			}
-			// Break out if temp file creation fails
-			// Collect errors for other failures within the archive
-			if tempFile, err := os.CreateTemp(s.cfg.TempDirectory(), "bh"); err != nil {
-				return []string{}, err, 0
-			} else if srcFile, err := f.Open(); err != nil {
-				errs.Add(fmt.Errorf("error opening file %s in archive %s: %v", f.Name, path, err))
-				failed++
-			} else if normFile, err := bomenc.NormalizeToUTF8(srcFile); err != nil {
-				errs.Add(fmt.Errorf("error normalizing file %s to UTF8 in archive %s: %v", f.Name, path, err))
-				failed++
-			} else if _, err := io.Copy(tempFile, normFile); err != nil {
-				errs.Add(fmt.Errorf("error extracting file %s in archive %s: %v", f.Name, path, err))
-				failed++
-			} else if err := tempFile.Close(); err != nil {
-				errs.Add(fmt.Errorf("error closing temp file %s: %v", f.Name, err))
-				failed++
-			} else {
-				filePaths[i] = tempFile.Name()
+	if extractErr != nil {
+		tempFile.Close()
+
+		if err := os.Remove(tempFile.Name()); err != nil {
+			log.Errorf("Error removing temp file %s: %v", tempFile.Name(), err)
+		}
+
+		s.fail(name, extractErr)
+	}
+
+	return nil
+}
+
+// extractZip extracts every file in the zip archive
+func (s *archiveExtractor) extractZip() ([]ingestFile, model.FileUploadJobResults, error) {
+	archive, err := zip.OpenReader(s.archivePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer archive.Close()
+
+	for _, f := range archive.File {
+		//skip directories
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		// Break out if temp file creation fails
+		// Collect errors for other failures within the archive
+		if srcFile, err := f.Open(); err != nil {
+			s.fail(f.Name, fmt.Errorf("error opening file %s in archive %s: %w", f.Name, s.archivePath, err))
+		} else {
+			err := s.extract(f.Name, srcFile)
+			srcFile.Close()
+
+			if err != nil {
+				return nil, nil, err
 			}
 		}
+	}
+
+	return s.files, s.failed, s.errs.Combined()
+}
+
+// listTar records every regular file in the tar archive read from the given reader so that it may be read straight
+// from the archive. The content of each file is read through the limiter to enforce the decompression limit before any
+// of it is ingested. Tar archives can only be read sequentially so a corrupt entry ends the listing of the rest of
+// the archive.
+func (s *archiveExtractor) listTar(reader io.Reader, fileType model.FileType) ([]ingestFile, model.FileUploadJobResults, error) {
+	archive := tar.NewReader(reader)
 
-		//Close the archive and delete it
-		if err := archive.Close(); err != nil {
-			log.Errorf("Error closing archive %s: %v", path, err)
-		} else if err := os.Remove(path); err != nil {
-			log.Errorf("Error deleting archive %s: %v", path, err)
+	for entry := 0; ; entry++ {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			s.fail(filepath.Base(s.archivePath), fmt.Errorf("error reading archive %s: %w", s.archivePath, err))
+			break
+		}
+
+		// Skip directories, links and any other special entries
+		if header.Typeflag != tar.TypeReg {
+			continue
 		}
 
-		return filePaths, errs.Combined(), failed
+		if _, err := io.Copy(io.Discard, s.limiter.Reader(archive)); err != nil {
+			s.fail(header.Name, fmt.Errorf("error reading file %s in archive %s: %w", header.Name, s.archivePath, err))
+		} else {
+			s.files = append(s.files, ingestFile{path: s.archivePath, name: header.Name, fileType: fileType, archived: true, entry: entry})
+		}
 	}
+
+	return s.files, s.failed, s.errs.Combined()
 }
 
-// processIngestFile reads the files at the path supplied, and returns the total number of files in the
-// archive, the number of files that failed to ingest as JSON, and an error
-func (s *Daemon) processIngestFile(ctx context.Context, path string, fileType model.FileType) (int, int, error) {
-	adcsEnabled := false
-	if adcsFlag, err := s.db.GetFlagByKey(ctx, appcfg.FeatureAdcs); err != nil {
-		log.Errorf("Error getting ADCS flag: %v", err)
-	} else {
-		adcsEnabled = adcsFlag.Enabled
+// preProcessCompressedFile inspects the decompressed content of a gzip or zstd compressed file. The files of compressed
+// tar archives are listed while compressed JSON files are ingested as is. Both are decompressed as they are read.
+func (s *Daemon) preProcessCompressedFile(path string, fileType model.FileType) ([]ingestFile, model.FileUploadJobResults, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer file.Close()
+
+	decompressor, err := fileupload.NewDecompressor(file, fileType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decompressing %s: %w", path, err)
 	}
-	if paths, err, failed := s.preProcessIngestFile(path, fileType); err != nil {
-		return 0, failed, err
+
+	defer decompressor.Close()
+
+	if reader := bufio.NewReader(decompressor); !fileupload.IsTarArchive(reader) {
+		return []ingestFile{{path: path, name: filepath.Base(path), fileType: fileType}}, nil, nil
 	} else {
-		failed = 0
-
-		return len(paths), failed, s.graphdb.BatchOperation(ctx, func(batch graph.Batch) error {
-			for _, filePath := range paths {
-				file, err := os.Open(filePath)
-				if err != nil {
-					failed++
-					return err
-				} else if err := ReadFileForIngest(batch, file, adcsEnabled); err != nil {
-					failed++
-					log.Errorf("Error reading ingest file %s: %v", filePath, err)
+		return s.newArchiveExtractor(path).listTar(reader, fileType)
+	}
+}
+
+// preProcessIngestFile will take a path and extract archives if necessary, returning the files to process along with
+// any errors and the results for files in the archive that failed to extract. Archives are left in place so that tar
+// entries may be read from them and an interrupted task may be extracted again; they are removed along with the task
+// once all of its files are ingested.
+func (s *Daemon) preProcessIngestFile(path string, fileType model.FileType) ([]ingestFile, model.FileUploadJobResults, error) {
+	switch fileType {
+	case model.FileTypeJson:
+		//If this isn't an archive, just return a slice with the path in it and let stuff process as normal
+		return []ingestFile{{path: path, name: filepath.Base(path), fileType: fileType}}, nil, nil
+
+	case model.FileTypeGzip, model.FileTypeZstd:
+		return s.preProcessCompressedFile(path, fileType)
+
+	case model.FileTypeTar:
+		if file, err := os.Open(path); err != nil {
+			return nil, nil, err
+		} else {
+			defer file.Close()
+			return s.newArchiveExtractor(path).listTar(file, fileType)
+		}
+
+	default:
+		return s.newArchiveExtractor(path).extractZip()
+	}
+}
+
+// openIngestFile opens the given file for reading. Archived and compressed files are read from their archive or
+// decompressed and normalized to UTF-8 as they are read.
+func (s *Daemon) openIngestFile(file ingestFile) (io.ReadSeekCloser, error) {
+	if file.archived {
+		return openTarEntryFile(file.path, file.fileType, file.entry, s.cfg.MaxIngestDecompressedBytes())
+	} else if file.fileType.IsCompressed() {
+		return openDecompressedFile(file.path, file.fileType, s.cfg.MaxIngestDecompressedBytes())
+	}
+
+	return os.Open(file.path)
+}
+
+// ingestWork is a single pass over a file of an ingest task that is ready to be read by an ingest worker. Each pass
+// writes the selected objects read from the file during its ingest phase.
+type ingestWork struct {
+	task   *ingestTaskState
+	file   ingestFile
+	meta   ingest.Metadata
+	phase  int
+	writes IngestWrites
+}
+
+// ingestFileProgress tracks the passes over a single file of an ingest task
+type ingestFileProgress struct {
+	result    *model.FileUploadJobResult
+	failed    bool
+	remaining int
+}
+
+// ingestTaskState tracks the progress of an ingest task whose files may be spread across several ingest workers. The
+// task is finished once the last pass over its last file has been ingested.
+type ingestTaskState struct {
+	task        model.IngestTask
+	dryRun      bool
+	workspace   null.Int64
+	collectedAt time.Time
+	results     model.FileUploadJobResults
+	files       map[string]*ingestFileProgress
+	failed      int
+	remaining   int
+	err         error
+}
+
+const (
+	ingestPhaseNodes = iota
+	ingestPhaseRelationships
+	ingestPhaseRemoved
+	numIngestPhases
+
+	// maxIngestDeadlockRetries is the number of times a pass over a file is read again after its batch deadlocked
+	maxIngestDeadlockRetries = 3
+)
+
+// ingestPasses returns the passes over a file of the given data type. The nodes of every file are written before any
+// relationships so that relationship endpoints exist, with their full set of kinds, by the time the relationships are
+// written. Deleted objects are ingested last so that objects and relationships submitted in the same job can not
+// reintroduce them. Dry runs do not write to the graph and read each file once.
+func ingestPasses(dataType ingest.DataType, dryRun bool) []ingestWork {
+	switch {
+	case dataType == ingest.DataTypeRemoved:
+		return []ingestWork{{phase: ingestPhaseRemoved}}
+	case dryRun:
+		return []ingestWork{{phase: ingestPhaseNodes}}
+	case dataType == ingest.DataTypeSession:
+		return []ingestWork{{phase: ingestPhaseRelationships}}
+	default:
+		return []ingestWork{{phase: ingestPhaseNodes, writes: WriteNodes}, {phase: ingestPhaseRelationships, writes: WriteRelationships}}
+	}
+}
+
+// mergeIngestPass merges the result of another pass over the same file into the given result. Every pass reads all
+// objects of the file so an object is only counted as written when each pass wrote it; the written count is that of
+// the pass that wrote the fewest objects. Errors reported by both passes, such as objects that could not be decoded,
+// are only recorded once.
+func mergeIngestPass(result *model.FileUploadJobResult, pass model.FileUploadJobResult) {
+	result.ObjectsRead = max(result.ObjectsRead, pass.ObjectsRead)
+	result.ObjectsWritten = min(result.ObjectsWritten, pass.ObjectsWritten)
+	result.ObjectsSkipped = result.ObjectsRead - result.ObjectsWritten
+	result.ErrorCount += pass.ErrorCount
+
+	for _, ingestErr := range pass.Errors {
+		if slices.Contains(result.Errors, ingestErr) {
+			result.ErrorCount--
+		} else if len(result.Errors) < model.MaxFileUploadJobResultErrors {
+			result.Errors = append(result.Errors, ingestErr)
+		}
+	}
+}
+
+// ingestWorkerCount returns the number of ingest workers to run
+func (s *Daemon) ingestWorkerCount() int {
+	return max(1, s.cfg.IngestWorkers)
+}
+
+// ingestPhaseWorkerCount returns the number of ingest workers to run for the given phase and its work. Nodes and
+// relationships are written by all workers, with no more workers than there are passes to run. Relationship writes
+// upsert both of their endpoints so concurrent batches may deadlock on PostgreSQL; those batches are retried by
+// ingestFile. Deleting objects rewrites the relationships of their nodes and is left to a single worker.
+func (s *Daemon) ingestPhaseWorkerCount(phase int, work []ingestWork) int {
+	if phase == ingestPhaseRemoved {
+		return 1
+	}
+
+	return max(1, min(s.ingestWorkerCount(), len(work)))
+}
+
+// ingestChunkSize returns the number of decoded objects each ingest worker submits to its batch at a time. The chunk
+// size is bounded so that all workers of a phase together buffer no more objects than the configured batch write size.
+func ingestChunkSize(batchWriteSize int, workers int) int {
+	if batchWriteSize <= 0 {
+		return IngestCountThreshold
+	}
+
+	return max(1, min(IngestCountThreshold, batchWriteSize/workers))
+}
+
+// prepareIngestTask extracts the files of the given ingest task and reads their meta tags. Files that can not be
+// prepared are recorded as failed against the task.
+func (s *Daemon) prepareIngestTask(ingestTask model.IngestTask) (*ingestTaskState, []ingestWork) {
+	var (
+		state = &ingestTaskState{
+			task:        ingestTask,
+			collectedAt: time.Now().UTC(),
+			files:       map[string]*ingestFileProgress{},
+		}
+		work []ingestWork
+	)
+
+	// The job must be known to not be a dry run before any of its files are written to the graph
+	if ingestTask.TaskID.Valid {
+		if job, err := s.db.GetFileUploadJob(s.ctx, ingestTask.TaskID.Int64); err != nil {
+			state.err = fmt.Errorf("error fetching file upload job: %w", err)
+			return state, nil
+		} else {
+			state.dryRun = job.DryRun
+			state.workspace = job.WorkspaceID
+			state.collectedAt = job.StartTime
+		}
+	}
+
+	files, failedResults, err := s.preProcessIngestFile(ingestTask.FileName, ingestTask.FileType)
+	state.results = append(state.results, failedResults...)
+	state.failed += len(failedResults)
+
+	if err != nil {
+		if len(files) == 0 {
+			state.err = err
+			return state, nil
+		}
+
+		log.Errorf("Error extracting ingest files from %s: %v", ingestTask.FileName, err)
+	}
+
+	for _, nextFile := range files {
+		result := model.FileUploadJobResult{
+			FileName: nextFile.name,
+		}
+
+		if file, err := s.openIngestFile(nextFile); err != nil {
+			state.failed++
+			result.AddError(0, err)
+			state.results = append(state.results, result)
+		} else {
+			meta, err := fileupload.ValidateMetaTag(file, false)
+
+			if err := file.Close(); err != nil {
+				log.Errorf("Error closing ingest file %s: %v", nextFile.path, err)
+			}
+
+			if err != nil {
+				state.failed++
+				result.AddError(0, fmt.Errorf("error validating meta tag: %w", err))
+				state.results = append(state.results, result)
+
+				log.Errorf("Error reading ingest file %s: %v", nextFile.name, err)
+
+				if nextFile.extracted {
+					removeIngestFile(nextFile.path)
+				}
+			} else {
+				passes := ingestPasses(meta.Type, state.dryRun)
+
+				for _, pass := range passes {
+					pass.task = state
+					pass.file = nextFile
+					pass.meta = meta
+
+					work = append(work, pass)
 				}
 
-				if err := file.Close(); err != nil {
-					log.Errorf("Error closing ingest file %s: %v", filePath, err)
-				} else if err := os.Remove(filePath); errors.Is(err, fs.ErrNotExist) {
-					log.Warnf("Removing ingest file %s: %w", filePath, err)
-				} else if err != nil {
-					log.Errorf("Error removing ingest file %s: %v", filePath, err)
+				state.files[nextFile.key()] = &ingestFileProgress{
+					remaining: len(passes),
 				}
 			}
+		}
+	}
 
-			return nil
-		})
+	state.remaining = len(work)
+	return state, work
+}
+
+func removeIngestFile(path string) {
+	if err := os.Remove(path); errors.Is(err, fs.ErrNotExist) {
+		log.Warnf("Removing ingest file %s: %w", path, err)
+	} else if err != nil {
+		log.Errorf("Error removing ingest file %s: %v", path, err)
+	}
+}
+
+// ingestFile reads a single pass over a prepared file into its own graph batch and returns the result of doing so
+// along with any error that caused the file to fail. The file is left in place; it is removed by the caller once every
+// pass over it has finished.
+func (s *Daemon) ingestFile(ctx context.Context, work ingestWork, options ReadOptions) (model.FileUploadJobResult, error) {
+	result := model.FileUploadJobResult{
+		FileName: work.file.name,
+		DataType: string(work.meta.Type),
+	}
+
+	file, err := s.openIngestFile(work.file)
+	if err != nil {
+		result.AddError(0, err)
+		return result, err
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Errorf("Error closing ingest file %s: %v", work.file.path, err)
+		}
+	}()
+
+	if work.task.dryRun {
+		batch := newDryRunBatch()
+		options.DryRun = true
+
+		readErr := IngestWrapper(batch, file, work.meta, &result, options)
+		result.Report = &batch.report
+
+		return result, readErr
+	}
+
+	options.Writes = work.writes
+
+	// Files of jobs started in a workspace are written to the graph of that workspace
+	if work.task.workspace.Valid {
+		ctx = graph.WithGraphTarget(ctx, model.WorkspaceGraph(work.task.workspace.Int64))
+	}
+
+	// A batch that deadlocked with the batch of another ingest worker was rolled back in full so the pass is read again
+	for attempt := 0; ; attempt++ {
+		if deadlocked, err := s.writeIngestFile(ctx, work, file, &result, options); !deadlocked || attempt == maxIngestDeadlockRetries {
+			return result, err
+		} else {
+			log.Warnf("Retrying ingest file %s after its batch deadlocked with another ingest worker: %v", work.file.name, err)
+
+			result = model.FileUploadJobResult{
+				FileName: work.file.name,
+				DataType: string(work.meta.Type),
+			}
+		}
+	}
+}
+
+// writeIngestFile reads a single pass over a prepared file into its own graph batch. The returned bool is true when
+// the batch failed to commit because it deadlocked with a concurrent batch.
+func (s *Daemon) writeIngestFile(ctx context.Context, work ingestWork, file io.ReadSeeker, result *model.FileUploadJobResult, options ReadOptions) (bool, error) {
+	var (
+		batch   *deadlockDetectingBatch
+		readErr error
+	)
+
+	// Collection coverage is only tracked for the default graph and is recorded once the relationships of the file
+	// have been written
+	if !work.task.workspace.Valid && work.writes != WriteNodes {
+		result.Collection = model.NewCollectionSummary()
+	}
+
+	if err := s.graphdb.BatchOperation(ctx, func(innerBatch graph.Batch) error {
+		batch = &deadlockDetectingBatch{Batch: innerBatch}
+		readErr = IngestWrapper(batch, file, work.meta, result, options)
+		return nil
+	}); err != nil {
+		// None of the objects submitted to a batch that failed to commit were written
+		result.ObjectsSkipped += result.ObjectsWritten
+		result.ObjectsWritten = 0
+		result.AddError(0, err)
+
+		return (batch != nil && batch.deadlocked) || pg.StateDeadlockDetected.ErrorMatches(err), err
+	}
+
+	if result.Collection != nil {
+		s.saveCollection(ctx, work, result.Collection)
+		result.Collection = nil
+	}
+
+	return false, readErr
+}
+
+// deadlockDetectingBatch records whether a write submitted to the wrapped batch failed because the batch deadlocked
+// with a concurrent batch. The batch transaction is aborted once that happens so every following write fails as well.
+type deadlockDetectingBatch struct {
+	graph.Batch
+	deadlocked bool
+}
+
+func (s *deadlockDetectingBatch) check(err error) error {
+	if pg.StateDeadlockDetected.ErrorMatches(err) {
+		s.deadlocked = true
 	}
+
+	return err
+}
+
+func (s *deadlockDetectingBatch) UpdateNodeBy(update graph.NodeUpdate) error {
+	return s.check(s.Batch.UpdateNodeBy(update))
+}
+
+func (s *deadlockDetectingBatch) UpdateRelationshipBy(update graph.RelationshipUpdate) error {
+	return s.check(s.Batch.UpdateRelationshipBy(update))
 }
 
-// processIngestTasks covers the generic file upload case for ingested data.
+func (s *deadlockDetectingBatch) DeleteNode(id graph.ID) error {
+	return s.check(s.Batch.DeleteNode(id))
+}
+
+func (s *deadlockDetectingBatch) DeleteRelationship(id graph.ID) error {
+	return s.check(s.Batch.DeleteRelationship(id))
+}
+
+// saveCollection records the collection coverage of the domains read from an ingested file along with the collection
+// failures of the computers read from it
+func (s *Daemon) saveCollection(ctx context.Context, work ingestWork, summary *model.CollectionSummary) {
+	if collections := summary.DomainCollections(work.meta, work.task.collectedAt); len(collections) > 0 {
+		if err := s.db.SaveDomainCollections(ctx, collections); err != nil {
+			log.Errorf("Error saving domain collections for ingest file %s: %v", work.file.name, err)
+		}
+	}
+
+	if len(summary.Computers) == 0 {
+		return
+	}
+
+	for idx := range summary.ComputerFailures {
+		summary.ComputerFailures[idx].CollectedAt = work.task.collectedAt
+	}
+
+	if err := s.db.ReplaceComputerCollectionFailures(ctx, summary.Computers, summary.ComputerFailures); err != nil {
+		log.Errorf("Error saving computer collection failures for ingest file %s: %v", work.file.name, err)
+	}
+}
+
+// runIngestWorkers ingests the given work using a pool of ingest workers. The onComplete function is called for each
+// file once it has been ingested and may be called concurrently.
+func (s *Daemon) runIngestWorkers(ctx context.Context, workers int, work []ingestWork, options ReadOptions, onComplete func(work ingestWork, result model.FileUploadJobResult, err error)) {
+	var (
+		queue     = make(chan ingestWork)
+		waitGroup sync.WaitGroup
+	)
+
+	for workerID := 0; workerID < workers; workerID++ {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for next := range queue {
+				result, err := s.ingestFile(ctx, next, options)
+				onComplete(next, result, err)
+			}
+		}()
+	}
+
+	defer waitGroup.Wait()
+	defer close(queue)
+
+	for _, next := range work {
+		select {
+		case queue <- next:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// finishIngestTask records the results of an ingest task against its file upload job and removes the task
+func (s *Daemon) finishIngestTask(ctx context.Context, state *ingestTaskState) {
+	var (
+		ingestTask = state.task
+		results    = state.results
+		err        = state.err
+	)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Warnf("Did not process ingest task %d with file %s: %v", ingestTask.ID, ingestTask.FileName, err)
+	} else if err != nil {
+		log.Errorf("Failed processing ingest task %d with file %s: %v", ingestTask.ID, ingestTask.FileName, err)
+	} else if job, err := s.db.GetFileUploadJob(ctx, ingestTask.TaskID.ValueOrZero()); err != nil {
+		log.Errorf("Failed to fetch job for ingest task %d: %v", ingestTask.ID, err)
+	} else {
+		job.TotalFiles = len(results)
+		job.FailedFiles += state.failed
+		if err = s.db.UpdateFileUploadJob(ctx, job); err != nil {
+			log.Errorf("Failed to update number of failed files for file upload job ID %d: %v", job.ID, err)
+		}
+	}
+
+	if err != nil && len(results) == 0 && !errors.Is(err, fs.ErrNotExist) {
+		result := model.FileUploadJobResult{
+			FileName: filepath.Base(ingestTask.FileName),
+		}
+
+		result.AddError(0, err)
+		results = append(results, result)
+	}
+
+	if ingestTask.TaskID.Valid {
+		for idx := range results {
+			results[idx].FileUploadJobID = ingestTask.TaskID.Int64
+		}
+
+		if err := s.db.CreateFileUploadJobResults(ctx, results); err != nil {
+			log.Errorf("Failed to save ingest results for ingest task %d: %v", ingestTask.ID, err)
+		}
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		removeIngestFile(ingestTask.FileName)
+	}
+
+	s.clearFileTask(ingestTask)
+}
+
+// processIngestTasks covers the generic file upload case for ingested data. The files of all available ingest tasks
+// are ingested concurrently by a pool of ingest workers, one graph batch per file.
 func (s *Daemon) processIngestTasks(ctx context.Context, ingestTasks model.IngestTasks) {
 	if err := s.db.SetDatapipeStatus(s.ctx, model.DatapipeStatusIngesting, false); err != nil {
 		log.Errorf("Error setting datapipe status: %v", err)
@@ -240,6 +797,30 @@ func (s *Daemon) processIngestTasks(ctx context.Context, ingestTasks model.Inges
 	}
 	defer s.db.SetDatapipeStatus(s.ctx, model.DatapipeStatusIdle, false)
 
+	if len(ingestTasks) == 0 {
+		return
+	}
+
+	if s.cfg.DisableIngest {
+		log.Warnf("Skipped processing of ingestTasks due to config flag.")
+		return
+	}
+
+	var (
+		batchWriteSize = appcfg.GetNeo4jParameters(ctx, s.db).BatchWriteSize
+		options        = ReadOptions{
+			RemoveDeletedObjects: appcfg.GetDeletedObjectsParameter(ctx, s.db).Remove,
+		}
+		phases    = make([][]ingestWork, numIngestPhases)
+		taskMutex sync.Mutex
+	)
+
+	if adcsFlag, err := s.db.GetFlagByKey(ctx, appcfg.FeatureAdcs); err != nil {
+		log.Errorf("Error getting ADCS flag: %v", err)
+	} else {
+		options.AdcsEnabled = adcsFlag.Enabled
+	}
+
 	for _, ingestTask := range ingestTasks {
 		// Check the context to see if we should continue processing ingest tasks. This has to be explicit since error
 		// handling assumes that all failures should be logged and not returned.
@@ -247,26 +828,71 @@ func (s *Daemon) processIngestTasks(ctx context.Context, ingestTasks model.Inges
 			return
 		}
 
-		if s.cfg.DisableIngest {
-			log.Warnf("Skipped processing of ingestTasks due to config flag.")
+		if state, work := s.prepareIngestTask(ingestTask); len(work) == 0 {
+			s.finishIngestTask(ctx, state)
+		} else {
+			for _, next := range work {
+				phases[next.phase] = append(phases[next.phase], next)
+			}
+		}
+	}
+
+	for phase, phaseWork := range phases {
+		if ctx.Err() != nil {
 			return
 		}
 
-		total, failed, err := s.processIngestFile(ctx, ingestTask.FileName, ingestTask.FileType)
-		if errors.Is(err, fs.ErrNotExist) {
-			log.Warnf("Did not process ingest task %d with file %s: %v", ingestTask.ID, ingestTask.FileName, err)
-		} else if err != nil {
-			log.Errorf("Failed processing ingest task %d with file %s: %v", ingestTask.ID, ingestTask.FileName, err)
-		} else if job, err := s.db.GetFileUploadJob(ctx, ingestTask.TaskID.ValueOrZero()); err != nil {
-			log.Errorf("Failed to fetch job for ingest task %d: %v", ingestTask.ID, err)
-		} else {
-			job.TotalFiles = total
-			job.FailedFiles += failed
-			if err = s.db.UpdateFileUploadJob(ctx, job); err != nil {
-				log.Errorf("Failed to update number of failed files for file upload job ID %s: %v", job.ID, err)
+		var (
+			workers      = s.ingestPhaseWorkerCount(phase, phaseWork)
+			phaseOptions = options
+		)
+
+		phaseOptions.ChunkSize = ingestChunkSize(batchWriteSize, workers)
+
+		s.runIngestWorkers(ctx, workers, phaseWork, phaseOptions, func(work ingestWork, result model.FileUploadJobResult, err error) {
+			taskMutex.Lock()
+			defer taskMutex.Unlock()
+
+			// Tasks that were interrupted are left in place, along with their files, so that they may be picked up
+			// again. Files extracted for them are cleared with the other orphaned temp files.
+			if ctx.Err() != nil {
+				return
 			}
-		}
 
-		s.clearFileTask(ingestTask)
+			var (
+				state    = work.task
+				progress = state.files[work.file.key()]
+			)
+
+			if err != nil {
+				progress.failed = true
+				log.Errorf("Error reading ingest file %s: %v", work.file.name, err)
+			}
+
+			if progress.result == nil {
+				progress.result = &result
+			} else {
+				mergeIngestPass(progress.result, result)
+			}
+
+			progress.remaining--
+			state.remaining--
+
+			if progress.remaining == 0 {
+				if progress.failed {
+					state.failed++
+				}
+
+				state.results = append(state.results, *progress.result)
+
+				if work.file.extracted {
+					removeIngestFile(work.file.path)
+				}
+			}
+
+			if state.remaining == 0 {
+				s.finishIngestTask(ctx, state)
+			}
+		})
 	}
 }
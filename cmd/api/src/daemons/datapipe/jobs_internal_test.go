@@ -0,0 +1,781 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/klauspost/compress/zstd"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/src/config"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/database/types/null"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/appcfg"
+	"github.com/specterops/bloodhound/src/services/fileupload"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testUsersFile    = `{"data": [{"ObjectIdentifier": "S-1-5-21-1-1104", "Properties": {"name": "USER@TESTLAB.LOCAL"}}], "meta": {"type": "users", "version": 5, "count": 1, "methods": 0}}`
+	testGroupsFile   = `{"data": [{"ObjectIdentifier": "S-1-5-21-1-512", "Properties": {"name": "DOMAIN ADMINS@TESTLAB.LOCAL"}, "Members": [{"ObjectIdentifier": "S-1-5-21-1-1104", "ObjectType": "User"}]}], "meta": {"type": "groups", "version": 5, "count": 1, "methods": 0}}`
+	testSessionsFile = `{"data": [{"ComputerSID": "S-1-5-21-1-1000", "UserSID": "S-1-5-21-1-1104", "LogonType": 2}], "meta": {"type": "sessions", "version": 5, "count": 1, "methods": 0}}`
+)
+
+func writeTestZip(t *testing.T, path string, files map[string]string) {
+	fout, err := os.Create(path)
+	require.Nil(t, err)
+
+	archive := zip.NewWriter(fout)
+
+	for name, content := range files {
+		entry, err := archive.Create(name)
+		require.Nil(t, err)
+
+		_, err = entry.Write([]byte(content))
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, archive.Close())
+	require.Nil(t, fout.Close())
+}
+
+func writeTestGzip(t *testing.T, path string, content []byte) {
+	fout, err := os.Create(path)
+	require.Nil(t, err)
+
+	writer := gzip.NewWriter(fout)
+
+	_, err = writer.Write(content)
+	require.Nil(t, err)
+
+	require.Nil(t, writer.Close())
+	require.Nil(t, fout.Close())
+}
+
+func writeTestZstdTar(t *testing.T, path string, files map[string]string) {
+	fout, err := os.Create(path)
+	require.Nil(t, err)
+
+	writer, err := zstd.NewWriter(fout)
+	require.Nil(t, err)
+
+	archive := tar.NewWriter(writer)
+	require.Nil(t, archive.WriteHeader(&tar.Header{Name: "collection/", Typeflag: tar.TypeDir, Mode: 0755}))
+
+	for name, content := range files {
+		require.Nil(t, archive.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+
+		_, err = archive.Write([]byte(content))
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, archive.Close())
+	require.Nil(t, writer.Close())
+	require.Nil(t, fout.Close())
+}
+
+func TestDaemon_ProcessIngestTasks(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		graphDB  = memory.NewDatabase(0)
+		workDir  = t.TempDir()
+		daemon   = &Daemon{
+			db:      mockDB,
+			graphdb: graphDB,
+			cfg: config.Configuration{
+				WorkDir:       workDir,
+				IngestWorkers: 4,
+			},
+			ctx: context.Background(),
+		}
+
+		usersPath = filepath.Join(workDir, "users")
+		zipPath   = filepath.Join(workDir, "collection")
+
+		resultsLock sync.Mutex
+		results     = map[string]model.FileUploadJobResult{}
+		failedFiles int
+	)
+
+	require.Nil(t, os.MkdirAll(daemon.cfg.TempDirectory(), 0755))
+	require.Nil(t, os.WriteFile(usersPath, []byte(testUsersFile), 0644))
+
+	writeTestZip(t, zipPath, map[string]string{
+		"groups.json":   testGroupsFile,
+		"sessions.json": testSessionsFile,
+		"invalid.json":  `{"data": []}`,
+	})
+
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIngesting, false).Return(nil)
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIdle, false).Return(nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.Neo4jConfigs).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.DeletedObjectsKey).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureAdcs).Return(appcfg.FeatureFlag{}, nil)
+	mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(1)).Return(model.FileUploadJob{}, nil).Times(4)
+	mockDB.EXPECT().UpdateFileUploadJob(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, job model.FileUploadJob) error {
+		failedFiles += job.FailedFiles
+		return nil
+	}).Times(2)
+	mockDB.EXPECT().CreateFileUploadJobResults(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, taskResults model.FileUploadJobResults) error {
+		resultsLock.Lock()
+		defer resultsLock.Unlock()
+
+		for _, result := range taskResults {
+			require.Equal(t, int64(1), result.FileUploadJobID)
+			results[result.FileName] = result
+		}
+
+		return nil
+	}).Times(2)
+	mockDB.EXPECT().SaveDomainCollections(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, collections model.DomainCollections) error {
+		require.Equal(t, model.DomainCollections{{
+			DomainSID:   "S-1-5-21-1",
+			DataType:    "sessions",
+			ObjectCount: 1,
+		}}, collections)
+
+		return nil
+	})
+	mockDB.EXPECT().DeleteIngestTask(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	daemon.processIngestTasks(context.Background(), model.IngestTasks{{
+		FileName: usersPath,
+		TaskID:   null.Int64From(1),
+		FileType: model.FileTypeJson,
+	}, {
+		FileName: zipPath,
+		TaskID:   null.Int64From(1),
+		FileType: model.FileTypeZip,
+	}})
+
+	require.Equal(t, 1, failedFiles)
+	require.Len(t, results, 4)
+	require.Equal(t, "groups", results["groups.json"].DataType)
+	require.Equal(t, 1, results["groups.json"].ObjectsWritten)
+	require.Equal(t, 1, results["sessions.json"].ObjectsWritten)
+	require.Equal(t, 1, results["invalid.json"].ErrorCount)
+	require.NoFileExists(t, usersPath)
+	require.NoFileExists(t, zipPath)
+
+	tempFiles, err := os.ReadDir(daemon.cfg.TempDirectory())
+	require.Nil(t, err)
+	require.Empty(t, tempFiles)
+
+	require.Nil(t, graphDB.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Relationships().Filter(query.KindIn(query.Relationship(), ad.MemberOf, ad.HasSession)).Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(2), count)
+		}
+
+		return nil
+	}))
+}
+
+func TestIngestChunkSize(t *testing.T) {
+	require.Equal(t, IngestCountThreshold, ingestChunkSize(0, 4))
+	require.Equal(t, IngestCountThreshold, ingestChunkSize(1_000, 1))
+	require.Equal(t, 250, ingestChunkSize(1_000, 4))
+	require.Equal(t, 1, ingestChunkSize(1_000, 2_000))
+}
+
+func TestDaemon_IngestPhaseWorkerCount(t *testing.T) {
+	var (
+		daemon = &Daemon{
+			cfg: config.Configuration{
+				IngestWorkers: 4,
+			},
+		}
+		work = make([]ingestWork, 2)
+	)
+
+	// Phases run no more workers than they have passes to read
+	require.Equal(t, 2, daemon.ingestPhaseWorkerCount(ingestPhaseNodes, work))
+	require.Equal(t, 2, daemon.ingestPhaseWorkerCount(ingestPhaseRelationships, work))
+	require.Equal(t, 4, daemon.ingestPhaseWorkerCount(ingestPhaseRelationships, make([]ingestWork, 8)))
+	require.Equal(t, 1, daemon.ingestPhaseWorkerCount(ingestPhaseRelationships, nil))
+	require.Equal(t, 1, daemon.ingestPhaseWorkerCount(ingestPhaseRemoved, work))
+}
+
+// failingBatchDatabase runs batch delegates against the wrapped database but reports that the batch failed to commit
+type failingBatchDatabase struct {
+	graph.Database
+	err error
+}
+
+func (s failingBatchDatabase) BatchOperation(ctx context.Context, batchDelegate graph.BatchDelegate) error {
+	if err := s.Database.BatchOperation(ctx, batchDelegate); err != nil {
+		return err
+	}
+
+	return s.err
+}
+
+func TestDaemon_IngestFile_BatchFailure(t *testing.T) {
+	var (
+		batchErr = errors.New("batch failed")
+		workDir  = t.TempDir()
+		daemon   = &Daemon{
+			graphdb: failingBatchDatabase{
+				Database: memory.NewDatabase(0),
+				err:      batchErr,
+			},
+			cfg: config.Configuration{
+				WorkDir: workDir,
+			},
+			ctx: context.Background(),
+		}
+
+		usersPath = filepath.Join(workDir, "users")
+	)
+
+	require.Nil(t, os.WriteFile(usersPath, []byte(testUsersFile), 0644))
+
+	_, work := daemon.prepareIngestTask(model.IngestTask{
+		FileName: usersPath,
+		FileType: model.FileTypeJson,
+	})
+	require.Len(t, work, 2)
+
+	for _, pass := range work {
+		result, err := daemon.ingestFile(context.Background(), pass, ReadOptions{ChunkSize: 1})
+		require.ErrorIs(t, err, batchErr)
+		require.Equal(t, 1, result.ObjectsRead)
+		require.Equal(t, 0, result.ObjectsWritten)
+		require.Equal(t, 1, result.ObjectsSkipped)
+		require.Equal(t, 1, result.ErrorCount)
+	}
+}
+
+// deadlockingBatchDatabase runs batch delegates against the wrapped database but reports that the first batches
+// deadlocked, as PostgreSQL does when concurrent batches lock the same nodes in differing order
+type deadlockingBatchDatabase struct {
+	graph.Database
+	deadlocks *int
+}
+
+func (s deadlockingBatchDatabase) BatchOperation(ctx context.Context, batchDelegate graph.BatchDelegate) error {
+	if err := s.Database.BatchOperation(ctx, batchDelegate); err != nil {
+		return err
+	} else if *s.deadlocks > 0 {
+		*s.deadlocks--
+		return &pgconn.PgError{Code: pg.StateDeadlockDetected.String(), Message: "deadlock detected"}
+	}
+
+	return nil
+}
+
+func TestDaemon_IngestFile_DeadlockRetry(t *testing.T) {
+	var (
+		deadlocks = 2
+		workDir   = t.TempDir()
+		daemon    = &Daemon{
+			graphdb: deadlockingBatchDatabase{
+				Database:  memory.NewDatabase(0),
+				deadlocks: &deadlocks,
+			},
+			cfg: config.Configuration{
+				WorkDir: workDir,
+			},
+			ctx: context.Background(),
+		}
+
+		usersPath = filepath.Join(workDir, "users")
+	)
+
+	require.Nil(t, os.WriteFile(usersPath, []byte(testUsersFile), 0644))
+
+	_, work := daemon.prepareIngestTask(model.IngestTask{
+		FileName: usersPath,
+		FileType: model.FileTypeJson,
+	})
+	require.Len(t, work, 2)
+
+	// The pass is read again until its batch commits
+	result, err := daemon.ingestFile(context.Background(), work[0], ReadOptions{ChunkSize: 1})
+	require.Nil(t, err)
+	require.Equal(t, 0, deadlocks)
+	require.Equal(t, 1, result.ObjectsRead)
+	require.Equal(t, 1, result.ObjectsWritten)
+	require.Equal(t, 0, result.ErrorCount)
+
+	// Passes that keep deadlocking eventually fail
+	deadlocks = maxIngestDeadlockRetries + 1
+
+	result, err = daemon.ingestFile(context.Background(), work[1], ReadOptions{ChunkSize: 1})
+	require.True(t, pg.StateDeadlockDetected.ErrorMatches(err))
+	require.Equal(t, 0, deadlocks)
+	require.Equal(t, 0, result.ObjectsWritten)
+	require.Equal(t, 1, result.ErrorCount)
+}
+
+func TestDaemon_ProcessIngestTasks_Compressed(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		graphDB  = memory.NewDatabase(0)
+		workDir  = t.TempDir()
+		daemon   = &Daemon{
+			db:      mockDB,
+			graphdb: graphDB,
+			cfg: config.Configuration{
+				WorkDir:       workDir,
+				IngestWorkers: 2,
+			},
+			ctx: context.Background(),
+		}
+
+		gzipPath = filepath.Join(workDir, "users")
+		tarPath  = filepath.Join(workDir, "collection")
+		results  = map[string]model.FileUploadJobResult{}
+	)
+
+	require.Nil(t, os.MkdirAll(daemon.cfg.TempDirectory(), 0755))
+
+	writeTestGzip(t, gzipPath, []byte(testUsersFile))
+	writeTestZstdTar(t, tarPath, map[string]string{
+		"collection/groups.json":   testGroupsFile,
+		"collection/sessions.json": testSessionsFile,
+	})
+
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIngesting, false).Return(nil)
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIdle, false).Return(nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.Neo4jConfigs).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.DeletedObjectsKey).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureAdcs).Return(appcfg.FeatureFlag{}, nil)
+	mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(1)).Return(model.FileUploadJob{}, nil).Times(4)
+	mockDB.EXPECT().UpdateFileUploadJob(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, job model.FileUploadJob) error {
+		require.Zero(t, job.FailedFiles)
+		return nil
+	}).Times(2)
+	mockDB.EXPECT().CreateFileUploadJobResults(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, taskResults model.FileUploadJobResults) error {
+		for _, result := range taskResults {
+			results[result.FileName] = result
+		}
+
+		return nil
+	}).Times(2)
+	mockDB.EXPECT().SaveDomainCollections(gomock.Any(), gomock.Any()).Return(nil)
+	mockDB.EXPECT().DeleteIngestTask(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	daemon.processIngestTasks(context.Background(), model.IngestTasks{{
+		FileName: gzipPath,
+		TaskID:   null.Int64From(1),
+		FileType: model.FileTypeGzip,
+	}, {
+		FileName: tarPath,
+		TaskID:   null.Int64From(1),
+		FileType: model.FileTypeZstd,
+	}})
+
+	require.Len(t, results, 3)
+	require.Equal(t, 1, results["users"].ObjectsWritten)
+	require.Equal(t, 1, results["collection/groups.json"].ObjectsWritten)
+	require.Equal(t, 1, results["collection/sessions.json"].ObjectsWritten)
+	require.NoFileExists(t, gzipPath)
+	require.NoFileExists(t, tarPath)
+
+	tempFiles, err := os.ReadDir(daemon.cfg.TempDirectory())
+	require.Nil(t, err)
+	require.Empty(t, tempFiles)
+}
+
+// cancelingDatabase cancels ingest once the first batch has been written to the wrapped database
+type cancelingDatabase struct {
+	graph.Database
+	cancel context.CancelFunc
+}
+
+func (s cancelingDatabase) BatchOperation(ctx context.Context, batchDelegate graph.BatchDelegate) error {
+	defer s.cancel()
+	return s.Database.BatchOperation(ctx, batchDelegate)
+}
+
+func TestDaemon_ProcessIngestTasks_Interrupted(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockDB      = mocks.NewMockDatabase(mockCtrl)
+		ctx, cancel = context.WithCancel(context.Background())
+		workDir     = t.TempDir()
+		daemon      = &Daemon{
+			db: mockDB,
+			graphdb: cancelingDatabase{
+				Database: memory.NewDatabase(0),
+				cancel:   cancel,
+			},
+			cfg: config.Configuration{
+				WorkDir:       workDir,
+				IngestWorkers: 1,
+			},
+			ctx: context.Background(),
+		}
+
+		zipPath = filepath.Join(workDir, "collection")
+	)
+
+	defer cancel()
+
+	require.Nil(t, os.MkdirAll(daemon.cfg.TempDirectory(), 0755))
+
+	writeTestZip(t, zipPath, map[string]string{
+		"groups.json":   testGroupsFile,
+		"sessions.json": testSessionsFile,
+	})
+
+	// The interrupted task is neither finished nor removed so that it may be picked up again
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIngesting, false).Return(nil)
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIdle, false).Return(nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.Neo4jConfigs).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.DeletedObjectsKey).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureAdcs).Return(appcfg.FeatureFlag{}, nil)
+	mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(1)).Return(model.FileUploadJob{}, nil)
+
+	daemon.processIngestTasks(ctx, model.IngestTasks{{
+		FileName: zipPath,
+		TaskID:   null.Int64From(1),
+		FileType: model.FileTypeZip,
+	}})
+
+	require.FileExists(t, zipPath)
+}
+
+// recordingBatch records the order in which node and relationship writes are made to the wrapped batch
+type recordingBatch struct {
+	graph.Batch
+	writes *recordedWrites
+}
+
+type recordedWrites struct {
+	lock   sync.Mutex
+	writes []string
+}
+
+func (s *recordedWrites) record(write string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.writes = append(s.writes, write)
+}
+
+func (s recordingBatch) UpdateNodeBy(update graph.NodeUpdate) error {
+	s.writes.record("node")
+	return s.Batch.UpdateNodeBy(update)
+}
+
+func (s recordingBatch) UpdateRelationshipBy(update graph.RelationshipUpdate) error {
+	s.writes.record("relationship")
+	return s.Batch.UpdateRelationshipBy(update)
+}
+
+type recordingDatabase struct {
+	graph.Database
+	writes *recordedWrites
+}
+
+func (s recordingDatabase) BatchOperation(ctx context.Context, batchDelegate graph.BatchDelegate) error {
+	return s.Database.BatchOperation(ctx, func(batch graph.Batch) error {
+		return batchDelegate(recordingBatch{
+			Batch:  batch,
+			writes: s.writes,
+		})
+	})
+}
+
+func TestDaemon_ProcessIngestTasks_Phases(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		writes   = &recordedWrites{}
+		workDir  = t.TempDir()
+		daemon   = &Daemon{
+			db: mockDB,
+			graphdb: recordingDatabase{
+				Database: memory.NewDatabase(0),
+				writes:   writes,
+			},
+			cfg: config.Configuration{
+				WorkDir:       workDir,
+				IngestWorkers: 4,
+			},
+			ctx: context.Background(),
+		}
+
+		usersPath  = filepath.Join(workDir, "users")
+		groupsPath = filepath.Join(workDir, "groups")
+		results    = map[string]model.FileUploadJobResult{}
+	)
+
+	require.Nil(t, os.WriteFile(usersPath, []byte(testUsersFile), 0644))
+	require.Nil(t, os.WriteFile(groupsPath, []byte(testGroupsFile), 0644))
+
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIngesting, false).Return(nil)
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIdle, false).Return(nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.Neo4jConfigs).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.DeletedObjectsKey).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureAdcs).Return(appcfg.FeatureFlag{}, nil)
+	mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(1)).Return(model.FileUploadJob{}, nil).Times(4)
+	mockDB.EXPECT().UpdateFileUploadJob(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockDB.EXPECT().CreateFileUploadJobResults(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, taskResults model.FileUploadJobResults) error {
+		for _, result := range taskResults {
+			results[result.FileName] = result
+		}
+
+		return nil
+	}).Times(2)
+	mockDB.EXPECT().DeleteIngestTask(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	daemon.processIngestTasks(context.Background(), model.IngestTasks{{
+		FileName: groupsPath,
+		TaskID:   null.Int64From(1),
+		FileType: model.FileTypeJson,
+	}, {
+		FileName: usersPath,
+		TaskID:   null.Int64From(1),
+		FileType: model.FileTypeJson,
+	}})
+
+	// Each file is read once per phase but reported once
+	require.Len(t, results, 2)
+	require.Equal(t, 1, results["users"].ObjectsRead)
+	require.Equal(t, 1, results["users"].ObjectsWritten)
+	require.Equal(t, 1, results["groups"].ObjectsRead)
+	require.Equal(t, 1, results["groups"].ObjectsWritten)
+
+	// Every node is written before the first relationship
+	require.Equal(t, []string{"node", "node", "relationship"}, writes.writes)
+}
+
+func TestMergeIngestPass(t *testing.T) {
+	var (
+		decodeErr = model.IngestError{Offset: 10, Message: "decode failed"}
+		nodeErr   = model.IngestError{Offset: 0, Message: "node write failed"}
+		result    = model.FileUploadJobResult{
+			ObjectsRead:    4,
+			ObjectsWritten: 3,
+			ObjectsSkipped: 1,
+			ErrorCount:     2,
+			Errors:         model.IngestErrors{nodeErr, decodeErr},
+		}
+	)
+
+	mergeIngestPass(&result, model.FileUploadJobResult{
+		ObjectsRead:    4,
+		ObjectsWritten: 2,
+		ObjectsSkipped: 2,
+		ErrorCount:     2,
+		Errors:         model.IngestErrors{decodeErr, {Offset: 20, Message: "relationship write failed"}},
+	})
+
+	require.Equal(t, 4, result.ObjectsRead)
+	require.Equal(t, 2, result.ObjectsWritten)
+	require.Equal(t, 2, result.ObjectsSkipped)
+	require.Equal(t, 3, result.ErrorCount)
+	require.Len(t, result.Errors, 3)
+}
+
+func TestArchiveExtractor_DecompressionLimit(t *testing.T) {
+	var (
+		workDir = t.TempDir()
+		daemon  = &Daemon{
+			cfg: config.Configuration{
+				WorkDir: workDir,
+			},
+		}
+		tarPath = filepath.Join(workDir, "collection")
+	)
+
+	require.Nil(t, os.MkdirAll(daemon.cfg.TempDirectory(), 0755))
+
+	writeTestZstdTar(t, tarPath, map[string]string{
+		"users.json":  testUsersFile,
+		"groups.json": testGroupsFile,
+	})
+
+	fin, err := os.Open(tarPath)
+	require.Nil(t, err)
+	defer fin.Close()
+
+	decompressor, err := fileupload.NewDecompressor(fin, model.FileTypeZstd)
+	require.Nil(t, err)
+	defer decompressor.Close()
+
+	// The limit is shared by every entry so that many small entries can not be used to exceed it
+	extractor := daemon.newArchiveExtractor(tarPath)
+	extractor.limiter = fileupload.NewDecompressionLimiter(int64(len(testUsersFile)+len(testGroupsFile)) - 1)
+
+	files, failed, err := extractor.listTar(decompressor, model.FileTypeZstd)
+	require.ErrorIs(t, err, fileupload.ErrDecompressionLimitExceeded)
+	require.Len(t, files, 1)
+	require.Len(t, failed, 1)
+	require.Equal(t, 1, failed[0].ErrorCount)
+
+	// Tar entries are read straight from the archive rather than extracted
+	require.True(t, files[0].archived)
+	require.Equal(t, tarPath, files[0].path)
+
+	entries, err := os.ReadDir(daemon.cfg.TempDirectory())
+	require.Nil(t, err)
+	require.Empty(t, entries)
+}
+
+func TestOpenTarEntryFile(t *testing.T) {
+	var (
+		workDir = t.TempDir()
+		tarPath = filepath.Join(workDir, "collection")
+	)
+
+	writeTestZstdTar(t, tarPath, map[string]string{
+		"collection/users.json": testUsersFile,
+	})
+
+	// The first entry of the test archive is its directory
+	entryFile, err := openTarEntryFile(tarPath, model.FileTypeZstd, 1, 0)
+	require.Nil(t, err)
+	defer entryFile.Close()
+
+	// Seeking to the start reads the entry again from the start of the archive
+	for range 2 {
+		content, err := io.ReadAll(entryFile)
+		require.Nil(t, err)
+		require.Equal(t, testUsersFile, string(content))
+
+		_, err = entryFile.Seek(0, io.SeekStart)
+		require.Nil(t, err)
+	}
+
+	_, err = entryFile.Seek(10, io.SeekStart)
+	require.ErrorIs(t, err, ErrUnsupportedSeek)
+
+	_, err = openTarEntryFile(tarPath, model.FileTypeZstd, 2, 0)
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestDaemon_ProcessIngestTasks_DryRun(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		graphDB  = memory.NewDatabase(0)
+		workDir  = t.TempDir()
+		daemon   = &Daemon{
+			db:      mockDB,
+			graphdb: graphDB,
+			cfg: config.Configuration{
+				WorkDir:       workDir,
+				IngestWorkers: 2,
+			},
+			ctx: context.Background(),
+		}
+
+		zipPath = filepath.Join(workDir, "collection")
+		results = map[string]model.FileUploadJobResult{}
+	)
+
+	require.Nil(t, os.MkdirAll(daemon.cfg.TempDirectory(), 0755))
+
+	writeTestZip(t, zipPath, map[string]string{
+		"users.json":   `{"data": [{"ObjectIdentifier": "S-1-5-21-1-1104", "Properties": {"name": "USER@TESTLAB.LOCAL", "favoritecolor": "blue"}}], "meta": {"type": "users", "version": 5, "count": 1, "methods": 0}}`,
+		"groups.json":  testGroupsFile,
+		"deleted.json": `{"data": [{"ObjectIdentifier": "S-1-5-21-1-1105", "ObjectType": "User"}], "meta": {"type": "deleted", "version": 6, "count": 1, "methods": 0}}`,
+	})
+
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIngesting, false).Return(nil)
+	mockDB.EXPECT().SetDatapipeStatus(gomock.Any(), model.DatapipeStatusIdle, false).Return(nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.Neo4jConfigs).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.DeletedObjectsKey).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureAdcs).Return(appcfg.FeatureFlag{}, nil)
+	mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(1)).Return(model.FileUploadJob{DryRun: true}, nil).Times(2)
+	mockDB.EXPECT().UpdateFileUploadJob(gomock.Any(), gomock.Any()).Return(nil)
+	mockDB.EXPECT().CreateFileUploadJobResults(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, taskResults model.FileUploadJobResults) error {
+		for _, result := range taskResults {
+			results[result.FileName] = result
+		}
+
+		return nil
+	})
+	mockDB.EXPECT().DeleteIngestTask(gomock.Any(), gomock.Any()).Return(nil)
+
+	daemon.processIngestTasks(context.Background(), model.IngestTasks{{
+		FileName: zipPath,
+		TaskID:   null.Int64From(1),
+		FileType: model.FileTypeZip,
+	}})
+
+	require.Len(t, results, 3)
+	require.Equal(t, 1, results["deleted.json"].ObjectsWritten)
+
+	report := model.FileUploadJobResults{results["users.json"], results["groups.json"], results["deleted.json"]}.Report()
+	require.Equal(t, 1, report.NodeKinds[ad.User.String()])
+	require.Equal(t, 1, report.NodeKinds[ad.Group.String()])
+	require.Equal(t, 1, report.RelationshipKinds[ad.MemberOf.String()])
+	require.Equal(t, 1, report.UnknownProperties["favoritecolor"])
+	require.Empty(t, report.SchemaViolations)
+
+	// Nothing may be written to the graph by a dry run
+	require.Nil(t, graphDB.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Nodes().Count(); err != nil {
+			return err
+		} else {
+			require.Zero(t, count)
+		}
+
+		return nil
+	}))
+}
+
+func TestDryRunBatch_SchemaViolations(t *testing.T) {
+	batch := newDryRunBatch()
+
+	require.Nil(t, batch.UpdateNodeBy(graph.NodeUpdate{
+		Node:               graph.PrepareNode(graph.NewProperties().Set("name", "NOID"), graph.StringKind("Widget")),
+		IdentityKind:       ad.Entity,
+		IdentityProperties: []string{"objectid"},
+	}))
+
+	require.Nil(t, batch.UpdateRelationshipBy(graph.RelationshipUpdate{
+		Relationship:            graph.PrepareRelationship(graph.NewProperties(), graph.StringKind("Frobs")),
+		Start:                   graph.PrepareNode(graph.NewProperties().Set("objectid", "A"), ad.User),
+		StartIdentityKind:       ad.Entity,
+		StartIdentityProperties: []string{"objectid"},
+		End:                     graph.PrepareNode(graph.NewProperties(), ad.Computer),
+		EndIdentityKind:         ad.Entity,
+		EndIdentityProperties:   []string{"objectid"},
+	}))
+
+	require.Equal(t, 1, batch.report.NodeKinds["Widget"])
+	require.Equal(t, 1, batch.report.RelationshipKinds["Frobs"])
+	require.Equal(t, 1, batch.report.SchemaViolations["node kind Widget is not defined in the schema"])
+	require.Equal(t, 1, batch.report.SchemaViolations["node is missing identity property objectid"])
+	require.Equal(t, 1, batch.report.SchemaViolations["relationship kind Frobs is not defined in the schema"])
+	require.Equal(t, 1, batch.report.SchemaViolations["relationship end node is missing identity property objectid"])
+}
@@ -147,4 +147,22 @@ func TestProcessIngestedFileUploadJobs(t *testing.T) {
 
 		datapipe.ProcessIngestedFileUploadJobs(context.Background(), dbMock)
 	})
+
+	t.Run("Complete Dry Run Jobs without Analysis", func(t *testing.T) {
+		dbMock.EXPECT().GetFileUploadJobsWithStatus(gomock.Any(), model.JobStatusIngesting).Return([]model.FileUploadJob{{
+			BigSerial: model.BigSerial{
+				ID: jobID,
+			},
+			Status: model.JobStatusIngesting,
+			DryRun: true,
+		}}, nil)
+
+		dbMock.EXPECT().GetIngestTasksForJob(gomock.Any(), jobID).Return([]model.IngestTask{}, nil)
+		dbMock.EXPECT().UpdateFileUploadJob(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fileUploadJob model.FileUploadJob) error {
+			require.Equal(t, model.JobStatusComplete, fileUploadJob.Status)
+			return nil
+		})
+
+		datapipe.ProcessIngestedFileUploadJobs(context.Background(), dbMock)
+	})
 }
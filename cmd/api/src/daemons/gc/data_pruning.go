@@ -18,22 +18,29 @@ package gc
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/model/appcfg"
 )
 
 // Daemon holds data relevant to the data daemon
 type Daemon struct {
-	exitC chan struct{}
-	db    database.Database
+	exitC   chan struct{}
+	db      database.Database
+	graphDB graph.Database
 }
 
 // NewDataPruningDaemon creates a new data pruning daemon
-func NewDataPruningDaemon(db database.Database) *Daemon {
+func NewDataPruningDaemon(db database.Database, graphDB graph.Database) *Daemon {
 	return &Daemon{
-		exitC: make(chan struct{}),
-		db:    db,
+		exitC:   make(chan struct{}),
+		db:      db,
+		graphDB: graphDB,
 	}
 }
 
@@ -49,9 +56,10 @@ func (s *Daemon) Start(ctx context.Context) {
 	defer close(s.exitC)
 	defer ticker.Stop()
 
-	// prune sessions and collections once when the daemon starts up
+	// prune sessions, collections and stale graph sessions once when the daemon starts up
 	s.db.SweepSessions(ctx)
 	s.db.SweepAssetGroupCollections(ctx)
+	s.sweepHasSessionEdges(ctx)
 
 	// thereafter, prune conditionally once a day
 	for {
@@ -59,6 +67,7 @@ func (s *Daemon) Start(ctx context.Context) {
 		case <-ticker.C:
 			s.db.SweepSessions(ctx)
 			s.db.SweepAssetGroupCollections(ctx)
+			s.sweepHasSessionEdges(ctx)
 
 		case <-s.exitC:
 			return
@@ -66,6 +75,40 @@ func (s *Daemon) Start(ctx context.Context) {
 	}
 }
 
+// sweepHasSessionEdges deletes the HasSession edges that have not been observed by any ingest within the configured
+// HasSession edge TTL from the default graph and from the graph of every workspace
+func (s *Daemon) sweepHasSessionEdges(ctx context.Context) {
+	var (
+		pruneTTLParameters = appcfg.GetPruneTTLParameters(ctx, s.db)
+		before             = time.Now().UTC().Add(-pruneTTLParameters.HasSessionEdgeTTL)
+	)
+
+	s.deleteStaleSessions(ctx, before, "the default graph")
+
+	// Workspaces may only be created on PostgreSQL so there are none to sweep on other graph drivers
+	if workspaces, err := s.db.GetAllWorkspaces(ctx, ""); err != nil {
+		log.Errorf("Failed to fetch workspaces to prune HasSession edges: %v", err)
+	} else {
+		for _, workspace := range workspaces {
+			if ctx.Err() != nil {
+				return
+			}
+
+			s.deleteStaleSessions(graph.WithGraphTarget(ctx, workspace.Graph()), before, fmt.Sprintf("workspace %d (%s)", workspace.ID, workspace.Name))
+		}
+	}
+}
+
+// deleteStaleSessions deletes the HasSession edges last seen before the given time from the graph targeted by the
+// given context
+func (s *Daemon) deleteStaleSessions(ctx context.Context, before time.Time, graphName string) {
+	if numDeleted, err := adAnalysis.DeleteStaleSessions(ctx, s.graphDB, before); err != nil {
+		log.Errorf("Failed to prune HasSession edges last seen before %s from %s: %v", before.Format(time.RFC3339), graphName, err)
+	} else if numDeleted > 0 {
+		log.Infof("Pruned %d HasSession edges last seen before %s from %s", numDeleted, before.Format(time.RFC3339), graphName)
+	}
+}
+
 // Stop passes in a stop signal to the exit channel, thereby killing the daemon
 func (s *Daemon) Stop(ctx context.Context) error {
 	s.exitC <- struct{}{}
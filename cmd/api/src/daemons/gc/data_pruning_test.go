@@ -18,10 +18,18 @@ package gc
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
 	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/appcfg"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -30,7 +38,7 @@ func TestGC_NewDataPruningDaemon(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	daemon := NewDataPruningDaemon(mocks.NewMockDatabase(mockCtrl))
+	daemon := NewDataPruningDaemon(mocks.NewMockDatabase(mockCtrl), memory.NewDatabase(0))
 	require.NotNil(t, daemon)
 }
 
@@ -38,7 +46,7 @@ func TestGC_Name(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	daemon := NewDataPruningDaemon(mocks.NewMockDatabase(mockCtrl))
+	daemon := NewDataPruningDaemon(mocks.NewMockDatabase(mockCtrl), memory.NewDatabase(0))
 	require.NotNil(t, daemon)
 
 	result := daemon.Name()
@@ -49,7 +57,24 @@ func TestGC_Start(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mocks.NewMockDatabase(mockCtrl)
+	var (
+		mockDB  = mocks.NewMockDatabase(mockCtrl)
+		graphDB = memory.NewDatabase(0)
+		nowUTC  = time.Now().UTC()
+	)
+
+	require.Nil(t, graphDB.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if computer, err := tx.CreateNode(graph.NewProperties(), ad.Entity, ad.Computer); err != nil {
+			return err
+		} else if user, err := tx.CreateNode(graph.NewProperties(), ad.Entity, ad.User); err != nil {
+			return err
+		} else if _, err := tx.CreateRelationshipByIDs(computer.ID, user.ID, ad.HasSession, graph.NewProperties().Set(common.LastSeen.String(), nowUTC)); err != nil {
+			return err
+		} else {
+			_, err := tx.CreateRelationshipByIDs(user.ID, computer.ID, ad.HasSession, graph.NewProperties().Set(common.LastSeen.String(), nowUTC.Add(-2*appcfg.DefaultPruneHasSessionEdgeTTL)))
+			return err
+		}
+	}))
 
 	mockDB.EXPECT().SweepSessions(gomock.Any()).Do(func(ctx context.Context) {
 		// simulate some work being done
@@ -59,7 +84,10 @@ func TestGC_Start(t *testing.T) {
 		time.Sleep(1 * time.Millisecond)
 	})
 
-	daemon := NewDataPruningDaemon(mockDB)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PruneTTL).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetAllWorkspaces(gomock.Any(), "").Return(model.Workspaces{}, nil)
+
+	daemon := NewDataPruningDaemon(mockDB, graphDB)
 	require.NotNil(t, daemon)
 
 	go func() {
@@ -69,4 +97,70 @@ func TestGC_Start(t *testing.T) {
 	}()
 
 	daemon.Start(context.Background())
+
+	// Only the HasSession edge last seen outside the default TTL is pruned
+	require.Nil(t, graphDB.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Relationships().Filter(query.Kind(query.Relationship(), ad.HasSession)).Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(1), count)
+		}
+
+		return nil
+	}))
+}
+
+func TestGC_SweepHasSessionEdges_Workspaces(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		ctx       = context.Background()
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		graphDB   = graph.NewDatabaseSwitch(ctx, memory.NewDatabase(0))
+		workspace = model.Workspace{Name: "workspace"}
+		staleTime = time.Now().UTC().Add(-2 * appcfg.DefaultPruneHasSessionEdgeTTL)
+
+		createStaleSession = func(ctx context.Context) {
+			require.Nil(t, graphDB.WriteTransaction(ctx, func(tx graph.Transaction) error {
+				if computer, err := tx.CreateNode(graph.NewProperties(), ad.Entity, ad.Computer); err != nil {
+					return err
+				} else if user, err := tx.CreateNode(graph.NewProperties(), ad.Entity, ad.User); err != nil {
+					return err
+				} else {
+					_, err := tx.CreateRelationshipByIDs(computer.ID, user.ID, ad.HasSession, graph.NewProperties().Set(common.LastSeen.String(), staleTime))
+					return err
+				}
+			}))
+		}
+
+		countSessions = func(ctx context.Context) int64 {
+			var count int64
+
+			require.Nil(t, graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+				var err error
+
+				count, err = tx.Relationships().Filter(query.Kind(query.Relationship(), ad.HasSession)).Count()
+				return err
+			}))
+
+			return count
+		}
+	)
+
+	workspace.ID = 1
+	workspaceCtx := graph.WithGraphTarget(ctx, workspace.Graph())
+
+	createStaleSession(ctx)
+	createStaleSession(workspaceCtx)
+	require.Equal(t, int64(1), countSessions(ctx))
+	require.Equal(t, int64(1), countSessions(workspaceCtx))
+
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PruneTTL).Return(appcfg.Parameter{}, errors.New("not found"))
+	mockDB.EXPECT().GetAllWorkspaces(gomock.Any(), "").Return(model.Workspaces{workspace}, nil)
+
+	// Stale HasSession edges are pruned from the default graph and from the graph of every workspace
+	NewDataPruningDaemon(mockDB, graphDB).sweepHasSessionEdges(ctx)
+	require.Zero(t, countSessions(ctx))
+	require.Zero(t, countSessions(workspaceCtx))
 }
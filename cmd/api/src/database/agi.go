@@ -18,6 +18,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -172,6 +173,33 @@ func (s *BloodhoundDB) GetLatestAssetGroupCollection(ctx context.Context, assetG
 	return latestCollection, result.Error
 }
 
+func (s *BloodhoundDB) GetAssetGroupCollection(ctx context.Context, assetGroupID int32, collectionID int64) (model.AssetGroupCollection, error) {
+	var (
+		collection model.AssetGroupCollection
+		result     = s.preload(model.AssetGroupCollectionAssociations()).
+				WithContext(ctx).
+				Where("asset_group_id = ? AND id = ?", assetGroupID, collectionID).
+				First(&collection)
+	)
+
+	return collection, CheckError(result)
+}
+
+// GetAssetGroupCollectionBefore returns the most recent collection of the given asset group that was created at or
+// before the given time
+func (s *BloodhoundDB) GetAssetGroupCollectionBefore(ctx context.Context, assetGroupID int32, before time.Time) (model.AssetGroupCollection, error) {
+	var (
+		collection model.AssetGroupCollection
+		result     = s.preload(model.AssetGroupCollectionAssociations()).
+				WithContext(ctx).
+				Where("asset_group_id = ? AND created_at <= ?", assetGroupID, before).
+				Order("created_at DESC").
+				First(&collection)
+	)
+
+	return collection, CheckError(result)
+}
+
 func (s *BloodhoundDB) GetTimeRangedAssetGroupCollections(ctx context.Context, assetGroupID int32, from int64, to int64, order string) (model.AssetGroupCollections, error) {
 	var (
 		collections model.AssetGroupCollections
@@ -224,12 +252,7 @@ func (s *BloodhoundDB) UpdateAssetGroupSelectors(ctx context.Context, assetGroup
 		for _, selectorSpec := range selectorSpecs {
 			switch selectorSpec.Action {
 			case model.SelectorSpecActionAdd:
-				assetGroupSelector := model.AssetGroupSelector{
-					AssetGroupID:   assetGroup.ID,
-					Name:           selectorSpec.SelectorName,
-					Selector:       selectorSpec.EntityObjectID,
-					SystemSelector: systemSelector,
-				}
+				assetGroupSelector := selectorSpec.AssetGroupSelector(assetGroup.ID, systemSelector)
 
 				if selectorsMatched := tx.Where("asset_group_id=? AND name=?", assetGroup.ID, selectorSpec.SelectorName).Find(&model.AssetGroupSelector{}).RowsAffected; selectorsMatched == 0 {
 					// create a new db entry only if it doesn't exist, otherwise continue execution
@@ -241,8 +264,15 @@ func (s *BloodhoundDB) UpdateAssetGroupSelectors(ctx context.Context, assetGroup
 				updatedSelectors.Added = append(updatedSelectors.Added, assetGroupSelector)
 
 			case model.SelectorSpecActionRemove:
-				if result := tx.Where("asset_group_id=? AND name=?", assetGroup.ID, selectorSpec.SelectorName).Delete(&model.AssetGroupSelector{}); result.Error != nil {
+				var existingSelectors model.AssetGroupSelectors
+
+				if result := tx.Where("asset_group_id=? AND name=?", assetGroup.ID, selectorSpec.SelectorName).Find(&existingSelectors); result.Error != nil {
+					return CheckError(result)
+				} else if result := tx.Where("asset_group_id=? AND name=?", assetGroup.ID, selectorSpec.SelectorName).Delete(&model.AssetGroupSelector{}); result.Error != nil {
 					return CheckError(result)
+				} else if len(existingSelectors) > 0 {
+					// report the removed selector as it was stored so that dynamic selectors can be re-evaluated
+					updatedSelectors.Removed = append(updatedSelectors.Removed, existingSelectors...)
 				} else {
 					updatedSelectors.Removed = append(updatedSelectors.Removed, model.AssetGroupSelector{
 						AssetGroupID: assetGroup.ID,
@@ -259,10 +289,23 @@ func (s *BloodhoundDB) UpdateAssetGroupSelectors(ctx context.Context, assetGroup
 	return updatedSelectors, err
 }
 
+// UpdateAssetGroupSelectorMemberCounts records the number of members that each of the given selectors matched
+func (s *BloodhoundDB) UpdateAssetGroupSelectorMemberCounts(ctx context.Context, memberCounts map[int32]int) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for selectorID, memberCount := range memberCounts {
+			if result := tx.Model(&model.AssetGroupSelector{}).Where("id = ?", selectorID).Update("member_count", memberCount); result.Error != nil {
+				return CheckError(result)
+			}
+		}
+
+		return nil
+	})
+}
+
 func (s *BloodhoundDB) CreateAssetGroupCollection(ctx context.Context, collection model.AssetGroupCollection, entries model.AssetGroupCollectionEntries) error {
 	const CreateAssetGroupCollectionQuery = `INSERT INTO "asset_group_collection_entries"
-    ("asset_group_collection_id","object_id","node_label","properties","created_at","updated_at")
-	(SELECT * FROM unnest($1::bigint[], $2::text[], $3::text[], $4::jsonb[], $5::timestamp[], $5::timestamp[]));`
+    ("asset_group_collection_id","object_id","node_label","properties","selectors","created_at","updated_at")
+	(SELECT * FROM unnest($1::bigint[], $2::text[], $3::text[], $4::jsonb[], $5::text[]::jsonb[], $6::timestamp[], $6::timestamp[]));`
 
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var newCollection = collection
@@ -278,11 +321,23 @@ func (s *BloodhoundDB) CreateAssetGroupCollection(ctx context.Context, collectio
 				objectIds  = make([]string, len(entries))
 				labels     = make([]string, len(entries))
 				properties = make([]types.JSONUntypedObject, len(entries))
+				selectors  = make([]string, len(entries))
 				timestamps = make([]time.Time, len(entries))
 				now        = time.Now()
 			)
 
 			for idx := range entries {
+				entrySelectors := entries[idx].Selectors
+				if entrySelectors == nil {
+					entrySelectors = []string{}
+				}
+
+				if encodedSelectors, err := json.Marshal(entrySelectors); err != nil {
+					return err
+				} else {
+					selectors[idx] = string(encodedSelectors)
+				}
+
 				agIds[idx] = newCollection.ID
 				objectIds[idx] = entries[idx].ObjectID
 				labels[idx] = entries[idx].NodeLabel
@@ -290,7 +345,7 @@ func (s *BloodhoundDB) CreateAssetGroupCollection(ctx context.Context, collectio
 				timestamps[idx] = now
 			}
 
-			return CheckError(tx.Exec(CreateAssetGroupCollectionQuery, agIds, objectIds, labels, properties, timestamps))
+			return CheckError(tx.Exec(CreateAssetGroupCollectionQuery, agIds, objectIds, labels, properties, selectors, timestamps))
 		}
 
 		return nil
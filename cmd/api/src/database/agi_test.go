@@ -22,7 +22,9 @@ import (
 	"context"
 	"slices"
 	"testing"
+	"time"
 
+	"github.com/specterops/bloodhound/src/database"
 	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/test/integration"
 	"github.com/specterops/bloodhound/src/utils/test"
@@ -116,3 +118,48 @@ func TestAssetGroupMemberCount(t *testing.T) {
 		}
 	})
 }
+
+func TestGetAssetGroupCollectionForDiff(t *testing.T) {
+	var (
+		dbInst  = integration.SetupDB(t)
+		testCtx = context.Background()
+	)
+
+	assetGroup, err := dbInst.CreateAssetGroup(testCtx, "collection diff test group", "cdtest", false)
+	require.Nil(t, err)
+
+	_, err = dbInst.GetAssetGroupCollectionBefore(testCtx, assetGroup.ID, time.Now())
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	err = dbInst.CreateAssetGroupCollection(testCtx, model.AssetGroupCollection{AssetGroupID: assetGroup.ID}, model.AssetGroupCollectionEntries{
+		{ObjectID: "obj1", NodeLabel: "TestNode1", Selectors: []string{"first", "second"}},
+		{ObjectID: "obj2", NodeLabel: "TestNode2"},
+	})
+	require.Nil(t, err)
+
+	latestCollection, err := dbInst.GetLatestAssetGroupCollection(testCtx, assetGroup.ID)
+	require.Nil(t, err)
+	require.Len(t, latestCollection.Entries, 2)
+
+	for _, entry := range latestCollection.Entries {
+		if entry.ObjectID == "obj1" {
+			require.Equal(t, []string{"first", "second"}, entry.Selectors)
+		} else {
+			require.Equal(t, []string{}, entry.Selectors)
+		}
+	}
+
+	collection, err := dbInst.GetAssetGroupCollection(testCtx, assetGroup.ID, latestCollection.ID)
+	require.Nil(t, err)
+	require.Equal(t, latestCollection.ID, collection.ID)
+
+	_, err = dbInst.GetAssetGroupCollection(testCtx, assetGroup.ID+1, latestCollection.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	collection, err = dbInst.GetAssetGroupCollectionBefore(testCtx, assetGroup.ID, time.Now())
+	require.Nil(t, err)
+	require.Equal(t, latestCollection.ID, collection.ID)
+
+	_, err = dbInst.GetAssetGroupCollectionBefore(testCtx, assetGroup.ID, time.Now().AddDate(0, 0, -1))
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/specterops/bloodhound/src/database/migration"
+	"github.com/specterops/bloodhound/src/version"
+	"gorm.io/gorm"
+)
+
+// BackupTables lists the tables that make up the application state of an instance. Tables are ordered so that every
+// table is listed after the tables it references. Ingest, analysis, session and audit data is not included.
+var BackupTables = []string{
+	"installations",
+	"sso_providers",
+	"saml_providers",
+	"oidc_providers",
+	"roles",
+	"permissions",
+	"roles_permissions",
+	"users",
+	"users_roles",
+	"auth_secrets",
+	"auth_tokens",
+	"workspaces",
+	"workspaces_users",
+	"asset_groups",
+	"asset_group_selectors",
+	"asset_group_collections",
+	"asset_group_collection_entries",
+	"saved_queries",
+	"saved_queries_permissions",
+	"parameters",
+	"feature_flags",
+}
+
+// BackupTable contains every row of a table encoded as a JSON array of row objects
+type BackupTable struct {
+	Name string
+	Rows json.RawMessage
+}
+
+type BackupData interface {
+	GetSchemaVersion(ctx context.Context) (version.Version, error)
+	ExportTable(ctx context.Context, table string) (BackupTable, error)
+	RestoreTables(ctx context.Context, tables []BackupTable) error
+}
+
+func validateBackupTable(table string) error {
+	if !slices.Contains(BackupTables, table) {
+		return fmt.Errorf("table %s is not part of a backup", table)
+	}
+
+	return nil
+}
+
+// GetSchemaVersion returns the version of the last successfully applied schema migration
+func (s *BloodhoundDB) GetSchemaVersion(ctx context.Context) (version.Version, error) {
+	if latestMigration, err := migration.NewMigrator(s.db.WithContext(ctx)).LatestMigration(); err != nil {
+		return version.Version{}, err
+	} else {
+		return version.Version{
+			Major: int(latestMigration.Major),
+			Minor: int(latestMigration.Minor),
+			Patch: int(latestMigration.Patch),
+		}, nil
+	}
+}
+
+func (s *BloodhoundDB) ExportTable(ctx context.Context, table string) (BackupTable, error) {
+	var rows string
+
+	if err := validateBackupTable(table); err != nil {
+		return BackupTable{}, err
+	} else if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(`select coalesce(json_agg(t), '[]') from "%s" t`, table)).Scan(&rows); result.Error != nil {
+		return BackupTable{}, result.Error
+	}
+
+	return BackupTable{
+		Name: table,
+		Rows: json.RawMessage(rows),
+	}, nil
+}
+
+// RestoreTables replaces the content of every backup table with the given rows. Tables not given are left empty. Row
+// IDs are preserved and the ID sequence of each table is restarted after the largest restored ID.
+func (s *BloodhoundDB) RestoreTables(ctx context.Context, tables []BackupTable) error {
+	for _, table := range tables {
+		if err := validateBackupTable(table.Name); err != nil {
+			return err
+		}
+	}
+
+	slices.SortStableFunc(tables, func(a, b BackupTable) int {
+		return slices.Index(BackupTables, a.Name) - slices.Index(BackupTables, b.Name)
+	})
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		quotedTables := make([]string, len(BackupTables))
+
+		for idx, table := range BackupTables {
+			quotedTables[idx] = fmt.Sprintf(`"%s"`, table)
+		}
+
+		if err := tx.Exec(fmt.Sprintf(`truncate %s cascade`, strings.Join(quotedTables, ", "))).Error; err != nil {
+			return err
+		}
+
+		for _, table := range tables {
+			if err := tx.Exec(fmt.Sprintf(`insert into "%s" select * from json_populate_recordset(null::"%s", ?::json)`, table.Name, table.Name), string(table.Rows)).Error; err != nil {
+				return fmt.Errorf("failed restoring table %s: %w", table.Name, err)
+			}
+		}
+
+		for _, table := range BackupTables {
+			var serialColumns []string
+
+			if err := tx.Raw(`select column_name from information_schema.columns where table_schema = current_schema() and table_name = ? and column_default like 'nextval%'`, table).Scan(&serialColumns).Error; err != nil {
+				return err
+			}
+
+			for _, column := range serialColumns {
+				if err := tx.Exec(fmt.Sprintf(`select setval(pg_get_serial_sequence('"%s"', '%s'), coalesce(max("%s"), 0) + 1, false) from "%s"`, table, column, column, table)).Error; err != nil {
+					return fmt.Errorf("failed restarting sequence of %s.%s: %w", table, column, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
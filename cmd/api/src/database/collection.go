@@ -0,0 +1,94 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"slices"
+
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// computerCollectionBatchSize bounds the number of computers whose collection failures are replaced per statement
+const computerCollectionBatchSize = 1000
+
+type CollectionData interface {
+	SaveDomainCollections(ctx context.Context, collections model.DomainCollections) error
+	GetDomainCollections(ctx context.Context, domainSID string) (model.DomainCollections, error)
+	ReplaceComputerCollectionFailures(ctx context.Context, computerObjectIDs []string, failures model.ComputerCollectionFailures) error
+	GetComputerCollectionFailures(ctx context.Context, domainSID string, limit int) (model.ComputerCollectionFailures, int, error)
+}
+
+// SaveDomainCollections records the given collections as the most recent collection of their domain and data type.
+// Collections that share the collection time of the existing record were submitted by the same file upload job and
+// are merged into it. Collections that are older than the existing record are ignored.
+func (s *BloodhoundDB) SaveDomainCollections(ctx context.Context, collections model.DomainCollections) error {
+	if len(collections) == 0 {
+		return nil
+	}
+
+	return CheckError(s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "domain_sid"}, {Name: "data_type"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "methods"}, Value: gorm.Expr("CASE WHEN domain_collections.collected_at = excluded.collected_at THEN domain_collections.methods | excluded.methods ELSE excluded.methods END")},
+			{Column: clause.Column{Name: "object_count"}, Value: gorm.Expr("CASE WHEN domain_collections.collected_at = excluded.collected_at THEN domain_collections.object_count + excluded.object_count ELSE excluded.object_count END")},
+			{Column: clause.Column{Name: "collected_at"}, Value: gorm.Expr("excluded.collected_at")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("excluded.updated_at")},
+		},
+		Where: clause.Where{Exprs: []clause.Expression{gorm.Expr("domain_collections.collected_at <= excluded.collected_at")}},
+	}).Create(&collections))
+}
+
+func (s *BloodhoundDB) GetDomainCollections(ctx context.Context, domainSID string) (model.DomainCollections, error) {
+	var collections model.DomainCollections
+	return collections, CheckError(s.db.WithContext(ctx).Where("domain_sid = ?", domainSID).Order("data_type").Find(&collections))
+}
+
+// ReplaceComputerCollectionFailures removes the recorded collection failures of the given computers and records the
+// given failures in their place
+func (s *BloodhoundDB) ReplaceComputerCollectionFailures(ctx context.Context, computerObjectIDs []string, failures model.ComputerCollectionFailures) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for batch := range slices.Chunk(computerObjectIDs, computerCollectionBatchSize) {
+			if err := CheckError(tx.Where("computer_object_id IN ?", batch).Delete(&model.ComputerCollectionFailure{})); err != nil {
+				return err
+			}
+		}
+
+		if len(failures) == 0 {
+			return nil
+		}
+
+		return CheckError(tx.CreateInBatches(&failures, computerCollectionBatchSize))
+	})
+}
+
+// GetComputerCollectionFailures returns up to limit of the recorded collection failures of computers in the given
+// domain along with the total number of failures recorded
+func (s *BloodhoundDB) GetComputerCollectionFailures(ctx context.Context, domainSID string, limit int) (model.ComputerCollectionFailures, int, error) {
+	var (
+		failures model.ComputerCollectionFailures
+		count    int64
+	)
+
+	if err := CheckError(s.db.WithContext(ctx).Model(&model.ComputerCollectionFailure{}).Where("domain_sid = ?", domainSID).Count(&count)); err != nil {
+		return nil, 0, err
+	}
+
+	return failures, int(count), CheckError(s.db.WithContext(ctx).Where("domain_sid = ?", domainSID).Order("computer_name, collection").Limit(limit).Find(&failures))
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/ingest"
+	"github.com/specterops/bloodhound/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainCollections(t *testing.T) {
+	var (
+		testCtx     = context.Background()
+		dbInst      = integration.SetupDB(t)
+		firstJobAt  = time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+		secondJobAt = time.Now().UTC().Truncate(time.Microsecond)
+		collection  = model.DomainCollection{
+			DomainSID:   "S-1-5-21-1",
+			DataType:    string(ingest.DataTypeComputer),
+			Methods:     ingest.CollectionMethodSession,
+			ObjectCount: 10,
+			CollectedAt: firstJobAt,
+		}
+	)
+
+	require.Nil(t, dbInst.SaveDomainCollections(testCtx, model.DomainCollections{collection}))
+
+	// Files of the same job are merged
+	collection.Methods = ingest.CollectionMethodLocalAdmin
+	require.Nil(t, dbInst.SaveDomainCollections(testCtx, model.DomainCollections{collection}))
+
+	collections, err := dbInst.GetDomainCollections(testCtx, "S-1-5-21-1")
+	require.Nil(t, err)
+	require.Len(t, collections, 1)
+	require.Equal(t, 20, collections[0].ObjectCount)
+	require.Equal(t, ingest.CollectionMethodSession|ingest.CollectionMethodLocalAdmin, collections[0].Methods)
+
+	// Files of a later job replace the record
+	collection.CollectedAt = secondJobAt
+	collection.ObjectCount = 5
+	require.Nil(t, dbInst.SaveDomainCollections(testCtx, model.DomainCollections{collection}))
+
+	// Files of an earlier job are ignored
+	collection.CollectedAt = firstJobAt
+	collection.ObjectCount = 1
+	require.Nil(t, dbInst.SaveDomainCollections(testCtx, model.DomainCollections{collection}))
+
+	collections, err = dbInst.GetDomainCollections(testCtx, "S-1-5-21-1")
+	require.Nil(t, err)
+	require.Len(t, collections, 1)
+	require.Equal(t, 5, collections[0].ObjectCount)
+	require.Equal(t, ingest.CollectionMethodLocalAdmin, collections[0].Methods)
+	require.True(t, secondJobAt.Equal(collections[0].CollectedAt))
+}
+
+func TestComputerCollectionFailures(t *testing.T) {
+	var (
+		testCtx  = context.Background()
+		dbInst   = integration.SetupDB(t)
+		failures = model.ComputerCollectionFailures{{
+			ComputerObjectID: "S-1-5-21-1-1000",
+			DomainSID:        "S-1-5-21-1",
+			Collection:       model.ComputerCollectionSessions,
+			FailureReason:    "ErrorAccessDenied",
+			CollectedAt:      time.Now().UTC(),
+		}, {
+			ComputerObjectID: "S-1-5-21-1-1001",
+			DomainSID:        "S-1-5-21-1",
+			Collection:       model.ComputerCollectionStatus,
+			FailureReason:    "PortNotOpen",
+			CollectedAt:      time.Now().UTC(),
+		}}
+	)
+
+	require.Nil(t, dbInst.ReplaceComputerCollectionFailures(testCtx, []string{"S-1-5-21-1-1000", "S-1-5-21-1-1001"}, failures))
+
+	fetched, count, err := dbInst.GetComputerCollectionFailures(testCtx, "S-1-5-21-1", 1)
+	require.Nil(t, err)
+	require.Equal(t, 2, count)
+	require.Len(t, fetched, 1)
+
+	// A later collection of the first computer succeeded
+	require.Nil(t, dbInst.ReplaceComputerCollectionFailures(testCtx, []string{"S-1-5-21-1-1000"}, nil))
+
+	fetched, count, err = dbInst.GetComputerCollectionFailures(testCtx, "S-1-5-21-1", 10)
+	require.Nil(t, err)
+	require.Equal(t, 1, count)
+	require.Equal(t, "PortNotOpen", fetched[0].FailureReason)
+}
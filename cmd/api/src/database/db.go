@@ -80,11 +80,14 @@ type Database interface {
 	SweepAssetGroupCollections(ctx context.Context)
 	GetAssetGroupCollections(ctx context.Context, assetGroupID int32, order string, filter model.SQLFilter) (model.AssetGroupCollections, error)
 	GetLatestAssetGroupCollection(ctx context.Context, assetGroupID int32) (model.AssetGroupCollection, error)
+	GetAssetGroupCollection(ctx context.Context, assetGroupID int32, collectionID int64) (model.AssetGroupCollection, error)
+	GetAssetGroupCollectionBefore(ctx context.Context, assetGroupID int32, before time.Time) (model.AssetGroupCollection, error)
 	GetTimeRangedAssetGroupCollections(ctx context.Context, assetGroupID int32, from int64, to int64, order string) (model.AssetGroupCollections, error)
 	GetAssetGroupSelector(ctx context.Context, id int32) (model.AssetGroupSelector, error)
 	DeleteAssetGroupSelector(ctx context.Context, selector model.AssetGroupSelector) error
 	UpdateAssetGroupSelectors(ctx context.Context, assetGroup model.AssetGroup, selectorSpecs []model.AssetGroupSelectorSpec, systemSelector bool) (model.UpdatedAssetGroupSelectors, error)
 	DeleteAssetGroupSelectorsForAssetGroups(ctx context.Context, assetGroupIds []int) error
+	UpdateAssetGroupSelectorMemberCounts(ctx context.Context, memberCounts map[int32]int) error
 
 	Wipe(ctx context.Context) error
 	Migrate(ctx context.Context) error
@@ -162,6 +165,15 @@ type Database interface {
 
 	// Datapipe Status
 	DatapipeStatusData
+
+	// Backup
+	BackupData
+
+	// Workspaces
+	WorkspaceData
+
+	// Collection Coverage
+	CollectionData
 }
 
 type BloodhoundDB struct {
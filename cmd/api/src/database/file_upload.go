@@ -21,6 +21,7 @@ import (
 
 	"github.com/specterops/bloodhound/src/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (s *BloodhoundDB) UpdateFileUploadJob(ctx context.Context, job model.FileUploadJob) error {
@@ -42,6 +43,68 @@ func (s *BloodhoundDB) GetFileUploadJob(ctx context.Context, id int64) (model.Fi
 	}
 }
 
+func (s *BloodhoundDB) CreateFileUploadJobResults(ctx context.Context, results model.FileUploadJobResults) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	return CheckError(s.db.WithContext(ctx).Create(&results))
+}
+
+func (s *BloodhoundDB) GetFileUploadJobResults(ctx context.Context, jobID int64) (model.FileUploadJobResults, error) {
+	var results model.FileUploadJobResults
+	result := s.db.WithContext(ctx).Where("file_upload_job_id = ?", jobID).Order("id").Find(&results)
+
+	return results, CheckError(result)
+}
+
+func (s *BloodhoundDB) CreateResumableUpload(ctx context.Context, upload model.ResumableUpload) (model.ResumableUpload, error) {
+	result := s.db.WithContext(ctx).Create(&upload)
+	return upload, CheckError(result)
+}
+
+// LockResumableUpload reads the resumable upload under a row lock that is held until the given delegate returns so that
+// concurrent writes to the same upload are serialized. The upload is saved with the changes of the delegate unless the
+// delegate returns an error.
+func (s *BloodhoundDB) LockResumableUpload(ctx context.Context, jobID int64, id int64, delegate func(upload *model.ResumableUpload) error) (model.ResumableUpload, error) {
+	var upload model.ResumableUpload
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("file_upload_job_id = ?", jobID).First(&upload, id); result.Error != nil {
+			return CheckError(result)
+		} else if err := delegate(&upload); err != nil {
+			return err
+		}
+
+		return CheckError(tx.Save(&upload))
+	})
+
+	return upload, err
+}
+
+func (s *BloodhoundDB) GetResumableUpload(ctx context.Context, jobID int64, id int64) (model.ResumableUpload, error) {
+	var upload model.ResumableUpload
+	result := s.db.WithContext(ctx).Where("file_upload_job_id = ?", jobID).First(&upload, id)
+
+	return upload, CheckError(result)
+}
+
+func (s *BloodhoundDB) DeleteResumableUpload(ctx context.Context, upload model.ResumableUpload) error {
+	result := s.db.WithContext(ctx).Delete(&upload)
+	return CheckError(result)
+}
+
+// GetActiveResumableUploads returns the resumable uploads that belong to file upload jobs that are still running
+func (s *BloodhoundDB) GetActiveResumableUploads(ctx context.Context) (model.ResumableUploads, error) {
+	var uploads model.ResumableUploads
+	result := s.db.WithContext(ctx).
+		Joins("JOIN file_upload_jobs ON file_upload_jobs.id = resumable_uploads.file_upload_job_id").
+		Where("file_upload_jobs.status = ?", model.JobStatusRunning).
+		Find(&uploads)
+
+	return uploads, CheckError(result)
+}
+
 func (s *BloodhoundDB) GetFileUploadJobsWithStatus(ctx context.Context, status model.JobStatus) ([]model.FileUploadJob, error) {
 	var jobs model.FileUploadJobs
 	result := s.db.WithContext(ctx).Where("status = ?", status).Find(&jobs)
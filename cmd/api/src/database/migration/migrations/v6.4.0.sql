@@ -0,0 +1,146 @@
+-- Copyright 2024 Specter Ops, Inc.
+--
+-- Licensed under the Apache License, Version 2.0
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+--
+-- SPDX-License-Identifier: Apache-2.0
+
+-- Add named, typed parameter declarations to saved queries
+ALTER TABLE IF EXISTS saved_queries
+  ADD COLUMN IF NOT EXISTS parameters JSONB NOT NULL DEFAULT '[]'::JSONB;
+
+-- Track per-file ingest results for file upload jobs
+CREATE TABLE IF NOT EXISTS file_upload_job_results
+(
+  id                 BIGSERIAL PRIMARY KEY,
+  file_upload_job_id BIGINT REFERENCES file_upload_jobs (id) ON DELETE CASCADE NOT NULL,
+  file_name          TEXT    NOT NULL DEFAULT '',
+  data_type          TEXT    NOT NULL DEFAULT '',
+  objects_read       INTEGER NOT NULL DEFAULT 0,
+  objects_written    INTEGER NOT NULL DEFAULT 0,
+  objects_skipped    INTEGER NOT NULL DEFAULT 0,
+  error_count        INTEGER NOT NULL DEFAULT 0,
+  errors             JSONB   NOT NULL DEFAULT '[]'::JSONB,
+
+  created_at         TIMESTAMP WITH TIME ZONE DEFAULT now(),
+  updated_at         TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_file_upload_job_results_file_upload_job_id ON file_upload_job_results USING btree (file_upload_job_id);
+
+-- Add deleted object handling for ingest
+INSERT INTO parameters (key, name, description, value, created_at, updated_at)
+VALUES ('ingest.deleted_objects', 'Deleted Object Handling',
+        'This configuration parameter controls how objects reported as deleted by collectors are ingested. When enabled, deleted objects are removed from the graph. When disabled, deleted objects are kept and flagged with the isdeleted property. In both cases the collected relationships of deleted objects are removed.',
+        '{
+          "remove": true
+        }', current_timestamp, current_timestamp)
+ON CONFLICT DO NOTHING;
+
+-- Track objects removed or flagged by deleted object ingest
+ALTER TABLE IF EXISTS file_upload_job_results
+  ADD COLUMN IF NOT EXISTS objects_deleted INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS objects_flagged INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS relationships_deleted INTEGER NOT NULL DEFAULT 0;
+
+-- Track resumable uploads for file upload jobs
+CREATE TABLE IF NOT EXISTS resumable_uploads
+(
+  id                 BIGSERIAL PRIMARY KEY,
+  file_upload_job_id BIGINT REFERENCES file_upload_jobs (id) ON DELETE CASCADE NOT NULL,
+  file_name          TEXT    NOT NULL,
+  file_type          INTEGER NOT NULL DEFAULT 0,
+  length             BIGINT  NOT NULL,
+  "offset"           BIGINT  NOT NULL DEFAULT 0,
+  sha256             TEXT    NOT NULL,
+  finished           BOOLEAN NOT NULL DEFAULT false,
+
+  created_at         TIMESTAMP WITH TIME ZONE DEFAULT now(),
+  updated_at         TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_resumable_uploads_file_upload_job_id ON resumable_uploads USING btree (file_upload_job_id);
+
+-- Add dry run file upload jobs that report on ingest without writing to the graph
+ALTER TABLE IF EXISTS file_upload_jobs
+  ADD COLUMN IF NOT EXISTS dry_run BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE IF EXISTS file_upload_job_results
+  ADD COLUMN IF NOT EXISTS report JSONB;
+
+-- Add workspaces that each keep their graph data in a separate graph
+CREATE TABLE IF NOT EXISTS workspaces
+(
+  id          BIGSERIAL PRIMARY KEY,
+  name        TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+
+  created_at  TIMESTAMP WITH TIME ZONE DEFAULT now(),
+  updated_at  TIMESTAMP WITH TIME ZONE DEFAULT now(),
+  deleted_at  TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS workspaces_users
+(
+  workspace_id BIGINT REFERENCES workspaces (id) ON DELETE CASCADE NOT NULL,
+  user_id      TEXT REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+
+  PRIMARY KEY (workspace_id, user_id)
+);
+
+ALTER TABLE IF EXISTS file_upload_jobs
+  ADD COLUMN IF NOT EXISTS workspace_id BIGINT REFERENCES workspaces (id) ON DELETE CASCADE;
+
+-- Add collection coverage records that retain how and when each domain was last collected
+CREATE TABLE IF NOT EXISTS domain_collections
+(
+  id           BIGSERIAL PRIMARY KEY,
+  domain_sid   TEXT    NOT NULL,
+  data_type    TEXT    NOT NULL,
+  methods      BIGINT  NOT NULL DEFAULT 0,
+  object_count INTEGER NOT NULL DEFAULT 0,
+  collected_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  created_at   TIMESTAMP WITH TIME ZONE DEFAULT now(),
+  updated_at   TIMESTAMP WITH TIME ZONE DEFAULT now(),
+
+  UNIQUE (domain_sid, data_type)
+);
+
+CREATE TABLE IF NOT EXISTS computer_collection_failures
+(
+  id                 BIGSERIAL PRIMARY KEY,
+  computer_object_id TEXT NOT NULL,
+  computer_name      TEXT NOT NULL DEFAULT '',
+  domain_sid         TEXT NOT NULL,
+  collection         TEXT NOT NULL,
+  failure_reason     TEXT NOT NULL DEFAULT '',
+  collected_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+
+  created_at         TIMESTAMP WITH TIME ZONE DEFAULT now(),
+  updated_at         TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_computer_collection_failures_computer_object_id ON computer_collection_failures USING btree (computer_object_id);
+CREATE INDEX IF NOT EXISTS idx_computer_collection_failures_domain_sid ON computer_collection_failures USING btree (domain_sid);
+
+-- Add selector types so that asset group selectors may select nodes dynamically and record the number of members
+-- each selector matched during the last analysis run
+ALTER TABLE IF EXISTS asset_group_selectors
+  ADD COLUMN IF NOT EXISTS type         TEXT    NOT NULL DEFAULT 'object_id',
+  ADD COLUMN IF NOT EXISTS parameters   JSONB   NOT NULL DEFAULT '{}',
+  ADD COLUMN IF NOT EXISTS member_count INTEGER NOT NULL DEFAULT 0;
+
+-- Record the selectors that selected each asset group collection entry so that membership changes between
+-- collections can be attributed to selectors
+ALTER TABLE IF EXISTS asset_group_collection_entries
+  ADD COLUMN IF NOT EXISTS selectors JSONB NOT NULL DEFAULT '[]';
@@ -29,6 +29,7 @@ import (
 	database "github.com/specterops/bloodhound/src/database"
 	model "github.com/specterops/bloodhound/src/model"
 	appcfg "github.com/specterops/bloodhound/src/model/appcfg"
+	version "github.com/specterops/bloodhound/src/version"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -258,6 +259,20 @@ func (mr *MockDatabaseMockRecorder) CreateFileUploadJob(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFileUploadJob", reflect.TypeOf((*MockDatabase)(nil).CreateFileUploadJob), arg0, arg1)
 }
 
+// CreateFileUploadJobResults mocks base method.
+func (m *MockDatabase) CreateFileUploadJobResults(arg0 context.Context, arg1 model.FileUploadJobResults) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFileUploadJobResults", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFileUploadJobResults indicates an expected call of CreateFileUploadJobResults.
+func (mr *MockDatabaseMockRecorder) CreateFileUploadJobResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFileUploadJobResults", reflect.TypeOf((*MockDatabase)(nil).CreateFileUploadJobResults), arg0, arg1)
+}
+
 // CreateIngestTask mocks base method.
 func (m *MockDatabase) CreateIngestTask(arg0 context.Context, arg1 model.IngestTask) (model.IngestTask, error) {
 	m.ctrl.T.Helper()
@@ -303,6 +318,21 @@ func (mr *MockDatabaseMockRecorder) CreateOIDCProvider(arg0, arg1, arg2, arg3 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOIDCProvider", reflect.TypeOf((*MockDatabase)(nil).CreateOIDCProvider), arg0, arg1, arg2, arg3)
 }
 
+// CreateResumableUpload mocks base method.
+func (m *MockDatabase) CreateResumableUpload(arg0 context.Context, arg1 model.ResumableUpload) (model.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResumableUpload", arg0, arg1)
+	ret0, _ := ret[0].(model.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResumableUpload indicates an expected call of CreateResumableUpload.
+func (mr *MockDatabaseMockRecorder) CreateResumableUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResumableUpload", reflect.TypeOf((*MockDatabase)(nil).CreateResumableUpload), arg0, arg1)
+}
+
 // CreateSAMLIdentityProvider mocks base method.
 func (m *MockDatabase) CreateSAMLIdentityProvider(arg0 context.Context, arg1 model.SAMLProvider) (model.SAMLProvider, error) {
 	m.ctrl.T.Helper()
@@ -334,18 +364,18 @@ func (mr *MockDatabaseMockRecorder) CreateSSOProvider(arg0, arg1, arg2 interface
 }
 
 // CreateSavedQuery mocks base method.
-func (m *MockDatabase) CreateSavedQuery(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4 string) (model.SavedQuery, error) {
+func (m *MockDatabase) CreateSavedQuery(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4 string, arg5 model.SavedQueryParameters) (model.SavedQuery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSavedQuery", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "CreateSavedQuery", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(model.SavedQuery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSavedQuery indicates an expected call of CreateSavedQuery.
-func (mr *MockDatabaseMockRecorder) CreateSavedQuery(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) CreateSavedQuery(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedQuery", reflect.TypeOf((*MockDatabase)(nil).CreateSavedQuery), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedQuery", reflect.TypeOf((*MockDatabase)(nil).CreateSavedQuery), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // CreateSavedQueryPermissionToPublic mocks base method.
@@ -413,6 +443,21 @@ func (mr *MockDatabaseMockRecorder) CreateUserSession(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserSession", reflect.TypeOf((*MockDatabase)(nil).CreateUserSession), arg0, arg1)
 }
 
+// CreateWorkspace mocks base method.
+func (m *MockDatabase) CreateWorkspace(arg0 context.Context, arg1 model.Workspace) (model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockDatabaseMockRecorder) CreateWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockDatabase)(nil).CreateWorkspace), arg0, arg1)
+}
+
 // DeleteAllDataQuality mocks base method.
 func (m *MockDatabase) DeleteAllDataQuality(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -553,6 +598,20 @@ func (mr *MockDatabaseMockRecorder) DeleteIngestTask(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngestTask", reflect.TypeOf((*MockDatabase)(nil).DeleteIngestTask), arg0, arg1)
 }
 
+// DeleteResumableUpload mocks base method.
+func (m *MockDatabase) DeleteResumableUpload(arg0 context.Context, arg1 model.ResumableUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResumableUpload", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResumableUpload indicates an expected call of DeleteResumableUpload.
+func (mr *MockDatabaseMockRecorder) DeleteResumableUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResumableUpload", reflect.TypeOf((*MockDatabase)(nil).DeleteResumableUpload), arg0, arg1)
+}
+
 // DeleteSSOProvider mocks base method.
 func (m *MockDatabase) DeleteSSOProvider(arg0 context.Context, arg1 int) error {
 	m.ctrl.T.Helper()
@@ -614,6 +673,20 @@ func (mr *MockDatabaseMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockDatabase)(nil).DeleteUser), arg0, arg1)
 }
 
+// DeleteWorkspace mocks base method.
+func (m *MockDatabase) DeleteWorkspace(arg0 context.Context, arg1 model.Workspace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspace indicates an expected call of DeleteWorkspace.
+func (mr *MockDatabaseMockRecorder) DeleteWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockDatabase)(nil).DeleteWorkspace), arg0, arg1)
+}
+
 // EndUserSession mocks base method.
 func (m *MockDatabase) EndUserSession(arg0 context.Context, arg1 model.UserSession) {
 	m.ctrl.T.Helper()
@@ -626,6 +699,21 @@ func (mr *MockDatabaseMockRecorder) EndUserSession(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndUserSession", reflect.TypeOf((*MockDatabase)(nil).EndUserSession), arg0, arg1)
 }
 
+// ExportTable mocks base method.
+func (m *MockDatabase) ExportTable(arg0 context.Context, arg1 string) (database.BackupTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTable", arg0, arg1)
+	ret0, _ := ret[0].(database.BackupTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTable indicates an expected call of ExportTable.
+func (mr *MockDatabaseMockRecorder) ExportTable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTable", reflect.TypeOf((*MockDatabase)(nil).ExportTable), arg0, arg1)
+}
+
 // GetADDataQualityAggregations mocks base method.
 func (m *MockDatabase) GetADDataQualityAggregations(arg0 context.Context, arg1, arg2 time.Time, arg3 string, arg4, arg5 int) (model.ADDataQualityAggregations, int, error) {
 	m.ctrl.T.Helper()
@@ -658,6 +746,21 @@ func (mr *MockDatabaseMockRecorder) GetADDataQualityStats(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetADDataQualityStats", reflect.TypeOf((*MockDatabase)(nil).GetADDataQualityStats), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// GetActiveResumableUploads mocks base method.
+func (m *MockDatabase) GetActiveResumableUploads(arg0 context.Context) (model.ResumableUploads, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveResumableUploads", arg0)
+	ret0, _ := ret[0].(model.ResumableUploads)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveResumableUploads indicates an expected call of GetActiveResumableUploads.
+func (mr *MockDatabaseMockRecorder) GetActiveResumableUploads(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveResumableUploads", reflect.TypeOf((*MockDatabase)(nil).GetActiveResumableUploads), arg0)
+}
+
 // GetAllAssetGroups mocks base method.
 func (m *MockDatabase) GetAllAssetGroups(arg0 context.Context, arg1 string, arg2 model.SQLFilter) (model.AssetGroups, error) {
 	m.ctrl.T.Helper()
@@ -824,6 +927,21 @@ func (mr *MockDatabaseMockRecorder) GetAllUsers(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockDatabase)(nil).GetAllUsers), arg0, arg1, arg2)
 }
 
+// GetAllWorkspaces mocks base method.
+func (m *MockDatabase) GetAllWorkspaces(arg0 context.Context, arg1 string) (model.Workspaces, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllWorkspaces", arg0, arg1)
+	ret0, _ := ret[0].(model.Workspaces)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllWorkspaces indicates an expected call of GetAllWorkspaces.
+func (mr *MockDatabaseMockRecorder) GetAllWorkspaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWorkspaces", reflect.TypeOf((*MockDatabase)(nil).GetAllWorkspaces), arg0, arg1)
+}
+
 // GetAnalysisRequest mocks base method.
 func (m *MockDatabase) GetAnalysisRequest(arg0 context.Context) (model.AnalysisRequest, error) {
 	m.ctrl.T.Helper()
@@ -854,6 +972,36 @@ func (mr *MockDatabaseMockRecorder) GetAssetGroup(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroup", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroup), arg0, arg1)
 }
 
+// GetAssetGroupCollection mocks base method.
+func (m *MockDatabase) GetAssetGroupCollection(arg0 context.Context, arg1 int32, arg2 int64) (model.AssetGroupCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.AssetGroupCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetGroupCollection indicates an expected call of GetAssetGroupCollection.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupCollection", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupCollection), arg0, arg1, arg2)
+}
+
+// GetAssetGroupCollectionBefore mocks base method.
+func (m *MockDatabase) GetAssetGroupCollectionBefore(arg0 context.Context, arg1 int32, arg2 time.Time) (model.AssetGroupCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupCollectionBefore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.AssetGroupCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetGroupCollectionBefore indicates an expected call of GetAssetGroupCollectionBefore.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupCollectionBefore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupCollectionBefore", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupCollectionBefore), arg0, arg1, arg2)
+}
+
 // GetAssetGroupCollections mocks base method.
 func (m *MockDatabase) GetAssetGroupCollections(arg0 context.Context, arg1 int32, arg2 string, arg3 model.SQLFilter) (model.AssetGroupCollections, error) {
 	m.ctrl.T.Helper()
@@ -946,6 +1094,22 @@ func (mr *MockDatabaseMockRecorder) GetAzureDataQualityStats(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAzureDataQualityStats", reflect.TypeOf((*MockDatabase)(nil).GetAzureDataQualityStats), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// GetComputerCollectionFailures mocks base method.
+func (m *MockDatabase) GetComputerCollectionFailures(arg0 context.Context, arg1 string, arg2 int) (model.ComputerCollectionFailures, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputerCollectionFailures", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.ComputerCollectionFailures)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetComputerCollectionFailures indicates an expected call of GetComputerCollectionFailures.
+func (mr *MockDatabaseMockRecorder) GetComputerCollectionFailures(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputerCollectionFailures", reflect.TypeOf((*MockDatabase)(nil).GetComputerCollectionFailures), arg0, arg1, arg2)
+}
+
 // GetConfigurationParameter mocks base method.
 func (m *MockDatabase) GetConfigurationParameter(arg0 context.Context, arg1 string) (appcfg.Parameter, error) {
 	m.ctrl.T.Helper()
@@ -976,6 +1140,21 @@ func (mr *MockDatabaseMockRecorder) GetDatapipeStatus(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatapipeStatus", reflect.TypeOf((*MockDatabase)(nil).GetDatapipeStatus), arg0)
 }
 
+// GetDomainCollections mocks base method.
+func (m *MockDatabase) GetDomainCollections(arg0 context.Context, arg1 string) (model.DomainCollections, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomainCollections", arg0, arg1)
+	ret0, _ := ret[0].(model.DomainCollections)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomainCollections indicates an expected call of GetDomainCollections.
+func (mr *MockDatabaseMockRecorder) GetDomainCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomainCollections", reflect.TypeOf((*MockDatabase)(nil).GetDomainCollections), arg0, arg1)
+}
+
 // GetFileUploadJob mocks base method.
 func (m *MockDatabase) GetFileUploadJob(arg0 context.Context, arg1 int64) (model.FileUploadJob, error) {
 	m.ctrl.T.Helper()
@@ -991,6 +1170,21 @@ func (mr *MockDatabaseMockRecorder) GetFileUploadJob(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileUploadJob", reflect.TypeOf((*MockDatabase)(nil).GetFileUploadJob), arg0, arg1)
 }
 
+// GetFileUploadJobResults mocks base method.
+func (m *MockDatabase) GetFileUploadJobResults(arg0 context.Context, arg1 int64) (model.FileUploadJobResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileUploadJobResults", arg0, arg1)
+	ret0, _ := ret[0].(model.FileUploadJobResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileUploadJobResults indicates an expected call of GetFileUploadJobResults.
+func (mr *MockDatabaseMockRecorder) GetFileUploadJobResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileUploadJobResults", reflect.TypeOf((*MockDatabase)(nil).GetFileUploadJobResults), arg0, arg1)
+}
+
 // GetFileUploadJobsWithStatus mocks base method.
 func (m *MockDatabase) GetFileUploadJobsWithStatus(arg0 context.Context, arg1 model.JobStatus) ([]model.FileUploadJob, error) {
 	m.ctrl.T.Helper()
@@ -1111,6 +1305,21 @@ func (mr *MockDatabaseMockRecorder) GetPublicSavedQueries(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicSavedQueries", reflect.TypeOf((*MockDatabase)(nil).GetPublicSavedQueries), arg0)
 }
 
+// GetResumableUpload mocks base method.
+func (m *MockDatabase) GetResumableUpload(arg0 context.Context, arg1, arg2 int64) (model.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResumableUpload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResumableUpload indicates an expected call of GetResumableUpload.
+func (mr *MockDatabaseMockRecorder) GetResumableUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResumableUpload", reflect.TypeOf((*MockDatabase)(nil).GetResumableUpload), arg0, arg1, arg2)
+}
+
 // GetRole mocks base method.
 func (m *MockDatabase) GetRole(arg0 context.Context, arg1 int32) (model.Role, error) {
 	m.ctrl.T.Helper()
@@ -1231,6 +1440,21 @@ func (mr *MockDatabaseMockRecorder) GetSavedQuery(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedQuery", reflect.TypeOf((*MockDatabase)(nil).GetSavedQuery), arg0, arg1)
 }
 
+// GetSchemaVersion mocks base method.
+func (m *MockDatabase) GetSchemaVersion(arg0 context.Context) (version.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", arg0)
+	ret0, _ := ret[0].(version.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockDatabaseMockRecorder) GetSchemaVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockDatabase)(nil).GetSchemaVersion), arg0)
+}
+
 // GetScopeForSavedQuery mocks base method.
 func (m *MockDatabase) GetScopeForSavedQuery(arg0 context.Context, arg1 int64, arg2 uuid.UUID) (database.SavedQueryScopeMap, error) {
 	m.ctrl.T.Helper()
@@ -1321,6 +1545,21 @@ func (mr *MockDatabaseMockRecorder) GetUserToken(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserToken", reflect.TypeOf((*MockDatabase)(nil).GetUserToken), arg0, arg1, arg2)
 }
 
+// GetWorkspace mocks base method.
+func (m *MockDatabase) GetWorkspace(arg0 context.Context, arg1 int64) (model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspace indicates an expected call of GetWorkspace.
+func (mr *MockDatabaseMockRecorder) GetWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspace", reflect.TypeOf((*MockDatabase)(nil).GetWorkspace), arg0, arg1)
+}
+
 // HasAnalysisRequest mocks base method.
 func (m *MockDatabase) HasAnalysisRequest(arg0 context.Context) bool {
 	m.ctrl.T.Helper()
@@ -1441,6 +1680,21 @@ func (mr *MockDatabaseMockRecorder) ListSavedQueries(arg0, arg1, arg2, arg3, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedQueries", reflect.TypeOf((*MockDatabase)(nil).ListSavedQueries), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// LockResumableUpload mocks base method.
+func (m *MockDatabase) LockResumableUpload(arg0 context.Context, arg1, arg2 int64, arg3 func(*model.ResumableUpload) error) (model.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockResumableUpload", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockResumableUpload indicates an expected call of LockResumableUpload.
+func (mr *MockDatabaseMockRecorder) LockResumableUpload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockResumableUpload", reflect.TypeOf((*MockDatabase)(nil).LockResumableUpload), arg0, arg1, arg2, arg3)
+}
+
 // LookupActiveSessionsByUser mocks base method.
 func (m *MockDatabase) LookupActiveSessionsByUser(arg0 context.Context, arg1 model.User) ([]model.UserSession, error) {
 	m.ctrl.T.Helper()
@@ -1485,6 +1739,20 @@ func (mr *MockDatabaseMockRecorder) Migrate(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockDatabase)(nil).Migrate), arg0)
 }
 
+// ReplaceComputerCollectionFailures mocks base method.
+func (m *MockDatabase) ReplaceComputerCollectionFailures(arg0 context.Context, arg1 []string, arg2 model.ComputerCollectionFailures) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceComputerCollectionFailures", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceComputerCollectionFailures indicates an expected call of ReplaceComputerCollectionFailures.
+func (mr *MockDatabaseMockRecorder) ReplaceComputerCollectionFailures(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceComputerCollectionFailures", reflect.TypeOf((*MockDatabase)(nil).ReplaceComputerCollectionFailures), arg0, arg1, arg2)
+}
+
 // RequestAnalysis mocks base method.
 func (m *MockDatabase) RequestAnalysis(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1513,6 +1781,34 @@ func (mr *MockDatabaseMockRecorder) RequestCollectedGraphDataDeletion(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCollectedGraphDataDeletion", reflect.TypeOf((*MockDatabase)(nil).RequestCollectedGraphDataDeletion), arg0, arg1)
 }
 
+// RestoreTables mocks base method.
+func (m *MockDatabase) RestoreTables(arg0 context.Context, arg1 []database.BackupTable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTables", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTables indicates an expected call of RestoreTables.
+func (mr *MockDatabaseMockRecorder) RestoreTables(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTables", reflect.TypeOf((*MockDatabase)(nil).RestoreTables), arg0, arg1)
+}
+
+// SaveDomainCollections mocks base method.
+func (m *MockDatabase) SaveDomainCollections(arg0 context.Context, arg1 model.DomainCollections) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDomainCollections", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDomainCollections indicates an expected call of SaveDomainCollections.
+func (mr *MockDatabaseMockRecorder) SaveDomainCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDomainCollections", reflect.TypeOf((*MockDatabase)(nil).SaveDomainCollections), arg0, arg1)
+}
+
 // SavedQueryBelongsToUser mocks base method.
 func (m *MockDatabase) SavedQueryBelongsToUser(arg0 context.Context, arg1 uuid.UUID, arg2 int64) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1636,6 +1932,20 @@ func (mr *MockDatabaseMockRecorder) UpdateAssetGroup(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssetGroup", reflect.TypeOf((*MockDatabase)(nil).UpdateAssetGroup), arg0, arg1)
 }
 
+// UpdateAssetGroupSelectorMemberCounts mocks base method.
+func (m *MockDatabase) UpdateAssetGroupSelectorMemberCounts(arg0 context.Context, arg1 map[int32]int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssetGroupSelectorMemberCounts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAssetGroupSelectorMemberCounts indicates an expected call of UpdateAssetGroupSelectorMemberCounts.
+func (mr *MockDatabaseMockRecorder) UpdateAssetGroupSelectorMemberCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssetGroupSelectorMemberCounts", reflect.TypeOf((*MockDatabase)(nil).UpdateAssetGroupSelectorMemberCounts), arg0, arg1)
+}
+
 // UpdateAssetGroupSelectors mocks base method.
 func (m *MockDatabase) UpdateAssetGroupSelectors(arg0 context.Context, arg1 model.AssetGroup, arg2 []model.AssetGroupSelectorSpec, arg3 bool) (model.UpdatedAssetGroupSelectors, error) {
 	m.ctrl.T.Helper()
@@ -1767,6 +2077,21 @@ func (mr *MockDatabaseMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockDatabase)(nil).UpdateUser), arg0, arg1)
 }
 
+// UpdateWorkspace mocks base method.
+func (m *MockDatabase) UpdateWorkspace(arg0 context.Context, arg1 model.Workspace) (model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockDatabaseMockRecorder) UpdateWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockDatabase)(nil).UpdateWorkspace), arg0, arg1)
+}
+
 // Wipe mocks base method.
 func (m *MockDatabase) Wipe(arg0 context.Context) error {
 	m.ctrl.T.Helper()
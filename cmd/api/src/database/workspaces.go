@@ -0,0 +1,87 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+)
+
+var ErrDuplicateWorkspaceName = errors.New("duplicate workspace name")
+
+type WorkspaceData interface {
+	CreateWorkspace(ctx context.Context, workspace model.Workspace) (model.Workspace, error)
+	GetWorkspace(ctx context.Context, id int64) (model.Workspace, error)
+	GetAllWorkspaces(ctx context.Context, order string) (model.Workspaces, error)
+	UpdateWorkspace(ctx context.Context, workspace model.Workspace) (model.Workspace, error)
+	DeleteWorkspace(ctx context.Context, workspace model.Workspace) error
+}
+
+func checkWorkspaceError(result *gorm.DB) error {
+	if result.Error != nil && strings.Contains(result.Error.Error(), "duplicate key value violates unique constraint \"workspaces_name_key\"") {
+		return fmt.Errorf("%w: %v", ErrDuplicateWorkspaceName, result.Error)
+	}
+
+	return CheckError(result)
+}
+
+// CreateWorkspace creates the given workspace along with its memberships. Members must refer to existing users.
+func (s *BloodhoundDB) CreateWorkspace(ctx context.Context, workspace model.Workspace) (model.Workspace, error) {
+	return workspace, checkWorkspaceError(s.db.WithContext(ctx).Omit("Members.*").Create(&workspace))
+}
+
+func (s *BloodhoundDB) GetWorkspace(ctx context.Context, id int64) (model.Workspace, error) {
+	var workspace model.Workspace
+	return workspace, CheckError(s.db.WithContext(ctx).Preload("Members").First(&workspace, id))
+}
+
+func (s *BloodhoundDB) GetAllWorkspaces(ctx context.Context, order string) (model.Workspaces, error) {
+	var (
+		workspaces model.Workspaces
+		cursor     = s.db.WithContext(ctx).Preload("Members")
+	)
+
+	if order != "" {
+		cursor = cursor.Order(order)
+	} else {
+		cursor = cursor.Order("id")
+	}
+
+	return workspaces, CheckError(cursor.Find(&workspaces))
+}
+
+// UpdateWorkspace saves the given workspace and replaces its memberships with the members of the given workspace
+func (s *BloodhoundDB) UpdateWorkspace(ctx context.Context, workspace model.Workspace) (model.Workspace, error) {
+	return workspace, s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&workspace).Omit("Members.*").Association("Members").Replace(&workspace.Members); err != nil {
+			return err
+		}
+
+		return checkWorkspaceError(tx.Omit("Members").Save(&workspace))
+	})
+}
+
+// DeleteWorkspace removes the given workspace along with its memberships and file upload jobs. The graph of the
+// workspace is left for the caller to remove.
+func (s *BloodhoundDB) DeleteWorkspace(ctx context.Context, workspace model.Workspace) error {
+	return CheckError(s.db.WithContext(ctx).Delete(&workspace))
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaces(t *testing.T) {
+	var (
+		testCtx      = context.Background()
+		dbInst, user = initAndCreateUser(t)
+	)
+
+	workspace, err := dbInst.CreateWorkspace(testCtx, model.Workspace{
+		Name:        "client a",
+		Description: "first client",
+		Members:     model.Users{user},
+	})
+	require.Nil(t, err)
+	assert.NotZero(t, workspace.ID)
+
+	_, err = dbInst.CreateWorkspace(testCtx, model.Workspace{Name: "client a"})
+	assert.ErrorIs(t, err, database.ErrDuplicateWorkspaceName)
+
+	fetched, err := dbInst.GetWorkspace(testCtx, workspace.ID)
+	require.Nil(t, err)
+	assert.Equal(t, "client a", fetched.Name)
+	assert.True(t, fetched.HasMember(user))
+
+	fetched.Name = "client b"
+	fetched.Members = nil
+
+	_, err = dbInst.UpdateWorkspace(testCtx, fetched)
+	require.Nil(t, err)
+
+	workspaces, err := dbInst.GetAllWorkspaces(testCtx, "")
+	require.Nil(t, err)
+	require.Len(t, workspaces, 1)
+	assert.Equal(t, "client b", workspaces[0].Name)
+	assert.False(t, workspaces[0].HasMember(user))
+
+	// Users remain after their memberships are removed
+	_, err = dbInst.GetUser(testCtx, user.ID)
+	require.Nil(t, err)
+
+	require.Nil(t, dbInst.DeleteWorkspace(testCtx, workspaces[0]))
+
+	_, err = dbInst.GetWorkspace(testCtx, workspace.ID)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
@@ -17,16 +17,112 @@
 package model
 
 import (
+	"errors"
 	"fmt"
-
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
 	"github.com/specterops/bloodhound/src/database/types"
 )
 
+// AssetGroupSelectorType determines how the Selector of an AssetGroupSelector is interpreted
+type AssetGroupSelectorType string
+
+const (
+	// AssetGroupSelectorTypeObjectID selects the single node with the object ID given by the selector
+	AssetGroupSelectorTypeObjectID AssetGroupSelectorType = "object_id"
+
+	// AssetGroupSelectorTypeCypher selects every node returned by the read-only cypher query given by the selector. The
+	// selector parameters are bound to the parameters referenced by the query.
+	AssetGroupSelectorTypeCypher AssetGroupSelectorType = "cypher"
+
+	// AssetGroupSelectorTypeContainedBy selects every node contained, recursively, by the domain or OU with the object
+	// ID given by the selector
+	AssetGroupSelectorTypeContainedBy AssetGroupSelectorType = "contained_by"
+
+	// AssetGroupSelectorTypeProperty selects every node with the property named by the selector. Nodes may be
+	// narrowed to a kind and to a property value with the "kind" and "value" selector parameters.
+	AssetGroupSelectorTypeProperty AssetGroupSelectorType = "property"
+)
+
+const (
+	AssetGroupSelectorParameterKind  = "kind"
+	AssetGroupSelectorParameterValue = "value"
+)
+
+var (
+	ErrAssetGroupSelectorTypeInvalid       = errors.New("invalid asset group selector type")
+	ErrAssetGroupSelectorEmpty             = errors.New("asset group selector must not be empty")
+	ErrAssetGroupSelectorParametersInvalid = errors.New("asset group selector parameters are not supported by the selector type")
+	ErrAssetGroupSelectorPropertyInvalid   = errors.New("asset group selector property name is invalid")
+	ErrAssetGroupSelectorKindInvalid       = errors.New("asset group selector kind is not a known node kind")
+
+	// Property names and kinds of property selectors are written into the translated graph query so property names
+	// are restricted to plain identifiers and kinds to the known node kinds
+	assetGroupSelectorPropertyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// validatePropertySelector checks the property name and parameters of a property selector
+func validatePropertySelector(property string, parameters map[string]any) error {
+	if !assetGroupSelectorPropertyPattern.MatchString(property) {
+		return fmt.Errorf("%w: %q", ErrAssetGroupSelectorPropertyInvalid, property)
+	}
+
+	for name, value := range parameters {
+		switch name {
+		case AssetGroupSelectorParameterValue:
+			continue
+
+		case AssetGroupSelectorParameterKind:
+			if kind, isString := value.(string); !isString {
+				return fmt.Errorf("%w: %s must be a string", ErrAssetGroupSelectorParametersInvalid, name)
+			} else if !slices.ContainsFunc(assetGroupSelectorKinds(), func(knownKind graph.Kind) bool { return knownKind.String() == kind }) {
+				return fmt.Errorf("%w: %q", ErrAssetGroupSelectorKindInvalid, kind)
+			}
+
+		default:
+			return fmt.Errorf("%w: %s", ErrAssetGroupSelectorParametersInvalid, name)
+		}
+	}
+
+	return nil
+}
+
+// assetGroupSelectorKinds returns the node kinds that property selectors may be narrowed to
+func assetGroupSelectorKinds() graph.Kinds {
+	return append(graph.Kinds(ad.NodeKinds()), azure.NodeKinds()...)
+}
+
+// IsValid returns true if the selector type is known
+func (s AssetGroupSelectorType) IsValid() bool {
+	switch s {
+	case AssetGroupSelectorTypeObjectID, AssetGroupSelectorTypeCypher, AssetGroupSelectorTypeContainedBy, AssetGroupSelectorTypeProperty:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDynamic returns true if the members selected by the selector type are re-evaluated against the graph on every
+// analysis run
+func (s AssetGroupSelectorType) IsDynamic() bool {
+	return s != AssetGroupSelectorTypeObjectID
+}
+
 type AssetGroupSelector struct {
-	AssetGroupID   int32  `json:"asset_group_id" gorm:"UNIQUE_INDEX:compositeindex"`
-	Name           string `json:"name" gorm:"UNIQUE_INDEX:compositeindex"`
-	Selector       string `json:"selector"`
-	SystemSelector bool   `json:"system_selector"`
+	AssetGroupID   int32                   `json:"asset_group_id" gorm:"UNIQUE_INDEX:compositeindex"`
+	Name           string                  `json:"name" gorm:"UNIQUE_INDEX:compositeindex"`
+	Selector       string                  `json:"selector"`
+	Type           AssetGroupSelectorType  `json:"type"`
+	Parameters     types.JSONUntypedObject `json:"parameters"`
+	SystemSelector bool                    `json:"system_selector"`
+	MemberCount    int                     `json:"member_count"`
 
 	Serial
 }
@@ -35,21 +131,54 @@ func (s AssetGroupSelector) AuditData() AuditData {
 	return AuditData{
 		"name":     s.Name,
 		"selector": s.Selector,
+		"type":     s.Type,
 	}
 }
 
+// SelectorType returns the type of the selector. Selectors stored before selector types were introduced are object ID
+// selectors.
+func (s AssetGroupSelector) SelectorType() AssetGroupSelectorType {
+	if s.Type == "" {
+		return AssetGroupSelectorTypeObjectID
+	}
+
+	return s.Type
+}
+
+// ValidatePropertySelector checks the property name and parameters of a property selector. Selectors are validated
+// when they are added but are checked again before evaluation as they are interpolated into the graph query.
+func (s AssetGroupSelector) ValidatePropertySelector() error {
+	return validatePropertySelector(s.Selector, s.Parameters)
+}
+
 type AssetGroupSelectors []AssetGroupSelector
 
+// Strings returns the object IDs of the object ID selectors
 func (s AssetGroupSelectors) Strings() []string {
-	selectorStrings := make([]string, len(s))
+	selectorStrings := make([]string, 0, len(s))
 
 	for idx := 0; idx < len(s); idx++ {
-		selectorStrings[idx] = s[idx].Selector
+		if s[idx].SelectorType() == AssetGroupSelectorTypeObjectID {
+			selectorStrings = append(selectorStrings, s[idx].Selector)
+		}
 	}
 
 	return selectorStrings
 }
 
+// Dynamic returns the selectors that are re-evaluated against the graph on every analysis run
+func (s AssetGroupSelectors) Dynamic() AssetGroupSelectors {
+	dynamicSelectors := AssetGroupSelectors{}
+
+	for _, selector := range s {
+		if selector.SelectorType().IsDynamic() {
+			dynamicSelectors = append(dynamicSelectors, selector)
+		}
+	}
+
+	return dynamicSelectors
+}
+
 // AssetGroupAssociations returns a list of AssetGroup model associations to load eagerly by default with GORM
 // Preload(...). Note: this does not include the "Collections" association on-purpose since this collection grows
 // over time and may require additional parameters for fetching.
@@ -206,16 +335,124 @@ type AssetGroupCollectionEntry struct {
 	ObjectID               string                  `json:"object_id"`
 	NodeLabel              string                  `json:"node_label"`
 	Properties             types.JSONUntypedObject `json:"properties"`
+	Selectors              []string                `json:"selectors" gorm:"serializer:json"`
 
 	BigSerial
 }
 
 type AssetGroupCollectionEntries []AssetGroupCollectionEntry
 
+// AssetGroupMembershipChange describes an object that entered or left an asset group between two collections
+type AssetGroupMembershipChange struct {
+	ObjectID  string   `json:"object_id"`
+	NodeLabel string   `json:"node_label"`
+	Name      string   `json:"name"`
+	Selectors []string `json:"selectors"`
+}
+
+func newAssetGroupMembershipChange(entry AssetGroupCollectionEntry) AssetGroupMembershipChange {
+	change := AssetGroupMembershipChange{
+		ObjectID:  entry.ObjectID,
+		NodeLabel: entry.NodeLabel,
+		Selectors: entry.Selectors,
+	}
+
+	if name, ok := entry.Properties[common.Name.String()].(string); ok {
+		change.Name = name
+	}
+
+	if change.Selectors == nil {
+		change.Selectors = []string{}
+	}
+
+	return change
+}
+
+// AssetGroupCollectionDiff lists the objects that entered or left an asset group between two of its collections
+type AssetGroupCollectionDiff struct {
+	AssetGroupID     int32                        `json:"asset_group_id"`
+	FromCollectionID int64                        `json:"from_collection_id"`
+	FromCreatedAt    time.Time                    `json:"from_created_at"`
+	ToCollectionID   int64                        `json:"to_collection_id"`
+	ToCreatedAt      time.Time                    `json:"to_created_at"`
+	Added            []AssetGroupMembershipChange `json:"added"`
+	Removed          []AssetGroupMembershipChange `json:"removed"`
+}
+
+// HasChanges returns true if any object entered or left the asset group
+func (s AssetGroupCollectionDiff) HasChanges() bool {
+	return len(s.Added) > 0 || len(s.Removed) > 0
+}
+
+func (s AssetGroupCollectionDiff) AuditData() AuditData {
+	var (
+		added   = make([]string, len(s.Added))
+		removed = make([]string, len(s.Removed))
+	)
+
+	for idx, change := range s.Added {
+		added[idx] = change.ObjectID
+	}
+
+	for idx, change := range s.Removed {
+		removed[idx] = change.ObjectID
+	}
+
+	return AuditData{
+		"asset_group_id":     s.AssetGroupID,
+		"from_collection_id": s.FromCollectionID,
+		"to_collection_id":   s.ToCollectionID,
+		"added_object_ids":   added,
+		"removed_object_ids": removed,
+	}
+}
+
+// DiffAssetGroupCollections returns the objects that entered or left an asset group between the from and to
+// collections. Added objects are reported with the selectors recorded in the to collection while removed objects are
+// reported with the selectors recorded in the from collection.
+func DiffAssetGroupCollections(from, to AssetGroupCollection) AssetGroupCollectionDiff {
+	var (
+		fromObjectIDs = make(map[string]struct{}, len(from.Entries))
+		toObjectIDs   = make(map[string]struct{}, len(to.Entries))
+		diff          = AssetGroupCollectionDiff{
+			AssetGroupID:     to.AssetGroupID,
+			FromCollectionID: from.ID,
+			FromCreatedAt:    from.CreatedAt,
+			ToCollectionID:   to.ID,
+			ToCreatedAt:      to.CreatedAt,
+			Added:            []AssetGroupMembershipChange{},
+			Removed:          []AssetGroupMembershipChange{},
+		}
+	)
+
+	for _, entry := range from.Entries {
+		fromObjectIDs[entry.ObjectID] = struct{}{}
+	}
+
+	for _, entry := range to.Entries {
+		toObjectIDs[entry.ObjectID] = struct{}{}
+
+		if _, found := fromObjectIDs[entry.ObjectID]; !found {
+			diff.Added = append(diff.Added, newAssetGroupMembershipChange(entry))
+		}
+	}
+
+	for _, entry := range from.Entries {
+		if _, found := toObjectIDs[entry.ObjectID]; !found {
+			diff.Removed = append(diff.Removed, newAssetGroupMembershipChange(entry))
+		}
+	}
+
+	return diff
+}
+
 type AssetGroupSelectorSpec struct {
-	SelectorName   string `json:"selector_name"`
-	EntityObjectID string `json:"sid"`
-	Action         string `json:"action"`
+	SelectorName   string                 `json:"selector_name"`
+	EntityObjectID string                 `json:"sid"`
+	Action         string                 `json:"action"`
+	Type           AssetGroupSelectorType `json:"type,omitempty"`
+	Selector       string                 `json:"selector,omitempty"`
+	Parameters     map[string]any         `json:"parameters,omitempty"`
 }
 
 type UpdatedAssetGroupSelectors struct {
@@ -232,13 +469,72 @@ const (
 	OwnedAssetGroupTag       = "owned"
 )
 
+// SelectorType returns the type of the selector to add. Specs that do not specify a type select an object ID.
+func (s AssetGroupSelectorSpec) SelectorType() AssetGroupSelectorType {
+	if s.Type == "" {
+		return AssetGroupSelectorTypeObjectID
+	}
+
+	return s.Type
+}
+
+// SelectorValue returns the selector to add. Object ID selectors may be given by either the sid or the selector field.
+func (s AssetGroupSelectorSpec) SelectorValue() string {
+	if s.Selector == "" && s.SelectorType() == AssetGroupSelectorTypeObjectID {
+		return s.EntityObjectID
+	}
+
+	return s.Selector
+}
+
+// AssetGroupSelector returns the selector described by the spec for the given asset group
+func (s AssetGroupSelectorSpec) AssetGroupSelector(assetGroupID int32, systemSelector bool) AssetGroupSelector {
+	parameters := types.JSONUntypedObject{}
+
+	for name, value := range s.Parameters {
+		parameters[name] = value
+	}
+
+	return AssetGroupSelector{
+		AssetGroupID:   assetGroupID,
+		Name:           s.SelectorName,
+		Selector:       s.SelectorValue(),
+		Type:           s.SelectorType(),
+		Parameters:     parameters,
+		SystemSelector: systemSelector,
+	}
+}
+
 func (s AssetGroupSelectorSpec) Validate() error {
-	return nil
+	if s.Action != SelectorSpecActionAdd {
+		return nil
+	} else if !s.SelectorType().IsValid() {
+		return fmt.Errorf("%w: %s", ErrAssetGroupSelectorTypeInvalid, s.Type)
+	} else if strings.TrimSpace(s.SelectorValue()) == "" {
+		return ErrAssetGroupSelectorEmpty
+	}
+
+	switch s.SelectorType() {
+	case AssetGroupSelectorTypeCypher:
+		return nil
+
+	case AssetGroupSelectorTypeProperty:
+		return validatePropertySelector(s.SelectorValue(), s.Parameters)
+
+	default:
+		if len(s.Parameters) > 0 {
+			return ErrAssetGroupSelectorParametersInvalid
+		}
+
+		return nil
+	}
 }
 
 func (s AssetGroupSelectorSpec) AuditData() AuditData {
 	return AuditData{
 		"selector_name":             s.SelectorName,
 		"selector_entity_object_id": s.EntityObjectID,
+		"selector_type":             s.SelectorType(),
+		"selector":                  s.SelectorValue(),
 	}
 }
@@ -18,6 +18,8 @@ package model
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAssetGroupSelectorSpec_Validate(t *testing.T) {
@@ -45,3 +47,121 @@ func TestAssetGroupSelectorSpec_Validate(t *testing.T) {
 	//
 	//assert.Equalf(t, bustedAction.Validate(), ErrActionInvalid, "Expected bad node label to fail validation")
 }
+
+func TestAssetGroupSelectorSpec_ValidateSelectorTypes(t *testing.T) {
+	objectIDSpec := AssetGroupSelectorSpec{
+		SelectorName:   "object id",
+		EntityObjectID: "S-1-5-21-570004220-2248230615-4072641716-544",
+		Action:         SelectorSpecActionAdd,
+	}
+
+	assert.Nil(t, objectIDSpec.Validate())
+	assert.Equal(t, AssetGroupSelectorTypeObjectID, objectIDSpec.SelectorType())
+	assert.Equal(t, objectIDSpec.EntityObjectID, objectIDSpec.SelectorValue())
+
+	propertySpec := AssetGroupSelectorSpec{
+		SelectorName: "unconstrained computers",
+		Action:       SelectorSpecActionAdd,
+		Type:         AssetGroupSelectorTypeProperty,
+		Selector:     "unconstraineddelegation",
+		Parameters: map[string]any{
+			AssetGroupSelectorParameterKind:  "Computer",
+			AssetGroupSelectorParameterValue: true,
+		},
+	}
+
+	assert.Nil(t, propertySpec.Validate())
+
+	selector := propertySpec.AssetGroupSelector(1, false)
+	assert.Equal(t, AssetGroupSelectorTypeProperty, selector.Type)
+	assert.Equal(t, "unconstraineddelegation", selector.Selector)
+	assert.Equal(t, true, selector.Parameters[AssetGroupSelectorParameterValue])
+
+	propertySpec.Parameters = map[string]any{"other": "value"}
+	assert.ErrorIs(t, propertySpec.Validate(), ErrAssetGroupSelectorParametersInvalid)
+
+	propertySpec.Parameters = map[string]any{AssetGroupSelectorParameterKind: 1}
+	assert.ErrorIs(t, propertySpec.Validate(), ErrAssetGroupSelectorParametersInvalid)
+
+	propertySpec.Parameters = map[string]any{AssetGroupSelectorParameterKind: "Computer' or true; select 1 --"}
+	assert.ErrorIs(t, propertySpec.Validate(), ErrAssetGroupSelectorKindInvalid)
+
+	propertySpec.Parameters = map[string]any{AssetGroupSelectorParameterKind: "AZUser"}
+	assert.Nil(t, propertySpec.Validate())
+
+	// property names are interpolated into the translated query and must be plain identifiers
+	propertySpec.Parameters = nil
+	for _, hostileProperty := range []string{"x' or true; select 1 --", "name)", "1name", "na me", "name\n"} {
+		propertySpec.Selector = hostileProperty
+		assert.ErrorIs(t, propertySpec.Validate(), ErrAssetGroupSelectorPropertyInvalid, hostileProperty)
+		assert.ErrorIs(t, propertySpec.AssetGroupSelector(1, false).ValidatePropertySelector(), ErrAssetGroupSelectorPropertyInvalid, hostileProperty)
+	}
+
+	containedBySpec := AssetGroupSelectorSpec{
+		SelectorName: "servers ou",
+		Action:       SelectorSpecActionAdd,
+		Type:         AssetGroupSelectorTypeContainedBy,
+		Parameters:   map[string]any{"unused": true},
+	}
+
+	assert.ErrorIs(t, containedBySpec.Validate(), ErrAssetGroupSelectorEmpty)
+
+	containedBySpec.Selector = "9A2F1C5E-0000-0000-0000-000000000001"
+	assert.ErrorIs(t, containedBySpec.Validate(), ErrAssetGroupSelectorParametersInvalid)
+
+	invalidTypeSpec := AssetGroupSelectorSpec{
+		SelectorName: "invalid",
+		Action:       SelectorSpecActionAdd,
+		Type:         "sql",
+		Selector:     "SELECT 1",
+	}
+
+	assert.ErrorIs(t, invalidTypeSpec.Validate(), ErrAssetGroupSelectorTypeInvalid)
+
+	// specs that remove a selector only need to name it
+	assert.Nil(t, AssetGroupSelectorSpec{SelectorName: "invalid", Action: SelectorSpecActionRemove, Type: "sql"}.Validate())
+}
+
+func TestDiffAssetGroupCollections(t *testing.T) {
+	var (
+		from = AssetGroupCollection{
+			AssetGroupID: 1,
+			BigSerial:    BigSerial{ID: 10},
+			Entries: AssetGroupCollectionEntries{
+				{ObjectID: "kept", NodeLabel: "User", Selectors: []string{"admins"}},
+				{ObjectID: "removed", NodeLabel: "Computer", Properties: map[string]any{"name": "REMOVED.LOCAL"}, Selectors: []string{"servers"}},
+			},
+		}
+		to = AssetGroupCollection{
+			AssetGroupID: 1,
+			BigSerial:    BigSerial{ID: 11},
+			Entries: AssetGroupCollectionEntries{
+				{ObjectID: "kept", NodeLabel: "User", Selectors: []string{"admins"}},
+				{ObjectID: "added", NodeLabel: "Group", Properties: map[string]any{"name": "ADDED@LOCAL"}, Selectors: []string{"cypher", "admins"}},
+				{ObjectID: "unattributed", NodeLabel: "Domain"},
+			},
+		}
+	)
+
+	diff := DiffAssetGroupCollections(from, to)
+	assert.True(t, diff.HasChanges())
+	assert.Equal(t, int32(1), diff.AssetGroupID)
+	assert.Equal(t, int64(10), diff.FromCollectionID)
+	assert.Equal(t, int64(11), diff.ToCollectionID)
+	assert.Equal(t, []AssetGroupMembershipChange{
+		{ObjectID: "added", NodeLabel: "Group", Name: "ADDED@LOCAL", Selectors: []string{"cypher", "admins"}},
+		{ObjectID: "unattributed", NodeLabel: "Domain", Selectors: []string{}},
+	}, diff.Added)
+	assert.Equal(t, []AssetGroupMembershipChange{
+		{ObjectID: "removed", NodeLabel: "Computer", Name: "REMOVED.LOCAL", Selectors: []string{"servers"}},
+	}, diff.Removed)
+
+	auditData := diff.AuditData()
+	assert.Equal(t, []string{"added", "unattributed"}, auditData["added_object_ids"])
+	assert.Equal(t, []string{"removed"}, auditData["removed_object_ids"])
+
+	unchanged := DiffAssetGroupCollections(to, to)
+	assert.False(t, unchanged.HasChanges())
+	assert.Equal(t, []AssetGroupMembershipChange{}, unchanged.Added)
+	assert.Equal(t, []AssetGroupMembershipChange{}, unchanged.Removed)
+}
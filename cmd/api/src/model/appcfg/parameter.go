@@ -45,6 +45,8 @@ const (
 	DefaultPruneHasSessionEdgeTTL = time.Hour * 24 * 3
 
 	ReconciliationKey = "analysis.reconciliation"
+
+	DeletedObjectsKey = "ingest.deleted_objects"
 	ScheduledAnalysis = "analysis.scheduled" //This key is not intended to be user updateable, so should not be added to IsValidKey
 )
 
@@ -73,6 +75,7 @@ func (s *Parameter) IsValidKey(parameterKey string) bool {
 		PruneTTL:                 true,
 		CitrixRDPSupportKey:      true,
 		ReconciliationKey:        true,
+		DeletedObjectsKey:        true,
 	}
 
 	return validKeys[parameterKey]
@@ -101,6 +104,8 @@ func (s *Parameter) Validate() utils.Errors {
 		v = &CitrixRDPSupport{}
 	case ReconciliationKey:
 		v = &ReconciliationParameter{}
+	case DeletedObjectsKey:
+		v = &DeletedObjectsParameter{}
 	default:
 		return utils.Errors{errors.New("invalid key")}
 	}
@@ -286,6 +291,26 @@ func GetReconciliationParameter(ctx context.Context, service ParameterService) b
 	return result.Enabled
 }
 
+// DeletedObjects
+
+// DeletedObjectsParameter controls how objects reported as deleted by collectors are applied to the graph. When Remove
+// is set the objects are removed from the graph, otherwise they are kept and flagged with the isdeleted property.
+type DeletedObjectsParameter struct {
+	Remove bool `json:"remove"`
+}
+
+func GetDeletedObjectsParameter(ctx context.Context, service ParameterService) DeletedObjectsParameter {
+	result := DeletedObjectsParameter{Remove: true}
+
+	if cfg, err := service.GetConfigurationParameter(ctx, DeletedObjectsKey); err != nil {
+		log.Warnf("Failed to fetch deleted objects configuration; returning default values")
+	} else if err := cfg.Map(&result); err != nil {
+		log.Warnf("Invalid deleted objects configuration supplied, %v. returning default values.", err)
+	}
+
+	return result
+}
+
 type ScheduledAnalysisParameter struct {
 	Enabled bool   `json:"enabled,omitempty"`
 	RRule   string `json:"rrule,omitempty"`
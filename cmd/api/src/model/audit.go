@@ -53,6 +53,8 @@ const (
 
 	AuditLogActionDeleteAssetGroupSelector AuditLogAction = "DeleteAssetGroupSelector"
 
+	AuditLogActionTierZeroMembershipChanged AuditLogAction = "TierZeroMembershipChanged"
+
 	AuditLogActionCreateAuthToken AuditLogAction = "CreateAuthToken"
 	AuditLogActionDeleteAuthToken AuditLogAction = "DeleteAuthToken"
 
@@ -0,0 +1,204 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/specterops/bloodhound/src/model/ingest"
+)
+
+// DefaultCollectionStaleAfterDays is the age in days after which collected data is reported as stale when no other
+// threshold is given
+const DefaultCollectionStaleAfterDays = 7
+
+const (
+	ComputerCollectionStatus             = "Status"
+	ComputerCollectionSessions           = "Sessions"
+	ComputerCollectionPrivilegedSessions = "PrivilegedSessions"
+	ComputerCollectionRegistrySessions   = "RegistrySessions"
+	ComputerCollectionLocalGroup         = "LocalGroup"
+	ComputerCollectionUserRights         = "UserRights"
+)
+
+// ExpectedDomainCollectionDataTypes returns the data types that a complete collection of a domain is expected to have
+// produced
+func ExpectedDomainCollectionDataTypes() []ingest.DataType {
+	return []ingest.DataType{
+		ingest.DataTypeDomain,
+		ingest.DataTypeUser,
+		ingest.DataTypeGroup,
+		ingest.DataTypeComputer,
+		ingest.DataTypeGPO,
+		ingest.DataTypeOU,
+		ingest.DataTypeContainer,
+		ingest.DataTypeSession,
+	}
+}
+
+// DomainCollection records the most recent collection of a single data type for a domain. CollectedAt is the start of
+// the file upload job that submitted the data, which is the closest available approximation of when it was
+// collected. Files of the same data type and domain that were submitted by the same job are merged into one record.
+type DomainCollection struct {
+	DomainSID   string                  `json:"domain_sid" gorm:"column:domain_sid"`
+	DataType    string                  `json:"data_type"`
+	Methods     ingest.CollectionMethod `json:"methods"`
+	ObjectCount int                     `json:"object_count"`
+	CollectedAt time.Time               `json:"collected_at"`
+
+	BigSerial
+}
+
+type DomainCollections []DomainCollection
+
+// ComputerCollectionFailure records a collection against a computer that did not succeed the last time the computer
+// was collected along with the reason reported by the collector. Collection names the failed collection and, for
+// local groups and user rights, is suffixed with the group or privilege that could not be collected.
+type ComputerCollectionFailure struct {
+	ComputerObjectID string    `json:"computer_object_id"`
+	ComputerName     string    `json:"computer_name"`
+	DomainSID        string    `json:"domain_sid" gorm:"column:domain_sid"`
+	Collection       string    `json:"collection"`
+	FailureReason    string    `json:"failure_reason"`
+	CollectedAt      time.Time `json:"collected_at"`
+
+	BigSerial
+}
+
+type ComputerCollectionFailures []ComputerCollectionFailure
+
+// CollectionSummary describes the collected data read from a single ingest file. It is used to update the collection
+// coverage of each domain once the file has been ingested.
+type CollectionSummary struct {
+	// DomainObjects counts the objects read for each domain SID
+	DomainObjects map[string]int
+
+	// Computers are the object IDs of every computer read. The collection failures of these computers are replaced by
+	// ComputerFailures.
+	Computers        []string
+	ComputerFailures ComputerCollectionFailures
+}
+
+func NewCollectionSummary() *CollectionSummary {
+	return &CollectionSummary{
+		DomainObjects: map[string]int{},
+	}
+}
+
+// DomainCollections returns a collection record for each domain in the summary
+func (s *CollectionSummary) DomainCollections(meta ingest.Metadata, collectedAt time.Time) DomainCollections {
+	collections := make(DomainCollections, 0, len(s.DomainObjects))
+
+	for domainSID, count := range s.DomainObjects {
+		collections = append(collections, DomainCollection{
+			DomainSID:   domainSID,
+			DataType:    string(meta.Type),
+			Methods:     meta.Methods,
+			ObjectCount: count,
+			CollectedAt: collectedAt,
+		})
+	}
+
+	return collections
+}
+
+// DataTypeCoverage describes the most recent collection of a single data type
+type DataTypeCoverage struct {
+	DataType    string    `json:"data_type"`
+	Methods     []string  `json:"methods"`
+	ObjectCount int       `json:"object_count"`
+	CollectedAt time.Time `json:"collected_at"`
+	Stale       bool      `json:"stale"`
+}
+
+// CollectionCoverage reports which data types and collection methods have been collected for a domain, which of them
+// are missing or stale and which computers failed to be collected
+type CollectionCoverage struct {
+	DomainSID             string                     `json:"domain_sid"`
+	StaleAfter            time.Time                  `json:"stale_after"`
+	DataTypes             []DataTypeCoverage         `json:"data_types"`
+	MissingDataTypes      []string                   `json:"missing_data_types"`
+	StaleDataTypes        []string                   `json:"stale_data_types"`
+	MissingMethods        []string                   `json:"missing_methods"`
+	ComputerFailureCount  int                        `json:"computer_failure_count"`
+	ComputerFailures      ComputerCollectionFailures `json:"computer_failures"`
+	LastCollectedAt       *time.Time                 `json:"last_collected_at"`
+	RecollectionSuggested bool                       `json:"recollection_suggested"`
+}
+
+// NewCollectionCoverage builds the collection coverage report of a domain. Data collected before staleAfter is
+// reported as stale.
+func NewCollectionCoverage(domainSID string, collections DomainCollections, failures ComputerCollectionFailures, failureCount int, staleAfter time.Time) CollectionCoverage {
+	var (
+		coverage = CollectionCoverage{
+			DomainSID:            domainSID,
+			StaleAfter:           staleAfter,
+			DataTypes:            []DataTypeCoverage{},
+			MissingDataTypes:     []string{},
+			StaleDataTypes:       []string{},
+			ComputerFailureCount: failureCount,
+			ComputerFailures:     failures,
+		}
+		collected  = map[string]struct{}{}
+		allMethods ingest.CollectionMethod
+	)
+
+	if coverage.ComputerFailures == nil {
+		coverage.ComputerFailures = ComputerCollectionFailures{}
+	}
+
+	for _, collection := range collections {
+		dataType := DataTypeCoverage{
+			DataType:    collection.DataType,
+			Methods:     collection.Methods.Names(),
+			ObjectCount: collection.ObjectCount,
+			CollectedAt: collection.CollectedAt,
+			Stale:       collection.CollectedAt.Before(staleAfter),
+		}
+
+		if dataType.Stale {
+			coverage.StaleDataTypes = append(coverage.StaleDataTypes, collection.DataType)
+		}
+
+		if coverage.LastCollectedAt == nil || collection.CollectedAt.After(*coverage.LastCollectedAt) {
+			lastCollectedAt := collection.CollectedAt
+			coverage.LastCollectedAt = &lastCollectedAt
+		}
+
+		collected[collection.DataType] = struct{}{}
+		allMethods = allMethods.Or(collection.Methods)
+		coverage.DataTypes = append(coverage.DataTypes, dataType)
+	}
+
+	for _, dataType := range ExpectedDomainCollectionDataTypes() {
+		if _, found := collected[string(dataType)]; !found {
+			coverage.MissingDataTypes = append(coverage.MissingDataTypes, string(dataType))
+		}
+	}
+
+	coverage.MissingMethods = (ingest.ExpectedCollectionMethods &^ allMethods).Names()
+
+	slices.SortFunc(coverage.DataTypes, func(a, b DataTypeCoverage) int {
+		return strings.Compare(a.DataType, b.DataType)
+	})
+	slices.Sort(coverage.StaleDataTypes)
+
+	coverage.RecollectionSuggested = len(coverage.MissingDataTypes) > 0 || len(coverage.StaleDataTypes) > 0 || len(coverage.MissingMethods) > 0
+	return coverage
+}
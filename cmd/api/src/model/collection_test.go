@@ -0,0 +1,68 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/ingest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCollectionCoverage(t *testing.T) {
+	var (
+		now         = time.Now().UTC()
+		staleAfter  = now.Add(-7 * 24 * time.Hour)
+		collections = model.DomainCollections{{
+			DomainSID:   "S-1-5-21-1",
+			DataType:    string(ingest.DataTypeUser),
+			Methods:     ingest.ExpectedCollectionMethods &^ ingest.CollectionMethodSession,
+			ObjectCount: 10,
+			CollectedAt: now,
+		}, {
+			DomainSID:   "S-1-5-21-1",
+			DataType:    string(ingest.DataTypeDomain),
+			Methods:     ingest.CollectionMethodTrusts,
+			ObjectCount: 1,
+			CollectedAt: now.Add(-30 * 24 * time.Hour),
+		}}
+		coverage = model.NewCollectionCoverage("S-1-5-21-1", collections, nil, 0, staleAfter)
+	)
+
+	require.Len(t, coverage.DataTypes, 2)
+	require.Equal(t, string(ingest.DataTypeDomain), coverage.DataTypes[0].DataType)
+	require.True(t, coverage.DataTypes[0].Stale)
+	require.False(t, coverage.DataTypes[1].Stale)
+	require.Equal(t, []string{string(ingest.DataTypeDomain)}, coverage.StaleDataTypes)
+	require.Equal(t, []string{"groups", "computers", "gpos", "ous", "containers", "sessions"}, coverage.MissingDataTypes)
+	require.Equal(t, []string{"Session"}, coverage.MissingMethods)
+	require.Equal(t, now, *coverage.LastCollectedAt)
+	require.NotNil(t, coverage.ComputerFailures)
+	require.True(t, coverage.RecollectionSuggested)
+
+	empty := model.NewCollectionCoverage("S-1-5-21-2", nil, nil, 0, staleAfter)
+	require.Nil(t, empty.LastCollectedAt)
+	require.Len(t, empty.MissingDataTypes, len(model.ExpectedDomainCollectionDataTypes()))
+	require.True(t, empty.RecollectionSuggested)
+}
+
+func TestCollectionMethod_Names(t *testing.T) {
+	require.Equal(t, []string{}, ingest.CollectionMethod(0).Names())
+	require.Equal(t, []string{"Group", "Session", "DCOM"}, (ingest.CollectionMethodDCOM | ingest.CollectionMethodGroup | ingest.CollectionMethodSession).Names())
+}
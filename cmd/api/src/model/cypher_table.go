@@ -0,0 +1,186 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// CypherTableNode is a node value in a tabular cypher query result
+type CypherTableNode struct {
+	ID string `json:"id"`
+	UnifiedNode
+}
+
+// CypherTableRelationship is a relationship value in a tabular cypher query result
+type CypherTableRelationship struct {
+	ID string `json:"id"`
+	UnifiedEdge
+}
+
+// CypherTablePath is a path value in a tabular cypher query result with its nodes and relationships in path order
+type CypherTablePath struct {
+	Nodes []CypherTableNode         `json:"nodes"`
+	Edges []CypherTableRelationship `json:"edges"`
+}
+
+// CypherTable is the tabular result of a cypher query containing the name of each returned column and the values of
+// each returned row in column order
+type CypherTable struct {
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+func newCypherTableNode(node *graph.Node, includeProperties bool) CypherTableNode {
+	return CypherTableNode{
+		ID:          node.ID.String(),
+		UnifiedNode: FromDAWGSNode(node, includeProperties),
+	}
+}
+
+func newCypherTableRelationship(relationship *graph.Relationship, includeProperties bool) CypherTableRelationship {
+	return CypherTableRelationship{
+		ID:          relationship.ID.String(),
+		UnifiedEdge: FromDAWGSRelationship(includeProperties)(relationship),
+	}
+}
+
+func newCypherTableValue(value any, includeProperties bool) any {
+	switch typedValue := value.(type) {
+	case *graph.Node:
+		return newCypherTableNode(typedValue, includeProperties)
+
+	case *graph.Relationship:
+		return newCypherTableRelationship(typedValue, includeProperties)
+
+	case *graph.Path:
+		path := CypherTablePath{
+			Nodes: make([]CypherTableNode, len(typedValue.Nodes)),
+			Edges: make([]CypherTableRelationship, len(typedValue.Edges)),
+		}
+
+		for idx, node := range typedValue.Nodes {
+			path.Nodes[idx] = newCypherTableNode(node, includeProperties)
+		}
+
+		for idx, relationship := range typedValue.Edges {
+			path.Edges[idx] = newCypherTableRelationship(relationship, includeProperties)
+		}
+
+		return path
+
+	case []any:
+		values := make([]any, len(typedValue))
+
+		for idx, nextValue := range typedValue {
+			values[idx] = newCypherTableValue(nextValue, includeProperties)
+		}
+
+		return values
+
+	case map[string]any:
+		values := make(map[string]any, len(typedValue))
+
+		for key, nextValue := range typedValue {
+			values[key] = newCypherTableValue(nextValue, includeProperties)
+		}
+
+		return values
+
+	default:
+		return value
+	}
+}
+
+// NewCypherTable converts the given rows of decoded graph values into a CypherTable. Nodes, relationships and paths
+// are converted to their rendered forms, optionally including their properties.
+func NewCypherTable(columns []string, rows [][]any, includeProperties bool) CypherTable {
+	table := CypherTable{
+		Columns: columns,
+		Rows:    make([][]any, len(rows)),
+	}
+
+	for rowIdx, row := range rows {
+		tableRow := make([]any, len(row))
+
+		for columnIdx, value := range row {
+			tableRow[columnIdx] = newCypherTableValue(value, includeProperties)
+		}
+
+		table.Rows[rowIdx] = tableRow
+	}
+
+	return table
+}
+
+func formatCypherTableCSVValue(value any) (string, error) {
+	switch typedValue := value.(type) {
+	case nil:
+		return "", nil
+
+	case string:
+		return typedValue, nil
+
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", typedValue), nil
+
+	case time.Time:
+		return typedValue.Format(time.RFC3339Nano), nil
+
+	default:
+		if content, err := json.Marshal(typedValue); err != nil {
+			return "", err
+		} else {
+			return string(content), nil
+		}
+	}
+}
+
+// WriteCSV writes the table as CSV with a header row of column names. Scalar values are written as-is and all other
+// values are written as JSON.
+func (s CypherTable) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write(s.Columns); err != nil {
+		return err
+	}
+
+	for _, row := range s.Rows {
+		record := make([]string, len(row))
+
+		for idx, value := range row {
+			if formattedValue, err := formatCypherTableCSVValue(value); err != nil {
+				return err
+			} else {
+				record[idx] = formattedValue
+			}
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCypherTable(t *testing.T) {
+	var (
+		user     = graph.NewNode(1, graph.AsProperties(map[string]any{common.ObjectID.String(): "S-1-5-21-1", common.Name.String(): "USER"}), ad.Entity, ad.User)
+		group    = graph.NewNode(2, graph.AsProperties(map[string]any{common.ObjectID.String(): "S-1-5-21-2", common.Name.String(): "GROUP"}), ad.Entity, ad.Group)
+		memberOf = graph.NewRelationship(3, 1, 2, graph.NewProperties(), ad.MemberOf)
+		table    = model.NewCypherTable([]string{"n", "names", "p"}, [][]any{{
+			user,
+			[]any{"USER", map[string]any{"group": group}},
+			&graph.Path{Nodes: []*graph.Node{user, group}, Edges: []*graph.Relationship{memberOf}},
+		}}, false)
+	)
+
+	require.Equal(t, []string{"n", "names", "p"}, table.Columns)
+	require.Len(t, table.Rows, 1)
+
+	node, isNode := table.Rows[0][0].(model.CypherTableNode)
+	require.True(t, isNode)
+	require.Equal(t, "1", node.ID)
+	require.Equal(t, "S-1-5-21-1", node.ObjectId)
+	require.Nil(t, node.Properties)
+
+	names, isList := table.Rows[0][1].([]any)
+	require.True(t, isList)
+	require.Equal(t, "USER", names[0])
+	require.Equal(t, "2", names[1].(map[string]any)["group"].(model.CypherTableNode).ID)
+
+	path, isPath := table.Rows[0][2].(model.CypherTablePath)
+	require.True(t, isPath)
+	require.Len(t, path.Nodes, 2)
+	require.Equal(t, "3", path.Edges[0].ID)
+	require.Equal(t, ad.MemberOf.String(), path.Edges[0].Kind)
+}
+
+func TestCypherTable_WriteCSV(t *testing.T) {
+	var (
+		output = &bytes.Buffer{}
+		table  = model.CypherTable{
+			Columns: []string{"name", "count", "enabled", "tags", "missing"},
+			Rows: [][]any{
+				{"USER, ONE", int64(2), true, []any{"a", "b"}, nil},
+			},
+		}
+	)
+
+	require.Nil(t, table.WriteCSV(output))
+	require.Equal(t, "name,count,enabled,tags,missing\n\"USER, ONE\",2,true,\"[\"\"a\"\",\"\"b\"\"]\",\n", output.String())
+}
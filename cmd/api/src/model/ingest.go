@@ -17,9 +17,18 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
 	"github.com/specterops/bloodhound/src/database/types/null"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
+// MaxFileUploadJobResultErrors is the maximum number of error messages retained for a single ingested file
+const MaxFileUploadJobResultErrors = 10
+
 type IngestTask struct {
 	FileName    string     `json:"file_name"`
 	RequestGUID string     `json:"request_guid"`
@@ -36,4 +45,190 @@ type FileType int
 const (
 	FileTypeJson FileType = iota
 	FileTypeZip
+	FileTypeGzip
+	FileTypeZstd
+	FileTypeTar
 )
+
+// IsCompressed returns true if the file type is a single compressed stream that may contain either JSON or a tar
+// archive
+func (s FileType) IsCompressed() bool {
+	return s == FileTypeGzip || s == FileTypeZstd
+}
+
+// IngestError describes a failure to ingest an object. Offset is the byte offset into the ingested JSON file that
+// immediately precedes the object, or chunk of objects, that failed.
+type IngestError struct {
+	Offset  int64  `json:"offset"`
+	Message string `json:"message"`
+}
+
+type IngestErrors []IngestError
+
+// Scan parses the input value (expected to be JSON) to []byte and then attempts to unmarshal it into the receiver
+func (s *IngestErrors) Scan(value any) error {
+	if bytes, ok := value.([]byte); !ok {
+		return fmt.Errorf("failed to unmarshal JSONB value: %v", value)
+	} else {
+		return json.Unmarshal(bytes, s)
+	}
+}
+
+// Value returns the json-marshaled value of the receiver
+func (s IngestErrors) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(s)
+}
+
+// GormDBDataType returns JSONB if postgres, otherwise panics due to lack of DB type support
+func (s IngestErrors) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	switch dbDialect := db.Dialector.Name(); dbDialect {
+	case "postgres":
+		return "JSONB"
+
+	default:
+		panic(fmt.Sprintf("Unsupported database dialect for JSON datatype: %s", dbDialect))
+	}
+}
+
+// FileUploadJobResult records the outcome of ingesting a single file that was submitted as part of a file upload job.
+// Files extracted from an uploaded archive each receive their own result. The deleted and flagged counts are only
+// populated for files that report deleted objects. The report is only populated for files of dry run jobs.
+type FileUploadJobResult struct {
+	FileUploadJobID      int64         `json:"file_upload_job_id"`
+	FileName             string        `json:"file_name"`
+	DataType             string        `json:"data_type"`
+	ObjectsRead          int           `json:"objects_read"`
+	ObjectsWritten       int           `json:"objects_written"`
+	ObjectsSkipped       int           `json:"objects_skipped"`
+	ObjectsDeleted       int           `json:"objects_deleted"`
+	ObjectsFlagged       int           `json:"objects_flagged"`
+	RelationshipsDeleted int           `json:"relationships_deleted"`
+	ErrorCount           int           `json:"error_count"`
+	Errors               IngestErrors  `json:"errors"`
+	Report               *IngestReport `json:"report,omitempty"`
+
+	// Collection describes the collected data read from the file. It is only populated for files that are written to
+	// the default graph and is not persisted with the result.
+	Collection *CollectionSummary `json:"-" gorm:"-"`
+
+	BigSerial
+}
+
+// AddError records an ingest error. Only the first MaxFileUploadJobResultErrors messages are retained but all errors
+// are counted.
+func (s *FileUploadJobResult) AddError(offset int64, err error) {
+	s.ErrorCount++
+
+	if len(s.Errors) < MaxFileUploadJobResultErrors {
+		s.Errors = append(s.Errors, IngestError{
+			Offset:  offset,
+			Message: err.Error(),
+		})
+	}
+}
+
+type FileUploadJobResults []FileUploadJobResult
+
+// Report merges the ingest reports of all results into a single report
+func (s FileUploadJobResults) Report() IngestReport {
+	report := NewIngestReport()
+
+	for _, result := range s {
+		if result.Report != nil {
+			report.Merge(*result.Report)
+		}
+	}
+
+	return report
+}
+
+// IngestReport describes the graph writes that ingesting a file would have made. Reports are produced by dry run file
+// upload jobs, which decode and convert ingest files without writing them to the graph. Each map is keyed by the kind,
+// property name or violation message that was counted.
+type IngestReport struct {
+	NodeKinds         map[string]int `json:"node_kinds"`
+	RelationshipKinds map[string]int `json:"relationship_kinds"`
+	UnknownProperties map[string]int `json:"unknown_properties"`
+	SchemaViolations  map[string]int `json:"schema_violations"`
+}
+
+func NewIngestReport() IngestReport {
+	return IngestReport{
+		NodeKinds:         map[string]int{},
+		RelationshipKinds: map[string]int{},
+		UnknownProperties: map[string]int{},
+		SchemaViolations:  map[string]int{},
+	}
+}
+
+// Merge adds the counts of the other report to this report
+func (s IngestReport) Merge(other IngestReport) {
+	for kind, count := range other.NodeKinds {
+		s.NodeKinds[kind] += count
+	}
+
+	for kind, count := range other.RelationshipKinds {
+		s.RelationshipKinds[kind] += count
+	}
+
+	for property, count := range other.UnknownProperties {
+		s.UnknownProperties[property] += count
+	}
+
+	for violation, count := range other.SchemaViolations {
+		s.SchemaViolations[violation] += count
+	}
+}
+
+// Scan parses the input value (expected to be JSON) to []byte and then attempts to unmarshal it into the receiver
+func (s *IngestReport) Scan(value any) error {
+	if value == nil {
+		return nil
+	} else if bytes, ok := value.([]byte); !ok {
+		return fmt.Errorf("failed to unmarshal JSONB value: %v", value)
+	} else {
+		return json.Unmarshal(bytes, s)
+	}
+}
+
+// Value returns the json-marshaled value of the receiver
+func (s IngestReport) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// GormDBDataType returns JSONB if postgres, otherwise panics due to lack of DB type support
+func (s IngestReport) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	switch dbDialect := db.Dialector.Name(); dbDialect {
+	case "postgres":
+		return "JSONB"
+
+	default:
+		panic(fmt.Sprintf("Unsupported database dialect for JSON datatype: %s", dbDialect))
+	}
+}
+
+// ResumableUpload tracks a file that is uploaded to a file upload job in multiple requests. Uploaded bytes are appended
+// to a temporary file until Offset reaches Length, at which point the upload is marked as Finished and the assembled
+// file is verified against its SHA-256 checksum before it is submitted for ingest.
+type ResumableUpload struct {
+	FileUploadJobID int64    `json:"file_upload_job_id"`
+	FileName        string   `json:"-"`
+	FileType        FileType `json:"file_type"`
+	Length          int64    `json:"length"`
+	Offset          int64    `json:"offset"`
+	SHA256          string   `json:"sha256" gorm:"column:sha256"`
+	Finished        bool     `json:"finished"`
+
+	BigSerial
+}
+
+type ResumableUploads []ResumableUpload
+
+// IsComplete returns true once all bytes of the upload have been received
+func (s ResumableUpload) IsComplete() bool {
+	return s.Offset >= s.Length
+}
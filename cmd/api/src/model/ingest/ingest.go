@@ -18,6 +18,7 @@ package ingest
 
 import (
 	"encoding/json"
+	"slices"
 
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/errors"
@@ -31,7 +32,26 @@ var AllowedZipFileUploadTypes = []string{
 	"application/zip-compressed",   // Not currently available in mediatypes
 }
 
-var AllowedFileUploadTypes = append([]string{mediatypes.ApplicationJson.String()}, AllowedZipFileUploadTypes...)
+var AllowedGzipFileUploadTypes = []string{
+	mediatypes.ApplicationGzip.String(),
+	"application/x-gzip", // Not currently available in mediatypes
+}
+
+var AllowedZstdFileUploadTypes = []string{
+	mediatypes.ApplicationZstd.String(),
+}
+
+var AllowedTarFileUploadTypes = []string{
+	"application/x-tar", // Not currently available in mediatypes
+}
+
+var AllowedFileUploadTypes = slices.Concat(
+	[]string{mediatypes.ApplicationJson.String()},
+	AllowedZipFileUploadTypes,
+	AllowedGzipFileUploadTypes,
+	AllowedZstdFileUploadTypes,
+	AllowedTarFileUploadTypes,
+)
 
 type Metadata struct {
 	Type    DataType         `json:"type"`
@@ -50,7 +70,7 @@ func (s Metadata) MatchKind() (graph.Kind, bool) {
 	case DataTypeGroup:
 		return ad.Group, true
 
-	case DataTypeGPO:
+	case DataTypeGPO, DataTypeGPOSettings:
 		return ad.GPO, true
 
 	case DataTypeDomain:
@@ -91,6 +111,7 @@ const (
 	DataTypeGroup          DataType = "groups"
 	DataTypeComputer       DataType = "computers"
 	DataTypeGPO            DataType = "gpos"
+	DataTypeGPOSettings    DataType = "gposettings"
 	DataTypeOU             DataType = "ous"
 	DataTypeDomain         DataType = "domains"
 	DataTypeRemoved        DataType = "deleted"
@@ -112,6 +133,7 @@ func AllIngestDataTypes() []DataType {
 		DataTypeGroup,
 		DataTypeComputer,
 		DataTypeGPO,
+		DataTypeGPOSettings,
 		DataTypeOU,
 		DataTypeDomain,
 		DataTypeRemoved,
@@ -161,6 +183,34 @@ const (
 	CollectionMethodCertServices
 )
 
+// ExpectedCollectionMethods are the collection methods that a complete collection of a domain is expected to have run
+const ExpectedCollectionMethods = CollectionMethodGroup | CollectionMethodLocalAdmin | CollectionMethodSession |
+	CollectionMethodTrusts | CollectionMethodACL | CollectionMethodContainer | CollectionMethodRDP |
+	CollectionMethodObjectProps | CollectionMethodDCOM | CollectionMethodSPNTargets | CollectionMethodPSRemote |
+	CollectionMethodUserRights
+
+var collectionMethodNames = map[CollectionMethod]string{
+	CollectionMethodGroup:         "Group",
+	CollectionMethodLocalAdmin:    "LocalAdmin",
+	CollectionMethodGPOLocalGroup: "GPOLocalGroup",
+	CollectionMethodSession:       "Session",
+	CollectionMethodLoggedOn:      "LoggedOn",
+	CollectionMethodTrusts:        "Trusts",
+	CollectionMethodACL:           "ACL",
+	CollectionMethodContainer:     "Container",
+	CollectionMethodRDP:           "RDP",
+	CollectionMethodObjectProps:   "ObjectProps",
+	CollectionMethodSessionLoop:   "SessionLoop",
+	CollectionMethodLoggedOnLoop:  "LoggedOnLoop",
+	CollectionMethodDCOM:          "DCOM",
+	CollectionMethodSPNTargets:    "SPNTargets",
+	CollectionMethodPSRemote:      "PSRemote",
+	CollectionMethodUserRights:    "UserRights",
+	CollectionMethodCARegistry:    "CARegistry",
+	CollectionMethodDCRegistry:    "DCRegistry",
+	CollectionMethodCertServices:  "CertServices",
+}
+
 func AllCollectionMethods() []CollectionMethod {
 	return []CollectionMethod{
 		CollectionMethodGroup,
@@ -195,6 +245,19 @@ func (s CollectionMethod) IsValid() bool {
 	return false
 }
 
+// Names returns the collector names of the collection methods set in the receiver
+func (s CollectionMethod) Names() []string {
+	names := []string{}
+
+	for _, method := range AllCollectionMethods() {
+		if s.Has(method) {
+			names = append(names, collectionMethodNames[method])
+		}
+	}
+
+	return names
+}
+
 func (s CollectionMethod) Has(flag CollectionMethod) bool {
 	return s.And(flag) != 0
 }
@@ -221,4 +284,7 @@ var (
 	ErrInvalidDataTag      = errors.New("invalid data tag found")
 	ErrJSONDecoderInternal = errors.New("json decoder internal error")
 	ErrInvalidZipFile      = errors.New("failed to find zip file header")
+	ErrInvalidGzipFile     = errors.New("failed to find gzip file header")
+	ErrInvalidZstdFile     = errors.New("failed to find zstd file header")
+	ErrInvalidTarFile      = errors.New("failed to find tar file header")
 )
@@ -36,6 +36,8 @@ type FileUploadJob struct {
 	LastIngest       time.Time   `json:"last_ingest"`
 	TotalFiles       int         `json:"total_files"`
 	FailedFiles      int         `json:"failed_files"`
+	DryRun           bool        `json:"dry_run"`
+	WorkspaceID      null.Int64  `json:"workspace_id"`
 	//DomainResults []DomainCollectionResult `json:"domain_results" gorm:"-"`
 
 	BigSerial
@@ -16,13 +16,205 @@
 
 package model
 
-import "errors"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	ErrInvalidSavedQueryParameterName      = errors.New("invalid saved query parameter name")
+	ErrInvalidSavedQueryParameterType      = errors.New("invalid saved query parameter type")
+	ErrDuplicateSavedQueryParameterName    = errors.New("duplicate saved query parameter name")
+	ErrSavedQueryParameterValueTypeInvalid = errors.New("saved query parameter value does not match its declared type")
+
+	savedQueryParameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+type SavedQueryParameterType string
+
+const (
+	SavedQueryParameterTypeString      SavedQueryParameterType = "string"
+	SavedQueryParameterTypeInteger     SavedQueryParameterType = "integer"
+	SavedQueryParameterTypeNumber      SavedQueryParameterType = "number"
+	SavedQueryParameterTypeBoolean     SavedQueryParameterType = "boolean"
+	SavedQueryParameterTypeStringList  SavedQueryParameterType = "string_list"
+	SavedQueryParameterTypeIntegerList SavedQueryParameterType = "integer_list"
+	SavedQueryParameterTypeNumberList  SavedQueryParameterType = "number_list"
+)
+
+func (s SavedQueryParameterType) IsValid() bool {
+	switch s {
+	case SavedQueryParameterTypeString,
+		SavedQueryParameterTypeInteger,
+		SavedQueryParameterTypeNumber,
+		SavedQueryParameterTypeBoolean,
+		SavedQueryParameterTypeStringList,
+		SavedQueryParameterTypeIntegerList,
+		SavedQueryParameterTypeNumberList:
+		return true
+	default:
+		return false
+	}
+}
+
+// Accepts returns true if the given value, as decoded from JSON or normalized for a cypher query, matches this
+// parameter type.
+func (s SavedQueryParameterType) Accepts(value any) bool {
+	switch s {
+	case SavedQueryParameterTypeString:
+		_, matches := value.(string)
+		return matches
+
+	case SavedQueryParameterTypeInteger:
+		switch typedValue := value.(type) {
+		case int64:
+			return true
+		case float64:
+			return typedValue == float64(int64(typedValue))
+		}
+
+	case SavedQueryParameterTypeNumber:
+		switch value.(type) {
+		case int64, float64:
+			return true
+		}
+
+	case SavedQueryParameterTypeBoolean:
+		_, matches := value.(bool)
+		return matches
+
+	case SavedQueryParameterTypeStringList:
+		return listAccepts(value, SavedQueryParameterTypeString)
+
+	case SavedQueryParameterTypeIntegerList:
+		return listAccepts(value, SavedQueryParameterTypeInteger)
+
+	case SavedQueryParameterTypeNumberList:
+		return listAccepts(value, SavedQueryParameterTypeNumber)
+	}
+
+	return false
+}
+
+func listAccepts(value any, elementType SavedQueryParameterType) bool {
+	switch typedValue := value.(type) {
+	case []any:
+		for _, element := range typedValue {
+			if !elementType.Accepts(element) {
+				return false
+			}
+		}
+
+		return true
+
+	case []string:
+		return elementType == SavedQueryParameterTypeString
+
+	case []int64:
+		return elementType == SavedQueryParameterTypeInteger || elementType == SavedQueryParameterTypeNumber
+
+	case []float64:
+		return elementType == SavedQueryParameterTypeNumber
+
+	default:
+		return false
+	}
+}
+
+// SavedQueryParameter declares a named, typed parameter (e.g. $domain) that must be supplied when running the
+// saved query
+type SavedQueryParameter struct {
+	Name        string                  `json:"name"`
+	Type        SavedQueryParameterType `json:"type"`
+	Description string                  `json:"description,omitempty"`
+}
+
+type SavedQueryParameters []SavedQueryParameter
+
+// Validate checks that each declared parameter has a valid cypher parameter name and type and that no parameter name
+// is declared more than once
+func (s SavedQueryParameters) Validate() error {
+	seen := make(map[string]struct{}, len(s))
+
+	for _, parameter := range s {
+		if !savedQueryParameterNamePattern.MatchString(parameter.Name) {
+			return fmt.Errorf("%w: %q", ErrInvalidSavedQueryParameterName, parameter.Name)
+		} else if !parameter.Type.IsValid() {
+			return fmt.Errorf("%w: %q", ErrInvalidSavedQueryParameterType, parameter.Type)
+		} else if _, duplicate := seen[parameter.Name]; duplicate {
+			return fmt.Errorf("%w: %q", ErrDuplicateSavedQueryParameterName, parameter.Name)
+		} else {
+			seen[parameter.Name] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// ValidateValues checks that each given parameter value matches the type of its declared parameter. Values for
+// parameters that are not declared are ignored.
+func (s SavedQueryParameters) ValidateValues(values map[string]any) error {
+	for _, parameter := range s {
+		if value, hasValue := values[parameter.Name]; hasValue && !parameter.Type.Accepts(value) {
+			return fmt.Errorf("%w: %s must be of type %s", ErrSavedQueryParameterValueTypeInvalid, parameter.Name, parameter.Type)
+		}
+	}
+
+	return nil
+}
+
+// Names returns the names of each declared parameter
+func (s SavedQueryParameters) Names() []string {
+	names := make([]string, 0, len(s))
+
+	for _, parameter := range s {
+		names = append(names, parameter.Name)
+	}
+
+	return names
+}
+
+// Scan parses the input value (expected to be JSON) to []byte and then attempts to unmarshal it into the receiver
+func (s *SavedQueryParameters) Scan(value any) error {
+	if bytes, ok := value.([]byte); !ok {
+		return fmt.Errorf("failed to unmarshal JSONB value: %v", value)
+	} else {
+		return json.Unmarshal(bytes, s)
+	}
+}
+
+// Value returns the json-marshaled value of the receiver
+func (s SavedQueryParameters) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(s)
+}
+
+// GormDBDataType returns JSONB if postgres, otherwise panics due to lack of DB type support
+func (s SavedQueryParameters) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	switch dbDialect := db.Dialector.Name(); dbDialect {
+	case "postgres":
+		return "JSONB"
+
+	default:
+		panic(fmt.Sprintf("Unsupported database dialect for JSON datatype: %s", dbDialect))
+	}
+}
 
 type SavedQuery struct {
-	UserID      string `json:"user_id" gorm:"index:,unique,composite:compositeIndex"`
-	Name        string `json:"name" gorm:"index:,unique,composite:compositeIndex"`
-	Query       string `json:"query"`
-	Description string `json:"description"`
+	UserID      string               `json:"user_id" gorm:"index:,unique,composite:compositeIndex"`
+	Name        string               `json:"name" gorm:"index:,unique,composite:compositeIndex"`
+	Query       string               `json:"query"`
+	Description string               `json:"description"`
+	Parameters  SavedQueryParameters `json:"parameters"`
 
 	BigSerial
 }
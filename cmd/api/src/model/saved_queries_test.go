@@ -79,3 +79,35 @@ func TestSavedQueries_IsString(t *testing.T) {
 		require.True(t, savedQueries.IsString(column))
 	}
 }
+
+func TestSavedQueryParameters_Validate(t *testing.T) {
+	require.Nil(t, model.SavedQueryParameters{
+		{Name: "domain", Type: model.SavedQueryParameterTypeString},
+		{Name: "object_ids", Type: model.SavedQueryParameterTypeStringList},
+	}.Validate())
+
+	require.ErrorIs(t, model.SavedQueryParameters{{Name: "$domain", Type: model.SavedQueryParameterTypeString}}.Validate(), model.ErrInvalidSavedQueryParameterName)
+	require.ErrorIs(t, model.SavedQueryParameters{{Name: "domain", Type: "map"}}.Validate(), model.ErrInvalidSavedQueryParameterType)
+	require.ErrorIs(t, model.SavedQueryParameters{
+		{Name: "domain", Type: model.SavedQueryParameterTypeString},
+		{Name: "domain", Type: model.SavedQueryParameterTypeInteger},
+	}.Validate(), model.ErrDuplicateSavedQueryParameterName)
+}
+
+func TestSavedQueryParameters_ValidateValues(t *testing.T) {
+	parameters := model.SavedQueryParameters{
+		{Name: "domain", Type: model.SavedQueryParameterTypeString},
+		{Name: "limit", Type: model.SavedQueryParameterTypeInteger},
+		{Name: "ids", Type: model.SavedQueryParameterTypeIntegerList},
+	}
+
+	require.Nil(t, parameters.ValidateValues(map[string]any{
+		"domain": "TESTLAB.LOCAL",
+		"limit":  float64(10),
+		"ids":    []any{float64(1), float64(2)},
+	}))
+
+	require.ErrorIs(t, parameters.ValidateValues(map[string]any{"domain": float64(1)}), model.ErrSavedQueryParameterValueTypeInvalid)
+	require.ErrorIs(t, parameters.ValidateValues(map[string]any{"limit": 1.5}), model.ErrSavedQueryParameterValueTypeInvalid)
+	require.ErrorIs(t, parameters.ValidateValues(map[string]any{"ids": []any{"1"}}), model.ErrSavedQueryParameterValueTypeInvalid)
+}
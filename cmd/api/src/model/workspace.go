@@ -0,0 +1,73 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"strconv"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema"
+)
+
+// WorkspaceGraphPrefix is the name prefix of every workspace graph
+const WorkspaceGraphPrefix = "workspace"
+
+// Workspace is an isolated environment within an instance. Every workspace has its own graph that data is ingested into,
+// analyzed in and read from. Only members of a workspace and user administrators may access it.
+type Workspace struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Members     Users  `json:"members" gorm:"many2many:workspaces_users"`
+
+	BigSerial
+}
+
+// WorkspaceGraph returns the schema of the graph that backs the workspace with the given ID
+func WorkspaceGraph(workspaceID int64) graph.Graph {
+	return graphschema.CombinedGraphSchema(WorkspaceGraphPrefix + "_" + strconv.FormatInt(workspaceID, 10))
+}
+
+// Graph returns the schema of the graph that backs this workspace
+func (s Workspace) Graph() graph.Graph {
+	return WorkspaceGraph(s.ID)
+}
+
+// HasMember returns true if the user with the given ID is a member of this workspace
+func (s Workspace) HasMember(user User) bool {
+	for _, member := range s.Members {
+		if member.ID == user.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Workspaces []Workspace
+
+func (s Workspaces) IsSortable(column string) bool {
+	switch column {
+	case "name",
+		"description",
+		"id",
+		"created_at",
+		"updated_at":
+		return true
+	default:
+		return false
+	}
+}
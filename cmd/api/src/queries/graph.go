@@ -21,8 +21,10 @@ package queries
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"sort"
@@ -33,7 +35,10 @@ import (
 
 	"github.com/specterops/bloodhound/dawgs/util"
 
+	"github.com/specterops/bloodhound/cypher/models/cypher"
 	"github.com/specterops/bloodhound/cypher/models/cypher/format"
+	"github.com/specterops/bloodhound/cypher/models/pgsql/visualization"
+	"github.com/specterops/bloodhound/cypher/models/walk"
 	"github.com/specterops/bloodhound/src/config"
 	"github.com/specterops/bloodhound/src/services/agi"
 
@@ -44,6 +49,7 @@ import (
 	"github.com/specterops/bloodhound/cache"
 	"github.com/specterops/bloodhound/cypher/analyzer"
 	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/ops"
 	"github.com/specterops/bloodhound/dawgs/query"
@@ -63,12 +69,23 @@ const (
 	SearchTypeFuzzy SearchType = "fuzzy"
 
 	MaxQueryComplexityWeightAllowed = 50
+
+	// maxSafeJSONInteger is the largest integer that a JSON number decoded as a float64 can represent exactly
+	maxSafeJSONInteger = 1 << 53
 )
 
 var (
 	ErrUnsupportedDataType   = errors.New("unsupported result type for this query")
 	ErrGraphUnsupported      = errors.New("type 'graph' is not supported for this endpoint")
 	ErrCypherQueryTooComplex = errors.New("cypher query is too complex and is likely to result in poor or unstable database performance")
+
+	ErrCypherParameterMissing          = errors.New("missing value for cypher query parameter")
+	ErrCypherParameterUnexpected       = errors.New("value given for a parameter not referenced by the cypher query")
+	ErrCypherParameterValueUnsupported = errors.New("unsupported cypher query parameter value")
+
+	ErrCypherQueryColumnsUnavailable = errors.New("tabular results require a cypher query that returns explicitly named columns")
+
+	ErrAssetGroupSelectorQueryMutation = errors.New("asset group selector cypher queries must be read-only")
 )
 
 type EntityQueryParameters struct {
@@ -139,13 +156,18 @@ type Graph interface {
 	GetEntityByObjectId(ctx context.Context, objectID string, kinds ...graph.Kind) (*graph.Node, error)
 	GetEntityCountResults(ctx context.Context, node *graph.Node, delegates map[string]any) map[string]any
 	GetNodesByKind(ctx context.Context, kinds ...graph.Kind) (graph.NodeSet, error)
-	GetFilteredAndSortedNodes(orderCriteria model.OrderCriteria, filterCriteria graph.Criteria) (graph.NodeSet, error)
+	GetFilteredAndSortedNodes(ctx context.Context, orderCriteria model.OrderCriteria, filterCriteria graph.Criteria) (graph.NodeSet, error)
 	FetchNodesByObjectIDs(ctx context.Context, objectIDs ...string) (graph.NodeSet, error)
 	ValidateOUs(ctx context.Context, ous []string) ([]string, error)
 	BatchNodeUpdate(ctx context.Context, nodeUpdate graph.NodeUpdate) error
 	RawCypherQuery(ctx context.Context, pQuery PreparedQuery, includeProperties bool) (model.UnifiedGraph, error)
-	PrepareCypherQuery(rawCypher string) (PreparedQuery, error)
+	RawCypherTableQuery(ctx context.Context, pQuery PreparedQuery, includeProperties bool, skip, limit int) (model.CypherTable, int, error)
+	PrepareCypherQuery(rawCypher string, parameters map[string]any) (PreparedQuery, error)
+	PrepareCypherQueryExplanation(rawCypher string, parameters map[string]any) (PreparedQuery, error)
+	ExplainCypherQuery(ctx context.Context, pQuery PreparedQuery) (CypherQueryExplanation, error)
 	UpdateSelectorTags(ctx context.Context, db agi.AgiData, selectors model.UpdatedAssetGroupSelectors) error
+	PrepareAssetGroupSelectorQuery(selector model.AssetGroupSelector) (string, map[string]any, error)
+	PreviewAssetGroupSelector(ctx context.Context, selector model.AssetGroupSelector) (graph.NodeSet, error)
 }
 
 type GraphQuery struct {
@@ -361,17 +383,207 @@ func (s *GraphQuery) SearchNodesByName(ctx context.Context, nodeKinds graph.Kind
 
 type PreparedQuery struct {
 	query         string
+	parameters    map[string]any
 	StrippedQuery string
 	complexity    *analyzer.ComplexityMeasure
+	columns       []string
 	HasMutation   bool
 }
 
-func (s *GraphQuery) PrepareCypherQuery(rawCypher string) (PreparedQuery, error) {
+// CypherQueryWeightContribution is the complexity weight added to a cypher query for a single reason
+type CypherQueryWeightContribution struct {
+	Reason string `json:"reason"`
+	Weight int64  `json:"weight"`
+}
+
+// CypherQueryExplanation describes how a prepared cypher query would be run without executing it. The translated SQL
+// and the query plan are only available when the graph database translates cypher to SQL. Rejected is set when the
+// weight of the query exceeds the complexity limit, in which case the query would not be run.
+type CypherQueryExplanation struct {
+	Query           string                          `json:"query"`
+	Weight          int64                           `json:"weight"`
+	Limit           int64                           `json:"limit,omitempty"`
+	Rejected        bool                            `json:"rejected"`
+	Contributions   []CypherQueryWeightContribution `json:"contributions"`
+	TimeoutSeconds  float64                         `json:"timeout_seconds"`
+	ReductionFactor int64                           `json:"reduction_factor"`
+	SQL             string                          `json:"sql,omitempty"`
+	Plan            json.RawMessage                 `json:"plan,omitempty"`
+	PlanPUML        string                          `json:"plan_puml,omitempty"`
+}
+
+// normalizeCypherParameterValue validates that the given parameter value, as decoded from JSON, is of a type that
+// can be safely bound to a cypher query. Whole numbers are converted to integers and homogeneous lists are converted
+// to typed slices so that the value binds to the expected type in each graph driver.
+func normalizeCypherParameterValue(value any) (any, error) {
+	switch typedValue := value.(type) {
+	case string, bool, int64:
+		return value, nil
+
+	case int:
+		return int64(typedValue), nil
+
+	case float64:
+		if typedValue == math.Trunc(typedValue) && math.Abs(typedValue) <= maxSafeJSONInteger {
+			return int64(typedValue), nil
+		}
+
+		return typedValue, nil
+
+	case []any:
+		var (
+			textValues    = make([]string, 0, len(typedValue))
+			integerValues = make([]int64, 0, len(typedValue))
+			numberValues  = make([]float64, 0, len(typedValue))
+		)
+
+		for _, element := range typedValue {
+			if normalizedElement, err := normalizeCypherParameterValue(element); err != nil {
+				return nil, err
+			} else {
+				switch typedElement := normalizedElement.(type) {
+				case string:
+					textValues = append(textValues, typedElement)
+
+				case int64:
+					integerValues = append(integerValues, typedElement)
+					numberValues = append(numberValues, float64(typedElement))
+
+				case float64:
+					numberValues = append(numberValues, typedElement)
+
+				default:
+					return nil, fmt.Errorf("%w: lists may only contain strings or numbers", ErrCypherParameterValueUnsupported)
+				}
+			}
+		}
+
+		switch len(typedValue) {
+		case len(textValues):
+			return textValues, nil
+
+		case len(integerValues):
+			return integerValues, nil
+
+		case len(numberValues):
+			return numberValues, nil
+
+		default:
+			return nil, fmt.Errorf("%w: lists may not mix value types", ErrCypherParameterValueUnsupported)
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrCypherParameterValueUnsupported, value)
+	}
+}
+
+// CypherQueryParameterNames returns the name of each user-specified parameter referenced by the given cypher query
+func CypherQueryParameterNames(queryModel *cypher.RegularQuery) ([]string, error) {
+	var (
+		names = []string{}
+		seen  = map[string]struct{}{}
+	)
+
+	return names, walk.WalkCypher(queryModel, walk.NewSimpleVisitor[cypher.SyntaxNode](func(node cypher.SyntaxNode, errorHandler walk.CancelableErrorHandler) {
+		switch typedNode := node.(type) {
+		case *cypher.Parameter:
+			if _, isSeen := seen[typedNode.Symbol]; !isSeen {
+				seen[typedNode.Symbol] = struct{}{}
+				names = append(names, typedNode.Symbol)
+			}
+		}
+	}))
+}
+
+// cypherQueryColumns returns the name of each column returned by the given cypher query. Projection items are named by
+// their alias when one is given, otherwise by their formatted expression. No columns are returned for queries that do
+// not end in a return clause or that return all variables in scope.
+func (s *GraphQuery) cypherQueryColumns(queryModel *cypher.RegularQuery) ([]string, error) {
+	var singlePartQuery *cypher.SinglePartQuery
+
+	if queryModel.SingleQuery == nil {
+		return nil, nil
+	} else if queryModel.SingleQuery.MultiPartQuery != nil {
+		singlePartQuery = queryModel.SingleQuery.MultiPartQuery.SinglePartQuery
+	} else {
+		singlePartQuery = queryModel.SingleQuery.SinglePartQuery
+	}
+
+	if singlePartQuery == nil || singlePartQuery.Return == nil || singlePartQuery.Return.Projection == nil {
+		return nil, nil
+	}
+
+	columns := make([]string, 0, len(singlePartQuery.Return.Projection.Items))
+
+	for _, item := range singlePartQuery.Return.Projection.Items {
+		var (
+			columnExpression cypher.Expression = item
+			columnBuffer                       = &bytes.Buffer{}
+		)
+
+		if projectionItem, isProjectionItem := item.(*cypher.ProjectionItem); isProjectionItem {
+			if variable, isVariable := projectionItem.Expression.(*cypher.Variable); isVariable && variable.Symbol == cypher.TokenLiteralAsterisk {
+				return nil, nil
+			} else if projectionItem.Binding != nil {
+				columnExpression = projectionItem.Binding
+			} else {
+				columnExpression = projectionItem.Expression
+			}
+		}
+
+		if err := s.cypherEmitter.WriteExpression(columnBuffer, columnExpression); err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, columnBuffer.String())
+	}
+
+	return columns, nil
+}
+
+// bindCypherParameters validates the given parameter values against the parameters referenced by the given cypher
+// query. Every referenced parameter must have a value and every value must be referenced by the query.
+func bindCypherParameters(queryModel *cypher.RegularQuery, parameters map[string]any) (map[string]any, error) {
+	boundParameters := make(map[string]any, len(parameters))
+
+	if parameterNames, err := CypherQueryParameterNames(queryModel); err != nil {
+		return nil, err
+	} else {
+		for _, name := range parameterNames {
+			if value, hasValue := parameters[name]; !hasValue {
+				return nil, fmt.Errorf("%w: $%s", ErrCypherParameterMissing, name)
+			} else if normalizedValue, err := normalizeCypherParameterValue(value); err != nil {
+				return nil, fmt.Errorf("parameter $%s: %w", name, err)
+			} else {
+				boundParameters[name] = normalizedValue
+			}
+		}
+	}
+
+	for name := range parameters {
+		if _, isBound := boundParameters[name]; !isBound {
+			return nil, fmt.Errorf("%w: $%s", ErrCypherParameterUnexpected, name)
+		}
+	}
+
+	return boundParameters, nil
+}
+
+func (s *GraphQuery) PrepareCypherQuery(rawCypher string, parameters map[string]any) (PreparedQuery, error) {
+	return s.prepareCypherQuery(rawCypher, parameters, !s.DisableCypherComplexityLimit)
+}
+
+// PrepareCypherQueryExplanation prepares the given query for ExplainCypherQuery. Unlike PrepareCypherQuery, queries that
+// exceed the complexity limit are not rejected so that their weight can still be explained.
+func (s *GraphQuery) PrepareCypherQueryExplanation(rawCypher string, parameters map[string]any) (PreparedQuery, error) {
+	return s.prepareCypherQuery(rawCypher, parameters, false)
+}
+
+func (s *GraphQuery) prepareCypherQuery(rawCypher string, parameters map[string]any, limitComplexity bool) (PreparedQuery, error) {
 	var (
 		cypherFilters = []frontend.Visitor{
 			&frontend.ExplicitProcedureInvocationFilter{},
 			&frontend.ImplicitProcedureInvocationFilter{},
-			&frontend.SpecifiedParametersFilter{},
 		}
 		queryBuffer         = &bytes.Buffer{}
 		strippedQueryBuffer = &bytes.Buffer{}
@@ -392,12 +604,16 @@ func (s *GraphQuery) PrepareCypherQuery(rawCypher string) (PreparedQuery, error)
 
 	graphQuery.HasMutation = parseCtx.HasMutation
 
+	if graphQuery.parameters, err = bindCypherParameters(queryModel, parameters); err != nil {
+		return graphQuery, err
+	}
+
 	complexityMeasure, err := analyzer.QueryComplexity(queryModel)
 	if err != nil {
 		return graphQuery, err
 	} else if err = s.strippedCypherEmitter.Write(queryModel, strippedQueryBuffer); err != nil {
 		return graphQuery, err
-	} else if !s.DisableCypherComplexityLimit && complexityMeasure.Weight > MaxQueryComplexityWeightAllowed {
+	} else if limitComplexity && complexityMeasure.Weight > MaxQueryComplexityWeightAllowed {
 		// log query details if it is rejected due to high complexity
 		highComplexityLog := log.WithLevel(log.LevelError)
 		highComplexityLog.Str("query", strippedQueryBuffer.String())
@@ -409,7 +625,9 @@ func (s *GraphQuery) PrepareCypherQuery(rawCypher string) (PreparedQuery, error)
 	graphQuery.StrippedQuery = strippedQueryBuffer.String()
 	graphQuery.complexity = complexityMeasure
 
-	if err = s.cypherEmitter.Write(queryModel, queryBuffer); err != nil {
+	if graphQuery.columns, err = s.cypherQueryColumns(queryModel); err != nil {
+		return graphQuery, err
+	} else if err = s.cypherEmitter.Write(queryModel, queryBuffer); err != nil {
 		return graphQuery, err
 	} else {
 		graphQuery.query = queryBuffer.String()
@@ -418,52 +636,106 @@ func (s *GraphQuery) PrepareCypherQuery(rawCypher string) (PreparedQuery, error)
 	return graphQuery, nil
 }
 
-func (s *GraphQuery) RawCypherQuery(ctx context.Context, pQuery PreparedQuery, includeProperties bool) (model.UnifiedGraph, error) {
-	var (
-		err error
+// PrepareAssetGroupSelectorQuery validates the cypher query of the given asset group selector and returns the query
+// and the parameters to run it with. Queries that mutate the graph are rejected regardless of whether cypher mutations
+// are enabled.
+func (s *GraphQuery) PrepareAssetGroupSelectorQuery(selector model.AssetGroupSelector) (string, map[string]any, error) {
+	if preparedQuery, err := s.PrepareCypherQuery(selector.Selector, selector.Parameters); err != nil {
+		return "", nil, err
+	} else if preparedQuery.HasMutation {
+		return "", nil, ErrAssetGroupSelectorQueryMutation
+	} else {
+		return preparedQuery.query, preparedQuery.parameters, nil
+	}
+}
 
-		graphResponse = model.NewUnifiedGraph()
-		bhCtxInst     = bhCtx.Get(ctx)
-	)
+// PreviewAssetGroupSelector evaluates the given asset group selector without saving it and returns the nodes that it
+// would select
+func (s *GraphQuery) PreviewAssetGroupSelector(ctx context.Context, selector model.AssetGroupSelector) (graph.NodeSet, error) {
+	var nodes graph.NodeSet
+
+	err := s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if selectorNodes, err := agi.FetchSelectorNodes(tx, s, selector); err != nil {
+			return err
+		} else {
+			nodes = selectorNodes
+			return nil
+		}
+	})
+
+	return nodes, err
+}
+
+func (s *GraphQuery) RawCypherQuery(ctx context.Context, pQuery PreparedQuery, includeProperties bool) (model.UnifiedGraph, error) {
+	graphResponse := model.NewUnifiedGraph()
 
-	txDelegate := func(tx graph.Transaction) error {
-		if pathSet, err := ops.FetchPathSetByQuery(tx, pQuery.query); err != nil {
+	err := s.runCypherQuery(ctx, pQuery, "RawCypherQuery", func(tx graph.Transaction) error {
+		if pathSet, err := ops.FetchPathSetByQuery(tx, pQuery.query, pQuery.parameters); err != nil {
 			return err
 		} else {
 			graphResponse.AddPathSet(pathSet, includeProperties)
 		}
 
 		return nil
-	}
+	})
 
-	txOptions := func(config *graph.TransactionConfig) {
-		// The upperbound for this query must be either the custom request timeout (capped at maxRuntime
-		// below), or if it isn't supplied then 15 minutes - since longer timeouts may call OOM kills.
-		var (
-			maxTimeout     = 30 * time.Minute
-			defaultTimeout = 15 * time.Minute
-		)
+	return graphResponse, err
+}
 
-		if bhCtxInst.Timeout > maxTimeout {
-			log.Debugf("Custom timeout is too large, using the maximum allowable timeout of %d minutes instead", maxTimeout.Minutes())
-			bhCtxInst.Timeout = maxTimeout
+// RawCypherTableQuery runs the given prepared query and returns its result as a table of named columns. Rows are paged
+// by skip and limit, where a limit of 0 returns all remaining rows. The total number of rows returned by the query is
+// returned alongside the table.
+func (s *GraphQuery) RawCypherTableQuery(ctx context.Context, pQuery PreparedQuery, includeProperties bool, skip, limit int) (model.CypherTable, int, error) {
+	var (
+		table = model.CypherTable{
+			Columns: pQuery.columns,
+			Rows:    [][]any{},
 		}
+		count int
+	)
 
-		availableRuntime := bhCtxInst.Timeout
-		if availableRuntime > 0 {
-			log.Debugf("Available timeout for query is set to: %d seconds", availableRuntime.Seconds())
+	if len(pQuery.columns) == 0 {
+		return table, count, ErrCypherQueryColumnsUnavailable
+	}
+
+	err := s.runCypherQuery(ctx, pQuery, "RawCypherTableQuery", func(tx graph.Transaction) error {
+		if rows, rowCount, err := ops.FetchRowsByQuery(tx, pQuery.query, pQuery.parameters, skip, limit); err != nil {
+			return err
 		} else {
-			availableRuntime = defaultTimeout
-			if !s.DisableCypherComplexityLimit {
-				var reductionFactor int64
-				availableRuntime, reductionFactor = applyTimeoutReduction(pQuery.complexity.Weight, availableRuntime)
-
-				logEvent := log.WithLevel(log.LevelInfo)
-				logEvent.Str("query", pQuery.StrippedQuery)
-				logEvent.Str("query cost", fmt.Sprintf("%d", pQuery.complexity.Weight))
-				logEvent.Str("reduction factor", strconv.FormatInt(reductionFactor, 10))
-				logEvent.Msg(fmt.Sprintf("Available timeout for query is set to: %.2f seconds", availableRuntime.Seconds()))
+			table = model.NewCypherTable(pQuery.columns, rows, includeProperties)
+			count = rowCount
+		}
+
+		return nil
+	})
+
+	return table, count, err
+}
+
+// runCypherQuery runs the given transaction delegate for a prepared user cypher query with a timeout derived from the
+// query's complexity. Mutations are run in a write transaction.
+func (s *GraphQuery) runCypherQuery(ctx context.Context, pQuery PreparedQuery, operation string, txDelegate graph.TransactionDelegate) error {
+	var (
+		err error
+
+		bhCtxInst = bhCtx.Get(ctx)
+	)
+
+	txOptions := func(config *graph.TransactionConfig) {
+		availableRuntime, reductionFactor := s.cypherQueryTimeout(bhCtxInst.Timeout, pQuery.complexity.Weight)
+
+		if bhCtxInst.Timeout > 0 {
+			if bhCtxInst.Timeout > availableRuntime {
+				log.Debugf("Custom timeout is too large, using the maximum allowable timeout of %d minutes instead", availableRuntime.Minutes())
 			}
+
+			log.Debugf("Available timeout for query is set to: %d seconds", availableRuntime.Seconds())
+		} else if !s.DisableCypherComplexityLimit {
+			logEvent := log.WithLevel(log.LevelInfo)
+			logEvent.Str("query", pQuery.StrippedQuery)
+			logEvent.Str("query cost", fmt.Sprintf("%d", pQuery.complexity.Weight))
+			logEvent.Str("reduction factor", strconv.FormatInt(reductionFactor, 10))
+			logEvent.Msg(fmt.Sprintf("Available timeout for query is set to: %.2f seconds", availableRuntime.Seconds()))
 		}
 
 		// Set the timeout for this DB interaction
@@ -494,12 +766,108 @@ func (s *GraphQuery) RawCypherQuery(ctx context.Context, pQuery PreparedQuery, i
 			timeoutLog.Str("query cost", fmt.Sprintf("%d", pQuery.complexity.Weight))
 			timeoutLog.Msg("Neo4j timed out while executing cypher query")
 		} else {
-			log.Warnf("RawCypherQuery failed: %v", err)
+			log.Warnf("%s failed: %v", operation, err)
 		}
-		return graphResponse, err
 	}
 
-	return graphResponse, nil
+	return err
+}
+
+// cypherQueryTimeout returns the timeout and reduction factor for a cypher query with the given complexity weight. The
+// upperbound for a query must be either the custom request timeout, capped at 30 minutes, or if it isn't supplied then
+// 15 minutes - since longer timeouts may cause OOM kills. The default timeout is reduced by the complexity of the query
+// unless the complexity limit has been disabled.
+func (s *GraphQuery) cypherQueryTimeout(requestedTimeout time.Duration, queryWeight int64) (time.Duration, int64) {
+	const (
+		maxTimeout     = 30 * time.Minute
+		defaultTimeout = 15 * time.Minute
+	)
+
+	if requestedTimeout > maxTimeout {
+		return maxTimeout, 1
+	} else if requestedTimeout > 0 {
+		return requestedTimeout, 1
+	} else if s.DisableCypherComplexityLimit {
+		return defaultTimeout, 1
+	}
+
+	return applyTimeoutReduction(queryWeight, defaultTimeout)
+}
+
+// ExplainCypherQuery reports the complexity breakdown and timeout of the given prepared query and whether the query
+// would be rejected for exceeding the complexity limit. When the graph database translates cypher to SQL the translated
+// statement and its PostgreSQL query plan are included as well. The query itself is never executed.
+func (s *GraphQuery) ExplainCypherQuery(ctx context.Context, pQuery PreparedQuery) (CypherQueryExplanation, error) {
+	explanation := CypherQueryExplanation{
+		Query:         pQuery.StrippedQuery,
+		Contributions: []CypherQueryWeightContribution{},
+	}
+
+	if pQuery.complexity != nil {
+		explanation.Weight = pQuery.complexity.Weight
+
+		for _, contribution := range pQuery.complexity.Contributions {
+			explanation.Contributions = append(explanation.Contributions, CypherQueryWeightContribution{
+				Reason: contribution.Reason,
+				Weight: contribution.Weight,
+			})
+		}
+	}
+
+	if !s.DisableCypherComplexityLimit {
+		explanation.Limit = MaxQueryComplexityWeightAllowed
+		explanation.Rejected = explanation.Weight > MaxQueryComplexityWeightAllowed
+	}
+
+	timeout, reductionFactor := s.cypherQueryTimeout(bhCtx.Get(ctx).Timeout, explanation.Weight)
+	explanation.TimeoutSeconds = timeout.Seconds()
+	explanation.ReductionFactor = reductionFactor
+
+	return explanation, s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		translator, canTranslate := tx.(pg.CypherTranslator)
+		if !canTranslate {
+			return nil
+		}
+
+		sqlQuery, sqlParameters, err := translator.TranslateCypher(pQuery.query, pQuery.parameters)
+		if err != nil {
+			return err
+		}
+
+		explanation.SQL = sqlQuery
+
+		var (
+			rawPlan []byte
+			result  = tx.Raw("explain (format json) "+sqlQuery, sqlParameters)
+		)
+
+		defer result.Close()
+
+		if !result.Next() {
+			if err := result.Error(); err != nil {
+				return err
+			}
+
+			return visualization.ErrEmptyExplainPlan
+		} else if err := result.Scan(&rawPlan); err != nil {
+			return err
+		}
+
+		planPUML := &bytes.Buffer{}
+
+		if planGraph, err := visualization.ExplainPlanToDigraph(rawPlan); err != nil {
+			return err
+		} else if err := visualization.GraphToPUMLDigraph(planGraph, planPUML); err != nil {
+			return err
+		}
+
+		explanation.Plan = rawPlan
+		explanation.PlanPUML = planPUML.String()
+
+		return result.Error()
+	}, func(config *graph.TransactionConfig) {
+		config.Timeout = timeout
+	})
 }
 
 func applyTimeoutReduction(queryWeight int64, availableRuntime time.Duration) (time.Duration, int64) {
@@ -673,10 +1041,10 @@ func (s *GraphQuery) GetNodesByKind(ctx context.Context, kinds ...graph.Kind) (g
 	})
 }
 
-func (s *GraphQuery) GetFilteredAndSortedNodes(orderCriteria model.OrderCriteria, filterCriteria graph.Criteria) (graph.NodeSet, error) {
+func (s *GraphQuery) GetFilteredAndSortedNodes(ctx context.Context, orderCriteria model.OrderCriteria, filterCriteria graph.Criteria) (graph.NodeSet, error) {
 	var nodes graph.NodeSet
 
-	if err := s.Graph.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+	if err := s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
 		nodeQuery := tx.Nodes().Filterf(func() graph.Criteria {
 			return filterCriteria
 		})
@@ -827,10 +1195,22 @@ func runEntityQuery(ctx context.Context, db graph.Database, delegate any, node *
 	return result, nil
 }
 
+// entityQueryCacheKey returns the cache key of the given entity query. Keys of queries scoped to a graph target include
+// the name of that graph so that results are never shared between graphs.
+func entityQueryCacheKey(ctx context.Context, params EntityQueryParameters) string {
+	cacheKey := fmt.Sprintf("ad-entity-query_%s_%s_%d", params.QueryName, params.ObjectID, params.RequestedType)
+
+	if target, hasTarget := graph.GraphTargetFromContext(ctx); hasTarget {
+		cacheKey = target.Name + "_" + cacheKey
+	}
+
+	return cacheKey
+}
+
 func (s *GraphQuery) runMaybeCachedEntityQuery(ctx context.Context, node *graph.Node, params EntityQueryParameters, cacheEnabled bool) (graph.NodeSet, error) {
 	var (
 		queryStart = time.Now()
-		cacheKey   = fmt.Sprintf("ad-entity-query_%s_%s_%d", params.QueryName, params.ObjectID, params.RequestedType)
+		cacheKey   = entityQueryCacheKey(ctx, params)
 
 		foundResultInCache = false
 
@@ -974,6 +1354,21 @@ func (s *GraphQuery) UpdateSelectorTags(ctx context.Context, db agi.AgiData, sel
 	return nil
 }
 
+// fetchSelectorTagNodes returns the nodes that the given selector tags. Object ID selectors must select an existing node.
+func fetchSelectorTagNodes(tx graph.Transaction, graphQuery *GraphQuery, selector model.AssetGroupSelector) ([]*graph.Node, error) {
+	if selector.SelectorType() == model.AssetGroupSelectorTypeObjectID {
+		if node, err := analysis.FetchNodeByObjectID(tx, selector.Selector); err != nil {
+			return nil, err
+		} else {
+			return []*graph.Node{node}, nil
+		}
+	} else if nodes, err := agi.FetchSelectorNodes(tx, graphQuery, selector); err != nil {
+		return nil, err
+	} else {
+		return nodes.Slice(), nil
+	}
+}
+
 func addTagsToSelector(ctx context.Context, graphQuery *GraphQuery, db agi.AgiData, selector model.AssetGroupSelector) error {
 	if assetGroup, err := db.GetAssetGroup(ctx, selector.AssetGroupID); err != nil {
 		return err
@@ -985,25 +1380,27 @@ func addTagsToSelector(ctx context.Context, graphQuery *GraphQuery, db agi.AgiDa
 				tagPropertyStr = common.UserTags.String()
 			}
 
-			if node, err := analysis.FetchNodeByObjectID(tx, selector.Selector); err != nil {
+			if nodes, err := fetchSelectorTagNodes(tx, graphQuery, selector); err != nil {
 				return err
 			} else {
-				if tags, err := node.Properties.Get(tagPropertyStr).String(); err != nil {
-					if graph.IsErrPropertyNotFound(err) {
-						node.Properties.Set(tagPropertyStr, assetGroup.Tag)
-					} else {
-						return err
-					}
-				} else if !strings.Contains(tags, assetGroup.Tag) {
-					if len(tags) == 0 {
-						node.Properties.Set(tagPropertyStr, assetGroup.Tag)
-					} else { // add a space and append if there are existing tags
-						node.Properties.Set(tagPropertyStr, tags+" "+assetGroup.Tag)
+				for _, node := range nodes {
+					if tags, err := node.Properties.Get(tagPropertyStr).String(); err != nil {
+						if graph.IsErrPropertyNotFound(err) {
+							node.Properties.Set(tagPropertyStr, assetGroup.Tag)
+						} else {
+							return err
+						}
+					} else if !strings.Contains(tags, assetGroup.Tag) {
+						if len(tags) == 0 {
+							node.Properties.Set(tagPropertyStr, assetGroup.Tag)
+						} else { // add a space and append if there are existing tags
+							node.Properties.Set(tagPropertyStr, tags+" "+assetGroup.Tag)
+						}
 					}
-				}
 
-				if err = tx.UpdateNode(node); err != nil {
-					return err
+					if err = tx.UpdateNode(node); err != nil {
+						return err
+					}
 				}
 			}
 
@@ -1023,23 +1420,25 @@ func removeTagsFromSelector(ctx context.Context, graphQuery *GraphQuery, db agi.
 				tagPropertyStr = common.UserTags.String()
 			}
 
-			if node, err := analysis.FetchNodeByObjectID(tx, selector.Selector); err != nil {
+			if nodes, err := fetchSelectorTagNodes(tx, graphQuery, selector); err != nil {
 				return err
 			} else {
-				if tags, err := node.Properties.Get(tagPropertyStr).String(); err != nil {
-					if graph.IsErrPropertyNotFound(err) {
-						node.Properties.Set(tagPropertyStr, assetGroup.Tag)
-					} else {
-						return err
+				for _, node := range nodes {
+					if tags, err := node.Properties.Get(tagPropertyStr).String(); err != nil {
+						if graph.IsErrPropertyNotFound(err) {
+							node.Properties.Set(tagPropertyStr, assetGroup.Tag)
+						} else {
+							return err
+						}
+					} else if strings.Contains(tags, assetGroup.Tag) {
+						// remove asset group tag and then remove any leftover double whitespace
+						tags = strings.ReplaceAll(strings.ReplaceAll(tags, assetGroup.Tag, ""), "  ", " ")
+						node.Properties.Set(tagPropertyStr, tags)
 					}
-				} else if strings.Contains(tags, assetGroup.Tag) {
-					// remove asset group tag and then remove any leftover double whitespace
-					tags = strings.ReplaceAll(strings.ReplaceAll(tags, assetGroup.Tag, ""), "  ", " ")
-					node.Properties.Set(tagPropertyStr, tags)
-				}
 
-				if err = tx.UpdateNode(node); err != nil {
-					return err
+					if err = tx.UpdateNode(node); err != nil {
+						return err
+					}
 				}
 			}
 
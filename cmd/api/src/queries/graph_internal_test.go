@@ -66,6 +66,28 @@ func Test_ApplyTimeoutReduction(t *testing.T) {
 	}
 }
 
+func Test_cypherQueryTimeout(t *testing.T) {
+	var (
+		graphQuery          = &GraphQuery{}
+		unlimitedGraphQuery = &GraphQuery{DisableCypherComplexityLimit: true}
+	)
+
+	timeout, reductionFactor := graphQuery.cypherQueryTimeout(0, 15)
+	require.Equal(t, 225*time.Second, timeout)
+	require.Equal(t, int64(4), reductionFactor)
+
+	timeout, reductionFactor = graphQuery.cypherQueryTimeout(5*time.Second, 15)
+	require.Equal(t, 5*time.Second, timeout)
+	require.Equal(t, int64(1), reductionFactor)
+
+	timeout, _ = graphQuery.cypherQueryTimeout(time.Hour, 15)
+	require.Equal(t, 30*time.Minute, timeout)
+
+	timeout, reductionFactor = unlimitedGraphQuery.cypherQueryTimeout(0, 15)
+	require.Equal(t, 15*time.Minute, timeout)
+	require.Equal(t, int64(1), reductionFactor)
+}
+
 const cacheKey = "ad-entity-query_queryName_objectID_1"
 
 func Test_runMaybeCachedEntityQuery(t *testing.T) {
@@ -259,3 +281,18 @@ func Test_nodesToSearchResult_default(t *testing.T) {
 	require.Equal(t, expectedObjectId, actual[0].ObjectID)
 	require.Equal(t, expectedDistinguishedName, actual[0].DistinguishedName)
 }
+
+func Test_EntityQueryCacheKey(t *testing.T) {
+	var (
+		params = EntityQueryParameters{
+			QueryName:     "Sessions",
+			ObjectID:      "S-1-5-21-1",
+			RequestedType: model.DataTypeList,
+		}
+		defaultKey   = entityQueryCacheKey(context.Background(), params)
+		workspaceKey = entityQueryCacheKey(graph.WithGraphTarget(context.Background(), graph.Graph{Name: "workspace_1"}), params)
+	)
+
+	require.Equal(t, fmt.Sprintf("ad-entity-query_Sessions_S-1-5-21-1_%d", model.DataTypeList), defaultKey)
+	require.Equal(t, "workspace_1_"+defaultKey, workspaceKey)
+}
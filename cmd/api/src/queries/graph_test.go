@@ -51,35 +51,108 @@ func TestGraphQuery_PrepareCypherQuery(t *testing.T) {
 		rawCypherRead     = "MATCH (n:Label) return n"
 		rawCypherMutation = "DETACH DELETE (n:Label)"
 		rawCypherInvalid  = "derp"
+
+		rawCypherParameterized = "match (n:Label) where n.domain = $domain and n.count > $threshold return n"
 	)
 
 	t.Run("invalid cypher", func(t *testing.T) {
-		_, err := gq.PrepareCypherQuery(rawCypherInvalid)
+		_, err := gq.PrepareCypherQuery(rawCypherInvalid, nil)
 		assert.ErrorContains(t, err, "mismatched input 'derp'")
 	})
 
 	t.Run("valid cypher with mutation while mutations disabled", func(t *testing.T) {
-		_, err := gqMutDisable.PrepareCypherQuery(rawCypherMutation)
+		_, err := gqMutDisable.PrepareCypherQuery(rawCypherMutation, nil)
 		assert.ErrorContains(t, err, "not supported")
 	})
 
 	t.Run("valid cypher without mutation", func(t *testing.T) {
-		preparedQuery, err := gq.PrepareCypherQuery(rawCypherRead)
+		preparedQuery, err := gq.PrepareCypherQuery(rawCypherRead, nil)
 		require.Nil(t, err)
 		assert.Equal(t, preparedQuery.HasMutation, false)
 	})
 
 	t.Run("valid cypher with mutation", func(t *testing.T) {
-		preparedQuery, err := gq.PrepareCypherQuery(rawCypherMutation)
+		preparedQuery, err := gq.PrepareCypherQuery(rawCypherMutation, nil)
 		require.Nil(t, err)
 		assert.Equal(t, preparedQuery.HasMutation, true)
 	})
 
 	t.Run("valid cypher without mutation while mutations disabled", func(t *testing.T) {
-		preparedQuery, err := gq.PrepareCypherQuery(rawCypherRead)
+		preparedQuery, err := gq.PrepareCypherQuery(rawCypherRead, nil)
 		require.Nil(t, err)
 		assert.Equal(t, preparedQuery.HasMutation, false)
 	})
+
+	t.Run("parameterized cypher", func(t *testing.T) {
+		_, err := gq.PrepareCypherQuery(rawCypherParameterized, map[string]any{
+			"domain":    "TESTLAB.LOCAL",
+			"threshold": float64(10),
+		})
+		require.Nil(t, err)
+	})
+
+	t.Run("parameterized cypher with missing parameter", func(t *testing.T) {
+		_, err := gq.PrepareCypherQuery(rawCypherParameterized, map[string]any{
+			"domain": "TESTLAB.LOCAL",
+		})
+		assert.ErrorIs(t, err, queries.ErrCypherParameterMissing)
+	})
+
+	t.Run("parameterized cypher with unexpected parameter", func(t *testing.T) {
+		_, err := gq.PrepareCypherQuery(rawCypherRead, map[string]any{
+			"domain": "TESTLAB.LOCAL",
+		})
+		assert.ErrorIs(t, err, queries.ErrCypherParameterUnexpected)
+	})
+
+	t.Run("parameterized cypher with unsupported parameter value", func(t *testing.T) {
+		_, err := gq.PrepareCypherQuery(rawCypherParameterized, map[string]any{
+			"domain":    map[string]any{"name": "TESTLAB.LOCAL"},
+			"threshold": float64(10),
+		})
+		assert.ErrorIs(t, err, queries.ErrCypherParameterValueUnsupported)
+
+		_, err = gq.PrepareCypherQuery(rawCypherParameterized, map[string]any{
+			"domain":    []any{"TESTLAB.LOCAL", float64(1)},
+			"threshold": float64(10),
+		})
+		assert.ErrorIs(t, err, queries.ErrCypherParameterValueUnsupported)
+	})
+}
+
+func TestGraphQuery_PrepareAssetGroupSelectorQuery(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{EnableCypherMutations: true})
+	)
+
+	t.Run("read-only cypher", func(t *testing.T) {
+		preparedQuery, parameters, err := gq.PrepareAssetGroupSelectorQuery(model.AssetGroupSelector{
+			Type:       model.AssetGroupSelectorTypeCypher,
+			Selector:   "match (n:Computer) where n.operatingsystem = $os return n",
+			Parameters: map[string]any{"os": "WINDOWS SERVER 2022"},
+		})
+		require.Nil(t, err)
+		assert.NotEmpty(t, preparedQuery)
+		assert.Equal(t, map[string]any{"os": "WINDOWS SERVER 2022"}, parameters)
+	})
+
+	t.Run("cypher with mutation while mutations enabled", func(t *testing.T) {
+		_, _, err := gq.PrepareAssetGroupSelectorQuery(model.AssetGroupSelector{
+			Type:     model.AssetGroupSelectorTypeCypher,
+			Selector: "match (n:Computer) set n.name = 'owned' return n",
+		})
+		assert.ErrorIs(t, err, queries.ErrAssetGroupSelectorQueryMutation)
+	})
+
+	t.Run("cypher with missing parameter", func(t *testing.T) {
+		_, _, err := gq.PrepareAssetGroupSelectorQuery(model.AssetGroupSelector{
+			Type:     model.AssetGroupSelectorTypeCypher,
+			Selector: "match (n:Computer) where n.operatingsystem = $os return n",
+		})
+		assert.ErrorIs(t, err, queries.ErrCypherParameterMissing)
+	})
 }
 
 func TestGraphQuery_RawCypherQuery(t *testing.T) {
@@ -121,7 +194,7 @@ func TestGraphQuery_RawCypherQuery(t *testing.T) {
 			return nil
 		})
 
-		preparedQuery, err := gq.PrepareCypherQuery("match (n:Label) return n;")
+		preparedQuery, err := gq.PrepareCypherQuery("match (n:Label) return n;", nil)
 		require.Nil(t, err)
 
 		_, err = gq.RawCypherQuery(outerBHCtxInst.ConstructGoContext(), preparedQuery, false)
@@ -171,7 +244,7 @@ func TestGraphQuery_RawCypherQuery(t *testing.T) {
 		// Therefore actual timeout = availableRuntime/reductionFactor : 900/4 = 225sec
 
 		outerBHCtxInst.Timeout = 0
-		preparedQuery, err := gq.PrepareCypherQuery("match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;")
+		preparedQuery, err := gq.PrepareCypherQuery("match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;", nil)
 		require.Nil(t, err)
 		_, err = gq.RawCypherQuery(outerBHCtxInst.ConstructGoContext(), preparedQuery, false)
 		require.Nil(t, err)
@@ -181,7 +254,7 @@ func TestGraphQuery_RawCypherQuery(t *testing.T) {
 		// This will be directly used as the config timeout, without any reduction factor
 		outerBHCtxInst.Timeout = time.Second * 5
 
-		preparedQuery, err = gq.PrepareCypherQuery("match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;")
+		preparedQuery, err = gq.PrepareCypherQuery("match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;", nil)
 		require.Nil(t, err)
 
 		_, err = gq.RawCypherQuery(outerBHCtxInst.ConstructGoContext(), preparedQuery, false)
@@ -192,7 +265,7 @@ func TestGraphQuery_RawCypherQuery(t *testing.T) {
 		mockGraphDB.EXPECT().WriteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 		mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
-		preparedQuery, err := gq.PrepareCypherQuery("match (b) where b.name = 'harley' return b;")
+		preparedQuery, err := gq.PrepareCypherQuery("match (b) where b.name = 'harley' return b;", nil)
 		require.Nil(t, err)
 
 		_, err = gq.RawCypherQuery(outerBHCtxInst.ConstructGoContext(), preparedQuery, false)
@@ -204,7 +277,7 @@ func TestGraphQuery_RawCypherQuery(t *testing.T) {
 		mockGraphDB.EXPECT().WriteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
 		qgWMut := queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{EnableCypherMutations: true})
-		preparedQuery, err := qgWMut.PrepareCypherQuery("match (b) where b.name = 'bruce' remove b.prop return b;")
+		preparedQuery, err := qgWMut.PrepareCypherQuery("match (b) where b.name = 'bruce' remove b.prop return b;", nil)
 		require.Nil(t, err)
 
 		_, err = qgWMut.RawCypherQuery(outerBHCtxInst.ConstructGoContext(), preparedQuery, false)
@@ -212,6 +285,170 @@ func TestGraphQuery_RawCypherQuery(t *testing.T) {
 	})
 }
 
+func TestGraphQuery_RawCypherTableQuery(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		ctx         = (&bhCtx.Context{StartTime: time.Now()}).ConstructGoContext()
+	)
+
+	t.Run("RawCypherTableQuery requires named columns", func(t *testing.T) {
+		preparedQuery, err := gq.PrepareCypherQuery("match (n:User) return *", nil)
+		require.Nil(t, err)
+
+		_, _, err = gq.RawCypherTableQuery(ctx, preparedQuery, false, 0, 10)
+		require.ErrorIs(t, err, queries.ErrCypherQueryColumnsUnavailable)
+	})
+
+	t.Run("RawCypherTableQuery returns named columns and paged rows", func(t *testing.T) {
+		var (
+			mockTx     = graphMocks.NewMockTransaction(mockCtrl)
+			mockResult = graphMocks.NewMockResult(mockCtrl)
+			mockValues = graphMocks.NewMockValueMapper(mockCtrl)
+		)
+
+		gomock.InOrder(
+			mockResult.EXPECT().Next().Return(true),
+			mockResult.EXPECT().Next().Return(true),
+			mockResult.EXPECT().Next().Return(true),
+			mockResult.EXPECT().Next().Return(false),
+		)
+
+		mockResult.EXPECT().Error().Return(nil).Times(2)
+		mockResult.EXPECT().Values().Return(mockValues, nil).Times(1)
+		mockResult.EXPECT().Close()
+		mockValues.EXPECT().Decode().Return([]any{"harley", int64(2)}, nil)
+
+		mockTx.EXPECT().Query(gomock.Any(), gomock.Any()).Return(mockResult)
+		mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+			return txDelegate(mockTx)
+		})
+
+		preparedQuery, err := gq.PrepareCypherQuery("match (n:User) return n.name as name, count(n.sessions)", nil)
+		require.Nil(t, err)
+
+		table, count, err := gq.RawCypherTableQuery(ctx, preparedQuery, false, 1, 1)
+		require.Nil(t, err)
+		require.Equal(t, 3, count)
+		require.Equal(t, []string{"name", "count(n.sessions)"}, table.Columns)
+		require.Equal(t, [][]any{{"harley", int64(2)}}, table.Rows)
+	})
+}
+
+type translatingTransaction struct {
+	*graphMocks.MockTransaction
+}
+
+func (s translatingTransaction) TranslateCypher(query string, parameters map[string]any) (string, map[string]any, error) {
+	return "select 1 where @pi0 = 'harley';", map[string]any{"pi0": parameters["name"]}, nil
+}
+
+func TestGraphQuery_ExplainCypherQuery(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		bhCtxInst   = &bhCtx.Context{
+			StartTime: time.Now(),
+			RequestID: must.NewUUIDv4().String(),
+			AuthCtx:   auth.Context{},
+			Host: &url.URL{
+				Scheme: "http",
+				Host:   "example.com",
+			},
+		}
+	)
+
+	t.Run("ExplainCypherQuery reports complexity and timeout without translation support", func(t *testing.T) {
+		mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+			txConfig := &graph.TransactionConfig{}
+			options[0](txConfig)
+
+			require.Equal(t, time.Second*225, txConfig.Timeout)
+
+			// Transactions that do not translate cypher must not be queried
+			return txDelegate(graphMocks.NewMockTransaction(mockCtrl))
+		})
+
+		preparedQuery, err := gq.PrepareCypherQuery("match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;", nil)
+		require.Nil(t, err)
+
+		explanation, err := gq.ExplainCypherQuery(bhCtxInst.ConstructGoContext(), preparedQuery)
+		require.Nil(t, err)
+
+		var contributionTotal int64
+		for _, contribution := range explanation.Contributions {
+			contributionTotal += contribution.Weight
+		}
+
+		require.Equal(t, int64(15), explanation.Weight)
+		require.Equal(t, explanation.Weight, contributionTotal)
+		require.Equal(t, int64(queries.MaxQueryComplexityWeightAllowed), explanation.Limit)
+		require.False(t, explanation.Rejected)
+		require.Equal(t, float64(225), explanation.TimeoutSeconds)
+		require.Equal(t, int64(4), explanation.ReductionFactor)
+		require.Empty(t, explanation.SQL)
+		require.Nil(t, explanation.Plan)
+	})
+
+	t.Run("ExplainCypherQuery reports the weight of queries that are too complex to run", func(t *testing.T) {
+		const tooComplexQuery = "match p = ()-[*..]->()-[*..]->()-[*..]->() return p"
+
+		mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+			return txDelegate(graphMocks.NewMockTransaction(mockCtrl))
+		})
+
+		_, err := gq.PrepareCypherQuery(tooComplexQuery, nil)
+		require.ErrorIs(t, err, queries.ErrCypherQueryTooComplex)
+
+		preparedQuery, err := gq.PrepareCypherQueryExplanation(tooComplexQuery, nil)
+		require.Nil(t, err)
+
+		explanation, err := gq.ExplainCypherQuery(bhCtxInst.ConstructGoContext(), preparedQuery)
+		require.Nil(t, err)
+
+		require.Greater(t, explanation.Weight, int64(queries.MaxQueryComplexityWeightAllowed))
+		require.NotEmpty(t, explanation.Contributions)
+		require.Equal(t, int64(queries.MaxQueryComplexityWeightAllowed), explanation.Limit)
+		require.True(t, explanation.Rejected)
+	})
+
+	t.Run("ExplainCypherQuery includes the translated SQL and plan", func(t *testing.T) {
+		const rawPlan = `[{"Plan": {"Node Type": "Result", "Startup Cost": 0, "Total Cost": 0.01, "Plan Rows": 1}}]`
+
+		var (
+			mockTx     = graphMocks.NewMockTransaction(mockCtrl)
+			mockResult = graphMocks.NewMockResult(mockCtrl)
+		)
+
+		mockResult.EXPECT().Next().Return(true)
+		mockResult.EXPECT().Scan(gomock.Any()).DoAndReturn(func(targets ...any) error {
+			*(targets[0].(*[]byte)) = []byte(rawPlan)
+			return nil
+		})
+		mockResult.EXPECT().Error().Return(nil)
+		mockResult.EXPECT().Close()
+
+		mockTx.EXPECT().Raw("explain (format json) select 1 where @pi0 = 'harley';", map[string]any{"pi0": "harley"}).Return(mockResult)
+		mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+			return txDelegate(translatingTransaction{
+				MockTransaction: mockTx,
+			})
+		})
+
+		preparedQuery, err := gq.PrepareCypherQuery("match (b) where b.name = $name return b;", map[string]any{"name": "harley"})
+		require.Nil(t, err)
+
+		explanation, err := gq.ExplainCypherQuery(bhCtxInst.ConstructGoContext(), preparedQuery)
+		require.Nil(t, err)
+
+		require.Equal(t, "select 1 where @pi0 = 'harley';", explanation.SQL)
+		require.JSONEq(t, rawPlan, string(explanation.Plan))
+		require.Contains(t, explanation.PlanPUML, "Result (cost=0.00..0.01 rows=1)")
+	})
+}
+
 func TestQueries_GetEntityObjectIDFromRequestPath(t *testing.T) {
 	req, err := http.NewRequest("GET", "/api/v2/users/S-1-5-21-570004220-2248230615-4072641716-4001/admin-rights", nil)
 	require.Nil(t, err)
@@ -68,6 +68,21 @@ func (mr *MockGraphMockRecorder) BatchNodeUpdate(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNodeUpdate", reflect.TypeOf((*MockGraph)(nil).BatchNodeUpdate), arg0, arg1)
 }
 
+// ExplainCypherQuery mocks base method.
+func (m *MockGraph) ExplainCypherQuery(arg0 context.Context, arg1 queries.PreparedQuery) (queries.CypherQueryExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainCypherQuery", arg0, arg1)
+	ret0, _ := ret[0].(queries.CypherQueryExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainCypherQuery indicates an expected call of ExplainCypherQuery.
+func (mr *MockGraphMockRecorder) ExplainCypherQuery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainCypherQuery", reflect.TypeOf((*MockGraph)(nil).ExplainCypherQuery), arg0, arg1)
+}
+
 // FetchNodesByObjectIDs mocks base method.
 func (m *MockGraph) FetchNodesByObjectIDs(arg0 context.Context, arg1 ...string) (graph.NodeSet, error) {
 	m.ctrl.T.Helper()
@@ -184,18 +199,18 @@ func (mr *MockGraphMockRecorder) GetEntityCountResults(arg0, arg1, arg2 interfac
 }
 
 // GetFilteredAndSortedNodes mocks base method.
-func (m *MockGraph) GetFilteredAndSortedNodes(arg0 model.OrderCriteria, arg1 graph.Criteria) (graph.NodeSet, error) {
+func (m *MockGraph) GetFilteredAndSortedNodes(arg0 context.Context, arg1 model.OrderCriteria, arg2 graph.Criteria) (graph.NodeSet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilteredAndSortedNodes", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetFilteredAndSortedNodes", arg0, arg1, arg2)
 	ret0, _ := ret[0].(graph.NodeSet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilteredAndSortedNodes indicates an expected call of GetFilteredAndSortedNodes.
-func (mr *MockGraphMockRecorder) GetFilteredAndSortedNodes(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockGraphMockRecorder) GetFilteredAndSortedNodes(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredAndSortedNodes", reflect.TypeOf((*MockGraph)(nil).GetFilteredAndSortedNodes), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredAndSortedNodes", reflect.TypeOf((*MockGraph)(nil).GetFilteredAndSortedNodes), arg0, arg1, arg2)
 }
 
 // GetNodesByKind mocks base method.
@@ -218,19 +233,65 @@ func (mr *MockGraphMockRecorder) GetNodesByKind(arg0 interface{}, arg1 ...interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesByKind", reflect.TypeOf((*MockGraph)(nil).GetNodesByKind), varargs...)
 }
 
+// PrepareAssetGroupSelectorQuery mocks base method.
+func (m *MockGraph) PrepareAssetGroupSelectorQuery(arg0 model.AssetGroupSelector) (string, map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareAssetGroupSelectorQuery", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(map[string]interface{})
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PrepareAssetGroupSelectorQuery indicates an expected call of PrepareAssetGroupSelectorQuery.
+func (mr *MockGraphMockRecorder) PrepareAssetGroupSelectorQuery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareAssetGroupSelectorQuery", reflect.TypeOf((*MockGraph)(nil).PrepareAssetGroupSelectorQuery), arg0)
+}
+
 // PrepareCypherQuery mocks base method.
-func (m *MockGraph) PrepareCypherQuery(arg0 string) (queries.PreparedQuery, error) {
+func (m *MockGraph) PrepareCypherQuery(arg0 string, arg1 map[string]interface{}) (queries.PreparedQuery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PrepareCypherQuery", arg0)
+	ret := m.ctrl.Call(m, "PrepareCypherQuery", arg0, arg1)
 	ret0, _ := ret[0].(queries.PreparedQuery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PrepareCypherQuery indicates an expected call of PrepareCypherQuery.
-func (mr *MockGraphMockRecorder) PrepareCypherQuery(arg0 interface{}) *gomock.Call {
+func (mr *MockGraphMockRecorder) PrepareCypherQuery(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareCypherQuery", reflect.TypeOf((*MockGraph)(nil).PrepareCypherQuery), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareCypherQuery", reflect.TypeOf((*MockGraph)(nil).PrepareCypherQuery), arg0, arg1)
+}
+
+// PrepareCypherQueryExplanation mocks base method.
+func (m *MockGraph) PrepareCypherQueryExplanation(arg0 string, arg1 map[string]interface{}) (queries.PreparedQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareCypherQueryExplanation", arg0, arg1)
+	ret0, _ := ret[0].(queries.PreparedQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareCypherQueryExplanation indicates an expected call of PrepareCypherQueryExplanation.
+func (mr *MockGraphMockRecorder) PrepareCypherQueryExplanation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareCypherQueryExplanation", reflect.TypeOf((*MockGraph)(nil).PrepareCypherQueryExplanation), arg0, arg1)
+}
+
+// PreviewAssetGroupSelector mocks base method.
+func (m *MockGraph) PreviewAssetGroupSelector(arg0 context.Context, arg1 model.AssetGroupSelector) (graph.NodeSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewAssetGroupSelector", arg0, arg1)
+	ret0, _ := ret[0].(graph.NodeSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewAssetGroupSelector indicates an expected call of PreviewAssetGroupSelector.
+func (mr *MockGraphMockRecorder) PreviewAssetGroupSelector(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewAssetGroupSelector", reflect.TypeOf((*MockGraph)(nil).PreviewAssetGroupSelector), arg0, arg1)
 }
 
 // RawCypherQuery mocks base method.
@@ -248,6 +309,22 @@ func (mr *MockGraphMockRecorder) RawCypherQuery(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawCypherQuery", reflect.TypeOf((*MockGraph)(nil).RawCypherQuery), arg0, arg1, arg2)
 }
 
+// RawCypherTableQuery mocks base method.
+func (m *MockGraph) RawCypherTableQuery(arg0 context.Context, arg1 queries.PreparedQuery, arg2 bool, arg3, arg4 int) (model.CypherTable, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RawCypherTableQuery", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(model.CypherTable)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RawCypherTableQuery indicates an expected call of RawCypherTableQuery.
+func (mr *MockGraphMockRecorder) RawCypherTableQuery(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawCypherTableQuery", reflect.TypeOf((*MockGraph)(nil).RawCypherTableQuery), arg0, arg1, arg2, arg3, arg4)
+}
+
 // SearchByNameOrObjectID mocks base method.
 func (m *MockGraph) SearchByNameOrObjectID(arg0 context.Context, arg1, arg2 string) (graph.NodeSet, error) {
 	m.ctrl.T.Helper()
@@ -37,6 +37,7 @@ type AgiData interface {
 	GetAllAssetGroups(ctx context.Context, order string, filter model.SQLFilter) (model.AssetGroups, error)
 	GetAssetGroup(ctx context.Context, id int32) (model.AssetGroup, error)
 	CreateAssetGroupCollection(ctx context.Context, collection model.AssetGroupCollection, entries model.AssetGroupCollectionEntries) error
+	UpdateAssetGroupSelectorMemberCounts(ctx context.Context, memberCounts map[int32]int) error
 }
 
 func FetchAssetGroupNodes(tx graph.Transaction, assetGroupTag string, isSystemGroup bool) (graph.NodeSet, error) {
@@ -70,7 +71,10 @@ func FetchAssetGroupNodes(tx graph.Transaction, assetGroupTag string, isSystemGr
 	return assetGroupNodes, err
 }
 
-func RunAssetGroupIsolationCollections(ctx context.Context, db AgiData, graphDB graph.Database) error {
+// RunAssetGroupIsolationCollections records the current members of every asset group as a new collection along with the
+// selectors that selected each member. Asset groups with selectors that failed to evaluate are skipped as their
+// collection would be missing the members of the failed selectors.
+func RunAssetGroupIsolationCollections(ctx context.Context, db AgiData, graphDB graph.Database, selections AssetGroupSelections) error {
 	defer log.Measure(log.LevelInfo, "Asset Group Isolation Collections")()
 
 	if assetGroups, err := db.GetAllAssetGroups(ctx, "", model.SQLFilter{}); err != nil {
@@ -78,6 +82,11 @@ func RunAssetGroupIsolationCollections(ctx context.Context, db AgiData, graphDB
 	} else {
 		return graphDB.WriteTransaction(ctx, func(tx graph.Transaction) error {
 			for _, assetGroup := range assetGroups {
+				if !selections.IsComplete(assetGroup.ID) {
+					log.Warnf("Skipping collection of asset group %d as its selectors were not all evaluated", assetGroup.ID)
+					continue
+				}
+
 				if assetGroupNodes, err := FetchAssetGroupNodes(tx, assetGroup.Tag, assetGroup.SystemGroup); err != nil {
 					return err
 				} else {
@@ -97,6 +106,7 @@ func RunAssetGroupIsolationCollections(ctx context.Context, db AgiData, graphDB
 								ObjectID:   objectID,
 								NodeLabel:  analysis.GetNodeKindDisplayLabel(node),
 								Properties: node.Properties.Map,
+								Selectors:  selections.Selectors(assetGroup.ID, node.ID),
 							}
 						}
 						idx++
@@ -114,52 +124,212 @@ func RunAssetGroupIsolationCollections(ctx context.Context, db AgiData, graphDB
 	}
 }
 
-func UpdateAssetGroupIsolationTags(ctx context.Context, db AgiData, graphDb graph.Database) error {
+// assetGroupTagProperty returns the node property that records the tags of the given asset group
+func assetGroupTagProperty(assetGroup model.AssetGroup) string {
+	if assetGroup.SystemGroup {
+		return common.SystemTags.String()
+	}
+
+	return common.UserTags.String()
+}
+
+// AssetGroupSelection is the result of evaluating the selectors of an asset group
+type AssetGroupSelection struct {
+	Members      graph.NodeSet
+	MemberCounts map[int32]int
+
+	// Matches holds the names of the selectors that selected each member
+	Matches map[graph.ID][]string
+
+	// Complete is false if any selector failed to evaluate
+	Complete bool
+}
+
+// AssetGroupSelections holds the selection of every asset group by asset group ID
+type AssetGroupSelections map[int32]AssetGroupSelection
+
+// Selectors returns the names of the selectors of the given asset group that selected the given node
+func (s AssetGroupSelections) Selectors(assetGroupID int32, nodeID graph.ID) []string {
+	if selectorNames := s[assetGroupID].Matches[nodeID]; selectorNames != nil {
+		return selectorNames
+	}
+
+	return []string{}
+}
+
+// IsComplete returns true if every selector of the given asset group was evaluated
+func (s AssetGroupSelections) IsComplete(assetGroupID int32) bool {
+	selection, found := s[assetGroupID]
+	return found && selection.Complete
+}
+
+func (s AssetGroupSelection) add(selector model.AssetGroupSelector, nodes graph.NodeSet) {
+	s.Members.AddSet(nodes)
+	s.MemberCounts[selector.ID] = nodes.Len()
+
+	for _, node := range nodes {
+		s.Matches[node.ID] = append(s.Matches[node.ID], selector.Name)
+	}
+}
+
+// fetchAssetGroupMembers evaluates the selectors of the given asset group. Object ID selectors are evaluated together
+// while dynamic selectors are each evaluated in their own transaction so that a failing selector does not prevent the
+// remaining selectors from being evaluated.
+func fetchAssetGroupMembers(ctx context.Context, graphDb graph.Database, preparer SelectorQueryPreparer, assetGroup model.AssetGroup) (AssetGroupSelection, error) {
+	selection := AssetGroupSelection{
+		Members:      graph.NewNodeSet(),
+		MemberCounts: map[int32]int{},
+		Matches:      map[graph.ID][]string{},
+		Complete:     true,
+	}
+
+	if objectIDs := assetGroup.Selectors.Strings(); len(objectIDs) > 0 {
+		var selectedNodes graph.NodeSet
+
+		if err := graphDb.ReadTransaction(ctx, func(tx graph.Transaction) error {
+			if nodes, err := ops.FetchNodeSet(tx.Nodes().Filterf(func() graph.Criteria {
+				return query.And(
+					query.KindIn(query.Node(), ad.Entity, azure.Entity),
+					query.In(query.NodeProperty(common.ObjectID.String()), objectIDs),
+				)
+			})); err != nil {
+				return err
+			} else {
+				selectedNodes = nodes
+				return nil
+			}
+		}); err != nil {
+			return selection, err
+		}
+
+		nodesByObjectID := make(map[string]graph.NodeSet, selectedNodes.Len())
+
+		for _, node := range selectedNodes {
+			if objectID, err := node.Properties.Get(common.ObjectID.String()).String(); err == nil {
+				if _, found := nodesByObjectID[objectID]; !found {
+					nodesByObjectID[objectID] = graph.NewNodeSet()
+				}
+
+				nodesByObjectID[objectID].Add(node)
+			}
+		}
+
+		for _, selector := range assetGroup.Selectors {
+			if selector.SelectorType() != model.AssetGroupSelectorTypeObjectID {
+				continue
+			} else if nodes, found := nodesByObjectID[selector.Selector]; found {
+				selection.add(selector, nodes)
+			} else {
+				selection.add(selector, graph.NewNodeSet())
+			}
+		}
+	}
+
+	for _, selector := range assetGroup.Selectors.Dynamic() {
+		if err := graphDb.ReadTransaction(ctx, func(tx graph.Transaction) error {
+			if nodes, err := FetchSelectorNodes(tx, preparer, selector); err != nil {
+				return err
+			} else {
+				selection.add(selector, nodes)
+				return nil
+			}
+		}); err != nil {
+			log.Errorf("Failed evaluating selector %d of asset group %d: %v", selector.ID, assetGroup.ID, err)
+			selection.Complete = false
+		}
+	}
+
+	return selection, nil
+}
+
+// UpdateAssetGroupIsolationTags tags the nodes selected by the selectors of every asset group. Dynamic selectors are
+// re-evaluated on every call, so custom asset groups with dynamic selectors additionally have their tag removed from
+// nodes that are no longer selected. The selectors that selected each member are returned so that they may be recorded
+// with the asset group collections.
+//
+// Selectors are shared by the default graph and every workspace graph, so the number of nodes selected by each selector
+// is only recorded when recordMemberCounts is set, which callers do for the default graph.
+func UpdateAssetGroupIsolationTags(ctx context.Context, db AgiData, graphDb graph.Database, preparer SelectorQueryPreparer, recordMemberCounts bool) (AssetGroupSelections, error) {
 	if assetGroups, err := db.GetAllAssetGroups(ctx, "", model.SQLFilter{}); err != nil {
-		return err
+		return nil, err
 	} else {
-		return graphDb.WriteTransaction(ctx, func(tx graph.Transaction) error {
-			for _, assetGroup := range assetGroups {
-				if assetGroupNodes, err := ops.FetchNodes(tx.Nodes().Filterf(func() graph.Criteria {
-					tagPropertyStr := common.SystemTags.String()
+		var (
+			selections          = AssetGroupSelections{}
+			changedMemberCounts = map[int32]int{}
+		)
+
+		for _, assetGroup := range assetGroups {
+			if selection, err := fetchAssetGroupMembers(ctx, graphDb, preparer, assetGroup); err != nil {
+				return nil, err
+			} else if err := graphDb.WriteTransaction(ctx, func(tx graph.Transaction) error {
+				pruneStaleMembers := selection.Complete && !assetGroup.SystemGroup && len(assetGroup.Selectors.Dynamic()) > 0
+				return tagAssetGroupMembers(tx, assetGroup, selection.Members, pruneStaleMembers)
+			}); err != nil {
+				return nil, err
+			} else {
+				selections[assetGroup.ID] = selection
 
-					if !assetGroup.SystemGroup {
-						tagPropertyStr = common.UserTags.String()
+				for _, selector := range assetGroup.Selectors {
+					if memberCount, evaluated := selection.MemberCounts[selector.ID]; evaluated && memberCount != selector.MemberCount {
+						changedMemberCounts[selector.ID] = memberCount
 					}
+				}
+			}
+		}
 
-					return query.And(
-						query.KindIn(query.Node(), ad.Entity, azure.Entity),
-						query.In(query.NodeProperty(common.ObjectID.String()), assetGroup.Selectors.Strings()),
-						query.Not(query.StringContains(query.NodeProperty(tagPropertyStr), assetGroup.Tag)),
-					)
-				})); err != nil {
-					return err
-				} else {
-					for _, node := range assetGroupNodes {
-						tagPropertyStr := common.SystemTags.String()
+		if !recordMemberCounts || len(changedMemberCounts) == 0 {
+			return selections, nil
+		}
 
-						if !assetGroup.SystemGroup {
-							tagPropertyStr = common.UserTags.String()
-						}
+		return selections, db.UpdateAssetGroupSelectorMemberCounts(ctx, changedMemberCounts)
+	}
+}
 
-						if tags, err := node.Properties.Get(tagPropertyStr).String(); err != nil {
-							if graph.IsErrPropertyNotFound(err) {
-								node.Properties.Set(tagPropertyStr, assetGroup.Tag)
-							} else {
-								return err
-							}
-						} else {
-							node.Properties.Set(tagPropertyStr, tags+" "+assetGroup.Tag)
-						}
+// tagAssetGroupMembers adds the tag of the given asset group to each member that is not yet tagged. If pruneStaleMembers
+// is set, the tag is also removed from tagged nodes that are not members.
+func tagAssetGroupMembers(tx graph.Transaction, assetGroup model.AssetGroup, members graph.NodeSet, pruneStaleMembers bool) error {
+	tagPropertyStr := assetGroupTagProperty(assetGroup)
 
-						if err := tx.UpdateNode(node); err != nil {
-							return err
-						}
-					}
-				}
+	for _, node := range members {
+		if tags, err := node.Properties.Get(tagPropertyStr).String(); err != nil {
+			if graph.IsErrPropertyNotFound(err) {
+				node.Properties.Set(tagPropertyStr, assetGroup.Tag)
+			} else {
+				return err
 			}
+		} else if slices.Contains(strings.Split(tags, " "), assetGroup.Tag) {
+			continue
+		} else if len(tags) == 0 {
+			node.Properties.Set(tagPropertyStr, assetGroup.Tag)
+		} else {
+			node.Properties.Set(tagPropertyStr, tags+" "+assetGroup.Tag)
+		}
 
-			return nil
-		})
+		if err := tx.UpdateNode(node); err != nil {
+			return err
+		}
 	}
+
+	if pruneStaleMembers {
+		if taggedNodes, err := FetchAssetGroupNodes(tx, assetGroup.Tag, assetGroup.SystemGroup); err != nil {
+			return err
+		} else {
+			for _, node := range taggedNodes {
+				if members.ContainsID(node.ID) {
+					continue
+				}
+
+				tags, _ := node.Properties.Get(tagPropertyStr).String()
+				node.Properties.Set(tagPropertyStr, strings.Join(slices.DeleteFunc(strings.Split(tags, " "), func(tag string) bool {
+					return tag == assetGroup.Tag
+				}), " "))
+
+				if err := tx.UpdateNode(node); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
 }
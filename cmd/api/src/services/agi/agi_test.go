@@ -0,0 +1,286 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/agi"
+	"github.com/specterops/bloodhound/src/services/agi/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type selectorTestGraph struct {
+	Domain           *graph.Node
+	ServersOU        *graph.Node
+	NestedOU         *graph.Node
+	Server           *graph.Node
+	NestedServer     *graph.Node
+	Workstation      *graph.Node
+	PreviouslyTagged *graph.Node
+}
+
+func newSelectorTestGraph(t *testing.T, db graph.Database, customTag string) selectorTestGraph {
+	var harness selectorTestGraph
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		newNode := func(objectID string, properties map[string]any, kinds ...graph.Kind) *graph.Node {
+			nodeProperties := graph.AsProperties(properties)
+			nodeProperties.Set(common.ObjectID.String(), objectID)
+			nodeProperties.Set(common.Name.String(), objectID)
+
+			node, err := tx.CreateNode(nodeProperties, append(graph.Kinds{ad.Entity}, kinds...)...)
+			require.Nil(t, err)
+
+			return node
+		}
+
+		contains := func(start, end *graph.Node) {
+			_, err := tx.CreateRelationshipByIDs(start.ID, end.ID, ad.Contains, graph.NewProperties())
+			require.Nil(t, err)
+		}
+
+		harness.Domain = newNode("S-1-5-21-1", nil, ad.Domain)
+		harness.ServersOU = newNode("9A2F1C5E-0000-0000-0000-000000000001", nil, ad.OU)
+		harness.NestedOU = newNode("9A2F1C5E-0000-0000-0000-000000000002", nil, ad.OU)
+		harness.Server = newNode("S-1-5-21-1-1001", map[string]any{ad.UnconstrainedDelegation.String(): true}, ad.Computer)
+		harness.NestedServer = newNode("S-1-5-21-1-1002", map[string]any{ad.UnconstrainedDelegation.String(): false}, ad.Computer)
+		harness.Workstation = newNode("S-1-5-21-1-1003", nil, ad.Computer)
+		harness.PreviouslyTagged = newNode("S-1-5-21-1-1004", map[string]any{common.UserTags.String(): "other " + customTag}, ad.User)
+
+		contains(harness.Domain, harness.ServersOU)
+		contains(harness.Domain, harness.Workstation)
+		contains(harness.ServersOU, harness.Server)
+		contains(harness.ServersOU, harness.NestedOU)
+		contains(harness.NestedOU, harness.NestedServer)
+
+		return nil
+	}))
+
+	return harness
+}
+
+func requireNodeSetOf(t *testing.T, nodes graph.NodeSet, expected ...*graph.Node) {
+	expectedIDs := make([]graph.ID, 0, len(expected))
+
+	for _, node := range expected {
+		expectedIDs = append(expectedIDs, node.ID)
+	}
+
+	require.ElementsMatch(t, expectedIDs, nodes.IDs())
+}
+
+func TestFetchSelectorNodes(t *testing.T) {
+	var (
+		db      = memory.NewDatabase(0)
+		harness = newSelectorTestGraph(t, db, "custom")
+	)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		nodes, err := agi.FetchSelectorNodes(tx, nil, model.AssetGroupSelector{
+			Selector: "S-1-5-21-1-1003",
+		})
+		require.Nil(t, err)
+		requireNodeSetOf(t, nodes, harness.Workstation)
+
+		nodes, err = agi.FetchSelectorNodes(tx, nil, model.AssetGroupSelector{
+			Type:     model.AssetGroupSelectorTypeContainedBy,
+			Selector: "9A2F1C5E-0000-0000-0000-000000000001",
+		})
+		require.Nil(t, err)
+		requireNodeSetOf(t, nodes, harness.Server, harness.NestedOU, harness.NestedServer)
+
+		nodes, err = agi.FetchSelectorNodes(tx, nil, model.AssetGroupSelector{
+			Type:     model.AssetGroupSelectorTypeContainedBy,
+			Selector: "9A2F1C5E-0000-0000-0000-00000000FFFF",
+		})
+		require.Nil(t, err)
+		requireNodeSetOf(t, nodes)
+
+		nodes, err = agi.FetchSelectorNodes(tx, nil, model.AssetGroupSelector{
+			Type:     model.AssetGroupSelectorTypeProperty,
+			Selector: ad.UnconstrainedDelegation.String(),
+		})
+		require.Nil(t, err)
+		requireNodeSetOf(t, nodes, harness.Server, harness.NestedServer)
+
+		nodes, err = agi.FetchSelectorNodes(tx, nil, model.AssetGroupSelector{
+			Type:     model.AssetGroupSelectorTypeProperty,
+			Selector: ad.UnconstrainedDelegation.String(),
+			Parameters: map[string]any{
+				model.AssetGroupSelectorParameterKind:  ad.Computer.String(),
+				model.AssetGroupSelectorParameterValue: true,
+			},
+		})
+		require.Nil(t, err)
+		requireNodeSetOf(t, nodes, harness.Server)
+
+		_, err = agi.FetchSelectorNodes(tx, nil, model.AssetGroupSelector{
+			Type:     model.AssetGroupSelectorTypeProperty,
+			Selector: "x' or true; select 1 --",
+		})
+		require.ErrorIs(t, err, model.ErrAssetGroupSelectorPropertyInvalid)
+
+		_, err = agi.FetchSelectorNodes(tx, nil, model.AssetGroupSelector{
+			Type:       model.AssetGroupSelectorTypeProperty,
+			Selector:   ad.UnconstrainedDelegation.String(),
+			Parameters: map[string]any{model.AssetGroupSelectorParameterKind: "Computer' or true --"},
+		})
+		require.ErrorIs(t, err, model.ErrAssetGroupSelectorKindInvalid)
+
+		_, err = agi.FetchSelectorNodes(tx, nil, model.AssetGroupSelector{Type: "sql"})
+		require.ErrorIs(t, err, model.ErrAssetGroupSelectorTypeInvalid)
+
+		return nil
+	}))
+}
+
+func TestUpdateAssetGroupIsolationTags(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockDB     = mocks.NewMockAgiData(mockCtrl)
+		db         = memory.NewDatabase(0)
+		harness    = newSelectorTestGraph(t, db, "custom")
+		assetGroup = model.AssetGroup{
+			Name: "Custom",
+			Tag:  "custom",
+			Selectors: model.AssetGroupSelectors{{
+				Name:     "workstation",
+				Selector: "S-1-5-21-1-1003",
+				Serial:   model.Serial{ID: 1},
+			}, {
+				Name:     "servers",
+				Type:     model.AssetGroupSelectorTypeContainedBy,
+				Selector: "9A2F1C5E-0000-0000-0000-000000000001",
+				Serial:   model.Serial{ID: 2},
+			}, {
+				Name:        "missing",
+				Selector:    "S-1-5-21-1-9999",
+				MemberCount: 1,
+				Serial:      model.Serial{ID: 3},
+			}},
+		}
+	)
+
+	mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{assetGroup}, nil)
+	mockDB.EXPECT().UpdateAssetGroupSelectorMemberCounts(gomock.Any(), map[int32]int{1: 1, 2: 3, 3: 0}).Return(nil)
+
+	selections, err := agi.UpdateAssetGroupIsolationTags(context.Background(), mockDB, db, nil, true)
+	require.Nil(t, err)
+	require.Equal(t, []string{"workstation"}, selections.Selectors(assetGroup.ID, harness.Workstation.ID))
+	require.Equal(t, []string{"servers"}, selections.Selectors(assetGroup.ID, harness.NestedServer.ID))
+	require.Equal(t, []string{}, selections.Selectors(assetGroup.ID, harness.Domain.ID))
+	require.True(t, selections.IsComplete(assetGroup.ID))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		nodes, err := agi.FetchAssetGroupNodes(tx, assetGroup.Tag, false)
+		require.Nil(t, err)
+		requireNodeSetOf(t, nodes, harness.Workstation, harness.Server, harness.NestedOU, harness.NestedServer)
+
+		// the tag is removed from nodes that are no longer selected while other tags are kept
+		previouslyTagged, err := analysis.FetchNodeByObjectID(tx, "S-1-5-21-1-1004")
+		require.Nil(t, err)
+
+		tags, err := previouslyTagged.Properties.Get(common.UserTags.String()).String()
+		require.Nil(t, err)
+		require.Equal(t, "other", tags)
+
+		return nil
+	}))
+}
+
+func TestUpdateAssetGroupIsolationTags_IncompleteSelection(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockDB     = mocks.NewMockAgiData(mockCtrl)
+		db         = memory.NewDatabase(0)
+		harness    = newSelectorTestGraph(t, db, "custom")
+		assetGroup = model.AssetGroup{
+			Name: "Custom",
+			Tag:  "custom",
+			Selectors: model.AssetGroupSelectors{{
+				Name:     "workstation",
+				Selector: "S-1-5-21-1-1003",
+				Serial:   model.Serial{ID: 1},
+			}, {
+				Name:     "failing",
+				Type:     model.AssetGroupSelectorTypeProperty,
+				Selector: "x' or true --",
+				Serial:   model.Serial{ID: 2},
+			}},
+		}
+	)
+
+	mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{assetGroup}, nil)
+	mockDB.EXPECT().UpdateAssetGroupSelectorMemberCounts(gomock.Any(), map[int32]int{1: 1}).Return(nil)
+
+	selections, err := agi.UpdateAssetGroupIsolationTags(context.Background(), mockDB, db, nil, true)
+	require.Nil(t, err)
+	require.False(t, selections.IsComplete(assetGroup.ID))
+	require.Equal(t, []string{"workstation"}, selections.Selectors(assetGroup.ID, harness.Workstation.ID))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		// stale members are not pruned while a selector fails to evaluate
+		nodes, err := agi.FetchAssetGroupNodes(tx, assetGroup.Tag, false)
+		require.Nil(t, err)
+		requireNodeSetOf(t, nodes, harness.Workstation, harness.PreviouslyTagged)
+
+		return nil
+	}))
+}
+
+func TestUpdateAssetGroupIsolationTags_WithoutMemberCounts(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockDB     = mocks.NewMockAgiData(mockCtrl)
+		db         = memory.NewDatabase(0)
+		harness    = newSelectorTestGraph(t, db, "custom")
+		assetGroup = model.AssetGroup{
+			Name: "Custom",
+			Tag:  "custom",
+			Selectors: model.AssetGroupSelectors{{
+				Name:     "workstation",
+				Selector: "S-1-5-21-1-1003",
+				Serial:   model.Serial{ID: 1},
+			}},
+		}
+	)
+
+	// Workspace graphs are tagged without overwriting the member counts recorded for the default graph
+	mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{assetGroup}, nil)
+	mockDB.EXPECT().UpdateAssetGroupSelectorMemberCounts(gomock.Any(), gomock.Any()).Times(0)
+
+	selections, err := agi.UpdateAssetGroupIsolationTags(context.Background(), mockDB, db, nil, false)
+	require.Nil(t, err)
+	require.Equal(t, []string{"workstation"}, selections.Selectors(assetGroup.ID, harness.Workstation.ID))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		nodes, err := agi.FetchAssetGroupNodes(tx, assetGroup.Tag, false)
+		require.Nil(t, err)
+		require.True(t, nodes.Contains(harness.Workstation))
+
+		return nil
+	}))
+}
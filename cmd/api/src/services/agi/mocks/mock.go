@@ -94,3 +94,17 @@ func (mr *MockAgiDataMockRecorder) GetAssetGroup(arg0, arg1 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroup", reflect.TypeOf((*MockAgiData)(nil).GetAssetGroup), arg0, arg1)
 }
+
+// UpdateAssetGroupSelectorMemberCounts mocks base method.
+func (m *MockAgiData) UpdateAssetGroupSelectorMemberCounts(arg0 context.Context, arg1 map[int32]int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssetGroupSelectorMemberCounts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAssetGroupSelectorMemberCounts indicates an expected call of UpdateAssetGroupSelectorMemberCounts.
+func (mr *MockAgiDataMockRecorder) UpdateAssetGroupSelectorMemberCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssetGroupSelectorMemberCounts", reflect.TypeOf((*MockAgiData)(nil).UpdateAssetGroupSelectorMemberCounts), arg0, arg1)
+}
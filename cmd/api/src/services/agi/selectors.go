@@ -0,0 +1,116 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package agi
+
+import (
+	"fmt"
+
+	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+// SelectorQueryPreparer validates the cypher query of a cypher asset group selector and returns the query and the
+// parameters to run it with
+type SelectorQueryPreparer interface {
+	PrepareAssetGroupSelectorQuery(selector model.AssetGroupSelector) (string, map[string]any, error)
+}
+
+func isAssetGroupMemberCandidate(node *graph.Node) bool {
+	return node.Kinds.ContainsOneOf(ad.Entity, azure.Entity)
+}
+
+// FetchSelectorNodes evaluates the given selector against the graph and returns the nodes that it selects. Only AD and
+// Azure entities may be asset group members so any other nodes selected are dropped.
+func FetchSelectorNodes(tx graph.Transaction, preparer SelectorQueryPreparer, selector model.AssetGroupSelector) (graph.NodeSet, error) {
+	switch selector.SelectorType() {
+	case model.AssetGroupSelectorTypeObjectID:
+		return ops.FetchNodeSet(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.KindIn(query.Node(), ad.Entity, azure.Entity),
+				query.Equals(query.NodeProperty(common.ObjectID.String()), selector.Selector),
+			)
+		}))
+
+	case model.AssetGroupSelectorTypeCypher:
+		if cypherQuery, parameters, err := preparer.PrepareAssetGroupSelectorQuery(selector); err != nil {
+			return nil, err
+		} else if pathSet, err := ops.FetchPathSetByQuery(tx, cypherQuery, parameters); err != nil {
+			return nil, err
+		} else {
+			nodes := graph.NewNodeSet()
+
+			for _, node := range pathSet.AllNodes() {
+				if isAssetGroupMemberCandidate(node) {
+					nodes.Add(node)
+				}
+			}
+
+			return nodes, nil
+		}
+
+	case model.AssetGroupSelectorTypeContainedBy:
+		if container, err := analysis.FetchNodeByObjectID(tx, selector.Selector); err != nil {
+			if graph.IsErrNotFound(err) {
+				return graph.NewNodeSet(), nil
+			}
+
+			return nil, err
+		} else {
+			return ops.AcyclicTraverseNodes(tx, ops.TraversalPlan{
+				Root:      container,
+				Direction: graph.DirectionOutbound,
+				BranchQuery: func() graph.Criteria {
+					return query.Kind(query.Relationship(), ad.Contains)
+				},
+			}, func(node *graph.Node) bool {
+				return node.ID != container.ID && isAssetGroupMemberCandidate(node)
+			})
+		}
+
+	case model.AssetGroupSelectorTypeProperty:
+		if err := selector.ValidatePropertySelector(); err != nil {
+			return nil, err
+		}
+
+		return ops.FetchNodeSet(tx.Nodes().Filterf(func() graph.Criteria {
+			criteria := []graph.Criteria{
+				query.KindIn(query.Node(), ad.Entity, azure.Entity),
+			}
+
+			if kind, hasKind := selector.Parameters[model.AssetGroupSelectorParameterKind].(string); hasKind && kind != "" {
+				criteria = append(criteria, query.Kind(query.Node(), graph.StringKind(kind)))
+			}
+
+			if value, hasValue := selector.Parameters[model.AssetGroupSelectorParameterValue]; hasValue {
+				criteria = append(criteria, query.Equals(query.NodeProperty(selector.Selector), value))
+			} else {
+				criteria = append(criteria, query.Exists(query.NodeProperty(selector.Selector)))
+			}
+
+			return query.And(criteria...)
+		}))
+
+	default:
+		return nil, fmt.Errorf("%w: %s", model.ErrAssetGroupSelectorTypeInvalid, selector.Type)
+	}
+}
@@ -117,7 +117,7 @@ func Entrypoint(ctx context.Context, cfg config.Configuration, connections boots
 
 		return []daemons.Daemon{
 			bhapi.NewDaemon(cfg, routerInst.Handler()),
-			gc.NewDataPruningDaemon(connections.RDMS),
+			gc.NewDataPruningDaemon(connections.RDMS, connections.Graph),
 			datapipeDaemon,
 		}, nil
 	}
@@ -0,0 +1,150 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fileupload
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/ingest"
+)
+
+const (
+	tarMagicOffset = 257
+	tarHeaderSize  = 512
+)
+
+var (
+	GzipMagicBytes = []byte{0x1f, 0x8b}
+	ZstdMagicBytes = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	TarMagicBytes  = []byte("ustar")
+
+	ErrDecompressionLimitExceeded = errors.New("decompressed content exceeds the configured size limit")
+)
+
+// NewDecompressor returns a reader of the decompressed content of the given gzip or zstd compressed reader. Readers of
+// any other file type are returned as is.
+func NewDecompressor(reader io.Reader, fileType model.FileType) (io.ReadCloser, error) {
+	switch fileType {
+	case model.FileTypeGzip:
+		return gzip.NewReader(reader)
+
+	case model.FileTypeZstd:
+		if decoder, err := zstd.NewReader(reader, zstd.WithDecoderConcurrency(1)); err != nil {
+			return nil, err
+		} else {
+			return decoder.IOReadCloser(), nil
+		}
+
+	default:
+		return io.NopCloser(reader), nil
+	}
+}
+
+// DecompressionLimiter bounds the number of decompressed bytes that may be read from one or more readers. It exists to
+// protect ingest from compression bombs. A limit of zero or less disables the check.
+type DecompressionLimiter struct {
+	remaining int64
+	disabled  bool
+}
+
+func NewDecompressionLimiter(limit int64) *DecompressionLimiter {
+	return &DecompressionLimiter{
+		remaining: limit,
+		disabled:  limit <= 0,
+	}
+}
+
+// Reader wraps the given reader so that reads from it count against the limit. Reads fail with
+// ErrDecompressionLimitExceeded once the limit has been exceeded.
+func (s *DecompressionLimiter) Reader(reader io.Reader) io.Reader {
+	return limitedReader{
+		limiter: s,
+		reader:  reader,
+	}
+}
+
+type limitedReader struct {
+	limiter *DecompressionLimiter
+	reader  io.Reader
+}
+
+func (s limitedReader) Read(p []byte) (int, error) {
+	if s.limiter.disabled {
+		return s.reader.Read(p)
+	} else if s.limiter.remaining < 0 {
+		return 0, ErrDecompressionLimitExceeded
+	}
+
+	// Allow a single byte beyond the limit to be read so that content ending exactly at the limit is accepted
+	if int64(len(p)) > s.limiter.remaining+1 {
+		p = p[:s.limiter.remaining+1]
+	}
+
+	read, err := s.reader.Read(p)
+
+	if s.limiter.remaining -= int64(read); s.limiter.remaining < 0 {
+		return read, ErrDecompressionLimitExceeded
+	}
+
+	return read, err
+}
+
+// IsTarArchive returns true if the reader begins with a tar header. The reader is not advanced.
+func IsTarArchive(reader *bufio.Reader) bool {
+	header, _ := reader.Peek(tarHeaderSize)
+	return len(header) == tarHeaderSize && bytes.Equal(header[tarMagicOffset:tarMagicOffset+len(TarMagicBytes)], TarMagicBytes)
+}
+
+func validateMagicBytes(reader io.Reader, magicBytes []byte, invalidErr error) error {
+	header := make([]byte, len(magicBytes))
+
+	if _, err := io.ReadFull(reader, header); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return invalidErr
+	} else if err != nil {
+		return err
+	} else if !bytes.Equal(header, magicBytes) {
+		return invalidErr
+	} else {
+		_, err := io.Copy(io.Discard, reader)
+		return err
+	}
+}
+
+func ValidateGzipFile(reader io.Reader) error {
+	return validateMagicBytes(reader, GzipMagicBytes, ingest.ErrInvalidGzipFile)
+}
+
+func ValidateZstdFile(reader io.Reader) error {
+	return validateMagicBytes(reader, ZstdMagicBytes, ingest.ErrInvalidZstdFile)
+}
+
+func ValidateTarFile(reader io.Reader) error {
+	if bufferedReader := bufio.NewReaderSize(reader, tarHeaderSize); !IsTarArchive(bufferedReader) {
+		return ingest.ErrInvalidTarFile
+	} else if _, err := io.Copy(io.Discard, bufferedReader); err != nil {
+		return fmt.Errorf("error reading tar file: %w", err)
+	}
+
+	return nil
+}
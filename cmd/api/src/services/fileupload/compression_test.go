@@ -0,0 +1,128 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fileupload
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/ingest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCompressedContent = `{"data": [], "meta": {"type": "users", "version": 5, "count": 0, "methods": 0}}`
+
+func TestNewDecompressor(t *testing.T) {
+	t.Run("gzip", func(t *testing.T) {
+		var (
+			buffer = bytes.Buffer{}
+			writer = gzip.NewWriter(&buffer)
+		)
+
+		_, err := writer.Write([]byte(testCompressedContent))
+		require.Nil(t, err)
+		require.Nil(t, writer.Close())
+		require.Nil(t, ValidateGzipFile(bytes.NewReader(buffer.Bytes())))
+
+		reader, err := NewDecompressor(&buffer, model.FileTypeGzip)
+		require.Nil(t, err)
+
+		content, err := io.ReadAll(reader)
+		require.Nil(t, err)
+		assert.Equal(t, testCompressedContent, string(content))
+	})
+
+	t.Run("zstd", func(t *testing.T) {
+		buffer := bytes.Buffer{}
+
+		writer, err := zstd.NewWriter(&buffer)
+		require.Nil(t, err)
+
+		_, err = writer.Write([]byte(testCompressedContent))
+		require.Nil(t, err)
+		require.Nil(t, writer.Close())
+		require.Nil(t, ValidateZstdFile(bytes.NewReader(buffer.Bytes())))
+
+		reader, err := NewDecompressor(&buffer, model.FileTypeZstd)
+		require.Nil(t, err)
+
+		content, err := io.ReadAll(reader)
+		require.Nil(t, err)
+		assert.Equal(t, testCompressedContent, string(content))
+	})
+
+	t.Run("invalid magic bytes", func(t *testing.T) {
+		assert.ErrorIs(t, ValidateGzipFile(strings.NewReader(testCompressedContent)), ingest.ErrInvalidGzipFile)
+		assert.ErrorIs(t, ValidateZstdFile(strings.NewReader(testCompressedContent)), ingest.ErrInvalidZstdFile)
+		assert.ErrorIs(t, ValidateTarFile(strings.NewReader(testCompressedContent)), ingest.ErrInvalidTarFile)
+	})
+}
+
+func TestDecompressionLimiter(t *testing.T) {
+	t.Run("content within the limit is read", func(t *testing.T) {
+		limiter := NewDecompressionLimiter(int64(len(testCompressedContent)))
+
+		content, err := io.ReadAll(limiter.Reader(strings.NewReader(testCompressedContent)))
+		require.Nil(t, err)
+		assert.Equal(t, testCompressedContent, string(content))
+	})
+
+	t.Run("limit is shared between readers", func(t *testing.T) {
+		limiter := NewDecompressionLimiter(int64(len(testCompressedContent)) + 10)
+
+		_, err := io.ReadAll(limiter.Reader(strings.NewReader(testCompressedContent)))
+		require.Nil(t, err)
+
+		_, err = io.ReadAll(limiter.Reader(strings.NewReader(testCompressedContent)))
+		assert.ErrorIs(t, err, ErrDecompressionLimitExceeded)
+	})
+
+	t.Run("limit of zero is disabled", func(t *testing.T) {
+		_, err := io.ReadAll(NewDecompressionLimiter(0).Reader(strings.NewReader(testCompressedContent)))
+		assert.Nil(t, err)
+	})
+}
+
+func TestIsTarArchive(t *testing.T) {
+	var (
+		buffer = bytes.Buffer{}
+		writer = tar.NewWriter(&buffer)
+	)
+
+	require.Nil(t, writer.WriteHeader(&tar.Header{Name: "users.json", Mode: 0644, Size: int64(len(testCompressedContent))}))
+	_, err := writer.Write([]byte(testCompressedContent))
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	reader := bufio.NewReader(bytes.NewReader(buffer.Bytes()))
+	assert.True(t, IsTarArchive(reader))
+
+	// The reader must not be advanced by the check
+	_, err = tar.NewReader(reader).Next()
+	assert.Nil(t, err)
+
+	assert.False(t, IsTarArchive(bufio.NewReader(strings.NewReader(testCompressedContent))))
+	assert.Nil(t, ValidateTarFile(bytes.NewReader(buffer.Bytes())))
+}
@@ -22,17 +22,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/specterops/bloodhound/bomenc"
 	"github.com/specterops/bloodhound/headers"
 	"github.com/specterops/bloodhound/mediatypes"
 	"github.com/specterops/bloodhound/src/model/ingest"
-	"github.com/specterops/bloodhound/src/utils"
 
 	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/database/types/null"
 	"github.com/specterops/bloodhound/src/model"
 )
 
@@ -44,6 +46,13 @@ type FileUploadData interface {
 	CreateFileUploadJob(ctx context.Context, job model.FileUploadJob) (model.FileUploadJob, error)
 	UpdateFileUploadJob(ctx context.Context, job model.FileUploadJob) error
 	GetFileUploadJob(ctx context.Context, id int64) (model.FileUploadJob, error)
+	CreateFileUploadJobResults(ctx context.Context, results model.FileUploadJobResults) error
+	GetFileUploadJobResults(ctx context.Context, jobID int64) (model.FileUploadJobResults, error)
+	CreateResumableUpload(ctx context.Context, upload model.ResumableUpload) (model.ResumableUpload, error)
+	LockResumableUpload(ctx context.Context, jobID int64, id int64, delegate func(upload *model.ResumableUpload) error) (model.ResumableUpload, error)
+	GetResumableUpload(ctx context.Context, jobID int64, id int64) (model.ResumableUpload, error)
+	DeleteResumableUpload(ctx context.Context, upload model.ResumableUpload) error
+	GetActiveResumableUploads(ctx context.Context) (model.ResumableUploads, error)
 	GetAllFileUploadJobs(ctx context.Context, skip int, limit int, order string, filter model.SQLFilter) ([]model.FileUploadJob, int, error)
 	GetFileUploadJobsWithStatus(ctx context.Context, status model.JobStatus) ([]model.FileUploadJob, error)
 	DeleteAllFileUploads(ctx context.Context) error
@@ -93,14 +102,22 @@ func GetAllFileUploadJobs(ctx context.Context, db FileUploadData, skip int, limi
 	return db.GetAllFileUploadJobs(ctx, skip, limit, order, filter)
 }
 
-func StartFileUploadJob(ctx context.Context, db FileUploadData, user model.User) (model.FileUploadJob, error) {
+// StartFileUploadJob creates a new running file upload job. Files of dry run jobs are decoded and reported on but are
+// never written to the graph. Files of jobs started in a workspace are ingested into the graph of that workspace.
+func StartFileUploadJob(ctx context.Context, db FileUploadData, user model.User, dryRun bool, workspace *model.Workspace) (model.FileUploadJob, error) {
 	job := model.FileUploadJob{
 		UserID:     user.ID,
 		User:       user,
 		Status:     model.JobStatusRunning,
 		StartTime:  time.Now().UTC(),
 		LastIngest: time.Now().UTC(),
+		DryRun:     dryRun,
 	}
+
+	if workspace != nil {
+		job.WorkspaceID = null.Int64From(workspace.ID)
+	}
+
 	return db.CreateFileUploadJob(ctx, job)
 }
 
@@ -108,6 +125,14 @@ func GetFileUploadJobByID(ctx context.Context, db FileUploadData, jobID int64) (
 	return db.GetFileUploadJob(ctx, jobID)
 }
 
+func GetFileUploadJobResults(ctx context.Context, db FileUploadData, jobID int64) (model.FileUploadJobResults, error) {
+	return db.GetFileUploadJobResults(ctx, jobID)
+}
+
+func GetResumableUpload(ctx context.Context, db FileUploadData, jobID int64, uploadID int64) (model.ResumableUpload, error) {
+	return db.GetResumableUpload(ctx, jobID, uploadID)
+}
+
 func WriteAndValidateZip(src io.Reader, dst io.Writer) error {
 	tr := io.TeeReader(src, dst)
 	return ValidateZipFile(tr)
@@ -123,21 +148,67 @@ func WriteAndValidateJSON(src io.Reader, dst io.Writer) error {
 	return err
 }
 
+func WriteAndValidateGzip(src io.Reader, dst io.Writer) error {
+	return ValidateGzipFile(io.TeeReader(src, dst))
+}
+
+func WriteAndValidateZstd(src io.Reader, dst io.Writer) error {
+	return ValidateZstdFile(io.TeeReader(src, dst))
+}
+
+func WriteAndValidateTar(src io.Reader, dst io.Writer) error {
+	return ValidateTarFile(io.TeeReader(src, dst))
+}
+
+// FileTypeForContentType returns the ingest file type for the given content type of an uploaded file
+func FileTypeForContentType(contentType string) (model.FileType, bool) {
+	if parsed, _, err := mime.ParseMediaType(contentType); err != nil {
+		return model.FileTypeJson, false
+	} else if parsed == mediatypes.ApplicationJson.String() {
+		return model.FileTypeJson, true
+	} else if slices.Contains(ingest.AllowedZipFileUploadTypes, parsed) {
+		return model.FileTypeZip, true
+	} else if slices.Contains(ingest.AllowedGzipFileUploadTypes, parsed) {
+		return model.FileTypeGzip, true
+	} else if slices.Contains(ingest.AllowedZstdFileUploadTypes, parsed) {
+		return model.FileTypeZstd, true
+	} else if slices.Contains(ingest.AllowedTarFileUploadTypes, parsed) {
+		return model.FileTypeTar, true
+	} else {
+		return model.FileTypeJson, false
+	}
+}
+
+// validatorForFileType returns the FileValidator for uploaded files of the given type. Compressed files and archives
+// are only checked for a valid header here; their content is validated as it is extracted for ingest.
+func validatorForFileType(fileType model.FileType) FileValidator {
+	switch fileType {
+	case model.FileTypeZip:
+		return WriteAndValidateZip
+	case model.FileTypeGzip:
+		return WriteAndValidateGzip
+	case model.FileTypeZstd:
+		return WriteAndValidateZstd
+	case model.FileTypeTar:
+		return WriteAndValidateTar
+	default:
+		return WriteAndValidateJSON
+	}
+}
+
 func SaveIngestFile(location string, request *http.Request) (string, model.FileType, error) {
-	fileData := request.Body
+	fileType, valid := FileTypeForContentType(request.Header.Get(headers.ContentType.String()))
+	if !valid {
+		// We should never get here since this is checked a level above
+		return "", model.FileTypeJson, fmt.Errorf("invalid content type for ingest file")
+	}
+
 	tempFile, err := os.CreateTemp(location, "bh")
 	if err != nil {
 		return "", model.FileTypeJson, fmt.Errorf("error creating ingest file: %w", err)
 	}
 
-	if utils.HeaderMatches(request.Header, headers.ContentType.String(), mediatypes.ApplicationJson.String()) {
-		return tempFile.Name(), model.FileTypeJson, WriteAndValidateFile(fileData, tempFile, WriteAndValidateJSON)
-	} else if utils.HeaderMatches(request.Header, headers.ContentType.String(), ingest.AllowedZipFileUploadTypes...) {
-		return tempFile.Name(), model.FileTypeZip, WriteAndValidateFile(fileData, tempFile, WriteAndValidateZip)
-	} else {
-		// We should never get here since this is checked a level above
-		return "", model.FileTypeJson, fmt.Errorf("invalid content type for ingest file")
-	}
+	return tempFile.Name(), fileType, WriteAndValidateFile(request.Body, tempFile, validatorForFileType(fileType))
 }
 
 type FileValidator func(src io.Reader, dst io.Writer) error
@@ -80,6 +80,35 @@ func (mr *MockFileUploadDataMockRecorder) CreateFileUploadJob(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFileUploadJob", reflect.TypeOf((*MockFileUploadData)(nil).CreateFileUploadJob), arg0, arg1)
 }
 
+// CreateFileUploadJobResults mocks base method.
+func (m *MockFileUploadData) CreateFileUploadJobResults(arg0 context.Context, arg1 model.FileUploadJobResults) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFileUploadJobResults", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFileUploadJobResults indicates an expected call of CreateFileUploadJobResults.
+func (mr *MockFileUploadDataMockRecorder) CreateFileUploadJobResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFileUploadJobResults", reflect.TypeOf((*MockFileUploadData)(nil).CreateFileUploadJobResults), arg0, arg1)
+}
+
+// CreateResumableUpload mocks base method.
+func (m *MockFileUploadData) CreateResumableUpload(arg0 context.Context, arg1 model.ResumableUpload) (model.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResumableUpload", arg0, arg1)
+	ret0, _ := ret[0].(model.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateResumableUpload indicates an expected call of CreateResumableUpload.
+func (mr *MockFileUploadDataMockRecorder) CreateResumableUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResumableUpload", reflect.TypeOf((*MockFileUploadData)(nil).CreateResumableUpload), arg0, arg1)
+}
+
 // DeleteAllFileUploads mocks base method.
 func (m *MockFileUploadData) DeleteAllFileUploads(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -108,6 +137,35 @@ func (mr *MockFileUploadDataMockRecorder) DeleteAllIngestTasks(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllIngestTasks", reflect.TypeOf((*MockFileUploadData)(nil).DeleteAllIngestTasks), arg0)
 }
 
+// DeleteResumableUpload mocks base method.
+func (m *MockFileUploadData) DeleteResumableUpload(arg0 context.Context, arg1 model.ResumableUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResumableUpload", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResumableUpload indicates an expected call of DeleteResumableUpload.
+func (mr *MockFileUploadDataMockRecorder) DeleteResumableUpload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResumableUpload", reflect.TypeOf((*MockFileUploadData)(nil).DeleteResumableUpload), arg0, arg1)
+}
+
+// GetActiveResumableUploads mocks base method.
+func (m *MockFileUploadData) GetActiveResumableUploads(arg0 context.Context) (model.ResumableUploads, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveResumableUploads", arg0)
+	ret0, _ := ret[0].(model.ResumableUploads)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveResumableUploads indicates an expected call of GetActiveResumableUploads.
+func (mr *MockFileUploadDataMockRecorder) GetActiveResumableUploads(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveResumableUploads", reflect.TypeOf((*MockFileUploadData)(nil).GetActiveResumableUploads), arg0)
+}
+
 // GetAllFileUploadJobs mocks base method.
 func (m *MockFileUploadData) GetAllFileUploadJobs(arg0 context.Context, arg1, arg2 int, arg3 string, arg4 model.SQLFilter) ([]model.FileUploadJob, int, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +197,21 @@ func (mr *MockFileUploadDataMockRecorder) GetFileUploadJob(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileUploadJob", reflect.TypeOf((*MockFileUploadData)(nil).GetFileUploadJob), arg0, arg1)
 }
 
+// GetFileUploadJobResults mocks base method.
+func (m *MockFileUploadData) GetFileUploadJobResults(arg0 context.Context, arg1 int64) (model.FileUploadJobResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileUploadJobResults", arg0, arg1)
+	ret0, _ := ret[0].(model.FileUploadJobResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileUploadJobResults indicates an expected call of GetFileUploadJobResults.
+func (mr *MockFileUploadDataMockRecorder) GetFileUploadJobResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileUploadJobResults", reflect.TypeOf((*MockFileUploadData)(nil).GetFileUploadJobResults), arg0, arg1)
+}
+
 // GetFileUploadJobsWithStatus mocks base method.
 func (m *MockFileUploadData) GetFileUploadJobsWithStatus(arg0 context.Context, arg1 model.JobStatus) ([]model.FileUploadJob, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +227,36 @@ func (mr *MockFileUploadDataMockRecorder) GetFileUploadJobsWithStatus(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileUploadJobsWithStatus", reflect.TypeOf((*MockFileUploadData)(nil).GetFileUploadJobsWithStatus), arg0, arg1)
 }
 
+// GetResumableUpload mocks base method.
+func (m *MockFileUploadData) GetResumableUpload(arg0 context.Context, arg1, arg2 int64) (model.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResumableUpload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResumableUpload indicates an expected call of GetResumableUpload.
+func (mr *MockFileUploadDataMockRecorder) GetResumableUpload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResumableUpload", reflect.TypeOf((*MockFileUploadData)(nil).GetResumableUpload), arg0, arg1, arg2)
+}
+
+// LockResumableUpload mocks base method.
+func (m *MockFileUploadData) LockResumableUpload(arg0 context.Context, arg1, arg2 int64, arg3 func(*model.ResumableUpload) error) (model.ResumableUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockResumableUpload", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.ResumableUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockResumableUpload indicates an expected call of LockResumableUpload.
+func (mr *MockFileUploadDataMockRecorder) LockResumableUpload(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockResumableUpload", reflect.TypeOf((*MockFileUploadData)(nil).LockResumableUpload), arg0, arg1, arg2, arg3)
+}
+
 // UpdateFileUploadJob mocks base method.
 func (m *MockFileUploadData) UpdateFileUploadJob(arg0 context.Context, arg1 model.FileUploadJob) error {
 	m.ctrl.T.Helper()
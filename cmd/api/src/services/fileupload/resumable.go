@@ -0,0 +1,173 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fileupload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+var (
+	ErrInvalidUploadLength    = errors.New("upload length must be greater than zero")
+	ErrUploadTooLarge         = errors.New("upload length exceeds the maximum upload length")
+	ErrInvalidUploadChecksum  = errors.New("upload checksum must be a hex encoded SHA-256 digest")
+	ErrUploadOffsetMismatch   = errors.New("upload offset does not match the current offset of the upload")
+	ErrUploadFinished         = errors.New("upload has already been finished")
+	ErrUploadLengthExceeded   = errors.New("upload data exceeds the declared length of the upload")
+	ErrUploadChecksumMismatch = errors.New("SHA-256 checksum of the uploaded file does not match the declared checksum")
+)
+
+// StartResumableUpload creates an empty file in the given location to assemble an upload of the given length into. The
+// length may not exceed the given maximum length unless the maximum is zero.
+func StartResumableUpload(ctx context.Context, db FileUploadData, location string, job model.FileUploadJob, fileType model.FileType, length, maxLength int64, checksum string) (model.ResumableUpload, error) {
+	checksum = strings.ToLower(checksum)
+
+	if length <= 0 {
+		return model.ResumableUpload{}, ErrInvalidUploadLength
+	} else if maxLength > 0 && length > maxLength {
+		return model.ResumableUpload{}, fmt.Errorf("%w of %d bytes", ErrUploadTooLarge, maxLength)
+	} else if digest, err := hex.DecodeString(checksum); err != nil || len(digest) != sha256.Size {
+		return model.ResumableUpload{}, ErrInvalidUploadChecksum
+	} else if uploadFile, err := os.CreateTemp(location, "bh"); err != nil {
+		return model.ResumableUpload{}, fmt.Errorf("error creating upload file: %w", err)
+	} else {
+		if err := uploadFile.Close(); err != nil {
+			log.Errorf("Error closing upload file %s: %v", uploadFile.Name(), err)
+		}
+
+		upload, err := db.CreateResumableUpload(ctx, model.ResumableUpload{
+			FileUploadJobID: job.ID,
+			FileName:        uploadFile.Name(),
+			FileType:        fileType,
+			Length:          length,
+			SHA256:          checksum,
+		})
+
+		if err != nil {
+			removeUploadFile(uploadFile.Name())
+		}
+
+		return upload, err
+	}
+}
+
+// WriteResumableUpload appends data to the upload starting at the given offset, which must match the current offset of
+// the upload. The upload is locked while data is written so that concurrent writes can not interleave. The new offset
+// is recorded even when reading data fails part way through so that the client may resume from the last byte received.
+//
+// The write that completes the upload marks it as finished while holding the lock. Only the caller that receives a
+// finished upload may finish it with FinishResumableUpload; any later write fails with ErrUploadFinished.
+func WriteResumableUpload(ctx context.Context, db FileUploadData, upload model.ResumableUpload, offset int64, data io.Reader) (model.ResumableUpload, error) {
+	var writeErr error
+
+	upload, err := db.LockResumableUpload(ctx, upload.FileUploadJobID, upload.ID, func(upload *model.ResumableUpload) error {
+		if upload.Finished {
+			return ErrUploadFinished
+		} else if offset != upload.Offset {
+			return ErrUploadOffsetMismatch
+		}
+
+		uploadFile, err := os.OpenFile(upload.FileName, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("error opening upload file: %w", err)
+		}
+
+		// Discard any bytes from a previously interrupted write that were not recorded in the upload offset
+		if err := uploadFile.Truncate(upload.Offset); err != nil {
+			uploadFile.Close()
+			return fmt.Errorf("error truncating upload file: %w", err)
+		} else if _, err := uploadFile.Seek(upload.Offset, io.SeekStart); err != nil {
+			uploadFile.Close()
+			return fmt.Errorf("error seeking upload file: %w", err)
+		}
+
+		written, err := io.Copy(uploadFile, io.LimitReader(data, upload.Length-upload.Offset))
+
+		if closeErr := uploadFile.Close(); closeErr != nil && err == nil {
+			return fmt.Errorf("error closing upload file: %w", closeErr)
+		}
+
+		upload.Offset += written
+
+		if err != nil {
+			writeErr = fmt.Errorf("error writing upload file: %w", err)
+		} else if upload.IsComplete() {
+			if extra, _ := data.Read(make([]byte, 1)); extra > 0 {
+				writeErr = ErrUploadLengthExceeded
+			} else {
+				upload.Finished = true
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return upload, err
+	}
+
+	return upload, writeErr
+}
+
+// FinishResumableUpload verifies the SHA-256 checksum of a completely uploaded file and then validates its content. The
+// name of the validated file, suitable for an ingest task, is returned. The assembled upload file is removed.
+func FinishResumableUpload(location string, upload model.ResumableUpload) (string, error) {
+	uploadFile, err := os.Open(upload.FileName)
+	if err != nil {
+		return "", fmt.Errorf("error opening upload file: %w", err)
+	}
+
+	defer func() {
+		uploadFile.Close()
+		removeUploadFile(upload.FileName)
+	}()
+
+	digest := sha256.New()
+
+	if _, err := io.Copy(digest, uploadFile); err != nil {
+		return "", fmt.Errorf("error reading upload file: %w", err)
+	} else if hex.EncodeToString(digest.Sum(nil)) != upload.SHA256 {
+		return "", ErrUploadChecksumMismatch
+	} else if _, err := uploadFile.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("error seeking upload file: %w", err)
+	} else if tempFile, err := os.CreateTemp(location, "bh"); err != nil {
+		return "", fmt.Errorf("error creating ingest file: %w", err)
+	} else {
+		return tempFile.Name(), WriteAndValidateFile(uploadFile, tempFile, validatorForFileType(upload.FileType))
+	}
+}
+
+// AbortResumableUpload removes the upload and its partially assembled file
+func AbortResumableUpload(ctx context.Context, db FileUploadData, upload model.ResumableUpload) error {
+	removeUploadFile(upload.FileName)
+	return db.DeleteResumableUpload(ctx, upload)
+}
+
+func removeUploadFile(path string) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Errorf("Error deleting upload file %s: %v", path, err)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fileupload_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/fileupload"
+	"github.com/specterops/bloodhound/src/services/fileupload/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testUploadFile = `{"data": [], "meta": {"type": "users", "version": 5, "count": 0, "methods": 0}}`
+
+var errDroppedConnection = errors.New("connection reset")
+
+// droppedReader returns the first n bytes of the underlying reader and then fails
+type droppedReader struct {
+	reader io.Reader
+	n      int
+}
+
+func (s *droppedReader) Read(p []byte) (int, error) {
+	if s.n <= 0 {
+		return 0, errDroppedConnection
+	} else if len(p) > s.n {
+		p = p[:s.n]
+	}
+
+	read, err := s.reader.Read(p)
+	s.n -= read
+
+	return read, err
+}
+
+func checksum(content string) string {
+	digest := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(digest[:])
+}
+
+func startTestUpload(t *testing.T, mockDB *mocks.MockFileUploadData, location, content, contentChecksum string) model.ResumableUpload {
+	mockDB.EXPECT().CreateResumableUpload(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, upload model.ResumableUpload) (model.ResumableUpload, error) {
+		upload.ID = 1
+		return upload, nil
+	})
+
+	upload, err := fileupload.StartResumableUpload(context.Background(), mockDB, location, model.FileUploadJob{BigSerial: model.BigSerial{ID: 5}}, model.FileTypeJson, int64(len(content)), 0, contentChecksum)
+	require.Nil(t, err)
+	require.Equal(t, int64(5), upload.FileUploadJobID)
+	require.FileExists(t, upload.FileName)
+
+	return upload
+}
+
+// expectLockedWrites stores the given upload and serializes the given number of locked writes to it like the row lock
+// of the database does
+func expectLockedWrites(mockDB *mocks.MockFileUploadData, upload model.ResumableUpload, times int) {
+	var (
+		stored = upload
+		lock   = &sync.Mutex{}
+	)
+
+	mockDB.EXPECT().LockResumableUpload(gomock.Any(), upload.FileUploadJobID, upload.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _, _ int64, delegate func(upload *model.ResumableUpload) error) (model.ResumableUpload, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		current := stored
+
+		if err := delegate(&current); err != nil {
+			return current, err
+		}
+
+		stored = current
+		return current, nil
+	}).Times(times)
+}
+
+func TestStartResumableUpload_Invalid(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockFileUploadData(mockCtrl)
+	)
+
+	_, err := fileupload.StartResumableUpload(context.Background(), mockDB, t.TempDir(), model.FileUploadJob{}, model.FileTypeJson, 0, 0, checksum(testUploadFile))
+	require.ErrorIs(t, err, fileupload.ErrInvalidUploadLength)
+
+	_, err = fileupload.StartResumableUpload(context.Background(), mockDB, t.TempDir(), model.FileUploadJob{}, model.FileTypeJson, 10, 0, "abc")
+	require.ErrorIs(t, err, fileupload.ErrInvalidUploadChecksum)
+
+	_, err = fileupload.StartResumableUpload(context.Background(), mockDB, t.TempDir(), model.FileUploadJob{}, model.FileTypeJson, 11, 10, checksum(testUploadFile))
+	require.ErrorIs(t, err, fileupload.ErrUploadTooLarge)
+}
+
+func TestWriteResumableUpload(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockFileUploadData(mockCtrl)
+		location = t.TempDir()
+		upload   = startTestUpload(t, mockDB, location, testUploadFile, strings.ToUpper(checksum(testUploadFile)))
+	)
+
+	expectLockedWrites(mockDB, upload, 4)
+
+	// A dropped connection records the bytes received so far
+	upload, err := fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 0, &droppedReader{reader: strings.NewReader(testUploadFile), n: 10})
+	require.ErrorIs(t, err, errDroppedConnection)
+	require.Equal(t, int64(10), upload.Offset)
+	require.False(t, upload.IsComplete())
+
+	// Writes must resume from the recorded offset
+	_, err = fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 0, strings.NewReader(testUploadFile))
+	require.ErrorIs(t, err, fileupload.ErrUploadOffsetMismatch)
+
+	upload, err = fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 10, strings.NewReader(testUploadFile[10:20]))
+	require.Nil(t, err)
+	require.Equal(t, int64(20), upload.Offset)
+
+	upload, err = fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 20, strings.NewReader(testUploadFile[20:]))
+	require.Nil(t, err)
+	require.True(t, upload.IsComplete())
+	require.True(t, upload.Finished)
+
+	fileName, err := fileupload.FinishResumableUpload(location, upload)
+	require.Nil(t, err)
+	require.NoFileExists(t, upload.FileName)
+
+	content, err := os.ReadFile(fileName)
+	require.Nil(t, err)
+	require.Equal(t, testUploadFile, string(content))
+}
+
+func TestWriteResumableUpload_LengthExceeded(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockFileUploadData(mockCtrl)
+		upload   = startTestUpload(t, mockDB, t.TempDir(), testUploadFile, checksum(testUploadFile))
+	)
+
+	expectLockedWrites(mockDB, upload, 1)
+
+	upload, err := fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 0, strings.NewReader(testUploadFile+"extra"))
+	require.ErrorIs(t, err, fileupload.ErrUploadLengthExceeded)
+	require.Equal(t, int64(len(testUploadFile)), upload.Offset)
+	require.False(t, upload.Finished)
+}
+
+func TestWriteResumableUpload_Concurrent(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockFileUploadData(mockCtrl)
+		location = t.TempDir()
+		upload   = startTestUpload(t, mockDB, location, testUploadFile, checksum(testUploadFile))
+		results  = make(chan error, 2)
+	)
+
+	expectLockedWrites(mockDB, upload, 2)
+
+	// Both writes start from the same offset but only the first one to lock the upload may write
+	for range 2 {
+		go func() {
+			_, err := fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 0, strings.NewReader(testUploadFile[:10]))
+			results <- err
+		}()
+	}
+
+	var errs []error
+
+	for range 2 {
+		if err := <-results; err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], fileupload.ErrUploadOffsetMismatch)
+
+	content, err := os.ReadFile(upload.FileName)
+	require.Nil(t, err)
+	require.Equal(t, testUploadFile[:10], string(content))
+}
+
+func TestWriteResumableUpload_ConcurrentFinalWrites(t *testing.T) {
+	type result struct {
+		upload model.ResumableUpload
+		err    error
+	}
+
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockFileUploadData(mockCtrl)
+		upload   = startTestUpload(t, mockDB, t.TempDir(), testUploadFile, checksum(testUploadFile))
+		results  = make(chan result, 2)
+	)
+
+	expectLockedWrites(mockDB, upload, 3)
+
+	upload, err := fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 0, strings.NewReader(testUploadFile[:10]))
+	require.Nil(t, err)
+
+	// A retried final write must not finish the upload a second time
+	for range 2 {
+		go func() {
+			finished, err := fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 10, strings.NewReader(testUploadFile[10:]))
+			results <- result{upload: finished, err: err}
+		}()
+	}
+
+	var (
+		numFinished int
+		errs        []error
+	)
+
+	for range 2 {
+		if next := <-results; next.err != nil {
+			errs = append(errs, next.err)
+		} else if next.upload.Finished {
+			numFinished++
+		}
+	}
+
+	require.Equal(t, 1, numFinished)
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], fileupload.ErrUploadFinished)
+}
+
+func TestFinishResumableUpload_ChecksumMismatch(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockFileUploadData(mockCtrl)
+		location = t.TempDir()
+		upload   = startTestUpload(t, mockDB, location, testUploadFile, checksum("something else"))
+	)
+
+	expectLockedWrites(mockDB, upload, 1)
+	mockDB.EXPECT().DeleteResumableUpload(gomock.Any(), gomock.Any()).Return(nil)
+
+	upload, err := fileupload.WriteResumableUpload(context.Background(), mockDB, upload, 0, strings.NewReader(testUploadFile))
+	require.Nil(t, err)
+
+	_, err = fileupload.FinishResumableUpload(location, upload)
+	require.ErrorIs(t, err, fileupload.ErrUploadChecksumMismatch)
+	require.Nil(t, fileupload.AbortResumableUpload(context.Background(), mockDB, upload))
+
+	entries, err := os.ReadDir(location)
+	require.Nil(t, err)
+	require.Empty(t, entries)
+}
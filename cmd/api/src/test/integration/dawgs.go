@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
 	"github.com/specterops/bloodhound/dawgs/drivers/neo4j"
 	"github.com/specterops/bloodhound/dawgs/drivers/pg"
 	"github.com/specterops/bloodhound/dawgs/graph"
@@ -56,6 +57,9 @@ func OpenGraphDB(testCtrl test.Controller, schema graph.Schema) graph.Database {
 			DriverCfg: cfg.Neo4J.Neo4jConnectionString(),
 		})
 
+	case memory.DriverName:
+		graphDatabase, err = dawgs.Open(context.TODO(), cfg.GraphDriver, dawgs.Config{})
+
 	default:
 		testCtrl.Fatalf("unsupported graph driver name %s", cfg.GraphDriver)
 	}
@@ -281,6 +281,24 @@ func (s *GraphTestContext) NewAzureSubscription(name, objectID, tenantID string)
 	}), azure.Entity, azure.Subscription)
 }
 
+func (s *GraphTestContext) NewAzureAdministrativeUnit(name, objectID, tenantID string, isMemberManagementRestricted bool) *graph.Node {
+	return s.NewNode(graph.AsProperties(graph.PropertyMap{
+		common.Name:                        name,
+		common.ObjectID:                    objectID,
+		azure.TenantID:                     tenantID,
+		azure.IsMemberManagementRestricted: isMemberManagementRestricted,
+	}), azure.Entity, azure.AdministrativeUnit)
+}
+
+func (s *GraphTestContext) NewAzureStorageAccount(name, objectID, tenantID string, allowSharedKeyAccess bool) *graph.Node {
+	return s.NewNode(graph.AsProperties(graph.PropertyMap{
+		common.Name:                name,
+		common.ObjectID:            objectID,
+		azure.TenantID:             tenantID,
+		azure.AllowSharedKeyAccess: allowSharedKeyAccess,
+	}), azure.Entity, azure.StorageAccount)
+}
+
 func (s *GraphTestContext) NewRelationship(startNode, endNode *graph.Node, kind graph.Kind, propertyBags ...*graph.Properties) *graph.Relationship {
 	var (
 		relationshipProperties = graph.NewPropertiesRed()
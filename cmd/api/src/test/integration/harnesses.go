@@ -631,6 +631,48 @@ func (s *RDPHarness) Setup(testCtx *GraphTestContext) {
 	testCtx.NewRelationship(s.RDPLocalGroup, s.Computer, ad.LocalToComputer, DefaultRelProperties)
 }
 
+type URAPrivilegeHarness struct {
+	Computer                  *graph.Node
+	AdministratorsLocalGroup  *graph.Node
+	BackupOperatorsLocalGroup *graph.Node
+	BackupOperatorsGroup      *graph.Node
+	AdminUser                 *graph.Node
+	BackupUser                *graph.Node
+	DebugUser                 *graph.Node
+	ImpersonateUser           *graph.Node
+}
+
+func (s *URAPrivilegeHarness) Setup(testCtx *GraphTestContext) {
+	domainSID := testCtx.Harness.RootADHarness.ActiveDirectoryDomainSID
+
+	s.Computer = testCtx.NewActiveDirectoryComputer("WIN12", domainSID)
+	s.AdministratorsLocalGroup = testCtx.NewActiveDirectoryLocalGroup("Administrators", domainSID)
+	s.BackupOperatorsLocalGroup = testCtx.NewActiveDirectoryLocalGroup("Backup Operators", domainSID)
+	s.BackupOperatorsGroup = testCtx.NewActiveDirectoryGroup("Server Backup Operators", domainSID)
+	s.AdminUser = testCtx.NewActiveDirectoryUser("AdminUser", domainSID)
+	s.BackupUser = testCtx.NewActiveDirectoryUser("BackupUser", domainSID)
+	s.DebugUser = testCtx.NewActiveDirectoryUser("DebugUser", domainSID)
+	s.ImpersonateUser = testCtx.NewActiveDirectoryUser("ImpersonateUser", domainSID)
+
+	administratorsObjectID, _ := s.AdministratorsLocalGroup.Properties.Get(common.ObjectID.String()).String()
+	s.AdministratorsLocalGroup.Properties.Set(common.ObjectID.String(), administratorsObjectID+adAnalysis.AdminGroupSuffix)
+	testCtx.UpdateNode(s.AdministratorsLocalGroup)
+
+	testCtx.NewRelationship(s.AdministratorsLocalGroup, s.Computer, ad.LocalToComputer, DefaultRelProperties)
+	testCtx.NewRelationship(s.AdministratorsLocalGroup, s.Computer, ad.DebugPrivilege, DefaultRelProperties)
+	testCtx.NewRelationship(s.AdministratorsLocalGroup, s.Computer, ad.ImpersonatePrivilege, DefaultRelProperties)
+	testCtx.NewRelationship(s.AdminUser, s.AdministratorsLocalGroup, ad.MemberOfLocalGroup, DefaultRelProperties)
+
+	testCtx.NewRelationship(s.BackupOperatorsLocalGroup, s.Computer, ad.LocalToComputer, DefaultRelProperties)
+	testCtx.NewRelationship(s.BackupOperatorsLocalGroup, s.Computer, ad.BackupPrivilege, DefaultRelProperties)
+	testCtx.NewRelationship(s.BackupOperatorsLocalGroup, s.Computer, ad.RestorePrivilege, DefaultRelProperties)
+	testCtx.NewRelationship(s.BackupOperatorsGroup, s.BackupOperatorsLocalGroup, ad.MemberOfLocalGroup, DefaultRelProperties)
+	testCtx.NewRelationship(s.BackupUser, s.BackupOperatorsGroup, ad.MemberOf, DefaultRelProperties)
+
+	testCtx.NewRelationship(s.DebugUser, s.Computer, ad.DebugPrivilege, DefaultRelProperties)
+	testCtx.NewRelationship(s.ImpersonateUser, s.Computer, ad.ImpersonatePrivilege, DefaultRelProperties)
+}
+
 type RDPHarnessWithCitrix struct {
 	IrshadUser *graph.Node
 	EliUser    *graph.Node
@@ -6875,6 +6917,123 @@ func (s *AZAddSecretHarness) Setup(graphTestContext *GraphTestContext) {
 	graphTestContext.NewRelationship(s.AZTenant, s.CloudAppAdminRole, azure.Contains)
 }
 
+type AZAdministrativeUnitHarness struct {
+	Tenant              *graph.Node
+	AdministrativeUnit  *graph.Node
+	RestrictedUnit      *graph.Node
+	HelpdeskAdminRole   *graph.Node
+	GroupsAdminRole     *graph.Node
+	TenantHelpdeskAdmin *graph.Node
+	ScopedHelpdeskAdmin *graph.Node
+	ScopedGroupsAdmin   *graph.Node
+	UnitUser            *graph.Node
+	UnitGroup           *graph.Node
+	RestrictedUnitUser  *graph.Node
+	UserOutsideUnits    *graph.Node
+}
+
+func (s *AZAdministrativeUnitHarness) Setup(graphTestContext *GraphTestContext) {
+	tenantID := RandomObjectID(graphTestContext.testCtx)
+	s.Tenant = graphTestContext.NewAzureTenant(tenantID)
+
+	administrativeUnitID := RandomObjectID(graphTestContext.testCtx)
+	s.AdministrativeUnit = graphTestContext.NewAzureAdministrativeUnit("AdministrativeUnit", administrativeUnitID, tenantID, false)
+	s.RestrictedUnit = graphTestContext.NewAzureAdministrativeUnit("RestrictedUnit", RandomObjectID(graphTestContext.testCtx), tenantID, true)
+
+	s.HelpdeskAdminRole = graphTestContext.NewAzureRole("HelpdeskAdminRole", RandomObjectID(graphTestContext.testCtx), azure.HelpdeskAdministratorRole, tenantID)
+	s.GroupsAdminRole = graphTestContext.NewAzureRole("GroupsAdminRole", RandomObjectID(graphTestContext.testCtx), azure.GroupsAdministratorRole, tenantID)
+
+	s.TenantHelpdeskAdmin = graphTestContext.NewAzureUser("TenantHelpdeskAdmin", "TenantHelpdeskAdmin", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.ScopedHelpdeskAdmin = graphTestContext.NewAzureUser("ScopedHelpdeskAdmin", "ScopedHelpdeskAdmin", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.ScopedGroupsAdmin = graphTestContext.NewAzureUser("ScopedGroupsAdmin", "ScopedGroupsAdmin", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.UnitUser = graphTestContext.NewAzureUser("UnitUser", "UnitUser", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.RestrictedUnitUser = graphTestContext.NewAzureUser("RestrictedUnitUser", "RestrictedUnitUser", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.UserOutsideUnits = graphTestContext.NewAzureUser("UserOutsideUnits", "UserOutsideUnits", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.UnitGroup = graphTestContext.NewAzureGroup("UnitGroup", RandomObjectID(graphTestContext.testCtx), tenantID)
+	s.UnitGroup.Properties.Set(azure.IsAssignableToRole.String(), false)
+	graphTestContext.UpdateNode(s.UnitGroup)
+
+	for _, node := range []*graph.Node{s.AdministrativeUnit, s.RestrictedUnit, s.HelpdeskAdminRole, s.GroupsAdminRole, s.TenantHelpdeskAdmin, s.ScopedHelpdeskAdmin, s.ScopedGroupsAdmin, s.UnitUser, s.RestrictedUnitUser, s.UserOutsideUnits, s.UnitGroup} {
+		graphTestContext.NewRelationship(s.Tenant, node, azure.Contains)
+	}
+
+	graphTestContext.NewRelationship(s.UnitUser, s.AdministrativeUnit, azure.AdministrativeUnitMember)
+	graphTestContext.NewRelationship(s.UnitGroup, s.AdministrativeUnit, azure.AdministrativeUnitMember)
+	graphTestContext.NewRelationship(s.RestrictedUnitUser, s.RestrictedUnit, azure.AdministrativeUnitMember)
+
+	graphTestContext.NewRelationship(s.TenantHelpdeskAdmin, s.HelpdeskAdminRole, azure.HasRole)
+	graphTestContext.NewRelationship(s.ScopedHelpdeskAdmin, s.HelpdeskAdminRole, azure.HasScopedRole, graph.AsProperties(graph.PropertyMap{
+		azure.Scope: administrativeUnitID,
+	}))
+	graphTestContext.NewRelationship(s.ScopedGroupsAdmin, s.GroupsAdminRole, azure.HasScopedRole, graph.AsProperties(graph.PropertyMap{
+		azure.Scope: administrativeUnitID,
+	}))
+}
+
+type AZStorageAccountHarness struct {
+	Tenant                      *graph.Node
+	ResourceGroup               *graph.Node
+	SharedKeyStorageAccount     *graph.Node
+	EntraOnlyStorageAccount     *graph.Node
+	StorageAccountContributor   *graph.Node
+	StorageAccountKeyOperator   *graph.Node
+	StorageBlobDataOwner        *graph.Node
+	EntraOnlyAccountContributor *graph.Node
+}
+
+func (s *AZStorageAccountHarness) Setup(graphTestContext *GraphTestContext) {
+	tenantID := RandomObjectID(graphTestContext.testCtx)
+	s.Tenant = graphTestContext.NewAzureTenant(tenantID)
+	s.ResourceGroup = graphTestContext.NewAzureResourceGroup("ResourceGroup", RandomObjectID(graphTestContext.testCtx), tenantID)
+	s.SharedKeyStorageAccount = graphTestContext.NewAzureStorageAccount("SharedKeyStorageAccount", RandomObjectID(graphTestContext.testCtx), tenantID, true)
+	s.EntraOnlyStorageAccount = graphTestContext.NewAzureStorageAccount("EntraOnlyStorageAccount", RandomObjectID(graphTestContext.testCtx), tenantID, false)
+
+	s.StorageAccountContributor = graphTestContext.NewAzureUser("StorageAccountContributor", "StorageAccountContributor", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.StorageAccountKeyOperator = graphTestContext.NewAzureServicePrincipal("StorageAccountKeyOperator", RandomObjectID(graphTestContext.testCtx), tenantID)
+	s.StorageBlobDataOwner = graphTestContext.NewAzureUser("StorageBlobDataOwner", "StorageBlobDataOwner", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.EntraOnlyAccountContributor = graphTestContext.NewAzureUser("EntraOnlyAccountContributor", "EntraOnlyAccountContributor", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+
+	graphTestContext.NewRelationship(s.Tenant, s.ResourceGroup, azure.Contains)
+	graphTestContext.NewRelationship(s.ResourceGroup, s.SharedKeyStorageAccount, azure.Contains)
+	graphTestContext.NewRelationship(s.ResourceGroup, s.EntraOnlyStorageAccount, azure.Contains)
+
+	for _, node := range []*graph.Node{s.StorageAccountContributor, s.StorageAccountKeyOperator, s.StorageBlobDataOwner, s.EntraOnlyAccountContributor} {
+		graphTestContext.NewRelationship(s.Tenant, node, azure.Contains)
+	}
+
+	graphTestContext.NewRelationship(s.StorageAccountContributor, s.SharedKeyStorageAccount, azure.StorageAccountContributor)
+	graphTestContext.NewRelationship(s.StorageAccountKeyOperator, s.SharedKeyStorageAccount, azure.StorageAccountKeyOperator)
+	graphTestContext.NewRelationship(s.StorageBlobDataOwner, s.SharedKeyStorageAccount, azure.StorageBlobDataOwner)
+	graphTestContext.NewRelationship(s.EntraOnlyAccountContributor, s.EntraOnlyStorageAccount, azure.StorageAccountContributor)
+}
+
+type AZGuestHarness struct {
+	HomeTenant       *graph.Node
+	ResourceTenant   *graph.Node
+	HomeUser         *graph.Node
+	GuestUser        *graph.Node
+	UncollectedGuest *graph.Node
+	MemberUser       *graph.Node
+}
+
+func (s *AZGuestHarness) Setup(graphTestContext *GraphTestContext) {
+	homeTenantID := RandomObjectID(graphTestContext.testCtx)
+	resourceTenantID := RandomObjectID(graphTestContext.testCtx)
+
+	s.HomeTenant = graphTestContext.NewAzureTenant(homeTenantID)
+	s.ResourceTenant = graphTestContext.NewAzureTenant(resourceTenantID)
+
+	s.HomeUser = graphTestContext.NewAzureUser("ALICE@CONTOSO.COM", "alice@contoso.com", "", RandomObjectID(graphTestContext.testCtx), "", homeTenantID, false)
+	s.GuestUser = graphTestContext.NewAzureUser("ALICE_CONTOSO.COM#EXT#@FABRIKAM.ONMICROSOFT.COM", "alice_contoso.com#EXT#@fabrikam.onmicrosoft.com", "", RandomObjectID(graphTestContext.testCtx), "", resourceTenantID, false)
+	s.UncollectedGuest = graphTestContext.NewAzureUser("BOB_NORTHWIND.COM#EXT#@FABRIKAM.ONMICROSOFT.COM", "bob_northwind.com#EXT#@fabrikam.onmicrosoft.com", "", RandomObjectID(graphTestContext.testCtx), "", resourceTenantID, false)
+	s.MemberUser = graphTestContext.NewAzureUser("CAROL@FABRIKAM.ONMICROSOFT.COM", "carol@fabrikam.onmicrosoft.com", "", RandomObjectID(graphTestContext.testCtx), "", resourceTenantID, false)
+
+	graphTestContext.NewRelationship(s.HomeTenant, s.HomeUser, azure.Contains)
+	graphTestContext.NewRelationship(s.ResourceTenant, s.GuestUser, azure.Contains)
+	graphTestContext.NewRelationship(s.ResourceTenant, s.UncollectedGuest, azure.Contains)
+	graphTestContext.NewRelationship(s.ResourceTenant, s.MemberUser, azure.Contains)
+}
+
 type ExtendedByPolicyHarness struct {
 	IssuancePolicy0 *graph.Node
 	IssuancePolicy1 *graph.Node
@@ -7067,6 +7226,46 @@ func (s *HybridAttackPaths) Setup(graphTestContext *GraphTestContext, adUserObje
 	}
 }
 
+type EntraConnectHarness struct {
+	AZTenant         *graph.Node
+	SyncAccountsRole *graph.Node
+	EntraSyncAccount *graph.Node
+	SyncedEntraUser  *graph.Node
+	CloudOnlyUser    *graph.Node
+	EntraConnect     *graph.Node
+	ConnectorAccount *graph.Node
+	OtherComputer    *graph.Node
+}
+
+func (s *EntraConnectHarness) Setup(graphTestContext *GraphTestContext) {
+	tenantID := RandomObjectID(graphTestContext.testCtx)
+	domainSID := RandomDomainSID()
+
+	s.AZTenant = graphTestContext.NewAzureTenant(tenantID)
+	s.SyncAccountsRole = graphTestContext.NewAzureRole("Directory Synchronization Accounts", RandomObjectID(graphTestContext.testCtx), azure.DirectorySynchronizationAccountsRole, tenantID)
+	s.EntraSyncAccount = graphTestContext.NewAzureUser("SYNC_AADCONNECT_0123456789AB@CONTOSO.ONMICROSOFT.COM", "Sync_AADCONNECT_0123456789ab@contoso.onmicrosoft.com", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.CloudOnlyUser = graphTestContext.NewAzureUser("CLOUDONLY@CONTOSO.ONMICROSOFT.COM", "cloudonly@contoso.onmicrosoft.com", "", RandomObjectID(graphTestContext.testCtx), "", tenantID, false)
+	s.SyncedEntraUser = graphTestContext.NewCustomAzureUser(graph.AsProperties(graph.PropertyMap{
+		common.Name:             "SYNCED@CONTOSO.COM",
+		common.ObjectID:         RandomObjectID(graphTestContext.testCtx),
+		azure.TenantID:          tenantID,
+		azure.OnPremSyncEnabled: true,
+		azure.OnPremID:          RandomObjectID(graphTestContext.testCtx),
+	}))
+
+	for _, node := range []*graph.Node{s.SyncAccountsRole, s.EntraSyncAccount, s.CloudOnlyUser, s.SyncedEntraUser} {
+		graphTestContext.NewRelationship(s.AZTenant, node, azure.Contains)
+	}
+
+	graphTestContext.NewRelationship(s.EntraSyncAccount, s.SyncAccountsRole, azure.HasRole)
+
+	s.EntraConnect = graphTestContext.NewActiveDirectoryComputer("AADCONNECT.CONTOSO.LOCAL", domainSID)
+	s.OtherComputer = graphTestContext.NewActiveDirectoryComputer("WORKSTATION.CONTOSO.LOCAL", domainSID)
+	s.ConnectorAccount = graphTestContext.NewActiveDirectoryUser("MSOL_0123456789AB@CONTOSO.LOCAL", domainSID)
+	s.ConnectorAccount.Properties.Set(common.Description.String(), "Account created by Microsoft Azure Active Directory Connect with installation identifier 0123456789ab running on computer AADCONNECT configured to synchronize to tenant contoso.onmicrosoft.com. This account must have directory replication permissions in the local Active Directory and write permission on certain attributes to enable Hybrid Deployment.")
+	graphTestContext.UpdateNode(s.ConnectorAccount)
+}
+
 type DCSyncHarness struct {
 	Domain1 *graph.Node
 
@@ -8460,6 +8659,7 @@ type HarnessDetails struct {
 	RDP                                             RDPHarness
 	RDPB                                            RDPHarness2
 	RDPHarnessWithCitrix                            RDPHarnessWithCitrix
+	URAPrivilegeHarness                             URAPrivilegeHarness
 	GPOEnforcement                                  GPOEnforcementHarness
 	Session                                         SessionHarness
 	LocalGroupSQL                                   LocalGroupHarness
@@ -8502,6 +8702,9 @@ type HarnessDetails struct {
 	AZInboundControlHarness                         AZInboundControlHarness
 	ExtendedByPolicyHarness                         ExtendedByPolicyHarness
 	AZAddSecretHarness                              AZAddSecretHarness
+	AZAdministrativeUnitHarness                     AZAdministrativeUnitHarness
+	AZStorageAccountHarness                         AZStorageAccountHarness
+	AZGuestHarness                                  AZGuestHarness
 	ESC3Harness1                                    ESC3Harness1
 	ESC3Harness2                                    ESC3Harness2
 	ESC3Harness3                                    ESC3Harness3
@@ -8555,4 +8758,5 @@ type HarnessDetails struct {
 	DCSyncHarness                                   DCSyncHarness
 	SyncLAPSPasswordHarness                         SyncLAPSPasswordHarness
 	HybridAttackPaths                               HybridAttackPaths
+	EntraConnectHarness                             EntraConnectHarness
 }
@@ -113,6 +113,12 @@ function* runPathfindingQuery(payload: PathfindingRequest): SagaIterator {
     const standardExclusions = [
         ActiveDirectoryRelationshipKind.LocalToComputer,
         ActiveDirectoryRelationshipKind.RemoteInteractiveLogonRight,
+        ActiveDirectoryRelationshipKind.BackupPrivilege,
+        ActiveDirectoryRelationshipKind.RestorePrivilege,
+        ActiveDirectoryRelationshipKind.DebugPrivilege,
+        ActiveDirectoryRelationshipKind.ImpersonatePrivilege,
+        ActiveDirectoryRelationshipKind.LoadDriverPrivilege,
+        ActiveDirectoryRelationshipKind.TakeOwnershipPrivilege,
         ActiveDirectoryRelationshipKind.MemberOfLocalGroup,
         ActiveDirectoryRelationshipKind.GetChanges,
         ActiveDirectoryRelationshipKind.GetChangesAll,
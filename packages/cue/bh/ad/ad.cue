@@ -217,6 +217,34 @@ LogonType: types.#StringEnum & {
 	representation: "logontype"
 }
 
+SessionSource: types.#StringEnum & {
+	symbol:         "SessionSource"
+	schema:         "ad"
+	name:           "Session Source"
+	representation: "sessionsource"
+}
+
+NetSessionEnumLastSeen: types.#StringEnum & {
+	symbol:         "NetSessionEnumLastSeen"
+	schema:         "ad"
+	name:           "NetSessionEnum Last Seen"
+	representation: "netsessionenumlastseen"
+}
+
+NetWkstaUserEnumLastSeen: types.#StringEnum & {
+	symbol:         "NetWkstaUserEnumLastSeen"
+	schema:         "ad"
+	name:           "NetWkstaUserEnum Last Seen"
+	representation: "netwkstauserenumlastseen"
+}
+
+RegistryLastSeen: types.#StringEnum & {
+	symbol:         "RegistryLastSeen"
+	schema:         "ad"
+	name:           "Registry Last Seen"
+	representation: "registrylastseen"
+}
+
 Department: types.#StringEnum & {
 	symbol:         "Department"
 	schema:         "ad"
@@ -294,6 +322,48 @@ HasURA: types.#StringEnum & {
 	representation: "hasura"
 }
 
+GPOLocalAdmins: types.#StringEnum & {
+	symbol:         "GPOLocalAdmins"
+	schema:         "ad"
+	name:           "GPO Local Admins"
+	representation: "gpolocaladmins"
+}
+
+GPORemoteDesktopUsers: types.#StringEnum & {
+	symbol:         "GPORemoteDesktopUsers"
+	schema:         "ad"
+	name:           "GPO Remote Desktop Users"
+	representation: "gporemotedesktopusers"
+}
+
+GPODcomUsers: types.#StringEnum & {
+	symbol:         "GPODcomUsers"
+	schema:         "ad"
+	name:           "GPO DCOM Users"
+	representation: "gpodcomusers"
+}
+
+GPOPSRemoteUsers: types.#StringEnum & {
+	symbol:         "GPOPSRemoteUsers"
+	schema:         "ad"
+	name:           "GPO PSRemote Users"
+	representation: "gpopsremoteusers"
+}
+
+GPOAdminPrivilegeHolders: types.#StringEnum & {
+	symbol:         "GPOAdminPrivilegeHolders"
+	schema:         "ad"
+	name:           "GPO Admin Privilege Holders"
+	representation: "gpoadminprivilegeholders"
+}
+
+GPORemoteInteractiveLogonRight: types.#StringEnum & {
+	symbol:         "GPORemoteInteractiveLogonRight"
+	schema:         "ad"
+	name:           "GPO Remote Interactive Logon Right"
+	representation: "gporemoteinteractivelogonright"
+}
+
 PasswordNeverExpires: types.#StringEnum & {
 	symbol:         "PasswordNeverExpires"
 	schema:         "ad"
@@ -774,7 +844,17 @@ Properties: [
 	HasLAPS,
 	DontRequirePreAuth,
 	LogonType,
+	SessionSource,
+	NetSessionEnumLastSeen,
+	NetWkstaUserEnumLastSeen,
+	RegistryLastSeen,
 	HasURA,
+	GPOLocalAdmins,
+	GPORemoteDesktopUsers,
+	GPODcomUsers,
+	GPOPSRemoteUsers,
+	GPOAdminPrivilegeHolders,
+	GPORemoteInteractiveLogonRight,
 	PasswordNeverExpires,
 	PasswordNotRequired,
 	FunctionalLevel,
@@ -1118,6 +1198,36 @@ RemoteInteractiveLogonRight: types.#Kind & {
 	schema: "active_directory"
 }
 
+BackupPrivilege: types.#Kind & {
+	symbol: "BackupPrivilege"
+	schema: "active_directory"
+}
+
+RestorePrivilege: types.#Kind & {
+	symbol: "RestorePrivilege"
+	schema: "active_directory"
+}
+
+DebugPrivilege: types.#Kind & {
+	symbol: "DebugPrivilege"
+	schema: "active_directory"
+}
+
+ImpersonatePrivilege: types.#Kind & {
+	symbol: "ImpersonatePrivilege"
+	schema: "active_directory"
+}
+
+LoadDriverPrivilege: types.#Kind & {
+	symbol: "LoadDriverPrivilege"
+	schema: "active_directory"
+}
+
+TakeOwnershipPrivilege: types.#Kind & {
+	symbol: "TakeOwnershipPrivilege"
+	schema: "active_directory"
+}
+
 SyncLAPSPassword: types.#Kind & {
 	symbol: "SyncLAPSPassword"
 	schema: "active_directory"
@@ -1288,6 +1398,11 @@ SyncedToEntraUser: types.#Kind & {
 	schema: "active_directory"
 }
 
+DumpEntraConnectCredentials: types.#Kind & {
+	symbol: "DumpEntraConnectCredentials"
+	schema: "active_directory"
+}
+
 // Relationship Kinds
 RelationshipKinds: [
 	Owns,
@@ -1326,6 +1441,12 @@ RelationshipKinds: [
 	LocalToComputer,
 	MemberOfLocalGroup,
 	RemoteInteractiveLogonRight,
+	BackupPrivilege,
+	RestorePrivilege,
+	DebugPrivilege,
+	ImpersonatePrivilege,
+	LoadDriverPrivilege,
+	TakeOwnershipPrivilege,
 	SyncLAPSPassword,
 	WriteAccountRestrictions,
 	WriteGPLink,
@@ -1358,6 +1479,7 @@ RelationshipKinds: [
 	ADCSESC10b,
 	ADCSESC13,
 	SyncedToEntraUser,
+	DumpEntraConnectCredentials,
 ]
 
 // ACL Relationships
@@ -1438,6 +1560,7 @@ PathfindingRelationships: [
 	ADCSESC13,
 	DCFor,
 	SyncedToEntraUser,
+	DumpEntraConnectCredentials,
 ]
 
 EdgeCompositionRelationships: [
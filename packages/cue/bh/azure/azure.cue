@@ -243,6 +243,83 @@ ServicePrincipalNames: types.#StringEnum & {
 	representation: "service_principal_names"
 }
 
+IsMemberManagementRestricted: types.#StringEnum & {
+	symbol:         "IsMemberManagementRestricted"
+	schema:         "azure"
+	name:           "Is Member Management Restricted"
+	representation: "ismembermanagementrestricted"
+}
+
+AllowedResourceActions: types.#StringEnum & {
+	symbol:         "AllowedResourceActions"
+	schema:         "azure"
+	name:           "Allowed Resource Actions"
+	representation: "allowedresourceactions"
+}
+
+ExcludedResourceActions: types.#StringEnum & {
+	symbol:         "ExcludedResourceActions"
+	schema:         "azure"
+	name:           "Excluded Resource Actions"
+	representation: "excludedresourceactions"
+}
+
+AllowedDataActions: types.#StringEnum & {
+	symbol:         "AllowedDataActions"
+	schema:         "azure"
+	name:           "Allowed Data Actions"
+	representation: "alloweddataactions"
+}
+
+ExcludedDataActions: types.#StringEnum & {
+	symbol:         "ExcludedDataActions"
+	schema:         "azure"
+	name:           "Excluded Data Actions"
+	representation: "excludeddataactions"
+}
+
+RoleDefinitionID: types.#StringEnum & {
+	symbol:         "RoleDefinitionID"
+	schema:         "azure"
+	name:           "Role Definition ID"
+	representation: "roledefinitionid"
+}
+
+AllowSharedKeyAccess: types.#StringEnum & {
+	symbol:         "AllowSharedKeyAccess"
+	schema:         "azure"
+	name:           "Allow Shared Key Access"
+	representation: "allowsharedkeyaccess"
+}
+
+IsMFAAccepted: types.#StringEnum & {
+	symbol:         "IsMFAAccepted"
+	schema:         "azure"
+	name:           "Is MFA Accepted"
+	representation: "ismfaaccepted"
+}
+
+IsCompliantDeviceAccepted: types.#StringEnum & {
+	symbol:         "IsCompliantDeviceAccepted"
+	schema:         "azure"
+	name:           "Is Compliant Device Accepted"
+	representation: "iscompliantdeviceaccepted"
+}
+
+IsHybridJoinedDeviceAccepted: types.#StringEnum & {
+	symbol:         "IsHybridJoinedDeviceAccepted"
+	schema:         "azure"
+	name:           "Is Hybrid Joined Device Accepted"
+	representation: "ishybridjoineddeviceaccepted"
+}
+
+IsInboundSyncAllowed: types.#StringEnum & {
+	symbol:         "IsInboundSyncAllowed"
+	schema:         "azure"
+	name:           "Is Inbound Sync Allowed"
+	representation: "isinboundsyncallowed"
+}
+
 TenantID: types.#StringEnum & {
 	symbol:         "TenantID"
 	schema:         "azure"
@@ -283,6 +360,17 @@ Properties: [
 	PublisherDomain,
 	SignInAudience,
 	RoleTemplateID,
+	AllowedResourceActions,
+	ExcludedResourceActions,
+	AllowedDataActions,
+	ExcludedDataActions,
+	RoleDefinitionID,
+	IsMemberManagementRestricted,
+	AllowSharedKeyAccess,
+	IsMFAAccepted,
+	IsCompliantDeviceAccepted,
+	IsHybridJoinedDeviceAccepted,
+	IsInboundSyncAllowed,
 ]
 
 // Kinds
@@ -406,6 +494,30 @@ AutomationAccount: types.#Kind & {
 	representation: "AZAutomationAccount"
 }
 
+AdministrativeUnit: types.#Kind & {
+	symbol:         "AdministrativeUnit"
+	schema:         "azure"
+	representation: "AZAdministrativeUnit"
+}
+
+StorageAccount: types.#Kind & {
+	symbol:         "StorageAccount"
+	schema:         "azure"
+	representation: "AZStorageAccount"
+}
+
+StorageContainer: types.#Kind & {
+	symbol:         "StorageContainer"
+	schema:         "azure"
+	representation: "AZStorageContainer"
+}
+
+RoleDefinition: types.#Kind & {
+	symbol:         "RoleDefinition"
+	schema:         "azure"
+	representation: "AZRoleDefinition"
+}
+
 NodeKinds: [
 	Entity,
 	VMScaleSet,
@@ -427,6 +539,10 @@ NodeKinds: [
 	WebApp,
 	LogicApp,
 	AutomationAccount,
+	AdministrativeUnit,
+	StorageAccount,
+	StorageContainer,
+	RoleDefinition,
 ]
 
 AvereContributor: types.#Kind & {
@@ -717,6 +833,66 @@ LogicAppContributor: types.#Kind & {
 	representation:	"AZLogicAppContributor"
 }
 
+AdministrativeUnitMember: types.#Kind & {
+	symbol:         "AdministrativeUnitMember"
+	schema:         "azure"
+	representation: "AZAdministrativeUnitMember"
+}
+
+HasScopedRole: types.#Kind & {
+	symbol:         "HasScopedRole"
+	schema:         "azure"
+	representation: "AZHasScopedRole"
+}
+
+StorageAccountContributor: types.#Kind & {
+	symbol:         "StorageAccountContributor"
+	schema:         "azure"
+	representation: "AZStorageAccountContributor"
+}
+
+StorageAccountKeyOperator: types.#Kind & {
+	symbol:         "StorageAccountKeyOperator"
+	schema:         "azure"
+	representation: "AZStorageAccountKeyOperator"
+}
+
+StorageBlobDataOwner: types.#Kind & {
+	symbol:         "StorageBlobDataOwner"
+	schema:         "azure"
+	representation: "AZStorageBlobDataOwner"
+}
+
+StorageBlobDataContributor: types.#Kind & {
+	symbol:         "StorageBlobDataContributor"
+	schema:         "azure"
+	representation: "AZStorageBlobDataContributor"
+}
+
+ListStorageKeys: types.#Kind & {
+	symbol:         "ListStorageKeys"
+	schema:         "azure"
+	representation: "AZListStorageKeys"
+}
+
+HasGuestAccount: types.#Kind & {
+	symbol:         "HasGuestAccount"
+	schema:         "azure"
+	representation: "AZHasGuestAccount"
+}
+
+CrossTenantAccess: types.#Kind & {
+	symbol:         "CrossTenantAccess"
+	schema:         "azure"
+	representation: "AZCrossTenantAccess"
+}
+
+RBACRoleAssignment: types.#Kind & {
+	symbol:         "RBACRoleAssignment"
+	schema:         "azure"
+	representation: "AZRBACRoleAssignment"
+}
+
 SyncedToADUser: types.#Kind & {
 	symbol:			"SyncedToADUser"
 	schema:			"azure"
@@ -771,6 +947,16 @@ RelationshipKinds: [
 	AZMGGrantAppRoles,
 	AZMGGrantRole,
 	SyncedToADUser,
+	AdministrativeUnitMember,
+	HasScopedRole,
+	StorageAccountContributor,
+	StorageAccountKeyOperator,
+	StorageBlobDataOwner,
+	StorageBlobDataContributor,
+	ListStorageKeys,
+	HasGuestAccount,
+	CrossTenantAccess,
+	RBACRoleAssignment,
 ]
 
 AppRoleTransitRelationshipKinds: [
@@ -820,6 +1006,8 @@ ControlRelationshipKinds: [
 	AZMGAddSecret,
 	AZMGGrantAppRoles,
 	AZMGGrantRole,
+	StorageAccountContributor,
+	StorageAccountKeyOperator,
 ]
 
 ExecutionPrivilegeKinds: [
@@ -871,4 +1059,10 @@ PathfindingRelationships: [
 	AZMGGrantAppRoles,
 	AZMGGrantRole,
 	SyncedToADUser,
+	StorageAccountContributor,
+	StorageAccountKeyOperator,
+	StorageBlobDataOwner,
+	StorageBlobDataContributor,
+	ListStorageKeys,
+	HasGuestAccount,
 ]
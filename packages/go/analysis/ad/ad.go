@@ -36,8 +36,10 @@ import (
 )
 
 var (
-	AdminGroupSuffix = "-544"
-	RDPGroupSuffix   = "-555"
+	AdminGroupSuffix    = "-544"
+	RDPGroupSuffix      = "-555"
+	DCOMGroupSuffix     = "-562"
+	PSRemoteGroupSuffix = "-580"
 )
 
 const (
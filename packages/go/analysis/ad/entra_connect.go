@@ -0,0 +1,132 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/util/channels"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+)
+
+// EntraConnectAccountPrefix is the sAMAccountName prefix of the AD DS connector accounts created by the Entra Connect
+// express installation
+const EntraConnectAccountPrefix = "MSOL_"
+
+// entraConnectAccountDescription matches the description Entra Connect writes to its AD DS connector account, e.g.
+// "Account created by Microsoft Azure Active Directory Connect with installation identifier ... running on computer
+// AADCONNECT configured to synchronize to tenant contoso.onmicrosoft.com. ..."
+var entraConnectAccountDescription = regexp.MustCompile(`(?i)running on computer (\S+) configured to synchronize to tenant`)
+
+// EntraConnectServerName returns the NetBIOS name of the Entra Connect server from the description of its AD DS
+// connector account
+func EntraConnectServerName(description string) (string, bool) {
+	if matches := entraConnectAccountDescription.FindStringSubmatch(description); len(matches) != 2 {
+		return "", false
+	} else {
+		return strings.ToUpper(matches[1]), true
+	}
+}
+
+// FetchEntraConnectServers returns the computers with the given NetBIOS name. Computers in the given domain are
+// preferred, otherwise a computer is only returned if the name is unique across all collected domains.
+func FetchEntraConnectServers(tx graph.Transaction, serverName, domainSID string) (graph.NodeSet, error) {
+	if computers, err := ops.FetchNodeSet(tx.Nodes().Filterf(func() graph.Criteria {
+		return query.And(
+			query.Kind(query.Node(), ad.Computer),
+			query.StringStartsWith(query.NodeProperty(common.Name.String()), strings.ToUpper(serverName)+"."),
+		)
+	})); err != nil {
+		return nil, err
+	} else {
+		domainComputers := graph.NewNodeSet()
+
+		for _, computer := range computers {
+			if computerDomainSID, _ := computer.Properties.Get(ad.DomainSID.String()).String(); domainSID != "" && computerDomainSID == domainSID {
+				domainComputers.Add(computer)
+			}
+		}
+
+		if domainComputers.Len() > 0 {
+			return domainComputers, nil
+		} else if computers.Len() == 1 {
+			return computers, nil
+		} else {
+			return graph.NewNodeSet(), nil
+		}
+	}
+}
+
+// PostEntraConnect creates DumpEntraConnectCredentials edges from Entra Connect servers to the AD DS connector accounts
+// whose credentials they store. The connector accounts hold directory replication rights, so this ties the server to
+// the DCSync paths of the domain.
+func PostEntraConnect(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessingStats, error) {
+	operation := analysis.NewPostRelationshipOperation(ctx, db, "DumpEntraConnectCredentials Post Processing")
+
+	if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
+		if connectorAccounts, err := ops.FetchNodes(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Node(), ad.User),
+				query.StringStartsWith(query.NodeProperty(common.Name.String()), EntraConnectAccountPrefix),
+			)
+		})); err != nil {
+			return err
+		} else {
+			for _, connectorAccount := range connectorAccounts {
+				if description, err := connectorAccount.Properties.Get(common.Description.String()).String(); err != nil {
+					continue
+				} else if serverName, ok := EntraConnectServerName(description); !ok {
+					continue
+				} else {
+					domainSID, _ := connectorAccount.Properties.Get(ad.DomainSID.String()).String()
+
+					if servers, err := FetchEntraConnectServers(tx, serverName, domainSID); err != nil {
+						return err
+					} else {
+						for _, server := range servers {
+							if !channels.Submit(ctx, outC, analysis.CreatePostRelationshipJob{
+								FromID: server.ID,
+								ToID:   connectorAccount.ID,
+								Kind:   ad.DumpEntraConnectCredentials,
+							}) {
+								return nil
+							}
+						}
+					}
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		if err := operation.Done(); err != nil {
+			log.Errorf("Error caught during DumpEntraConnectCredentials teardown: %v", err)
+		}
+
+		return &operation.Stats, err
+	}
+
+	return &operation.Stats, operation.Done()
+}
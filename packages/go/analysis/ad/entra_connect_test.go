@@ -0,0 +1,33 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad_test
+
+import (
+	"testing"
+
+	ad2 "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntraConnectServerName(t *testing.T) {
+	serverName, ok := ad2.EntraConnectServerName("Account created by Microsoft Azure Active Directory Connect with installation identifier 0123456789ab running on computer aadconnect01 configured to synchronize to tenant contoso.onmicrosoft.com. This account must have directory replication permissions in the local Active Directory and write permission on certain attributes to enable Hybrid Deployment.")
+	assert.True(t, ok)
+	assert.Equal(t, "AADCONNECT01", serverName)
+
+	_, ok = ad2.EntraConnectServerName("Service account for the reporting service")
+	assert.False(t, ok)
+}
@@ -0,0 +1,247 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad
+
+import (
+	"context"
+	"strings"
+
+	"github.com/specterops/bloodhound/dawgs/cardinality"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+)
+
+// gpoLocalGroupProperties maps the SID suffixes of the builtin local groups to the GPO properties that record their
+// configured members
+var gpoLocalGroupProperties = map[string]ad.Property{
+	AdminGroupSuffix:    ad.GPOLocalAdmins,
+	RDPGroupSuffix:      ad.GPORemoteDesktopUsers,
+	DCOMGroupSuffix:     ad.GPODcomUsers,
+	PSRemoteGroupSuffix: ad.GPOPSRemoteUsers,
+}
+
+// GPOGrants are the principals that the GPOs applying to a computer configure as members of its builtin local groups
+// or as holders of its user rights assignments. The settings of all applicable GPOs are combined since precedence
+// between GPOs that configure the same group or right is not modeled.
+type GPOGrants struct {
+	LocalGroups                 map[string]cardinality.Duplex[uint64]
+	AdminPrivilegeHolders       cardinality.Duplex[uint64]
+	RemoteInteractiveLogonRight cardinality.Duplex[uint64]
+
+	// RemoteInteractiveLogonRightAssigned is set when a GPO configures the remote interactive logon right. When it is
+	// not, the default assignment to the Remote Desktop Users group is assumed.
+	RemoteInteractiveLogonRightAssigned bool
+
+	// RemoteDesktopUsersHaveLogonRight is set when a GPO assigns the remote interactive logon right to the builtin
+	// Remote Desktop Users group
+	RemoteDesktopUsersHaveLogonRight bool
+}
+
+func NewGPOGrants() *GPOGrants {
+	grants := &GPOGrants{
+		LocalGroups:                 make(map[string]cardinality.Duplex[uint64], len(gpoLocalGroupProperties)),
+		AdminPrivilegeHolders:       cardinality.NewBitmap64(),
+		RemoteInteractiveLogonRight: cardinality.NewBitmap64(),
+	}
+
+	for suffix := range gpoLocalGroupProperties {
+		grants.LocalGroups[suffix] = cardinality.NewBitmap64()
+	}
+
+	return grants
+}
+
+// ComputerGPOGrants holds the GPO grants of each computer that a GPO with local group or user rights settings applies to
+type ComputerGPOGrants map[graph.ID]*GPOGrants
+
+func gpoSettingsProperties() []ad.Property {
+	properties := []ad.Property{ad.GPOAdminPrivilegeHolders, ad.GPORemoteInteractiveLogonRight}
+
+	for _, property := range gpoLocalGroupProperties {
+		properties = append(properties, property)
+	}
+
+	return properties
+}
+
+// fetchGPOSettingsPrincipals resolves the object IDs recorded in the given GPO property to the IDs of the domain
+// principals they identify. Object IDs that do not resolve, such as those of builtin groups, are ignored.
+func fetchGPOSettingsPrincipals(tx graph.Transaction, gpo *graph.Node, property ad.Property) (cardinality.Duplex[uint64], error) {
+	if objectIDs, err := gpo.Properties.GetOrDefault(property.String(), []string{}).StringSlice(); err != nil || len(objectIDs) == 0 {
+		return cardinality.NewBitmap64(), nil
+	} else if principalIDs, err := ops.FetchNodeIDs(tx.Nodes().Filterf(func() graph.Criteria {
+		return query.And(
+			query.KindIn(query.Node(), ad.User, ad.Group, ad.Computer),
+			query.In(query.NodeProperty(common.ObjectID.String()), objectIDs),
+		)
+	})); err != nil {
+		return nil, err
+	} else {
+		principals := cardinality.NewBitmap64()
+
+		for _, principalID := range principalIDs {
+			principals.Add(principalID.Uint64())
+		}
+
+		return principals, nil
+	}
+}
+
+// gpoAssignsLogonRightToRemoteDesktopUsers returns true if the remote interactive logon right recorded on the GPO
+// includes the builtin Remote Desktop Users group
+func gpoAssignsLogonRightToRemoteDesktopUsers(gpo *graph.Node) bool {
+	if objectIDs, err := gpo.Properties.GetOrDefault(ad.GPORemoteInteractiveLogonRight.String(), []string{}).StringSlice(); err == nil {
+		for _, objectID := range objectIDs {
+			if strings.HasSuffix(objectID, RDPGroupSuffix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FetchComputerGPOGrants applies the local group and user rights settings recorded on GPO nodes to the computers
+// within the scope of each GPO's links. Scope follows the Contains hierarchy below each linked domain or OU and stops
+// at OUs that block inheritance unless the link is enforced.
+func FetchComputerGPOGrants(ctx context.Context, db graph.Database) (ComputerGPOGrants, error) {
+	defer log.Measure(log.LevelInfo, "FetchComputerGPOGrants")()
+
+	var (
+		computerGrants  = ComputerGPOGrants{}
+		settingsFilters = make([]graph.Criteria, 0, len(gpoLocalGroupProperties)+2)
+		affectedFetcher = CreateGPOAffectedIntermediariesListDelegate(SelectComputersCandidateFilter)
+	)
+
+	for _, property := range gpoSettingsProperties() {
+		settingsFilters = append(settingsFilters, query.Exists(query.NodeProperty(property.String())))
+	}
+
+	return computerGrants, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if gpos, err := ops.FetchNodes(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Node(), ad.GPO),
+				query.Or(settingsFilters...),
+			)
+		})); err != nil {
+			return err
+		} else {
+			for _, gpo := range gpos {
+				gpoGrants := NewGPOGrants()
+
+				for suffix, property := range gpoLocalGroupProperties {
+					if members, err := fetchGPOSettingsPrincipals(tx, gpo, property); err != nil {
+						return err
+					} else {
+						gpoGrants.LocalGroups[suffix] = members
+					}
+				}
+
+				if holders, err := fetchGPOSettingsPrincipals(tx, gpo, ad.GPOAdminPrivilegeHolders); err != nil {
+					return err
+				} else {
+					gpoGrants.AdminPrivilegeHolders = holders
+				}
+
+				if holders, err := fetchGPOSettingsPrincipals(tx, gpo, ad.GPORemoteInteractiveLogonRight); err != nil {
+					return err
+				} else {
+					gpoGrants.RemoteInteractiveLogonRight = holders
+				}
+
+				if objectIDs, _ := gpo.Properties.GetOrDefault(ad.GPORemoteInteractiveLogonRight.String(), []string{}).StringSlice(); len(objectIDs) > 0 {
+					gpoGrants.RemoteInteractiveLogonRightAssigned = true
+					gpoGrants.RemoteDesktopUsersHaveLogonRight = gpoAssignsLogonRightToRemoteDesktopUsers(gpo)
+				}
+
+				if computers, err := affectedFetcher(tx, gpo, 0, 0); err != nil {
+					return err
+				} else {
+					for _, computer := range computers {
+						computerGrants.add(computer.ID, gpoGrants)
+					}
+				}
+			}
+
+			return nil
+		}
+	})
+}
+
+func (s ComputerGPOGrants) add(computer graph.ID, gpoGrants *GPOGrants) {
+	grants, found := s[computer]
+	if !found {
+		grants = NewGPOGrants()
+		s[computer] = grants
+	}
+
+	for suffix, members := range gpoGrants.LocalGroups {
+		grants.LocalGroups[suffix].Or(members)
+	}
+
+	grants.AdminPrivilegeHolders.Or(gpoGrants.AdminPrivilegeHolders)
+	grants.RemoteInteractiveLogonRight.Or(gpoGrants.RemoteInteractiveLogonRight)
+	grants.RemoteInteractiveLogonRightAssigned = grants.RemoteInteractiveLogonRightAssigned || gpoGrants.RemoteInteractiveLogonRightAssigned
+	grants.RemoteDesktopUsersHaveLogonRight = grants.RemoteDesktopUsersHaveLogonRight || gpoGrants.RemoteDesktopUsersHaveLogonRight
+}
+
+// FetchLocalGroupBitmapForComputer returns the GPO configured members of the local group with the given SID suffix.
+// Members are only returned when the local group was not collected from the computer itself.
+func (s ComputerGPOGrants) FetchLocalGroupBitmapForComputer(tx graph.Transaction, computer graph.ID, suffix string) (cardinality.Duplex[uint64], error) {
+	if grants, found := s[computer]; !found {
+		return cardinality.NewBitmap64(), nil
+	} else if members, found := grants.LocalGroups[suffix]; !found || members.Cardinality() == 0 {
+		return cardinality.NewBitmap64(), nil
+	} else if _, err := FetchComputerLocalGroupBySIDSuffix(tx, computer, suffix); err == nil {
+		return cardinality.NewBitmap64(), nil
+	} else if !graph.IsErrNotFound(err) {
+		return nil, err
+	} else {
+		return members.Clone(), nil
+	}
+}
+
+// FetchAdminToEntityBitmapForComputer returns the GPO configured local administrators of the computer along with the
+// GPO configured holders of admin equivalent privileges when user rights were not collected from the computer itself
+func (s ComputerGPOGrants) FetchAdminToEntityBitmapForComputer(tx graph.Transaction, computer graph.ID) (cardinality.Duplex[uint64], error) {
+	if admins, err := s.FetchLocalGroupBitmapForComputer(tx, computer, AdminGroupSuffix); err != nil {
+		return nil, err
+	} else if grants, found := s[computer]; found && grants.AdminPrivilegeHolders.Cardinality() > 0 && !ComputerHasURACollection(tx, computer) {
+		admins.Or(grants.AdminPrivilegeHolders)
+		return admins, nil
+	} else {
+		return admins, nil
+	}
+}
+
+// FetchCanRDPEntityBitmapForComputer returns the GPO configured members of the Remote Desktop Users group of the
+// computer. If a GPO configures the remote interactive logon right without including the Remote Desktop Users group,
+// only members that are directly assigned the right are returned.
+func (s ComputerGPOGrants) FetchCanRDPEntityBitmapForComputer(tx graph.Transaction, computer graph.ID) (cardinality.Duplex[uint64], error) {
+	if members, err := s.FetchLocalGroupBitmapForComputer(tx, computer, RDPGroupSuffix); err != nil {
+		return nil, err
+	} else if grants := s[computer]; members.Cardinality() > 0 && grants.RemoteInteractiveLogonRightAssigned && !grants.RemoteDesktopUsersHaveLogonRight {
+		members.And(grants.RemoteInteractiveLogonRight)
+		return members, nil
+	} else {
+		return members, nil
+	}
+}
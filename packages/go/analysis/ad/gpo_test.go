@@ -0,0 +1,205 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad_test
+
+import (
+	"context"
+	"testing"
+
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/dawgs/cardinality"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/ein"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/require"
+)
+
+type gpoTestGraph struct {
+	UncollectedComputer *graph.Node
+	CollectedComputer   *graph.Node
+	BlockedComputer     *graph.Node
+	AdminUser           *graph.Node
+	PrivilegeUser       *graph.Node
+	RDPUser             *graph.Node
+	NoLogonRightUser    *graph.Node
+	PSRemoteUser        *graph.Node
+}
+
+// newGPOTestGraph links an unenforced and an enforced GPO to an OU. The OU contains a computer without host collection,
+// a computer with a collected local administrators group and user rights, and a nested OU that blocks inheritance.
+func newGPOTestGraph(t *testing.T, db graph.Database) gpoTestGraph {
+	var harness gpoTestGraph
+
+	member := func(node *graph.Node) ein.TypedPrincipal {
+		objectID, _ := node.Properties.Get(common.ObjectID.String()).String()
+		return ein.TypedPrincipal{ObjectIdentifier: objectID, ObjectType: "User"}
+	}
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		newNode := func(objectID string, properties map[string]any, kinds ...graph.Kind) *graph.Node {
+			nodeProperties := graph.AsProperties(properties)
+			nodeProperties.Set(common.ObjectID.String(), objectID)
+
+			node, err := tx.CreateNode(nodeProperties, append(graph.Kinds{ad.Entity}, kinds...)...)
+			require.Nil(t, err)
+
+			return node
+		}
+
+		newRelationship := func(start, end *graph.Node, kind graph.Kind, properties map[string]any) {
+			_, err := tx.CreateRelationshipByIDs(start.ID, end.ID, kind, graph.AsProperties(properties))
+			require.Nil(t, err)
+		}
+
+		harness.AdminUser = newNode("S-1-5-21-1-1101", nil, ad.User)
+		harness.PrivilegeUser = newNode("S-1-5-21-1-1102", nil, ad.User)
+		harness.RDPUser = newNode("S-1-5-21-1-1103", nil, ad.User)
+		harness.NoLogonRightUser = newNode("S-1-5-21-1-1104", nil, ad.User)
+		harness.PSRemoteUser = newNode("S-1-5-21-1-1105", nil, ad.User)
+
+		unenforcedGPO := ein.ParseGPOSettings(ein.GPOSettings{
+			ObjectIdentifier: "F2A1C3B4-0000-0000-0000-000000000001",
+			LocalGroups: []ein.GPOLocalGroupSetting{{
+				ObjectIdentifier: ein.BuiltinAdministratorsSID,
+				Members:          []ein.TypedPrincipal{member(harness.AdminUser)},
+			}, {
+				ObjectIdentifier: ein.BuiltinRemoteDesktopUsersSID,
+				Members:          []ein.TypedPrincipal{member(harness.RDPUser), member(harness.NoLogonRightUser)},
+			}},
+			UserRights: []ein.GPOUserRightSetting{{
+				Privilege: ein.UserRightBackup,
+				Members:   []ein.TypedPrincipal{member(harness.PrivilegeUser)},
+			}, {
+				Privilege: ein.UserRightRemoteInteractiveLogon,
+				Members:   []ein.TypedPrincipal{member(harness.RDPUser)},
+			}},
+		})
+
+		enforcedGPO := ein.ParseGPOSettings(ein.GPOSettings{
+			ObjectIdentifier: "F2A1C3B4-0000-0000-0000-000000000002",
+			LocalGroups: []ein.GPOLocalGroupSetting{{
+				ObjectIdentifier: ein.BuiltinRemoteManagementSID,
+				Members:          []ein.TypedPrincipal{member(harness.PSRemoteUser)},
+			}},
+		})
+
+		var (
+			unenforcedGPONode = newNode(unenforcedGPO.ObjectID, unenforcedGPO.PropertyMap, ad.GPO)
+			enforcedGPONode   = newNode(enforcedGPO.ObjectID, enforcedGPO.PropertyMap, ad.GPO)
+			ou                = newNode("F2A1C3B4-0000-0000-0000-000000000003", nil, ad.OU)
+			blockingOU        = newNode("F2A1C3B4-0000-0000-0000-000000000004", map[string]any{ad.BlocksInheritance.String(): true}, ad.OU)
+			nestedOU          = newNode("F2A1C3B4-0000-0000-0000-000000000005", nil, ad.OU)
+		)
+
+		harness.UncollectedComputer = newNode("S-1-5-21-1-1001", nil, ad.Computer)
+		harness.CollectedComputer = newNode("S-1-5-21-1-1002", map[string]any{ad.HasURA.String(): true}, ad.Computer)
+		harness.BlockedComputer = newNode("S-1-5-21-1-1003", nil, ad.Computer)
+
+		collectedAdmins := newNode("S-1-5-21-1-1002-544", nil, ad.LocalGroup)
+		newRelationship(collectedAdmins, harness.CollectedComputer, ad.LocalToComputer, nil)
+
+		newRelationship(unenforcedGPONode, ou, ad.GPLink, map[string]any{ad.Enforced.String(): false})
+		newRelationship(enforcedGPONode, ou, ad.GPLink, map[string]any{ad.Enforced.String(): true})
+		newRelationship(ou, harness.UncollectedComputer, ad.Contains, nil)
+		newRelationship(ou, harness.CollectedComputer, ad.Contains, nil)
+		newRelationship(ou, blockingOU, ad.Contains, nil)
+		newRelationship(blockingOU, nestedOU, ad.Contains, nil)
+		newRelationship(nestedOU, harness.BlockedComputer, ad.Contains, nil)
+
+		return nil
+	}))
+
+	return harness
+}
+
+func requireBitmapOf(t *testing.T, bitmap cardinality.Duplex[uint64], nodes ...*graph.Node) {
+	expected := cardinality.NewBitmap64()
+
+	for _, node := range nodes {
+		expected.Add(node.ID.Uint64())
+	}
+
+	require.ElementsMatch(t, expected.Slice(), bitmap.Slice())
+}
+
+func TestFetchComputerGPOGrants(t *testing.T) {
+	var (
+		db      = memory.NewDatabase(0)
+		harness = newGPOTestGraph(t, db)
+	)
+
+	grants, err := adAnalysis.FetchComputerGPOGrants(context.Background(), db)
+	require.Nil(t, err)
+	require.Len(t, grants, 3)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		// Both GPOs apply to the computer without host collection
+		admins, err := grants.FetchAdminToEntityBitmapForComputer(tx, harness.UncollectedComputer.ID)
+		require.Nil(t, err)
+		requireBitmapOf(t, admins, harness.AdminUser, harness.PrivilegeUser)
+
+		rdpUsers, err := grants.FetchCanRDPEntityBitmapForComputer(tx, harness.UncollectedComputer.ID)
+		require.Nil(t, err)
+		requireBitmapOf(t, rdpUsers, harness.RDPUser)
+
+		psRemoteUsers, err := grants.FetchLocalGroupBitmapForComputer(tx, harness.UncollectedComputer.ID, adAnalysis.PSRemoteGroupSuffix)
+		require.Nil(t, err)
+		requireBitmapOf(t, psRemoteUsers, harness.PSRemoteUser)
+
+		// Host collection takes precedence over the GPO settings
+		admins, err = grants.FetchAdminToEntityBitmapForComputer(tx, harness.CollectedComputer.ID)
+		require.Nil(t, err)
+		requireBitmapOf(t, admins)
+
+		psRemoteUsers, err = grants.FetchLocalGroupBitmapForComputer(tx, harness.CollectedComputer.ID, adAnalysis.PSRemoteGroupSuffix)
+		require.Nil(t, err)
+		requireBitmapOf(t, psRemoteUsers, harness.PSRemoteUser)
+
+		// Blocked inheritance only stops the unenforced GPO
+		admins, err = grants.FetchAdminToEntityBitmapForComputer(tx, harness.BlockedComputer.ID)
+		require.Nil(t, err)
+		requireBitmapOf(t, admins)
+
+		psRemoteUsers, err = grants.FetchLocalGroupBitmapForComputer(tx, harness.BlockedComputer.ID, adAnalysis.PSRemoteGroupSuffix)
+		require.Nil(t, err)
+		requireBitmapOf(t, psRemoteUsers, harness.PSRemoteUser)
+
+		return nil
+	}))
+}
+
+func TestGPOAdminPrivilegeHoldersMatchAdminEquivalentPrivileges(t *testing.T) {
+	privileges := []string{
+		ein.UserRightRemoteInteractiveLogon,
+		ein.UserRightBackup,
+		ein.UserRightRestore,
+		ein.UserRightDebug,
+		ein.UserRightImpersonate,
+		ein.UserRightLoadDriver,
+		ein.UserRightTakeOwnership,
+	}
+
+	for _, privilege := range privileges {
+		kind, ok := ein.UserRightKind(privilege)
+		require.True(t, ok)
+
+		property, _ := ein.GPOUserRightProperty(privilege)
+		require.Equal(t, graph.Kinds(adAnalysis.AdminEquivalentPrivileges()).ContainsOneOf(kind), property == ad.GPOAdminPrivilegeHolders, privilege)
+	}
+}
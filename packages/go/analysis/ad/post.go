@@ -59,6 +59,7 @@ func PostProcessedRelationships() []graph.Kind {
 		ad.EnrollOnBehalfOf,
 		ad.SyncedToEntraUser,
 		ad.ExtendedByPolicy,
+		ad.DumpEntraConnectCredentials,
 	}
 }
 
@@ -234,14 +235,10 @@ func getLAPSComputersForDomain(tx graph.Transaction, domain *graph.Node) ([]grap
 }
 
 func PostLocalGroups(ctx context.Context, db graph.Database, localGroupExpansions impact.PathAggregator, enforceURA bool, citrixEnabled bool) (*analysis.AtomicPostProcessingStats, error) {
-	var (
-		adminGroupSuffix    = "-544"
-		psRemoteGroupSuffix = "-580"
-		dcomGroupSuffix     = "-562"
-	)
-
 	if computers, err := FetchComputers(ctx, db); err != nil {
 		return &analysis.AtomicPostProcessingStats{}, err
+	} else if gpoGrants, err := FetchComputerGPOGrants(ctx, db); err != nil {
+		return &analysis.AtomicPostProcessingStats{}, err
 	} else {
 		var (
 			threadSafeLocalGroupExpansions = impact.NewThreadSafeAggregator(localGroupExpansions)
@@ -256,9 +253,13 @@ func PostLocalGroups(ctx context.Context, db graph.Database, localGroupExpansion
 			}
 
 			if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
-				if entities, err := FetchLocalGroupBitmapForComputer(tx, computerID, dcomGroupSuffix); err != nil {
+				if entities, err := FetchLocalGroupBitmapForComputer(tx, computerID, DCOMGroupSuffix); err != nil {
+					return err
+				} else if gpoEntities, err := gpoGrants.FetchLocalGroupBitmapForComputer(tx, computerID, DCOMGroupSuffix); err != nil {
 					return err
 				} else {
+					entities.Or(gpoEntities)
+
 					for _, admin := range entities.Slice() {
 						nextJob := analysis.CreatePostRelationshipJob{
 							FromID: graph.ID(admin),
@@ -278,9 +279,13 @@ func PostLocalGroups(ctx context.Context, db graph.Database, localGroupExpansion
 			}
 
 			if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
-				if entities, err := FetchLocalGroupBitmapForComputer(tx, computerID, psRemoteGroupSuffix); err != nil {
+				if entities, err := FetchLocalGroupBitmapForComputer(tx, computerID, PSRemoteGroupSuffix); err != nil {
+					return err
+				} else if gpoEntities, err := gpoGrants.FetchLocalGroupBitmapForComputer(tx, computerID, PSRemoteGroupSuffix); err != nil {
 					return err
 				} else {
+					entities.Or(gpoEntities)
+
 					for _, admin := range entities.Slice() {
 						nextJob := analysis.CreatePostRelationshipJob{
 							FromID: graph.ID(admin),
@@ -300,9 +305,13 @@ func PostLocalGroups(ctx context.Context, db graph.Database, localGroupExpansion
 			}
 
 			if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
-				if entities, err := FetchLocalGroupBitmapForComputer(tx, computerID, adminGroupSuffix); err != nil {
+				if entities, err := FetchAdminToEntityBitmapForComputer(tx, computerID, AdminGroupSuffix); err != nil {
+					return err
+				} else if gpoEntities, err := gpoGrants.FetchAdminToEntityBitmapForComputer(tx, computerID); err != nil {
 					return err
 				} else {
+					entities.Or(gpoEntities)
+
 					for _, admin := range entities.Slice() {
 						nextJob := analysis.CreatePostRelationshipJob{
 							FromID: graph.ID(admin),
@@ -324,7 +333,11 @@ func PostLocalGroups(ctx context.Context, db graph.Database, localGroupExpansion
 			if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
 				if entities, err := FetchCanRDPEntityBitmapForComputer(tx, computerID, threadSafeLocalGroupExpansions, enforceURA, citrixEnabled); err != nil {
 					return err
+				} else if gpoEntities, err := gpoGrants.FetchCanRDPEntityBitmapForComputer(tx, computerID); err != nil {
+					return err
 				} else {
+					entities.Or(gpoEntities)
+
 					for _, rdp := range entities.Slice() {
 						nextJob := analysis.CreatePostRelationshipJob{
 							FromID: graph.ID(rdp),
@@ -482,6 +495,76 @@ func FetchLocalGroupBitmapForComputer(tx graph.Transaction, computer graph.ID, s
 	}
 }
 
+// AdminEquivalentPrivileges returns the user rights assignments that allow the holder to escalate to SYSTEM on the
+// computer without further preconditions. ImpersonatePrivilege is excluded since it is granted to service accounts by
+// default and its abuse depends on coercing a privileged token.
+func AdminEquivalentPrivileges() []graph.Kind {
+	return []graph.Kind{
+		ad.BackupPrivilege,
+		ad.RestorePrivilege,
+		ad.DebugPrivilege,
+		ad.LoadDriverPrivilege,
+		ad.TakeOwnershipPrivilege,
+	}
+}
+
+// FetchAdminEquivalentPrivilegeBitmapForComputer returns a bitmap containing the ID's of all domain entities that hold
+// an admin equivalent privilege on the specified computer, either directly or via first degree membership of a local
+// group that holds the privilege
+func FetchAdminEquivalentPrivilegeBitmapForComputer(tx graph.Transaction, computer graph.ID) (cardinality.Duplex[uint64], error) {
+	entities := cardinality.NewBitmap64()
+
+	if holders, err := ops.FetchStartNodes(tx.Relationships().Filterf(func() graph.Criteria {
+		return query.And(
+			query.KindIn(query.Relationship(), AdminEquivalentPrivileges()...),
+			query.Equals(query.EndID(), computer),
+		)
+	})); err != nil {
+		return nil, err
+	} else {
+		for _, holder := range holders {
+			if holder.ID == computer {
+				continue
+			} else if holder.Kinds.ContainsOneOf(ad.LocalGroup) {
+				if err := tx.Relationships().Filter(
+					query.And(
+						query.Kind(query.Relationship(), ad.MemberOfLocalGroup),
+						query.KindIn(query.Start(), ad.Group, ad.User, ad.Computer),
+						query.Equals(query.EndID(), holder.ID),
+					),
+				).FetchTriples(func(cursor graph.Cursor[graph.RelationshipTripleResult]) error {
+					for result := range cursor.Chan() {
+						if result.StartID != computer {
+							entities.Add(result.StartID.Uint64())
+						}
+					}
+
+					return cursor.Error()
+				}); err != nil {
+					return nil, err
+				}
+			} else if holder.Kinds.ContainsOneOf(ad.User, ad.Group, ad.Computer) {
+				entities.Add(holder.ID.Uint64())
+			}
+		}
+	}
+
+	return entities, nil
+}
+
+// FetchAdminToEntityBitmapForComputer returns a bitmap containing the ID's of all entities that are members of the
+// local administrators group of the specified computer or that hold an admin equivalent privilege on it
+func FetchAdminToEntityBitmapForComputer(tx graph.Transaction, computer graph.ID, adminGroupSuffix string) (cardinality.Duplex[uint64], error) {
+	if admins, err := FetchLocalGroupBitmapForComputer(tx, computer, adminGroupSuffix); err != nil {
+		return nil, err
+	} else if privilegeHolders, err := FetchAdminEquivalentPrivilegeBitmapForComputer(tx, computer); err != nil {
+		return nil, err
+	} else {
+		admins.Or(privilegeHolders)
+		return admins, nil
+	}
+}
+
 func ExpandAllRDPLocalGroups(ctx context.Context, db graph.Database) (impact.PathAggregator, error) {
 	log.Infof("Expanding all AD group and local group memberships")
 
@@ -1169,6 +1169,50 @@ func FetchComputerSessions(tx graph.Transaction, node *graph.Node, skip, limit i
 	})
 }
 
+func CreateUserSessionPathDelegate(filter SessionFilter) analysis.PathDelegate {
+	return func(tx graph.Transaction, node *graph.Node) (graph.PathSet, error) {
+		return ops.TraversePaths(tx, ops.TraversalPlan{
+			Root:        node,
+			Direction:   graph.DirectionInbound,
+			BranchQuery: filter.SessionCriteria,
+		})
+	}
+}
+
+func CreateUserSessionListDelegate(filter SessionFilter) analysis.ListDelegate {
+	return func(tx graph.Transaction, node *graph.Node, skip, limit int) (graph.NodeSet, error) {
+		return ops.AcyclicTraverseTerminals(tx, ops.TraversalPlan{
+			Root:        node,
+			Direction:   graph.DirectionInbound,
+			BranchQuery: filter.SessionCriteria,
+			Skip:        skip,
+			Limit:       limit,
+		})
+	}
+}
+
+func CreateComputerSessionPathDelegate(filter SessionFilter) analysis.PathDelegate {
+	return func(tx graph.Transaction, node *graph.Node) (graph.PathSet, error) {
+		return ops.TraversePaths(tx, ops.TraversalPlan{
+			Root:        node,
+			Direction:   graph.DirectionOutbound,
+			BranchQuery: filter.SessionCriteria,
+		})
+	}
+}
+
+func CreateComputerSessionListDelegate(filter SessionFilter) analysis.ListDelegate {
+	return func(tx graph.Transaction, node *graph.Node, skip, limit int) (graph.NodeSet, error) {
+		return ops.AcyclicTraverseTerminals(tx, ops.TraversalPlan{
+			Root:        node,
+			Direction:   graph.DirectionOutbound,
+			BranchQuery: filter.SessionCriteria,
+			Skip:        skip,
+			Limit:       limit,
+		})
+	}
+}
+
 func FetchEntityGroupMembershipPaths(tx graph.Transaction, node *graph.Node) (graph.PathSet, error) {
 	return ops.TraversePaths(tx, ops.TraversalPlan{
 		Root:        node,
@@ -0,0 +1,119 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/ein"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+)
+
+// SessionFilter restricts HasSession relationships to those observed by the given source no earlier than Since. A zero
+// Since or an empty Source leaves that dimension unrestricted.
+type SessionFilter struct {
+	Since  time.Time
+	Source string
+}
+
+// NewSessionFilter creates a SessionFilter for sessions observed within maxAge of now by the given source
+func NewSessionFilter(maxAge time.Duration, source string) (SessionFilter, error) {
+	filter := SessionFilter{
+		Source: source,
+	}
+
+	if maxAge < 0 {
+		return SessionFilter{}, fmt.Errorf("invalid session max age: %s", maxAge)
+	} else if maxAge > 0 {
+		filter.Since = time.Now().UTC().Add(-maxAge)
+	}
+
+	if _, ok := ein.SessionLastSeenProperty(source); source != "" && !ok {
+		return SessionFilter{}, fmt.Errorf("invalid session source: %s", source)
+	}
+
+	return filter, nil
+}
+
+func (s SessionFilter) IsEmpty() bool {
+	return s.Since.IsZero() && s.Source == ""
+}
+
+// lastSeenProperty returns the relationship property that records when the filtered source last observed a session
+func (s SessionFilter) lastSeenProperty() string {
+	if lastSeenProperty, ok := ein.SessionLastSeenProperty(s.Source); ok {
+		return lastSeenProperty.String()
+	}
+
+	return common.LastSeen.String()
+}
+
+// SessionCriteria returns the criteria a HasSession relationship must match to pass the filter
+func (s SessionFilter) SessionCriteria() graph.Criteria {
+	criteria := []graph.Criteria{
+		query.Kind(query.Relationship(), ad.HasSession),
+	}
+
+	if !s.Since.IsZero() {
+		criteria = append(criteria, query.GreaterThanOrEquals(query.RelationshipProperty(s.lastSeenProperty()), s.Since))
+	} else if s.Source != "" {
+		criteria = append(criteria, query.Exists(query.RelationshipProperty(s.lastSeenProperty())))
+	}
+
+	return query.And(criteria...)
+}
+
+// PathfindingCriteria returns criteria that pass all relationships other than the HasSession relationships rejected by
+// the filter
+func (s SessionFilter) PathfindingCriteria() graph.Criteria {
+	return query.Or(
+		query.Not(query.Kind(query.Relationship(), ad.HasSession)),
+		s.SessionCriteria(),
+	)
+}
+
+// DeleteStaleSessions deletes the HasSession relationships that no source has observed since the given time and returns
+// the number of deleted relationships
+func DeleteStaleSessions(ctx context.Context, db graph.Database, before time.Time) (int, error) {
+	var numDeleted int
+
+	if err := db.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		if staleSessions, err := ops.FetchRelationshipIDs(tx.Relationships().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Relationship(), ad.HasSession),
+				query.Before(query.RelationshipProperty(common.LastSeen.String()), before),
+			)
+		})); err != nil {
+			return err
+		} else if len(staleSessions) == 0 {
+			return nil
+		} else {
+			numDeleted = len(staleSessions)
+			return ops.DeleteRelationships(tx, staleSessions...)
+		}
+	}); err != nil {
+		return 0, err
+	}
+
+	return numDeleted, nil
+}
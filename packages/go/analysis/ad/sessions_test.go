@@ -0,0 +1,158 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/ein"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/require"
+)
+
+type sessionTestGraph struct {
+	Computer       *graph.Node
+	RegistryUser   *graph.Node
+	StaleUser      *graph.Node
+	NetSessionUser *graph.Node
+}
+
+func newSessionTestGraph(t *testing.T, db graph.Database) sessionTestGraph {
+	var (
+		nowUTC     = time.Now().UTC()
+		tenDaysAgo = nowUTC.Add(-10 * 24 * time.Hour)
+		sessions   sessionTestGraph
+		newUser    = func(tx graph.Transaction, objectID string) (*graph.Node, error) {
+			return tx.CreateNode(graph.AsProperties(map[string]any{common.ObjectID.String(): objectID}), ad.Entity, ad.User)
+		}
+		newSession = func(tx graph.Transaction, user *graph.Node, lastSeenProperty ad.Property, lastSeen time.Time) error {
+			_, err := tx.CreateRelationshipByIDs(sessions.Computer.ID, user.ID, ad.HasSession, graph.AsProperties(map[string]any{
+				common.LastSeen.String():  lastSeen,
+				lastSeenProperty.String(): lastSeen,
+			}))
+
+			return err
+		}
+	)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		var err error
+
+		if sessions.Computer, err = tx.CreateNode(graph.AsProperties(map[string]any{common.ObjectID.String(): "S-1-5-21-1-1001"}), ad.Entity, ad.Computer); err != nil {
+			return err
+		} else if sessions.RegistryUser, err = newUser(tx, "S-1-5-21-1-1105"); err != nil {
+			return err
+		} else if sessions.StaleUser, err = newUser(tx, "S-1-5-21-1-1106"); err != nil {
+			return err
+		} else if sessions.NetSessionUser, err = newUser(tx, "S-1-5-21-1-1107"); err != nil {
+			return err
+		} else if err := newSession(tx, sessions.RegistryUser, ad.RegistryLastSeen, nowUTC); err != nil {
+			return err
+		} else if err := newSession(tx, sessions.StaleUser, ad.NetSessionEnumLastSeen, tenDaysAgo); err != nil {
+			return err
+		} else {
+			return newSession(tx, sessions.NetSessionUser, ad.NetSessionEnumLastSeen, nowUTC)
+		}
+	}))
+
+	return sessions
+}
+
+func TestNewSessionFilter(t *testing.T) {
+	filter, err := adAnalysis.NewSessionFilter(0, "")
+	require.Nil(t, err)
+	require.True(t, filter.IsEmpty())
+
+	filter, err = adAnalysis.NewSessionFilter(time.Hour, ein.SessionSourceRegistry)
+	require.Nil(t, err)
+	require.False(t, filter.IsEmpty())
+	require.WithinDuration(t, time.Now().UTC().Add(-time.Hour), filter.Since, time.Minute)
+
+	_, err = adAnalysis.NewSessionFilter(-time.Hour, "")
+	require.NotNil(t, err)
+
+	_, err = adAnalysis.NewSessionFilter(0, "wmi")
+	require.NotNil(t, err)
+}
+
+func TestCreateComputerSessionListDelegate(t *testing.T) {
+	var (
+		db       = memory.NewDatabase(0)
+		sessions = newSessionTestGraph(t, db)
+		weekAgo  = time.Now().UTC().Add(-7 * 24 * time.Hour)
+	)
+
+	fetchSessions := func(filter adAnalysis.SessionFilter) graph.NodeSet {
+		var users graph.NodeSet
+
+		require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+			var err error
+			users, err = adAnalysis.CreateComputerSessionListDelegate(filter)(tx, sessions.Computer, 0, 0)
+			return err
+		}))
+
+		return users
+	}
+
+	users := fetchSessions(adAnalysis.SessionFilter{})
+	require.Equal(t, 3, users.Len())
+
+	users = fetchSessions(adAnalysis.SessionFilter{Since: weekAgo})
+	require.Equal(t, 2, users.Len())
+	require.True(t, users.Contains(sessions.RegistryUser))
+	require.True(t, users.Contains(sessions.NetSessionUser))
+
+	users = fetchSessions(adAnalysis.SessionFilter{Source: ein.SessionSourceNetSessionEnum})
+	require.Equal(t, 2, users.Len())
+	require.True(t, users.Contains(sessions.StaleUser))
+	require.True(t, users.Contains(sessions.NetSessionUser))
+
+	users = fetchSessions(adAnalysis.SessionFilter{Since: weekAgo, Source: ein.SessionSourceNetSessionEnum})
+	require.Equal(t, 1, users.Len())
+	require.True(t, users.Contains(sessions.NetSessionUser))
+}
+
+func TestDeleteStaleSessions(t *testing.T) {
+	var (
+		db       = memory.NewDatabase(0)
+		sessions = newSessionTestGraph(t, db)
+	)
+
+	numDeleted, err := adAnalysis.DeleteStaleSessions(context.Background(), db, time.Now().UTC().Add(-3*24*time.Hour))
+	require.Nil(t, err)
+	require.Equal(t, 1, numDeleted)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Relationships().Filter(query.Kind(query.Relationship(), ad.HasSession)).Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(2), count)
+		}
+
+		_, err := tx.Relationships().Filter(query.Equals(query.EndID(), sessions.StaleUser.ID)).First()
+		require.True(t, graph.IsErrNotFound(err))
+
+		return nil
+	}))
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure
+
+import (
+	"fmt"
+
+	"github.com/specterops/bloodhound/dawgs/cardinality"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+)
+
+// TenantAdministrativeUnits returns the administrative unit nodes contained by the given tenant node
+func TenantAdministrativeUnits(tx graph.Transaction, tenant *graph.Node) (graph.NodeSet, error) {
+	if !IsTenantNode(tenant) {
+		return nil, fmt.Errorf("cannot fetch tenant administrative units - node %d must be of kind %s", tenant.ID, azure.Tenant)
+	}
+
+	return EndNodes(tx, tenant, azure.Contains, azure.AdministrativeUnit)
+}
+
+// AdministrativeUnitMembers returns the users, groups and devices that are members of the given administrative unit
+func AdministrativeUnitMembers(tx graph.Transaction, administrativeUnit *graph.Node) (graph.NodeSet, error) {
+	return ops.FetchStartNodes(tx.Relationships().Filterf(func() graph.Criteria {
+		return query.And(
+			query.Equals(query.EndID(), administrativeUnit.ID),
+			query.Kind(query.Relationship(), azure.AdministrativeUnitMember),
+		)
+	}))
+}
+
+// AdministrativeUnitRoleAssignments returns the role assignments that are scoped to the given administrative unit
+func AdministrativeUnitRoleAssignments(tx graph.Transaction, administrativeUnit *graph.Node) ([]*graph.Relationship, error) {
+	if objectID, err := administrativeUnit.Properties.Get(common.ObjectID.String()).String(); err != nil {
+		return nil, fmt.Errorf("administrative unit node %d is missing property %s: %w", administrativeUnit.ID, common.ObjectID, err)
+	} else {
+		return ops.FetchRelationships(tx.Relationships().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Relationship(), azure.HasScopedRole),
+				query.Equals(query.RelationshipProperty(azure.Scope.String()), objectID),
+			)
+		}))
+	}
+}
+
+// IsMemberManagementRestricted returns true if the members of the given administrative unit may only be managed
+// through role assignments scoped to the administrative unit
+func IsMemberManagementRestricted(administrativeUnit *graph.Node) (bool, error) {
+	if restricted, err := administrativeUnit.Properties.Get(azure.IsMemberManagementRestricted.String()).Bool(); err != nil {
+		if graph.IsErrPropertyNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	} else {
+		return restricted, nil
+	}
+}
+
+// TenantManagementRestrictedPrincipals returns a roaring bitmap of the members of the given tenant's restricted
+// management administrative units. Tenant wide role assignments do not grant management of these principals.
+func TenantManagementRestrictedPrincipals(tx graph.Transaction, tenant *graph.Node) (cardinality.Duplex[uint64], error) {
+	restrictedPrincipals := cardinality.NewBitmap64()
+
+	if administrativeUnits, err := TenantAdministrativeUnits(tx, tenant); err != nil {
+		return nil, err
+	} else {
+		for _, administrativeUnit := range administrativeUnits {
+			if restricted, err := IsMemberManagementRestricted(administrativeUnit); err != nil {
+				return nil, err
+			} else if !restricted {
+				continue
+			} else if members, err := AdministrativeUnitMembers(tx, administrativeUnit); err != nil {
+				return nil, err
+			} else {
+				restrictedPrincipals.Or(members.IDBitmap())
+			}
+		}
+	}
+
+	return restrictedPrincipals, nil
+}
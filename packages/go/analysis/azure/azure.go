@@ -48,6 +48,9 @@ func GetDescendentKinds(kind graph.Kind) []graph.Kind {
 			azure.ServicePrincipal,
 			azure.Device,
 			azure.FunctionApp,
+			azure.AdministrativeUnit,
+			azure.StorageAccount,
+			azure.StorageContainer,
 		}
 
 	case azure.ManagementGroup:
@@ -64,6 +67,8 @@ func GetDescendentKinds(kind graph.Kind) []graph.Kind {
 			azure.AutomationAccount,
 			azure.KeyVault,
 			azure.FunctionApp,
+			azure.StorageAccount,
+			azure.StorageContainer,
 		}
 
 	case azure.ResourceGroup:
@@ -77,6 +82,8 @@ func GetDescendentKinds(kind graph.Kind) []graph.Kind {
 			azure.AutomationAccount,
 			azure.KeyVault,
 			azure.FunctionApp,
+			azure.StorageAccount,
+			azure.StorageContainer,
 		}
 
 	case azure.Subscription:
@@ -91,6 +98,8 @@ func GetDescendentKinds(kind graph.Kind) []graph.Kind {
 			azure.AutomationAccount,
 			azure.KeyVault,
 			azure.FunctionApp,
+			azure.StorageAccount,
+			azure.StorageContainer,
 		}
 	}
 
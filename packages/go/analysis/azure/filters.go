@@ -25,7 +25,7 @@ import (
 )
 
 func FilterEntityActiveAssignments() graph.Criteria {
-	return query.KindIn(query.Relationship(), azure.HasRole, azure.MemberOf)
+	return query.KindIn(query.Relationship(), azure.HasRole, azure.HasScopedRole, azure.MemberOf)
 }
 
 func FilterEntityPIMAssignments() graph.Criteria {
@@ -28,10 +28,10 @@ func NewGroupEntityDetails(node *graph.Node) GroupDetails {
 	}
 }
 
-func GroupEntityDetails(db graph.Database, objectID string, hydrateCounts bool) (GroupDetails, error) {
+func GroupEntityDetails(ctx context.Context, db graph.Database, objectID string, hydrateCounts bool) (GroupDetails, error) {
 	var details GroupDetails
 
-	return details, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+	return details, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
 		if node, err := FetchEntityByObjectID(tx, objectID); err != nil {
 			return err
 		} else {
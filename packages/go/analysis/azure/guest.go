@@ -0,0 +1,124 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/util/channels"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+)
+
+// GuestUserPrincipalNameMarker separates the mangled home identity of a B2B guest from the resource tenant domain
+// in the guest's user principal name, e.g. alice_contoso.com#EXT#@fabrikam.onmicrosoft.com
+const GuestUserPrincipalNameMarker = "#EXT#@"
+
+// GuestHomeUserPrincipalName returns the user principal name of the home tenant identity of a B2B guest user
+func GuestHomeUserPrincipalName(userPrincipalName string) (string, bool) {
+	if markerIndex := strings.Index(strings.ToUpper(userPrincipalName), GuestUserPrincipalNameMarker); markerIndex < 0 {
+		return "", false
+	} else if mangledName := userPrincipalName[:markerIndex]; mangledName == "" {
+		return "", false
+	} else if separatorIndex := strings.LastIndex(mangledName, "_"); separatorIndex <= 0 || separatorIndex == len(mangledName)-1 {
+		return "", false
+	} else {
+		return mangledName[:separatorIndex] + "@" + mangledName[separatorIndex+1:], true
+	}
+}
+
+// GuestAccounts creates AZHasGuestAccount edges from collected home tenant users to their B2B guest users in other
+// collected tenants
+func GuestAccounts(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessingStats, error) {
+	operation := analysis.NewPostRelationshipOperation(ctx, db, "AZHasGuestAccount Post Processing")
+
+	if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
+		guestsByHomeName := map[string][]*graph.Node{}
+
+		if guests, err := ops.FetchNodes(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Node(), azure.User),
+				query.StringContains(query.NodeProperty(common.Name.String()), GuestUserPrincipalNameMarker),
+			)
+		})); err != nil {
+			return err
+		} else {
+			for _, guest := range guests {
+				if name, err := guest.Properties.Get(common.Name.String()).String(); err != nil {
+					continue
+				} else if homeName, isGuest := GuestHomeUserPrincipalName(name); isGuest {
+					homeName = strings.ToUpper(homeName)
+					guestsByHomeName[homeName] = append(guestsByHomeName[homeName], guest)
+				}
+			}
+		}
+
+		if len(guestsByHomeName) == 0 {
+			return nil
+		}
+
+		homeNames := make([]string, 0, len(guestsByHomeName))
+		for homeName := range guestsByHomeName {
+			homeNames = append(homeNames, homeName)
+		}
+
+		return tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Node(), azure.User),
+				query.In(query.NodeProperty(common.Name.String()), homeNames),
+			)
+		}).Fetch(func(cursor graph.Cursor[*graph.Node]) error {
+			for homeUser := range cursor.Chan() {
+				homeName, _ := homeUser.Properties.Get(common.Name.String()).String()
+				homeTenantID, _ := homeUser.Properties.Get(azure.TenantID.String()).String()
+
+				for _, guest := range guestsByHomeName[homeName] {
+					// Guests are only meaningful across tenant boundaries
+					if guestTenantID, _ := guest.Properties.Get(azure.TenantID.String()).String(); strings.EqualFold(guestTenantID, homeTenantID) {
+						continue
+					}
+
+					nextJob := analysis.CreatePostRelationshipJob{
+						FromID: homeUser.ID,
+						ToID:   guest.ID,
+						Kind:   azure.HasGuestAccount,
+					}
+
+					if !channels.Submit(ctx, outC, nextJob) {
+						return nil
+					}
+				}
+			}
+
+			return cursor.Error()
+		})
+	}); err != nil {
+		if err := operation.Done(); err != nil {
+			log.Errorf("Error caught during azure GuestAccounts teardown: %v", err)
+		}
+
+		return &operation.Stats, err
+	}
+
+	return &operation.Stats, operation.Done()
+}
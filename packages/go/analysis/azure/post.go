@@ -132,6 +132,8 @@ func PostProcessedRelationships() []graph.Kind {
 		azure.AZMGGrantAppRoles,
 		azure.AZMGGrantRole,
 		azure.SyncedToADUser,
+		azure.ListStorageKeys,
+		azure.HasGuestAccount,
 	}
 }
 
@@ -653,13 +655,22 @@ func createAZMGServicePrincipalEndpointReadWriteAllEdges(ctx context.Context, db
 
 func addSecret(operation analysis.StatTrackedOperation[analysis.CreatePostRelationshipJob], tenant *graph.Node) error {
 	return operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
-		if addSecretRoles, err := TenantRoles(tx, tenant, AddSecretRoleIDs()...); err != nil {
+		if tenantRoles, err := TenantRoles(tx, tenant); err != nil {
+			return err
+		} else if appSecretRoles, err := RolesWithPermission(tenantRoles, AddSecretRoleIDs(), ApplicationCredentialsUpdateAction); err != nil {
+			return err
+		} else if servicePrincipalSecretRoles, err := RolesWithPermission(tenantRoles, AddSecretRoleIDs(), ServicePrincipalCredentialsUpdateAction); err != nil {
 			return err
 		} else if tenantAppsAndSPs, err := TenantApplicationsAndServicePrincipals(tx, tenant); err != nil {
 			return err
 		} else {
-			for _, role := range addSecretRoles {
-				for _, target := range tenantAppsAndSPs {
+			for _, target := range tenantAppsAndSPs {
+				addSecretRoles := appSecretRoles
+				if target.Kinds.ContainsOneOf(azure.ServicePrincipal) {
+					addSecretRoles = servicePrincipalSecretRoles
+				}
+
+				for _, role := range addSecretRoles {
 					log.Debugf("Adding AZAddSecret edge from role %s to %s %d", role.ID.String(), target.Kinds.Strings(), target.ID)
 					nextJob := analysis.CreatePostRelationshipJob{
 						FromID: role.ID,
@@ -689,7 +700,13 @@ func ExecuteCommand(ctx context.Context, db graph.Database) (*analysis.AtomicPos
 					return err
 				} else if tenantDevices.Len() == 0 {
 					return nil
-				} else if intuneAdmins, err := RoleMembers(tx, tenant, azure.IntuneServiceAdministratorRole); err != nil {
+				} else if tenantRoles, err := TenantRoles(tx, tenant); err != nil {
+					return err
+				} else if executeCommandRoles, err := RolesWithPermission(tenantRoles, []string{azure.IntuneServiceAdministratorRole}, IntuneAllTasksAction); err != nil {
+					return err
+				} else if executeCommandRoleTemplateIDs := RoleTemplateIDs(executeCommandRoles); len(executeCommandRoleTemplateIDs) == 0 {
+					continue
+				} else if intuneAdmins, err := RoleMembers(tx, tenant, executeCommandRoleTemplateIDs...); err != nil {
 					return err
 				} else {
 					for _, tenantDevice := range tenantDevices {
@@ -735,13 +752,16 @@ func ExecuteCommand(ctx context.Context, db graph.Database) (*analysis.AtomicPos
 
 func resetPassword(operation analysis.StatTrackedOperation[analysis.CreatePostRelationshipJob], tenant *graph.Node, roleAssignments RoleAssignments) error {
 	return operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
-		if pwResetRoles, err := TenantRoles(tx, tenant, ResetPasswordRoleIDs()...); err != nil {
+		if tenantRoles, err := TenantRoles(tx, tenant); err != nil {
+			return err
+		} else if pwResetRoles, err := RolesWithPermission(tenantRoles, ResetPasswordRoleIDs(), UserPasswordUpdateAction); err != nil {
 			return err
 		} else {
 			for _, role := range pwResetRoles {
 				if targets, err := resetPasswordEndNodeBitmapForRole(role, roleAssignments); err != nil {
 					return fmt.Errorf("unable to continue processing azresetpassword for tenant node %d: %w", tenant.ID, err)
 				} else {
+					roleAssignments.RemoveManagementRestricted(targets)
 					targets.Each(func(nextID uint64) bool {
 						nextJob := analysis.CreatePostRelationshipJob{
 							FromID: role.ID,
@@ -762,6 +782,9 @@ func resetPassword(operation analysis.StatTrackedOperation[analysis.CreatePostRe
 	})
 }
 
+// resetPasswordEndNodeBitmapForRole returns the users whose passwords the given role may reset. Built-in roles may reset
+// the passwords of users holding the roles that Entra allows them to manage. Any other role that permits password
+// resets, such as a custom role, may only reset the passwords of users that hold no roles.
 func resetPasswordEndNodeBitmapForRole(role *graph.Node, roleAssignments RoleAssignments) (cardinality.Duplex[uint64], error) {
 	if roleTemplateIDProp := role.Properties.Get(azure.RoleTemplateID.String()); roleTemplateIDProp.IsNil() {
 		return nil, fmt.Errorf("role node %d is missing property %s", role.ID, azure.RoleTemplateID)
@@ -784,10 +807,8 @@ func resetPasswordEndNodeBitmapForRole(role *graph.Node, roleAssignments RoleAss
 		case azure.PasswordAdministratorRole:
 			result.Or(roleAssignments.UsersWithoutRoles())
 			result.Or(roleAssignments.UsersWithRolesExclusive(PasswordAdministratorPasswordResetTargetRoles()...))
-		case azure.PartnerTier1SupportRole:
-			result.Or(roleAssignments.UsersWithoutRoles())
 		default:
-			return nil, fmt.Errorf("role node %d has unsupported role template id '%s'", role.ID, roleTemplateID)
+			result.Or(roleAssignments.UsersWithoutRoles())
 		}
 
 		return result, nil
@@ -860,13 +881,22 @@ func privilegedAuthAdmins(roleAssignments RoleAssignments, tenant *graph.Node, o
 	}
 }
 
-func addMembers(roleAssignments RoleAssignments, operation analysis.StatTrackedOperation[analysis.CreatePostRelationshipJob]) {
+func addMembers(roleAssignments RoleAssignments, operation analysis.StatTrackedOperation[analysis.CreatePostRelationshipJob]) error {
+	groupMemberRoleTemplateIDs, err := roleAssignments.RoleTemplateIDsWithPermission(AddMemberGroupNotRoleAssignableTargetRoles(), AddMembersActions()...)
+	if err != nil {
+		return err
+	}
+
 	for tenantGroupID, tenantGroup := range roleAssignments.Principals.Get(azure.Group) {
 		var (
 			innerGroupID = tenantGroupID
 			innerGroup   = tenantGroup
 		)
 
+		if roleAssignments.IsManagementRestricted(innerGroupID) {
+			continue
+		}
+
 		if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
 			roleAssignments.UsersWithRole(AddMemberAllGroupsTargetRoles()...).Each(func(nextID uint64) bool {
 				nextJob := analysis.CreatePostRelationshipJob{
@@ -895,7 +925,7 @@ func addMembers(roleAssignments RoleAssignments, operation analysis.StatTrackedO
 					return err
 				}
 			} else if !isRoleAssignable {
-				roleAssignments.UsersWithRole(AddMemberGroupNotRoleAssignableTargetRoles()...).Each(func(nextID uint64) bool {
+				roleAssignments.UsersWithRole(groupMemberRoleTemplateIDs...).Each(func(nextID uint64) bool {
 					nextJob := analysis.CreatePostRelationshipJob{
 						FromID: graph.ID(nextID),
 						ToID:   innerGroupID,
@@ -915,6 +945,86 @@ func addMembers(roleAssignments RoleAssignments, operation analysis.StatTrackedO
 			log.Errorf("Failed to submit azure add members AddMemberGroupNotRoleAssignableTargetRoles post processing job: %v", err)
 		}
 	}
+
+	return nil
+}
+
+// administrativeUnitRoleAssignments creates the AZResetPassword and AZAddMembers edges granted by role assignments scoped
+// to an administrative unit. These edges start at the assigned principal, rather than the role, and end only at members
+// of the administrative unit.
+func administrativeUnitRoleAssignments(operation analysis.StatTrackedOperation[analysis.CreatePostRelationshipJob], tenant *graph.Node, roleAssignments RoleAssignments) error {
+	return operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
+		if administrativeUnits, err := TenantAdministrativeUnits(tx, tenant); err != nil {
+			return err
+		} else {
+			for _, administrativeUnit := range administrativeUnits {
+				if scopedAssignments, err := AdministrativeUnitRoleAssignments(tx, administrativeUnit); err != nil {
+					return err
+				} else if len(scopedAssignments) == 0 {
+					continue
+				} else if members, err := AdministrativeUnitMembers(tx, administrativeUnit); err != nil {
+					return err
+				} else {
+					memberKinds := members.KindSet()
+
+					for _, scopedAssignment := range scopedAssignments {
+						role := roleAssignments.Roles.Get(scopedAssignment.EndID)
+						if role == nil {
+							continue
+						}
+
+						scopedRole := graph.NewNodeSet(role)
+
+						if pwResetRoles, err := RolesWithPermission(scopedRole, ResetPasswordRoleIDs(), UserPasswordUpdateAction); err != nil {
+							return err
+						} else if pwResetRoles.Len() > 0 {
+							if targets, err := resetPasswordEndNodeBitmapForRole(role, roleAssignments); err != nil {
+								return fmt.Errorf("unable to continue processing azresetpassword for administrative unit node %d: %w", administrativeUnit.ID, err)
+							} else {
+								targets.And(memberKinds.Get(azure.User).IDBitmap())
+
+								for _, target := range targets.Slice() {
+									nextJob := analysis.CreatePostRelationshipJob{
+										FromID: scopedAssignment.StartID,
+										ToID:   graph.ID(target),
+										Kind:   azure.ResetPassword,
+									}
+
+									if !channels.Submit(ctx, outC, nextJob) {
+										return nil
+									}
+								}
+							}
+						}
+
+						if addMembersRoles, err := RolesWithPermission(scopedRole, append(AddMemberAllGroupsTargetRoles(), AddMemberGroupNotRoleAssignableTargetRoles()...), AddMembersActions()...); err != nil {
+							return err
+						} else if addMembersRoles.Len() > 0 {
+							for _, group := range memberKinds.Get(azure.Group) {
+								if isRoleAssignable, err := group.Properties.Get(azure.IsAssignableToRole.String()).Bool(); err != nil && !graph.IsErrPropertyNotFound(err) {
+									return err
+								} else if isRoleAssignable {
+									continue
+								}
+
+								nextJob := analysis.CreatePostRelationshipJob{
+									FromID: scopedAssignment.StartID,
+									ToID:   group.ID,
+									Kind:   azure.AddMembers,
+								}
+
+								if !channels.Submit(ctx, outC, nextJob) {
+									return nil
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+
+		return nil
+	})
 }
 
 func UserRoleAssignments(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessingStats, error) {
@@ -941,7 +1051,22 @@ func UserRoleAssignments(ctx context.Context, db graph.Database) (*analysis.Atom
 					globalAdmins(roleAssignments, tenant, operation)
 					privilegedRoleAdmins(roleAssignments, tenant, operation)
 					privilegedAuthAdmins(roleAssignments, tenant, operation)
-					addMembers(roleAssignments, operation)
+
+					if err := addMembers(roleAssignments, operation); err != nil {
+						if err := operation.Done(); err != nil {
+							log.Errorf("Error caught during azure UserRoleAssignments.addMembers teardown: %v", err)
+						}
+
+						return &analysis.AtomicPostProcessingStats{}, err
+					}
+
+					if err := administrativeUnitRoleAssignments(operation, tenant, roleAssignments); err != nil {
+						if err := operation.Done(); err != nil {
+							log.Errorf("Error caught during azure UserRoleAssignments.administrativeUnitRoleAssignments teardown: %v", err)
+						}
+
+						return &analysis.AtomicPostProcessingStats{}, err
+					}
 				}
 			}
 		}
@@ -91,6 +91,23 @@ func TestRoleAssignments_NodesWithRolesExclusive(t *testing.T) {
 	assert.Equal(t, graph.EmptyNodeSet().Get(0), assignments.NodesWithRolesExclusive(azschema.ReportsReaderRole).Get(azschema.User).Get(user.ID))
 }
 
+func TestRoleAssignments_ManagementRestricted(t *testing.T) {
+	assignments := setupRoleAssignments()
+	assert.False(t, assignments.IsManagementRestricted(user2.ID))
+
+	targets := assignments.UsersWithoutRoles()
+	assignments.RemoveManagementRestricted(targets)
+	assert.True(t, targets.Contains(uint64(user2.ID)))
+
+	assignments.ManagementRestricted = cardinality.NewBitmap64()
+	assignments.ManagementRestricted.Add(uint64(user2.ID))
+	assert.True(t, assignments.IsManagementRestricted(user2.ID))
+	assert.False(t, assignments.IsManagementRestricted(user.ID))
+
+	assignments.RemoveManagementRestricted(targets)
+	assert.False(t, targets.Contains(uint64(user2.ID)))
+}
+
 func TestTenantRoles(t *testing.T) {
 	var (
 		ctrl       = gomock.NewController(t)
@@ -337,3 +354,98 @@ func TestEndNodes(t *testing.T) {
 	assert.Contains(t, nodes.Slice(), stubDevice2)
 	assert.Contains(t, nodes.Slice(), stubDevice3)
 }
+
+func TestResourceActionMatches(t *testing.T) {
+	assert.True(t, azure.ResourceActionMatches("microsoft.directory/users/password/update", azure.UserPasswordUpdateAction))
+	assert.True(t, azure.ResourceActionMatches("microsoft.directory/users/allProperties/allTasks", azure.UserPasswordUpdateAction))
+	assert.True(t, azure.ResourceActionMatches("microsoft.directory/users/allProperties/update", azure.UserPasswordUpdateAction))
+	assert.True(t, azure.ResourceActionMatches("microsoft.directory/*", azure.UserPasswordUpdateAction))
+	assert.True(t, azure.ResourceActionMatches("microsoft.directory/*/password/update", azure.UserPasswordUpdateAction))
+	assert.True(t, azure.ResourceActionMatches("Microsoft.Directory/servicePrincipals/credentials/update", azure.ServicePrincipalCredentialsUpdateAction))
+	assert.True(t, azure.ResourceActionMatches("microsoft.intune/allEntities/allTasks", azure.IntuneAllTasksAction))
+
+	assert.False(t, azure.ResourceActionMatches("microsoft.directory/users/allProperties/read", azure.UserPasswordUpdateAction))
+	assert.False(t, azure.ResourceActionMatches("microsoft.directory/users/password", azure.UserPasswordUpdateAction))
+	assert.False(t, azure.ResourceActionMatches("microsoft.directory/users/password/update/extra", azure.UserPasswordUpdateAction))
+	assert.False(t, azure.ResourceActionMatches("microsoft.directory/applications/credentials/update", azure.ServicePrincipalCredentialsUpdateAction))
+	assert.False(t, azure.ResourceActionMatches("microsoft.directory/allProperties/allTasks", azure.UserPasswordUpdateAction))
+}
+
+func TestRolePermissions_Allows(t *testing.T) {
+	permissions := azure.RolePermissions{
+		AllowedActions:  []string{"microsoft.directory/users/allproperties/alltasks", "microsoft.directory/groups/members/update"},
+		ExcludedActions: []string{"microsoft.directory/users/password/update"},
+	}
+
+	assert.False(t, permissions.Allows(azure.UserPasswordUpdateAction))
+	assert.True(t, permissions.Allows(azure.UserPasswordUpdateAction, azure.GroupMembersUpdateAction))
+	assert.False(t, permissions.Allows(azure.ApplicationCredentialsUpdateAction))
+	assert.False(t, azure.RolePermissions{}.Allows(azure.UserPasswordUpdateAction))
+}
+
+func TestRolesWithPermission(t *testing.T) {
+	var (
+		newRole = func(id graph.ID, roleTemplateID string, allowedActions ...string) *graph.Node {
+			properties := graph.NewProperties().Set(azschema.RoleTemplateID.String(), roleTemplateID)
+
+			if allowedActions != nil {
+				actions := make([]any, 0, len(allowedActions))
+				for _, action := range allowedActions {
+					actions = append(actions, action)
+				}
+
+				properties.Set(azschema.AllowedResourceActions.String(), actions)
+				properties.Set(azschema.ExcludedResourceActions.String(), []any{})
+			}
+
+			return graph.NewNode(id, properties, azschema.Entity, azschema.Role)
+		}
+
+		legacyHelpdeskAdmin = newRole(1, azschema.HelpdeskAdministratorRole)
+		legacyCustomRole    = newRole(2, "b2c3d4e5-0000-0000-0000-000000000000")
+		customPasswordRole  = newRole(3, "a1b2c3d4-0000-0000-0000-000000000000", "microsoft.directory/users/password/update")
+		customReaderRole    = newRole(4, "c3d4e5f6-0000-0000-0000-000000000000", "microsoft.directory/users/standard/read")
+		roles               = graph.NewNodeSet(legacyHelpdeskAdmin, legacyCustomRole, customPasswordRole, customReaderRole)
+	)
+
+	pwResetRoles, err := azure.RolesWithPermission(roles, azure.ResetPasswordRoleIDs(), azure.UserPasswordUpdateAction)
+	require.Nil(t, err)
+	assert.Equal(t, 2, pwResetRoles.Len())
+	assert.True(t, pwResetRoles.Contains(legacyHelpdeskAdmin))
+	assert.True(t, pwResetRoles.Contains(customPasswordRole))
+	assert.ElementsMatch(t, []string{azschema.HelpdeskAdministratorRole, "a1b2c3d4-0000-0000-0000-000000000000"}, azure.RoleTemplateIDs(pwResetRoles))
+}
+
+func TestAllowsSharedKeyAccess(t *testing.T) {
+	sharedKeyAccount := graph.NewNode(10, graph.AsProperties(graph.PropertyMap{azschema.AllowSharedKeyAccess: true}), azschema.StorageAccount)
+	entraOnlyAccount := graph.NewNode(11, graph.AsProperties(graph.PropertyMap{azschema.AllowSharedKeyAccess: false}), azschema.StorageAccount)
+	unknownAccount := graph.NewNode(12, graph.NewProperties(), azschema.StorageAccount)
+
+	allowed, err := azure.AllowsSharedKeyAccess(sharedKeyAccount)
+	require.Nil(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = azure.AllowsSharedKeyAccess(entraOnlyAccount)
+	require.Nil(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = azure.AllowsSharedKeyAccess(unknownAccount)
+	require.Nil(t, err)
+	assert.False(t, allowed)
+}
+
+func TestGuestHomeUserPrincipalName(t *testing.T) {
+	homeName, isGuest := azure.GuestHomeUserPrincipalName("alice_contoso.com#EXT#@fabrikam.onmicrosoft.com")
+	assert.True(t, isGuest)
+	assert.Equal(t, "alice@contoso.com", homeName)
+
+	homeName, isGuest = azure.GuestHomeUserPrincipalName("FIRST_LAST_CONTOSO.COM#EXT#@FABRIKAM.ONMICROSOFT.COM")
+	assert.True(t, isGuest)
+	assert.Equal(t, "FIRST_LAST@CONTOSO.COM", homeName)
+
+	_, isGuest = azure.GuestHomeUserPrincipalName("alice@fabrikam.onmicrosoft.com")
+	assert.False(t, isGuest)
+
+	_, isGuest = azure.GuestHomeUserPrincipalName("alice#EXT#@fabrikam.onmicrosoft.com")
+	assert.False(t, isGuest)
+}
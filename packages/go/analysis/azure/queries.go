@@ -677,7 +677,7 @@ func fetchRolesTraversalPlan(root *graph.Node) ops.TraversalPlan {
 		Direction: graph.DirectionOutbound,
 		BranchQuery: func() graph.Criteria {
 			return query.And(
-				query.KindIn(query.Relationship(), azure.MemberOf, azure.HasRole),
+				query.KindIn(query.Relationship(), azure.MemberOf, azure.HasRole, azure.HasScopedRole),
 			)
 		},
 		DescentFilter: roleDescentFilter,
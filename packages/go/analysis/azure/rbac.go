@@ -0,0 +1,284 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+)
+
+// Azure RBAC resource provider operations that grant abusable control over Azure resources
+const (
+	RoleAssignmentsWriteAction              = "microsoft.authorization/roleassignments/write"
+	VMScaleSetRunCommandAction              = "microsoft.compute/virtualmachinescalesets/virtualmachines/runcommand/action"
+	WebSitesWriteAction                     = "microsoft.web/sites/write"
+	LogicAppWorkflowsWriteAction            = "microsoft.logic/workflows/write"
+	ManagedClusterRunCommandAction          = "microsoft.containerservice/managedclusters/runcommand/action"
+	AutomationAccountRunbooksWriteAction    = "microsoft.automation/automationaccounts/runbooks/write"
+	ContainerRegistryTasksWriteAction       = "microsoft.containerregistry/registries/tasks/write"
+	StorageAccountsWriteAction              = "microsoft.storage/storageaccounts/write"
+	StorageAccountListKeysAction            = "microsoft.storage/storageaccounts/listkeys/action"
+	StorageBlobsModifyPermissionsDataAction = "microsoft.storage/storageaccounts/blobservices/containers/blobs/modifypermissions/action"
+	StorageBlobsWriteDataAction             = "microsoft.storage/storageaccounts/blobservices/containers/blobs/write"
+)
+
+// rbacResourceControl is the resource provider operation that lets a principal take control of an Azure resource of a
+// kind and the edge kind of the built-in role that is scoped to granting it, if there is one
+type rbacResourceControl struct {
+	Action     string
+	ScopedKind graph.Kind
+}
+
+func getRBACResourceControl(target *graph.Node) (rbacResourceControl, bool) {
+	switch {
+	case target.Kinds.ContainsOneOf(azure.VMScaleSet):
+		return rbacResourceControl{Action: VMScaleSetRunCommandAction, ScopedKind: azure.VMContributor}, true
+	case target.Kinds.ContainsOneOf(azure.FunctionApp, azure.WebApp):
+		return rbacResourceControl{Action: WebSitesWriteAction, ScopedKind: azure.WebsiteContributor}, true
+	case target.Kinds.ContainsOneOf(azure.LogicApp):
+		return rbacResourceControl{Action: LogicAppWorkflowsWriteAction, ScopedKind: azure.LogicAppContributor}, true
+	case target.Kinds.ContainsOneOf(azure.ManagedCluster):
+		return rbacResourceControl{Action: ManagedClusterRunCommandAction, ScopedKind: azure.AKSContributor}, true
+	case target.Kinds.ContainsOneOf(azure.AutomationAccount):
+		return rbacResourceControl{Action: AutomationAccountRunbooksWriteAction, ScopedKind: azure.AutomationContributor}, true
+	case target.Kinds.ContainsOneOf(azure.ContainerRegistry):
+		return rbacResourceControl{Action: ContainerRegistryTasksWriteAction}, true
+	case target.Kinds.ContainsOneOf(azure.StorageAccount):
+		return rbacResourceControl{Action: StorageAccountsWriteAction, ScopedKind: azure.StorageAccountContributor}, true
+	default:
+		return rbacResourceControl{}, false
+	}
+}
+
+// RBACRoleAssignmentRelationships returns the Azure RBAC abuse edge kinds that may be derived from the role definition
+// of an AZRBACRoleAssignment edge
+func RBACRoleAssignmentRelationships() []graph.Kind {
+	return []graph.Kind{
+		azure.Owner,
+		azure.UserAccessAdministrator,
+		azure.Contributor,
+		azure.VMContributor,
+		azure.WebsiteContributor,
+		azure.LogicAppContributor,
+		azure.AKSContributor,
+		azure.AutomationContributor,
+		azure.StorageAccountContributor,
+		azure.StorageAccountKeyOperator,
+		azure.StorageBlobDataOwner,
+		azure.StorageBlobDataContributor,
+	}
+}
+
+// RBACRolePermissions is the effective set of control and data plane actions that an Azure RBAC role definition permits
+type RBACRolePermissions struct {
+	Actions     RolePermissions
+	DataActions RolePermissions
+}
+
+// GetRBACRolePermissions returns the permissions ingested for the given AZRoleDefinition node
+func GetRBACRolePermissions(roleDefinition *graph.Node) (RBACRolePermissions, error) {
+	var (
+		permissions RBACRolePermissions
+		properties  = []struct {
+			name   string
+			target *[]string
+		}{
+			{name: azure.AllowedResourceActions.String(), target: &permissions.Actions.AllowedActions},
+			{name: azure.ExcludedResourceActions.String(), target: &permissions.Actions.ExcludedActions},
+			{name: azure.AllowedDataActions.String(), target: &permissions.DataActions.AllowedActions},
+			{name: azure.ExcludedDataActions.String(), target: &permissions.DataActions.ExcludedActions},
+		}
+	)
+
+	for _, property := range properties {
+		if actions, err := roleDefinition.Properties.Get(property.name).StringSlice(); err != nil && !graph.IsErrPropertyNotFound(err) {
+			return permissions, fmt.Errorf("role definition node %d property %s is not a string slice: %w", roleDefinition.ID, property.name, err)
+		} else {
+			*property.target = actions
+		}
+	}
+
+	return permissions, nil
+}
+
+// RBACRoleAssignmentKinds returns the abuse edge kinds that an assignment of a role with the given permissions grants
+// over the given target. Roles that allow both writing role assignments and taking control of the target are treated
+// as Owner, roles that only allow writing role assignments as User Access Administrator and roles that allow every
+// action but writing role assignments as Contributor. Any other role that allows taking control of the target has the
+// edge kind of the built-in role scoped to the target's resource provider.
+func RBACRoleAssignmentKinds(permissions RBACRolePermissions, target *graph.Node) []graph.Kind {
+	var kinds []graph.Kind
+
+	control, isAbusable := getRBACResourceControl(target)
+	if !isAbusable {
+		return kinds
+	}
+
+	var (
+		allowsControl         = permissions.Actions.Allows(control.Action)
+		allowsRoleAssignments = permissions.Actions.Allows(RoleAssignmentsWriteAction)
+		allowsAllActions      = slices.Contains(permissions.Actions.AllowedActions, resourceActionWildcard)
+	)
+
+	switch {
+	case allowsControl && allowsRoleAssignments:
+		return append(kinds, azure.Owner)
+
+	case allowsControl && allowsAllActions:
+		return append(kinds, azure.Contributor)
+
+	case allowsRoleAssignments:
+		kinds = append(kinds, azure.UserAccessAdministrator)
+
+	case allowsControl && control.ScopedKind != nil:
+		kinds = append(kinds, control.ScopedKind)
+	}
+
+	if target.Kinds.ContainsOneOf(azure.StorageAccount) {
+		if !allowsControl && permissions.Actions.Allows(StorageAccountListKeysAction) {
+			kinds = append(kinds, azure.StorageAccountKeyOperator)
+		}
+
+		if permissions.DataActions.Allows(StorageBlobsModifyPermissionsDataAction) {
+			kinds = append(kinds, azure.StorageBlobDataOwner)
+		} else if permissions.DataActions.Allows(StorageBlobsWriteDataAction) {
+			kinds = append(kinds, azure.StorageBlobDataContributor)
+		}
+	}
+
+	return kinds
+}
+
+// DeleteRBACRoleAssignmentEdges deletes the abuse edges previously derived from AZRBACRoleAssignment edges. Derived
+// edges are told apart from the ingested edges of built-in roles by their role definition ID property.
+func DeleteRBACRoleAssignmentEdges(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessingStats, error) {
+	var (
+		relationships []*graph.Relationship
+		stats         = analysis.NewAtomicPostProcessingStats()
+	)
+
+	if err := db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		var err error
+
+		relationships, err = ops.FetchRelationships(tx.Relationships().Filterf(func() graph.Criteria {
+			return query.And(
+				query.KindIn(query.Relationship(), RBACRoleAssignmentRelationships()...),
+				query.Exists(query.RelationshipProperty(azure.RoleDefinitionID.String())),
+			)
+		}))
+
+		return err
+	}); err != nil {
+		return &stats, err
+	}
+
+	return &stats, db.BatchOperation(ctx, func(batch graph.Batch) error {
+		for _, relationship := range relationships {
+			if err := batch.DeleteRelationship(relationship.ID); err != nil {
+				return err
+			}
+
+			stats.AddRelationshipsDeleted(relationship.Kind, 1)
+		}
+
+		return nil
+	})
+}
+
+// CustomRBACRoleAssignments creates the abuse edges of Azure RBAC role assignments whose role definition has no
+// built-in edge kind, such as custom roles, from the actions of the role definition. Assignments of role definitions
+// that have not been ingested are skipped.
+func CustomRBACRoleAssignments(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessingStats, error) {
+	type derivedRelationship struct {
+		FromID           graph.ID
+		ToID             graph.ID
+		Kind             graph.Kind
+		RoleDefinitionID string
+	}
+
+	var derivedRelationships []derivedRelationship
+
+	stats, err := DeleteRBACRoleAssignmentEdges(ctx, db)
+	if err != nil {
+		return stats, err
+	}
+
+	if err := db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		rolePermissions := map[string]RBACRolePermissions{}
+
+		if roleDefinitions, err := ops.FetchNodes(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.Kind(query.Node(), azure.RoleDefinition)
+		})); err != nil {
+			return err
+		} else {
+			for _, roleDefinition := range roleDefinitions {
+				if objectID, err := roleDefinition.Properties.Get(common.ObjectID.String()).String(); err != nil {
+					log.Warnf("Unable to read %s for role definition %d: %v", common.ObjectID, roleDefinition.ID, err)
+				} else if permissions, err := GetRBACRolePermissions(roleDefinition); err != nil {
+					log.Warnf("Unable to read permissions for role definition %d: %v", roleDefinition.ID, err)
+				} else {
+					rolePermissions[objectID] = permissions
+				}
+			}
+		}
+
+		return ops.ForEachEndNode(tx.Relationships().Filterf(func() graph.Criteria {
+			return query.Kind(query.Relationship(), azure.RBACRoleAssignment)
+		}), func(relationship *graph.Relationship, target *graph.Node) error {
+			if roleDefinitionID, err := relationship.Properties.Get(azure.RoleDefinitionID.String()).String(); err != nil {
+				log.Warnf("Unable to read %s for relationship %d: %v", azure.RoleDefinitionID, relationship.ID, err)
+			} else if permissions, found := rolePermissions[roleDefinitionID]; found {
+				for _, kind := range RBACRoleAssignmentKinds(permissions, target) {
+					derivedRelationships = append(derivedRelationships, derivedRelationship{
+						FromID:           relationship.StartID,
+						ToID:             relationship.EndID,
+						Kind:             kind,
+						RoleDefinitionID: roleDefinitionID,
+					})
+				}
+			}
+
+			return nil
+		})
+	}); err != nil {
+		return stats, err
+	}
+
+	return stats, db.BatchOperation(ctx, func(batch graph.Batch) error {
+		for _, nextRelationship := range derivedRelationships {
+			properties := analysis.NewPropertiesWithLastSeen()
+			properties.Set(azure.RoleDefinitionID.String(), nextRelationship.RoleDefinitionID)
+
+			if err := batch.CreateRelationshipByIDs(nextRelationship.FromID, nextRelationship.ToID, nextRelationship.Kind, properties); err != nil {
+				return err
+			}
+
+			stats.AddRelationshipsCreated(nextRelationship.Kind, 1)
+		}
+
+		return nil
+	})
+}
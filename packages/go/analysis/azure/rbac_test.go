@@ -0,0 +1,141 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/analysis/azure"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	azschema "github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACRoleAssignmentKinds(t *testing.T) {
+	var (
+		vmScaleSet     = &graph.Node{Kinds: graph.Kinds{azschema.Entity, azschema.VMScaleSet}}
+		storageAccount = &graph.Node{Kinds: graph.Kinds{azschema.Entity, azschema.StorageAccount}}
+		keyVault       = &graph.Node{Kinds: graph.Kinds{azschema.Entity, azschema.KeyVault}}
+		actions        = func(allowed []string, excluded ...string) azure.RolePermissions {
+			return azure.RolePermissions{AllowedActions: allowed, ExcludedActions: excluded}
+		}
+	)
+
+	// Built-in role shapes
+	owner := azure.RBACRolePermissions{Actions: actions([]string{"*"})}
+	assert.Equal(t, []graph.Kind{azschema.Owner}, azure.RBACRoleAssignmentKinds(owner, vmScaleSet))
+
+	contributor := azure.RBACRolePermissions{Actions: actions([]string{"*"}, "microsoft.authorization/*/write", "microsoft.authorization/*/delete")}
+	assert.Equal(t, []graph.Kind{azschema.Contributor}, azure.RBACRoleAssignmentKinds(contributor, vmScaleSet))
+
+	userAccessAdmin := azure.RBACRolePermissions{Actions: actions([]string{"*/read", "microsoft.authorization/*"})}
+	assert.Equal(t, []graph.Kind{azschema.UserAccessAdministrator}, azure.RBACRoleAssignmentKinds(userAccessAdmin, vmScaleSet))
+
+	// Custom roles scoped to a resource provider
+	scaleSetOperator := azure.RBACRolePermissions{Actions: actions([]string{"Microsoft.Compute/virtualMachineScaleSets/*"})}
+	assert.Equal(t, []graph.Kind{azschema.VMContributor}, azure.RBACRoleAssignmentKinds(scaleSetOperator, vmScaleSet))
+	assert.Empty(t, azure.RBACRoleAssignmentKinds(scaleSetOperator, storageAccount))
+
+	scaleSetReader := azure.RBACRolePermissions{Actions: actions([]string{"microsoft.compute/virtualmachinescalesets/*"}, "microsoft.compute/virtualmachinescalesets/virtualmachines/runcommand/*")}
+	assert.Empty(t, azure.RBACRoleAssignmentKinds(scaleSetReader, vmScaleSet))
+
+	keyReader := azure.RBACRolePermissions{
+		Actions:     actions([]string{"microsoft.storage/storageaccounts/listkeys/action"}),
+		DataActions: actions([]string{"microsoft.storage/storageaccounts/blobservices/containers/blobs/*"}),
+	}
+	assert.Equal(t, []graph.Kind{azschema.StorageAccountKeyOperator, azschema.StorageBlobDataOwner}, azure.RBACRoleAssignmentKinds(keyReader, storageAccount))
+
+	blobWriter := azure.RBACRolePermissions{DataActions: actions([]string{"microsoft.storage/storageaccounts/blobservices/containers/blobs/*"}, "microsoft.storage/storageaccounts/blobservices/containers/blobs/modifypermissions/action")}
+	assert.Equal(t, []graph.Kind{azschema.StorageBlobDataContributor}, azure.RBACRoleAssignmentKinds(blobWriter, storageAccount))
+
+	// Resources without modeled RBAC abuse
+	assert.Empty(t, azure.RBACRoleAssignmentKinds(owner, keyVault))
+}
+
+func TestCustomRBACRoleAssignments(t *testing.T) {
+	var (
+		ctx = context.Background()
+		db  = memory.NewDatabase(0)
+
+		principal, vmScaleSet *graph.Node
+	)
+
+	require.Nil(t, db.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		var err error
+
+		if principal, err = tx.CreateNode(graph.AsProperties(map[string]any{common.ObjectID.String(): "PRINCIPAL"}), azschema.Entity, azschema.User); err != nil {
+			return err
+		} else if vmScaleSet, err = tx.CreateNode(graph.AsProperties(map[string]any{common.ObjectID.String(): "SCALESET"}), azschema.Entity, azschema.VMScaleSet); err != nil {
+			return err
+		} else if _, err := tx.CreateNode(graph.AsProperties(map[string]any{
+			common.ObjectID.String():                 "CUSTOM-ROLE",
+			azschema.AllowedResourceActions.String(): []string{"microsoft.compute/virtualmachinescalesets/*"},
+		}), azschema.RoleDefinition); err != nil {
+			return err
+		} else if _, err := tx.CreateRelationshipByIDs(principal.ID, vmScaleSet.ID, azschema.RBACRoleAssignment, graph.AsProperties(map[string]any{
+			azschema.RoleDefinitionID.String(): "CUSTOM-ROLE",
+		})); err != nil {
+			return err
+		} else {
+			// Assignments of role definitions that were not ingested are skipped
+			_, err := tx.CreateRelationshipByIDs(principal.ID, vmScaleSet.ID, azschema.RBACRoleAssignment, graph.AsProperties(map[string]any{
+				azschema.RoleDefinitionID.String(): "UNKNOWN-ROLE",
+			}))
+
+			return err
+		}
+	}))
+
+	fetchDerived := func() []*graph.Relationship {
+		var relationships []*graph.Relationship
+
+		require.Nil(t, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+			var err error
+
+			relationships, err = ops.FetchRelationships(tx.Relationships().Filterf(func() graph.Criteria {
+				return query.KindIn(query.Relationship(), azure.RBACRoleAssignmentRelationships()...)
+			}))
+
+			return err
+		}))
+
+		return relationships
+	}
+
+	// Running the post-processing again must replace the derived edges rather than duplicate them
+	for range 2 {
+		stats, err := azure.CustomRBACRoleAssignments(ctx, db)
+		require.Nil(t, err)
+		require.Equal(t, int32(1), *stats.RelationshipsCreated[azschema.VMContributor])
+
+		derived := fetchDerived()
+		require.Len(t, derived, 1)
+		assert.Equal(t, principal.ID, derived[0].StartID)
+		assert.Equal(t, vmScaleSet.ID, derived[0].EndID)
+		assert.Equal(t, azschema.VMContributor, derived[0].Kind)
+
+		roleDefinitionID, err := derived[0].Properties.Get(azschema.RoleDefinitionID.String()).String()
+		require.Nil(t, err)
+		assert.Equal(t, "CUSTOM-ROLE", roleDefinitionID)
+	}
+}
@@ -88,7 +88,11 @@ func (s RoleAssignmentMap) HasRole(id graph.ID, roleTemplateIDs ...string) bool
 
 type RoleAssignments struct {
 	Principals graph.NodeKindSet
+	Roles      graph.NodeSet
 	RoleMap    map[string]cardinality.Duplex[uint64]
+
+	// ManagementRestricted contains the members of restricted management administrative units
+	ManagementRestricted cardinality.Duplex[uint64]
 }
 
 func (s RoleAssignments) GetNodeKindSet(bm cardinality.Duplex[uint64]) graph.NodeKindSet {
@@ -175,6 +179,29 @@ func (s RoleAssignments) NodesWithRolesExclusive(roleTemplateIDs ...string) grap
 	return s.GetNodeKindSet(bm)
 }
 
+// RoleTemplateIDsWithPermission returns the template IDs of the assigned roles that are either one of the given built-in
+// roles or whose permissions allow any of the given resource actions
+func (s RoleAssignments) RoleTemplateIDsWithPermission(builtInRoleTemplateIDs []string, actions ...string) ([]string, error) {
+	if roles, err := RolesWithPermission(s.Roles, builtInRoleTemplateIDs, actions...); err != nil {
+		return nil, err
+	} else {
+		return RoleTemplateIDs(roles), nil
+	}
+}
+
+// IsManagementRestricted returns true if the given principal is a member of a restricted management administrative unit
+// and may not be managed through tenant wide role assignments
+func (s RoleAssignments) IsManagementRestricted(id graph.ID) bool {
+	return s.ManagementRestricted != nil && s.ManagementRestricted.Contains(id.Uint64())
+}
+
+// RemoveManagementRestricted removes the members of restricted management administrative units from the given bitmap
+func (s RoleAssignments) RemoveManagementRestricted(principals cardinality.Duplex[uint64]) {
+	if s.ManagementRestricted != nil {
+		principals.AndNot(s.ManagementRestricted)
+	}
+}
+
 func (s RoleAssignments) NodeHasRole(id graph.ID, roleTemplateIDs ...string) bool {
 	for _, roleID := range roleTemplateIDs {
 		if bm, ok := s.RoleMap[roleID]; ok {
@@ -193,8 +220,10 @@ func initTenantRoleAssignments(tx graph.Transaction, tenant *graph.Node) (RoleAs
 		return RoleAssignments{}, err
 	} else {
 		return RoleAssignments{
-			Principals: roleMembers.KindSet(),
-			RoleMap:    make(map[string]cardinality.Duplex[uint64]),
+			Principals:           roleMembers.KindSet(),
+			Roles:                graph.NewNodeSet(),
+			RoleMap:              make(map[string]cardinality.Duplex[uint64]),
+			ManagementRestricted: cardinality.NewBitmap64(),
 		}, nil
 	}
 }
@@ -206,7 +235,12 @@ func TenantRoleAssignments(ctx context.Context, db graph.Database, tenant *graph
 			return err
 		} else if roles, err := TenantRoles(tx, tenant); err != nil {
 			return err
+		} else if managementRestricted, err := TenantManagementRestrictedPrincipals(tx, tenant); err != nil {
+			return err
 		} else {
+			fetchedRoleAssignments.Roles = roles
+			fetchedRoleAssignments.ManagementRestricted = managementRestricted
+
 			return roles.KindSet().EachNode(func(node *graph.Node) error {
 				if roleTemplateID, err := node.Properties.Get(azure.RoleTemplateID.String()).String(); err != nil {
 					if !graph.IsErrPropertyNotFound(err) {
@@ -0,0 +1,164 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/azure"
+)
+
+// Entra directory role resource actions that grant abusable control over other tenant objects
+const (
+	UserPasswordUpdateAction                = "microsoft.directory/users/password/update"
+	ApplicationCredentialsUpdateAction      = "microsoft.directory/applications/credentials/update"
+	ServicePrincipalCredentialsUpdateAction = "microsoft.directory/serviceprincipals/credentials/update"
+	GroupMembersUpdateAction                = "microsoft.directory/groups/members/update"
+	SecurityGroupMembersUpdateAction        = "microsoft.directory/groups.security/members/update"
+	UnifiedGroupMembersUpdateAction         = "microsoft.directory/groups.unified/members/update"
+	IntuneAllTasksAction                    = "microsoft.intune/allentities/alltasks"
+)
+
+const (
+	resourceActionWildcard      = "*"
+	resourceActionAllProperties = "allproperties"
+	resourceActionAllTasks      = "alltasks"
+)
+
+func AddMembersActions() []string {
+	return []string{
+		GroupMembersUpdateAction,
+		SecurityGroupMembersUpdateAction,
+		UnifiedGroupMembersUpdateAction,
+	}
+}
+
+// ResourceActionMatches returns true if the given resource action pattern from a role definition grants the given
+// resource action. Patterns may use the Azure RBAC wildcard, either within a segment or as the trailing segment to
+// match every action beneath it, as well as the Entra allProperties and allTasks shorthands.
+func ResourceActionMatches(pattern, action string) bool {
+	var (
+		patternSegments = strings.Split(strings.ToLower(pattern), "/")
+		actionSegments  = strings.Split(strings.ToLower(action), "/")
+	)
+
+	for idx, patternSegment := range patternSegments {
+		isLastSegment := idx == len(patternSegments)-1
+
+		if idx >= len(actionSegments) {
+			return false
+		}
+
+		switch {
+		case isLastSegment && patternSegment == resourceActionWildcard:
+			return true
+
+		case isLastSegment && patternSegment == resourceActionAllTasks:
+			return idx == len(actionSegments)-1
+
+		case !isLastSegment && idx > 1 && patternSegment == resourceActionAllProperties:
+			continue
+
+		default:
+			if matched, err := path.Match(patternSegment, actionSegments[idx]); err != nil || !matched {
+				return false
+			}
+		}
+	}
+
+	return len(patternSegments) == len(actionSegments)
+}
+
+// RolePermissions is the effective set of resource actions that an Entra directory role definition or the control or
+// data plane of an Azure RBAC role definition permits.
+type RolePermissions struct {
+	AllowedActions  []string
+	ExcludedActions []string
+}
+
+// Allows returns true if any of the given resource actions is allowed and not excluded
+func (s RolePermissions) Allows(actions ...string) bool {
+	for _, action := range actions {
+		isMatch := func(pattern string) bool {
+			return ResourceActionMatches(pattern, action)
+		}
+
+		if slices.ContainsFunc(s.AllowedActions, isMatch) && !slices.ContainsFunc(s.ExcludedActions, isMatch) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRolePermissions returns the permissions ingested for the given role node. The returned bool is false when the role
+// has no permission data, which is the case for roles ingested from collections that predate role permissions.
+func GetRolePermissions(role *graph.Node) (RolePermissions, bool, error) {
+	var permissions RolePermissions
+
+	if allowedActions, err := role.Properties.Get(azure.AllowedResourceActions.String()).StringSlice(); err != nil {
+		if graph.IsErrPropertyNotFound(err) {
+			return permissions, false, nil
+		}
+
+		return permissions, false, fmt.Errorf("role node %d property %s is not a string slice: %w", role.ID, azure.AllowedResourceActions, err)
+	} else if excludedActions, err := role.Properties.Get(azure.ExcludedResourceActions.String()).StringSlice(); err != nil && !graph.IsErrPropertyNotFound(err) {
+		return permissions, false, fmt.Errorf("role node %d property %s is not a string slice: %w", role.ID, azure.ExcludedResourceActions, err)
+	} else {
+		permissions.AllowedActions = allowedActions
+		permissions.ExcludedActions = excludedActions
+
+		return permissions, true, nil
+	}
+}
+
+// RolesWithPermission returns the roles of the given set that are one of the given built-in roles or whose ingested
+// permissions allow any of the given resource actions. The built-in roles are included regardless of their
+// permission data as BloodHound models abuse for some of them that their permissions alone do not describe, and they
+// are the only source of truth for roles ingested without permission data.
+func RolesWithPermission(roles graph.NodeSet, builtInRoleTemplateIDs []string, actions ...string) (graph.NodeSet, error) {
+	result := graph.NewNodeSet()
+
+	for _, role := range roles {
+		if roleTemplateID, err := role.Properties.Get(azure.RoleTemplateID.String()).String(); err == nil && slices.Contains(builtInRoleTemplateIDs, roleTemplateID) {
+			result.Add(role)
+		} else if permissions, hasPermissions, err := GetRolePermissions(role); err != nil {
+			return nil, err
+		} else if hasPermissions && permissions.Allows(actions...) {
+			result.Add(role)
+		}
+	}
+
+	return result, nil
+}
+
+// RoleTemplateIDs returns the role template IDs of the given roles
+func RoleTemplateIDs(roles graph.NodeSet) []string {
+	roleTemplateIDs := make([]string, 0, roles.Len())
+
+	for _, role := range roles {
+		if roleTemplateID, err := role.Properties.Get(azure.RoleTemplateID.String()).String(); err == nil {
+			roleTemplateIDs = append(roleTemplateIDs, roleTemplateID)
+		}
+	}
+
+	return roleTemplateIDs
+}
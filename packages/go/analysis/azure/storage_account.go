@@ -0,0 +1,120 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/util/channels"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/log"
+)
+
+// ListStorageKeysRelationships returns the storage account role edges that grant the
+// Microsoft.Storage/storageAccounts/listKeys/action permission
+func ListStorageKeysRelationships() []graph.Kind {
+	return []graph.Kind{
+		azure.Owner,
+		azure.Contributor,
+		azure.StorageAccountContributor,
+		azure.StorageAccountKeyOperator,
+	}
+}
+
+// AllowsSharedKeyAccess returns true if the storage account will authorize data plane requests signed with one of
+// its account keys. AzureHound omits the setting when it is disabled, so a missing property is treated as disabled.
+func AllowsSharedKeyAccess(storageAccount *graph.Node) (bool, error) {
+	if allowSharedKeyAccess, err := storageAccount.Properties.Get(azure.AllowSharedKeyAccess.String()).Bool(); graph.IsErrPropertyNotFound(err) {
+		return false, nil
+	} else {
+		return allowSharedKeyAccess, err
+	}
+}
+
+// StorageKeyReaders returns the principals that hold a role on the given storage account that allows them to list
+// the storage account keys
+func StorageKeyReaders(tx graph.Transaction, storageAccount *graph.Node) (graph.NodeSet, error) {
+	return ops.FetchStartNodes(tx.Relationships().Filterf(func() graph.Criteria {
+		return query.And(
+			query.Equals(query.EndID(), storageAccount.ID),
+			query.KindIn(query.Relationship(), ListStorageKeysRelationships()...),
+		)
+	}))
+}
+
+// ListStorageKeys creates AZListStorageKeys edges from principals that may list the keys of a storage account to the
+// storage account when the account allows shared key authorization
+func ListStorageKeys(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessingStats, error) {
+	var storageAccounts []*graph.Node
+
+	if err := db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		var err error
+
+		storageAccounts, err = ops.FetchNodes(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.Kind(query.Node(), azure.StorageAccount)
+		}))
+
+		return err
+	}); err != nil {
+		return &analysis.AtomicPostProcessingStats{}, err
+	}
+
+	operation := analysis.NewPostRelationshipOperation(ctx, db, "AZListStorageKeys Post Processing")
+
+	for _, storageAccount := range storageAccounts {
+		innerStorageAccount := storageAccount
+
+		if allowsSharedKeyAccess, err := AllowsSharedKeyAccess(innerStorageAccount); err != nil {
+			log.Warnf("Unable to read %s for storage account %d: %v", azure.AllowSharedKeyAccess, innerStorageAccount.ID, err)
+			continue
+		} else if !allowsSharedKeyAccess {
+			continue
+		}
+
+		if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
+			if keyReaders, err := StorageKeyReaders(tx, innerStorageAccount); err != nil {
+				return err
+			} else {
+				for _, keyReader := range keyReaders {
+					nextJob := analysis.CreatePostRelationshipJob{
+						FromID: keyReader.ID,
+						ToID:   innerStorageAccount.ID,
+						Kind:   azure.ListStorageKeys,
+					}
+
+					if !channels.Submit(ctx, outC, nextJob) {
+						return nil
+					}
+				}
+			}
+
+			return nil
+		}); err != nil {
+			if err := operation.Done(); err != nil {
+				log.Errorf("Error caught during azure ListStorageKeys teardown: %v", err)
+			}
+
+			return &operation.Stats, err
+		}
+	}
+
+	return &operation.Stats, operation.Done()
+}
@@ -33,10 +33,10 @@ func NewTenantEntityDetails(node *graph.Node) TenantDetails {
 	}
 }
 
-func TenantEntityDetails(db graph.Database, objectID string, hydrateCounts bool) (TenantDetails, error) {
+func TenantEntityDetails(ctx context.Context, db graph.Database, objectID string, hydrateCounts bool) (TenantDetails, error) {
 	var details TenantDetails
 
-	return details, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+	return details, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
 		if node, err := FetchEntityByObjectID(tx, objectID); err != nil {
 			return err
 		} else {
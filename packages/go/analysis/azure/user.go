@@ -28,10 +28,10 @@ func NewUserEntityDetails(node *graph.Node) UserDetails {
 	}
 }
 
-func UserEntityDetails(db graph.Database, objectID string, hydrateCounts bool) (UserDetails, error) {
+func UserEntityDetails(ctx context.Context, db graph.Database, objectID string, hydrateCounts bool) (UserDetails, error) {
 	var details UserDetails
 
-	return details, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+	return details, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
 		if node, err := FetchEntityByObjectID(tx, objectID); err != nil {
 			return err
 		} else {
@@ -0,0 +1,139 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package hybrid
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/specterops/bloodhound/analysis"
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/analysis/azure"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/util/channels"
+	adSchema "github.com/specterops/bloodhound/graphschema/ad"
+	azureSchema "github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+)
+
+// entraSyncAccountName matches the names of the Entra sync accounts created by Entra Connect. Older versions create a
+// Sync_<server>_<installation id> user while newer versions create a ConnectSyncProvisioning_<server>_<installation id>
+// service principal.
+var entraSyncAccountName = regexp.MustCompile(`(?i)^(?:SYNC|CONNECTSYNCPROVISIONING)_(.+)_[0-9A-F]+@`)
+
+// EntraSyncServerName returns the NetBIOS name of the Entra Connect server from the name of an Entra sync account node
+func EntraSyncServerName(name string) (string, bool) {
+	if matches := entraSyncAccountName.FindStringSubmatch(name); len(matches) != 2 {
+		return "", false
+	} else {
+		return strings.ToUpper(matches[1]), true
+	}
+}
+
+// PostEntraConnect creates hybrid attack paths through Entra Connect:
+//   - DumpEntraConnectCredentials from the Entra Connect server to the Entra sync accounts whose credentials it stores
+//   - AZResetPassword from each Entra sync account to the synced users of its tenant, whose password hashes it can write
+func PostEntraConnect(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessingStats, error) {
+	tenants, err := azure.FetchTenants(ctx, db)
+	if err != nil {
+		return &analysis.AtomicPostProcessingStats{}, fmt.Errorf("fetching Entra tenants: %w", err)
+	}
+
+	operation := analysis.NewPostRelationshipOperation(ctx, db, "Entra Connect Post Processing")
+
+	for _, tenant := range tenants {
+		innerTenant := tenant
+
+		if err := operation.Operation.SubmitReader(func(ctx context.Context, tx graph.Transaction, outC chan<- analysis.CreatePostRelationshipJob) error {
+			if roleMembers, err := azure.RoleMembers(tx, innerTenant, azureSchema.DirectorySynchronizationAccountsRole); err != nil {
+				return err
+			} else if syncAccounts := roleMembers.ContainingNodeKinds(azureSchema.User, azureSchema.ServicePrincipal); syncAccounts.Len() == 0 {
+				return nil
+			} else if tenantUsers, err := fetchEntraUsers(tx, innerTenant); err != nil {
+				return err
+			} else {
+				for _, syncAccount := range syncAccounts {
+					for _, tenantUser := range tenantUsers {
+						if tenantUser.ID == syncAccount.ID {
+							continue
+						} else if _, hasOnPrem, err := hasOnPremUser(tenantUser); err != nil || !hasOnPrem {
+							continue
+						} else if !channels.Submit(ctx, outC, analysis.CreatePostRelationshipJob{
+							FromID: syncAccount.ID,
+							ToID:   tenantUser.ID,
+							Kind:   azureSchema.ResetPassword,
+						}) {
+							return nil
+						}
+					}
+
+					if name, err := syncAccount.Properties.Get(common.Name.String()).String(); err != nil {
+						continue
+					} else if serverName, ok := EntraSyncServerName(name); !ok {
+						continue
+					} else if servers, err := fetchEntraConnectServers(tx, serverName); err != nil {
+						return err
+					} else {
+						for _, server := range servers {
+							if !channels.Submit(ctx, outC, analysis.CreatePostRelationshipJob{
+								FromID: server.ID,
+								ToID:   syncAccount.ID,
+								Kind:   adSchema.DumpEntraConnectCredentials,
+							}) {
+								return nil
+							}
+						}
+					}
+				}
+
+				return nil
+			}
+		}); err != nil {
+			if err := operation.Done(); err != nil {
+				log.Errorf("Error caught during Entra Connect teardown: %v", err)
+			}
+
+			return &operation.Stats, err
+		}
+	}
+
+	return &operation.Stats, operation.Done()
+}
+
+// fetchEntraConnectServers prefers computers that AD post-processing already identified as Entra Connect servers from
+// their AD DS connector accounts
+func fetchEntraConnectServers(tx graph.Transaction, serverName string) (graph.NodeSet, error) {
+	if detectedServers, err := ops.FetchStartNodes(tx.Relationships().Filterf(func() graph.Criteria {
+		return query.And(
+			query.Kind(query.Start(), adSchema.Computer),
+			query.StringStartsWith(query.StartProperty(common.Name.String()), serverName+"."),
+			query.Kind(query.Relationship(), adSchema.DumpEntraConnectCredentials),
+			query.Kind(query.End(), adSchema.User),
+		)
+	})); err != nil {
+		return nil, err
+	} else if detectedServers.Len() > 0 {
+		return detectedServers, nil
+	} else {
+		return adAnalysis.FetchEntraConnectServers(tx, serverName, "")
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package hybrid_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/analysis/hybrid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntraSyncServerName(t *testing.T) {
+	serverName, ok := hybrid.EntraSyncServerName("SYNC_AADCONNECT01_0123456789AB@CONTOSO.ONMICROSOFT.COM")
+	assert.True(t, ok)
+	assert.Equal(t, "AADCONNECT01", serverName)
+
+	serverName, ok = hybrid.EntraSyncServerName("CONNECTSYNCPROVISIONING_AAD_CONNECT_0123456789AB@CONTOSO")
+	assert.True(t, ok)
+	assert.Equal(t, "AAD_CONNECT", serverName)
+
+	_, ok = hybrid.EntraSyncServerName("SYNCHRONIZED@CONTOSO.ONMICROSOFT.COM")
+	assert.False(t, ok)
+}
@@ -46,9 +46,36 @@ func TestQueryComplexity(t *testing.T) {
 						complexity, analyzerErr := analyzer.QueryComplexity(queryModel)
 						require.Nil(t, analyzerErr)
 						require.Equal(t, *details.Complexity, complexity.Weight)
+
+						var contributionTotal int64
+						for _, contribution := range complexity.Contributions {
+							contributionTotal += contribution.Weight
+						}
+
+						require.Equal(t, complexity.Weight, contributionTotal)
 					}
 				}
 			})
 		}
 	}
 }
+
+func TestQueryComplexity_Contributions(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.NewContext(), "match (n) match (m) return n, m")
+	require.Nil(t, err)
+
+	complexity, err := analyzer.QueryComplexity(queryModel)
+	require.Nil(t, err)
+
+	require.Equal(t, int64(57), complexity.Weight)
+	require.Equal(t, []analyzer.WeightContribution{{
+		Reason: "pattern parts",
+		Weight: 3,
+	}, {
+		Reason: "unlabeled node lookup",
+		Weight: 4,
+	}, {
+		Reason: "unconstrained query",
+		Weight: 50,
+	}}, complexity.Contributions)
+}
@@ -34,8 +34,15 @@ const (
 	weightMaxComplexity int64 = 50
 )
 
-type ComplexityMeasure struct {
+// WeightContribution records the total weight added to a query's complexity measure for a single reason
+type WeightContribution struct {
+	Reason string
 	Weight int64
+}
+
+type ComplexityMeasure struct {
+	Weight        int64
+	Contributions []WeightContribution
 
 	hasWhere             bool
 	hasPatternProperties bool
@@ -47,9 +54,29 @@ type ComplexityMeasure struct {
 	nodeLookupKinds map[string]graph.Kinds
 }
 
+func (s *ComplexityMeasure) addWeight(reason string, weight int64) {
+	if weight == 0 {
+		return
+	}
+
+	s.Weight += weight
+
+	for idx := range s.Contributions {
+		if s.Contributions[idx].Reason == reason {
+			s.Contributions[idx].Weight += weight
+			return
+		}
+	}
+
+	s.Contributions = append(s.Contributions, WeightContribution{
+		Reason: reason,
+		Weight: weight,
+	})
+}
+
 func (s *ComplexityMeasure) onCreate(_ *cypher.WalkStack, _ *cypher.Create) error {
 	// Let's add 1 per create
-	s.Weight += weight1
+	s.addWeight("create", weight1)
 	s.isCreate = true
 
 	return nil
@@ -58,9 +85,9 @@ func (s *ComplexityMeasure) onCreate(_ *cypher.WalkStack, _ *cypher.Create) erro
 func (s *ComplexityMeasure) onDelete(_ *cypher.WalkStack, node *cypher.Delete) error {
 	// Base weight for delete is 3, if detach is specified, we give a heavy weight on top to account
 	// for the extra complexity of deleting relationships
-	s.Weight += weight3
+	s.addWeight("delete", weight3)
 	if node.Detach {
-		s.Weight += weightHeavy
+		s.addWeight("detach delete", weightHeavy)
 	}
 
 	return nil
@@ -72,7 +99,7 @@ func (s *ComplexityMeasure) onExit() {
 	for _, kindMatchers := range s.nodeLookupKinds {
 		if len(kindMatchers) == 0 {
 			// Unlabeled nodes will incur a lookup of all nodes in the graph
-			s.Weight += weight2
+			s.addWeight("unlabeled node lookup", weight2)
 		} else {
 			hasKindMatcher = true
 		}
@@ -80,7 +107,7 @@ func (s *ComplexityMeasure) onExit() {
 
 	// TODO: This is a little gross and needs to be refactored
 	if !hasKindMatcher && !s.hasPatternProperties && !s.hasWhere && !s.hasLimit && !s.isCreate {
-		s.Weight += weightMaxComplexity
+		s.addWeight("unconstrained query", weightMaxComplexity)
 	}
 }
 
@@ -88,11 +115,11 @@ func (s *ComplexityMeasure) onFunctionInvocation(_ *cypher.WalkStack, node *cyph
 	switch node.Name {
 	case "collect":
 		// Collect will force an eager aggregation
-		s.Weight += weight2
+		s.addWeight("collect aggregation", weight2)
 
 	case "type":
 		// Calling for a relationship's type is highly likely to be inefficient and should add weight
-		s.Weight += weight2
+		s.addWeight("relationship type function", weight2)
 	}
 
 	return nil
@@ -110,7 +137,7 @@ func (s *ComplexityMeasure) onKindMatcher(_ *cypher.WalkStack, node *cypher.Kind
 
 func (s *ComplexityMeasure) onMerge(_ *cypher.WalkStack, node *cypher.Merge) error {
 	// Let's add 1 per merge action
-	s.Weight += weight1 * int64(len(node.MergeActions))
+	s.addWeight("merge actions", weight1*int64(len(node.MergeActions)))
 
 	return nil
 }
@@ -119,7 +146,7 @@ func (s *ComplexityMeasure) onNodePattern(_ *cypher.WalkStack, node *cypher.Node
 	if node.Binding == nil {
 		if len(node.Kinds) == 0 {
 			// Unlabeled, unbound nodes will incur a lookup of all nodes in the graph
-			s.Weight += weight2
+			s.addWeight("unlabeled node lookup", weight2)
 		}
 	} else if nodePatternBinding, typeOK := node.Binding.(*cypher.Variable); !typeOK {
 		return fmt.Errorf("expected variable for node pattern binding but got: %T", node.Binding)
@@ -148,7 +175,7 @@ func (s *ComplexityMeasure) onPartialComparison(_ *cypher.WalkStack, node *cyphe
 	case cypher.OperatorRegexMatch:
 		// Regular expression matching incurs a weight since it can be far more involved than any of the other
 		// string operators
-		s.Weight += weight1
+		s.addWeight("regular expression match", weight1)
 	}
 
 	return nil
@@ -157,17 +184,17 @@ func (s *ComplexityMeasure) onPartialComparison(_ *cypher.WalkStack, node *cyphe
 func (s *ComplexityMeasure) onPatternPart(_ *cypher.WalkStack, node *cypher.PatternPart) error {
 	// All pattern parts incur a compounding weight
 	s.numPatterns += 1
-	s.Weight += s.numPatterns
+	s.addWeight("pattern parts", s.numPatterns)
 
 	if node.ShortestPathPattern {
 		// Rendering the shortest path, while cheaper than rendering all shortest paths, still could incur a large
 		// search cost
-		s.Weight += weight1
+		s.addWeight("shortest path", weight1)
 	}
 
 	if node.AllShortestPathsPattern {
 		// Rendering all shortest paths could result in a large search
-		s.Weight += weight2
+		s.addWeight("all shortest paths", weight2)
 	}
 
 	return nil
@@ -175,12 +202,12 @@ func (s *ComplexityMeasure) onPatternPart(_ *cypher.WalkStack, node *cypher.Patt
 
 func (s *ComplexityMeasure) onProjection(_ *cypher.WalkStack, node *cypher.Projection) error {
 	// We want to capture the cost of additional inline projections so ignore the first projection
-	s.Weight += s.numProjections
+	s.addWeight("inline projections", s.numProjections)
 	s.numProjections += 1
 
 	if node.Distinct {
 		// Distinct incurs a weight since it will change how the projection is materialized
-		s.Weight += weight1
+		s.addWeight("distinct projection", weight1)
 	}
 
 	if node.Limit != nil {
@@ -193,7 +220,7 @@ func (s *ComplexityMeasure) onProjection(_ *cypher.WalkStack, node *cypher.Proje
 func (s *ComplexityMeasure) onQuantifier(_ *cypher.WalkStack, _ *cypher.Quantifier) error {
 	// Quantifier expressions may increase the size of an inline projection to apply its contained filter and should
 	// be weighted
-	s.Weight += weight1
+	s.addWeight("quantifier", weight1)
 	return nil
 }
 
@@ -201,35 +228,35 @@ func (s *ComplexityMeasure) onRelationshipPattern(_ *cypher.WalkStack, node *cyp
 	numKindMatchers := len(node.Kinds)
 
 	// All relationship lookups incur a weight
-	s.Weight += weight1
+	s.addWeight("relationship lookup", weight1)
 
 	if node.Direction == graph.DirectionBoth {
 		// Bidirectional searches add weight
-		s.Weight += weight1
+		s.addWeight("bidirectional relationship", weight1)
 	}
 
 	if numKindMatchers == 0 {
 		// If user is expanding all relationship types add weight
-		s.Weight += weight2
+		s.addWeight("untyped relationship", weight2)
 	}
 
 	if node.Range != nil {
 		if numKindMatchers > 2 {
 			// If we're matching on more than two relationship types add weight
-			s.Weight += weight1
+			s.addWeight("relationship types in expansion", weight1)
 		}
 
 		if node.Range.StartIndex != nil && *node.Range.StartIndex > 1 {
 			// Patterns that must have a floor greater than 1 may result in large expansions
-			s.Weight += weight1
+			s.addWeight("expansion lower bound", weight1)
 		}
 
 		if node.Range.EndIndex == nil {
 			// Unbounded range literals are likely to result in large expansions
-			s.Weight += weight3
+			s.addWeight("unbounded expansion", weight3)
 		} else if *node.Range.EndIndex > 1 {
 			// Patterns that must have a ceiling greater than 1 may result in large expansions
-			s.Weight += weight1
+			s.addWeight("expansion upper bound", weight1)
 		}
 	}
 
@@ -246,27 +273,27 @@ func (s *ComplexityMeasure) onRelationshipPattern(_ *cypher.WalkStack, node *cyp
 
 func (s *ComplexityMeasure) onRemove(_ *cypher.WalkStack, node *cypher.Remove) error {
 	// Let's add 1 per remove
-	s.Weight += weight1
+	s.addWeight("remove", weight1)
 
 	return nil
 }
 
 func (s *ComplexityMeasure) onSet(_ *cypher.WalkStack, node *cypher.Set) error {
 	// Let's add 1 per set
-	s.Weight += weight1
+	s.addWeight("set", weight1)
 
 	return nil
 }
 
 func (s *ComplexityMeasure) onSortItem(_ *cypher.WalkStack, _ *cypher.SortItem) error {
 	// Sorting incurs a weight since it will change how the projection is materialized
-	s.Weight += weight1
+	s.addWeight("sort", weight1)
 	return nil
 }
 
 func (s *ComplexityMeasure) onWhere(_ *cypher.WalkStack, _ *cypher.Where) error {
 	// Filters in the query plan may or may not take advantage of indexes and should be weighted accordingly
-	s.Weight += weight1
+	s.addWeight("where filter", weight1)
 	s.hasWhere = true
 	return nil
 }
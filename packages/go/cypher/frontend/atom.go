@@ -111,6 +111,34 @@ func (s *QuantifierVisitor) ExitOC_FilterExpression(ctx *parser.OC_FilterExpress
 	s.Quantifier.Filter = s.ctx.Exit().(*FilterExpressionVisitor).FilterExpression
 }
 
+type ListComprehensionVisitor struct {
+	BaseVisitor
+
+	ListComprehension *cypher.ListComprehension
+}
+
+func NewListComprehensionVisitor() *ListComprehensionVisitor {
+	return &ListComprehensionVisitor{
+		ListComprehension: cypher.NewListComprehension(),
+	}
+}
+
+func (s *ListComprehensionVisitor) EnterOC_FilterExpression(ctx *parser.OC_FilterExpressionContext) {
+	s.ctx.Enter(NewFilterExpressionVisitor())
+}
+
+func (s *ListComprehensionVisitor) ExitOC_FilterExpression(ctx *parser.OC_FilterExpressionContext) {
+	s.ListComprehension.Filter = s.ctx.Exit().(*FilterExpressionVisitor).FilterExpression
+}
+
+func (s *ListComprehensionVisitor) EnterOC_Expression(ctx *parser.OC_ExpressionContext) {
+	s.ctx.Enter(NewExpressionVisitor())
+}
+
+func (s *ListComprehensionVisitor) ExitOC_Expression(ctx *parser.OC_ExpressionContext) {
+	s.ListComprehension.Projection = s.ctx.Exit().(*ExpressionVisitor).Expression
+}
+
 // AtomVisitor
 //
 // oC_Atom
@@ -194,6 +222,14 @@ func (s *AtomVisitor) ExitOC_Quantifier(ctx *parser.OC_QuantifierContext) {
 	s.Atom = s.ctx.Exit().(*QuantifierVisitor).Quantifier
 }
 
+func (s *AtomVisitor) EnterOC_ListComprehension(ctx *parser.OC_ListComprehensionContext) {
+	s.ctx.Enter(NewListComprehensionVisitor())
+}
+
+func (s *AtomVisitor) ExitOC_ListComprehension(ctx *parser.OC_ListComprehensionContext) {
+	s.Atom = s.ctx.Exit().(*ListComprehensionVisitor).ListComprehension
+}
+
 func (s *AtomVisitor) EnterOC_Literal(ctx *parser.OC_LiteralContext) {
 	// String and null are special types in the cypher grammar and will not have downstream state transitions
 	if ctx.NULL() == nil && ctx.StringLiteral() == nil {
@@ -69,6 +69,9 @@ func Copy[T any](value T, extensions ...CopyExtension[T]) T {
 	case *Quantifier:
 		return any(typedValue.copy()).(T)
 
+	case *ListComprehension:
+		return any(typedValue.copy()).(T)
+
 	case *Where:
 		return any(typedValue.copy()).(T)
 
@@ -79,6 +79,7 @@ func TestCopy(t *testing.T) {
 	validateCopy(t, &model2.IDInCollection{})
 	validateCopy(t, &model2.FilterExpression{})
 	validateCopy(t, &model2.Quantifier{})
+	validateCopy(t, &model2.ListComprehension{})
 
 	validateCopy(t, &model2.MultiPartQueryPart{})
 	validateCopy(t, &model2.Remove{})
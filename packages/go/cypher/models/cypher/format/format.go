@@ -564,6 +564,29 @@ func (s Emitter) WriteExpression(writer io.Writer, expression cypher.Expression)
 			return err
 		}
 
+	case *cypher.ListComprehension:
+		if _, err := io.WriteString(writer, "["); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Filter); err != nil {
+			return err
+		}
+
+		if typedExpression.Projection != nil {
+			if _, err := io.WriteString(writer, " | "); err != nil {
+				return err
+			}
+
+			if err := s.WriteExpression(writer, typedExpression.Projection); err != nil {
+				return err
+			}
+		}
+
+		if _, err := io.WriteString(writer, "]"); err != nil {
+			return err
+		}
+
 	case *cypher.Parenthetical:
 		if _, err := io.WriteString(writer, "("); err != nil {
 			return err
@@ -34,6 +34,9 @@ const (
 	ToIntegerFunction          = "toint"
 	ListSizeFunction           = "size"
 	CoalesceFunction           = "coalesce"
+	NodesFunction              = "nodes"
+	RelationshipsFunction      = "relationships"
+	LengthFunction             = "length"
 
 	// ITTC - Instant Type; Temporal Component (https://neo4j.com/docs/cypher-manual/current/functions/temporal/)
 	ITTCYear              = "year"
@@ -675,6 +675,29 @@ func (s *Quantifier) copy() *Quantifier {
 	}
 }
 
+// ListComprehension represents the openCypher list comprehension expression:
+//
+//	[x IN list WHERE x.prop = 'value' | x.name]
+type ListComprehension struct {
+	Filter     *FilterExpression
+	Projection Expression
+}
+
+func NewListComprehension() *ListComprehension {
+	return &ListComprehension{}
+}
+
+func (s *ListComprehension) copy() *ListComprehension {
+	if s == nil {
+		return s
+	}
+
+	return &ListComprehension{
+		Filter:     Copy(s.Filter),
+		Projection: Copy(s.Projection),
+	}
+}
+
 type RangeQuantifier struct {
 	Value string
 }
@@ -179,6 +179,10 @@ func cypherModelCollect(nextCursor *WalkCursor, expression Expression) bool {
 	case *Quantifier:
 		Collect(nextCursor, typedExpr.Filter)
 
+	case *ListComprehension:
+		Collect(nextCursor, typedExpr.Filter)
+		CollectExpression(nextCursor, typedExpr.Projection)
+
 	case *FilterExpression:
 		Collect(nextCursor, typedExpr.Specifier)
 		Collect(nextCursor, typedExpr.Where)
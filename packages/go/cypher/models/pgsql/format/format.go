@@ -28,6 +28,7 @@ type OutputBuilder struct {
 	MaterializeParameters bool
 	StripLiterals         bool
 	parameters            map[string]any
+	tableRewrites         map[pgsql.Identifier]pgsql.Identifier
 	builder               *strings.Builder
 }
 
@@ -44,6 +45,23 @@ func (s *OutputBuilder) WithMaterializedParameters(parameters map[string]any) *O
 	return s
 }
 
+// WithTableRewrites formats every reference to a table named by a key of the given map as a reference to the table
+// named by its value instead
+func (s *OutputBuilder) WithTableRewrites(rewrites map[pgsql.Identifier]pgsql.Identifier) *OutputBuilder {
+	s.tableRewrites = rewrites
+	return s
+}
+
+func (s *OutputBuilder) rewriteTableName(name pgsql.CompoundIdentifier) pgsql.CompoundIdentifier {
+	if len(name) == 1 {
+		if rewrite, hasRewrite := s.tableRewrites[name[0]]; hasRewrite {
+			return pgsql.CompoundIdentifier{rewrite}
+		}
+	}
+
+	return name
+}
+
 func (s *OutputBuilder) HasOutput() bool {
 	return s.builder.Len() != 0
 }
@@ -278,7 +296,7 @@ func formatNode(builder *OutputBuilder, rootExpr pgsql.SyntaxNode) error {
 				exprStack = append(exprStack, typedNextExpr.Binding.Value, pgsql.FormattingLiteral(" "))
 			}
 
-			exprStack = append(exprStack, typedNextExpr.Name)
+			exprStack = append(exprStack, builder.rewriteTableName(typedNextExpr.Name))
 
 		case pgsql.Assignment:
 			exprStack = append(exprStack,
@@ -26,6 +26,7 @@ const (
 	FunctionJSONBBuildObject       Identifier = "jsonb_build_object"
 	FunctionJSONBArrayLength       Identifier = "jsonb_array_length"
 	FunctionArrayLength            Identifier = "array_length"
+	FunctionCardinality            Identifier = "cardinality"
 	FunctionArrayAggregate         Identifier = "array_agg"
 	FunctionMin                    Identifier = "min"
 	FunctionMax                    Identifier = "max"
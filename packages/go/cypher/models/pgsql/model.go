@@ -329,6 +329,10 @@ func (s Parenthetical) AsExpression() Expression {
 	return s
 }
 
+func (s Parenthetical) AsSelectItem() SelectItem {
+	return s
+}
+
 type JoinType int
 
 const (
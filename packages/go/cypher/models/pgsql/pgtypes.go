@@ -42,6 +42,8 @@ const (
 	ColumnStartID    Identifier = "start_id"
 	ColumnNextID     Identifier = "next_id"
 	ColumnEndID      Identifier = "end_id"
+	ColumnNodes      Identifier = "nodes"
+	ColumnEdges      Identifier = "edges"
 )
 
 var (
@@ -109,6 +111,7 @@ const (
 	ExpansionRootNode     DataType = "expansion_root_node"
 	ExpansionEdge         DataType = "expansion_edge"
 	ExpansionTerminalNode DataType = "expansion_terminal_node"
+	ListElement           DataType = "list_element"
 )
 
 func (s DataType) IsKnown() bool {
@@ -323,6 +326,10 @@ func (s DataType) ToArrayType() (DataType, error) {
 		return TextArray, nil
 	case Numeric, NumericArray:
 		return NumericArray, nil
+	case NodeComposite, NodeCompositeArray:
+		return NodeCompositeArray, nil
+	case EdgeComposite, EdgeCompositeArray:
+		return EdgeCompositeArray, nil
 	default:
 		return UnknownDataType, ErrNoAvailableArrayDataType
 	}
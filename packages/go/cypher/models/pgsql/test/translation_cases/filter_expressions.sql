@@ -0,0 +1,125 @@
+-- Copyright 2024 Specter Ops, Inc.
+--
+-- Licensed under the Apache License, Version 2.0
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+--
+-- SPDX-License-Identifier: Apache-2.0
+
+-- case: match p = ()-[]->()-[]->() return nodes(p), relationships(p), length(p)
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0, (e0.id, e0.start_id, e0.end_id, e0.kind_id, e0.properties)::edgecomposite as e0, (n1.id, n1.kind_ids, n1.properties)::nodecomposite as n1
+            from edge e0
+                   join node n0 on n0.id = e0.start_id
+                   join node n1 on n1.id = e0.end_id),
+     s1 as (select s0.e0 as e0, s0.n0 as n0, s0.n1 as n1, (e1.id, e1.start_id, e1.end_id, e1.kind_id, e1.properties)::edgecomposite as e1, (n2.id, n2.kind_ids, n2.properties)::nodecomposite as n2
+            from s0,
+                 edge e1
+                          join node n2 on n2.id = e1.end_id
+            where (s0.n1).id = e1.start_id)
+select (edges_to_path(variadic array [(s1.e0).id, (s1.e1).id]::int8[])::pathcomposite).nodes, (edges_to_path(variadic array [(s1.e0).id, (s1.e1).id]::int8[])::pathcomposite).edges, cardinality(array [(s1.e0).id, (s1.e1).id]::int8[])::int
+from s1;
+
+-- case: match p = ()-[*1..]->() return nodes(p), relationships(p), length(p)
+with s0 as (with recursive ex0(root_id, next_id, depth, satisfied, is_cycle, path) as (select e0.start_id, e0.end_id, 1, false, e0.start_id = e0.end_id, array [e0.id] from edge e0 join node n0 on n0.id = e0.start_id join node n1 on n1.id = e0.end_id union select ex0.root_id, e0.end_id, ex0.depth + 1, false, e0.id = any (ex0.path), ex0.path || e0.id from ex0 join edge e0 on e0.start_id = ex0.next_id join node n1 on n1.id = e0.end_id where ex0.depth < 5 and not ex0.is_cycle) select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0, (select array_agg((e0.id, e0.start_id, e0.end_id, e0.kind_id, e0.properties)::edgecomposite) from edge e0 where e0.id = any (ex0.path)) as e0, ex0.path as ep0, (n1.id, n1.kind_ids, n1.properties)::nodecomposite as n1
+            from ex0
+                   join edge e0 on e0.id = any (ex0.path)
+                   join node n0 on n0.id = ex0.root_id
+                   join node n1 on e0.id = ex0.path[array_length(ex0.path, 1)::int] and n1.id = e0.end_id)
+select (edges_to_path(variadic ep0)::pathcomposite).nodes, (edges_to_path(variadic ep0)::pathcomposite).edges, cardinality(s0.ep0)::int
+from s0;
+
+-- case: match p = (n) return nodes(p), relationships(p), length(p)
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0
+            from node n0)
+select array [s0.n0]::nodecomposite[], array []::edgecomposite[], 0
+from s0;
+
+-- case: match p = (n:NodeKind1) where length(p) = 0 return n
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0
+            from node n0
+            where n0.kind_ids operator (pg_catalog.&&) array [1]::int2[]
+              and 0 = 0)
+select s0.n0 as n
+from s0;
+
+-- case: match p = ()-[]->() where length(p) > 1 return p
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0, (e0.id, e0.start_id, e0.end_id, e0.kind_id, e0.properties)::edgecomposite as e0, (n1.id, n1.kind_ids, n1.properties)::nodecomposite as n1
+            from edge e0
+                   join node n0 on n0.id = e0.start_id
+                   join node n1 on n1.id = e0.end_id
+            where cardinality(array [e0.id]::int8[])::int > 1)
+select edges_to_path(variadic array [(s0.e0).id]::int8[])::pathcomposite as p
+from s0;
+
+-- case: match p = ()-[*1..]->() where all(r in relationships(p) where not r.isacl) return p
+with s0 as (with recursive ex0(root_id, next_id, depth, satisfied, is_cycle, path) as (select e0.start_id, e0.end_id, 1, false, e0.start_id = e0.end_id, array [e0.id] from edge e0 join node n0 on n0.id = e0.start_id join node n1 on n1.id = e0.end_id union select ex0.root_id, e0.end_id, ex0.depth + 1, false, e0.id = any (ex0.path), ex0.path || e0.id from ex0 join edge e0 on e0.start_id = ex0.next_id join node n1 on n1.id = e0.end_id where ex0.depth < 5 and not ex0.is_cycle) select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0, (select array_agg((e0.id, e0.start_id, e0.end_id, e0.kind_id, e0.properties)::edgecomposite) from edge e0 where e0.id = any (ex0.path)) as e0, ex0.path as ep0, (n1.id, n1.kind_ids, n1.properties)::nodecomposite as n1
+            from ex0
+                   join edge e0 on e0.id = any (ex0.path)
+                   join node n0 on n0.id = ex0.root_id
+                   join node n1 on e0.id = ex0.path[array_length(ex0.path, 1)::int] and n1.id = e0.end_id)
+select edges_to_path(variadic ep0)::pathcomposite as p
+from s0
+where (select count(*)
+       from unnest((edges_to_path(variadic ep0)::pathcomposite).edges) as e1
+       where not (not (e1.properties -> 'isacl')::bool)) = 0;
+
+-- case: match (n) where any(x in n.tags where x = 'a') return n
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0
+            from node n0)
+select s0.n0 as n
+from s0
+where (select count(*)
+       from jsonb_array_elements_text((s0.n0).properties -> 'tags') as le0
+       where (le0)::text = 'a') >= 1;
+
+-- case: match (n), (m) where none(x in n.tags where x = m.name) return n
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0
+            from node n0),
+     s1 as (select s0.n0 as n0, (n1.id, n1.kind_ids, n1.properties)::nodecomposite as n1
+            from s0,
+                 node n1)
+select s1.n0 as n
+from s1
+where (select count(*)
+       from jsonb_array_elements_text((s1.n0).properties -> 'tags') as le0
+       where (le0)::text = (s1.n1).properties ->> 'name') = 0;
+
+-- case: match (n) where single(x in [1, 2, 3] where x = n.value) return n
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0
+            from node n0)
+select s0.n0 as n
+from s0
+where (select count(*)
+       from unnest(array [1, 2, 3]::int8[]) as le0
+       where (le0)::int8 = ((s0.n0).properties -> 'value')::int8) = 1;
+
+-- case: match (n) return [x in n.tags where x starts with 'a']
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0
+            from node n0)
+select (select array_agg(le0)
+        from jsonb_array_elements_text((s0.n0).properties -> 'tags') as le0
+        where (le0)::text like 'a%')
+from s0;
+
+-- case: match p = ()-[]->()-[]->() return [n in nodes(p) where n.name = 'x' | n.objectid]
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0, (e0.id, e0.start_id, e0.end_id, e0.kind_id, e0.properties)::edgecomposite as e0, (n1.id, n1.kind_ids, n1.properties)::nodecomposite as n1
+            from edge e0
+                   join node n0 on n0.id = e0.start_id
+                   join node n1 on n1.id = e0.end_id),
+     s1 as (select s0.e0 as e0, s0.n0 as n0, s0.n1 as n1, (e1.id, e1.start_id, e1.end_id, e1.kind_id, e1.properties)::edgecomposite as e1, (n2.id, n2.kind_ids, n2.properties)::nodecomposite as n2
+            from s0,
+                 edge e1
+                          join node n2 on n2.id = e1.end_id
+            where (s0.n1).id = e1.start_id)
+select (select array_agg(n3.properties -> 'objectid')
+        from unnest((edges_to_path(variadic array [(s1.e0).id, (s1.e1).id]::int8[])::pathcomposite).nodes) as n3
+        where n3.properties ->> 'name' = 'x')
+from s1;
@@ -24,6 +24,12 @@ with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite
 select edges_to_path(variadic array [(s0.e0).id]::int8[])::pathcomposite as p
 from s0;
 
+-- case: match p = (n) return p
+with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite as n0
+            from node n0)
+select (array [s0.n0]::nodecomposite[], array []::edgecomposite[])::pathcomposite as p
+from s0;
+
 -- case: match p = ()-[r1]->()-[r2]->(e) return e
 with s0 as (select (n0.id, n0.kind_ids, n0.properties)::nodecomposite                        as n0,
                    (e0.id, e0.start_id, e0.end_id, e0.kind_id, e0.properties)::edgecomposite as e0,
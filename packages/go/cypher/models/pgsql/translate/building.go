@@ -33,6 +33,8 @@ func (s *Translator) buildProjection(scope *Scope) error {
 
 	if projectionConstraint, err := s.treeTranslator.ConsumeAll(); err != nil {
 		return err
+	} else if err := RewriteExpressionIdentifiers(projectionConstraint.Expression, scope.CurrentFrameBinding().Identifier, scope.Visible()); err != nil {
+		return err
 	} else if projection, err := buildExternalProjection(scope, s.projections.Projections); err != nil {
 		return err
 	} else {
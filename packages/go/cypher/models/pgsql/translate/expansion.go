@@ -531,9 +531,9 @@ func (s *Translator) buildAllShortestPathsExpansionRoot(part *PatternPart, trave
 	)
 
 	// Create a new container for the parameter and its value
-	if primerStatement, err := format.Statement(primerInsert, format.NewOutputBuilder().WithMaterializedParameters(s.translation.Parameters)); err != nil {
+	if primerStatement, err := format.Statement(primerInsert, format.NewOutputBuilder().WithTableRewrites(s.translation.TableRewrites).WithMaterializedParameters(s.translation.Parameters)); err != nil {
 		return pgsql.Query{}, err
-	} else if recursiveStatement, err := format.Statement(recursiveInsert, format.NewOutputBuilder().WithMaterializedParameters(s.translation.Parameters)); err != nil {
+	} else if recursiveStatement, err := format.Statement(recursiveInsert, format.NewOutputBuilder().WithTableRewrites(s.translation.TableRewrites).WithMaterializedParameters(s.translation.Parameters)); err != nil {
 		return pgsql.Query{}, err
 	} else if primerParameterBinding, err := s.query.Scope.DefineNew(pgsql.ParameterIdentifier); err != nil {
 		return pgsql.Query{}, err
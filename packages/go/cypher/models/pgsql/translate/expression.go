@@ -65,11 +65,19 @@ func decomposePropertyLookup(expression pgsql.Expression) (PropertyLookup, error
 }
 
 func ExtractSyntaxNodeReferences(root pgsql.SyntaxNode) (*pgsql.IdentifierSet, error) {
-	dependencies := pgsql.NewIdentifierSet()
+	var (
+		dependencies  = pgsql.NewIdentifierSet()
+		localBindings = pgsql.NewIdentifierSet()
+	)
 
-	return dependencies, walk.WalkPgSQL(root, walk.NewSimpleVisitor[pgsql.SyntaxNode](
+	if err := walk.WalkPgSQL(root, walk.NewSimpleVisitor[pgsql.SyntaxNode](
 		func(node pgsql.SyntaxNode, errorHandler walk.CancelableErrorHandler) {
 			switch typedNode := node.(type) {
+			case pgsql.Select:
+				// Set-returning function sources bound within the expression (e.g. unnest(...) as i) are local to
+				// the expression and are not references to identifiers in the outer scope
+				localBindings.Add(localSourceBindings(typedNode.From)...)
+
 			case pgsql.Identifier:
 				// Filter for reserved identifiers
 				if !pgsql.IsReservedIdentifier(typedNode) {
@@ -84,7 +92,11 @@ func ExtractSyntaxNodeReferences(root pgsql.SyntaxNode) (*pgsql.IdentifierSet, e
 				}
 			}
 		},
-	))
+	)); err != nil {
+		return nil, err
+	}
+
+	return dependencies.RemoveSet(localBindings), nil
 }
 
 func applyUnaryExpressionTypeHints(expression *pgsql.UnaryExpression) error {
@@ -764,6 +776,7 @@ func (s *ExpressionTreeTranslator) PopPushBinaryExpression(scope *Scope, operato
 
 		case pgsql.OperatorCypherStartsWith:
 			newExpression.Operator = pgsql.OperatorLike
+			rewriteROperand := false
 
 			switch typedLOperand := newExpression.LOperand.(type) {
 			case *pgsql.BinaryExpression:
@@ -773,6 +786,14 @@ func (s *ExpressionTreeTranslator) PopPushBinaryExpression(scope *Scope, operato
 					return fmt.Errorf("unexpected operator %s for binary expression \"%s\" left operand", typedLOperand.Operator, operator)
 				}
 
+				rewriteROperand = true
+
+			case pgsql.TypeCast:
+				// Type cast left operands are produced by references to filter expression list elements
+				rewriteROperand = true
+			}
+
+			if rewriteROperand {
 				switch typedROperand := newExpression.ROperand.(type) {
 				case *pgsql.Parameter:
 					newExpression.ROperand = pgsql.NewBinaryExpression(
@@ -827,6 +848,7 @@ func (s *ExpressionTreeTranslator) PopPushBinaryExpression(scope *Scope, operato
 
 		case pgsql.OperatorCypherEndsWith:
 			newExpression.Operator = pgsql.OperatorLike
+			rewriteROperand := false
 
 			switch typedLOperand := newExpression.LOperand.(type) {
 			case *pgsql.BinaryExpression:
@@ -836,6 +858,14 @@ func (s *ExpressionTreeTranslator) PopPushBinaryExpression(scope *Scope, operato
 					return fmt.Errorf("unexpected operator %s for binary expression \"%s\" left operand", typedLOperand.Operator, operator)
 				}
 
+				rewriteROperand = true
+
+			case pgsql.TypeCast:
+				// Type cast left operands are produced by references to filter expression list elements
+				rewriteROperand = true
+			}
+
+			if rewriteROperand {
 				switch typedROperand := newExpression.ROperand.(type) {
 				case *pgsql.Parameter:
 					newExpression.ROperand = pgsql.NewBinaryExpression(
@@ -0,0 +1,251 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package translate
+
+import (
+	"fmt"
+
+	"github.com/specterops/bloodhound/cypher/models"
+	"github.com/specterops/bloodhound/cypher/models/cypher"
+	"github.com/specterops/bloodhound/cypher/models/pgsql"
+)
+
+// filterSource returns the set-returning expression used to iterate the given collection along with the data type
+// of each element produced by it.
+func filterSource(collection pgsql.Expression) (pgsql.Expression, pgsql.DataType, error) {
+	if propertyLookup, isPropertyLookup := asPropertyLookup(collection); isPropertyLookup {
+		// Properties are stored as JSONB and must be expanded with the JSONB set-returning functions
+		propertyLookup.Operator = pgsql.OperatorJSONField
+
+		return pgsql.FunctionCall{
+			Function:   pgsql.FunctionJSONBArrayElementsText,
+			Parameters: []pgsql.Expression{propertyLookup},
+		}, pgsql.Text, nil
+	}
+
+	unnest := pgsql.FunctionCall{
+		Function:   pgsql.FunctionUnnest,
+		Parameters: []pgsql.Expression{collection},
+	}
+
+	switch typedCollection := collection.(type) {
+	case pgsql.CompoundExpression:
+		// Path component lookups are formatted as compound expressions: (<path>).nodes or (<path>).edges
+		if len(typedCollection) > 1 {
+			switch typedCollection[len(typedCollection)-1] {
+			case pgsql.ColumnNodes:
+				return unnest, pgsql.NodeComposite, nil
+
+			case pgsql.ColumnEdges:
+				return unnest, pgsql.EdgeComposite, nil
+			}
+		}
+
+	case pgsql.TypeHinted:
+		if collectionType := typedCollection.TypeHint(); collectionType.IsArrayType() {
+			if elementType, err := collectionType.ArrayBaseType(); err != nil {
+				return nil, pgsql.UnsetDataType, err
+			} else {
+				return unnest, elementType, nil
+			}
+		}
+	}
+
+	return nil, pgsql.UnsetDataType, fmt.Errorf("unable to determine the element type of filter collection %T", collection)
+}
+
+func (s *Translator) currentFilter() (*Filter, error) {
+	if len(s.filters) == 0 {
+		return nil, fmt.Errorf("expected a filter expression to be in scope")
+	}
+
+	return s.filters[len(s.filters)-1], nil
+}
+
+func (s *Translator) translateIDInCollection(idInCollection *cypher.IDInCollection) error {
+	var (
+		alias         = pgsql.Identifier(idInCollection.Variable.Symbol)
+		shadowedAlias = models.EmptyOptional[pgsql.Identifier]()
+	)
+
+	if collection, err := s.treeTranslator.Pop(); err != nil {
+		return err
+	} else if source, elementType, err := filterSource(collection); err != nil {
+		return err
+	} else {
+		bindingType := elementType
+
+		switch elementType {
+		case pgsql.NodeComposite, pgsql.EdgeComposite:
+		default:
+			bindingType = pgsql.ListElement
+		}
+
+		if binding, err := s.query.Scope.DefineNew(bindingType); err != nil {
+			return err
+		} else {
+			if shadowedBinding, isShadowed := s.query.Scope.AliasedLookup(alias); isShadowed {
+				shadowedAlias = models.ValueOptional(shadowedBinding.Identifier)
+			}
+
+			s.query.Scope.Alias(alias, binding)
+
+			s.filters = append(s.filters, &Filter{
+				Binding:       binding,
+				ElementType:   elementType,
+				Source:        source,
+				ShadowedAlias: shadowedAlias,
+			})
+		}
+	}
+
+	return nil
+}
+
+// filterElementReference returns the expression used to reference the given filter iteration binding. List
+// elements carry no type information of their own and are type cast to the element type of the filter's collection.
+func (s *Translator) filterElementReference(binding *BoundIdentifier) pgsql.Expression {
+	if binding.DataType == pgsql.ListElement {
+		for _, filter := range s.filters {
+			if filter.Binding == binding {
+				return pgsql.NewTypeCast(binding.Identifier, filter.ElementType)
+			}
+		}
+	}
+
+	return binding.Identifier
+}
+
+// popFilter removes the current filter from the translator and restores any alias that the filter's iteration
+// variable shadowed.
+func (s *Translator) popFilter() (*Filter, error) {
+	if filter, err := s.currentFilter(); err != nil {
+		return nil, err
+	} else {
+		s.filters = s.filters[:len(s.filters)-1]
+		s.query.Scope.Unalias(filter.Binding.Alias.Value)
+
+		if filter.ShadowedAlias.Set {
+			if shadowedBinding, bound := s.query.Scope.Lookup(filter.ShadowedAlias.Value); !bound {
+				return nil, fmt.Errorf("unable to restore shadowed identifier %s", filter.ShadowedAlias.Value)
+			} else {
+				s.query.Scope.Alias(filter.Binding.Alias.Value, shadowedBinding)
+			}
+		}
+
+		return filter, nil
+	}
+}
+
+func (s *Filter) selectFrom(projection pgsql.SelectItem, predicate pgsql.Expression) pgsql.Parenthetical {
+	return pgsql.Parenthetical{
+		Expression: pgsql.Select{
+			Projection: []pgsql.SelectItem{projection},
+			From: []pgsql.FromClause{{
+				Source: pgsql.AliasedExpression{
+					Expression: s.Source,
+					Alias:      pgsql.AsOptionalIdentifier(s.Binding.Identifier),
+				},
+			}},
+			Where: predicate,
+		},
+	}
+}
+
+func (s *Translator) translateQuantifier(quantifier *cypher.Quantifier) error {
+	if filter, err := s.popFilter(); err != nil {
+		return err
+	} else {
+		var (
+			predicate = filter.Predicate
+			operator  = pgsql.OperatorEquals
+			count     int
+		)
+
+		switch quantifier.Type {
+		case cypher.QuantifierTypeAll:
+			// All elements satisfy the predicate if no element fails to satisfy it
+			if predicate != nil {
+				predicate = pgsql.NewUnaryExpression(pgsql.OperatorNot, pgsql.Parenthetical{
+					Expression: predicate,
+				})
+			} else {
+				predicate = pgsql.NewLiteral(false, pgsql.Boolean)
+			}
+
+		case cypher.QuantifierTypeAny:
+			operator = pgsql.OperatorGreaterThanOrEqualTo
+			count = 1
+
+		case cypher.QuantifierTypeNone:
+
+		case cypher.QuantifierTypeSingle:
+			count = 1
+
+		default:
+			return fmt.Errorf("unsupported quantifier type: %s", quantifier.Type)
+		}
+
+		s.treeTranslator.Push(pgsql.NewBinaryExpression(
+			filter.selectFrom(pgsql.FunctionCall{
+				Function:   pgsql.FunctionCount,
+				Parameters: []pgsql.Expression{pgsql.WildcardIdentifier},
+			}, predicate),
+			operator,
+			pgsql.NewLiteral(count, pgsql.Int),
+		))
+	}
+
+	return nil
+}
+
+func (s *Translator) translateListComprehension(listComprehension *cypher.ListComprehension) error {
+	var projection pgsql.Expression
+
+	if listComprehension.Projection != nil {
+		if projectionExpression, err := s.treeTranslator.Pop(); err != nil {
+			return err
+		} else {
+			projection = projectionExpression
+		}
+	}
+
+	if filter, err := s.popFilter(); err != nil {
+		return err
+	} else {
+		if projection == nil {
+			projection = filter.Binding.Identifier
+		}
+
+		aggregate := pgsql.FunctionCall{
+			Function:   pgsql.FunctionArrayAggregate,
+			Parameters: []pgsql.Expression{projection},
+		}
+
+		if projectionType, hasHint := GetTypeHint(projection); hasHint && projectionType.IsKnown() && !projectionType.IsArrayType() {
+			if arrayType, err := projectionType.ToArrayType(); err != nil {
+				return err
+			} else {
+				aggregate.CastType = arrayType
+			}
+		}
+
+		s.treeTranslator.Push(filter.selectFrom(aggregate, filter.Predicate))
+	}
+
+	return nil
+}
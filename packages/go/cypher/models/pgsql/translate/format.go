@@ -27,10 +27,14 @@ import (
 )
 
 func Translated(translation Result) (string, error) {
-	return format.Statement(translation.Statement, format.NewOutputBuilder())
+	return format.Statement(translation.Statement, format.NewOutputBuilder().WithTableRewrites(translation.TableRewrites))
 }
 
 func FromCypher(ctx context.Context, regularQuery *cypher.RegularQuery, kindMapper pgsql.KindMapper, stripLiterals bool) (format.Formatted, error) {
+	return FromCypherWithTableRewrites(ctx, regularQuery, kindMapper, stripLiterals, nil)
+}
+
+func FromCypherWithTableRewrites(ctx context.Context, regularQuery *cypher.RegularQuery, kindMapper pgsql.KindMapper, stripLiterals bool, rewrites map[pgsql.Identifier]pgsql.Identifier) (format.Formatted, error) {
 	var (
 		output  = &bytes.Buffer{}
 		emitter = cypherFormat.NewCypherEmitter(stripLiterals)
@@ -44,9 +48,9 @@ func FromCypher(ctx context.Context, regularQuery *cypher.RegularQuery, kindMapp
 
 	output.WriteString("\n")
 
-	if translation, err := Translate(ctx, regularQuery, kindMapper, nil); err != nil {
+	if translation, err := TranslateWithTableRewrites(ctx, regularQuery, kindMapper, nil, rewrites); err != nil {
 		return format.Formatted{}, err
-	} else if sqlQuery, err := format.Statement(translation.Statement, format.NewOutputBuilder()); err != nil {
+	} else if sqlQuery, err := Translated(translation); err != nil {
 		return format.Formatted{}, err
 	} else {
 		output.WriteString(sqlQuery)
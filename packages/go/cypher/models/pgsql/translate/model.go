@@ -150,6 +150,17 @@ func (s *Pattern) CurrentPart() *PatternPart {
 	return s.Parts[len(s.Parts)-1]
 }
 
+// Filter tracks the translation of an openCypher filter expression (`x IN list WHERE ...`) as used by quantifier
+// expressions and list comprehensions. The filter's iteration variable is bound for the lifetime of the filter and
+// any alias it shadows is restored once the filter has been translated.
+type Filter struct {
+	Binding       *BoundIdentifier
+	ElementType   pgsql.DataType
+	Source        pgsql.Expression
+	Predicate     pgsql.Expression
+	ShadowedAlias models.Optional[pgsql.Identifier]
+}
+
 type Query struct {
 	Model   *pgsql.Query
 	Scope   *Scope
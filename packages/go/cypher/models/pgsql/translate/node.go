@@ -158,6 +158,11 @@ func (s *Translator) translateNodePatternToStep(scope *Scope, part *PatternPart,
 		}
 
 		part.NodeSelect.Binding = bindingResult.Binding
+
+		// A path bound to a single node pattern, e.g. p = (n), is a zero-hop path made up of only that node
+		if part.PatternBinding.Set {
+			part.PatternBinding.Value.DependOn(bindingResult.Binding)
+		}
 	}
 
 	return nil
@@ -171,6 +171,27 @@ func buildProjection(alias pgsql.Identifier, projected *BoundIdentifier, scope *
 			edgeReferences      []pgsql.Expression
 		)
 
+		// A zero-hop path is made up of its single node and no edges
+		if node, isZeroHop := zeroHopPathNode(projected); isZeroHop {
+			return []pgsql.SelectItem{
+				&pgsql.AliasedExpression{
+					Expression: pgsql.CompositeValue{
+						DataType: pgsql.PathComposite,
+						Values: []pgsql.Expression{
+							pgsql.ArrayLiteral{
+								Values:   []pgsql.Expression{node.Identifier},
+								CastType: pgsql.NodeComposite,
+							},
+							pgsql.ArrayLiteral{
+								CastType: pgsql.EdgeComposite,
+							},
+						},
+					},
+					Alias: pgsql.AsOptionalIdentifier(alias),
+				},
+			}, nil
+		}
+
 		for _, dependency := range projected.Dependencies {
 			switch dependency.DataType {
 			case pgsql.ExpansionPath:
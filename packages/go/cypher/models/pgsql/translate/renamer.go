@@ -64,21 +64,49 @@ type IdentifierRewriter struct {
 
 	scopeIdentifier pgsql.Identifier
 	targets         *pgsql.IdentifierSet
+	localBindings   *pgsql.IdentifierSet
 	stack           []pgsql.SyntaxNode
 }
 
+// localSourceBindings returns the set of identifiers bound by set-returning function sources in the given from
+// clauses (e.g. unnest(...) as i). These bindings are local to the select they belong to.
+func localSourceBindings(fromClauses []pgsql.FromClause) []pgsql.Identifier {
+	var bindings []pgsql.Identifier
+
+	for _, fromClause := range fromClauses {
+		if aliasedSource, isAliased := fromClause.Source.(pgsql.AliasedExpression); isAliased && aliasedSource.Alias.Set {
+			if _, isFunctionCall := aliasedSource.Expression.(pgsql.FunctionCall); isFunctionCall {
+				bindings = append(bindings, aliasedSource.Alias.Value)
+			}
+		}
+	}
+
+	return bindings
+}
+
+func (s *IdentifierRewriter) isTarget(identifier pgsql.Identifier) bool {
+	if s.localBindings.Contains(identifier) {
+		return false
+	}
+
+	return s.targets == nil || s.targets.Contains(identifier)
+}
+
 func (s *IdentifierRewriter) enter(node pgsql.SyntaxNode) error {
 	switch typedExpression := node.(type) {
+	case pgsql.Select:
+		s.localBindings.Add(localSourceBindings(typedExpression.From)...)
+
 	case pgsql.Projection:
 		for idx, projection := range typedExpression {
 			switch typedProjection := projection.(type) {
 			case pgsql.Identifier:
-				if s.targets == nil || s.targets.Contains(typedProjection) {
+				if s.isTarget(typedProjection) {
 					typedExpression[idx] = rewriteCompositeTypeReferenceRoot(s.scopeIdentifier, pgsql.CompoundIdentifier{typedProjection})
 				}
 
 			case pgsql.CompoundIdentifier:
-				if s.targets == nil || s.targets.Contains(typedProjection.Root()) {
+				if s.isTarget(typedProjection.Root()) {
 					typedExpression[idx] = rewriteCompositeTypeFieldReference(s.scopeIdentifier, typedProjection)
 				}
 			}
@@ -88,12 +116,12 @@ func (s *IdentifierRewriter) enter(node pgsql.SyntaxNode) error {
 		for idx, value := range typedExpression.Values {
 			switch typedValue := value.(type) {
 			case pgsql.Identifier:
-				if s.targets == nil || s.targets.Contains(typedValue) {
+				if s.isTarget(typedValue) {
 					typedExpression.Values[idx] = rewriteCompositeTypeReference(s.scopeIdentifier, typedValue)
 				}
 
 			case pgsql.CompoundIdentifier:
-				if s.targets == nil || s.targets.Contains(typedValue.Root()) {
+				if s.isTarget(typedValue.Root()) {
 					typedExpression.Values[idx] = rewriteCompositeTypeReferenceRoot(s.scopeIdentifier, typedValue)
 				}
 			}
@@ -103,26 +131,41 @@ func (s *IdentifierRewriter) enter(node pgsql.SyntaxNode) error {
 		for idx, parameter := range typedExpression.Parameters {
 			switch typedParameter := parameter.(type) {
 			case pgsql.Identifier:
-				if s.targets == nil || s.targets.Contains(typedParameter) {
+				if s.isTarget(typedParameter) {
 					typedExpression.Parameters[idx] = rewriteCompositeTypeReference(s.scopeIdentifier, typedParameter)
 				}
 
 			case pgsql.CompoundIdentifier:
-				if s.targets == nil || s.targets.Contains(typedParameter.Root()) {
+				if s.isTarget(typedParameter.Root()) {
 					typedExpression.Parameters[idx] = rewriteCompositeTypeReferenceRoot(s.scopeIdentifier, typedParameter)
 				}
 			}
 		}
 
+	case pgsql.ArrayLiteral:
+		for idx, value := range typedExpression.Values {
+			switch typedValue := value.(type) {
+			case pgsql.Identifier:
+				if s.isTarget(typedValue) {
+					typedExpression.Values[idx] = rewriteCompositeTypeReference(s.scopeIdentifier, typedValue)
+				}
+
+			case pgsql.CompoundIdentifier:
+				if s.isTarget(typedValue.Root()) {
+					typedExpression.Values[idx] = rewriteCompositeTypeFieldReference(s.scopeIdentifier, typedValue)
+				}
+			}
+		}
+
 	case *pgsql.ArrayIndex:
 		switch typedArrayIndexExpression := typedExpression.Expression.(type) {
 		case pgsql.Identifier:
-			if s.targets == nil || s.targets.Contains(typedArrayIndexExpression) {
+			if s.isTarget(typedArrayIndexExpression) {
 				typedExpression.Expression = rewriteCompositeTypeReference(s.scopeIdentifier, typedArrayIndexExpression)
 			}
 
 		case pgsql.CompoundIdentifier:
-			if s.targets == nil || s.targets.Contains(typedArrayIndexExpression.Root()) {
+			if s.isTarget(typedArrayIndexExpression.Root()) {
 				typedExpression.Expression = rewriteCompositeTypeReferenceRoot(s.scopeIdentifier, typedArrayIndexExpression)
 			}
 		}
@@ -130,12 +173,12 @@ func (s *IdentifierRewriter) enter(node pgsql.SyntaxNode) error {
 		for idx, indexExpression := range typedExpression.Indexes {
 			switch typedIndexExpression := indexExpression.(type) {
 			case pgsql.Identifier:
-				if s.targets == nil || s.targets.Contains(typedIndexExpression) {
+				if s.isTarget(typedIndexExpression) {
 					typedExpression.Indexes[idx] = rewriteCompositeTypeReference(s.scopeIdentifier, typedIndexExpression)
 				}
 
 			case pgsql.CompoundIdentifier:
-				if s.targets == nil || s.targets.Contains(typedIndexExpression.Root()) {
+				if s.isTarget(typedIndexExpression.Root()) {
 					typedExpression.Indexes[idx] = rewriteCompositeTypeReferenceRoot(s.scopeIdentifier, typedIndexExpression)
 				}
 			}
@@ -144,12 +187,12 @@ func (s *IdentifierRewriter) enter(node pgsql.SyntaxNode) error {
 	case *pgsql.Parenthetical:
 		switch typedParentheticalExpression := typedExpression.Expression.(type) {
 		case pgsql.Identifier:
-			if s.targets == nil || s.targets.Contains(typedParentheticalExpression) {
+			if s.isTarget(typedParentheticalExpression) {
 				typedExpression.Expression = rewriteCompositeTypeReference(s.scopeIdentifier, typedParentheticalExpression)
 			}
 
 		case pgsql.CompoundIdentifier:
-			if s.targets == nil || s.targets.Contains(typedParentheticalExpression.Root()) {
+			if s.isTarget(typedParentheticalExpression.Root()) {
 				typedExpression.Expression = rewriteCompositeTypeReferenceRoot(s.scopeIdentifier, typedParentheticalExpression)
 			}
 		}
@@ -157,12 +200,12 @@ func (s *IdentifierRewriter) enter(node pgsql.SyntaxNode) error {
 	case *pgsql.AliasedExpression:
 		switch typedAliasedExpression := typedExpression.Expression.(type) {
 		case pgsql.Identifier:
-			if s.targets == nil || s.targets.Contains(typedAliasedExpression) {
+			if s.isTarget(typedAliasedExpression) {
 				typedExpression.Expression = rewriteCompositeTypeReference(s.scopeIdentifier, typedAliasedExpression)
 			}
 
 		case pgsql.CompoundIdentifier:
-			if s.targets == nil || s.targets.Contains(typedAliasedExpression.Root()) {
+			if s.isTarget(typedAliasedExpression.Root()) {
 				typedExpression.Expression = rewriteCompositeTypeReferenceRoot(s.scopeIdentifier, typedAliasedExpression)
 			}
 		}
@@ -170,24 +213,24 @@ func (s *IdentifierRewriter) enter(node pgsql.SyntaxNode) error {
 	case *pgsql.BinaryExpression:
 		switch typedLOperand := typedExpression.LOperand.(type) {
 		case pgsql.Identifier:
-			if s.targets == nil || s.targets.Contains(typedLOperand) {
+			if s.isTarget(typedLOperand) {
 				typedExpression.LOperand = rewriteCompositeTypeReference(s.scopeIdentifier, typedLOperand)
 			}
 
 		case pgsql.CompoundIdentifier:
-			if s.targets == nil || s.targets.Contains(typedLOperand.Root()) {
+			if s.isTarget(typedLOperand.Root()) {
 				typedExpression.LOperand = rewriteCompositeTypeFieldReference(s.scopeIdentifier, typedLOperand)
 			}
 		}
 
 		switch typedROperand := typedExpression.ROperand.(type) {
 		case pgsql.Identifier:
-			if s.targets == nil || s.targets.Contains(typedROperand) {
+			if s.isTarget(typedROperand) {
 				typedExpression.ROperand = rewriteCompositeTypeReference(s.scopeIdentifier, typedROperand)
 			}
 
 		case pgsql.CompoundIdentifier:
-			if s.targets == nil || s.targets.Contains(typedROperand.Root()) {
+			if s.isTarget(typedROperand.Root()) {
 				typedExpression.ROperand = rewriteCompositeTypeFieldReference(s.scopeIdentifier, typedROperand)
 			}
 		}
@@ -221,6 +264,7 @@ func NewIdentifierRewriter(scopeIdentifier pgsql.Identifier, targets *pgsql.Iden
 		HierarchicalVisitor: walk.NewComposableHierarchicalVisitor[pgsql.SyntaxNode](),
 		scopeIdentifier:     scopeIdentifier,
 		targets:             targets,
+		localBindings:       pgsql.NewIdentifierSet(),
 	}
 }
 
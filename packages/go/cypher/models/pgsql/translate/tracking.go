@@ -50,6 +50,8 @@ func (s IdentifierGenerator) NewIdentifier(dataType pgsql.DataType) (pgsql.Ident
 		return pgsql.Identifier("s" + nextIDStr), nil
 	case pgsql.ParameterIdentifier:
 		return pgsql.Identifier("pi" + nextIDStr), nil
+	case pgsql.ListElement:
+		return pgsql.Identifier("le" + nextIDStr), nil
 	default:
 		return "", fmt.Errorf("identifier with data type %s does not have a prefix case", dataType)
 	}
@@ -361,6 +363,12 @@ func (s *Scope) Alias(alias pgsql.Identifier, binding *BoundIdentifier) {
 	s.aliases[alias] = binding.Identifier
 }
 
+// Unalias removes the given alias from the scope. If the alias previously resolved to a different identifier
+// the caller is expected to restore it with Alias.
+func (s *Scope) Unalias(alias pgsql.Identifier) {
+	delete(s.aliases, alias)
+}
+
 func (s *Scope) Declare(identifier pgsql.Identifier) {
 	s.CurrentFrame().Visible.Add(identifier)
 }
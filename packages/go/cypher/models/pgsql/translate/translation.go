@@ -285,6 +285,124 @@ func (s *Translator) translateCoalesceFunction(functionInvocation *cypher.Functi
 	return nil
 }
 
+// zeroHopPathNode returns the node of a path binding that is bound to a single node pattern, e.g. p = (n).
+func zeroHopPathNode(pathBinding *BoundIdentifier) (*BoundIdentifier, bool) {
+	if len(pathBinding.Dependencies) == 1 && pathBinding.Dependencies[0].DataType == pgsql.NodeComposite {
+		return pathBinding.Dependencies[0], true
+	}
+
+	return nil, false
+}
+
+// pathEdgeIDs builds an int8[] expression of all edge identifiers that make up the given path binding.
+func pathEdgeIDs(pathBinding *BoundIdentifier) (pgsql.Expression, error) {
+	var (
+		edgeIDs        pgsql.Expression
+		edgeReferences []pgsql.Expression
+	)
+
+	for _, dependency := range pathBinding.Dependencies {
+		switch dependency.DataType {
+		case pgsql.ExpansionPath:
+			edgeIDs = pgsql.BinaryExpressionJoin(edgeIDs, pgsql.OperatorConcatenate, dependency.Identifier)
+
+		case pgsql.EdgeComposite:
+			edgeReferences = append(edgeReferences, pgsql.CompoundIdentifier{dependency.Identifier, pgsql.ColumnID})
+
+		default:
+			return nil, fmt.Errorf("unsupported nested composite type for pathcomposite: %s", dependency.DataType)
+		}
+	}
+
+	if len(edgeReferences) > 0 {
+		edgeIDs = pgsql.BinaryExpressionJoin(edgeIDs, pgsql.OperatorConcatenate, pgsql.ArrayLiteral{
+			Values:   edgeReferences,
+			CastType: pgsql.Int8Array,
+		})
+	}
+
+	if edgeIDs == nil {
+		return nil, fmt.Errorf("path %s has no edges", pathBinding.Identifier)
+	}
+
+	return edgeIDs, nil
+}
+
+// translateZeroHopPathFunction translates a path function for a path made up of a single node and no edges.
+func (s *Translator) translateZeroHopPathFunction(functionInvocation *cypher.FunctionInvocation, functionName string, node *BoundIdentifier) error {
+	switch functionName {
+	case cypher.NodesFunction:
+		s.treeTranslator.Push(pgsql.ArrayLiteral{
+			Values:   []pgsql.Expression{node.Identifier},
+			CastType: pgsql.NodeComposite,
+		})
+
+	case cypher.RelationshipsFunction:
+		s.treeTranslator.Push(pgsql.ArrayLiteral{
+			CastType: pgsql.EdgeComposite,
+		})
+
+	case cypher.LengthFunction:
+		s.treeTranslator.Push(pgsql.NewLiteral(0, pgsql.Int))
+
+	default:
+		return fmt.Errorf("unsupported path function: %s", functionInvocation.Name)
+	}
+
+	return nil
+}
+
+func (s *Translator) translatePathFunction(functionInvocation *cypher.FunctionInvocation, functionName string) error {
+	if functionInvocation.NumArguments() != 1 {
+		return fmt.Errorf("expected only one argument for cypher function: %s", functionInvocation.Name)
+	} else if argument, err := s.treeTranslator.Pop(); err != nil {
+		return err
+	} else if identifier, isIdentifier := argument.(pgsql.Identifier); !isIdentifier {
+		return fmt.Errorf("expected a path identifier for the cypher function: %s but received %T", functionInvocation.Name, argument)
+	} else if pathBinding, bound := s.query.Scope.Lookup(identifier); !bound {
+		return fmt.Errorf("unable to find identifier %s", identifier)
+	} else if pathBinding.DataType != pgsql.PathComposite {
+		return fmt.Errorf("expected a path identifier for the cypher function: %s but received type %s", functionInvocation.Name, pathBinding.DataType)
+	} else if node, isZeroHop := zeroHopPathNode(pathBinding); isZeroHop {
+		return s.translateZeroHopPathFunction(functionInvocation, functionName, node)
+	} else if edgeIDs, err := pathEdgeIDs(pathBinding); err != nil {
+		return err
+	} else {
+		path := pgsql.Parenthetical{
+			Expression: pgsql.FunctionCall{
+				Function: pgsql.FunctionEdgesToPath,
+				Parameters: []pgsql.Expression{
+					pgsql.Variadic{
+						Expression: edgeIDs,
+					},
+				},
+				CastType: pgsql.PathComposite,
+			},
+		}
+
+		switch functionName {
+		case cypher.NodesFunction:
+			s.treeTranslator.Push(pgsql.CompoundExpression{path, pgsql.ColumnNodes})
+
+		case cypher.RelationshipsFunction:
+			s.treeTranslator.Push(pgsql.CompoundExpression{path, pgsql.ColumnEdges})
+
+		case cypher.LengthFunction:
+			// The length of a path is the number of edges it contains
+			s.treeTranslator.Push(pgsql.FunctionCall{
+				Function:   pgsql.FunctionCardinality,
+				Parameters: []pgsql.Expression{edgeIDs},
+				CastType:   pgsql.Int,
+			})
+
+		default:
+			return fmt.Errorf("unsupported path function: %s", functionInvocation.Name)
+		}
+	}
+
+	return nil
+}
+
 func (s *Translator) translateKindMatcher(kindMatcher *cypher.KindMatcher) error {
 	if variable, isVariable := kindMatcher.Reference.(*cypher.Variable); !isVariable {
 		return fmt.Errorf("expected variable for kind matcher reference but found type: %T", kindMatcher.Reference)
@@ -41,6 +41,7 @@ const (
 	StateTranslatingUpdateClause
 	StateTranslatingPatternPredicate
 	StateTranslatingNestedExpression
+	StateTranslatingFilterExpression
 )
 
 func (s State) String() string {
@@ -61,6 +62,8 @@ func (s State) String() string {
 		return "pattern predicate"
 	case StateTranslatingNestedExpression:
 		return "nested expression"
+	case StateTranslatingFilterExpression:
+		return "filter expression"
 	default:
 		return ""
 	}
@@ -80,6 +83,7 @@ type Translator struct {
 	projections    *ProjectionClause
 	mutations      *Mutations
 	query          *Query
+	filters        []*Filter
 }
 
 func NewTranslator(ctx context.Context, kindMapper pgsql.KindMapper, parameters map[string]any) *Translator {
@@ -166,12 +170,22 @@ func (s *Translator) Enter(expression cypher.SyntaxNode) {
 		}
 
 	case *cypher.Where:
-		// Track that we're in a where clause first
-		s.pushState(StateTranslatingWhere)
+		// Where clauses that belong to a filter expression are predicates of the filter and not constraints of
+		// the query
+		if s.currentState() != StateTranslatingFilterExpression {
+			// Track that we're in a where clause first
+			s.pushState(StateTranslatingWhere)
+		}
 
 		// If there's a where AST node present in the cypher model we likely have an expression to translate
 		s.pushState(StateTranslatingNestedExpression)
 
+	case *cypher.Quantifier, *cypher.ListComprehension, *cypher.IDInCollection:
+		s.pushState(StateTranslatingNestedExpression)
+
+	case *cypher.FilterExpression:
+		s.pushState(StateTranslatingFilterExpression)
+
 	case graph.Kinds:
 		s.treeTranslator.Push(pgsql.KindListLiteral{
 			Values: typedExpression,
@@ -227,7 +241,7 @@ func (s *Translator) Enter(expression cypher.SyntaxNode) {
 			if binding, resolved := s.query.Scope.LookupString(typedExpression.Symbol); !resolved {
 				s.SetErrorf("unable to find identifier %s", typedExpression.Symbol)
 			} else {
-				s.treeTranslator.Push(binding.Identifier)
+				s.treeTranslator.Push(s.filterElementReference(binding))
 			}
 
 		default:
@@ -664,6 +678,11 @@ func (s *Translator) Exit(expression cypher.SyntaxNode) {
 				s.SetError(err)
 			}
 
+		case cypher.NodesFunction, cypher.RelationshipsFunction, cypher.LengthFunction:
+			if err := s.translatePathFunction(typedExpression, formattedName); err != nil {
+				s.SetError(err)
+			}
+
 		default:
 			s.SetErrorf("unknown cypher function: %s", typedExpression.Name)
 		}
@@ -743,10 +762,45 @@ func (s *Translator) Exit(expression cypher.SyntaxNode) {
 	case *cypher.Where:
 		// Validate state transitions
 		s.exitState(StateTranslatingNestedExpression)
-		s.exitState(StateTranslatingWhere)
 
-		// Assign the last operands as identifier set constraints
-		if err := s.treeTranslator.ConstrainRemainingOperands(); err != nil {
+		if s.currentState() == StateTranslatingFilterExpression {
+			if filter, err := s.currentFilter(); err != nil {
+				s.SetError(err)
+			} else if predicate, err := s.treeTranslator.Pop(); err != nil {
+				s.SetError(err)
+			} else {
+				filter.Predicate = predicate
+			}
+		} else {
+			s.exitState(StateTranslatingWhere)
+
+			// Assign the last operands as identifier set constraints
+			if err := s.treeTranslator.ConstrainRemainingOperands(); err != nil {
+				s.SetError(err)
+			}
+		}
+
+	case *cypher.IDInCollection:
+		s.exitState(StateTranslatingNestedExpression)
+
+		if err := s.translateIDInCollection(typedExpression); err != nil {
+			s.SetError(err)
+		}
+
+	case *cypher.FilterExpression:
+		s.exitState(StateTranslatingFilterExpression)
+
+	case *cypher.Quantifier:
+		s.exitState(StateTranslatingNestedExpression)
+
+		if err := s.translateQuantifier(typedExpression); err != nil {
+			s.SetError(err)
+		}
+
+	case *cypher.ListComprehension:
+		s.exitState(StateTranslatingNestedExpression)
+
+		if err := s.translateListComprehension(typedExpression); err != nil {
 			s.SetError(err)
 		}
 
@@ -860,6 +914,10 @@ func (s *Translator) Exit(expression cypher.SyntaxNode) {
 type Result struct {
 	Statement  pgsql.Statement
 	Parameters map[string]any
+
+	// TableRewrites maps the names of tables referenced by the translated statement to the names of the tables they
+	// must be formatted as
+	TableRewrites map[pgsql.Identifier]pgsql.Identifier
 }
 
 func Translate(ctx context.Context, cypherQuery *cypher.RegularQuery, kindMapper pgsql.KindMapper, parameters map[string]any) (Result, error) {
@@ -871,3 +929,17 @@ func Translate(ctx context.Context, cypherQuery *cypher.RegularQuery, kindMapper
 
 	return translator.translation, nil
 }
+
+// TranslateWithTableRewrites translates the given cypher query with every reference to a table named by a key of the
+// given rewrites formatted as a reference to the table named by its value. This allows a query to be scoped to a
+// single graph partition.
+func TranslateWithTableRewrites(ctx context.Context, cypherQuery *cypher.RegularQuery, kindMapper pgsql.KindMapper, parameters map[string]any, rewrites map[pgsql.Identifier]pgsql.Identifier) (Result, error) {
+	translator := NewTranslator(ctx, kindMapper, parameters)
+	translator.translation.TableRewrites = rewrites
+
+	if err := walk.WalkCypher(cypherQuery, translator); err != nil {
+		return Result{}, err
+	}
+
+	return translator.translation, nil
+}
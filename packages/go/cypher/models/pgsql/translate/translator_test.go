@@ -17,13 +17,18 @@
 package translate_test
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/specterops/bloodhound/dawgs/drivers/pg/pgutil"
+	"github.com/stretchr/testify/require"
 
+	"github.com/specterops/bloodhound/cypher/frontend"
 	"github.com/specterops/bloodhound/cypher/models/pgsql"
 	"github.com/specterops/bloodhound/cypher/models/pgsql/test"
+	"github.com/specterops/bloodhound/cypher/models/pgsql/translate"
 	"github.com/specterops/bloodhound/dawgs/graph"
 )
 
@@ -66,3 +71,34 @@ func TestTranslate(t *testing.T) {
 
 	fmt.Printf("Ran %d test cases\n", casesRun)
 }
+
+func TestTranslateWithTableRewrites(t *testing.T) {
+	rewrites := map[pgsql.Identifier]pgsql.Identifier{
+		pgsql.TableNode: "node_2",
+		pgsql.TableEdge: "edge_2",
+	}
+
+	for _, cypherQuery := range []string{
+		"match (n:NodeKind1)-[r:EdgeKind1]->(m) return n, r, m",
+		"match p = (n:NodeKind1)-[:EdgeKind1*1..]->(m) return p",
+		"match (n:NodeKind1) detach delete n",
+	} {
+		regularQuery, err := frontend.ParseCypher(frontend.NewContext(), cypherQuery)
+		require.Nil(t, err)
+
+		translation, err := translate.TranslateWithTableRewrites(context.Background(), regularQuery, newKindMapper(), nil, rewrites)
+		require.Nil(t, err)
+
+		formattedQuery, err := translate.Translated(translation)
+		require.Nil(t, err)
+
+		for _, parameter := range translation.Parameters {
+			if statement, isString := parameter.(string); isString {
+				formattedQuery += " " + statement
+			}
+		}
+
+		require.Containsf(t, formattedQuery, "node_2", "Rewritten node table missing for query: '%s'", cypherQuery)
+		require.Falsef(t, strings.Contains(formattedQuery, "from node ") || strings.Contains(formattedQuery, "join node ") || strings.Contains(formattedQuery, "from edge ") || strings.Contains(formattedQuery, "join edge "), "Unscoped table reference for query '%s': %s", cypherQuery, formattedQuery)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package visualization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrEmptyExplainPlan is returned when a PostgreSQL EXPLAIN result contains no plan to visualize.
+var ErrEmptyExplainPlan = errors.New("explain output contains no plan")
+
+// ExplainPlanNode is a single node of a PostgreSQL EXPLAIN (FORMAT JSON) plan tree. Only the fields required for
+// visualization are decoded.
+type ExplainPlanNode struct {
+	NodeType     string            `json:"Node Type"`
+	RelationName string            `json:"Relation Name,omitempty"`
+	Alias        string            `json:"Alias,omitempty"`
+	IndexName    string            `json:"Index Name,omitempty"`
+	JoinType     string            `json:"Join Type,omitempty"`
+	StartupCost  float64           `json:"Startup Cost"`
+	TotalCost    float64           `json:"Total Cost"`
+	PlanRows     float64           `json:"Plan Rows"`
+	Plans        []ExplainPlanNode `json:"Plans,omitempty"`
+}
+
+type explainStatement struct {
+	Plan ExplainPlanNode `json:"Plan"`
+}
+
+type explainVisualizer struct {
+	graph  Graph
+	nextID int
+}
+
+func (s *explainVisualizer) getNextID(prefix string) string {
+	nextID := s.nextID
+	s.nextID += 1
+
+	return prefix + strconv.Itoa(nextID)
+}
+
+func explainNodeLabel(planNode ExplainPlanNode) string {
+	builder := strings.Builder{}
+
+	if planNode.JoinType != "" {
+		builder.WriteString(planNode.JoinType)
+		builder.WriteString(" ")
+	}
+
+	builder.WriteString(planNode.NodeType)
+
+	if planNode.IndexName != "" {
+		builder.WriteString(" using ")
+		builder.WriteString(planNode.IndexName)
+	}
+
+	if planNode.RelationName != "" {
+		builder.WriteString(" on ")
+		builder.WriteString(planNode.RelationName)
+
+		if planNode.Alias != "" && planNode.Alias != planNode.RelationName {
+			builder.WriteString(" ")
+			builder.WriteString(planNode.Alias)
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf(" (cost=%.2f..%.2f rows=%.0f)", planNode.StartupCost, planNode.TotalCost, planNode.PlanRows))
+	return builder.String()
+}
+
+func (s *explainVisualizer) visit(planNode ExplainPlanNode, parent *Node) {
+	nextNode := Node{
+		ID:     s.getNextID("n"),
+		Labels: []string{planNode.NodeType},
+		Properties: map[string]any{
+			"value":        explainNodeLabel(planNode),
+			"startup_cost": planNode.StartupCost,
+			"total_cost":   planNode.TotalCost,
+			"plan_rows":    planNode.PlanRows,
+		},
+	}
+
+	s.graph.Nodes = append(s.graph.Nodes, nextNode)
+
+	if parent != nil {
+		s.graph.Relationships = append(s.graph.Relationships, Relationship{
+			ID:     s.getNextID("r"),
+			FromID: nextNode.ID,
+			ToID:   parent.ID,
+		})
+	}
+
+	for _, childPlan := range planNode.Plans {
+		s.visit(childPlan, &nextNode)
+	}
+}
+
+// ExplainPlanToDigraph converts the raw JSON output of a PostgreSQL EXPLAIN (FORMAT JSON) statement into a digraph
+// that may be rendered with GraphToPUMLDigraph.
+func ExplainPlanToDigraph(rawPlan []byte) (Graph, error) {
+	var statements []explainStatement
+
+	if err := json.Unmarshal(rawPlan, &statements); err != nil {
+		return Graph{}, fmt.Errorf("failed to decode explain output: %w", err)
+	} else if len(statements) == 0 {
+		return Graph{}, ErrEmptyExplainPlan
+	}
+
+	visualizer := &explainVisualizer{}
+
+	for _, statement := range statements {
+		visualizer.visit(statement.Plan, nil)
+	}
+
+	return visualizer.graph, nil
+}
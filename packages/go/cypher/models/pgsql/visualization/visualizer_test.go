@@ -43,3 +43,24 @@ func TestGraphToPUMLDigraph(t *testing.T) {
 	require.Nil(t, err)
 	require.Nil(t, GraphToPUMLDigraph(graph, &bytes.Buffer{}))
 }
+
+func TestExplainPlanToDigraph(t *testing.T) {
+	const rawPlan = `[{"Plan": {"Node Type": "Hash Join", "Join Type": "Inner", "Startup Cost": 1.5, "Total Cost": 20.25, "Plan Rows": 10, "Plans": [{"Node Type": "Seq Scan", "Relation Name": "node", "Alias": "n0", "Startup Cost": 0, "Total Cost": 12.5, "Plan Rows": 250}, {"Node Type": "Hash", "Startup Cost": 1, "Total Cost": 1, "Plan Rows": 5, "Plans": [{"Node Type": "Index Scan", "Index Name": "node_pkey", "Relation Name": "node", "Alias": "n1", "Startup Cost": 0, "Total Cost": 1, "Plan Rows": 5}]}]}}]`
+
+	graph, err := ExplainPlanToDigraph([]byte(rawPlan))
+	require.Nil(t, err)
+
+	require.Len(t, graph.Nodes, 4)
+	require.Len(t, graph.Relationships, 3)
+	require.Equal(t, "Inner Hash Join (cost=1.50..20.25 rows=10)", graph.Nodes[0].Properties["value"])
+	require.Equal(t, "Seq Scan on node n0 (cost=0.00..12.50 rows=250)", graph.Nodes[1].Properties["value"])
+	require.Equal(t, "Index Scan using node_pkey on node n1 (cost=0.00..1.00 rows=5)", graph.Nodes[3].Properties["value"])
+	require.Equal(t, graph.Nodes[2].ID, graph.Relationships[2].ToID)
+
+	buffer := &bytes.Buffer{}
+	require.Nil(t, GraphToPUMLDigraph(graph, buffer))
+	require.Contains(t, buffer.String(), "Seq Scan on node n0")
+
+	_, err = ExplainPlanToDigraph([]byte(`[]`))
+	require.ErrorIs(t, err, ErrEmptyExplainPlan)
+}
@@ -240,6 +240,18 @@ func newCypherWalkCursor(node cypher.SyntaxNode) (*Cursor[cypher.SyntaxNode], er
 			Branches: []cypher.SyntaxNode{typedNode.Filter},
 		}, nil
 
+	case *cypher.ListComprehension:
+		nextCursor := &Cursor[cypher.SyntaxNode]{
+			Node:     node,
+			Branches: []cypher.SyntaxNode{typedNode.Filter},
+		}
+
+		if typedNode.Projection != nil {
+			nextCursor.AddBranches(typedNode.Projection)
+		}
+
+		return nextCursor, nil
+
 	case *cypher.FilterExpression:
 		nextCursor := &Cursor[cypher.SyntaxNode]{
 			Node:     node,
@@ -0,0 +1,271 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// batch applies operations to its inner write transaction as they are submitted. Node upserts are resolved through an
+// index of identity property values that is built on first use.
+type batch struct {
+	ctx              context.Context
+	innerTransaction *transaction
+	identityIndexes  map[string]map[string]graph.ID
+}
+
+func newBatch(ctx context.Context, tx *transaction) *batch {
+	return &batch{
+		ctx:              ctx,
+		innerTransaction: tx,
+		identityIndexes:  map[string]map[string]graph.ID{},
+	}
+}
+
+// resetIdentityIndexes discards all identity indexes. This must be called whenever nodes may have been changed
+// outside the upsert logic of this batch.
+func (s *batch) resetIdentityIndexes() {
+	s.identityIndexes = map[string]map[string]graph.ID{}
+}
+
+func (s *batch) WithGraph(graphSchema graph.Graph) graph.Batch {
+	s.innerTransaction.WithGraph(graphSchema)
+	s.resetIdentityIndexes()
+
+	return s
+}
+
+func (s *batch) CreateNode(node *graph.Node) error {
+	_, err := s.innerTransaction.createNode(node.ID, node.Properties, node.Kinds)
+	s.resetIdentityIndexes()
+
+	return err
+}
+
+func (s *batch) DeleteNode(id graph.ID) error {
+	if data, err := s.innerTransaction.mutableData(); err != nil {
+		return err
+	} else {
+		data.deleteNode(id)
+		s.resetIdentityIndexes()
+
+		return nil
+	}
+}
+
+func (s *batch) Nodes() graph.NodeQuery {
+	// Queries may modify nodes so any identity indexes can no longer be trusted
+	s.resetIdentityIndexes()
+	return s.innerTransaction.Nodes()
+}
+
+func (s *batch) Relationships() graph.RelationshipQuery {
+	s.resetIdentityIndexes()
+	return s.innerTransaction.Relationships()
+}
+
+func identityIndexName(identityProperties []string) string {
+	sortedProperties := slices.Clone(identityProperties)
+	slices.Sort(sortedProperties)
+
+	return strings.Join(sortedProperties, "\x00")
+}
+
+// identityKey formats the values of the given identity properties. The returned boolean is false if any of the
+// identity properties are missing.
+func identityKey(properties *graph.Properties, identityProperties []string) (string, bool) {
+	key := strings.Builder{}
+
+	for idx, identityProperty := range identityProperties {
+		if properties == nil || !properties.Exists(identityProperty) {
+			return "", false
+		}
+
+		if idx > 0 {
+			key.WriteString("\x00")
+		}
+
+		key.WriteString(fmt.Sprintf("%v", normalizeValue(properties.Get(identityProperty).Any())))
+	}
+
+	return key.String(), true
+}
+
+func (s *batch) identityIndex(data *graphData, identityProperties []string) map[string]graph.ID {
+	indexName := identityIndexName(identityProperties)
+
+	if index, exists := s.identityIndexes[indexName]; exists {
+		return index
+	}
+
+	index := map[string]graph.ID{}
+
+	for _, nodeID := range data.sortedNodeIDs() {
+		if key, hasKey := identityKey(data.nodes[nodeID].Properties, identityProperties); hasKey {
+			if _, indexed := index[key]; !indexed {
+				index[key] = nodeID
+			}
+		}
+	}
+
+	s.identityIndexes[indexName] = index
+	return index
+}
+
+// upsertNode creates the given node or, if a node with matching identity property values exists, merges the
+// properties and kinds of the given node into the existing node. Like the PostgreSQL driver, identity kinds are not
+// considered when matching nodes.
+func (s *batch) upsertNode(update graph.NodeUpdate) (graph.ID, error) {
+	if len(update.IdentityProperties) == 0 {
+		return 0, fmt.Errorf("node update requires at least one identity property")
+	}
+
+	data, err := s.innerTransaction.mutableData()
+
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		identityProperties = slices.Clone(update.IdentityProperties)
+		index              = s.identityIndex(data, identityProperties)
+	)
+
+	key, hasKey := identityKey(update.Node.Properties, identityProperties)
+
+	if !hasKey {
+		return 0, fmt.Errorf("node update is missing values for identity properties %v", identityProperties)
+	}
+
+	if existingID, exists := index[key]; exists {
+		if existing, exists := data.nodes[existingID]; exists {
+			merged := copyNode(existing)
+			merged.Kinds = merged.Kinds.Add(update.Node.Kinds...)
+
+			if update.Node.Properties != nil {
+				for propertyKey, value := range update.Node.Properties.Map {
+					merged.Properties.Map[propertyKey] = value
+				}
+			}
+
+			data.putNode(merged)
+			return existingID, nil
+		}
+	}
+
+	if created, err := s.innerTransaction.createNode(0, update.Node.Properties, update.Node.Kinds); err != nil {
+		return 0, err
+	} else {
+		index[key] = created.ID
+		return created.ID, nil
+	}
+}
+
+func (s *batch) UpdateNodeBy(update graph.NodeUpdate) error {
+	_, err := s.upsertNode(update)
+	return err
+}
+
+// upsertRelationship creates the given relationship or, if a relationship with the same start node, end node, kind
+// and identity property values exists, merges the properties of the given relationship into the existing one.
+func (s *batch) upsertRelationship(relationship *graph.Relationship, identityProperties []string) error {
+	data, err := s.innerTransaction.mutableData()
+
+	if err != nil {
+		return err
+	}
+
+	key, _ := identityKey(relationship.Properties, identityProperties)
+
+	for _, existing := range data.adjacent(relationship.StartID, graph.DirectionOutbound) {
+		if existing.EndID != relationship.EndID || existing.Kind.String() != relationship.Kind.String() {
+			continue
+		}
+
+		if existingKey, _ := identityKey(existing.Properties, identityProperties); existingKey == key {
+			merged := copyRelationship(existing)
+
+			if relationship.Properties != nil {
+				for propertyKey, value := range relationship.Properties.Map {
+					merged.Properties.Map[propertyKey] = value
+				}
+			}
+
+			data.putRelationship(merged)
+			return nil
+		}
+	}
+
+	_, err = s.innerTransaction.createRelationship(relationship.StartID, relationship.EndID, relationship.Kind, relationship.Properties)
+	return err
+}
+
+// CreateRelationship creates the given relationship. Like the PostgreSQL driver, an existing relationship with the
+// same start node, end node and kind is updated with the properties of the given relationship instead.
+func (s *batch) CreateRelationship(relationship *graph.Relationship) error {
+	return s.upsertRelationship(relationship, nil)
+}
+
+func (s *batch) CreateRelationshipByIDs(startNodeID, endNodeID graph.ID, kind graph.Kind, properties *graph.Properties) error {
+	return s.CreateRelationship(&graph.Relationship{
+		StartID:    startNodeID,
+		EndID:      endNodeID,
+		Kind:       kind,
+		Properties: properties,
+	})
+}
+
+func (s *batch) DeleteRelationship(id graph.ID) error {
+	if data, err := s.innerTransaction.mutableData(); err != nil {
+		return err
+	} else {
+		data.deleteRelationship(id)
+		return nil
+	}
+}
+
+func (s *batch) UpdateRelationshipBy(update graph.RelationshipUpdate) error {
+	if startID, err := s.upsertNode(graph.NodeUpdate{
+		Node:               update.Start,
+		IdentityKind:       update.StartIdentityKind,
+		IdentityProperties: update.StartIdentityProperties,
+	}); err != nil {
+		return err
+	} else if endID, err := s.upsertNode(graph.NodeUpdate{
+		Node:               update.End,
+		IdentityKind:       update.EndIdentityKind,
+		IdentityProperties: update.EndIdentityProperties,
+	}); err != nil {
+		return err
+	} else {
+		return s.upsertRelationship(&graph.Relationship{
+			StartID:    startID,
+			EndID:      endID,
+			Kind:       update.Relationship.Kind,
+			Properties: update.Relationship.Properties,
+		}, update.IdentityProperties)
+	}
+}
+
+func (s *batch) Commit() error {
+	return s.innerTransaction.Commit()
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func queryRows(t *testing.T, tx graph.Transaction, cypherQuery string, parameters map[string]any) [][]any {
+	var (
+		result = tx.Query(cypherQuery, parameters)
+		rows   [][]any
+	)
+
+	defer result.Close()
+
+	for result.Next() {
+		values, err := result.Values()
+		require.Nil(t, err)
+
+		decoded, err := values.Decode()
+		require.Nil(t, err)
+
+		rows = append(rows, decoded)
+	}
+
+	require.Nil(t, result.Error())
+	return rows
+}
+
+func TestCypher_Read(t *testing.T) {
+	var (
+		db        = memory.NewDatabase(0)
+		testGraph = newTestGraph(t, db)
+	)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		rows := queryRows(t, tx, "match (n) where n.name <> 'admins' return n.name order by n.name desc", nil)
+		require.Equal(t, [][]any{{"ws01"}, {"alice"}}, rows)
+
+		rows = queryRows(t, tx, "match (n:User)-[:MemberOf]->(g:Group) return n.name, g.name", nil)
+		require.Equal(t, [][]any{{"alice", "admins"}}, rows)
+
+		rows = queryRows(t, tx, "match (n) where n.name = $name return id(n)", map[string]any{"name": "ws01"})
+		require.Equal(t, [][]any{{int64(testGraph.computer.ID)}}, rows)
+
+		rows = queryRows(t, tx, "match (n) return count(n), collect(n.name)[0] skip 0 limit 1", nil)
+		require.Len(t, rows, 1)
+		require.Equal(t, int64(3), rows[0][0])
+
+		rows = queryRows(t, tx, "match (n) optional match (n)-[:AdminTo]->(c) return n.name, c.name order by n.name", nil)
+		require.Equal(t, [][]any{{"admins", "ws01"}, {"alice", nil}, {"ws01", nil}}, rows)
+
+		rows = queryRows(t, tx, "match p = (:User)-[*1..]->(:Computer) return length(p)", nil)
+		require.Equal(t, [][]any{{int64(2)}}, rows)
+
+		rows = queryRows(t, tx, "match p = shortestPath((u:User)-[*]->(c:Computer)) return [n in nodes(p) | n.name]", nil)
+		require.Equal(t, [][]any{{[]any{"alice", "admins", "ws01"}}}, rows)
+
+		rows = queryRows(t, tx, "match (n) with n.name as name where name starts with 'a' return name order by name", nil)
+		require.Equal(t, [][]any{{"admins"}, {"alice"}}, rows)
+
+		rows = queryRows(t, tx, "unwind [1, 2, 3] as x return sum(x), [y in range(1, 3) where y > 1 | y * 2]", nil)
+		require.Equal(t, [][]any{{int64(6), []any{int64(4), int64(6)}}}, rows)
+
+		result := tx.Query("create (n:User)", nil)
+		result.Close()
+		require.ErrorIs(t, result.Error(), memory.ErrReadOnlyTransaction)
+
+		return nil
+	}))
+}
+
+func TestCypher_Write(t *testing.T) {
+	var (
+		db        = memory.NewDatabase(0)
+		testGraph = newTestGraph(t, db)
+	)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		queryRows(t, tx, "match (n:User) set n:Group, n.title = 'admin' remove n:User, n.enabled", nil)
+		queryRows(t, tx, "match (c:Computer) create (c)-[:HasSession {lastseen: 1}]->(u:User {name: 'bob'})", nil)
+		queryRows(t, tx, "merge (u:User {name: 'bob'}) on match set u.merged = true", nil)
+		queryRows(t, tx, "merge (u:User {name: 'carol'}) on create set u.created = true", nil)
+		return nil
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		rows := queryRows(t, tx, "match (n) where id(n) = $id return labels(n), properties(n)", map[string]any{"id": testGraph.user.ID})
+		require.Equal(t, [][]any{{[]any{"Group"}, map[string]any{"name": "alice", "title": "admin"}}}, rows)
+
+		rows = queryRows(t, tx, "match (:Computer)-[r:HasSession]->(u:User) return r.lastseen, u.name, u.merged", nil)
+		require.Equal(t, [][]any{{int64(1), "bob", true}}, rows)
+
+		rows = queryRows(t, tx, "match (u:User {name: 'carol'}) return u.created", nil)
+		require.Equal(t, [][]any{{true}}, rows)
+
+		return nil
+	}))
+
+	require.NotNil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		result := tx.Query("match (n:Computer) delete n", nil)
+		result.Close()
+
+		return result.Error()
+	}))
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		queryRows(t, tx, "match (n:Computer) detach delete n", nil)
+		return nil
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		rows := queryRows(t, tx, "match ()-[r]->() return type(r)", nil)
+		require.Equal(t, [][]any{{"MemberOf"}}, rows)
+
+		return nil
+	}))
+}
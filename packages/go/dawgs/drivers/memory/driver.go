@@ -0,0 +1,183 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+// Driver is an in-memory implementation of graph.Database. Read transactions operate on a snapshot of the graph taken
+// when the transaction begins. Write transactions and batch operations are serialized and their changes become visible
+// to new transactions once committed.
+type Driver struct {
+	lock                  sync.RWMutex
+	writeLock             sync.Mutex
+	graphs                map[string]*graphData
+	defaultGraph          string
+	lastNodeID            graph.ID
+	lastRelationshipID    graph.ID
+	batchWriteSize        int
+	graphQueryMemoryLimit size.Size
+}
+
+func (s *Driver) SetWriteFlushSize(size int) {
+	// This is a no-op function since in-memory transactions do not require rotation
+}
+
+func (s *Driver) SetBatchWriteSize(size int) {
+	s.batchWriteSize = size
+}
+
+// snapshot returns the current committed graphs and the name of the default graph.
+func (s *Driver) snapshot() (map[string]*graphData, string) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return maps.Clone(s.graphs), s.defaultGraph
+}
+
+// install makes the given graph snapshots the committed state of their respective graphs.
+func (s *Driver) install(graphs map[string]*graphData) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for name, data := range graphs {
+		s.graphs[name] = data
+	}
+}
+
+func (s *Driver) nextNodeID() graph.ID {
+	s.lastNodeID++
+	return s.lastNodeID
+}
+
+func (s *Driver) nextRelationshipID() graph.ID {
+	s.lastRelationshipID++
+	return s.lastRelationshipID
+}
+
+// reserveNodeID ensures that IDs generated after a node has been created with a preset ID do not collide with it.
+func (s *Driver) reserveNodeID(id graph.ID) {
+	if id > s.lastNodeID {
+		s.lastNodeID = id
+	}
+}
+
+func renderConfig(options []graph.TransactionOption) graph.TransactionConfig {
+	var cfg graph.TransactionConfig
+
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	return cfg
+}
+
+func withTimeout(ctx context.Context, cfg graph.TransactionConfig) (context.Context, context.CancelFunc) {
+	if cfg.Timeout > 0 {
+		return context.WithTimeout(ctx, cfg.Timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+func (s *Driver) ReadTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+	txCtx, done := withTimeout(ctx, renderConfig(options))
+	defer done()
+
+	return txDelegate(newTransaction(txCtx, s, false))
+}
+
+func (s *Driver) WriteTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+	txCtx, done := withTimeout(ctx, renderConfig(options))
+	defer done()
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	tx := newTransaction(txCtx, s, true)
+
+	if err := txDelegate(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Driver) BatchOperation(ctx context.Context, batchDelegate graph.BatchDelegate) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	batch := newBatch(ctx, newTransaction(ctx, s, true))
+
+	if err := batchDelegate(batch); err != nil {
+		return err
+	}
+
+	return batch.Commit()
+}
+
+func (s *Driver) AssertSchema(ctx context.Context, schema graph.Schema) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, graphSchema := range schema.Graphs {
+		if _, exists := s.graphs[graphSchema.Name]; !exists {
+			s.graphs[graphSchema.Name] = newGraphData()
+		}
+	}
+
+	if schema.DefaultGraph.Name != "" {
+		if _, exists := s.graphs[schema.DefaultGraph.Name]; !exists {
+			s.graphs[schema.DefaultGraph.Name] = newGraphData()
+		}
+
+		s.defaultGraph = schema.DefaultGraph.Name
+	}
+
+	return nil
+}
+
+func (s *Driver) SetDefaultGraph(ctx context.Context, graphSchema graph.Graph) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, exists := s.graphs[graphSchema.Name]; !exists {
+		s.graphs[graphSchema.Name] = newGraphData()
+	}
+
+	s.defaultGraph = graphSchema.Name
+	return nil
+}
+
+func (s *Driver) Run(ctx context.Context, query string, parameters map[string]any) error {
+	return s.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		result := tx.Query(query, parameters)
+		defer result.Close()
+
+		return result.Error()
+	})
+}
+
+func (s *Driver) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,439 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	User       = graph.StringKind("User")
+	Group      = graph.StringKind("Group")
+	Computer   = graph.StringKind("Computer")
+	MemberOf   = graph.StringKind("MemberOf")
+	AdminTo    = graph.StringKind("AdminTo")
+	HasSession = graph.StringKind("HasSession")
+)
+
+type testGraph struct {
+	user     *graph.Node
+	group    *graph.Node
+	computer *graph.Node
+	memberOf *graph.Relationship
+	adminTo  *graph.Relationship
+}
+
+func newTestGraph(t *testing.T, db graph.Database) testGraph {
+	var testGraph testGraph
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		var err error
+
+		if testGraph.user, err = tx.CreateNode(graph.AsProperties(map[string]any{"name": "alice", "enabled": true}), User); err != nil {
+			return err
+		} else if testGraph.group, err = tx.CreateNode(graph.AsProperties(map[string]any{"name": "admins"}), Group); err != nil {
+			return err
+		} else if testGraph.computer, err = tx.CreateNode(graph.AsProperties(map[string]any{"name": "ws01"}), Computer); err != nil {
+			return err
+		} else if testGraph.memberOf, err = tx.CreateRelationshipByIDs(testGraph.user.ID, testGraph.group.ID, MemberOf, graph.NewProperties()); err != nil {
+			return err
+		} else if testGraph.adminTo, err = tx.CreateRelationshipByIDs(testGraph.group.ID, testGraph.computer.ID, AdminTo, graph.NewProperties()); err != nil {
+			return err
+		}
+
+		return nil
+	}))
+
+	return testGraph
+}
+
+func TestOpen(t *testing.T) {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+
+	require.Nil(t, err)
+	require.IsType(t, &memory.Driver{}, db)
+}
+
+func TestTransaction_NodeQueries(t *testing.T) {
+	var (
+		db        = memory.NewDatabase(0)
+		testGraph = newTestGraph(t, db)
+	)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if node, err := tx.Nodes().Filter(query.Equals(query.NodeProperty("name"), "alice")).First(); err != nil {
+			return err
+		} else {
+			require.Equal(t, testGraph.user.ID, node.ID)
+			require.Equal(t, graph.Kinds{User}, node.Kinds)
+		}
+
+		if count, err := tx.Nodes().Filter(query.KindIn(query.Node(), User, Group)).Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(2), count)
+		}
+
+		if nodes, err := ops.FetchNodes(tx.Nodes().Filter(query.StringStartsWith(query.NodeProperty("name"), "ws"))); err != nil {
+			return err
+		} else {
+			require.Len(t, nodes, 1)
+			require.Equal(t, testGraph.computer.ID, nodes[0].ID)
+		}
+
+		if nodes, err := ops.FetchNodes(tx.Nodes().Filter(query.InIDs(query.NodeID(), testGraph.user.ID, testGraph.computer.ID))); err != nil {
+			return err
+		} else {
+			require.Len(t, nodes, 2)
+			require.ElementsMatch(t, []graph.ID{testGraph.user.ID, testGraph.computer.ID}, []graph.ID{nodes[0].ID, nodes[1].ID})
+		}
+
+		_, err := tx.Nodes().Filter(query.Equals(query.NodeProperty("name"), "bob")).First()
+		require.True(t, graph.IsErrNotFound(err))
+
+		return nil
+	}))
+}
+
+func TestTransaction_RelationshipQueries(t *testing.T) {
+	var (
+		db        = memory.NewDatabase(0)
+		testGraph = newTestGraph(t, db)
+	)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if relationship, err := tx.Relationships().Filter(query.And(
+			query.Equals(query.StartID(), testGraph.user.ID),
+			query.Kind(query.Relationship(), MemberOf),
+		)).First(); err != nil {
+			return err
+		} else {
+			require.Equal(t, testGraph.memberOf.ID, relationship.ID)
+		}
+
+		var triples int
+
+		if err := tx.Relationships().Filter(query.Kind(query.End(), Computer)).FetchTriples(func(cursor graph.Cursor[graph.RelationshipTripleResult]) error {
+			for next := range cursor.Chan() {
+				require.Equal(t, testGraph.group.ID, next.StartID)
+				require.Equal(t, testGraph.computer.ID, next.EndID)
+				triples++
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return err
+		}
+
+		require.Equal(t, 1, triples)
+
+		var paths []graph.Path
+
+		if err := tx.Relationships().Filter(query.And(
+			query.Equals(query.StartID(), testGraph.user.ID),
+			query.Equals(query.EndID(), testGraph.computer.ID),
+		)).FetchAllShortestPaths(func(cursor graph.Cursor[graph.Path]) error {
+			for path := range cursor.Chan() {
+				paths = append(paths, path)
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return err
+		}
+
+		require.Len(t, paths, 1)
+		require.Len(t, paths[0].Edges, 2)
+		require.Equal(t, testGraph.user.ID, paths[0].Root().ID)
+		require.Equal(t, testGraph.computer.ID, paths[0].Terminal().ID)
+
+		return nil
+	}))
+}
+
+func TestTransaction_Updates(t *testing.T) {
+	var (
+		db        = memory.NewDatabase(0)
+		testGraph = newTestGraph(t, db)
+	)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		testGraph.user.Properties.Set("enabled", false)
+		testGraph.user.Properties.Delete("name")
+		testGraph.user.AddKinds(Group)
+		testGraph.user.DeleteKinds(User)
+
+		return tx.UpdateNode(testGraph.user)
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if node, err := ops.FetchNode(tx, testGraph.user.ID); err != nil {
+			return err
+		} else {
+			require.Equal(t, graph.Kinds{Group}, node.Kinds)
+			require.False(t, node.Properties.Exists("name"))
+
+			enabled, err := node.Properties.Get("enabled").Bool()
+			require.Nil(t, err)
+			require.False(t, enabled)
+		}
+
+		return nil
+	}))
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		return tx.Nodes().Filter(query.Equals(query.NodeID(), testGraph.group.ID)).Delete()
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Relationships().Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(0), count)
+		}
+
+		return nil
+	}))
+}
+
+func TestTransaction_Isolation(t *testing.T) {
+	var (
+		db          = memory.NewDatabase(0)
+		expectedErr = errors.New("rollback")
+	)
+
+	newTestGraph(t, db)
+
+	require.ErrorIs(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if err := tx.Nodes().Delete(); err != nil {
+			return err
+		}
+
+		// Changes are visible within the transaction that made them
+		if count, err := tx.Nodes().Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(0), count)
+		}
+
+		return expectedErr
+	}), expectedErr)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Nodes().Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(3), count)
+		}
+
+		_, err := tx.CreateNode(graph.NewProperties(), User)
+		require.ErrorIs(t, err, memory.ErrReadOnlyTransaction)
+
+		return nil
+	}))
+}
+
+func TestTransaction_PropertyCopies(t *testing.T) {
+	var (
+		db         = memory.NewDatabase(0)
+		tags       = []string{"a", "b"}
+		values     = []any{1, 2}
+		properties = graph.AsProperties(map[string]any{
+			"tags":   tags,
+			"values": values,
+		})
+		nodeID graph.ID
+	)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if node, err := tx.CreateNode(properties, User); err != nil {
+			return err
+		} else {
+			nodeID = node.ID
+		}
+
+		// Mutating the properties given to the driver must not change the stored node
+		tags[0] = "changed"
+		values[0] = "changed"
+		properties.Set("name", "changed")
+
+		return nil
+	}))
+
+	readNode := func() *graph.Node {
+		var node *graph.Node
+
+		require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+			var err error
+
+			node, err = tx.Nodes().Filter(query.Equals(query.NodeID(), nodeID)).First()
+			return err
+		}))
+
+		return node
+	}
+
+	node := readNode()
+
+	// Typed slices are read back as []any like they are from persistent drivers
+	require.Equal(t, []any{"a", "b"}, node.Properties.Get("tags").Any())
+	require.Equal(t, []any{1, 2}, node.Properties.Get("values").Any())
+	require.False(t, node.Properties.Exists("name"))
+
+	// Mutating a node that was read must not change the stored node
+	node.Properties.Get("tags").Any().([]any)[0] = "changed"
+	node.Properties.Get("values").Any().([]any)[0] = "changed"
+
+	node = readNode()
+	require.Equal(t, []any{"a", "b"}, node.Properties.Get("tags").Any())
+	require.Equal(t, []any{1, 2}, node.Properties.Get("values").Any())
+}
+
+func TestBatch(t *testing.T) {
+	db := memory.NewDatabase(0)
+
+	require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		for i := 0; i < 2; i++ {
+			if err := batch.UpdateRelationshipBy(graph.RelationshipUpdate{
+				Relationship:            graph.PrepareRelationship(graph.AsProperties(map[string]any{"lastseen": i}), HasSession),
+				Start:                   graph.PrepareNode(graph.AsProperties(map[string]any{"objectid": "computer"}), Computer),
+				StartIdentityKind:       Computer,
+				StartIdentityProperties: []string{"objectid"},
+				End:                     graph.PrepareNode(graph.AsProperties(map[string]any{"objectid": "user", "name": "alice"}), User),
+				EndIdentityKind:         User,
+				EndIdentityProperties:   []string{"objectid"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		return batch.UpdateNodeBy(graph.NodeUpdate{
+			Node:               graph.PrepareNode(graph.AsProperties(map[string]any{"objectid": "user", "enabled": true}), Group),
+			IdentityKind:       User,
+			IdentityProperties: []string{"objectid"},
+		})
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Nodes().Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(2), count)
+		}
+
+		if node, err := tx.Nodes().Filter(query.Equals(query.NodeProperty("objectid"), "user")).First(); err != nil {
+			return err
+		} else {
+			require.ElementsMatch(t, graph.Kinds{User, Group}, node.Kinds)
+			require.Equal(t, "alice", node.Properties.Get("name").Any())
+			require.Equal(t, true, node.Properties.Get("enabled").Any())
+		}
+
+		if relationships, err := ops.FetchRelationships(tx.Relationships()); err != nil {
+			return err
+		} else {
+			require.Len(t, relationships, 1)
+
+			lastSeen, err := relationships[0].Properties.Get("lastseen").Int()
+			require.Nil(t, err)
+			require.Equal(t, 1, lastSeen)
+		}
+
+		return nil
+	}))
+}
+
+func TestWithGraph(t *testing.T) {
+	var (
+		db           = memory.NewDatabase(0)
+		otherGraph   = graph.Graph{Name: "other"}
+		defaultGraph = graph.Graph{Name: "default"}
+	)
+
+	require.Nil(t, db.AssertSchema(context.Background(), graph.Schema{
+		Graphs:       []graph.Graph{defaultGraph, otherGraph},
+		DefaultGraph: defaultGraph,
+	}))
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		_, err := tx.WithGraph(otherGraph).CreateNode(graph.NewProperties(), User)
+		return err
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		if count, err := tx.Nodes().Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(0), count)
+		}
+
+		if count, err := tx.WithGraph(otherGraph).Nodes().Count(); err != nil {
+			return err
+		} else {
+			require.Equal(t, int64(1), count)
+		}
+
+		return nil
+	}))
+}
+
+func TestDatabaseSwitch_GraphTarget(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		db           = graph.NewDatabaseSwitch(ctx, memory.NewDatabase(0))
+		otherGraph   = graph.Graph{Name: "other"}
+		defaultGraph = graph.Graph{Name: "default"}
+		scopedCtx    = graph.WithGraphTarget(ctx, otherGraph)
+	)
+
+	require.Nil(t, db.AssertSchema(ctx, graph.Schema{
+		Graphs:       []graph.Graph{defaultGraph, otherGraph},
+		DefaultGraph: defaultGraph,
+	}))
+
+	require.Nil(t, db.WriteTransaction(scopedCtx, func(tx graph.Transaction) error {
+		_, err := tx.CreateNode(graph.NewProperties(), User)
+		return err
+	}))
+
+	require.Nil(t, db.BatchOperation(scopedCtx, func(batch graph.Batch) error {
+		return batch.CreateNode(graph.PrepareNode(graph.NewProperties(), Group))
+	}))
+
+	for _, testCase := range []struct {
+		ctx      context.Context
+		expected int64
+	}{
+		{ctx: ctx, expected: 0},
+		{ctx: scopedCtx, expected: 2},
+	} {
+		require.Nil(t, db.ReadTransaction(testCase.ctx, func(tx graph.Transaction) error {
+			count, err := tx.Nodes().Count()
+			require.Equal(t, testCase.expected, count)
+			return err
+		}))
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/specterops/bloodhound/cypher/models/cypher"
+)
+
+// row binds variable names to values while a query is being evaluated.
+type row map[string]any
+
+func (s row) with(symbol string, value any) row {
+	next := maps.Clone(s)
+	next[symbol] = value
+
+	return next
+}
+
+// executor evaluates openCypher queries directly against the graph data of a transaction. Clauses are evaluated in
+// order, each one transforming the rows produced by the clause before it.
+type executor struct {
+	ctx        context.Context
+	tx         *transaction
+	parameters map[string]any
+
+	// aggregates holds the computed value of each aggregate function for the group currently being projected
+	aggregates map[*cypher.FunctionInvocation]any
+}
+
+func newExecutor(ctx context.Context, tx *transaction, parameters map[string]any) *executor {
+	return &executor{
+		ctx:        ctx,
+		tx:         tx,
+		parameters: parameters,
+	}
+}
+
+func compilationErrors(regularQuery *cypher.RegularQuery) error {
+	var modelErrors []error
+
+	cypher.Walk(regularQuery, cypher.NewVisitor(func(stack *cypher.WalkStack, element cypher.Expression) error {
+		if errorNode, typeOK := element.(cypher.Fallible); typeOK {
+			if len(errorNode.Errors()) > 0 {
+				modelErrors = append(modelErrors, errorNode.Errors()...)
+			}
+		}
+
+		return nil
+	}, nil))
+
+	return errors.Join(modelErrors...)
+}
+
+func (s *executor) execute(regularQuery *cypher.RegularQuery) ([][]any, error) {
+	if regularQuery == nil || regularQuery.SingleQuery == nil {
+		return nil, fmt.Errorf("query is empty")
+	}
+
+	if err := compilationErrors(regularQuery); err != nil {
+		return nil, err
+	}
+
+	var (
+		rows = []row{{}}
+		err  error
+	)
+
+	if multiPartQuery := regularQuery.SingleQuery.MultiPartQuery; multiPartQuery != nil {
+		for _, part := range multiPartQuery.Parts {
+			if rows, err = s.readingClauses(rows, part.ReadingClauses); err != nil {
+				return nil, err
+			}
+
+			for _, updatingClause := range part.UpdatingClauses {
+				if rows, err = s.updatingClause(rows, updatingClause); err != nil {
+					return nil, err
+				}
+			}
+
+			if part.With != nil {
+				if rows, err = s.with(rows, part.With); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return s.singlePartQuery(rows, multiPartQuery.SinglePartQuery)
+	}
+
+	return s.singlePartQuery(rows, regularQuery.SingleQuery.SinglePartQuery)
+}
+
+func (s *executor) singlePartQuery(rows []row, singlePartQuery *cypher.SinglePartQuery) ([][]any, error) {
+	if singlePartQuery == nil {
+		return nil, nil
+	}
+
+	rows, err := s.readingClauses(rows, singlePartQuery.ReadingClauses)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, updatingClause := range singlePartQuery.UpdatingClauses {
+		if rows, err = s.updatingClause(rows, updatingClause); err != nil {
+			return nil, err
+		}
+	}
+
+	if singlePartQuery.Return == nil {
+		return nil, nil
+	}
+
+	if projectedRows, columns, err := s.project(rows, singlePartQuery.Return.Projection); err != nil {
+		return nil, err
+	} else {
+		results := make([][]any, len(projectedRows))
+
+		for idx, projectedRow := range projectedRows {
+			values := make([]any, len(columns))
+
+			for columnIdx, column := range columns {
+				values[columnIdx] = s.outputValue(projectedRow[column])
+			}
+
+			results[idx] = values
+		}
+
+		return results, nil
+	}
+}
+
+func (s *executor) readingClauses(rows []row, readingClauses []*cypher.ReadingClause) ([]row, error) {
+	var err error
+
+	for _, readingClause := range readingClauses {
+		if err := s.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case readingClause.Match != nil:
+			rows, err = s.match(rows, readingClause.Match)
+
+		case readingClause.Unwind != nil:
+			rows, err = s.unwind(rows, readingClause.Unwind)
+
+		default:
+			err = fmt.Errorf("unsupported reading clause")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+func (s *executor) unwind(rows []row, unwind *cypher.Unwind) ([]row, error) {
+	if unwind.Binding == nil {
+		return nil, fmt.Errorf("unwind requires a binding")
+	}
+
+	var unwound []row
+
+	for _, nextRow := range rows {
+		if value, err := s.evaluate(unwind.Expression, nextRow); err != nil {
+			return nil, err
+		} else {
+			switch typedValue := value.(type) {
+			case nil:
+			case []any:
+				for _, element := range typedValue {
+					unwound = append(unwound, nextRow.with(unwind.Binding.Symbol, element))
+				}
+
+			default:
+				unwound = append(unwound, nextRow.with(unwind.Binding.Symbol, typedValue))
+			}
+		}
+	}
+
+	return unwound, nil
+}
+
+func (s *executor) with(rows []row, with *cypher.With) ([]row, error) {
+	projectedRows, _, err := s.project(rows, with.Projection)
+
+	if err != nil || with.Where == nil {
+		return projectedRows, err
+	}
+
+	return s.filter(projectedRows, whereExpression(with.Where))
+}
+
+// filter returns the rows for which the given predicate is true.
+func (s *executor) filter(rows []row, predicate cypher.Expression) ([]row, error) {
+	if predicate == nil {
+		return rows, nil
+	}
+
+	var filtered []row
+
+	for _, nextRow := range rows {
+		if value, err := s.evaluate(predicate, nextRow); err != nil {
+			return nil, err
+		} else if isTrue(value) {
+			filtered = append(filtered, nextRow)
+		}
+	}
+
+	return filtered, nil
+}
+
+// whereExpression combines the expressions of a WHERE clause into a single predicate.
+func whereExpression(where *cypher.Where) cypher.Expression {
+	if where == nil {
+		return nil
+	}
+
+	switch expressions := where.GetAll(); len(expressions) {
+	case 0:
+		return nil
+	case 1:
+		return expressions[0]
+	default:
+		return cypher.NewConjunction(expressions...)
+	}
+}
@@ -0,0 +1,779 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/specterops/bloodhound/cypher/models/cypher"
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// currentNode returns the version of the given node visible to the transaction. Nodes bound earlier in a query may have
+// been updated by later clauses of the same query.
+func (s *executor) currentNode(node *graph.Node) *graph.Node {
+	if current, exists := s.tx.data().nodes[node.ID]; exists {
+		return current
+	}
+
+	return node
+}
+
+func (s *executor) currentRelationship(relationship *graph.Relationship) *graph.Relationship {
+	if current, exists := s.tx.data().relationships[relationship.ID]; exists {
+		return current
+	}
+
+	return relationship
+}
+
+// outputValue prepares a value for inclusion in a result. Graph entities are replaced with copies of their current
+// version so that callers may freely modify them.
+func (s *executor) outputValue(value any) any {
+	switch typedValue := value.(type) {
+	case *graph.Node:
+		return copyNode(s.currentNode(typedValue))
+
+	case *graph.Relationship:
+		return copyRelationship(s.currentRelationship(typedValue))
+
+	case *graph.Path:
+		path := &graph.Path{
+			Nodes: make([]*graph.Node, len(typedValue.Nodes)),
+			Edges: make([]*graph.Relationship, len(typedValue.Edges)),
+		}
+
+		for idx, node := range typedValue.Nodes {
+			path.Nodes[idx] = copyNode(s.currentNode(node))
+		}
+
+		for idx, relationship := range typedValue.Edges {
+			path.Edges[idx] = copyRelationship(s.currentRelationship(relationship))
+		}
+
+		return path
+
+	case []any:
+		values := make([]any, len(typedValue))
+
+		for idx, element := range typedValue {
+			values[idx] = s.outputValue(element)
+		}
+
+		return values
+
+	case map[string]any:
+		values := make(map[string]any, len(typedValue))
+
+		for key, element := range typedValue {
+			values[key] = s.outputValue(element)
+		}
+
+		return values
+
+	default:
+		return value
+	}
+}
+
+func literalValue(literal *cypher.Literal) any {
+	if literal.Null {
+		return nil
+	}
+
+	if stringValue, isString := literal.Value.(string); isString && len(stringValue) >= 2 {
+		// Cypher parser wraps string literals with ' characters
+		return stringValue[1 : len(stringValue)-1]
+	}
+
+	return literal.Value
+}
+
+func (s *executor) parameterValue(parameter *cypher.Parameter) (any, error) {
+	if parameter.Symbol == "" {
+		return normalizeValue(parameter.Value), nil
+	}
+
+	if value, exists := s.parameters[parameter.Symbol]; exists {
+		return normalizeValue(value), nil
+	}
+
+	if parameter.Value != nil {
+		return normalizeValue(parameter.Value), nil
+	}
+
+	return nil, fmt.Errorf("missing value for parameter $%s", parameter.Symbol)
+}
+
+func (s *executor) evaluateMap(mapLiteral cypher.MapLiteral, current row) (map[string]any, error) {
+	values := make(map[string]any, len(mapLiteral))
+
+	for key, expression := range mapLiteral {
+		if value, err := s.evaluate(expression, current); err != nil {
+			return nil, err
+		} else {
+			values[key] = value
+		}
+	}
+
+	return values, nil
+}
+
+func (s *executor) evaluateList(listLiteral *cypher.ListLiteral, current row) ([]any, error) {
+	values := make([]any, 0, len(*listLiteral))
+
+	for _, expression := range *listLiteral {
+		if value, err := s.evaluate(expression, current); err != nil {
+			return nil, err
+		} else {
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+// evaluatePropertyMap evaluates the properties expression of a node or relationship pattern.
+func (s *executor) evaluatePropertyMap(expression cypher.Expression, current row) (map[string]any, error) {
+	var (
+		value any
+		err   error
+	)
+
+	switch typedExpression := expression.(type) {
+	case nil:
+		return nil, nil
+
+	case *cypher.Properties:
+		if typedExpression.Parameter != nil {
+			value, err = s.parameterValue(typedExpression.Parameter)
+		} else {
+			value, err = s.evaluateMap(typedExpression.Map, current)
+		}
+
+	default:
+		value, err = s.evaluate(expression, current)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch typedValue := value.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return typedValue, nil
+	default:
+		return nil, fmt.Errorf("expected a map of properties but received %T", value)
+	}
+}
+
+func (s *executor) evaluate(expression cypher.Expression, current row) (any, error) {
+	switch typedExpression := expression.(type) {
+	case nil:
+		return nil, nil
+
+	case *cypher.Literal:
+		switch typedValue := literalValue(typedExpression).(type) {
+		case cypher.MapLiteral:
+			return s.evaluateMap(typedValue, current)
+		case *cypher.ListLiteral:
+			return s.evaluateList(typedValue, current)
+		default:
+			return normalizeValue(typedValue), nil
+		}
+
+	case cypher.MapLiteral:
+		return s.evaluateMap(typedExpression, current)
+
+	case *cypher.ListLiteral:
+		return s.evaluateList(typedExpression, current)
+
+	case *cypher.Parameter:
+		return s.parameterValue(typedExpression)
+
+	case *cypher.Variable:
+		if value, bound := current[typedExpression.Symbol]; bound {
+			return value, nil
+		}
+
+		return nil, fmt.Errorf("variable %s is not defined", typedExpression.Symbol)
+
+	case *cypher.Parenthetical:
+		return s.evaluate(typedExpression.Expression, current)
+
+	case *cypher.PropertyLookup:
+		return s.evaluatePropertyLookup(typedExpression, current)
+
+	case *cypher.KindMatcher:
+		return s.evaluateKindMatcher(typedExpression, current)
+
+	case *cypher.FunctionInvocation:
+		return s.evaluateFunction(typedExpression, current)
+
+	case *cypher.Comparison:
+		return s.evaluateComparison(typedExpression, current)
+
+	case *cypher.Negation:
+		if value, err := s.evaluate(typedExpression.Expression, current); err != nil {
+			return nil, err
+		} else if truth, err := truthValue(value); err != nil || truth == nil {
+			return nil, err
+		} else {
+			return !truth.(bool), nil
+		}
+
+	case *cypher.Conjunction:
+		return s.evaluateLogical(typedExpression.GetAll(), current, logicalAnd)
+
+	case *cypher.Disjunction:
+		return s.evaluateLogical(typedExpression.GetAll(), current, logicalOr)
+
+	case *cypher.ExclusiveDisjunction:
+		return s.evaluateLogical(typedExpression.GetAll(), current, logicalXor)
+
+	case *cypher.ArithmeticExpression:
+		return s.evaluateArithmetic(typedExpression, current)
+
+	case *cypher.UnaryAddOrSubtractExpression:
+		if value, err := s.evaluate(typedExpression.Right, current); err != nil {
+			return nil, err
+		} else if typedExpression.Operator == cypher.OperatorSubtract {
+			switch typedValue := value.(type) {
+			case nil:
+				return nil, nil
+			case int64:
+				return -typedValue, nil
+			case float64:
+				return -typedValue, nil
+			default:
+				return nil, fmt.Errorf("unable to negate value of type %T", value)
+			}
+		} else {
+			return value, nil
+		}
+
+	case *cypher.PatternPredicate:
+		return s.evaluatePatternPredicate(typedExpression, current)
+
+	case *cypher.Quantifier:
+		return s.evaluateQuantifier(typedExpression, current)
+
+	case *cypher.ListComprehension:
+		return s.evaluateListComprehension(typedExpression, current)
+
+	default:
+		return nil, fmt.Errorf("unsupported expression type %T", expression)
+	}
+}
+
+func (s *executor) evaluatePropertyLookup(lookup *cypher.PropertyLookup, current row) (any, error) {
+	value, err := s.evaluate(lookup.Atom, current)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, symbol := range lookup.Symbols {
+		switch typedValue := value.(type) {
+		case nil:
+			return nil, nil
+
+		case *graph.Node:
+			value = normalizeValue(s.currentNode(typedValue).Properties.Get(symbol).Any())
+
+		case *graph.Relationship:
+			value = normalizeValue(s.currentRelationship(typedValue).Properties.Get(symbol).Any())
+
+		case map[string]any:
+			value = typedValue[symbol]
+
+		case time.Time:
+			if value, err = temporalComponent(typedValue, symbol); err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, fmt.Errorf("unable to look up property %s on value of type %T", symbol, value)
+		}
+	}
+
+	return value, nil
+}
+
+func temporalComponent(value time.Time, component string) (any, error) {
+	switch strings.ToLower(component) {
+	case cypher.ITTCYear:
+		return int64(value.Year()), nil
+	case cypher.ITTCMonth:
+		return int64(value.Month()), nil
+	case cypher.ITTCDay:
+		return int64(value.Day()), nil
+	case cypher.ITTCHour:
+		return int64(value.Hour()), nil
+	case cypher.ITTCMinute:
+		return int64(value.Minute()), nil
+	case cypher.ITTCSecond:
+		return int64(value.Second()), nil
+	case cypher.ITTCMillisecond:
+		return int64(value.Nanosecond() / int(time.Millisecond)), nil
+	case cypher.ITTCMicrosecond:
+		return int64(value.Nanosecond() / int(time.Microsecond)), nil
+	case cypher.ITTCNanosecond:
+		return int64(value.Nanosecond()), nil
+	case cypher.ITTCEpochSeconds:
+		return value.Unix(), nil
+	case cypher.ITTCEpochMilliseconds:
+		return value.UnixMilli(), nil
+	case cypher.ITTCTimeZone:
+		return value.Location().String(), nil
+	default:
+		return nil, fmt.Errorf("unsupported temporal component %s", component)
+	}
+}
+
+// kindsOf returns the current kinds of the given node or the kind of the given relationship.
+func (s *executor) kindsOf(value any) (graph.Kinds, error) {
+	switch typedValue := value.(type) {
+	case *graph.Node:
+		return s.currentNode(typedValue).Kinds, nil
+	case *graph.Relationship:
+		return graph.Kinds{typedValue.Kind}, nil
+	default:
+		return nil, fmt.Errorf("unable to match kinds of value of type %T", value)
+	}
+}
+
+func (s *executor) evaluateKindMatcher(kindMatcher *cypher.KindMatcher, current row) (any, error) {
+	if reference, err := s.evaluate(kindMatcher.Reference, current); err != nil || reference == nil {
+		return nil, err
+	} else if kinds, err := s.kindsOf(reference); err != nil {
+		return nil, err
+	} else {
+		return kinds.ContainsOneOf(kindMatcher.Kinds...), nil
+	}
+}
+
+type logicalOperator int
+
+const (
+	logicalAnd logicalOperator = iota
+	logicalOr
+	logicalXor
+)
+
+// evaluateLogical combines the given boolean expressions using three-valued logic. AND and OR evaluation stops as soon
+// as the result is known.
+func (s *executor) evaluateLogical(expressions []cypher.Expression, current row, operator logicalOperator) (any, error) {
+	var (
+		result  = operator == logicalAnd
+		hasNull = false
+	)
+
+	for _, expression := range expressions {
+		value, err := s.evaluate(expression, current)
+
+		if err != nil {
+			return nil, err
+		}
+
+		truth, err := truthValue(value)
+
+		if err != nil {
+			return nil, err
+		} else if truth == nil {
+			hasNull = true
+			continue
+		}
+
+		switch operator {
+		case logicalAnd:
+			if !truth.(bool) {
+				return false, nil
+			}
+
+		case logicalOr:
+			if truth.(bool) {
+				return true, nil
+			}
+
+		case logicalXor:
+			result = result != truth.(bool)
+		}
+	}
+
+	if hasNull {
+		return nil, nil
+	}
+
+	return result, nil
+}
+
+func (s *executor) evaluateComparison(comparison *cypher.Comparison, current row) (any, error) {
+	left, err := s.evaluate(comparison.Left, current)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Chained comparisons such as a < b < c are evaluated as a < b AND b < c
+	var result any = true
+
+	for _, partial := range comparison.Partials {
+		right, err := s.evaluate(partial.Right, current)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if partialResult, err := compareOperator(partial.Operator, left, right); err != nil {
+			return nil, err
+		} else if partialResult == false {
+			return false, nil
+		} else if partialResult == nil {
+			result = nil
+		}
+
+		left = right
+	}
+
+	return result, nil
+}
+
+func compareOperator(operator cypher.Operator, left, right any) (any, error) {
+	switch operator {
+	case cypher.OperatorIs:
+		return left == nil && right == nil, nil
+
+	case cypher.OperatorIsNot:
+		return left != nil || right != nil, nil
+	}
+
+	if left == nil || right == nil {
+		if operator == cypher.OperatorIn {
+			if list, isList := right.([]any); isList && len(list) == 0 {
+				return false, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	switch operator {
+	case cypher.OperatorEquals:
+		return equalValues(left, right), nil
+
+	case cypher.OperatorNotEquals:
+		if equal := equalValues(left, right); equal == nil {
+			return nil, nil
+		} else {
+			return !equal.(bool), nil
+		}
+
+	case cypher.OperatorLessThan, cypher.OperatorLessThanOrEqualTo, cypher.OperatorGreaterThan, cypher.OperatorGreaterThanOrEqualTo:
+		comparison, comparable := compareValues(left, right)
+
+		if !comparable {
+			return nil, nil
+		}
+
+		switch operator {
+		case cypher.OperatorLessThan:
+			return comparison < 0, nil
+		case cypher.OperatorLessThanOrEqualTo:
+			return comparison <= 0, nil
+		case cypher.OperatorGreaterThan:
+			return comparison > 0, nil
+		default:
+			return comparison >= 0, nil
+		}
+
+	case cypher.OperatorStartsWith, cypher.OperatorEndsWith, cypher.OperatorContains, cypher.OperatorRegexMatch:
+		leftString, leftIsString := left.(string)
+		rightString, rightIsString := right.(string)
+
+		if !leftIsString || !rightIsString {
+			return nil, nil
+		}
+
+		switch operator {
+		case cypher.OperatorStartsWith:
+			return strings.HasPrefix(leftString, rightString), nil
+		case cypher.OperatorEndsWith:
+			return strings.HasSuffix(leftString, rightString), nil
+		case cypher.OperatorContains:
+			return strings.Contains(leftString, rightString), nil
+		default:
+			if pattern, err := regexp.Compile("^(?:" + rightString + ")$"); err != nil {
+				return nil, fmt.Errorf("invalid regular expression %s: %w", rightString, err)
+			} else {
+				return pattern.MatchString(leftString), nil
+			}
+		}
+
+	case cypher.OperatorIn:
+		list, isList := right.([]any)
+
+		if !isList {
+			return nil, fmt.Errorf("expected a list for the right operand of IN but received %T", right)
+		}
+
+		var result any = false
+
+		for _, element := range list {
+			switch equalValues(left, element) {
+			case true:
+				return true, nil
+			case nil:
+				result = nil
+			}
+		}
+
+		return result, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported comparison operator %s", operator)
+	}
+}
+
+func (s *executor) evaluateArithmetic(arithmeticExpression *cypher.ArithmeticExpression, current row) (any, error) {
+	result, err := s.evaluate(arithmeticExpression.Left, current)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, partial := range arithmeticExpression.Partials {
+		if right, err := s.evaluate(partial.Right, current); err != nil {
+			return nil, err
+		} else if result, err = arithmetic(partial.Operator, result, right); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func arithmetic(operator cypher.Operator, left, right any) (any, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	if operator == cypher.OperatorAdd {
+		switch typedLeft := left.(type) {
+		case string:
+			switch typedRight := right.(type) {
+			case string:
+				return typedLeft + typedRight, nil
+			case int64, float64, bool:
+				return typedLeft + fmt.Sprintf("%v", typedRight), nil
+			}
+
+		case []any:
+			if typedRight, isList := right.([]any); isList {
+				return append(append([]any{}, typedLeft...), typedRight...), nil
+			}
+
+			return append(append([]any{}, typedLeft...), right), nil
+
+		default:
+			if typedRight, isList := right.([]any); isList {
+				return append([]any{left}, typedRight...), nil
+			}
+		}
+	}
+
+	leftInt, leftIsInt := left.(int64)
+	rightInt, rightIsInt := right.(int64)
+
+	if leftIsInt && rightIsInt && operator != cypher.OperatorPowerOf {
+		switch operator {
+		case cypher.OperatorAdd:
+			return leftInt + rightInt, nil
+		case cypher.OperatorSubtract:
+			return leftInt - rightInt, nil
+		case cypher.OperatorMultiply:
+			return leftInt * rightInt, nil
+		case cypher.OperatorDivide, cypher.OperatorModulo:
+			if rightInt == 0 {
+				return nil, fmt.Errorf("division by zero")
+			} else if operator == cypher.OperatorDivide {
+				return leftInt / rightInt, nil
+			} else {
+				return leftInt % rightInt, nil
+			}
+		}
+	}
+
+	leftNumber, leftIsNumber := asFloat64(left)
+	rightNumber, rightIsNumber := asFloat64(right)
+
+	if !leftIsNumber || !rightIsNumber {
+		return nil, fmt.Errorf("unsupported operand types %T and %T for operator %s", left, right, operator)
+	}
+
+	switch operator {
+	case cypher.OperatorAdd:
+		return leftNumber + rightNumber, nil
+	case cypher.OperatorSubtract:
+		return leftNumber - rightNumber, nil
+	case cypher.OperatorMultiply:
+		return leftNumber * rightNumber, nil
+	case cypher.OperatorDivide:
+		return leftNumber / rightNumber, nil
+	case cypher.OperatorModulo:
+		return math.Mod(leftNumber, rightNumber), nil
+	case cypher.OperatorPowerOf:
+		return math.Pow(leftNumber, rightNumber), nil
+	default:
+		return nil, fmt.Errorf("unsupported arithmetic operator %s", operator)
+	}
+}
+
+// filterElements evaluates the given filter expression for each element of its collection. The delegate is called for
+// each element along with the row the element is bound in and whether the element passed the filter's WHERE clause.
+func (s *executor) filterElements(filter *cypher.FilterExpression, current row, delegate func(elementRow row, passed any) error) (bool, error) {
+	if filter == nil || filter.Specifier == nil || filter.Specifier.Variable == nil {
+		return false, fmt.Errorf("filter expression requires a variable and a collection")
+	}
+
+	collection, err := s.evaluate(filter.Specifier.Expression, current)
+
+	if err != nil {
+		return false, err
+	} else if collection == nil {
+		return false, nil
+	}
+
+	elements, isList := collection.([]any)
+
+	if !isList {
+		return false, fmt.Errorf("expected a list to filter but received %T", collection)
+	}
+
+	predicate := whereExpression(filter.Where)
+
+	for _, element := range elements {
+		var (
+			elementRow     = current.with(filter.Specifier.Variable.Symbol, element)
+			passed     any = true
+		)
+
+		if predicate != nil {
+			if value, err := s.evaluate(predicate, elementRow); err != nil {
+				return false, err
+			} else if passed, err = truthValue(value); err != nil {
+				return false, err
+			}
+		}
+
+		if err := delegate(elementRow, passed); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (s *executor) evaluateQuantifier(quantifier *cypher.Quantifier, current row) (any, error) {
+	var numPassed, numNull, numElements int
+
+	if hasCollection, err := s.filterElements(quantifier.Filter, current, func(elementRow row, passed any) error {
+		numElements++
+
+		switch passed {
+		case true:
+			numPassed++
+		case nil:
+			numNull++
+		}
+
+		return nil
+	}); err != nil || !hasCollection {
+		return nil, err
+	}
+
+	switch quantifier.Type {
+	case cypher.QuantifierTypeAll:
+		if numPassed == numElements {
+			return true, nil
+		} else if numPassed+numNull == numElements {
+			return nil, nil
+		}
+
+		return false, nil
+
+	case cypher.QuantifierTypeAny:
+		if numPassed > 0 {
+			return true, nil
+		} else if numNull > 0 {
+			return nil, nil
+		}
+
+		return false, nil
+
+	case cypher.QuantifierTypeNone:
+		if numPassed > 0 {
+			return false, nil
+		} else if numNull > 0 {
+			return nil, nil
+		}
+
+		return true, nil
+
+	case cypher.QuantifierTypeSingle:
+		if numPassed > 1 || (numPassed == 1 && numNull == 0) {
+			return numPassed == 1, nil
+		} else if numNull > 0 {
+			return nil, nil
+		}
+
+		return false, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported quantifier type %s", quantifier.Type)
+	}
+}
+
+func (s *executor) evaluateListComprehension(listComprehension *cypher.ListComprehension, current row) (any, error) {
+	results := []any{}
+
+	if hasCollection, err := s.filterElements(listComprehension.Filter, current, func(elementRow row, passed any) error {
+		if passed != true {
+			return nil
+		}
+
+		if listComprehension.Projection == nil {
+			results = append(results, elementRow[listComprehension.Filter.Specifier.Variable.Symbol])
+		} else if value, err := s.evaluate(listComprehension.Projection, elementRow); err != nil {
+			return err
+		} else {
+			results = append(results, value)
+		}
+
+		return nil
+	}); err != nil || !hasCollection {
+		return nil, err
+	}
+
+	return results, nil
+}
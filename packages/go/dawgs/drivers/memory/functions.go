@@ -0,0 +1,611 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/specterops/bloodhound/cypher/models/cypher"
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+const (
+	collectFunction   = "collect"
+	sumFunction       = "sum"
+	avgFunction       = "avg"
+	minFunction       = "min"
+	maxFunction       = "max"
+	toIntegerFunction = "tointeger"
+	toFloatFunction   = "tofloat"
+	toBooleanFunction = "toboolean"
+)
+
+func functionName(invocation *cypher.FunctionInvocation) string {
+	return strings.ToLower(invocation.Name)
+}
+
+func isAggregate(invocation *cypher.FunctionInvocation) bool {
+	if len(invocation.Namespace) > 0 {
+		return false
+	}
+
+	switch functionName(invocation) {
+	case cypher.CountFunction, collectFunction, sumFunction, avgFunction, minFunction, maxFunction:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *executor) evaluateArguments(invocation *cypher.FunctionInvocation, current row) ([]any, error) {
+	arguments := make([]any, len(invocation.Arguments))
+
+	for idx, argument := range invocation.Arguments {
+		if value, err := s.evaluate(argument, current); err != nil {
+			return nil, err
+		} else {
+			arguments[idx] = value
+		}
+	}
+
+	return arguments, nil
+}
+
+func expectArguments(invocation *cypher.FunctionInvocation, arguments []any, minArguments, maxArguments int) error {
+	if len(arguments) < minArguments || len(arguments) > maxArguments {
+		if minArguments == maxArguments {
+			return fmt.Errorf("function %s expects %d argument(s) but received %d", invocation.Name, minArguments, len(arguments))
+		}
+
+		return fmt.Errorf("function %s expects between %d and %d arguments but received %d", invocation.Name, minArguments, maxArguments, len(arguments))
+	}
+
+	return nil
+}
+
+func expectString(invocation *cypher.FunctionInvocation, value any) (string, error) {
+	if stringValue, isString := value.(string); !isString {
+		return "", fmt.Errorf("function %s expects a string argument but received %T", invocation.Name, value)
+	} else {
+		return stringValue, nil
+	}
+}
+
+func expectInteger(invocation *cypher.FunctionInvocation, value any) (int64, error) {
+	if intValue, isInt := value.(int64); !isInt {
+		return 0, fmt.Errorf("function %s expects an integer argument but received %T", invocation.Name, value)
+	} else {
+		return intValue, nil
+	}
+}
+
+func (s *executor) evaluateFunction(invocation *cypher.FunctionInvocation, current row) (any, error) {
+	if isAggregate(invocation) {
+		if value, computed := s.aggregates[invocation]; computed {
+			return value, nil
+		}
+
+		return nil, fmt.Errorf("aggregate function %s is not supported in this context", invocation.Name)
+	}
+
+	if len(invocation.Namespace) > 0 {
+		return nil, fmt.Errorf("unsupported function %s.%s", strings.Join(invocation.Namespace, "."), invocation.Name)
+	}
+
+	arguments, err := s.evaluateArguments(invocation, current)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch name := functionName(invocation); name {
+	case cypher.CoalesceFunction:
+		for _, argument := range arguments {
+			if argument != nil {
+				return argument, nil
+			}
+		}
+
+		return nil, nil
+
+	case "exists":
+		if err := expectArguments(invocation, arguments, 1, 1); err != nil {
+			return nil, err
+		}
+
+		return arguments[0] != nil, nil
+
+	case cypher.DateTimeFunction:
+		if err := expectArguments(invocation, arguments, 0, 1); err != nil {
+			return nil, err
+		} else if len(arguments) == 0 {
+			return time.Now().UTC(), nil
+		} else if arguments[0] == nil {
+			return nil, nil
+		} else if stringValue, err := expectString(invocation, arguments[0]); err != nil {
+			return nil, err
+		} else if parsedTime, err := time.Parse(time.RFC3339Nano, stringValue); err != nil {
+			return nil, fmt.Errorf("function %s unable to parse %s: %w", invocation.Name, stringValue, err)
+		} else {
+			return parsedTime, nil
+		}
+
+	case "range":
+		if err := expectArguments(invocation, arguments, 2, 3); err != nil {
+			return nil, err
+		}
+
+		step := int64(1)
+
+		if len(arguments) == 3 {
+			if step, err = expectInteger(invocation, arguments[2]); err != nil {
+				return nil, err
+			} else if step == 0 {
+				return nil, fmt.Errorf("function %s step must not be zero", invocation.Name)
+			}
+		}
+
+		if start, err := expectInteger(invocation, arguments[0]); err != nil {
+			return nil, err
+		} else if end, err := expectInteger(invocation, arguments[1]); err != nil {
+			return nil, err
+		} else {
+			values := []any{}
+
+			for next := start; (step > 0 && next <= end) || (step < 0 && next >= end); next += step {
+				values = append(values, next)
+			}
+
+			return values, nil
+		}
+	}
+
+	// All remaining functions take a single leading argument and return null when it is null
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("function %s requires at least one argument", invocation.Name)
+	} else if arguments[0] == nil {
+		return nil, nil
+	}
+
+	return s.evaluateScalarFunction(invocation, arguments)
+}
+
+func (s *executor) evaluateScalarFunction(invocation *cypher.FunctionInvocation, arguments []any) (any, error) {
+	argument := arguments[0]
+
+	switch name := functionName(invocation); name {
+	case cypher.IdentityFunction:
+		switch typedArgument := argument.(type) {
+		case *graph.Node:
+			return typedArgument.ID.Int64(), nil
+		case *graph.Relationship:
+			return typedArgument.ID.Int64(), nil
+		}
+
+	case cypher.NodeLabelsFunction:
+		if node, isNode := argument.(*graph.Node); isNode {
+			return normalizeValue(s.currentNode(node).Kinds.Strings()), nil
+		}
+
+	case cypher.EdgeTypeFunction:
+		if relationship, isRelationship := argument.(*graph.Relationship); isRelationship {
+			return relationship.Kind.String(), nil
+		}
+
+	case "startnode", "endnode":
+		if relationship, isRelationship := argument.(*graph.Relationship); isRelationship {
+			nodeID := relationship.StartID
+
+			if name == "endnode" {
+				nodeID = relationship.EndID
+			}
+
+			if node, exists := s.tx.data().nodes[nodeID]; exists {
+				return node, nil
+			}
+
+			return nil, nil
+		}
+
+	case cypher.NodesFunction:
+		if path, isPath := argument.(*graph.Path); isPath {
+			nodes := make([]any, len(path.Nodes))
+
+			for idx, node := range path.Nodes {
+				nodes[idx] = node
+			}
+
+			return nodes, nil
+		}
+
+	case cypher.RelationshipsFunction:
+		switch typedArgument := argument.(type) {
+		case *graph.Path:
+			relationships := make([]any, len(typedArgument.Edges))
+
+			for idx, relationship := range typedArgument.Edges {
+				relationships[idx] = relationship
+			}
+
+			return relationships, nil
+
+		case []any:
+			return typedArgument, nil
+		}
+
+	case cypher.LengthFunction:
+		switch typedArgument := argument.(type) {
+		case *graph.Path:
+			return int64(len(typedArgument.Edges)), nil
+		case []any:
+			return int64(len(typedArgument)), nil
+		case string:
+			return int64(utf8.RuneCountInString(typedArgument)), nil
+		}
+
+	case cypher.ListSizeFunction:
+		switch typedArgument := argument.(type) {
+		case []any:
+			return int64(len(typedArgument)), nil
+		case string:
+			return int64(utf8.RuneCountInString(typedArgument)), nil
+		}
+
+	case "keys":
+		switch typedArgument := argument.(type) {
+		case *graph.Node, *graph.Relationship:
+			properties, _ := s.evaluateProperties(typedArgument)
+			return normalizeValue(sortedKeys(properties)), nil
+
+		case map[string]any:
+			return normalizeValue(sortedKeys(typedArgument)), nil
+		}
+
+	case "properties":
+		switch typedArgument := argument.(type) {
+		case *graph.Node, *graph.Relationship:
+			return s.evaluateProperties(typedArgument)
+
+		case map[string]any:
+			return typedArgument, nil
+		}
+
+	case "head", "last", "tail":
+		if list, isList := argument.([]any); isList {
+			switch {
+			case name == "tail" && len(list) == 0:
+				return []any{}, nil
+			case name == "tail":
+				return slices.Clone(list[1:]), nil
+			case len(list) == 0:
+				return nil, nil
+			case name == "head":
+				return list[0], nil
+			default:
+				return list[len(list)-1], nil
+			}
+		}
+
+	case "reverse":
+		switch typedArgument := argument.(type) {
+		case []any:
+			reversed := slices.Clone(typedArgument)
+			slices.Reverse(reversed)
+
+			return reversed, nil
+
+		case string:
+			runes := []rune(typedArgument)
+			slices.Reverse(runes)
+
+			return string(runes), nil
+		}
+
+	case "abs":
+		switch typedArgument := argument.(type) {
+		case int64:
+			if typedArgument < 0 {
+				return -typedArgument, nil
+			}
+
+			return typedArgument, nil
+
+		case float64:
+			return math.Abs(typedArgument), nil
+		}
+
+	case cypher.ToStringFunction:
+		switch typedArgument := argument.(type) {
+		case string:
+			return typedArgument, nil
+		case int64, bool:
+			return fmt.Sprintf("%v", typedArgument), nil
+		case float64:
+			return strconv.FormatFloat(typedArgument, 'f', -1, 64), nil
+		case time.Time:
+			return typedArgument.Format(time.RFC3339Nano), nil
+		}
+
+	case cypher.ToIntegerFunction, toIntegerFunction:
+		switch typedArgument := argument.(type) {
+		case int64:
+			return typedArgument, nil
+		case float64:
+			return int64(typedArgument), nil
+		case bool:
+			if typedArgument {
+				return int64(1), nil
+			}
+
+			return int64(0), nil
+
+		case string:
+			if intValue, err := strconv.ParseInt(strings.TrimSpace(typedArgument), 10, 64); err == nil {
+				return intValue, nil
+			} else if floatValue, err := strconv.ParseFloat(strings.TrimSpace(typedArgument), 64); err == nil {
+				return int64(floatValue), nil
+			}
+
+			return nil, nil
+		}
+
+	case toFloatFunction:
+		switch typedArgument := argument.(type) {
+		case int64:
+			return float64(typedArgument), nil
+		case float64:
+			return typedArgument, nil
+		case string:
+			if floatValue, err := strconv.ParseFloat(strings.TrimSpace(typedArgument), 64); err == nil {
+				return floatValue, nil
+			}
+
+			return nil, nil
+		}
+
+	case toBooleanFunction:
+		switch typedArgument := argument.(type) {
+		case bool:
+			return typedArgument, nil
+		case string:
+			if boolValue, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(typedArgument))); err == nil {
+				return boolValue, nil
+			}
+
+			return nil, nil
+		}
+
+	case cypher.ToLowerFunction, cypher.ToUpperFunction, "trim", "ltrim", "rtrim":
+		if stringValue, err := expectString(invocation, argument); err != nil {
+			return nil, err
+		} else {
+			switch name {
+			case cypher.ToLowerFunction:
+				return strings.ToLower(stringValue), nil
+			case cypher.ToUpperFunction:
+				return strings.ToUpper(stringValue), nil
+			case "ltrim":
+				return strings.TrimLeft(stringValue, " \t\n\r"), nil
+			case "rtrim":
+				return strings.TrimRight(stringValue, " \t\n\r"), nil
+			default:
+				return strings.TrimSpace(stringValue), nil
+			}
+		}
+
+	case cypher.StringSplitToArrayFunction:
+		if err := expectArguments(invocation, arguments, 2, 2); err != nil {
+			return nil, err
+		} else if arguments[1] == nil {
+			return nil, nil
+		} else if stringValue, err := expectString(invocation, argument); err != nil {
+			return nil, err
+		} else if delimiter, err := expectString(invocation, arguments[1]); err != nil {
+			return nil, err
+		} else {
+			return normalizeValue(strings.Split(stringValue, delimiter)), nil
+		}
+
+	case "replace":
+		if err := expectArguments(invocation, arguments, 3, 3); err != nil {
+			return nil, err
+		} else if arguments[1] == nil || arguments[2] == nil {
+			return nil, nil
+		} else if stringValue, err := expectString(invocation, argument); err != nil {
+			return nil, err
+		} else if search, err := expectString(invocation, arguments[1]); err != nil {
+			return nil, err
+		} else if replacement, err := expectString(invocation, arguments[2]); err != nil {
+			return nil, err
+		} else {
+			return strings.ReplaceAll(stringValue, search, replacement), nil
+		}
+
+	case "substring", "left", "right":
+		return substring(invocation, name, arguments)
+	}
+
+	return nil, fmt.Errorf("unsupported function %s for argument of type %T", invocation.Name, argument)
+}
+
+func substring(invocation *cypher.FunctionInvocation, name string, arguments []any) (any, error) {
+	maxArguments := 2
+
+	if name == "substring" {
+		maxArguments = 3
+	}
+
+	if err := expectArguments(invocation, arguments, 2, maxArguments); err != nil {
+		return nil, err
+	}
+
+	stringValue, err := expectString(invocation, arguments[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		runes  = []rune(stringValue)
+		start  = int64(0)
+		length = int64(len(runes))
+	)
+
+	switch name {
+	case "substring":
+		if start, err = expectInteger(invocation, arguments[1]); err != nil {
+			return nil, err
+		} else if len(arguments) == 3 {
+			if length, err = expectInteger(invocation, arguments[2]); err != nil {
+				return nil, err
+			}
+		}
+
+	case "left":
+		if length, err = expectInteger(invocation, arguments[1]); err != nil {
+			return nil, err
+		}
+
+	case "right":
+		if length, err = expectInteger(invocation, arguments[1]); err != nil {
+			return nil, err
+		}
+
+		start = max(int64(len(runes))-length, 0)
+	}
+
+	if start < 0 || length < 0 {
+		return nil, fmt.Errorf("function %s does not accept negative arguments", invocation.Name)
+	}
+
+	start = min(start, int64(len(runes)))
+	end := min(start+length, int64(len(runes)))
+
+	return string(runes[start:end]), nil
+}
+
+// evaluateProperties returns the current properties of the given node or relationship.
+func (s *executor) evaluateProperties(entity any) (map[string]any, error) {
+	switch typedEntity := entity.(type) {
+	case *graph.Node:
+		return normalizeValue(s.currentNode(typedEntity).Properties.MapOrEmpty()).(map[string]any), nil
+	case *graph.Relationship:
+		return normalizeValue(s.currentRelationship(typedEntity).Properties.MapOrEmpty()).(map[string]any), nil
+	default:
+		return nil, fmt.Errorf("expected a node or relationship but received %T", entity)
+	}
+}
+
+// aggregate computes the value of the given aggregate function over all rows of a group.
+func (s *executor) aggregate(invocation *cypher.FunctionInvocation, rows []row) (any, error) {
+	var (
+		name   = functionName(invocation)
+		values []any
+		seen   = map[string]struct{}{}
+	)
+
+	if name == cypher.CountFunction && len(invocation.Arguments) == 1 {
+		if _, isWildcard := invocation.Arguments[0].(*cypher.RangeQuantifier); isWildcard {
+			return int64(len(rows)), nil
+		}
+	}
+
+	if len(invocation.Arguments) != 1 {
+		return nil, fmt.Errorf("aggregate function %s expects 1 argument but received %d", invocation.Name, len(invocation.Arguments))
+	}
+
+	for _, nextRow := range rows {
+		if value, err := s.evaluate(invocation.Arguments[0], nextRow); err != nil {
+			return nil, err
+		} else if value != nil {
+			if invocation.Distinct {
+				key := valueKey(value)
+
+				if _, isDuplicate := seen[key]; isDuplicate {
+					continue
+				}
+
+				seen[key] = struct{}{}
+			}
+
+			values = append(values, value)
+		}
+	}
+
+	switch name {
+	case cypher.CountFunction:
+		return int64(len(values)), nil
+
+	case collectFunction:
+		if values == nil {
+			return []any{}, nil
+		}
+
+		return values, nil
+
+	case sumFunction, avgFunction:
+		var (
+			intSum   int64
+			floatSum float64
+			isFloat  = name == avgFunction
+		)
+
+		for _, value := range values {
+			switch typedValue := value.(type) {
+			case int64:
+				intSum += typedValue
+				floatSum += float64(typedValue)
+			case float64:
+				isFloat = true
+				floatSum += typedValue
+			default:
+				return nil, fmt.Errorf("aggregate function %s expects numeric values but received %T", invocation.Name, value)
+			}
+		}
+
+		if name == avgFunction {
+			if len(values) == 0 {
+				return nil, nil
+			}
+
+			return floatSum / float64(len(values)), nil
+		} else if isFloat {
+			return floatSum, nil
+		}
+
+		return intSum, nil
+
+	default:
+		var result any
+
+		for _, value := range values {
+			if result == nil {
+				result = value
+			} else if comparison := orderValues(value, result); (name == minFunction && comparison < 0) || (name == maxFunction && comparison > 0) {
+				result = value
+			}
+		}
+
+		return result, nil
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory implements a DAWGS graph driver that keeps all graph data in process memory. It requires no external
+// service and is intended for test harnesses and unit tests. Graph criteria and openCypher queries are evaluated
+// directly against the in-memory graph.
+package memory
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+const (
+	DriverName = "memory"
+
+	// defaultGraphName is the name of the graph targeted by transactions when no default graph has been set
+	defaultGraphName = "default"
+
+	defaultBatchWriteSize = 2_000
+)
+
+// NewDatabase creates a new, empty in-memory graph database. A graph query memory limit of 0 disables the limit.
+func NewDatabase(graphQueryMemoryLimit size.Size) *Driver {
+	return &Driver{
+		graphs: map[string]*graphData{
+			defaultGraphName: newGraphData(),
+		},
+		defaultGraph:          defaultGraphName,
+		batchWriteSize:        defaultBatchWriteSize,
+		graphQueryMemoryLimit: graphQueryMemoryLimit,
+	}
+}
+
+func init() {
+	dawgs.Register(DriverName, func(ctx context.Context, cfg dawgs.Config) (graph.Database, error) {
+		return NewDatabase(cfg.GraphQueryMemoryLimit), nil
+	})
+}
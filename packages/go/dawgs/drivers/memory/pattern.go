@@ -0,0 +1,1099 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/specterops/bloodhound/cypher/models/cypher"
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+var errPatternMatched = errors.New("pattern matched")
+
+func bindingSymbol(binding cypher.Expression) string {
+	if variable, isVariable := binding.(*cypher.Variable); isVariable && variable != nil {
+		return variable.Symbol
+	}
+
+	return ""
+}
+
+type patternNode struct {
+	pattern *cypher.NodePattern
+	symbol  string
+}
+
+type patternRelationship struct {
+	pattern        *cypher.RelationshipPattern
+	symbol         string
+	direction      graph.Direction
+	variableLength bool
+	minDepth       int64
+
+	// maxDepth is negative if the expansion is unbounded
+	maxDepth int64
+}
+
+// patternChain is a pattern part split into its node and relationship patterns. A chain always contains exactly one
+// more node than it contains relationships.
+type patternChain struct {
+	part          *cypher.PatternPart
+	nodes         []patternNode
+	relationships []patternRelationship
+}
+
+func newPatternChain(part *cypher.PatternPart) (*patternChain, error) {
+	chain := &patternChain{
+		part: part,
+	}
+
+	for idx, element := range part.PatternElements {
+		if nodePattern, isNodePattern := element.AsNodePattern(); isNodePattern {
+			if idx%2 != 0 {
+				return nil, fmt.Errorf("expected a relationship pattern at position %d of pattern", idx)
+			}
+
+			chain.nodes = append(chain.nodes, patternNode{
+				pattern: nodePattern,
+				symbol:  bindingSymbol(nodePattern.Binding),
+			})
+		} else if relationshipPattern, isRelationshipPattern := element.AsRelationshipPattern(); isRelationshipPattern {
+			if idx%2 != 1 {
+				return nil, fmt.Errorf("expected a node pattern at position %d of pattern", idx)
+			}
+
+			next := patternRelationship{
+				pattern:   relationshipPattern,
+				symbol:    bindingSymbol(relationshipPattern.Binding),
+				direction: relationshipPattern.Direction,
+				minDepth:  1,
+				maxDepth:  1,
+			}
+
+			if patternRange := relationshipPattern.Range; patternRange != nil {
+				next.variableLength = true
+				next.maxDepth = -1
+
+				if patternRange.StartIndex != nil {
+					next.minDepth = *patternRange.StartIndex
+				}
+
+				if patternRange.EndIndex != nil {
+					next.maxDepth = *patternRange.EndIndex
+				}
+			}
+
+			chain.relationships = append(chain.relationships, next)
+		} else {
+			return nil, fmt.Errorf("unsupported pattern element %T", element.Element)
+		}
+	}
+
+	if len(chain.nodes) != len(chain.relationships)+1 {
+		return nil, fmt.Errorf("pattern must begin and end with a node pattern")
+	}
+
+	return chain, nil
+}
+
+// reversed returns the chain with its elements in reverse order and the direction of each relationship reversed.
+func (s *patternChain) reversed() *patternChain {
+	reversed := &patternChain{
+		part:          s.part,
+		nodes:         slices.Clone(s.nodes),
+		relationships: slices.Clone(s.relationships),
+	}
+
+	slices.Reverse(reversed.nodes)
+	slices.Reverse(reversed.relationships)
+
+	for idx, relationship := range reversed.relationships {
+		if reversedDirection, err := relationship.direction.Reverse(); err == nil {
+			reversed.relationships[idx].direction = reversedDirection
+		}
+	}
+
+	return reversed
+}
+
+type symbolType int
+
+const (
+	symbolNode symbolType = iota
+	symbolRelationship
+	symbolRelationshipList
+	symbolPath
+)
+
+// patternSymbols returns the variables bound by the given pattern parts.
+func patternSymbols(chains []*patternChain) map[string]symbolType {
+	symbols := map[string]symbolType{}
+
+	for _, chain := range chains {
+		if symbol := bindingSymbol(chain.part.Binding); symbol != "" {
+			symbols[symbol] = symbolPath
+		}
+
+		for _, node := range chain.nodes {
+			if node.symbol != "" {
+				symbols[node.symbol] = symbolNode
+			}
+		}
+
+		for _, relationship := range chain.relationships {
+			if relationship.symbol != "" {
+				if relationship.variableLength || chain.part.ShortestPathPattern || chain.part.AllShortestPathsPattern {
+					symbols[relationship.symbol] = symbolRelationshipList
+				} else {
+					symbols[relationship.symbol] = symbolRelationship
+				}
+			}
+		}
+	}
+
+	return symbols
+}
+
+// splitConjunction returns the operands of the given predicate if it is a conjunction.
+func splitConjunction(predicate cypher.Expression) []cypher.Expression {
+	switch typedPredicate := predicate.(type) {
+	case nil:
+		return nil
+
+	case *cypher.Conjunction:
+		var conjuncts []cypher.Expression
+
+		for _, expression := range typedPredicate.GetAll() {
+			conjuncts = append(conjuncts, splitConjunction(expression)...)
+		}
+
+		return conjuncts
+
+	case *cypher.Parenthetical:
+		if _, isConjunction := typedPredicate.Expression.(*cypher.Conjunction); isConjunction {
+			return splitConjunction(typedPredicate.Expression)
+		}
+	}
+
+	return []cypher.Expression{predicate}
+}
+
+// freeVariables returns the variables referenced by the given expression that are not scoped to the expression
+// itself. The returned boolean is false if the expression could not be inspected.
+func freeVariables(expression cypher.Expression) (map[string]struct{}, bool) {
+	var (
+		referenced = map[string]struct{}{}
+		scoped     = map[string]struct{}{}
+	)
+
+	if err := cypher.Walk(expression, cypher.NewVisitor(func(stack *cypher.WalkStack, element cypher.Expression) error {
+		switch typedElement := element.(type) {
+		case *cypher.Variable:
+			referenced[typedElement.Symbol] = struct{}{}
+
+		case *cypher.IDInCollection:
+			if typedElement.Variable != nil {
+				scoped[typedElement.Variable.Symbol] = struct{}{}
+			}
+		}
+
+		return nil
+	}, nil)); err != nil {
+		return nil, false
+	}
+
+	for symbol := range scoped {
+		delete(referenced, symbol)
+	}
+
+	return referenced, true
+}
+
+type matchConjunct struct {
+	expression cypher.Expression
+	variables  map[string]struct{}
+}
+
+// matcher finds all bindings of pattern parts for a single input row. Predicates that reference a single unbound
+// pattern variable are evaluated while candidates for that variable are considered instead of after the whole pattern
+// has been matched.
+type matcher struct {
+	executor *executor
+	data     *graphData
+	symbols  map[string]symbolType
+	filters  map[string][]cypher.Expression
+	seeds    map[string][]graph.ID
+	used     map[graph.ID]struct{}
+}
+
+func (s *executor) newMatcher(symbols map[string]symbolType) *matcher {
+	return &matcher{
+		executor: s,
+		data:     s.tx.data(),
+		symbols:  symbols,
+		filters:  map[string][]cypher.Expression{},
+		seeds:    map[string][]graph.ID{},
+		used:     map[graph.ID]struct{}{},
+	}
+}
+
+// seedIDs returns the IDs a predicate of the form id(v) = x or id(v) IN x restricts the given variable to.
+func (s *matcher) seedIDs(conjunct cypher.Expression, symbol string, current row) ([]graph.ID, bool, error) {
+	comparison, isComparison := conjunct.(*cypher.Comparison)
+
+	if !isComparison || len(comparison.Partials) != 1 {
+		return nil, false, nil
+	}
+
+	operator := comparison.Partials[0].Operator
+
+	if operator != cypher.OperatorEquals && operator != cypher.OperatorIn {
+		return nil, false, nil
+	}
+
+	if function, isFunction := comparison.Left.(*cypher.FunctionInvocation); !isFunction || functionName(function) != cypher.IdentityFunction || len(function.Arguments) != 1 || len(function.Namespace) > 0 {
+		return nil, false, nil
+	} else if bindingSymbol(function.Arguments[0]) != symbol {
+		return nil, false, nil
+	}
+
+	value, err := s.executor.evaluate(comparison.Partials[0].Right, current)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	var values []any
+
+	if operator == cypher.OperatorEquals {
+		values = []any{value}
+	} else if list, isList := value.([]any); isList {
+		values = list
+	} else {
+		return nil, false, nil
+	}
+
+	ids := make([]graph.ID, 0, len(values))
+
+	for _, value := range values {
+		if intValue, isInt := value.(int64); isInt {
+			ids = append(ids, graph.ID(intValue))
+		}
+	}
+
+	slices.Sort(ids)
+	return slices.Compact(ids), true, nil
+}
+
+// plan assigns each conjunct either to the pattern variable it filters or to the returned residual predicate.
+func (s *matcher) plan(current row, conjuncts []matchConjunct) ([]cypher.Expression, error) {
+	var residual []cypher.Expression
+
+	for _, conjunct := range conjuncts {
+		if conjunct.variables == nil {
+			residual = append(residual, conjunct.expression)
+			continue
+		}
+
+		var unbound []string
+
+		for symbol := range conjunct.variables {
+			if _, isBound := current[symbol]; !isBound {
+				unbound = append(unbound, symbol)
+			}
+		}
+
+		if len(unbound) != 1 {
+			residual = append(residual, conjunct.expression)
+			continue
+		}
+
+		symbol := unbound[0]
+		symbolType, isPatternSymbol := s.symbols[symbol]
+
+		if !isPatternSymbol {
+			residual = append(residual, conjunct.expression)
+			continue
+		}
+
+		switch symbolType {
+		case symbolNode, symbolRelationship:
+			if ids, isSeed, err := s.seedIDs(conjunct.expression, symbol, current); err != nil {
+				return nil, err
+			} else if isSeed {
+				if existing, hasSeeds := s.seeds[symbol]; hasSeeds {
+					ids = slices.DeleteFunc(ids, func(id graph.ID) bool {
+						_, found := slices.BinarySearch(existing, id)
+						return !found
+					})
+				}
+
+				s.seeds[symbol] = ids
+			}
+
+			s.filters[symbol] = append(s.filters[symbol], conjunct.expression)
+
+		case symbolRelationshipList:
+			// Like the PostgreSQL translation, predicates on an expansion's relationship variable apply to each
+			// relationship of the expansion
+			s.filters[symbol] = append(s.filters[symbol], conjunct.expression)
+
+		default:
+			residual = append(residual, conjunct.expression)
+		}
+	}
+
+	return residual, nil
+}
+
+func (s *matcher) passesFilters(current row, symbol string, value any) (bool, error) {
+	if symbol == "" {
+		return true, nil
+	}
+
+	if seeds, hasSeeds := s.seeds[symbol]; hasSeeds {
+		var id graph.ID
+
+		switch typedValue := value.(type) {
+		case *graph.Node:
+			id = typedValue.ID
+		case *graph.Relationship:
+			id = typedValue.ID
+		}
+
+		if _, found := slices.BinarySearch(seeds, id); !found {
+			return false, nil
+		}
+	}
+
+	if filters := s.filters[symbol]; len(filters) > 0 {
+		filterRow := current.with(symbol, value)
+
+		for _, filter := range filters {
+			if result, err := s.executor.evaluate(filter, filterRow); err != nil {
+				return false, err
+			} else if !isTrue(result) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (s *matcher) propertiesMatch(current row, propertiesExpression cypher.Expression, properties *graph.Properties) (bool, error) {
+	if propertiesExpression == nil {
+		return true, nil
+	}
+
+	expected, err := s.executor.evaluatePropertyMap(propertiesExpression, current)
+
+	if err != nil {
+		return false, err
+	}
+
+	for key, expectedValue := range expected {
+		var actualValue any
+
+		if properties != nil {
+			actualValue = normalizeValue(properties.Get(key).Any())
+		}
+
+		if equalValues(actualValue, expectedValue) != true {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// bindNode binds the given node to the node pattern if the node satisfies it.
+func (s *matcher) bindNode(current row, step patternNode, node *graph.Node) (row, bool, error) {
+	if node == nil {
+		return nil, false, nil
+	}
+
+	isBound := false
+
+	if step.symbol != "" {
+		var boundValue any
+
+		if boundValue, isBound = current[step.symbol]; isBound {
+			if boundNode, isNode := boundValue.(*graph.Node); !isNode || boundNode.ID != node.ID {
+				return nil, false, nil
+			}
+		}
+	}
+
+	for _, kind := range step.pattern.Kinds {
+		if !node.Kinds.ContainsOneOf(kind) {
+			return nil, false, nil
+		}
+	}
+
+	if matched, err := s.propertiesMatch(current, step.pattern.Properties, node.Properties); err != nil || !matched {
+		return nil, false, err
+	}
+
+	if passed, err := s.passesFilters(current, step.symbol, node); err != nil || !passed {
+		return nil, false, err
+	}
+
+	if step.symbol == "" || isBound {
+		return current, true, nil
+	}
+
+	return current.with(step.symbol, node), true, nil
+}
+
+// relationshipMatches tests the given relationship against the relationship pattern. For expansions, the filters of
+// the pattern's variable are tested against the single relationship.
+func (s *matcher) relationshipMatches(current row, step patternRelationship, relationship *graph.Relationship) (bool, error) {
+	if _, isUsed := s.used[relationship.ID]; isUsed {
+		return false, nil
+	}
+
+	if len(step.pattern.Kinds) > 0 && !step.pattern.Kinds.ContainsOneOf(relationship.Kind) {
+		return false, nil
+	}
+
+	if matched, err := s.propertiesMatch(current, step.pattern.Properties, relationship.Properties); err != nil || !matched {
+		return false, err
+	}
+
+	return s.passesFilters(current, step.symbol, relationship)
+}
+
+func otherEnd(relationship *graph.Relationship, nodeID graph.ID, direction graph.Direction) graph.ID {
+	switch direction {
+	case graph.DirectionOutbound:
+		return relationship.EndID
+	case graph.DirectionInbound:
+		return relationship.StartID
+	default:
+		if relationship.StartID == nodeID {
+			return relationship.EndID
+		}
+
+		return relationship.StartID
+	}
+}
+
+// isKnown returns true if the node pattern's variable is already bound or restricted to a set of IDs.
+func (s *matcher) isKnown(current row, step patternNode) bool {
+	if step.symbol == "" {
+		return false
+	}
+
+	if _, isBound := current[step.symbol]; isBound {
+		return true
+	}
+
+	_, isSeeded := s.seeds[step.symbol]
+	return isSeeded
+}
+
+// nodeCandidates returns the nodes that may be bound to the given node pattern in ascending ID order.
+func (s *matcher) nodeCandidates(current row, step patternNode) []*graph.Node {
+	if step.symbol != "" {
+		if boundValue, isBound := current[step.symbol]; isBound {
+			if boundNode, isNode := boundValue.(*graph.Node); isNode {
+				if node, exists := s.data.nodes[boundNode.ID]; exists {
+					return []*graph.Node{node}
+				}
+			}
+
+			return nil
+		}
+
+		if seeds, isSeeded := s.seeds[step.symbol]; isSeeded {
+			var candidates []*graph.Node
+
+			for _, id := range seeds {
+				if node, exists := s.data.nodes[id]; exists {
+					candidates = append(candidates, node)
+				}
+			}
+
+			return candidates
+		}
+	}
+
+	nodeIDs := s.data.sortedNodeIDs()
+	candidates := make([]*graph.Node, len(nodeIDs))
+
+	for idx, nodeID := range nodeIDs {
+		candidates[idx] = s.data.nodes[nodeID]
+	}
+
+	return candidates
+}
+
+// relationshipCandidates returns the relationships that may be bound to the given relationship pattern in ascending
+// ID order.
+func (s *matcher) relationshipCandidates(current row, step patternRelationship) []*graph.Relationship {
+	if step.symbol != "" {
+		if boundValue, isBound := current[step.symbol]; isBound {
+			if boundRelationship, isRelationship := boundValue.(*graph.Relationship); isRelationship {
+				if relationship, exists := s.data.relationships[boundRelationship.ID]; exists {
+					return []*graph.Relationship{relationship}
+				}
+			}
+
+			return nil
+		}
+
+		if seeds, isSeeded := s.seeds[step.symbol]; isSeeded {
+			var candidates []*graph.Relationship
+
+			for _, id := range seeds {
+				if relationship, exists := s.data.relationships[id]; exists {
+					candidates = append(candidates, relationship)
+				}
+			}
+
+			return candidates
+		}
+	}
+
+	relationshipIDs := s.data.sortedRelationshipIDs()
+	candidates := make([]*graph.Relationship, len(relationshipIDs))
+
+	for idx, relationshipID := range relationshipIDs {
+		candidates[idx] = s.data.relationships[relationshipID]
+	}
+
+	return candidates
+}
+
+// patternMatch accumulates the nodes and relationships matched for a chain.
+type patternMatch struct {
+	nodes []*graph.Node
+	hops  [][]*graph.Relationship
+}
+
+// finish binds expansion variables and the path variable of the chain and emits the resulting row. If the chain was
+// matched in reverse, the match is reversed first so that bindings follow the order of the original pattern.
+func (s *matcher) finish(current row, chain *patternChain, match patternMatch, reversed bool, emit func(row) error) error {
+	var (
+		nodes         = slices.Clone(match.nodes)
+		hops          = slices.Clone(match.hops)
+		relationships = slices.Clone(chain.relationships)
+	)
+
+	if reversed {
+		slices.Reverse(nodes)
+		slices.Reverse(hops)
+		slices.Reverse(relationships)
+
+		for idx, hop := range hops {
+			reversedHop := slices.Clone(hop)
+			slices.Reverse(reversedHop)
+
+			hops[idx] = reversedHop
+		}
+	}
+
+	next := current
+	path := &graph.Path{
+		Nodes: nodes,
+	}
+
+	for idx, hop := range hops {
+		path.Edges = append(path.Edges, hop...)
+
+		if relationships[idx].symbol != "" && (relationships[idx].variableLength || chain.part.ShortestPathPattern || chain.part.AllShortestPathsPattern) {
+			values := make([]any, len(hop))
+
+			for hopIdx, relationship := range hop {
+				values[hopIdx] = relationship
+			}
+
+			next = next.with(relationships[idx].symbol, values)
+		}
+	}
+
+	if symbol := bindingSymbol(chain.part.Binding); symbol != "" {
+		next = next.with(symbol, path)
+	}
+
+	return emit(next)
+}
+
+// expand matches the relationship at the given hop of the chain and everything after it.
+func (s *matcher) expand(current row, chain *patternChain, hop int, match patternMatch, reversed bool, emit func(row) error) error {
+	if hop == len(chain.relationships) {
+		return s.finish(current, chain, match, reversed, emit)
+	}
+
+	var (
+		step     = chain.relationships[hop]
+		nextStep = chain.nodes[hop+1]
+		from     = match.nodes[len(match.nodes)-1]
+	)
+
+	if !step.variableLength {
+		for _, relationship := range s.data.adjacent(from.ID, step.direction) {
+			if matched, err := s.relationshipMatches(current, step, relationship); err != nil {
+				return err
+			} else if !matched {
+				continue
+			}
+
+			next := current
+
+			if step.symbol != "" {
+				if boundValue, isBound := current[step.symbol]; isBound {
+					if boundRelationship, isRelationship := boundValue.(*graph.Relationship); !isRelationship || boundRelationship.ID != relationship.ID {
+						continue
+					}
+				} else {
+					next = current.with(step.symbol, relationship)
+				}
+			}
+
+			nextNode := s.data.nodes[otherEnd(relationship, from.ID, step.direction)]
+
+			if next, bound, err := s.bindNode(next, nextStep, nextNode); err != nil {
+				return err
+			} else if bound {
+				s.used[relationship.ID] = struct{}{}
+
+				err := s.expand(next, chain, hop+1, patternMatch{
+					nodes: append(slices.Clone(match.nodes), nextNode),
+					hops:  append(slices.Clone(match.hops), []*graph.Relationship{relationship}),
+				}, reversed, emit)
+
+				delete(s.used, relationship.ID)
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	var walk func(node *graph.Node, depth int64, relationships []*graph.Relationship) error
+
+	walk = func(node *graph.Node, depth int64, relationships []*graph.Relationship) error {
+		if depth >= step.minDepth {
+			if next, bound, err := s.bindNode(current, nextStep, node); err != nil {
+				return err
+			} else if bound {
+				if err := s.expand(next, chain, hop+1, patternMatch{
+					nodes: append(slices.Clone(match.nodes), node),
+					hops:  append(slices.Clone(match.hops), slices.Clone(relationships)),
+				}, reversed, emit); err != nil {
+					return err
+				}
+			}
+		}
+
+		if step.maxDepth >= 0 && depth >= step.maxDepth {
+			return nil
+		}
+
+		for _, relationship := range s.data.adjacent(node.ID, step.direction) {
+			if matched, err := s.relationshipMatches(current, step, relationship); err != nil {
+				return err
+			} else if matched {
+				s.used[relationship.ID] = struct{}{}
+				err := walk(s.data.nodes[otherEnd(relationship, node.ID, step.direction)], depth+1, append(relationships, relationship))
+				delete(s.used, relationship.ID)
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	return walk(from, 0, nil)
+}
+
+// matchSingleHop matches a chain consisting of a single fixed-length relationship where neither node is bound by
+// scanning relationships instead of nodes.
+func (s *matcher) matchSingleHop(current row, chain *patternChain, emit func(row) error) error {
+	step := chain.relationships[0]
+
+	for _, relationship := range s.relationshipCandidates(current, step) {
+		if err := s.executor.ctx.Err(); err != nil {
+			return err
+		}
+
+		if matched, err := s.relationshipMatches(current, step, relationship); err != nil {
+			return err
+		} else if !matched {
+			continue
+		}
+
+		orientations := [][2]graph.ID{}
+
+		switch step.direction {
+		case graph.DirectionOutbound:
+			orientations = append(orientations, [2]graph.ID{relationship.StartID, relationship.EndID})
+		case graph.DirectionInbound:
+			orientations = append(orientations, [2]graph.ID{relationship.EndID, relationship.StartID})
+		default:
+			orientations = append(orientations, [2]graph.ID{relationship.StartID, relationship.EndID})
+
+			if relationship.StartID != relationship.EndID {
+				orientations = append(orientations, [2]graph.ID{relationship.EndID, relationship.StartID})
+			}
+		}
+
+		for _, orientation := range orientations {
+			next := current
+
+			if step.symbol != "" {
+				if _, isBound := current[step.symbol]; !isBound {
+					next = current.with(step.symbol, relationship)
+				}
+			}
+
+			var (
+				startNode = s.data.nodes[orientation[0]]
+				endNode   = s.data.nodes[orientation[1]]
+			)
+
+			if next, bound, err := s.bindNode(next, chain.nodes[0], startNode); err != nil {
+				return err
+			} else if !bound {
+				continue
+			} else if next, bound, err := s.bindNode(next, chain.nodes[1], endNode); err != nil {
+				return err
+			} else if bound {
+				s.used[relationship.ID] = struct{}{}
+
+				err := s.finish(next, chain, patternMatch{
+					nodes: []*graph.Node{startNode, endNode},
+					hops:  [][]*graph.Relationship{{relationship}},
+				}, false, emit)
+
+				delete(s.used, relationship.ID)
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *matcher) matchChain(current row, chain *patternChain, emit func(row) error) error {
+	var (
+		firstKnown = s.isKnown(current, chain.nodes[0])
+		lastKnown  = s.isKnown(current, chain.nodes[len(chain.nodes)-1])
+		reversed   = !firstKnown && lastKnown
+		working    = chain
+	)
+
+	if !firstKnown && !lastKnown && len(chain.relationships) == 1 && !chain.relationships[0].variableLength {
+		return s.matchSingleHop(current, chain, emit)
+	}
+
+	if reversed {
+		working = chain.reversed()
+	}
+
+	for _, node := range s.nodeCandidates(current, working.nodes[0]) {
+		if err := s.executor.ctx.Err(); err != nil {
+			return err
+		}
+
+		if next, bound, err := s.bindNode(current, working.nodes[0], node); err != nil {
+			return err
+		} else if bound {
+			if err := s.expand(next, working, 0, patternMatch{
+				nodes: []*graph.Node{node},
+			}, reversed, emit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type shortestPathParent struct {
+	relationship *graph.Relationship
+	nodeID       graph.ID
+}
+
+// matchShortestPaths matches shortestPath and allShortestPaths pattern parts with a breadth-first search from each
+// candidate start node.
+func (s *matcher) matchShortestPaths(current row, chain *patternChain, emit func(row) error) error {
+	if len(chain.relationships) != 1 {
+		return fmt.Errorf("shortest path patterns must contain exactly one relationship pattern")
+	}
+
+	var (
+		reversed = !s.isKnown(current, chain.nodes[0]) && s.isKnown(current, chain.nodes[1])
+		working  = chain
+	)
+
+	if reversed {
+		working = chain.reversed()
+	}
+
+	step := working.relationships[0]
+
+	for _, startNode := range s.nodeCandidates(current, working.nodes[0]) {
+		if err := s.executor.ctx.Err(); err != nil {
+			return err
+		}
+
+		startRow, bound, err := s.bindNode(current, working.nodes[0], startNode)
+
+		if err != nil {
+			return err
+		} else if !bound {
+			continue
+		}
+
+		var (
+			levels     = map[graph.ID]int64{startNode.ID: 0}
+			parents    = map[graph.ID][]shortestPathParent{}
+			discovered = []graph.ID{}
+			frontier   = []graph.ID{startNode.ID}
+		)
+
+		if step.minDepth == 0 {
+			discovered = append(discovered, startNode.ID)
+		}
+
+		for depth := int64(1); len(frontier) > 0 && (step.maxDepth < 0 || depth <= step.maxDepth); depth++ {
+			var nextFrontier []graph.ID
+
+			for _, nodeID := range frontier {
+				for _, relationship := range s.data.adjacent(nodeID, step.direction) {
+					if matched, err := s.relationshipMatches(startRow, step, relationship); err != nil {
+						return err
+					} else if !matched {
+						continue
+					}
+
+					otherID := otherEnd(relationship, nodeID, step.direction)
+
+					if level, seen := levels[otherID]; !seen {
+						levels[otherID] = depth
+						parents[otherID] = []shortestPathParent{{relationship: relationship, nodeID: nodeID}}
+						discovered = append(discovered, otherID)
+						nextFrontier = append(nextFrontier, otherID)
+					} else if level == depth {
+						parents[otherID] = append(parents[otherID], shortestPathParent{relationship: relationship, nodeID: nodeID})
+					}
+				}
+			}
+
+			frontier = nextFrontier
+		}
+
+		for _, endID := range discovered {
+			endNode := s.data.nodes[endID]
+
+			endRow, bound, err := s.bindNode(startRow, working.nodes[1], endNode)
+
+			if err != nil {
+				return err
+			} else if !bound {
+				continue
+			}
+
+			var collect func(nodeID graph.ID, relationships []*graph.Relationship) error
+
+			collect = func(nodeID graph.ID, relationships []*graph.Relationship) error {
+				if nodeID == startNode.ID {
+					var (
+						hop   = slices.Clone(relationships)
+						nodes = []*graph.Node{startNode}
+					)
+
+					slices.Reverse(hop)
+
+					for _, relationship := range hop {
+						nodes = append(nodes, s.data.nodes[otherEnd(relationship, nodes[len(nodes)-1].ID, step.direction)])
+					}
+
+					if err := s.finish(endRow, working, patternMatch{
+						nodes: nodes,
+						hops:  [][]*graph.Relationship{hop},
+					}, reversed, emit); err != nil {
+						return err
+					}
+
+					if working.part.ShortestPathPattern {
+						return errPatternMatched
+					}
+
+					return nil
+				}
+
+				for _, parent := range parents[nodeID] {
+					if err := collect(parent.nodeID, append(relationships, parent.relationship)); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}
+
+			if err := collect(endID, nil); err != nil && !errors.Is(err, errPatternMatched) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *matcher) matchParts(current row, chains []*patternChain, emit func(row) error) error {
+	if len(chains) == 0 {
+		return emit(current)
+	}
+
+	var (
+		chain = chains[0]
+		next  = func(nextRow row) error {
+			return s.matchParts(nextRow, chains[1:], emit)
+		}
+	)
+
+	if chain.part.ShortestPathPattern || chain.part.AllShortestPathsPattern {
+		return s.matchShortestPaths(current, chain, next)
+	}
+
+	return s.matchChain(current, chain, next)
+}
+
+func newPatternChains(parts []*cypher.PatternPart) ([]*patternChain, error) {
+	chains := make([]*patternChain, len(parts))
+
+	for idx, part := range parts {
+		if chain, err := newPatternChain(part); err != nil {
+			return nil, err
+		} else {
+			chains[idx] = chain
+		}
+	}
+
+	return chains, nil
+}
+
+func (s *executor) match(rows []row, match *cypher.Match) ([]row, error) {
+	chains, err := newPatternChains(match.Pattern)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		symbols   = patternSymbols(chains)
+		conjuncts []matchConjunct
+		results   []row
+	)
+
+	for _, conjunct := range splitConjunction(whereExpression(match.Where)) {
+		variables, _ := freeVariables(conjunct)
+
+		conjuncts = append(conjuncts, matchConjunct{
+			expression: conjunct,
+			variables:  variables,
+		})
+	}
+
+	for _, current := range rows {
+		var (
+			matcher   = s.newMatcher(symbols)
+			isMatched = false
+		)
+
+		residual, err := matcher.plan(current, conjuncts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var residualPredicate cypher.Expression
+
+		if len(residual) == 1 {
+			residualPredicate = residual[0]
+		} else if len(residual) > 1 {
+			residualPredicate = cypher.NewConjunction(residual...)
+		}
+
+		if err := matcher.matchParts(current, chains, func(next row) error {
+			if residualPredicate != nil {
+				if result, err := s.evaluate(residualPredicate, next); err != nil {
+					return err
+				} else if !isTrue(result) {
+					return nil
+				}
+			}
+
+			isMatched = true
+			results = append(results, next)
+
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		if !isMatched && match.Optional {
+			next := current
+
+			for _, symbol := range sortedKeys(symbols) {
+				if _, isBound := next[symbol]; !isBound {
+					next = next.with(symbol, nil)
+				}
+			}
+
+			results = append(results, next)
+		}
+	}
+
+	return results, nil
+}
+
+func (s *executor) evaluatePatternPredicate(patternPredicate *cypher.PatternPredicate, current row) (any, error) {
+	chain, err := newPatternChain(&cypher.PatternPart{
+		PatternElements: patternPredicate.PatternElements,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.newMatcher(patternSymbols([]*patternChain{chain})).matchChain(current, chain, func(row) error {
+		return errPatternMatched
+	}); errors.Is(err, errPatternMatched) {
+		return true, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return false, nil
+}
@@ -0,0 +1,400 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/specterops/bloodhound/cypher/models/cypher"
+	"github.com/specterops/bloodhound/cypher/models/cypher/format"
+)
+
+type projectionColumn struct {
+	name       string
+	expression cypher.Expression
+	aggregates []*cypher.FunctionInvocation
+}
+
+func formatExpression(expression cypher.Expression) (string, error) {
+	buffer := &bytes.Buffer{}
+
+	if err := format.NewCypherEmitter(false).WriteExpression(buffer, expression); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// collectAggregates returns the aggregate function invocations contained in the given expression.
+func collectAggregates(expression cypher.Expression) ([]*cypher.FunctionInvocation, error) {
+	var aggregates []*cypher.FunctionInvocation
+
+	err := cypher.Walk(expression, cypher.NewVisitor(func(stack *cypher.WalkStack, element cypher.Expression) error {
+		if invocation, isInvocation := element.(*cypher.FunctionInvocation); isInvocation && isAggregate(invocation) {
+			aggregates = append(aggregates, invocation)
+		}
+
+		return nil
+	}, nil))
+
+	return aggregates, err
+}
+
+func projectionColumns(projection *cypher.Projection, rows []row) ([]projectionColumn, error) {
+	var columns []projectionColumn
+
+	for _, item := range projection.Items {
+		projectionItem, isProjectionItem := item.(*cypher.ProjectionItem)
+
+		if !isProjectionItem {
+			return nil, fmt.Errorf("unsupported projection item type %T", item)
+		}
+
+		if bindingSymbol(projectionItem.Expression) == cypher.TokenLiteralAsterisk {
+			if len(rows) > 0 {
+				for _, symbol := range sortedKeys(rows[0]) {
+					columns = append(columns, projectionColumn{
+						name:       symbol,
+						expression: cypher.NewVariableWithSymbol(symbol),
+					})
+				}
+			}
+
+			continue
+		}
+
+		column := projectionColumn{
+			name:       bindingSymbol(projectionItem.Binding),
+			expression: projectionItem.Expression,
+		}
+
+		if column.name == "" {
+			if name, err := formatExpression(projectionItem.Expression); err != nil {
+				return nil, err
+			} else {
+				column.name = name
+			}
+		}
+
+		if aggregates, err := collectAggregates(projectionItem.Expression); err != nil {
+			return nil, err
+		} else {
+			column.aggregates = aggregates
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// projectedRow pairs a projected row with the source row it was projected from so that ORDER BY may refer to
+// variables that were not projected.
+type projectedRow struct {
+	source    row
+	projected row
+}
+
+// project evaluates a RETURN or WITH projection. The returned rows are keyed by column name.
+func (s *executor) project(rows []row, projection *cypher.Projection) ([]row, []string, error) {
+	if projection == nil {
+		return nil, nil, fmt.Errorf("projection is empty")
+	}
+
+	columns, err := projectionColumns(projection, rows)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		columnNames   = make([]string, len(columns))
+		isAggregation = false
+		projectedRows []projectedRow
+	)
+
+	for idx, column := range columns {
+		columnNames[idx] = column.name
+		isAggregation = isAggregation || len(column.aggregates) > 0
+	}
+
+	if isAggregation {
+		projectedRows, err = s.projectGroups(rows, columns)
+	} else {
+		projectedRows, err = s.projectRows(rows, columns)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if projection.Distinct {
+		var (
+			seen     = map[string]struct{}{}
+			distinct []projectedRow
+		)
+
+		for _, nextRow := range projectedRows {
+			values := make([]any, len(columnNames))
+
+			for idx, columnName := range columnNames {
+				values[idx] = nextRow.projected[columnName]
+			}
+
+			if key := valueKey(values); !hasKey(seen, key) {
+				seen[key] = struct{}{}
+				distinct = append(distinct, nextRow)
+			}
+		}
+
+		projectedRows = distinct
+	}
+
+	if projection.Order != nil {
+		if projectedRows, err = s.order(projectedRows, projection.Order, columnNames); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if projection.Skip != nil {
+		if skip, err := s.evaluateCount(projection.Skip.Value, "SKIP"); err != nil {
+			return nil, nil, err
+		} else {
+			projectedRows = projectedRows[min(skip, len(projectedRows)):]
+		}
+	}
+
+	if projection.Limit != nil {
+		if limit, err := s.evaluateCount(projection.Limit.Value, "LIMIT"); err != nil {
+			return nil, nil, err
+		} else {
+			projectedRows = projectedRows[:min(limit, len(projectedRows))]
+		}
+	}
+
+	results := make([]row, len(projectedRows))
+
+	for idx, nextRow := range projectedRows {
+		results[idx] = nextRow.projected
+	}
+
+	return results, columnNames, nil
+}
+
+func hasKey(set map[string]struct{}, key string) bool {
+	_, exists := set[key]
+	return exists
+}
+
+func (s *executor) evaluateCount(expression cypher.Expression, clause string) (int, error) {
+	if value, err := s.evaluate(expression, row{}); err != nil {
+		return 0, err
+	} else if count, isInt := value.(int64); !isInt || count < 0 {
+		return 0, fmt.Errorf("%s expects a non-negative integer but received %v", clause, value)
+	} else {
+		return int(count), nil
+	}
+}
+
+func (s *executor) projectRows(rows []row, columns []projectionColumn) ([]projectedRow, error) {
+	projectedRows := make([]projectedRow, 0, len(rows))
+
+	for _, source := range rows {
+		projected := make(row, len(columns))
+
+		for _, column := range columns {
+			if value, err := s.evaluate(column.expression, source); err != nil {
+				return nil, err
+			} else {
+				projected[column.name] = value
+			}
+		}
+
+		projectedRows = append(projectedRows, projectedRow{
+			source:    source,
+			projected: projected,
+		})
+	}
+
+	return projectedRows, nil
+}
+
+// projectGroups groups the given rows by the values of all non-aggregate columns and projects a single row for each
+// group. Groups are projected in the order they are first seen.
+func (s *executor) projectGroups(rows []row, columns []projectionColumn) ([]projectedRow, error) {
+	type group struct {
+		keys row
+		rows []row
+	}
+
+	var (
+		groups      = map[string]*group{}
+		groupOrder  []string
+		hasGrouping = false
+	)
+
+	for _, column := range columns {
+		hasGrouping = hasGrouping || len(column.aggregates) == 0
+	}
+
+	for _, source := range rows {
+		var (
+			keys      = row{}
+			keyValues []any
+		)
+
+		for _, column := range columns {
+			if len(column.aggregates) == 0 {
+				if value, err := s.evaluate(column.expression, source); err != nil {
+					return nil, err
+				} else {
+					keys[column.name] = value
+					keyValues = append(keyValues, value)
+				}
+			}
+		}
+
+		key := valueKey(keyValues)
+
+		if existing, exists := groups[key]; exists {
+			existing.rows = append(existing.rows, source)
+		} else {
+			groups[key] = &group{
+				keys: keys,
+				rows: []row{source},
+			}
+
+			groupOrder = append(groupOrder, key)
+		}
+	}
+
+	// Aggregating without grouping keys always yields exactly one row
+	if len(groupOrder) == 0 && !hasGrouping {
+		groups[""] = &group{
+			keys: row{},
+		}
+
+		groupOrder = append(groupOrder, "")
+	}
+
+	defer func() {
+		s.aggregates = nil
+	}()
+
+	projectedRows := make([]projectedRow, 0, len(groupOrder))
+
+	for _, key := range groupOrder {
+		var (
+			nextGroup = groups[key]
+			projected = maps.Clone(nextGroup.keys)
+			source    = row{}
+		)
+
+		if len(nextGroup.rows) > 0 {
+			source = nextGroup.rows[0]
+		}
+
+		s.aggregates = map[*cypher.FunctionInvocation]any{}
+
+		for _, column := range columns {
+			for _, invocation := range column.aggregates {
+				if value, err := s.aggregate(invocation, nextGroup.rows); err != nil {
+					return nil, err
+				} else {
+					s.aggregates[invocation] = value
+				}
+			}
+		}
+
+		for _, column := range columns {
+			if len(column.aggregates) > 0 {
+				if value, err := s.evaluate(column.expression, source); err != nil {
+					return nil, err
+				} else {
+					projected[column.name] = value
+				}
+			}
+		}
+
+		projectedRows = append(projectedRows, projectedRow{
+			source:    source,
+			projected: projected,
+		})
+	}
+
+	return projectedRows, nil
+}
+
+// order sorts projected rows. Sort expressions that match a projected column use the projected value; all other
+// sort expressions are evaluated against the source row with the projected columns in scope.
+func (s *executor) order(projectedRows []projectedRow, order *cypher.Order, columnNames []string) ([]projectedRow, error) {
+	type sortableRow struct {
+		projectedRow
+		values []any
+	}
+
+	sortable := make([]sortableRow, len(projectedRows))
+
+	for idx, nextRow := range projectedRows {
+		var (
+			scope  = maps.Clone(nextRow.source)
+			values = make([]any, len(order.Items))
+		)
+
+		maps.Copy(scope, nextRow.projected)
+
+		for itemIdx, item := range order.Items {
+			if name, err := formatExpression(item.Expression); err == nil && slices.Contains(columnNames, name) {
+				values[itemIdx] = nextRow.projected[name]
+			} else if value, err := s.evaluate(item.Expression, scope); err != nil {
+				return nil, err
+			} else {
+				values[itemIdx] = value
+			}
+		}
+
+		sortable[idx] = sortableRow{
+			projectedRow: nextRow,
+			values:       values,
+		}
+	}
+
+	slices.SortStableFunc(sortable, func(left, right sortableRow) int {
+		for idx, item := range order.Items {
+			if comparison := orderValues(left.values[idx], right.values[idx]); comparison != 0 {
+				if item.Ascending {
+					return comparison
+				}
+
+				return -comparison
+			}
+		}
+
+		return 0
+	})
+
+	ordered := make([]projectedRow, len(sortable))
+
+	for idx, nextRow := range sortable {
+		ordered[idx] = nextRow.projectedRow
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+func mapValue(rawValue, target any) (bool, error) {
+	switch typedTarget := target.(type) {
+	case *graph.Node:
+		if node, typeOK := rawValue.(*graph.Node); typeOK {
+			*typedTarget = *copyNode(node)
+			return true, nil
+		}
+
+	case *graph.Relationship:
+		if relationship, typeOK := rawValue.(*graph.Relationship); typeOK {
+			*typedTarget = *copyRelationship(relationship)
+			return true, nil
+		}
+
+	case *graph.Path:
+		if path, typeOK := rawValue.(*graph.Path); typeOK {
+			*typedTarget = *copyPath(path)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// NewValueMapper returns a graph.ValueMapper for values produced by this driver.
+func NewValueMapper(values []any) graph.ValueMapper {
+	return graph.NewValueMapper(values, mapValue)
+}
+
+// queryResult is a fully materialized result set. Nodes, relationships and paths contained in the result set are
+// copies and may be freely modified by the caller.
+type queryResult struct {
+	ctx  context.Context
+	rows [][]any
+	next int
+	err  error
+}
+
+func newResult(ctx context.Context, rows [][]any) *queryResult {
+	return &queryResult{
+		ctx:  ctx,
+		rows: rows,
+	}
+}
+
+func (s *queryResult) current() []any {
+	if s.next == 0 || s.next > len(s.rows) {
+		return nil
+	}
+
+	return s.rows[s.next-1]
+}
+
+func (s *queryResult) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	if err := s.ctx.Err(); err != nil {
+		s.err = err
+		return false
+	}
+
+	if s.next < len(s.rows) {
+		s.next++
+		return true
+	}
+
+	return false
+}
+
+func (s *queryResult) Values() (graph.ValueMapper, error) {
+	return NewValueMapper(s.current()), nil
+}
+
+func (s *queryResult) Scan(targets ...any) error {
+	return NewValueMapper(s.current()).Scan(targets...)
+}
+
+func (s *queryResult) Error() error {
+	return s.err
+}
+
+func (s *queryResult) Close() {
+	s.rows = nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// graphData is a snapshot of the nodes and relationships of a single graph. Snapshots are copy-on-write: a write
+// transaction clones the snapshot before changing it and entities stored in a snapshot are never modified in place.
+// Instead, a modified entity replaces the stored pointer.
+type graphData struct {
+	nodes         map[graph.ID]*graph.Node
+	relationships map[graph.ID]*graph.Relationship
+	outbound      map[graph.ID][]graph.ID
+	inbound       map[graph.ID][]graph.ID
+}
+
+func newGraphData() *graphData {
+	return &graphData{
+		nodes:         map[graph.ID]*graph.Node{},
+		relationships: map[graph.ID]*graph.Relationship{},
+		outbound:      map[graph.ID][]graph.ID{},
+		inbound:       map[graph.ID][]graph.ID{},
+	}
+}
+
+// clone returns a shallow copy of this snapshot that may be modified without affecting readers of the original.
+// Adjacency slices are shared: appending never changes the elements visible to the original snapshot and removals
+// always allocate a new slice.
+func (s *graphData) clone() *graphData {
+	return &graphData{
+		nodes:         maps.Clone(s.nodes),
+		relationships: maps.Clone(s.relationships),
+		outbound:      maps.Clone(s.outbound),
+		inbound:       maps.Clone(s.inbound),
+	}
+}
+
+// sortedNodeIDs returns the IDs of all nodes in the graph in ascending order so that full scans are deterministic.
+func (s *graphData) sortedNodeIDs() []graph.ID {
+	nodeIDs := make([]graph.ID, 0, len(s.nodes))
+
+	for nodeID := range s.nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	slices.Sort(nodeIDs)
+	return nodeIDs
+}
+
+// sortedRelationshipIDs returns the IDs of all relationships in the graph in ascending order.
+func (s *graphData) sortedRelationshipIDs() []graph.ID {
+	relationshipIDs := make([]graph.ID, 0, len(s.relationships))
+
+	for relationshipID := range s.relationships {
+		relationshipIDs = append(relationshipIDs, relationshipID)
+	}
+
+	slices.Sort(relationshipIDs)
+	return relationshipIDs
+}
+
+// adjacent returns the relationships attached to the given node in the given direction. Relationships that start and
+// end at the given node are returned once when the direction is graph.DirectionBoth.
+func (s *graphData) adjacent(nodeID graph.ID, direction graph.Direction) []*graph.Relationship {
+	var relationships []*graph.Relationship
+
+	if direction == graph.DirectionOutbound || direction == graph.DirectionBoth {
+		for _, relationshipID := range s.outbound[nodeID] {
+			relationships = append(relationships, s.relationships[relationshipID])
+		}
+	}
+
+	if direction == graph.DirectionInbound || direction == graph.DirectionBoth {
+		for _, relationshipID := range s.inbound[nodeID] {
+			if relationship := s.relationships[relationshipID]; direction != graph.DirectionBoth || relationship.StartID != nodeID {
+				relationships = append(relationships, relationship)
+			}
+		}
+	}
+
+	return relationships
+}
+
+func (s *graphData) putNode(node *graph.Node) {
+	s.nodes[node.ID] = node
+}
+
+func (s *graphData) putRelationship(relationship *graph.Relationship) {
+	if _, exists := s.relationships[relationship.ID]; !exists {
+		s.outbound[relationship.StartID] = append(s.outbound[relationship.StartID], relationship.ID)
+		s.inbound[relationship.EndID] = append(s.inbound[relationship.EndID], relationship.ID)
+	}
+
+	s.relationships[relationship.ID] = relationship
+}
+
+func removeID(ids []graph.ID, target graph.ID) []graph.ID {
+	remaining := make([]graph.ID, 0, len(ids))
+
+	for _, id := range ids {
+		if id != target {
+			remaining = append(remaining, id)
+		}
+	}
+
+	return remaining
+}
+
+func (s *graphData) deleteRelationship(relationshipID graph.ID) {
+	if relationship, exists := s.relationships[relationshipID]; exists {
+		delete(s.relationships, relationshipID)
+
+		s.outbound[relationship.StartID] = removeID(s.outbound[relationship.StartID], relationshipID)
+		s.inbound[relationship.EndID] = removeID(s.inbound[relationship.EndID], relationshipID)
+	}
+}
+
+func (s *graphData) hasRelationships(nodeID graph.ID) bool {
+	return len(s.outbound[nodeID]) > 0 || len(s.inbound[nodeID]) > 0
+}
+
+// deleteNode removes the node and all relationships attached to it.
+func (s *graphData) deleteNode(nodeID graph.ID) {
+	for _, relationship := range s.adjacent(nodeID, graph.DirectionBoth) {
+		s.deleteRelationship(relationship.ID)
+	}
+
+	delete(s.nodes, nodeID)
+	delete(s.outbound, nodeID)
+	delete(s.inbound, nodeID)
+}
+
+// storedValue returns a copy of the value as it would be read back from a persistent driver. Slices are copied so that
+// stored properties never share memory with callers. Typed slices are returned as []any since neither neo4j nor pg
+// preserve the element type of list properties.
+func storedValue(value any) any {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil
+
+	case []any:
+		return slices.Clone(typedValue)
+
+	case []byte:
+		return slices.Clone(typedValue)
+	}
+
+	if reflectValue := reflect.ValueOf(value); reflectValue.Kind() != reflect.Slice {
+		return value
+	} else {
+		stored := make([]any, reflectValue.Len())
+
+		for idx := 0; idx < reflectValue.Len(); idx++ {
+			stored[idx] = reflectValue.Index(idx).Interface()
+		}
+
+		return stored
+	}
+}
+
+func copyProperties(properties *graph.Properties) *graph.Properties {
+	copied := &graph.Properties{
+		Map: map[string]any{},
+	}
+
+	if properties != nil {
+		for key, value := range properties.Map {
+			copied.Map[key] = storedValue(value)
+		}
+	}
+
+	return copied
+}
+
+func copyNode(node *graph.Node) *graph.Node {
+	return &graph.Node{
+		ID:         node.ID,
+		Kinds:      node.Kinds.Copy(),
+		Properties: copyProperties(node.Properties),
+	}
+}
+
+func copyRelationship(relationship *graph.Relationship) *graph.Relationship {
+	return &graph.Relationship{
+		ID:         relationship.ID,
+		StartID:    relationship.StartID,
+		EndID:      relationship.EndID,
+		Kind:       relationship.Kind,
+		Properties: copyProperties(relationship.Properties),
+	}
+}
+
+func copyPath(path *graph.Path) *graph.Path {
+	copied := &graph.Path{
+		Nodes: make([]*graph.Node, len(path.Nodes)),
+		Edges: make([]*graph.Relationship, len(path.Edges)),
+	}
+
+	for idx, node := range path.Nodes {
+		copied.Nodes[idx] = copyNode(node)
+	}
+
+	for idx, relationship := range path.Edges {
+		copied.Edges[idx] = copyRelationship(relationship)
+	}
+
+	return copied
+}
@@ -0,0 +1,248 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/specterops/bloodhound/cypher/models/cypher"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+var (
+	ErrReadOnlyTransaction = errors.New("write operation attempted in a read-only transaction")
+)
+
+type transaction struct {
+	ctx         context.Context
+	driver      *Driver
+	graphs      map[string]*graphData
+	dirty       map[string]struct{}
+	targetGraph string
+	writable    bool
+}
+
+func newTransaction(ctx context.Context, driver *Driver, writable bool) *transaction {
+	graphs, defaultGraph := driver.snapshot()
+
+	return &transaction{
+		ctx:         ctx,
+		driver:      driver,
+		graphs:      graphs,
+		dirty:       map[string]struct{}{},
+		targetGraph: defaultGraph,
+		writable:    writable,
+	}
+}
+
+// data returns the current state of the graph targeted by this transaction.
+func (s *transaction) data() *graphData {
+	if data, exists := s.graphs[s.targetGraph]; exists {
+		return data
+	}
+
+	return newGraphData()
+}
+
+// mutableData returns a copy of the graph targeted by this transaction that is owned by this transaction and may be
+// modified.
+func (s *transaction) mutableData() (*graphData, error) {
+	if !s.writable {
+		return nil, ErrReadOnlyTransaction
+	}
+
+	if _, isDirty := s.dirty[s.targetGraph]; !isDirty {
+		if data, exists := s.graphs[s.targetGraph]; exists {
+			s.graphs[s.targetGraph] = data.clone()
+		} else {
+			s.graphs[s.targetGraph] = newGraphData()
+		}
+
+		s.dirty[s.targetGraph] = struct{}{}
+	}
+
+	return s.graphs[s.targetGraph], nil
+}
+
+func (s *transaction) GraphQueryMemoryLimit() size.Size {
+	return s.driver.graphQueryMemoryLimit
+}
+
+func (s *transaction) WithGraph(graphSchema graph.Graph) graph.Transaction {
+	s.targetGraph = graphSchema.Name
+	return s
+}
+
+func (s *transaction) createNode(id graph.ID, properties *graph.Properties, kinds graph.Kinds) (*graph.Node, error) {
+	if data, err := s.mutableData(); err != nil {
+		return nil, err
+	} else {
+		if id == 0 || id == graph.UnregisteredNodeID {
+			id = s.driver.nextNodeID()
+		} else if _, exists := data.nodes[id]; exists {
+			return nil, fmt.Errorf("node with ID %d already exists", id)
+		} else {
+			s.driver.reserveNodeID(id)
+		}
+
+		node := graph.NewNode(id, copyProperties(properties), kinds.Copy()...)
+		data.putNode(node)
+
+		return node, nil
+	}
+}
+
+func (s *transaction) createRelationship(startID, endID graph.ID, kind graph.Kind, properties *graph.Properties) (*graph.Relationship, error) {
+	if data, err := s.mutableData(); err != nil {
+		return nil, err
+	} else if _, exists := data.nodes[startID]; !exists {
+		return nil, fmt.Errorf("start node %d for relationship does not exist", startID)
+	} else if _, exists := data.nodes[endID]; !exists {
+		return nil, fmt.Errorf("end node %d for relationship does not exist", endID)
+	} else {
+		relationship := graph.NewRelationship(s.driver.nextRelationshipID(), startID, endID, copyProperties(properties), kind)
+		data.putRelationship(relationship)
+
+		return relationship, nil
+	}
+}
+
+func (s *transaction) CreateNode(properties *graph.Properties, kinds ...graph.Kind) (*graph.Node, error) {
+	if node, err := s.createNode(0, properties, kinds); err != nil {
+		return nil, err
+	} else {
+		return copyNode(node), nil
+	}
+}
+
+func (s *transaction) UpdateNode(node *graph.Node) error {
+	var (
+		properties       = node.Properties
+		updateStatements []graph.Criteria
+	)
+
+	if addedKinds := node.AddedKinds; len(addedKinds) > 0 {
+		updateStatements = append(updateStatements, query.AddKinds(query.Node(), addedKinds))
+	}
+
+	if deletedKinds := node.DeletedKinds; len(deletedKinds) > 0 {
+		updateStatements = append(updateStatements, cypher.NewUpdatingClause(query.DeleteKinds(query.Node(), deletedKinds)))
+	}
+
+	if modifiedProperties := properties.ModifiedProperties(); len(modifiedProperties) > 0 {
+		updateStatements = append(updateStatements, query.SetProperties(query.Node(), modifiedProperties))
+	}
+
+	if deletedProperties := properties.DeletedProperties(); len(deletedProperties) > 0 {
+		updateStatements = append(updateStatements, query.DeleteProperties(query.Node(), deletedProperties...))
+	}
+
+	if len(updateStatements) == 0 {
+		return nil
+	}
+
+	return s.Nodes().Filter(query.Equals(query.NodeID(), node.ID)).Query(func(results graph.Result) error {
+		return results.Error()
+	}, updateStatements...)
+}
+
+func (s *transaction) Nodes() graph.NodeQuery {
+	return &nodeQuery{
+		liveQuery: newLiveQuery(s.ctx, s),
+	}
+}
+
+func (s *transaction) CreateRelationshipByIDs(startNodeID, endNodeID graph.ID, kind graph.Kind, properties *graph.Properties) (*graph.Relationship, error) {
+	if relationship, err := s.createRelationship(startNodeID, endNodeID, kind, properties); err != nil {
+		return nil, err
+	} else {
+		return copyRelationship(relationship), nil
+	}
+}
+
+func (s *transaction) UpdateRelationship(relationship *graph.Relationship) error {
+	var (
+		properties       = relationship.Properties
+		updateStatements []graph.Criteria
+	)
+
+	if modifiedProperties := properties.ModifiedProperties(); len(modifiedProperties) > 0 {
+		updateStatements = append(updateStatements, query.SetProperties(query.Relationship(), modifiedProperties))
+	}
+
+	if deletedProperties := properties.DeletedProperties(); len(deletedProperties) > 0 {
+		updateStatements = append(updateStatements, query.DeleteProperties(query.Relationship(), deletedProperties...))
+	}
+
+	if len(updateStatements) == 0 {
+		return nil
+	}
+
+	return s.Relationships().Filter(query.Equals(query.RelationshipID(), relationship.ID)).Query(func(results graph.Result) error {
+		return results.Error()
+	}, updateStatements...)
+}
+
+func (s *transaction) Relationships() graph.RelationshipQuery {
+	return &relationshipQuery{
+		liveQuery: newLiveQuery(s.ctx, s),
+	}
+}
+
+// Raw executes the given openCypher query. openCypher is the native query language of this driver so Raw is
+// equivalent to Query.
+func (s *transaction) Raw(query string, parameters map[string]any) graph.Result {
+	return s.Query(query, parameters)
+}
+
+func (s *transaction) Query(query string, parameters map[string]any) graph.Result {
+	if parsedQuery, err := frontend.ParseCypher(frontend.NewContext(), query); err != nil {
+		return graph.NewErrorResult(err)
+	} else {
+		return s.execute(parsedQuery, parameters)
+	}
+}
+
+func (s *transaction) execute(regularQuery *cypher.RegularQuery, parameters map[string]any) graph.Result {
+	if rows, err := newExecutor(s.ctx, s, parameters).execute(regularQuery); err != nil {
+		return graph.NewErrorResult(err)
+	} else {
+		return newResult(s.ctx, rows)
+	}
+}
+
+func (s *transaction) Commit() error {
+	if len(s.dirty) > 0 {
+		committed := make(map[string]*graphData, len(s.dirty))
+
+		for name := range s.dirty {
+			committed[name] = s.graphs[name]
+		}
+
+		s.driver.install(committed)
+
+		// Further changes in this transaction must not modify the committed snapshots
+		s.dirty = map[string]struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,460 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+
+	"github.com/specterops/bloodhound/cypher/models/cypher"
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+func (s *executor) updatingClause(rows []row, clause cypher.Expression) ([]row, error) {
+	updatingClause, isUpdatingClause := clause.(*cypher.UpdatingClause)
+
+	if !isUpdatingClause {
+		return nil, fmt.Errorf("unexpected type for updating clause: %T", clause)
+	}
+
+	data, err := s.tx.mutableData()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch typedClause := updatingClause.Clause.(type) {
+	case *cypher.Create:
+		return s.create(rows, typedClause.Pattern)
+
+	case *cypher.Merge:
+		return s.merge(data, rows, typedClause)
+
+	case *cypher.Set:
+		for _, nextRow := range rows {
+			if err := s.set(data, nextRow, typedClause); err != nil {
+				return nil, err
+			}
+		}
+
+	case *cypher.Remove:
+		for _, nextRow := range rows {
+			if err := s.remove(data, nextRow, typedClause); err != nil {
+				return nil, err
+			}
+		}
+
+	case *cypher.Delete:
+		for _, nextRow := range rows {
+			if err := s.delete(data, nextRow, typedClause); err != nil {
+				return nil, err
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported updating clause type %T", updatingClause.Clause)
+	}
+
+	return rows, nil
+}
+
+func toProperties(values map[string]any) *graph.Properties {
+	properties := &graph.Properties{
+		Map: make(map[string]any, len(values)),
+	}
+
+	for key, value := range values {
+		if value != nil {
+			properties.Map[key] = value
+		}
+	}
+
+	return properties
+}
+
+func (s *executor) createNode(current row, step patternNode) (*graph.Node, error) {
+	if properties, err := s.evaluatePropertyMap(step.pattern.Properties, current); err != nil {
+		return nil, err
+	} else {
+		return s.tx.createNode(0, toProperties(properties), step.pattern.Kinds)
+	}
+}
+
+// createChain creates the nodes and relationships of the given chain that are not already bound in the row.
+func (s *executor) createChain(current row, chain *patternChain) (row, error) {
+	var (
+		nodes         = make([]*graph.Node, len(chain.nodes))
+		relationships = make([]*graph.Relationship, 0, len(chain.relationships))
+	)
+
+	for idx, step := range chain.nodes {
+		if boundValue, isBound := current[step.symbol]; step.symbol != "" && isBound {
+			if boundNode, isNode := boundValue.(*graph.Node); !isNode {
+				return nil, fmt.Errorf("variable %s is not bound to a node", step.symbol)
+			} else {
+				nodes[idx] = boundNode
+			}
+		} else if node, err := s.createNode(current, step); err != nil {
+			return nil, err
+		} else {
+			nodes[idx] = node
+
+			if step.symbol != "" {
+				current = current.with(step.symbol, node)
+			}
+		}
+	}
+
+	for idx, step := range chain.relationships {
+		if len(step.pattern.Kinds) != 1 {
+			return nil, fmt.Errorf("relationships must be created with exactly one kind")
+		} else if step.variableLength {
+			return nil, fmt.Errorf("variable length relationships can not be created")
+		}
+
+		startID, endID := nodes[idx].ID, nodes[idx+1].ID
+
+		switch step.direction {
+		case graph.DirectionInbound:
+			startID, endID = endID, startID
+		case graph.DirectionBoth:
+			return nil, fmt.Errorf("relationships must be created with a direction")
+		}
+
+		if properties, err := s.evaluatePropertyMap(step.pattern.Properties, current); err != nil {
+			return nil, err
+		} else if relationship, err := s.tx.createRelationship(startID, endID, step.pattern.Kinds[0], toProperties(properties)); err != nil {
+			return nil, err
+		} else {
+			relationships = append(relationships, relationship)
+
+			if step.symbol != "" {
+				current = current.with(step.symbol, relationship)
+			}
+		}
+	}
+
+	if symbol := bindingSymbol(chain.part.Binding); symbol != "" {
+		current = current.with(symbol, &graph.Path{
+			Nodes: nodes,
+			Edges: relationships,
+		})
+	}
+
+	return current, nil
+}
+
+func (s *executor) create(rows []row, pattern []*cypher.PatternPart) ([]row, error) {
+	chains, err := newPatternChains(pattern)
+
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]row, 0, len(rows))
+
+	for _, nextRow := range rows {
+		for _, chain := range chains {
+			if nextRow, err = s.createChain(nextRow, chain); err != nil {
+				return nil, err
+			}
+		}
+
+		created = append(created, nextRow)
+	}
+
+	return created, nil
+}
+
+func (s *executor) merge(data *graphData, rows []row, merge *cypher.Merge) ([]row, error) {
+	chain, err := newPatternChain(merge.PatternPart)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		symbols = patternSymbols([]*patternChain{chain})
+		merged  []row
+	)
+
+	for _, current := range rows {
+		var matched []row
+
+		if err := s.newMatcher(symbols).matchChain(current, chain, func(next row) error {
+			matched = append(matched, next)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		isCreated := len(matched) == 0
+
+		if isCreated {
+			if created, err := s.createChain(current, chain); err != nil {
+				return nil, err
+			} else {
+				matched = []row{created}
+			}
+		}
+
+		for _, action := range merge.MergeActions {
+			if action.Set == nil || (action.OnCreate && !isCreated) || (action.OnMatch && isCreated) {
+				continue
+			}
+
+			for _, nextRow := range matched {
+				if err := s.set(data, nextRow, action.Set); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		merged = append(merged, matched...)
+	}
+
+	return merged, nil
+}
+
+// updateEntity replaces the current version of the given node or relationship with a modified copy.
+func (s *executor) updateEntity(data *graphData, entity any, delegate func(properties *graph.Properties, kinds *graph.Kinds) error) error {
+	switch typedEntity := entity.(type) {
+	case nil:
+		return nil
+
+	case *graph.Node:
+		if current, exists := data.nodes[typedEntity.ID]; !exists {
+			return nil
+		} else {
+			updated := copyNode(current)
+
+			if err := delegate(updated.Properties, &updated.Kinds); err != nil {
+				return err
+			}
+
+			data.putNode(updated)
+		}
+
+	case *graph.Relationship:
+		if current, exists := data.relationships[typedEntity.ID]; !exists {
+			return nil
+		} else {
+			updated := copyRelationship(current)
+
+			if err := delegate(updated.Properties, nil); err != nil {
+				return err
+			}
+
+			data.putRelationship(updated)
+		}
+
+	default:
+		return fmt.Errorf("expected a node or relationship but received %T", entity)
+	}
+
+	return nil
+}
+
+func setProperty(properties *graph.Properties, key string, value any) {
+	if value == nil {
+		delete(properties.Map, key)
+	} else {
+		properties.Map[key] = value
+	}
+}
+
+func (s *executor) set(data *graphData, current row, set *cypher.Set) error {
+	for _, item := range set.Items {
+		switch item.Operator {
+		case cypher.OperatorLabelAssignment:
+			kinds, isKinds := item.Right.(graph.Kinds)
+
+			if !isKinds {
+				return fmt.Errorf("expected kinds for label assignment but received %T", item.Right)
+			}
+
+			if entity, err := s.evaluate(item.Left, current); err != nil {
+				return err
+			} else if err := s.updateEntity(data, entity, func(properties *graph.Properties, entityKinds *graph.Kinds) error {
+				if entityKinds == nil {
+					return fmt.Errorf("labels may only be assigned to nodes")
+				}
+
+				for _, kind := range kinds {
+					if !entityKinds.ContainsOneOf(kind) {
+						*entityKinds = append(*entityKinds, kind)
+					}
+				}
+
+				return nil
+			}); err != nil {
+				return err
+			}
+
+		case cypher.OperatorAssignment, cypher.OperatorAdditionAssignment:
+			value, err := s.evaluate(item.Right, current)
+
+			if err != nil {
+				return err
+			}
+
+			if propertyLookup, isPropertyLookup := item.Left.(*cypher.PropertyLookup); isPropertyLookup {
+				if item.Operator != cypher.OperatorAssignment || len(propertyLookup.Symbols) != 1 {
+					return fmt.Errorf("unsupported property assignment")
+				}
+
+				if entity, err := s.evaluate(propertyLookup.Atom, current); err != nil {
+					return err
+				} else if err := s.updateEntity(data, entity, func(properties *graph.Properties, kinds *graph.Kinds) error {
+					setProperty(properties, propertyLookup.Symbols[0], value)
+					return nil
+				}); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			var values map[string]any
+
+			switch typedValue := value.(type) {
+			case nil:
+			case map[string]any:
+				values = typedValue
+			case *graph.Node, *graph.Relationship:
+				if values, err = s.evaluateProperties(typedValue); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("expected a map of properties but received %T", value)
+			}
+
+			if entity, err := s.evaluate(item.Left, current); err != nil {
+				return err
+			} else if err := s.updateEntity(data, entity, func(properties *graph.Properties, kinds *graph.Kinds) error {
+				if item.Operator == cypher.OperatorAssignment {
+					clear(properties.Map)
+				}
+
+				for key, propertyValue := range values {
+					setProperty(properties, key, propertyValue)
+				}
+
+				return nil
+			}); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unsupported set operator %s", item.Operator)
+		}
+	}
+
+	return nil
+}
+
+func (s *executor) remove(data *graphData, current row, remove *cypher.Remove) error {
+	for _, item := range remove.Items {
+		switch {
+		case item.KindMatcher != nil:
+			if entity, err := s.evaluate(item.KindMatcher.Reference, current); err != nil {
+				return err
+			} else if err := s.updateEntity(data, entity, func(properties *graph.Properties, kinds *graph.Kinds) error {
+				if kinds == nil {
+					return fmt.Errorf("labels may only be removed from nodes")
+				}
+
+				*kinds = kinds.Exclude(item.KindMatcher.Kinds)
+				return nil
+			}); err != nil {
+				return err
+			}
+
+		case item.Property != nil:
+			if len(item.Property.Symbols) != 1 {
+				return fmt.Errorf("unsupported property removal")
+			}
+
+			if entity, err := s.evaluate(item.Property.Atom, current); err != nil {
+				return err
+			} else if err := s.updateEntity(data, entity, func(properties *graph.Properties, kinds *graph.Kinds) error {
+				delete(properties.Map, item.Property.Symbols[0])
+				return nil
+			}); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("remove item is empty")
+		}
+	}
+
+	return nil
+}
+
+func (s *executor) deleteValue(data *graphData, value any, detach bool) error {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil
+
+	case *graph.Node:
+		if _, exists := data.nodes[typedValue.ID]; !exists {
+			return nil
+		} else if !detach && data.hasRelationships(typedValue.ID) {
+			return fmt.Errorf("node %d still has relationships and can not be deleted without DETACH", typedValue.ID)
+		}
+
+		data.deleteNode(typedValue.ID)
+
+	case *graph.Relationship:
+		data.deleteRelationship(typedValue.ID)
+
+	case *graph.Path:
+		for _, relationship := range typedValue.Edges {
+			data.deleteRelationship(relationship.ID)
+		}
+
+		for _, node := range typedValue.Nodes {
+			if err := s.deleteValue(data, node, detach); err != nil {
+				return err
+			}
+		}
+
+	case []any:
+		for _, element := range typedValue {
+			if err := s.deleteValue(data, element, detach); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unable to delete value of type %T", value)
+	}
+
+	return nil
+}
+
+func (s *executor) delete(data *graphData, current row, deleteClause *cypher.Delete) error {
+	for _, expression := range deleteClause.Expressions {
+		if value, err := s.evaluate(expression, current); err != nil {
+			return err
+		} else if err := s.deleteValue(data, value, deleteClause.Detach); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
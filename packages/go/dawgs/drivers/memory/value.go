@@ -0,0 +1,461 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// normalizeValue converts the given value into one of the types the query executor operates on: nil, bool, int64,
+// float64, string, time.Time, []any, map[string]any, *graph.Node, *graph.Relationship or *graph.Path.
+func normalizeValue(value any) any {
+	switch typedValue := value.(type) {
+	case nil, bool, int64, float64, string, time.Time, *graph.Node, *graph.Relationship, *graph.Path:
+		return value
+
+	case int:
+		return int64(typedValue)
+	case int8:
+		return int64(typedValue)
+	case int16:
+		return int64(typedValue)
+	case int32:
+		return int64(typedValue)
+	case uint:
+		return int64(typedValue)
+	case uint8:
+		return int64(typedValue)
+	case uint16:
+		return int64(typedValue)
+	case uint32:
+		return int64(typedValue)
+	case uint64:
+		return int64(typedValue)
+	case float32:
+		return float64(typedValue)
+	case graph.ID:
+		return typedValue.Int64()
+	case graph.Kind:
+		return typedValue.String()
+
+	case []any:
+		normalized := make([]any, len(typedValue))
+
+		for idx, element := range typedValue {
+			normalized[idx] = normalizeValue(element)
+		}
+
+		return normalized
+
+	case map[string]any:
+		normalized := make(map[string]any, len(typedValue))
+
+		for key, element := range typedValue {
+			normalized[key] = normalizeValue(element)
+		}
+
+		return normalized
+
+	case *graph.Properties:
+		if typedValue == nil {
+			return nil
+		}
+
+		return normalizeValue(typedValue.Map)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	switch reflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		if reflectValue.Kind() == reflect.Slice && reflectValue.IsNil() {
+			return nil
+		}
+
+		normalized := make([]any, reflectValue.Len())
+
+		for idx := 0; idx < reflectValue.Len(); idx++ {
+			normalized[idx] = normalizeValue(reflectValue.Index(idx).Interface())
+		}
+
+		return normalized
+
+	case reflect.Map:
+		if reflectValue.Type().Key().Kind() != reflect.String {
+			return value
+		}
+
+		normalized := make(map[string]any, reflectValue.Len())
+
+		for iter := reflectValue.MapRange(); iter.Next(); {
+			normalized[iter.Key().String()] = normalizeValue(iter.Value().Interface())
+		}
+
+		return normalized
+
+	case reflect.String:
+		return reflectValue.String()
+
+	case reflect.Pointer:
+		if reflectValue.IsNil() {
+			return nil
+		}
+
+		return normalizeValue(reflectValue.Elem().Interface())
+	}
+
+	return value
+}
+
+func asFloat64(value any) (float64, bool) {
+	switch typedValue := value.(type) {
+	case int64:
+		return float64(typedValue), true
+	case float64:
+		return typedValue, true
+	default:
+		return 0, false
+	}
+}
+
+func asTime(value any) (time.Time, bool) {
+	switch typedValue := value.(type) {
+	case time.Time:
+		return typedValue, true
+
+	case string:
+		if parsedTime, err := time.Parse(time.RFC3339Nano, typedValue); err == nil {
+			return parsedTime, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// compareValues orders two non-null values. The returned boolean is false if the values are not comparable.
+func compareValues(left, right any) (int, bool) {
+	if leftNumber, isNumber := asFloat64(left); isNumber {
+		if rightNumber, isNumber := asFloat64(right); isNumber {
+			// Compare integers directly to avoid losing precision for large values
+			if leftInt, isInt := left.(int64); isInt {
+				if rightInt, isInt := right.(int64); isInt {
+					return compareOrdered(leftInt, rightInt), true
+				}
+			}
+
+			return compareOrdered(leftNumber, rightNumber), true
+		}
+
+		return 0, false
+	}
+
+	switch typedLeft := left.(type) {
+	case string:
+		if typedRight, typeOK := right.(string); typeOK {
+			return strings.Compare(typedLeft, typedRight), true
+		} else if rightTime, typeOK := right.(time.Time); typeOK {
+			if leftTime, isTime := asTime(typedLeft); isTime {
+				return leftTime.Compare(rightTime), true
+			}
+		}
+
+	case time.Time:
+		if rightTime, isTime := asTime(right); isTime {
+			return typedLeft.Compare(rightTime), true
+		}
+
+	case bool:
+		if typedRight, typeOK := right.(bool); typeOK {
+			switch {
+			case typedLeft == typedRight:
+				return 0, true
+			case typedRight:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+
+	case *graph.Node:
+		if typedRight, typeOK := right.(*graph.Node); typeOK {
+			return compareOrdered(typedLeft.ID, typedRight.ID), true
+		}
+
+	case *graph.Relationship:
+		if typedRight, typeOK := right.(*graph.Relationship); typeOK {
+			return compareOrdered(typedLeft.ID, typedRight.ID), true
+		}
+
+	case []any:
+		if typedRight, typeOK := right.([]any); typeOK {
+			for idx := 0; idx < len(typedLeft) && idx < len(typedRight); idx++ {
+				if typedLeft[idx] == nil || typedRight[idx] == nil {
+					return 0, false
+				} else if comparison, comparable := compareValues(typedLeft[idx], typedRight[idx]); !comparable {
+					return 0, false
+				} else if comparison != 0 {
+					return comparison, true
+				}
+			}
+
+			return compareOrdered(len(typedLeft), len(typedRight)), true
+		}
+	}
+
+	return 0, false
+}
+
+func compareOrdered[T int | int64 | float64 | graph.ID](left, right T) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// equalValues tests two values for equality using three-valued logic. A nil return value represents null.
+func equalValues(left, right any) any {
+	if left == nil || right == nil {
+		return nil
+	}
+
+	switch typedLeft := left.(type) {
+	case []any:
+		typedRight, typeOK := right.([]any)
+
+		if !typeOK || len(typedLeft) != len(typedRight) {
+			return false
+		}
+
+		var result any = true
+
+		for idx := range typedLeft {
+			switch equalValues(typedLeft[idx], typedRight[idx]) {
+			case false:
+				return false
+			case nil:
+				result = nil
+			}
+		}
+
+		return result
+
+	case map[string]any:
+		typedRight, typeOK := right.(map[string]any)
+
+		if !typeOK || len(typedLeft) != len(typedRight) {
+			return false
+		}
+
+		var result any = true
+
+		for key, leftValue := range typedLeft {
+			if rightValue, exists := typedRight[key]; !exists {
+				return false
+			} else {
+				switch equalValues(leftValue, rightValue) {
+				case false:
+					return false
+				case nil:
+					result = nil
+				}
+			}
+		}
+
+		return result
+
+	case *graph.Path:
+		typedRight, typeOK := right.(*graph.Path)
+
+		if !typeOK || len(typedLeft.Nodes) != len(typedRight.Nodes) || len(typedLeft.Edges) != len(typedRight.Edges) {
+			return false
+		}
+
+		for idx := range typedLeft.Nodes {
+			if typedLeft.Nodes[idx].ID != typedRight.Nodes[idx].ID {
+				return false
+			}
+		}
+
+		for idx := range typedLeft.Edges {
+			if typedLeft.Edges[idx].ID != typedRight.Edges[idx].ID {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if comparison, comparable := compareValues(left, right); comparable {
+		return comparison == 0
+	}
+
+	return false
+}
+
+// valueKey renders a value into a string that is equal for equal values. It is used for grouping and DISTINCT.
+func valueKey(value any) string {
+	builder := strings.Builder{}
+	writeValueKey(&builder, value)
+
+	return builder.String()
+}
+
+func writeValueKey(builder *strings.Builder, value any) {
+	switch typedValue := value.(type) {
+	case nil:
+		builder.WriteString("null")
+
+	case int64:
+		// Integers and floats with the same value must group together
+		builder.WriteString(fmt.Sprintf("n:%v", float64(typedValue)))
+
+	case float64:
+		builder.WriteString(fmt.Sprintf("n:%v", typedValue))
+
+	case string:
+		builder.WriteString(fmt.Sprintf("s:%q", typedValue))
+
+	case time.Time:
+		builder.WriteString("t:")
+		builder.WriteString(typedValue.UTC().Format(time.RFC3339Nano))
+
+	case *graph.Node:
+		builder.WriteString(fmt.Sprintf("node:%d", typedValue.ID))
+
+	case *graph.Relationship:
+		builder.WriteString(fmt.Sprintf("rel:%d", typedValue.ID))
+
+	case *graph.Path:
+		builder.WriteString("path:")
+
+		for _, node := range typedValue.Nodes {
+			builder.WriteString(fmt.Sprintf("%d,", node.ID))
+		}
+
+		builder.WriteString(";")
+
+		for _, relationship := range typedValue.Edges {
+			builder.WriteString(fmt.Sprintf("%d,", relationship.ID))
+		}
+
+	case []any:
+		builder.WriteString("[")
+
+		for _, element := range typedValue {
+			writeValueKey(builder, element)
+			builder.WriteString(",")
+		}
+
+		builder.WriteString("]")
+
+	case map[string]any:
+		builder.WriteString("{")
+
+		for _, key := range sortedKeys(typedValue) {
+			builder.WriteString(fmt.Sprintf("%q:", key))
+			writeValueKey(builder, typedValue[key])
+			builder.WriteString(",")
+		}
+
+		builder.WriteString("}")
+
+	default:
+		builder.WriteString(fmt.Sprintf("%T:%v", value, value))
+	}
+}
+
+// orderValues orders values for ORDER BY. Unlike compareValues, all values are orderable: nulls sort after all other
+// values and values of different types are ordered by type.
+func orderValues(left, right any) int {
+	if left == nil || right == nil {
+		switch {
+		case left == nil && right == nil:
+			return 0
+		case left == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	if comparison, comparable := compareValues(left, right); comparable {
+		return comparison
+	}
+
+	return strings.Compare(typeOrderKey(left), typeOrderKey(right))
+}
+
+func typeOrderKey(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "0"
+	case *graph.Node:
+		return "1"
+	case *graph.Relationship:
+		return "2"
+	case []any:
+		return "3"
+	case *graph.Path:
+		return "4"
+	case string:
+		return "5"
+	case bool:
+		return "6"
+	case int64, float64:
+		return "7"
+	default:
+		return "8" + fmt.Sprintf("%T", value)
+	}
+}
+
+// truthValue interprets a value as a boolean using three-valued logic. A nil return value represents null.
+func truthValue(value any) (any, error) {
+	switch typedValue := value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return typedValue, nil
+	default:
+		return nil, fmt.Errorf("expected a boolean value but received %T", value)
+	}
+}
+
+func isTrue(value any) bool {
+	typedValue, typeOK := value.(bool)
+	return typeOK && typedValue
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
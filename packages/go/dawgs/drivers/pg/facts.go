@@ -35,4 +35,5 @@ func (s SQLState) ErrorMatches(err error) bool {
 
 const (
 	StateObjectDoesNotExist SQLState = "42704"
+	StateDeadlockDetected   SQLState = "40P01"
 )
@@ -0,0 +1,61 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueMapper_DecodeMapColumns(t *testing.T) {
+	var (
+		kindMapper = newKindMapper()
+		nodeMap    = map[string]any{
+			"id":         int64(1),
+			"kind_ids":   []any{int16(1)},
+			"properties": map[string]any{"name": "node"},
+		}
+		pathMap = map[string]any{
+			"nodes": []any{nodeMap},
+			"edges": []any{},
+		}
+		propertiesMap = map[string]any{"name": "node", "enabled": true}
+		literalMap    = map[string]any{"a": int64(1)}
+	)
+
+	decoded, err := NewValueMapper(context.Background(), []any{propertiesMap, literalMap, map[string]any{}, nodeMap, pathMap}, kindMapper).Decode()
+	require.Nil(t, err)
+	require.Len(t, decoded, 5)
+
+	// Map values that are not graph entities must decode as maps rather than empty paths
+	require.Equal(t, propertiesMap, decoded[0])
+	require.Equal(t, literalMap, decoded[1])
+	require.Equal(t, map[string]any{}, decoded[2])
+
+	node, isNode := decoded[3].(*graph.Node)
+	require.True(t, isNode)
+	require.Equal(t, graph.ID(1), node.ID)
+	require.Equal(t, graph.Kinds{NodeKind1}, node.Kinds)
+
+	path, isPath := decoded[4].(*graph.Path)
+	require.True(t, isPath)
+	require.Len(t, path.Nodes, 1)
+	require.Empty(t, path.Edges)
+}
@@ -19,11 +19,17 @@ package pg
 import (
 	"context"
 
+	"github.com/specterops/bloodhound/cypher/models/pgsql"
 	"github.com/specterops/bloodhound/cypher/models/pgsql/translate"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/query"
 )
 
+// graphScopedTransaction is implemented by transactions that scope translated queries to the partitions of a graph
+type graphScopedTransaction interface {
+	graphTableRewrites() (map[pgsql.Identifier]pgsql.Identifier, error)
+}
+
 type liveQuery struct {
 	ctx          context.Context
 	tx           graph.Transaction
@@ -40,10 +46,20 @@ func newLiveQuery(ctx context.Context, tx graph.Transaction, kindMapper KindMapp
 	}
 }
 
+func (s *liveQuery) tableRewrites() (map[pgsql.Identifier]pgsql.Identifier, error) {
+	if scopedTx, isScoped := s.tx.(graphScopedTransaction); isScoped {
+		return scopedTx.graphTableRewrites()
+	}
+
+	return nil, nil
+}
+
 func (s *liveQuery) runRegularQuery(allShortestPaths bool) graph.Result {
 	if regularQuery, err := s.queryBuilder.Build(allShortestPaths); err != nil {
 		return graph.NewErrorResult(err)
-	} else if translation, err := translate.FromCypher(s.ctx, regularQuery, s.kindMapper, false); err != nil {
+	} else if tableRewrites, err := s.tableRewrites(); err != nil {
+		return graph.NewErrorResult(err)
+	} else if translation, err := translate.FromCypherWithTableRewrites(s.ctx, regularQuery, s.kindMapper, false, tableRewrites); err != nil {
 		return graph.NewErrorResult(err)
 	} else {
 		return s.tx.Raw(translation.Statement, translation.Parameters)
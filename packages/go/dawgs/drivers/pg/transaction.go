@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/specterops/bloodhound/cypher/models/cypher"
 	"github.com/specterops/bloodhound/cypher/models/pgsql"
 	"github.com/specterops/bloodhound/cypher/models/pgsql/translate"
+	"github.com/specterops/bloodhound/cypher/models/walk"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -128,6 +130,25 @@ func (s *transaction) getTargetGraph() (model.Graph, error) {
 	return s.schemaManager.AssertGraph(s, s.targetSchema)
 }
 
+// graphTableRewrites returns the table rewrites that scope translated queries to the partitions of the target graph of
+// this transaction. Without a target graph, translated queries read from the parent tables of every graph.
+func (s *transaction) graphTableRewrites() (map[pgsql.Identifier]pgsql.Identifier, error) {
+	if !s.targetSchemaSet {
+		if _, hasDefaultGraph := s.schemaManager.DefaultGraph(); !hasDefaultGraph {
+			return nil, nil
+		}
+	}
+
+	if graphTarget, err := s.getTargetGraph(); err != nil {
+		return nil, err
+	} else {
+		return map[pgsql.Identifier]pgsql.Identifier{
+			pgsql.TableNode: pgsql.Identifier(model.NodePartitionTableName(graphTarget.ID)),
+			pgsql.TableEdge: pgsql.Identifier(model.EdgePartitionTableName(graphTarget.ID)),
+		}, nil
+	}
+}
+
 func (s *transaction) CreateNode(properties *graph.Properties, kinds ...graph.Kind) (*graph.Node, error) {
 	if graphTarget, err := s.getTargetGraph(); err != nil {
 		return nil, err
@@ -277,15 +298,51 @@ func (s *transaction) query(query string, parameters map[string]any) (pgx.Rows,
 	return s.driver().Query(s.ctx, query, queryArgs...)
 }
 
-func (s *transaction) Query(query string, parameters map[string]any) graph.Result {
+// bindCypherParameters sets the value of each parameter referenced in the given openCypher query from the given
+// parameter map. Parameters without a value in the map are left unbound.
+func bindCypherParameters(cypherQuery *cypher.RegularQuery, parameters map[string]any) error {
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	return walk.WalkCypher(cypherQuery, walk.NewSimpleVisitor[cypher.SyntaxNode](func(node cypher.SyntaxNode, errorHandler walk.CancelableErrorHandler) {
+		switch typedNode := node.(type) {
+		case *cypher.Parameter:
+			if value, hasValue := parameters[typedNode.Symbol]; hasValue {
+				typedNode.Value = value
+			}
+		}
+	}))
+}
+
+// CypherTranslator is implemented by transactions that translate openCypher queries to SQL before execution.
+type CypherTranslator interface {
+	// TranslateCypher translates the given openCypher query to SQL without executing it. The returned parameter map
+	// contains the named arguments referenced by the translated SQL.
+	TranslateCypher(query string, parameters map[string]any) (string, map[string]any, error)
+}
+
+func (s *transaction) TranslateCypher(query string, parameters map[string]any) (string, map[string]any, error) {
 	if parsedQuery, err := frontend.ParseCypher(frontend.NewContext(), query); err != nil {
-		return graph.NewErrorResult(err)
-	} else if translated, err := translate.Translate(s.ctx, parsedQuery, s.schemaManager, parameters); err != nil {
-		return graph.NewErrorResult(err)
+		return "", nil, err
+	} else if err := bindCypherParameters(parsedQuery, parameters); err != nil {
+		return "", nil, err
+	} else if tableRewrites, err := s.graphTableRewrites(); err != nil {
+		return "", nil, err
+	} else if translated, err := translate.TranslateWithTableRewrites(s.ctx, parsedQuery, s.schemaManager, nil, tableRewrites); err != nil {
+		return "", nil, err
 	} else if sqlQuery, err := translate.Translated(translated); err != nil {
+		return "", nil, err
+	} else {
+		return sqlQuery, translated.Parameters, nil
+	}
+}
+
+func (s *transaction) Query(query string, parameters map[string]any) graph.Result {
+	if sqlQuery, sqlParameters, err := s.TranslateCypher(query, parameters); err != nil {
 		return graph.NewErrorResult(err)
 	} else {
-		return s.Raw(sqlQuery, translated.Parameters)
+		return s.Raw(sqlQuery, sqlParameters)
 	}
 }
 
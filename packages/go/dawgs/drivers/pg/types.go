@@ -230,46 +230,47 @@ func (s *pathComposite) TryMap(compositeMap map[string]any) bool {
 }
 
 func (s *pathComposite) FromMap(compositeMap map[string]any) error {
-	if rawNodes, hasNodes := compositeMap["nodes"]; hasNodes {
-		if typedRawNodes, typeOK := rawNodes.([]any); !typeOK {
-			return fmt.Errorf("")
-		} else {
-			for _, rawNode := range typedRawNodes {
-				switch typedNode := rawNode.(type) {
-				case map[string]any:
-					var node nodeComposite
-
-					if err := node.FromMap(typedNode); err != nil {
-						return err
-					}
-
-					s.Nodes = append(s.Nodes, node)
-
-				default:
-					return fmt.Errorf("unexpected type for raw node: %T", rawNode)
+	// Both keys are required, otherwise any map value, such as the properties of a node, would map to an empty path
+	if rawNodes, hasNodes := compositeMap["nodes"]; !hasNodes {
+		return fmt.Errorf("composite map does not contain expected key nodes")
+	} else if typedRawNodes, typeOK := rawNodes.([]any); !typeOK {
+		return fmt.Errorf("unexpected type for raw nodes: %T", rawNodes)
+	} else {
+		for _, rawNode := range typedRawNodes {
+			switch typedNode := rawNode.(type) {
+			case map[string]any:
+				var node nodeComposite
+
+				if err := node.FromMap(typedNode); err != nil {
+					return err
 				}
+
+				s.Nodes = append(s.Nodes, node)
+
+			default:
+				return fmt.Errorf("unexpected type for raw node: %T", rawNode)
 			}
 		}
 	}
 
-	if rawEdges, hasEdges := compositeMap["edges"]; hasEdges {
-		if typedRawEdges, typeOK := rawEdges.([]any); !typeOK {
-			return fmt.Errorf("")
-		} else {
-			for _, rawEdge := range typedRawEdges {
-				switch typedNode := rawEdge.(type) {
-				case map[string]any:
-					var edge edgeComposite
+	if rawEdges, hasEdges := compositeMap["edges"]; !hasEdges {
+		return fmt.Errorf("composite map does not contain expected key edges")
+	} else if typedRawEdges, typeOK := rawEdges.([]any); !typeOK {
+		return fmt.Errorf("unexpected type for raw edges: %T", rawEdges)
+	} else {
+		for _, rawEdge := range typedRawEdges {
+			switch typedNode := rawEdge.(type) {
+			case map[string]any:
+				var edge edgeComposite
 
-					if err := edge.FromMap(typedNode); err != nil {
-						return err
-					}
+				if err := edge.FromMap(typedNode); err != nil {
+					return err
+				}
 
-					s.Edges = append(s.Edges, edge)
+				s.Edges = append(s.Edges, edge)
 
-				default:
-					return fmt.Errorf("unexpected type for raw edge: %T", rawEdge)
-				}
+			default:
+				return fmt.Errorf("unexpected type for raw edge: %T", rawEdge)
 			}
 		}
 	}
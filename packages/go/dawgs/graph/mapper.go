@@ -466,6 +466,69 @@ func (s *valueMapper) MapOptions(targets ...any) (any, error) {
 	}
 }
 
+func (s *valueMapper) decodeValue(rawValue any) (any, error) {
+	var (
+		relationship Relationship
+		node         Node
+		path         Path
+	)
+
+	for _, target := range []any{&relationship, &node, &path} {
+		for _, mapperFunc := range s.mapperFuncs {
+			if mapped, _ := mapperFunc(rawValue, target); mapped {
+				return target, nil
+			}
+		}
+	}
+
+	switch typedRawValue := rawValue.(type) {
+	case []any:
+		decodedValues := make([]any, len(typedRawValue))
+
+		for idx, nextRawValue := range typedRawValue {
+			if decodedValue, err := s.decodeValue(nextRawValue); err != nil {
+				return nil, err
+			} else {
+				decodedValues[idx] = decodedValue
+			}
+		}
+
+		return decodedValues, nil
+
+	case map[string]any:
+		decodedValues := make(map[string]any, len(typedRawValue))
+
+		for key, nextRawValue := range typedRawValue {
+			if decodedValue, err := s.decodeValue(nextRawValue); err != nil {
+				return nil, err
+			} else {
+				decodedValues[key] = decodedValue
+			}
+		}
+
+		return decodedValues, nil
+
+	default:
+		return rawValue, nil
+	}
+}
+
+func (s *valueMapper) Decode() ([]any, error) {
+	var decodedValues []any
+
+	for s.idx < len(s.values) {
+		if rawValue, err := s.Next(); err != nil {
+			return nil, err
+		} else if decodedValue, err := s.decodeValue(rawValue); err != nil {
+			return nil, err
+		} else {
+			decodedValues = append(decodedValues, decodedValue)
+		}
+	}
+
+	return decodedValues, nil
+}
+
 func (s *valueMapper) Scan(targets ...any) error {
 	for idx, mapValue := range targets {
 		if err := s.Map(mapValue); err != nil {
@@ -0,0 +1,58 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+type testNodeValue struct {
+	ID graph.ID
+}
+
+func mapTestNode(rawValue, target any) (bool, error) {
+	if typedTarget, isNodeTarget := target.(*graph.Node); isNodeTarget {
+		if nodeValue, isNodeValue := rawValue.(testNodeValue); isNodeValue {
+			typedTarget.ID = nodeValue.ID
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func TestValueMapper_Decode(t *testing.T) {
+	values, err := graph.NewValueMapper([]any{
+		"name",
+		int64(1),
+		testNodeValue{ID: 1},
+		[]any{testNodeValue{ID: 2}, "other"},
+		map[string]any{"node": testNodeValue{ID: 3}},
+	}, mapTestNode).Decode()
+
+	require.Nil(t, err)
+	require.Equal(t, []any{
+		"name",
+		int64(1),
+		&graph.Node{ID: 1},
+		[]any{&graph.Node{ID: 2}, "other"},
+		map[string]any{"node": &graph.Node{ID: 3}},
+	}, values)
+}
@@ -50,6 +50,21 @@ func (m *MockValueMapper) EXPECT() *MockValueMapperMockRecorder {
 	return m.recorder
 }
 
+// Decode mocks base method.
+func (m *MockValueMapper) Decode() ([]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decode")
+	ret0, _ := ret[0].([]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decode indicates an expected call of Decode.
+func (mr *MockValueMapperMockRecorder) Decode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decode", reflect.TypeOf((*MockValueMapper)(nil).Decode))
+}
+
 // Map mocks base method.
 func (m *MockValueMapper) Map(target any) error {
 	m.ctrl.T.Helper()
@@ -23,6 +23,11 @@ type ValueMapper interface {
 	Map(target any) error
 	MapOptions(target ...any) (any, error)
 	Scan(targets ...any) error
+
+	// Decode consumes all remaining values and converts them to their graph representation. Nodes, relationships and
+	// paths are returned as *Node, *Relationship and *Path, lists and maps are decoded element-wise and all other values
+	// are returned as-is.
+	Decode() ([]any, error)
 }
 
 type Scanner interface {
@@ -131,6 +131,30 @@ func (s *DatabaseSwitch) retireInternalContext(ctx context.Context) {
 	}
 }
 
+// scopeTransactionDelegate wraps the given delegate so that it receives a transaction scoped to the graph target of the
+// given context, if any
+func scopeTransactionDelegate(ctx context.Context, txDelegate TransactionDelegate) TransactionDelegate {
+	if target, hasTarget := GraphTargetFromContext(ctx); hasTarget {
+		return func(tx Transaction) error {
+			return txDelegate(tx.WithGraph(target))
+		}
+	}
+
+	return txDelegate
+}
+
+// scopeBatchDelegate wraps the given delegate so that it receives a batch scoped to the graph target of the given
+// context, if any
+func scopeBatchDelegate(ctx context.Context, batchDelegate BatchDelegate) BatchDelegate {
+	if target, hasTarget := GraphTargetFromContext(ctx); hasTarget {
+		return func(batch Batch) error {
+			return batchDelegate(batch.WithGraph(target))
+		}
+	}
+
+	return batchDelegate
+}
+
 func (s *DatabaseSwitch) ReadTransaction(ctx context.Context, txDelegate TransactionDelegate, options ...TransactionOption) error {
 	if internalCtx, err := s.newInternalContext(ctx); err != nil {
 		return err
@@ -140,7 +164,7 @@ func (s *DatabaseSwitch) ReadTransaction(ctx context.Context, txDelegate Transac
 		s.currentDBLock.RLock()
 		defer s.currentDBLock.RUnlock()
 
-		return s.currentDB.ReadTransaction(internalCtx, txDelegate, options...)
+		return s.currentDB.ReadTransaction(internalCtx, scopeTransactionDelegate(ctx, txDelegate), options...)
 	}
 }
 
@@ -153,7 +177,7 @@ func (s *DatabaseSwitch) WriteTransaction(ctx context.Context, txDelegate Transa
 		s.currentDBLock.RLock()
 		defer s.currentDBLock.RUnlock()
 
-		return s.currentDB.WriteTransaction(internalCtx, txDelegate, options...)
+		return s.currentDB.WriteTransaction(internalCtx, scopeTransactionDelegate(ctx, txDelegate), options...)
 	}
 }
 
@@ -166,7 +190,7 @@ func (s *DatabaseSwitch) BatchOperation(ctx context.Context, batchDelegate Batch
 		s.currentDBLock.RLock()
 		defer s.currentDBLock.RUnlock()
 
-		return s.currentDB.BatchOperation(internalCtx, batchDelegate)
+		return s.currentDB.BatchOperation(internalCtx, scopeBatchDelegate(ctx, batchDelegate))
 	}
 }
 
@@ -0,0 +1,33 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import "context"
+
+type graphTargetKey struct{}
+
+// WithGraphTarget returns a copy of the given context that scopes every transaction opened through a DatabaseSwitch to
+// the given graph.
+func WithGraphTarget(ctx context.Context, target Graph) context.Context {
+	return context.WithValue(ctx, graphTargetKey{}, target)
+}
+
+// GraphTargetFromContext returns the graph target of the given context, if any.
+func GraphTargetFromContext(ctx context.Context) (Graph, bool) {
+	target, hasTarget := ctx.Value(graphTargetKey{}).(Graph)
+	return target, hasTarget
+}
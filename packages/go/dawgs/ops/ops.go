@@ -164,13 +164,13 @@ func FetchNodeIDs(query graph.NodeQuery) ([]graph.ID, error) {
 	})
 }
 
-func FetchPathSetByQuery(tx graph.Transaction, query string) (graph.PathSet, error) {
+func FetchPathSetByQuery(tx graph.Transaction, query string, parameters map[string]any) (graph.PathSet, error) {
 	var (
 		currentPath graph.Path
 		pathSet     graph.PathSet
 	)
 
-	if result := tx.Query(query, map[string]any{}); result.Error() != nil {
+	if result := tx.Query(query, parameters); result.Error() != nil {
 		return pathSet, result.Error()
 	} else {
 		defer result.Close()
@@ -217,6 +217,40 @@ func FetchPathSetByQuery(tx graph.Transaction, query string) (graph.PathSet, err
 	}
 }
 
+// FetchRowsByQuery runs the given query and decodes the values of each returned row. Rows are counted but not decoded
+// until skip rows have been seen and no more than limit rows are decoded, unless limit is 0. The total number of rows
+// returned by the query is returned alongside the decoded rows.
+func FetchRowsByQuery(tx graph.Transaction, query string, parameters map[string]any, skip, limit int) ([][]any, int, error) {
+	var (
+		rows  = [][]any{}
+		count = 0
+	)
+
+	if result := tx.Query(query, parameters); result.Error() != nil {
+		return rows, count, result.Error()
+	} else {
+		defer result.Close()
+
+		for result.Next() {
+			count++
+
+			if count <= skip || (limit > 0 && len(rows) >= limit) {
+				continue
+			}
+
+			if values, err := result.Values(); err != nil {
+				return rows, count, err
+			} else if row, err := values.Decode(); err != nil {
+				return rows, count, err
+			} else {
+				rows = append(rows, row)
+			}
+		}
+
+		return rows, count, result.Error()
+	}
+}
+
 func FetchNode(tx graph.Transaction, id graph.ID) (*graph.Node, error) {
 	return tx.Nodes().Filterf(func() graph.Criteria {
 		return query.Equals(query.NodeID(), id)
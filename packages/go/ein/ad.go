@@ -17,6 +17,7 @@
 package ein
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 
@@ -519,7 +520,7 @@ func ParseComputerMiscData(computer Computer) []IngestibleRelationship {
 					TargetType: ad.User,
 				},
 				IngestibleRel{
-					RelProps: map[string]any{"isacl": false},
+					RelProps: map[string]any{"isacl": false, ad.SessionSource.String(): SessionSourceNetSessionEnum},
 					RelType:  ad.HasSession,
 				},
 			))
@@ -538,7 +539,7 @@ func ParseComputerMiscData(computer Computer) []IngestibleRelationship {
 					TargetType: ad.User,
 				},
 				IngestibleRel{
-					RelProps: map[string]any{"isacl": false},
+					RelProps: map[string]any{"isacl": false, ad.SessionSource.String(): SessionSourceNetWkstaUserEnum},
 					RelType:  ad.HasSession,
 				},
 			))
@@ -557,7 +558,7 @@ func ParseComputerMiscData(computer Computer) []IngestibleRelationship {
 					TargetType: ad.User,
 				},
 				IngestibleRel{
-					RelProps: map[string]any{"isacl": false},
+					RelProps: map[string]any{"isacl": false, ad.SessionSource.String(): SessionSourceRegistry},
 					RelType:  ad.HasSession,
 				},
 			))
@@ -665,6 +666,39 @@ func ConvertLocalGroup(localGroup LocalGroupAPIResult, computer Computer) Parsed
 	return parsedData
 }
 
+// sessionLastSeenProperties maps the session sources to the relationship properties that record when a source last
+// observed the session
+var sessionLastSeenProperties = map[string]ad.Property{
+	SessionSourceNetSessionEnum:   ad.NetSessionEnumLastSeen,
+	SessionSourceNetWkstaUserEnum: ad.NetWkstaUserEnumLastSeen,
+	SessionSourceRegistry:         ad.RegistryLastSeen,
+}
+
+// SessionLastSeenProperty returns the HasSession relationship property that records when the given session source last
+// observed the session
+func SessionLastSeenProperty(source string) (ad.Property, bool) {
+	property, ok := sessionLastSeenProperties[source]
+	return property, ok
+}
+
+// userRightKinds maps the collected user rights assignments to the relationship kinds they are ingested as
+var userRightKinds = map[string]graph.Kind{
+	UserRightRemoteInteractiveLogon: ad.RemoteInteractiveLogonRight,
+	UserRightBackup:                 ad.BackupPrivilege,
+	UserRightRestore:                ad.RestorePrivilege,
+	UserRightDebug:                  ad.DebugPrivilege,
+	UserRightImpersonate:            ad.ImpersonatePrivilege,
+	UserRightLoadDriver:             ad.LoadDriverPrivilege,
+	UserRightTakeOwnership:          ad.TakeOwnershipPrivilege,
+}
+
+// UserRightKind returns the relationship kind for the given user rights assignment. Only the logon right and the
+// privileges that allow escalation on the computer are ingested.
+func UserRightKind(privilege string) (graph.Kind, bool) {
+	kind, ok := userRightKinds[privilege]
+	return kind, ok
+}
+
 func ParseUserRightData(userRight UserRightsAssignmentAPIResult, computer Computer, right graph.Kind) []IngestibleRelationship {
 	relationships := make([]IngestibleRelationship, 0)
 
@@ -688,6 +722,77 @@ func ParseUserRightData(userRight UserRightsAssignmentAPIResult, computer Comput
 	return relationships
 }
 
+// gpoLocalGroupProperties maps the builtin local groups that GPO settings may configure to the GPO properties that
+// record their configured members
+var gpoLocalGroupProperties = map[string]ad.Property{
+	BuiltinAdministratorsSID:      ad.GPOLocalAdmins,
+	BuiltinRemoteDesktopUsersSID:  ad.GPORemoteDesktopUsers,
+	BuiltinDistributedCOMUsersSID: ad.GPODcomUsers,
+	BuiltinRemoteManagementSID:    ad.GPOPSRemoteUsers,
+}
+
+// gpoUserRightProperties maps the user rights assignments that GPO settings may configure to the GPO properties that
+// record their configured holders. The privileges mapped to GPOAdminPrivilegeHolders must match the admin equivalent
+// privileges used by post-processing.
+var gpoUserRightProperties = map[string]ad.Property{
+	UserRightRemoteInteractiveLogon: ad.GPORemoteInteractiveLogonRight,
+	UserRightBackup:                 ad.GPOAdminPrivilegeHolders,
+	UserRightRestore:                ad.GPOAdminPrivilegeHolders,
+	UserRightDebug:                  ad.GPOAdminPrivilegeHolders,
+	UserRightLoadDriver:             ad.GPOAdminPrivilegeHolders,
+	UserRightTakeOwnership:          ad.GPOAdminPrivilegeHolders,
+}
+
+// GPOUserRightProperty returns the GPO property that records the holders of the given user rights assignment
+func GPOUserRightProperty(privilege string) (ad.Property, bool) {
+	property, ok := gpoUserRightProperties[privilege]
+	return property, ok
+}
+
+// ParseGPOSettings converts the local group and user rights settings of a GPO into the object ID lists stored on the
+// GPO node. Every list is always written so that settings removed from the GPO are cleared on the next ingest.
+func ParseGPOSettings(settings GPOSettings) IngestibleNode {
+	propMap := make(map[string]any)
+
+	for _, property := range gpoLocalGroupProperties {
+		propMap[property.String()] = []string{}
+	}
+
+	for _, property := range gpoUserRightProperties {
+		propMap[property.String()] = []string{}
+	}
+
+	appendMembers := func(property ad.Property, members []TypedPrincipal) {
+		memberIDs := propMap[property.String()].([]string)
+
+		for _, member := range members {
+			if memberID := strings.ToUpper(member.ObjectIdentifier); memberID != "" && !slices.Contains(memberIDs, memberID) {
+				memberIDs = append(memberIDs, memberID)
+			}
+		}
+
+		propMap[property.String()] = memberIDs
+	}
+
+	for _, localGroup := range settings.LocalGroups {
+		if property, ok := gpoLocalGroupProperties[strings.ToUpper(localGroup.ObjectIdentifier)]; ok {
+			appendMembers(property, localGroup.Members)
+		}
+	}
+
+	for _, userRight := range settings.UserRights {
+		if property, ok := GPOUserRightProperty(userRight.Privilege); ok {
+			appendMembers(property, userRight.Members)
+		}
+	}
+
+	return IngestibleNode{
+		ObjectID:    settings.ObjectIdentifier,
+		PropertyMap: propMap,
+		Label:       ad.GPO,
+	}
+}
+
 func ParseCARegistryProperties(enterpriseCA EnterpriseCA) IngestibleNode {
 	propMap := make(map[string]any)
 
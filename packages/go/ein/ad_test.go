@@ -133,3 +133,52 @@ func TestParseDomainTrusts_TrustAttributesFix(t *testing.T) {
 	assert.Contains(t, rel.RelProps, "trustattributes")
 	assert.Equal(t, rel.RelProps["trustattributes"], 12345)
 }
+
+func TestUserRightKind(t *testing.T) {
+	kind, ok := ein.UserRightKind(ein.UserRightRemoteInteractiveLogon)
+	assert.True(t, ok)
+	assert.Equal(t, ad.RemoteInteractiveLogonRight, kind)
+
+	kind, ok = ein.UserRightKind(ein.UserRightBackup)
+	assert.True(t, ok)
+	assert.Equal(t, ad.BackupPrivilege, kind)
+
+	kind, ok = ein.UserRightKind(ein.UserRightImpersonate)
+	assert.True(t, ok)
+	assert.Equal(t, ad.ImpersonatePrivilege, kind)
+
+	_, ok = ein.UserRightKind("SeShutdownPrivilege")
+	assert.False(t, ok)
+}
+
+func TestParseGPOSettings(t *testing.T) {
+	node := ein.ParseGPOSettings(ein.GPOSettings{
+		ObjectIdentifier: "F2A1C3B4-0000-0000-0000-000000000001",
+		LocalGroups: []ein.GPOLocalGroupSetting{{
+			ObjectIdentifier: ein.BuiltinAdministratorsSID,
+			Members: []ein.TypedPrincipal{
+				{ObjectIdentifier: "S-1-5-21-1-1101", ObjectType: "User"},
+				{ObjectIdentifier: "s-1-5-21-1-1101", ObjectType: "User"},
+			},
+		}, {
+			ObjectIdentifier: "S-1-5-32-551",
+			Members:          []ein.TypedPrincipal{{ObjectIdentifier: "S-1-5-21-1-1102", ObjectType: "User"}},
+		}},
+		UserRights: []ein.GPOUserRightSetting{{
+			Privilege: ein.UserRightDebug,
+			Members:   []ein.TypedPrincipal{{ObjectIdentifier: "S-1-5-21-1-1103", ObjectType: "Group"}},
+		}, {
+			Privilege: ein.UserRightImpersonate,
+			Members:   []ein.TypedPrincipal{{ObjectIdentifier: "S-1-5-21-1-1104", ObjectType: "User"}},
+		}},
+	})
+
+	assert.Equal(t, ad.GPO, node.Label)
+	assert.Equal(t, []string{"S-1-5-21-1-1101"}, node.PropertyMap[ad.GPOLocalAdmins.String()])
+	assert.Equal(t, []string{"S-1-5-21-1-1103"}, node.PropertyMap[ad.GPOAdminPrivilegeHolders.String()])
+
+	// Unconfigured settings are cleared and unsupported groups and rights are ignored
+	assert.Equal(t, []string{}, node.PropertyMap[ad.GPORemoteDesktopUsers.String()])
+	assert.Equal(t, []string{}, node.PropertyMap[ad.GPORemoteInteractiveLogonRight.String()])
+	assert.Len(t, node.PropertyMap, 6)
+}
@@ -37,8 +37,10 @@ import (
 )
 
 const (
-	ISO8601               string = "2006-01-02T15:04:05Z"
-	KeyVaultPermissionGet string = "Get"
+	ISO8601                          string = "2006-01-02T15:04:05Z"
+	KeyVaultPermissionGet            string = "Get"
+	AdministrativeUnitDirectoryScope string = "/administrativeUnits/"
+	AzureBuiltInRoleType             string = "BuiltInRole"
 )
 
 var (
@@ -211,6 +213,8 @@ func ConvertAzureVMScaleSetRoleAssignment(data models.AzureRoleAssignments) []In
 						RelType:  KindFromRoleId(raw.RoleDefinitionId),
 					},
 				))
+			} else {
+				relationships = append(relationships, ConvertAzureRBACRoleAssignmentToRel(raw, azure.VMScaleSet, data.ObjectId))
 			}
 		}
 	}
@@ -386,6 +390,8 @@ func ConvertAzureFunctionAppRoleAssignmentToRels(data models.AzureRoleAssignment
 						RelType:  KindFromRoleId(raw.RoleDefinitionId),
 					},
 				))
+			} else {
+				relationships = append(relationships, ConvertAzureRBACRoleAssignmentToRel(raw, azure.FunctionApp, data.ObjectId))
 			}
 		}
 	}
@@ -727,50 +733,50 @@ func ConvertAzureManagementGroupUserAccessAdminToRels(data models.ManagementGrou
 
 func ConvertAzureManagementGroup(data models.ManagementGroup) (IngestibleNode, IngestibleRelationship) {
 	return IngestibleNode{
-			ObjectID: strings.ToUpper(data.Id),
-			PropertyMap: map[string]any{
-				common.Name.String():    strings.ToUpper(fmt.Sprintf("%s@%s", data.Properties.DisplayName, data.TenantName)),
-				azure.TenantID.String(): strings.ToUpper(data.TenantId),
-			},
-			Label: azure.ManagementGroup,
-		}, NewIngestibleRelationship(
-			IngestibleSource{
-				Source:     strings.ToUpper(data.TenantId),
-				SourceType: azure.Tenant,
-			},
-			IngestibleTarget{
-				TargetType: azure.ManagementGroup,
-				Target:     strings.ToUpper(data.Id),
-			},
-			IngestibleRel{
-				RelProps: map[string]any{},
-				RelType:  azure.Contains,
-			},
-		)
+		ObjectID: strings.ToUpper(data.Id),
+		PropertyMap: map[string]any{
+			common.Name.String():    strings.ToUpper(fmt.Sprintf("%s@%s", data.Properties.DisplayName, data.TenantName)),
+			azure.TenantID.String(): strings.ToUpper(data.TenantId),
+		},
+		Label: azure.ManagementGroup,
+	}, NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(data.TenantId),
+			SourceType: azure.Tenant,
+		},
+		IngestibleTarget{
+			TargetType: azure.ManagementGroup,
+			Target:     strings.ToUpper(data.Id),
+		},
+		IngestibleRel{
+			RelProps: map[string]any{},
+			RelType:  azure.Contains,
+		},
+	)
 }
 
 func ConvertAzureResourceGroup(data models.ResourceGroup) (IngestibleNode, IngestibleRelationship) {
 	return IngestibleNode{
-			ObjectID: strings.ToUpper(data.Id),
-			PropertyMap: map[string]any{
-				common.Name.String():    strings.ToUpper(data.Name),
-				azure.TenantID.String(): strings.ToUpper(data.TenantId),
-			},
-			Label: azure.ResourceGroup,
-		}, NewIngestibleRelationship(
-			IngestibleSource{
-				Source:     strings.ToUpper(data.SubscriptionId),
-				SourceType: azure.Subscription,
-			},
-			IngestibleTarget{
-				TargetType: azure.ResourceGroup,
-				Target:     strings.ToUpper(data.Id),
-			},
-			IngestibleRel{
-				RelProps: map[string]any{},
-				RelType:  azure.Contains,
-			},
-		)
+		ObjectID: strings.ToUpper(data.Id),
+		PropertyMap: map[string]any{
+			common.Name.String():    strings.ToUpper(data.Name),
+			azure.TenantID.String(): strings.ToUpper(data.TenantId),
+		},
+		Label: azure.ResourceGroup,
+	}, NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(data.SubscriptionId),
+			SourceType: azure.Subscription,
+		},
+		IngestibleTarget{
+			TargetType: azure.ResourceGroup,
+			Target:     strings.ToUpper(data.Id),
+		},
+		IngestibleRel{
+			RelProps: map[string]any{},
+			RelType:  azure.Contains,
+		},
+	)
 }
 
 func ConvertAzureResourceGroupOwnerToRels(data models.ResourceGroupOwners) []IngestibleRelationship {
@@ -822,33 +828,160 @@ func ConvertAzureResourceGroupUserAccessAdminToRels(data models.ResourceGroupUse
 }
 
 func ConvertAzureRole(data models.Role) (IngestibleNode, IngestibleRelationship) {
-	roleObjectId := fmt.Sprintf("%s@%s", strings.ToUpper(data.Id), strings.ToUpper(data.TenantId))
+	var (
+		roleObjectId = fmt.Sprintf("%s@%s", strings.ToUpper(data.Id), strings.ToUpper(data.TenantId))
+		propertyMap  = map[string]any{
+			common.Name.String():          strings.ToUpper(fmt.Sprintf("%s@%s", data.DisplayName, data.TenantName)),
+			common.Description.String():   data.Description,
+			common.DisplayName.String():   data.DisplayName,
+			common.Enabled.String():       data.IsEnabled,
+			azure.IsBuiltIn.String():      data.IsBuiltIn,
+			azure.RoleTemplateID.String(): data.TemplateId,
+			azure.TenantID.String():       strings.ToUpper(data.TenantId),
+		}
+	)
+
+	// Older collections do not include role permissions. The properties are left unset so that post-processing can tell
+	// a role without permission data apart from a role that permits nothing.
+	if len(data.RolePermissions) > 0 {
+		allowedActions, excludedActions := ConvertAzureRolePermissions(data.RolePermissions)
+		propertyMap[azure.AllowedResourceActions.String()] = allowedActions
+		propertyMap[azure.ExcludedResourceActions.String()] = excludedActions
+	}
+
 	return IngestibleNode{
-			ObjectID: roleObjectId,
-			PropertyMap: map[string]any{
-				common.Name.String():          strings.ToUpper(fmt.Sprintf("%s@%s", data.DisplayName, data.TenantName)),
-				common.Description.String():   data.Description,
-				common.DisplayName.String():   data.DisplayName,
-				common.Enabled.String():       data.IsEnabled,
-				azure.IsBuiltIn.String():      data.IsBuiltIn,
-				azure.RoleTemplateID.String(): data.TemplateId,
-				azure.TenantID.String():       strings.ToUpper(data.TenantId),
-			},
-			Label: azure.Role,
-		}, NewIngestibleRelationship(
-			IngestibleSource{
-				Source:     strings.ToUpper(data.TenantId),
-				SourceType: azure.Tenant,
-			},
-			IngestibleTarget{
-				TargetType: azure.Role,
-				Target:     roleObjectId,
-			},
-			IngestibleRel{
-				RelProps: map[string]any{},
-				RelType:  azure.Contains,
-			},
+		ObjectID:    roleObjectId,
+		PropertyMap: propertyMap,
+		Label:       azure.Role,
+	}, NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(data.TenantId),
+			SourceType: azure.Tenant,
+		},
+		IngestibleTarget{
+			TargetType: azure.Role,
+			Target:     roleObjectId,
+		},
+		IngestibleRel{
+			RelProps: map[string]any{},
+			RelType:  azure.Contains,
+		},
+	)
+}
+
+func ConvertAzureAdministrativeUnit(data AzureAdministrativeUnit) (IngestibleNode, IngestibleRelationship) {
+	return IngestibleNode{
+		ObjectID: strings.ToUpper(data.Id),
+		PropertyMap: map[string]any{
+			common.Name.String():                        strings.ToUpper(fmt.Sprintf("%s@%s", data.DisplayName, data.TenantName)),
+			common.Description.String():                 data.Description,
+			common.DisplayName.String():                 data.DisplayName,
+			azure.IsMemberManagementRestricted.String(): data.IsMemberManagementRestricted,
+			azure.TenantID.String():                     strings.ToUpper(data.TenantId),
+		},
+		Label: azure.AdministrativeUnit,
+	}, NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(data.TenantId),
+			SourceType: azure.Tenant,
+		},
+		IngestibleTarget{
+			TargetType: azure.AdministrativeUnit,
+			Target:     strings.ToUpper(data.Id),
+		},
+		IngestibleRel{
+			RelProps: map[string]any{},
+			RelType:  azure.Contains,
+		},
+	)
+}
+
+func ConvertAzureAdministrativeUnitMembersToRels(data AzureAdministrativeUnitMembers) []IngestibleRelationship {
+	relationships := make([]IngestibleRelationship, 0)
+
+	for _, raw := range data.Members {
+		var (
+			member azure2.DirectoryObject
 		)
+		if err := json.Unmarshal(raw.Member, &member); err != nil {
+			log.Errorf(SerialError, "azure administrative unit member", err)
+		} else if memberType, err := ExtractTypeFromDirectoryObject(member); errors.Is(err, InvalidTypeErr) {
+			log.Warnf(ExtractError, err)
+		} else if err != nil {
+			log.Errorf(ExtractError, err)
+		} else {
+			relationships = append(relationships, NewIngestibleRelationship(
+				IngestibleSource{
+					Source:     strings.ToUpper(member.Id),
+					SourceType: memberType,
+				},
+				IngestibleTarget{
+					TargetType: azure.AdministrativeUnit,
+					Target:     strings.ToUpper(data.AdministrativeUnitId),
+				},
+				IngestibleRel{
+					RelProps: map[string]any{},
+					RelType:  azure.AdministrativeUnitMember,
+				},
+			))
+		}
+	}
+
+	return relationships
+}
+
+// ConvertAzureCrossTenantAccessPartner creates an edge from the partner tenant to the tenant whose cross-tenant access
+// policy trusts it
+func ConvertAzureCrossTenantAccessPartner(data AzureCrossTenantAccessPartner) IngestibleRelationship {
+	return NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(data.PartnerTenantId),
+			SourceType: azure.Tenant,
+		},
+		IngestibleTarget{
+			TargetType: azure.Tenant,
+			Target:     strings.ToUpper(data.TenantId),
+		},
+		IngestibleRel{
+			RelProps: map[string]any{
+				azure.IsMFAAccepted.String():                data.InboundTrust.IsMfaAccepted,
+				azure.IsCompliantDeviceAccepted.String():    data.InboundTrust.IsCompliantDeviceAccepted,
+				azure.IsHybridJoinedDeviceAccepted.String(): data.InboundTrust.IsHybridAzureADJoinedDeviceAccepted,
+				azure.IsInboundSyncAllowed.String():         data.IdentitySynchronization.UserSyncInbound.IsSyncAllowed,
+			},
+			RelType: azure.CrossTenantAccess,
+		},
+	)
+}
+
+// ConvertAzureRolePermissions returns the allowed and excluded resource actions of a role definition's permissions.
+// Permissions that carry a condition, such as only applying to resources the principal owns, do not grant the action
+// tenant wide and are skipped. Actions are lower cased as resource action names are not case sensitive.
+func ConvertAzureRolePermissions(permissions []azure2.RolePermission) ([]string, []string) {
+	var (
+		allowedActions  = make([]string, 0)
+		excludedActions = make([]string, 0)
+	)
+
+	for _, permission := range permissions {
+		if permission.Condition != "" {
+			continue
+		}
+
+		for _, action := range permission.AllowedResourceActions {
+			if action = strings.ToLower(action); !slices.Contains(allowedActions, action) {
+				allowedActions = append(allowedActions, action)
+			}
+		}
+
+		for _, action := range permission.ExcludedResourceActions {
+			if action = strings.ToLower(action); !slices.Contains(excludedActions, action) {
+				excludedActions = append(excludedActions, action)
+			}
+		}
+	}
+
+	return allowedActions, excludedActions
 }
 
 func ConvertAzureRoleAssignmentToRels(roleAssignment azure2.UnifiedRoleAssignment, data models.RoleAssignments, roleObjectId string) []IngestibleRelationship {
@@ -863,7 +996,26 @@ func ConvertAzureRoleAssignmentToRels(roleAssignment azure2.UnifiedRoleAssignmen
 		scope = strings.ToUpper(roleAssignment.DirectoryScopeId[1:])
 	}
 
-	if CanAddSecret(roleAssignment.RoleDefinitionId) && roleAssignment.DirectoryScopeId != "/" {
+	if administrativeUnitID, isScopedToAdministrativeUnit := strings.CutPrefix(roleAssignment.DirectoryScopeId, AdministrativeUnitDirectoryScope); isScopedToAdministrativeUnit {
+		// Assignments scoped to an administrative unit only grant the role over the unit's members. They are kept apart
+		// from tenant wide assignments so that the role's edges are not inherited by the assigned principal.
+		relationships = append(relationships, NewIngestibleRelationship(
+			IngestibleSource{
+				Source:     strings.ToUpper(roleAssignment.PrincipalId),
+				SourceType: azure.Entity,
+			},
+			IngestibleTarget{
+				TargetType: azure.Role,
+				Target:     roleObjectId,
+			},
+			IngestibleRel{
+				RelProps: map[string]any{
+					azure.Scope.String(): strings.ToUpper(administrativeUnitID),
+				},
+				RelType: azure.HasScopedRole,
+			},
+		))
+	} else if CanAddSecret(roleAssignment.RoleDefinitionId) && roleAssignment.DirectoryScopeId != "/" {
 		if relType, err := GetAddSecretRoleKind(roleAssignment.RoleDefinitionId); err != nil {
 			log.Errorf("Error processing role assignment for role %s: %v", roleObjectId, err)
 		} else {
@@ -1058,6 +1210,8 @@ func ConvertAzureLogicAppRoleAssignment(roleAssignment models.AzureRoleAssignmen
 						RelType:  KindFromRoleId(raw.RoleDefinitionId),
 					},
 				))
+			} else {
+				relationships = append(relationships, ConvertAzureRBACRoleAssignmentToRel(raw, azure.LogicApp, roleAssignment.ObjectId))
 			}
 		}
 	}
@@ -1206,36 +1360,36 @@ func ConvertAzureUser(data models.User) (IngestibleNode, IngestibleNode, Ingesti
 	}
 
 	return IngestibleNode{
-			ObjectID: strings.ToUpper(data.Id),
-			PropertyMap: map[string]any{
-				common.Name.String():             strings.ToUpper(data.UserPrincipalName),
-				common.Enabled.String():          data.AccountEnabled,
-				common.WhenCreated.String():      ParseISO8601(data.CreatedDateTime),
-				common.DisplayName.String():      data.DisplayName,
-				common.Title.String():            data.JobTitle,
-				common.PasswordLastSet.String():  ParseISO8601(data.LastPasswordChangeDateTime),
-				common.Email.String():            data.Mail,
-				azure.OnPremID.String():          data.OnPremisesSecurityIdentifier,
-				azure.OnPremSyncEnabled.String(): data.OnPremisesSyncEnabled,
-				azure.UserPrincipalName.String(): data.UserPrincipalName,
-				azure.UserType.String():          data.UserType,
-				azure.TenantID.String():          strings.ToUpper(data.TenantId),
-			},
-			Label: azure.User,
-		}, onPremNode, NewIngestibleRelationship(
-			IngestibleSource{
-				Source:     strings.ToUpper(data.TenantId),
-				SourceType: azure.Tenant,
-			},
-			IngestibleTarget{
-				TargetType: azure.User,
-				Target:     strings.ToUpper(data.Id),
-			},
-			IngestibleRel{
-				RelProps: map[string]any{},
-				RelType:  azure.Contains,
-			},
-		)
+		ObjectID: strings.ToUpper(data.Id),
+		PropertyMap: map[string]any{
+			common.Name.String():             strings.ToUpper(data.UserPrincipalName),
+			common.Enabled.String():          data.AccountEnabled,
+			common.WhenCreated.String():      ParseISO8601(data.CreatedDateTime),
+			common.DisplayName.String():      data.DisplayName,
+			common.Title.String():            data.JobTitle,
+			common.PasswordLastSet.String():  ParseISO8601(data.LastPasswordChangeDateTime),
+			common.Email.String():            data.Mail,
+			azure.OnPremID.String():          data.OnPremisesSecurityIdentifier,
+			azure.OnPremSyncEnabled.String(): data.OnPremisesSyncEnabled,
+			azure.UserPrincipalName.String(): data.UserPrincipalName,
+			azure.UserType.String():          data.UserType,
+			azure.TenantID.String():          strings.ToUpper(data.TenantId),
+		},
+		Label: azure.User,
+	}, onPremNode, NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(data.TenantId),
+			SourceType: azure.Tenant,
+		},
+		IngestibleTarget{
+			TargetType: azure.User,
+			Target:     strings.ToUpper(data.Id),
+		},
+		IngestibleRel{
+			RelProps: map[string]any{},
+			RelType:  azure.Contains,
+		},
+	)
 }
 
 func ConvertAzureVirtualMachine(data models.VirtualMachine) (IngestibleNode, []IngestibleRelationship) {
@@ -1514,6 +1668,8 @@ func ConvertAzureManagedClusterRoleAssignmentToRels(data models.AzureRoleAssignm
 						RelType:  KindFromRoleId(raw.RoleDefinitionId),
 					},
 				))
+			} else {
+				relationships = append(relationships, ConvertAzureRBACRoleAssignmentToRel(raw, azure.ManagedCluster, data.ObjectId))
 			}
 		}
 	}
@@ -1678,6 +1834,8 @@ func ConvertAzureAutomationAccountRoleAssignment(roleAssignments models.AzureRol
 						RelType:  KindFromRoleId(raw.RoleDefinitionId),
 					},
 				))
+			} else {
+				relationships = append(relationships, ConvertAzureRBACRoleAssignmentToRel(raw, azure.AutomationAccount, roleAssignments.ObjectId))
 			}
 		}
 	}
@@ -1708,6 +1866,8 @@ func ConvertAzureContainerRegistryRoleAssignment(roleAssignment models.AzureRole
 						RelType:  KindFromRoleId(raw.RoleDefinitionId),
 					},
 				))
+			} else {
+				relationships = append(relationships, ConvertAzureRBACRoleAssignmentToRel(raw, azure.ContainerRegistry, roleAssignment.ObjectId))
 			}
 		}
 	}
@@ -1739,6 +1899,8 @@ func ConvertAzureWebAppRoleAssignment(roleAssignment models.AzureRoleAssignments
 						RelType:  KindFromRoleId(raw.RoleDefinitionId),
 					},
 				))
+			} else {
+				relationships = append(relationships, ConvertAzureRBACRoleAssignmentToRel(raw, azure.WebApp, roleAssignment.ObjectId))
 			}
 		}
 	}
@@ -1813,6 +1975,157 @@ func ConvertAzureAutomationAccount(account models.AutomationAccount) (Ingestible
 	return node, relationships
 }
 
+func ConvertAzureStorageAccount(data models.StorageAccount) (IngestibleNode, IngestibleRelationship) {
+	return IngestibleNode{
+		ObjectID: strings.ToUpper(data.Id),
+		PropertyMap: map[string]any{
+			common.Name.String():                strings.ToUpper(data.Name),
+			azure.TenantID.String():             strings.ToUpper(data.TenantId),
+			azure.AllowSharedKeyAccess.String(): data.Properties.AllowSharedKeyAccess,
+		},
+		Label: azure.StorageAccount,
+	}, NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(data.ResourceGroupId),
+			SourceType: azure.ResourceGroup,
+		},
+		IngestibleTarget{
+			TargetType: azure.StorageAccount,
+			Target:     strings.ToUpper(data.Id),
+		},
+		IngestibleRel{
+			RelProps: map[string]any{},
+			RelType:  azure.Contains,
+		},
+	)
+}
+
+func ConvertAzureStorageContainer(data models.StorageContainer) (IngestibleNode, IngestibleRelationship) {
+	return IngestibleNode{
+		ObjectID: strings.ToUpper(data.Id),
+		PropertyMap: map[string]any{
+			common.Name.String():    strings.ToUpper(data.Name),
+			azure.TenantID.String(): strings.ToUpper(data.TenantId),
+		},
+		Label: azure.StorageContainer,
+	}, NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(data.StorageAccountId),
+			SourceType: azure.StorageAccount,
+		},
+		IngestibleTarget{
+			TargetType: azure.StorageContainer,
+			Target:     strings.ToUpper(data.Id),
+		},
+		IngestibleRel{
+			RelProps: map[string]any{},
+			RelType:  azure.Contains,
+		},
+	)
+}
+
+func ConvertAzureStorageAccountRoleAssignment(roleAssignment models.AzureRoleAssignments) []IngestibleRelationship {
+	relationships := make([]IngestibleRelationship, 0)
+	for _, raw := range roleAssignment.RoleAssignments {
+		if strings.EqualFold(raw.Assignee.Properties.Scope, raw.ObjectId) {
+			if slices.Contains([]string{
+				constants.OwnerRoleID,
+				constants.UserAccessAdminRoleID,
+				constants.ContributorRoleID,
+				constants.StorageAccountContributorRoleID,
+				constants.StorageAccountKeyOperatorServiceRoleID,
+				constants.StorageBlobDataOwnerRoleID,
+				constants.StorageBlobDataContributorRoleID,
+			}, strings.ToLower(raw.RoleDefinitionId)) {
+				relationships = append(relationships, NewIngestibleRelationship(
+					IngestibleSource{
+						Source:     strings.ToUpper(raw.Assignee.GetPrincipalId()),
+						SourceType: azure.Entity,
+					},
+					IngestibleTarget{
+						TargetType: azure.StorageAccount,
+						Target:     strings.ToUpper(roleAssignment.ObjectId),
+					},
+					IngestibleRel{
+						RelProps: map[string]any{},
+						RelType:  KindFromRoleId(strings.ToLower(raw.RoleDefinitionId)),
+					},
+				))
+			} else {
+				relationships = append(relationships, ConvertAzureRBACRoleAssignmentToRel(raw, azure.StorageAccount, roleAssignment.ObjectId))
+			}
+		}
+	}
+
+	return relationships
+}
+
+// ConvertAzureRoleDefinition converts an Azure RBAC role definition to a node keyed by the role definition ID that role
+// assignments reference. Actions are lower cased as resource provider operation names are not case sensitive.
+func ConvertAzureRoleDefinition(data AzureRoleDefinition) IngestibleNode {
+	var (
+		allowedActions      = make([]string, 0)
+		excludedActions     = make([]string, 0)
+		allowedDataActions  = make([]string, 0)
+		excludedDataActions = make([]string, 0)
+	)
+
+	for _, permission := range data.Properties.Permissions {
+		allowedActions = appendLowerCaseActions(allowedActions, permission.Actions)
+		excludedActions = appendLowerCaseActions(excludedActions, permission.NotActions)
+		allowedDataActions = appendLowerCaseActions(allowedDataActions, permission.DataActions)
+		excludedDataActions = appendLowerCaseActions(excludedDataActions, permission.NotDataActions)
+	}
+
+	return IngestibleNode{
+		ObjectID: strings.ToUpper(data.Name),
+		PropertyMap: map[string]any{
+			common.Name.String():                   strings.ToUpper(data.Properties.RoleName),
+			common.Description.String():            data.Properties.Description,
+			common.DisplayName.String():            data.Properties.RoleName,
+			azure.IsBuiltIn.String():               data.Properties.Type == AzureBuiltInRoleType,
+			azure.TenantID.String():                strings.ToUpper(data.TenantId),
+			azure.AllowedResourceActions.String():  allowedActions,
+			azure.ExcludedResourceActions.String(): excludedActions,
+			azure.AllowedDataActions.String():      allowedDataActions,
+			azure.ExcludedDataActions.String():     excludedDataActions,
+		},
+		Label: azure.RoleDefinition,
+	}
+}
+
+func appendLowerCaseActions(actions []string, newActions []string) []string {
+	for _, action := range newActions {
+		if action = strings.ToLower(action); !slices.Contains(actions, action) {
+			actions = append(actions, action)
+		}
+	}
+
+	return actions
+}
+
+// ConvertAzureRBACRoleAssignmentToRel converts an Azure RBAC role assignment whose role definition has no built-in
+// abuse edge kind for the target, such as a custom role, to an AZRBACRoleAssignment edge. Post-processing derives the
+// abuse edges of the assignment from the actions of the ingested role definition.
+func ConvertAzureRBACRoleAssignmentToRel(raw models.AzureRoleAssignment, targetType graph.Kind, targetId string) IngestibleRelationship {
+	return NewIngestibleRelationship(
+		IngestibleSource{
+			Source:     strings.ToUpper(raw.Assignee.GetPrincipalId()),
+			SourceType: azure.Entity,
+		},
+		IngestibleTarget{
+			TargetType: targetType,
+			Target:     strings.ToUpper(targetId),
+		},
+		IngestibleRel{
+			RelProps: map[string]any{
+				azure.RoleDefinitionID.String(): strings.ToUpper(raw.RoleDefinitionId),
+			},
+			RelType: azure.RBACRoleAssignment,
+		},
+	)
+}
+
 func CanAddSecret(roleDefinitionId string) bool {
 	return roleDefinitionId == azure.ApplicationAdministratorRole || roleDefinitionId == azure.CloudApplicationAdministratorRole
 }
@@ -1837,6 +2150,9 @@ func ParseISO8601(datetime string) time.Time {
 	}
 }
 
+// KindFromRoleId returns the abuse edge kind of a built-in Azure RBAC role definition ID. Any other role definition
+// ID, including that of a custom Azure RBAC role, has no kind and its assignments are ingested as AZRBACRoleAssignment
+// edges instead.
 func KindFromRoleId(roleId string) graph.Kind {
 	switch roleId {
 	case constants.OwnerRoleID:
@@ -1855,6 +2171,14 @@ func KindFromRoleId(roleId string) graph.Kind {
 		return azure.VMContributor
 	case azure.AKSContributorRole:
 		return azure.AKSContributor
+	case constants.StorageAccountContributorRoleID:
+		return azure.StorageAccountContributor
+	case constants.StorageAccountKeyOperatorServiceRoleID:
+		return azure.StorageAccountKeyOperator
+	case constants.StorageBlobDataOwnerRoleID:
+		return azure.StorageBlobDataOwner
+	case constants.StorageBlobDataContributorRoleID:
+		return azure.StorageBlobDataContributor
 	default:
 		return graph.StringKind("")
 	}
@@ -0,0 +1,233 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ein_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bloodhoundad/azurehound/v2/constants"
+	"github.com/bloodhoundad/azurehound/v2/models"
+	azure2 "github.com/bloodhoundad/azurehound/v2/models/azure"
+	"github.com/specterops/bloodhound/ein"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAzureRole_RolePermissions(t *testing.T) {
+	role := models.Role{
+		Role: azure2.Role{
+			DisplayName: "Password Resetter",
+			RolePermissions: []azure2.RolePermission{
+				{
+					AllowedResourceActions:  []string{"microsoft.directory/users/password/update", "microsoft.directory/users/allProperties/read"},
+					ExcludedResourceActions: []string{"microsoft.directory/users/basic/update"},
+				},
+				{
+					AllowedResourceActions: []string{"microsoft.directory/applications/credentials/update", "Microsoft.Directory/Users/Password/Update"},
+					Condition:              "$ResourceIsSelf",
+				},
+			},
+		},
+		TenantId: "tenant",
+	}
+
+	node, _ := ein.ConvertAzureRole(role)
+	assert.Equal(t, []string{"microsoft.directory/users/password/update", "microsoft.directory/users/allproperties/read"}, node.PropertyMap[azure.AllowedResourceActions.String()])
+	assert.Equal(t, []string{"microsoft.directory/users/basic/update"}, node.PropertyMap[azure.ExcludedResourceActions.String()])
+
+	role.RolePermissions = nil
+	node, _ = ein.ConvertAzureRole(role)
+	assert.NotContains(t, node.PropertyMap, azure.AllowedResourceActions.String())
+	assert.NotContains(t, node.PropertyMap, azure.ExcludedResourceActions.String())
+}
+
+func TestConvertAzureRoleAssignmentToRels_AdministrativeUnitScope(t *testing.T) {
+	var (
+		data = models.RoleAssignments{
+			TenantId: "tenant",
+		}
+		tenantAssignment = azure2.UnifiedRoleAssignment{
+			RoleDefinitionId: azure.HelpdeskAdministratorRole,
+			PrincipalId:      "principal",
+			DirectoryScopeId: "/",
+		}
+		scopedAssignment = azure2.UnifiedRoleAssignment{
+			RoleDefinitionId: azure.HelpdeskAdministratorRole,
+			PrincipalId:      "principal",
+			DirectoryScopeId: "/administrativeUnits/unit",
+		}
+	)
+
+	rels := ein.ConvertAzureRoleAssignmentToRels(tenantAssignment, data, "ROLE@TENANT")
+	assert.Len(t, rels, 1)
+	assert.Equal(t, azure.HasRole, rels[0].RelType)
+	assert.Equal(t, "TENANT", rels[0].RelProps[azure.Scope.String()])
+
+	rels = ein.ConvertAzureRoleAssignmentToRels(scopedAssignment, data, "ROLE@TENANT")
+	assert.Len(t, rels, 1)
+	assert.Equal(t, azure.HasScopedRole, rels[0].RelType)
+	assert.Equal(t, "PRINCIPAL", rels[0].Source)
+	assert.Equal(t, "ROLE@TENANT", rels[0].Target)
+	assert.Equal(t, "UNIT", rels[0].RelProps[azure.Scope.String()])
+}
+
+func TestConvertAzureAdministrativeUnitMembersToRels(t *testing.T) {
+	data := ein.AzureAdministrativeUnitMembers{
+		AdministrativeUnitId: "unit",
+		Members: []ein.AzureAdministrativeUnitMember{
+			{Member: json.RawMessage(`{"id": "user", "@odata.type": "#microsoft.graph.user"}`), AdministrativeUnitId: "unit"},
+			{Member: json.RawMessage(`{"id": "group", "@odata.type": "#microsoft.graph.group"}`), AdministrativeUnitId: "unit"},
+			{Member: json.RawMessage(`{"id": "unknown", "@odata.type": "#microsoft.graph.unknown"}`), AdministrativeUnitId: "unit"},
+		},
+	}
+
+	rels := ein.ConvertAzureAdministrativeUnitMembersToRels(data)
+	assert.Len(t, rels, 2)
+	assert.Equal(t, "USER", rels[0].Source)
+	assert.Equal(t, azure.User, rels[0].SourceType)
+	assert.Equal(t, azure.Group, rels[1].SourceType)
+
+	for _, rel := range rels {
+		assert.Equal(t, "UNIT", rel.Target)
+		assert.Equal(t, azure.AdministrativeUnit, rel.TargetType)
+		assert.Equal(t, azure.AdministrativeUnitMember, rel.RelType)
+	}
+}
+
+func TestConvertAzureStorageAccountRoleAssignment(t *testing.T) {
+	storageAccountID := "/subscriptions/sub/resourcegroups/rg/providers/microsoft.storage/storageaccounts/account"
+	roleAssignment := func(principalID, roleDefinitionID, scope string) models.AzureRoleAssignment {
+		assignment := models.AzureRoleAssignment{
+			ObjectId:         storageAccountID,
+			RoleDefinitionId: roleDefinitionID,
+		}
+		assignment.Assignee.Properties.PrincipalId = principalID
+		assignment.Assignee.Properties.Scope = scope
+		return assignment
+	}
+
+	data := models.AzureRoleAssignments{
+		ObjectId: storageAccountID,
+		RoleAssignments: []models.AzureRoleAssignment{
+			roleAssignment("contributor", constants.StorageAccountContributorRoleID, storageAccountID),
+			roleAssignment("keyoperator", strings.ToUpper(constants.StorageAccountKeyOperatorServiceRoleID), storageAccountID),
+			roleAssignment("blobowner", constants.StorageBlobDataOwnerRoleID, storageAccountID),
+			roleAssignment("blobreader", constants.StorageBlobDataReaderRoleID, storageAccountID),
+			roleAssignment("inherited", constants.StorageBlobDataContributorRoleID, "/subscriptions/sub"),
+		},
+	}
+
+	rels := ein.ConvertAzureStorageAccountRoleAssignment(data)
+	assert.Len(t, rels, 4)
+	assert.Equal(t, "CONTRIBUTOR", rels[0].Source)
+	assert.Equal(t, azure.StorageAccountContributor, rels[0].RelType)
+	assert.Equal(t, azure.StorageAccountKeyOperator, rels[1].RelType)
+	assert.Equal(t, azure.StorageBlobDataOwner, rels[2].RelType)
+
+	// Roles without a built-in abuse edge kind are kept so that post-processing can derive edges from their actions
+	assert.Equal(t, "BLOBREADER", rels[3].Source)
+	assert.Equal(t, azure.RBACRoleAssignment, rels[3].RelType)
+	assert.Equal(t, strings.ToUpper(constants.StorageBlobDataReaderRoleID), rels[3].RelProps[azure.RoleDefinitionID.String()])
+
+	for _, rel := range rels {
+		assert.Equal(t, strings.ToUpper(storageAccountID), rel.Target)
+		assert.Equal(t, azure.StorageAccount, rel.TargetType)
+	}
+}
+
+func TestConvertAzureRoleDefinition(t *testing.T) {
+	var roleDefinition ein.AzureRoleDefinition
+
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"id": "/subscriptions/sub/providers/Microsoft.Authorization/roleDefinitions/8b7c3c0e-0b31-4f4d-9f1f-7a8c0b1c2d3e",
+		"name": "8b7c3c0e-0b31-4f4d-9f1f-7a8c0b1c2d3e",
+		"tenantId": "tenant",
+		"properties": {
+			"roleName": "Scale Set Operator",
+			"type": "CustomRole",
+			"permissions": [
+				{
+					"actions": ["Microsoft.Compute/virtualMachineScaleSets/*", "microsoft.compute/virtualmachinescalesets/read"],
+					"notActions": ["Microsoft.Compute/virtualMachineScaleSets/delete"],
+					"dataActions": [],
+					"notDataActions": []
+				},
+				{
+					"actions": ["Microsoft.Compute/virtualMachineScaleSets/*"],
+					"dataActions": ["Microsoft.Storage/storageAccounts/blobServices/containers/blobs/read"]
+				}
+			]
+		}
+	}`), &roleDefinition))
+
+	node := ein.ConvertAzureRoleDefinition(roleDefinition)
+	assert.Equal(t, "8B7C3C0E-0B31-4F4D-9F1F-7A8C0B1C2D3E", node.ObjectID)
+	assert.Equal(t, azure.RoleDefinition, node.Label)
+	assert.Equal(t, false, node.PropertyMap[azure.IsBuiltIn.String()])
+	assert.Equal(t, "TENANT", node.PropertyMap[azure.TenantID.String()])
+	assert.Equal(t, []string{"microsoft.compute/virtualmachinescalesets/*", "microsoft.compute/virtualmachinescalesets/read"}, node.PropertyMap[azure.AllowedResourceActions.String()])
+	assert.Equal(t, []string{"microsoft.compute/virtualmachinescalesets/delete"}, node.PropertyMap[azure.ExcludedResourceActions.String()])
+	assert.Equal(t, []string{"microsoft.storage/storageaccounts/blobservices/containers/blobs/read"}, node.PropertyMap[azure.AllowedDataActions.String()])
+	assert.Equal(t, []string{}, node.PropertyMap[azure.ExcludedDataActions.String()])
+}
+
+func TestConvertAzureServicePrincipal_MultiTenant(t *testing.T) {
+	data := models.ServicePrincipal{
+		ServicePrincipal: azure2.ServicePrincipal{
+			AppId:                  "app",
+			AppOwnerOrganizationId: "hometenant",
+		},
+		TenantId: "resourcetenant",
+	}
+	data.Id = "serviceprincipal"
+
+	nodes, rels := ein.ConvertAzureServicePrincipal(data)
+	require.Len(t, nodes, 2)
+
+	// The home application is keyed by app ID so foreign tenant service principals link to the application collected
+	// from the owning tenant
+	assert.Equal(t, "APP", nodes[1].ObjectID)
+	assert.Equal(t, azure.App, nodes[1].Label)
+	assert.Equal(t, "HOMETENANT", nodes[1].PropertyMap[azure.TenantID.String()])
+	assert.Equal(t, "APP", rels[0].Source)
+	assert.Equal(t, "SERVICEPRINCIPAL", rels[0].Target)
+	assert.Equal(t, azure.RunsAs, rels[0].RelType)
+}
+
+func TestConvertAzureCrossTenantAccessPartner(t *testing.T) {
+	var data ein.AzureCrossTenantAccessPartner
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"tenantId": "resourcetenant",
+		"partnerTenantId": "partnertenant",
+		"inboundTrust": {"isMfaAccepted": true, "isCompliantDeviceAccepted": false, "isHybridAzureADJoinedDeviceAccepted": true},
+		"identitySynchronization": {"userSyncInbound": {"isSyncAllowed": true}}
+	}`), &data))
+
+	rel := ein.ConvertAzureCrossTenantAccessPartner(data)
+	assert.Equal(t, "PARTNERTENANT", rel.Source)
+	assert.Equal(t, azure.Tenant, rel.SourceType)
+	assert.Equal(t, "RESOURCETENANT", rel.Target)
+	assert.Equal(t, azure.Tenant, rel.TargetType)
+	assert.Equal(t, azure.CrossTenantAccess, rel.RelType)
+	assert.Equal(t, true, rel.RelProps[azure.IsMFAAccepted.String()])
+	assert.Equal(t, false, rel.RelProps[azure.IsCompliantDeviceAccepted.String()])
+	assert.Equal(t, true, rel.RelProps[azure.IsHybridJoinedDeviceAccepted.String()])
+	assert.Equal(t, true, rel.RelProps[azure.IsInboundSyncAllowed.String()])
+}
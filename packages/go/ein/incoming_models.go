@@ -17,6 +17,8 @@
 package ein
 
 import (
+	"encoding/json"
+
 	"github.com/specterops/bloodhound/analysis"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/graphschema/ad"
@@ -29,6 +31,27 @@ const (
 	TrustDirectionBidirectional     = "Bidirectional"
 	IgnoredName                     = "IGNOREME"
 	UserRightRemoteInteractiveLogon = "SeRemoteInteractiveLogonRight"
+	UserRightBackup                 = "SeBackupPrivilege"
+	UserRightRestore                = "SeRestorePrivilege"
+	UserRightDebug                  = "SeDebugPrivilege"
+	UserRightImpersonate            = "SeImpersonatePrivilege"
+	UserRightLoadDriver             = "SeLoadDriverPrivilege"
+	UserRightTakeOwnership          = "SeTakeOwnershipPrivilege"
+)
+
+// Session sources identify the collection method that observed a session
+const (
+	SessionSourceNetSessionEnum   = "netsessionenum"
+	SessionSourceNetWkstaUserEnum = "netwkstauserenum"
+	SessionSourceRegistry         = "registry"
+)
+
+// Well known SIDs of the builtin local groups that GPO settings may configure the membership of
+const (
+	BuiltinAdministratorsSID      = "S-1-5-32-544"
+	BuiltinRemoteDesktopUsersSID  = "S-1-5-32-555"
+	BuiltinDistributedCOMUsersSID = "S-1-5-32-562"
+	BuiltinRemoteManagementSID    = "S-1-5-32-580"
 )
 
 func parseADKind(rawKindStr string) graph.Kind {
@@ -149,6 +172,26 @@ type StrongCertificateBindingEnforcement struct {
 
 type GPO IngestBase
 
+// GPOLocalGroupSetting is the membership of a builtin local group as configured by Restricted Groups or Group Policy
+// Preferences local users and groups. ObjectIdentifier is the well known SID of the local group.
+type GPOLocalGroupSetting struct {
+	ObjectIdentifier string
+	Members          []TypedPrincipal
+}
+
+// GPOUserRightSetting is a user rights assignment as configured by the security settings of a GPO
+type GPOUserRightSetting struct {
+	Privilege string
+	Members   []TypedPrincipal
+}
+
+// GPOSettings are the parsed settings of the GPO identified by ObjectIdentifier that affect the computers it applies to
+type GPOSettings struct {
+	ObjectIdentifier string
+	LocalGroups      []GPOLocalGroupSetting
+	UserRights       []GPOUserRightSetting
+}
+
 type AIACA IngestBase
 
 type IssuancePolicy struct {
@@ -284,3 +327,69 @@ type OU struct {
 	ChildObjects []TypedPrincipal
 	Links        []GPLink
 }
+
+// AzureAdministrativeUnit is an Entra ID administrative unit. AzureHound does not model administrative units so the
+// ingest format is defined here.
+type AzureAdministrativeUnit struct {
+	Id                           string `json:"id"`
+	DisplayName                  string `json:"displayName"`
+	Description                  string `json:"description"`
+	IsMemberManagementRestricted bool   `json:"isMemberManagementRestricted"`
+	TenantId                     string `json:"tenantId"`
+	TenantName                   string `json:"tenantName"`
+}
+
+type AzureAdministrativeUnitMember struct {
+	Member               json.RawMessage `json:"member"`
+	AdministrativeUnitId string          `json:"administrativeUnitId"`
+}
+
+type AzureAdministrativeUnitMembers struct {
+	Members              []AzureAdministrativeUnitMember `json:"members"`
+	AdministrativeUnitId string                          `json:"administrativeUnitId"`
+}
+
+// AzureCrossTenantAccessPartner is a partner entry from a tenant's cross-tenant access policy. AzureHound does not
+// collect cross-tenant access settings so the ingest format, which follows the Microsoft Graph partner configuration,
+// is defined here.
+type AzureCrossTenantAccessPartner struct {
+	TenantId                string                                  `json:"tenantId"`
+	PartnerTenantId         string                                  `json:"partnerTenantId"`
+	InboundTrust            AzureCrossTenantAccessInboundTrust      `json:"inboundTrust"`
+	IdentitySynchronization AzureCrossTenantIdentitySynchronization `json:"identitySynchronization"`
+}
+
+type AzureCrossTenantAccessInboundTrust struct {
+	IsMfaAccepted                       bool `json:"isMfaAccepted"`
+	IsCompliantDeviceAccepted           bool `json:"isCompliantDeviceAccepted"`
+	IsHybridAzureADJoinedDeviceAccepted bool `json:"isHybridAzureADJoinedDeviceAccepted"`
+}
+
+type AzureCrossTenantIdentitySynchronization struct {
+	UserSyncInbound struct {
+		IsSyncAllowed bool `json:"isSyncAllowed"`
+	} `json:"userSyncInbound"`
+}
+
+// AzureRoleDefinition is an Azure RBAC role definition as returned by Azure Resource Manager. AzureHound only collects
+// the role definition ID of a role assignment so the ingest format is defined here.
+type AzureRoleDefinition struct {
+	Id         string                        `json:"id"`
+	Name       string                        `json:"name"`
+	TenantId   string                        `json:"tenantId"`
+	Properties AzureRoleDefinitionProperties `json:"properties"`
+}
+
+type AzureRoleDefinitionProperties struct {
+	RoleName    string                          `json:"roleName"`
+	Description string                          `json:"description"`
+	Type        string                          `json:"type"`
+	Permissions []AzureRoleDefinitionPermission `json:"permissions"`
+}
+
+type AzureRoleDefinitionPermission struct {
+	Actions        []string `json:"actions"`
+	NotActions     []string `json:"notActions"`
+	DataActions    []string `json:"dataActions"`
+	NotDataActions []string `json:"notDataActions"`
+}
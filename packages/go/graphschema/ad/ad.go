@@ -78,6 +78,12 @@ var (
 	LocalToComputer             = graph.StringKind("LocalToComputer")
 	MemberOfLocalGroup          = graph.StringKind("MemberOfLocalGroup")
 	RemoteInteractiveLogonRight = graph.StringKind("RemoteInteractiveLogonRight")
+	BackupPrivilege             = graph.StringKind("BackupPrivilege")
+	RestorePrivilege            = graph.StringKind("RestorePrivilege")
+	DebugPrivilege              = graph.StringKind("DebugPrivilege")
+	ImpersonatePrivilege        = graph.StringKind("ImpersonatePrivilege")
+	LoadDriverPrivilege         = graph.StringKind("LoadDriverPrivilege")
+	TakeOwnershipPrivilege      = graph.StringKind("TakeOwnershipPrivilege")
 	SyncLAPSPassword            = graph.StringKind("SyncLAPSPassword")
 	WriteAccountRestrictions    = graph.StringKind("WriteAccountRestrictions")
 	WriteGPLink                 = graph.StringKind("WriteGPLink")
@@ -110,6 +116,7 @@ var (
 	ADCSESC10b                  = graph.StringKind("ADCSESC10b")
 	ADCSESC13                   = graph.StringKind("ADCSESC13")
 	SyncedToEntraUser           = graph.StringKind("SyncedToEntraUser")
+	DumpEntraConnectCredentials = graph.StringKind("DumpEntraConnectCredentials")
 )
 
 type Property string
@@ -153,7 +160,17 @@ const (
 	HasLAPS                                 Property = "haslaps"
 	DontRequirePreAuth                      Property = "dontreqpreauth"
 	LogonType                               Property = "logontype"
+	SessionSource                           Property = "sessionsource"
+	NetSessionEnumLastSeen                  Property = "netsessionenumlastseen"
+	NetWkstaUserEnumLastSeen                Property = "netwkstauserenumlastseen"
+	RegistryLastSeen                        Property = "registrylastseen"
 	HasURA                                  Property = "hasura"
+	GPOLocalAdmins                          Property = "gpolocaladmins"
+	GPORemoteDesktopUsers                   Property = "gporemotedesktopusers"
+	GPODcomUsers                            Property = "gpodcomusers"
+	GPOPSRemoteUsers                        Property = "gpopsremoteusers"
+	GPOAdminPrivilegeHolders                Property = "gpoadminprivilegeholders"
+	GPORemoteInteractiveLogonRight          Property = "gporemoteinteractivelogonright"
 	PasswordNeverExpires                    Property = "pwdneverexpires"
 	PasswordNotRequired                     Property = "passwordnotreqd"
 	FunctionalLevel                         Property = "functionallevel"
@@ -219,7 +236,7 @@ const (
 )
 
 func AllProperties() []Property {
-	return []Property{AdminCount, CASecurityCollected, CAName, CertChain, CertName, CertThumbprint, CertThumbprints, HasEnrollmentAgentRestrictions, EnrollmentAgentRestrictionsCollected, IsUserSpecifiesSanEnabled, IsUserSpecifiesSanEnabledCollected, RoleSeparationEnabled, RoleSeparationEnabledCollected, HasBasicConstraints, BasicConstraintPathLength, UnresolvedPublishedTemplates, DNSHostname, CrossCertificatePair, DistinguishedName, DomainFQDN, DomainSID, Sensitive, HighValue, BlocksInheritance, IsACL, IsACLProtected, IsDeleted, Enforced, Department, HasCrossCertificatePair, HasSPN, UnconstrainedDelegation, LastLogon, LastLogonTimestamp, IsPrimaryGroup, HasLAPS, DontRequirePreAuth, LogonType, HasURA, PasswordNeverExpires, PasswordNotRequired, FunctionalLevel, TrustType, SidFiltering, TrustedToAuth, SamAccountName, CertificateMappingMethodsRaw, CertificateMappingMethods, StrongCertificateBindingEnforcementRaw, StrongCertificateBindingEnforcement, EKUs, SubjectAltRequireUPN, SubjectAltRequireDNS, SubjectAltRequireDomainDNS, SubjectAltRequireEmail, SubjectAltRequireSPN, SubjectRequireEmail, AuthorizedSignatures, ApplicationPolicies, IssuancePolicies, SchemaVersion, RequiresManagerApproval, AuthenticationEnabled, SchannelAuthenticationEnabled, EnrolleeSuppliesSubject, CertificateApplicationPolicy, CertificateNameFlag, EffectiveEKUs, EnrollmentFlag, Flags, NoSecurityExtension, RenewalPeriod, ValidityPeriod, OID, HomeDirectory, CertificatePolicy, CertTemplateOID, GroupLinkID, ObjectGUID, ExpirePasswordsOnSmartCardOnlyAccounts, MachineAccountQuota, SupportedKerberosEncryptionTypes, TGTDelegationEnabled, PasswordStoredUsingReversibleEncryption, SmartcardRequired, UseDESKeyOnly, LogonScriptEnabled, LockedOut, UserCannotChangePassword, PasswordExpired, DSHeuristics, UserAccountControl, TrustAttributes, MinPwdLength, PwdProperties, PwdHistoryLength, LockoutThreshold, MinPwdAge, MaxPwdAge, LockoutDuration, LockoutObservationWindow}
+	return []Property{AdminCount, CASecurityCollected, CAName, CertChain, CertName, CertThumbprint, CertThumbprints, HasEnrollmentAgentRestrictions, EnrollmentAgentRestrictionsCollected, IsUserSpecifiesSanEnabled, IsUserSpecifiesSanEnabledCollected, RoleSeparationEnabled, RoleSeparationEnabledCollected, HasBasicConstraints, BasicConstraintPathLength, UnresolvedPublishedTemplates, DNSHostname, CrossCertificatePair, DistinguishedName, DomainFQDN, DomainSID, Sensitive, HighValue, BlocksInheritance, IsACL, IsACLProtected, IsDeleted, Enforced, Department, HasCrossCertificatePair, HasSPN, UnconstrainedDelegation, LastLogon, LastLogonTimestamp, IsPrimaryGroup, HasLAPS, DontRequirePreAuth, LogonType, SessionSource, NetSessionEnumLastSeen, NetWkstaUserEnumLastSeen, RegistryLastSeen, HasURA, GPOLocalAdmins, GPORemoteDesktopUsers, GPODcomUsers, GPOPSRemoteUsers, GPOAdminPrivilegeHolders, GPORemoteInteractiveLogonRight, PasswordNeverExpires, PasswordNotRequired, FunctionalLevel, TrustType, SidFiltering, TrustedToAuth, SamAccountName, CertificateMappingMethodsRaw, CertificateMappingMethods, StrongCertificateBindingEnforcementRaw, StrongCertificateBindingEnforcement, EKUs, SubjectAltRequireUPN, SubjectAltRequireDNS, SubjectAltRequireDomainDNS, SubjectAltRequireEmail, SubjectAltRequireSPN, SubjectRequireEmail, AuthorizedSignatures, ApplicationPolicies, IssuancePolicies, SchemaVersion, RequiresManagerApproval, AuthenticationEnabled, SchannelAuthenticationEnabled, EnrolleeSuppliesSubject, CertificateApplicationPolicy, CertificateNameFlag, EffectiveEKUs, EnrollmentFlag, Flags, NoSecurityExtension, RenewalPeriod, ValidityPeriod, OID, HomeDirectory, CertificatePolicy, CertTemplateOID, GroupLinkID, ObjectGUID, ExpirePasswordsOnSmartCardOnlyAccounts, MachineAccountQuota, SupportedKerberosEncryptionTypes, TGTDelegationEnabled, PasswordStoredUsingReversibleEncryption, SmartcardRequired, UseDESKeyOnly, LogonScriptEnabled, LockedOut, UserCannotChangePassword, PasswordExpired, DSHeuristics, UserAccountControl, TrustAttributes, MinPwdLength, PwdProperties, PwdHistoryLength, LockoutThreshold, MinPwdAge, MaxPwdAge, LockoutDuration, LockoutObservationWindow}
 }
 func ParseProperty(source string) (Property, error) {
 	switch source {
@@ -299,8 +316,28 @@ func ParseProperty(source string) (Property, error) {
 		return DontRequirePreAuth, nil
 	case "logontype":
 		return LogonType, nil
+	case "sessionsource":
+		return SessionSource, nil
+	case "netsessionenumlastseen":
+		return NetSessionEnumLastSeen, nil
+	case "netwkstauserenumlastseen":
+		return NetWkstaUserEnumLastSeen, nil
+	case "registrylastseen":
+		return RegistryLastSeen, nil
 	case "hasura":
 		return HasURA, nil
+	case "gpolocaladmins":
+		return GPOLocalAdmins, nil
+	case "gporemotedesktopusers":
+		return GPORemoteDesktopUsers, nil
+	case "gpodcomusers":
+		return GPODcomUsers, nil
+	case "gpopsremoteusers":
+		return GPOPSRemoteUsers, nil
+	case "gpoadminprivilegeholders":
+		return GPOAdminPrivilegeHolders, nil
+	case "gporemoteinteractivelogonright":
+		return GPORemoteInteractiveLogonRight, nil
 	case "pwdneverexpires":
 		return PasswordNeverExpires, nil
 	case "passwordnotreqd":
@@ -507,8 +544,28 @@ func (s Property) String() string {
 		return string(DontRequirePreAuth)
 	case LogonType:
 		return string(LogonType)
+	case SessionSource:
+		return string(SessionSource)
+	case NetSessionEnumLastSeen:
+		return string(NetSessionEnumLastSeen)
+	case NetWkstaUserEnumLastSeen:
+		return string(NetWkstaUserEnumLastSeen)
+	case RegistryLastSeen:
+		return string(RegistryLastSeen)
 	case HasURA:
 		return string(HasURA)
+	case GPOLocalAdmins:
+		return string(GPOLocalAdmins)
+	case GPORemoteDesktopUsers:
+		return string(GPORemoteDesktopUsers)
+	case GPODcomUsers:
+		return string(GPODcomUsers)
+	case GPOPSRemoteUsers:
+		return string(GPOPSRemoteUsers)
+	case GPOAdminPrivilegeHolders:
+		return string(GPOAdminPrivilegeHolders)
+	case GPORemoteInteractiveLogonRight:
+		return string(GPORemoteInteractiveLogonRight)
 	case PasswordNeverExpires:
 		return string(PasswordNeverExpires)
 	case PasswordNotRequired:
@@ -715,8 +772,28 @@ func (s Property) Name() string {
 		return "Do Not Require Pre-Authentication"
 	case LogonType:
 		return "Logon Type"
+	case SessionSource:
+		return "Session Source"
+	case NetSessionEnumLastSeen:
+		return "NetSessionEnum Last Seen"
+	case NetWkstaUserEnumLastSeen:
+		return "NetWkstaUserEnum Last Seen"
+	case RegistryLastSeen:
+		return "Registry Last Seen"
 	case HasURA:
 		return "Has User Rights Assignment Collection"
+	case GPOLocalAdmins:
+		return "GPO Local Admins"
+	case GPORemoteDesktopUsers:
+		return "GPO Remote Desktop Users"
+	case GPODcomUsers:
+		return "GPO DCOM Users"
+	case GPOPSRemoteUsers:
+		return "GPO PSRemote Users"
+	case GPOAdminPrivilegeHolders:
+		return "GPO Admin Privilege Holders"
+	case GPORemoteInteractiveLogonRight:
+		return "GPO Remote Interactive Logon Right"
 	case PasswordNeverExpires:
 		return "Password Never Expires"
 	case PasswordNotRequired:
@@ -857,13 +934,13 @@ func Nodes() []graph.Kind {
 	return []graph.Kind{Entity, User, Computer, Group, GPO, OU, Container, Domain, LocalGroup, LocalUser, AIACA, RootCA, EnterpriseCA, NTAuthStore, CertTemplate, IssuancePolicy}
 }
 func Relationships() []graph.Kind {
-	return []graph.Kind{Owns, GenericAll, GenericWrite, WriteOwner, WriteDACL, MemberOf, ForceChangePassword, AllExtendedRights, AddMember, HasSession, Contains, GPLink, AllowedToDelegate, CoerceToTGT, GetChanges, GetChangesAll, GetChangesInFilteredSet, TrustedBy, AllowedToAct, AdminTo, CanPSRemote, CanRDP, ExecuteDCOM, HasSIDHistory, AddSelf, DCSync, ReadLAPSPassword, ReadGMSAPassword, DumpSMSAPassword, SQLAdmin, AddAllowedToAct, WriteSPN, AddKeyCredentialLink, LocalToComputer, MemberOfLocalGroup, RemoteInteractiveLogonRight, SyncLAPSPassword, WriteAccountRestrictions, WriteGPLink, RootCAFor, DCFor, PublishedTo, ManageCertificates, ManageCA, DelegatedEnrollmentAgent, Enroll, HostsCAService, WritePKIEnrollmentFlag, WritePKINameFlag, NTAuthStoreFor, TrustedForNTAuth, EnterpriseCAFor, IssuedSignedBy, GoldenCert, EnrollOnBehalfOf, OIDGroupLink, ExtendedByPolicy, ADCSESC1, ADCSESC3, ADCSESC4, ADCSESC6a, ADCSESC6b, ADCSESC9a, ADCSESC9b, ADCSESC10a, ADCSESC10b, ADCSESC13, SyncedToEntraUser}
+	return []graph.Kind{Owns, GenericAll, GenericWrite, WriteOwner, WriteDACL, MemberOf, ForceChangePassword, AllExtendedRights, AddMember, HasSession, Contains, GPLink, AllowedToDelegate, CoerceToTGT, GetChanges, GetChangesAll, GetChangesInFilteredSet, TrustedBy, AllowedToAct, AdminTo, CanPSRemote, CanRDP, ExecuteDCOM, HasSIDHistory, AddSelf, DCSync, ReadLAPSPassword, ReadGMSAPassword, DumpSMSAPassword, SQLAdmin, AddAllowedToAct, WriteSPN, AddKeyCredentialLink, LocalToComputer, MemberOfLocalGroup, RemoteInteractiveLogonRight, BackupPrivilege, RestorePrivilege, DebugPrivilege, ImpersonatePrivilege, LoadDriverPrivilege, TakeOwnershipPrivilege, SyncLAPSPassword, WriteAccountRestrictions, WriteGPLink, RootCAFor, DCFor, PublishedTo, ManageCertificates, ManageCA, DelegatedEnrollmentAgent, Enroll, HostsCAService, WritePKIEnrollmentFlag, WritePKINameFlag, NTAuthStoreFor, TrustedForNTAuth, EnterpriseCAFor, IssuedSignedBy, GoldenCert, EnrollOnBehalfOf, OIDGroupLink, ExtendedByPolicy, ADCSESC1, ADCSESC3, ADCSESC4, ADCSESC6a, ADCSESC6b, ADCSESC9a, ADCSESC9b, ADCSESC10a, ADCSESC10b, ADCSESC13, SyncedToEntraUser, DumpEntraConnectCredentials}
 }
 func ACLRelationships() []graph.Kind {
 	return []graph.Kind{AllExtendedRights, ForceChangePassword, AddMember, AddAllowedToAct, GenericAll, WriteDACL, WriteOwner, GenericWrite, ReadLAPSPassword, ReadGMSAPassword, Owns, AddSelf, WriteSPN, AddKeyCredentialLink, GetChanges, GetChangesAll, GetChangesInFilteredSet, WriteAccountRestrictions, WriteGPLink, SyncLAPSPassword, DCSync, ManageCertificates, ManageCA, Enroll, WritePKIEnrollmentFlag, WritePKINameFlag}
 }
 func PathfindingRelationships() []graph.Kind {
-	return []graph.Kind{Owns, GenericAll, GenericWrite, WriteOwner, WriteDACL, MemberOf, ForceChangePassword, AllExtendedRights, AddMember, HasSession, Contains, GPLink, AllowedToDelegate, CoerceToTGT, TrustedBy, AllowedToAct, AdminTo, CanPSRemote, CanRDP, ExecuteDCOM, HasSIDHistory, AddSelf, DCSync, ReadLAPSPassword, ReadGMSAPassword, DumpSMSAPassword, SQLAdmin, AddAllowedToAct, WriteSPN, AddKeyCredentialLink, SyncLAPSPassword, WriteAccountRestrictions, WriteGPLink, GoldenCert, ADCSESC1, ADCSESC3, ADCSESC4, ADCSESC6a, ADCSESC6b, ADCSESC9a, ADCSESC9b, ADCSESC10a, ADCSESC10b, ADCSESC13, DCFor, SyncedToEntraUser}
+	return []graph.Kind{Owns, GenericAll, GenericWrite, WriteOwner, WriteDACL, MemberOf, ForceChangePassword, AllExtendedRights, AddMember, HasSession, Contains, GPLink, AllowedToDelegate, CoerceToTGT, TrustedBy, AllowedToAct, AdminTo, CanPSRemote, CanRDP, ExecuteDCOM, HasSIDHistory, AddSelf, DCSync, ReadLAPSPassword, ReadGMSAPassword, DumpSMSAPassword, SQLAdmin, AddAllowedToAct, WriteSPN, AddKeyCredentialLink, SyncLAPSPassword, WriteAccountRestrictions, WriteGPLink, GoldenCert, ADCSESC1, ADCSESC3, ADCSESC4, ADCSESC6a, ADCSESC6b, ADCSESC9a, ADCSESC9b, ADCSESC10a, ADCSESC10b, ADCSESC13, DCFor, SyncedToEntraUser, DumpEntraConnectCredentials}
 }
 func IsACLKind(s graph.Kind) bool {
 	for _, acl := range ACLRelationships() {
@@ -46,6 +46,10 @@ var (
 	WebApp                               = graph.StringKind("AZWebApp")
 	LogicApp                             = graph.StringKind("AZLogicApp")
 	AutomationAccount                    = graph.StringKind("AZAutomationAccount")
+	AdministrativeUnit                   = graph.StringKind("AZAdministrativeUnit")
+	StorageAccount                       = graph.StringKind("AZStorageAccount")
+	StorageContainer                     = graph.StringKind("AZStorageContainer")
+	RoleDefinition                       = graph.StringKind("AZRoleDefinition")
 	AvereContributor                     = graph.StringKind("AZAvereContributor")
 	Contains                             = graph.StringKind("AZContains")
 	Contributor                          = graph.StringKind("AZContributor")
@@ -93,47 +97,68 @@ var (
 	AZMGGrantAppRoles                    = graph.StringKind("AZMGGrantAppRoles")
 	AZMGGrantRole                        = graph.StringKind("AZMGGrantRole")
 	SyncedToADUser                       = graph.StringKind("SyncedToADUser")
+	AdministrativeUnitMember             = graph.StringKind("AZAdministrativeUnitMember")
+	HasScopedRole                        = graph.StringKind("AZHasScopedRole")
+	StorageAccountContributor            = graph.StringKind("AZStorageAccountContributor")
+	StorageAccountKeyOperator            = graph.StringKind("AZStorageAccountKeyOperator")
+	StorageBlobDataOwner                 = graph.StringKind("AZStorageBlobDataOwner")
+	StorageBlobDataContributor           = graph.StringKind("AZStorageBlobDataContributor")
+	ListStorageKeys                      = graph.StringKind("AZListStorageKeys")
+	HasGuestAccount                      = graph.StringKind("AZHasGuestAccount")
+	CrossTenantAccess                    = graph.StringKind("AZCrossTenantAccess")
+	RBACRoleAssignment                   = graph.StringKind("AZRBACRoleAssignment")
 )
 
 type Property string
 
 const (
-	AppOwnerOrganizationID  Property = "appownerorganizationid"
-	AppDescription          Property = "appdescription"
-	AppDisplayName          Property = "appdisplayname"
-	ServicePrincipalType    Property = "serviceprincipaltype"
-	UserType                Property = "usertype"
-	TenantID                Property = "tenantid"
-	ServicePrincipalID      Property = "service_principal_id"
-	ServicePrincipalNames   Property = "service_principal_names"
-	OperatingSystemVersion  Property = "operatingsystemversion"
-	TrustType               Property = "trustype"
-	IsBuiltIn               Property = "isbuiltin"
-	AppID                   Property = "appid"
-	AppRoleID               Property = "approleid"
-	DeviceID                Property = "deviceid"
-	NodeResourceGroupID     Property = "noderesourcegroupid"
-	OnPremID                Property = "onpremid"
-	OnPremSyncEnabled       Property = "onpremsyncenabled"
-	SecurityEnabled         Property = "securityenabled"
-	SecurityIdentifier      Property = "securityidentifier"
-	EnableRBACAuthorization Property = "enablerbacauthorization"
-	Scope                   Property = "scope"
-	Offer                   Property = "offer"
-	MFAEnabled              Property = "mfaenabled"
-	License                 Property = "license"
-	Licenses                Property = "licenses"
-	LoginURL                Property = "loginurl"
-	MFAEnforced             Property = "mfaenforced"
-	UserPrincipalName       Property = "userprincipalname"
-	IsAssignableToRole      Property = "isassignabletorole"
-	PublisherDomain         Property = "publisherdomain"
-	SignInAudience          Property = "signinaudience"
-	RoleTemplateID          Property = "templateid"
+	AppOwnerOrganizationID       Property = "appownerorganizationid"
+	AppDescription               Property = "appdescription"
+	AppDisplayName               Property = "appdisplayname"
+	ServicePrincipalType         Property = "serviceprincipaltype"
+	UserType                     Property = "usertype"
+	TenantID                     Property = "tenantid"
+	ServicePrincipalID           Property = "service_principal_id"
+	ServicePrincipalNames        Property = "service_principal_names"
+	OperatingSystemVersion       Property = "operatingsystemversion"
+	TrustType                    Property = "trustype"
+	IsBuiltIn                    Property = "isbuiltin"
+	AppID                        Property = "appid"
+	AppRoleID                    Property = "approleid"
+	DeviceID                     Property = "deviceid"
+	NodeResourceGroupID          Property = "noderesourcegroupid"
+	OnPremID                     Property = "onpremid"
+	OnPremSyncEnabled            Property = "onpremsyncenabled"
+	SecurityEnabled              Property = "securityenabled"
+	SecurityIdentifier           Property = "securityidentifier"
+	EnableRBACAuthorization      Property = "enablerbacauthorization"
+	Scope                        Property = "scope"
+	Offer                        Property = "offer"
+	MFAEnabled                   Property = "mfaenabled"
+	License                      Property = "license"
+	Licenses                     Property = "licenses"
+	LoginURL                     Property = "loginurl"
+	MFAEnforced                  Property = "mfaenforced"
+	UserPrincipalName            Property = "userprincipalname"
+	IsAssignableToRole           Property = "isassignabletorole"
+	PublisherDomain              Property = "publisherdomain"
+	SignInAudience               Property = "signinaudience"
+	RoleTemplateID               Property = "templateid"
+	AllowedResourceActions       Property = "allowedresourceactions"
+	ExcludedResourceActions      Property = "excludedresourceactions"
+	AllowedDataActions           Property = "alloweddataactions"
+	ExcludedDataActions          Property = "excludeddataactions"
+	RoleDefinitionID             Property = "roledefinitionid"
+	IsMemberManagementRestricted Property = "ismembermanagementrestricted"
+	AllowSharedKeyAccess         Property = "allowsharedkeyaccess"
+	IsMFAAccepted                Property = "ismfaaccepted"
+	IsCompliantDeviceAccepted    Property = "iscompliantdeviceaccepted"
+	IsHybridJoinedDeviceAccepted Property = "ishybridjoineddeviceaccepted"
+	IsInboundSyncAllowed         Property = "isinboundsyncallowed"
 )
 
 func AllProperties() []Property {
-	return []Property{AppOwnerOrganizationID, AppDescription, AppDisplayName, ServicePrincipalType, UserType, TenantID, ServicePrincipalID, ServicePrincipalNames, OperatingSystemVersion, TrustType, IsBuiltIn, AppID, AppRoleID, DeviceID, NodeResourceGroupID, OnPremID, OnPremSyncEnabled, SecurityEnabled, SecurityIdentifier, EnableRBACAuthorization, Scope, Offer, MFAEnabled, License, Licenses, LoginURL, MFAEnforced, UserPrincipalName, IsAssignableToRole, PublisherDomain, SignInAudience, RoleTemplateID}
+	return []Property{AppOwnerOrganizationID, AppDescription, AppDisplayName, ServicePrincipalType, UserType, TenantID, ServicePrincipalID, ServicePrincipalNames, OperatingSystemVersion, TrustType, IsBuiltIn, AppID, AppRoleID, DeviceID, NodeResourceGroupID, OnPremID, OnPremSyncEnabled, SecurityEnabled, SecurityIdentifier, EnableRBACAuthorization, Scope, Offer, MFAEnabled, License, Licenses, LoginURL, MFAEnforced, UserPrincipalName, IsAssignableToRole, PublisherDomain, SignInAudience, RoleTemplateID, AllowedResourceActions, ExcludedResourceActions, AllowedDataActions, ExcludedDataActions, RoleDefinitionID, IsMemberManagementRestricted, AllowSharedKeyAccess, IsMFAAccepted, IsCompliantDeviceAccepted, IsHybridJoinedDeviceAccepted, IsInboundSyncAllowed}
 }
 func ParseProperty(source string) (Property, error) {
 	switch source {
@@ -201,6 +226,28 @@ func ParseProperty(source string) (Property, error) {
 		return SignInAudience, nil
 	case "templateid":
 		return RoleTemplateID, nil
+	case "allowedresourceactions":
+		return AllowedResourceActions, nil
+	case "excludedresourceactions":
+		return ExcludedResourceActions, nil
+	case "alloweddataactions":
+		return AllowedDataActions, nil
+	case "excludeddataactions":
+		return ExcludedDataActions, nil
+	case "roledefinitionid":
+		return RoleDefinitionID, nil
+	case "ismembermanagementrestricted":
+		return IsMemberManagementRestricted, nil
+	case "allowsharedkeyaccess":
+		return AllowSharedKeyAccess, nil
+	case "ismfaaccepted":
+		return IsMFAAccepted, nil
+	case "iscompliantdeviceaccepted":
+		return IsCompliantDeviceAccepted, nil
+	case "ishybridjoineddeviceaccepted":
+		return IsHybridJoinedDeviceAccepted, nil
+	case "isinboundsyncallowed":
+		return IsInboundSyncAllowed, nil
 	default:
 		return "", errors.New("Invalid enumeration value: " + source)
 	}
@@ -271,6 +318,28 @@ func (s Property) String() string {
 		return string(SignInAudience)
 	case RoleTemplateID:
 		return string(RoleTemplateID)
+	case AllowedResourceActions:
+		return string(AllowedResourceActions)
+	case ExcludedResourceActions:
+		return string(ExcludedResourceActions)
+	case AllowedDataActions:
+		return string(AllowedDataActions)
+	case ExcludedDataActions:
+		return string(ExcludedDataActions)
+	case RoleDefinitionID:
+		return string(RoleDefinitionID)
+	case IsMemberManagementRestricted:
+		return string(IsMemberManagementRestricted)
+	case AllowSharedKeyAccess:
+		return string(AllowSharedKeyAccess)
+	case IsMFAAccepted:
+		return string(IsMFAAccepted)
+	case IsCompliantDeviceAccepted:
+		return string(IsCompliantDeviceAccepted)
+	case IsHybridJoinedDeviceAccepted:
+		return string(IsHybridJoinedDeviceAccepted)
+	case IsInboundSyncAllowed:
+		return string(IsInboundSyncAllowed)
 	default:
 		return "Invalid enumeration case: " + string(s)
 	}
@@ -341,6 +410,28 @@ func (s Property) Name() string {
 		return "Sign In Audience"
 	case RoleTemplateID:
 		return "Role Template ID"
+	case AllowedResourceActions:
+		return "Allowed Resource Actions"
+	case ExcludedResourceActions:
+		return "Excluded Resource Actions"
+	case AllowedDataActions:
+		return "Allowed Data Actions"
+	case ExcludedDataActions:
+		return "Excluded Data Actions"
+	case RoleDefinitionID:
+		return "Role Definition ID"
+	case IsMemberManagementRestricted:
+		return "Is Member Management Restricted"
+	case AllowSharedKeyAccess:
+		return "Allow Shared Key Access"
+	case IsMFAAccepted:
+		return "Is MFA Accepted"
+	case IsCompliantDeviceAccepted:
+		return "Is Compliant Device Accepted"
+	case IsHybridJoinedDeviceAccepted:
+		return "Is Hybrid Joined Device Accepted"
+	case IsInboundSyncAllowed:
+		return "Is Inbound Sync Allowed"
 	default:
 		return "Invalid enumeration case: " + string(s)
 	}
@@ -354,7 +445,7 @@ func (s Property) Is(others ...graph.Kind) bool {
 	return false
 }
 func Relationships() []graph.Kind {
-	return []graph.Kind{AvereContributor, Contains, Contributor, GetCertificates, GetKeys, GetSecrets, HasRole, MemberOf, Owner, RunsAs, VMContributor, AutomationContributor, KeyVaultContributor, VMAdminLogin, AddMembers, AddSecret, ExecuteCommand, GlobalAdmin, PrivilegedAuthAdmin, Grant, GrantSelf, PrivilegedRoleAdmin, ResetPassword, UserAccessAdministrator, Owns, ScopedTo, CloudAppAdmin, AppAdmin, AddOwner, ManagedIdentity, ApplicationReadWriteAll, AppRoleAssignmentReadWriteAll, DirectoryReadWriteAll, GroupReadWriteAll, GroupMemberReadWriteAll, RoleManagementReadWriteDirectory, ServicePrincipalEndpointReadWriteAll, AKSContributor, NodeResourceGroup, WebsiteContributor, LogicAppContributor, AZMGAddMember, AZMGAddOwner, AZMGAddSecret, AZMGGrantAppRoles, AZMGGrantRole, SyncedToADUser}
+	return []graph.Kind{AvereContributor, Contains, Contributor, GetCertificates, GetKeys, GetSecrets, HasRole, MemberOf, Owner, RunsAs, VMContributor, AutomationContributor, KeyVaultContributor, VMAdminLogin, AddMembers, AddSecret, ExecuteCommand, GlobalAdmin, PrivilegedAuthAdmin, Grant, GrantSelf, PrivilegedRoleAdmin, ResetPassword, UserAccessAdministrator, Owns, ScopedTo, CloudAppAdmin, AppAdmin, AddOwner, ManagedIdentity, ApplicationReadWriteAll, AppRoleAssignmentReadWriteAll, DirectoryReadWriteAll, GroupReadWriteAll, GroupMemberReadWriteAll, RoleManagementReadWriteDirectory, ServicePrincipalEndpointReadWriteAll, AKSContributor, NodeResourceGroup, WebsiteContributor, LogicAppContributor, AZMGAddMember, AZMGAddOwner, AZMGAddSecret, AZMGGrantAppRoles, AZMGGrantRole, SyncedToADUser, AdministrativeUnitMember, HasScopedRole, StorageAccountContributor, StorageAccountKeyOperator, StorageBlobDataOwner, StorageBlobDataContributor, ListStorageKeys, HasGuestAccount, CrossTenantAccess, RBACRoleAssignment}
 }
 func AppRoleTransitRelationshipKinds() []graph.Kind {
 	return []graph.Kind{AZMGAddMember, AZMGAddOwner, AZMGAddSecret, AZMGGrantAppRoles, AZMGGrantRole}
@@ -363,14 +454,14 @@ func AbusableAppRoleRelationshipKinds() []graph.Kind {
 	return []graph.Kind{ApplicationReadWriteAll, AppRoleAssignmentReadWriteAll, DirectoryReadWriteAll, GroupReadWriteAll, GroupMemberReadWriteAll, RoleManagementReadWriteDirectory, ServicePrincipalEndpointReadWriteAll}
 }
 func ControlRelationships() []graph.Kind {
-	return []graph.Kind{AvereContributor, Contributor, Owner, VMContributor, AutomationContributor, KeyVaultContributor, AddMembers, AddSecret, ExecuteCommand, GlobalAdmin, Grant, GrantSelf, PrivilegedRoleAdmin, ResetPassword, UserAccessAdministrator, Owns, CloudAppAdmin, AppAdmin, AddOwner, ManagedIdentity, AKSContributor, WebsiteContributor, LogicAppContributor, AZMGAddMember, AZMGAddOwner, AZMGAddSecret, AZMGGrantAppRoles, AZMGGrantRole}
+	return []graph.Kind{AvereContributor, Contributor, Owner, VMContributor, AutomationContributor, KeyVaultContributor, AddMembers, AddSecret, ExecuteCommand, GlobalAdmin, Grant, GrantSelf, PrivilegedRoleAdmin, ResetPassword, UserAccessAdministrator, Owns, CloudAppAdmin, AppAdmin, AddOwner, ManagedIdentity, AKSContributor, WebsiteContributor, LogicAppContributor, AZMGAddMember, AZMGAddOwner, AZMGAddSecret, AZMGGrantAppRoles, AZMGGrantRole, StorageAccountContributor, StorageAccountKeyOperator}
 }
 func ExecutionPrivileges() []graph.Kind {
 	return []graph.Kind{VMAdminLogin, VMContributor, AvereContributor, WebsiteContributor, Contributor, ExecuteCommand}
 }
 func PathfindingRelationships() []graph.Kind {
-	return []graph.Kind{AvereContributor, Contains, Contributor, GetCertificates, GetKeys, GetSecrets, HasRole, MemberOf, Owner, RunsAs, VMContributor, AutomationContributor, KeyVaultContributor, VMAdminLogin, AddMembers, AddSecret, ExecuteCommand, GlobalAdmin, PrivilegedAuthAdmin, Grant, GrantSelf, PrivilegedRoleAdmin, ResetPassword, UserAccessAdministrator, Owns, CloudAppAdmin, AppAdmin, AddOwner, ManagedIdentity, AKSContributor, NodeResourceGroup, WebsiteContributor, LogicAppContributor, AZMGAddMember, AZMGAddOwner, AZMGAddSecret, AZMGGrantAppRoles, AZMGGrantRole, SyncedToADUser}
+	return []graph.Kind{AvereContributor, Contains, Contributor, GetCertificates, GetKeys, GetSecrets, HasRole, MemberOf, Owner, RunsAs, VMContributor, AutomationContributor, KeyVaultContributor, VMAdminLogin, AddMembers, AddSecret, ExecuteCommand, GlobalAdmin, PrivilegedAuthAdmin, Grant, GrantSelf, PrivilegedRoleAdmin, ResetPassword, UserAccessAdministrator, Owns, CloudAppAdmin, AppAdmin, AddOwner, ManagedIdentity, AKSContributor, NodeResourceGroup, WebsiteContributor, LogicAppContributor, AZMGAddMember, AZMGAddOwner, AZMGAddSecret, AZMGGrantAppRoles, AZMGGrantRole, SyncedToADUser, StorageAccountContributor, StorageAccountKeyOperator, StorageBlobDataOwner, StorageBlobDataContributor, ListStorageKeys, HasGuestAccount}
 }
 func NodeKinds() []graph.Kind {
-	return []graph.Kind{Entity, VMScaleSet, App, Role, Device, FunctionApp, Group, KeyVault, ManagementGroup, ResourceGroup, ServicePrincipal, Subscription, Tenant, User, VM, ManagedCluster, ContainerRegistry, WebApp, LogicApp, AutomationAccount}
+	return []graph.Kind{Entity, VMScaleSet, App, Role, Device, FunctionApp, Group, KeyVault, ManagementGroup, ResourceGroup, ServicePrincipal, Subscription, Tenant, User, VM, ManagedCluster, ContainerRegistry, WebApp, LogicApp, AutomationAccount, AdministrativeUnit, StorageAccount, StorageContainer, RoleDefinition}
 }
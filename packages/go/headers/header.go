@@ -26,7 +26,10 @@ func (s Header) String() string {
 
 // Non-standard headers
 const (
-	RequestDate Header = "RequestDate"
-	RequestID   Header = "RequestID"
-	Signature   Header = "Signature" // https://www.ietf.org/archive/id/draft-ietf-httpbis-message-signatures-04.html#name-the-signature-http-header
+	RequestDate  Header = "RequestDate"
+	RequestID    Header = "RequestID"
+	Signature    Header = "Signature" // https://www.ietf.org/archive/id/draft-ietf-httpbis-message-signatures-04.html#name-the-signature-http-header
+	UploadLength Header = "Upload-Length"
+	UploadOffset Header = "Upload-Offset"
+	Workspace    Header = "Workspace"
 )
@@ -29,6 +29,8 @@ var (
 	StartNode         = newParam("start_node", nil)
 	EndNode           = newParam("end_node", nil)
 	RelationshipKinds = newParam("relationship_kinds", containsPredicate)
+	SessionMaxAge     = newParam("session_max_age", nil)
+	SessionSource     = newParam("session_source", nil)
 )
 
 // param is an immutable path or query parameter
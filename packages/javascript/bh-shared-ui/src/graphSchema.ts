@@ -107,6 +107,12 @@ export enum ActiveDirectoryRelationshipKind {
     LocalToComputer = 'LocalToComputer',
     MemberOfLocalGroup = 'MemberOfLocalGroup',
     RemoteInteractiveLogonRight = 'RemoteInteractiveLogonRight',
+    BackupPrivilege = 'BackupPrivilege',
+    RestorePrivilege = 'RestorePrivilege',
+    DebugPrivilege = 'DebugPrivilege',
+    ImpersonatePrivilege = 'ImpersonatePrivilege',
+    LoadDriverPrivilege = 'LoadDriverPrivilege',
+    TakeOwnershipPrivilege = 'TakeOwnershipPrivilege',
     SyncLAPSPassword = 'SyncLAPSPassword',
     WriteAccountRestrictions = 'WriteAccountRestrictions',
     WriteGPLink = 'WriteGPLink',
@@ -139,6 +145,7 @@ export enum ActiveDirectoryRelationshipKind {
     ADCSESC10b = 'ADCSESC10b',
     ADCSESC13 = 'ADCSESC13',
     SyncedToEntraUser = 'SyncedToEntraUser',
+    DumpEntraConnectCredentials = 'DumpEntraConnectCredentials',
 }
 export function ActiveDirectoryRelationshipKindToDisplay(value: ActiveDirectoryRelationshipKind): string | undefined {
     switch (value) {
@@ -214,6 +221,18 @@ export function ActiveDirectoryRelationshipKindToDisplay(value: ActiveDirectoryR
             return 'MemberOfLocalGroup';
         case ActiveDirectoryRelationshipKind.RemoteInteractiveLogonRight:
             return 'RemoteInteractiveLogonRight';
+        case ActiveDirectoryRelationshipKind.BackupPrivilege:
+            return 'BackupPrivilege';
+        case ActiveDirectoryRelationshipKind.RestorePrivilege:
+            return 'RestorePrivilege';
+        case ActiveDirectoryRelationshipKind.DebugPrivilege:
+            return 'DebugPrivilege';
+        case ActiveDirectoryRelationshipKind.ImpersonatePrivilege:
+            return 'ImpersonatePrivilege';
+        case ActiveDirectoryRelationshipKind.LoadDriverPrivilege:
+            return 'LoadDriverPrivilege';
+        case ActiveDirectoryRelationshipKind.TakeOwnershipPrivilege:
+            return 'TakeOwnershipPrivilege';
         case ActiveDirectoryRelationshipKind.SyncLAPSPassword:
             return 'SyncLAPSPassword';
         case ActiveDirectoryRelationshipKind.WriteAccountRestrictions:
@@ -278,6 +297,8 @@ export function ActiveDirectoryRelationshipKindToDisplay(value: ActiveDirectoryR
             return 'ADCSESC13';
         case ActiveDirectoryRelationshipKind.SyncedToEntraUser:
             return 'SyncedToEntraUser';
+        case ActiveDirectoryRelationshipKind.DumpEntraConnectCredentials:
+            return 'DumpEntraConnectCredentials';
         default:
             return undefined;
     }
@@ -335,7 +356,17 @@ export enum ActiveDirectoryKindProperties {
     HasLAPS = 'haslaps',
     DontRequirePreAuth = 'dontreqpreauth',
     LogonType = 'logontype',
+    SessionSource = 'sessionsource',
+    NetSessionEnumLastSeen = 'netsessionenumlastseen',
+    NetWkstaUserEnumLastSeen = 'netwkstauserenumlastseen',
+    RegistryLastSeen = 'registrylastseen',
     HasURA = 'hasura',
+    GPOLocalAdmins = 'gpolocaladmins',
+    GPORemoteDesktopUsers = 'gporemotedesktopusers',
+    GPODcomUsers = 'gpodcomusers',
+    GPOPSRemoteUsers = 'gpopsremoteusers',
+    GPOAdminPrivilegeHolders = 'gpoadminprivilegeholders',
+    GPORemoteInteractiveLogonRight = 'gporemoteinteractivelogonright',
     PasswordNeverExpires = 'pwdneverexpires',
     PasswordNotRequired = 'passwordnotreqd',
     FunctionalLevel = 'functionallevel',
@@ -477,8 +508,28 @@ export function ActiveDirectoryKindPropertiesToDisplay(value: ActiveDirectoryKin
             return 'Do Not Require Pre-Authentication';
         case ActiveDirectoryKindProperties.LogonType:
             return 'Logon Type';
+        case ActiveDirectoryKindProperties.SessionSource:
+            return 'Session Source';
+        case ActiveDirectoryKindProperties.NetSessionEnumLastSeen:
+            return 'NetSessionEnum Last Seen';
+        case ActiveDirectoryKindProperties.NetWkstaUserEnumLastSeen:
+            return 'NetWkstaUserEnum Last Seen';
+        case ActiveDirectoryKindProperties.RegistryLastSeen:
+            return 'Registry Last Seen';
         case ActiveDirectoryKindProperties.HasURA:
             return 'Has User Rights Assignment Collection';
+        case ActiveDirectoryKindProperties.GPOLocalAdmins:
+            return 'GPO Local Admins';
+        case ActiveDirectoryKindProperties.GPORemoteDesktopUsers:
+            return 'GPO Remote Desktop Users';
+        case ActiveDirectoryKindProperties.GPODcomUsers:
+            return 'GPO DCOM Users';
+        case ActiveDirectoryKindProperties.GPOPSRemoteUsers:
+            return 'GPO PSRemote Users';
+        case ActiveDirectoryKindProperties.GPOAdminPrivilegeHolders:
+            return 'GPO Admin Privilege Holders';
+        case ActiveDirectoryKindProperties.GPORemoteInteractiveLogonRight:
+            return 'GPO Remote Interactive Logon Right';
         case ActiveDirectoryKindProperties.PasswordNeverExpires:
             return 'Password Never Expires';
         case ActiveDirectoryKindProperties.PasswordNotRequired:
@@ -655,6 +706,7 @@ export function ActiveDirectoryPathfindingEdges(): ActiveDirectoryRelationshipKi
         ActiveDirectoryRelationshipKind.ADCSESC13,
         ActiveDirectoryRelationshipKind.DCFor,
         ActiveDirectoryRelationshipKind.SyncedToEntraUser,
+        ActiveDirectoryRelationshipKind.DumpEntraConnectCredentials,
     ];
 }
 export enum AzureNodeKind {
@@ -678,6 +730,10 @@ export enum AzureNodeKind {
     WebApp = 'AZWebApp',
     LogicApp = 'AZLogicApp',
     AutomationAccount = 'AZAutomationAccount',
+    AdministrativeUnit = 'AZAdministrativeUnit',
+    StorageAccount = 'AZStorageAccount',
+    StorageContainer = 'AZStorageContainer',
+    RoleDefinition = 'AZRoleDefinition',
 }
 export function AzureNodeKindToDisplay(value: AzureNodeKind): string | undefined {
     switch (value) {
@@ -721,6 +777,14 @@ export function AzureNodeKindToDisplay(value: AzureNodeKind): string | undefined
             return 'LogicApp';
         case AzureNodeKind.AutomationAccount:
             return 'AutomationAccount';
+        case AzureNodeKind.AdministrativeUnit:
+            return 'AdministrativeUnit';
+        case AzureNodeKind.StorageAccount:
+            return 'StorageAccount';
+        case AzureNodeKind.StorageContainer:
+            return 'StorageContainer';
+        case AzureNodeKind.RoleDefinition:
+            return 'RoleDefinition';
         default:
             return undefined;
     }
@@ -773,6 +837,16 @@ export enum AzureRelationshipKind {
     AZMGGrantAppRoles = 'AZMGGrantAppRoles',
     AZMGGrantRole = 'AZMGGrantRole',
     SyncedToADUser = 'SyncedToADUser',
+    AdministrativeUnitMember = 'AZAdministrativeUnitMember',
+    HasScopedRole = 'AZHasScopedRole',
+    StorageAccountContributor = 'AZStorageAccountContributor',
+    StorageAccountKeyOperator = 'AZStorageAccountKeyOperator',
+    StorageBlobDataOwner = 'AZStorageBlobDataOwner',
+    StorageBlobDataContributor = 'AZStorageBlobDataContributor',
+    ListStorageKeys = 'AZListStorageKeys',
+    HasGuestAccount = 'AZHasGuestAccount',
+    CrossTenantAccess = 'AZCrossTenantAccess',
+    RBACRoleAssignment = 'AZRBACRoleAssignment',
 }
 export function AzureRelationshipKindToDisplay(value: AzureRelationshipKind): string | undefined {
     switch (value) {
@@ -870,6 +944,26 @@ export function AzureRelationshipKindToDisplay(value: AzureRelationshipKind): st
             return 'AZMGGrantRole';
         case AzureRelationshipKind.SyncedToADUser:
             return 'SyncedToADUser';
+        case AzureRelationshipKind.AdministrativeUnitMember:
+            return 'AdministrativeUnitMember';
+        case AzureRelationshipKind.HasScopedRole:
+            return 'HasScopedRole';
+        case AzureRelationshipKind.StorageAccountContributor:
+            return 'StorageAccountContributor';
+        case AzureRelationshipKind.StorageAccountKeyOperator:
+            return 'StorageAccountKeyOperator';
+        case AzureRelationshipKind.StorageBlobDataOwner:
+            return 'StorageBlobDataOwner';
+        case AzureRelationshipKind.StorageBlobDataContributor:
+            return 'StorageBlobDataContributor';
+        case AzureRelationshipKind.ListStorageKeys:
+            return 'ListStorageKeys';
+        case AzureRelationshipKind.HasGuestAccount:
+            return 'HasGuestAccount';
+        case AzureRelationshipKind.CrossTenantAccess:
+            return 'CrossTenantAccess';
+        case AzureRelationshipKind.RBACRoleAssignment:
+            return 'RBACRoleAssignment';
         default:
             return undefined;
     }
@@ -908,6 +1002,17 @@ export enum AzureKindProperties {
     PublisherDomain = 'publisherdomain',
     SignInAudience = 'signinaudience',
     RoleTemplateID = 'templateid',
+    AllowedResourceActions = 'allowedresourceactions',
+    ExcludedResourceActions = 'excludedresourceactions',
+    AllowedDataActions = 'alloweddataactions',
+    ExcludedDataActions = 'excludeddataactions',
+    RoleDefinitionID = 'roledefinitionid',
+    IsMemberManagementRestricted = 'ismembermanagementrestricted',
+    AllowSharedKeyAccess = 'allowsharedkeyaccess',
+    IsMFAAccepted = 'ismfaaccepted',
+    IsCompliantDeviceAccepted = 'iscompliantdeviceaccepted',
+    IsHybridJoinedDeviceAccepted = 'ishybridjoineddeviceaccepted',
+    IsInboundSyncAllowed = 'isinboundsyncallowed',
 }
 export function AzureKindPropertiesToDisplay(value: AzureKindProperties): string | undefined {
     switch (value) {
@@ -975,6 +1080,28 @@ export function AzureKindPropertiesToDisplay(value: AzureKindProperties): string
             return 'Sign In Audience';
         case AzureKindProperties.RoleTemplateID:
             return 'Role Template ID';
+        case AzureKindProperties.AllowedResourceActions:
+            return 'Allowed Resource Actions';
+        case AzureKindProperties.ExcludedResourceActions:
+            return 'Excluded Resource Actions';
+        case AzureKindProperties.AllowedDataActions:
+            return 'Allowed Data Actions';
+        case AzureKindProperties.ExcludedDataActions:
+            return 'Excluded Data Actions';
+        case AzureKindProperties.RoleDefinitionID:
+            return 'Role Definition ID';
+        case AzureKindProperties.IsMemberManagementRestricted:
+            return 'Is Member Management Restricted';
+        case AzureKindProperties.AllowSharedKeyAccess:
+            return 'Allow Shared Key Access';
+        case AzureKindProperties.IsMFAAccepted:
+            return 'Is MFA Accepted';
+        case AzureKindProperties.IsCompliantDeviceAccepted:
+            return 'Is Compliant Device Accepted';
+        case AzureKindProperties.IsHybridJoinedDeviceAccepted:
+            return 'Is Hybrid Joined Device Accepted';
+        case AzureKindProperties.IsInboundSyncAllowed:
+            return 'Is Inbound Sync Allowed';
         default:
             return undefined;
     }
@@ -1020,6 +1147,12 @@ export function AzurePathfindingEdges(): AzureRelationshipKind[] {
         AzureRelationshipKind.AZMGGrantAppRoles,
         AzureRelationshipKind.AZMGGrantRole,
         AzureRelationshipKind.SyncedToADUser,
+        AzureRelationshipKind.StorageAccountContributor,
+        AzureRelationshipKind.StorageAccountKeyOperator,
+        AzureRelationshipKind.StorageBlobDataOwner,
+        AzureRelationshipKind.StorageBlobDataContributor,
+        AzureRelationshipKind.ListStorageKeys,
+        AzureRelationshipKind.HasGuestAccount,
     ];
 }
 export enum CommonNodeKind {